@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clock provides an abstraction of the time functions used by the components,
+// e.g. to flush batches or to wait before retrying a request, so that the tests can
+// control the passing of time instead of sleeping. See the clocktest package for a
+// fake implementation.
+package clock // import "go.opentelemetry.io/collector/clock"
+
+import (
+	"time"
+)
+
+// Clock tells the current time and creates timers and tickers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer creates a new Timer that sends the current time on its channel after at least
+	// the duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker creates a new Ticker that sends the current time on its channel with a period
+	// of the duration d. The duration d must be greater than zero.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the equivalent of time.Timer.
+type Timer interface {
+	// Chan returns the channel on which the time is delivered.
+	Chan() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns true if the call stops the timer,
+	// false if the timer has already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after the duration d. It returns true if the timer
+	// had been active, false if the timer had expired or been stopped.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the equivalent of time.Ticker.
+type Ticker interface {
+	// Chan returns the channel on which the ticks are delivered.
+	Chan() <-chan time.Time
+
+	// Stop turns off the ticker. After Stop, no more ticks will be sent.
+	Stop()
+
+	// Reset stops the ticker and resets its period to the duration d.
+	Reset(d time.Duration)
+}
+
+// Since returns the time elapsed since t according to the clock.
+func Since(c Clock, t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// System returns the Clock using the time package.
+func System() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) Chan() <-chan time.Time {
+	return t.C
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) Chan() <-chan time.Time {
+	return t.C
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemClock(t *testing.T) {
+	c := System()
+	start := c.Now()
+
+	timer := c.NewTimer(time.Millisecond)
+	fired := <-timer.Chan()
+	assert.False(t, fired.Before(start.Add(time.Millisecond)))
+	assert.False(t, timer.Stop())
+	assert.False(t, timer.Reset(time.Hour))
+	assert.True(t, timer.Stop())
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.Chan()
+	ticker.Reset(time.Hour)
+	ticker.Stop()
+
+	assert.GreaterOrEqual(t, Since(c, start), time.Millisecond)
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clocktest provides a fake clock.Clock for testing.
+package clocktest // import "go.opentelemetry.io/collector/clock/clocktest"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/clock"
+)
+
+// FakeClock is a clock.Clock whose time only changes when Advance or Set is called.
+// The timers and tickers fire when the time of the clock reaches their deadline.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+var _ clock.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer creates a clock.Timer firing once the clock is advanced by at least the duration d.
+func (c *FakeClock) NewTimer(d time.Duration) clock.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &waiter{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return (*fakeTimer)(w)
+}
+
+// NewTicker creates a clock.Ticker firing every time the clock is advanced by the duration d.
+// Like time.Ticker, the ticks are dropped for the receivers not keeping up.
+func (c *FakeClock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &waiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.schedule(w, d)
+	return (*fakeTicker)(w)
+}
+
+// Advance moves the time of the clock forward by the duration d, firing the timers and tickers
+// whose deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set sets the time of the clock, firing the timers and tickers whose deadline has been reached.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(now)
+}
+
+// Waiters returns the number of active timers and tickers, e.g. to wait until the code under
+// test created its timer before advancing the clock.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// set must be called with the lock held.
+func (c *FakeClock) set(now time.Time) {
+	c.now = now
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+	active := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(now) {
+			active = append(active, w)
+			continue
+		}
+		select {
+		case w.ch <- now:
+		default:
+		}
+		if w.period == 0 {
+			continue
+		}
+		for !w.deadline.After(now) {
+			w.deadline = w.deadline.Add(w.period)
+		}
+		active = append(active, w)
+	}
+	clear(c.waiters[len(active):])
+	c.waiters = active
+}
+
+// schedule must be called with the lock held.
+func (c *FakeClock) schedule(w *waiter, d time.Duration) {
+	w.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	if d <= 0 {
+		c.set(c.now)
+	}
+}
+
+// stop must be called with the lock held, it returns whether the waiter was active.
+func (c *FakeClock) stop(w *waiter) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type waiter struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+	// period is zero for timers.
+	period time.Duration
+}
+
+type fakeTimer waiter
+
+func (t *fakeTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.stop((*waiter)(t))
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.stop((*waiter)(t))
+	t.clock.schedule((*waiter)(t), d)
+	return active
+}
+
+type fakeTicker waiter
+
+func (t *fakeTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.stop((*waiter)(t))
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.stop((*waiter)(t))
+	t.period = d
+	t.clock.schedule((*waiter)(t), d)
+}
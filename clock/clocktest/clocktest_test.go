@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func assertFired(t *testing.T, ch <-chan time.Time, expected time.Time) {
+	select {
+	case got := <-ch:
+		assert.Equal(t, expected, got)
+	default:
+		assert.Fail(t, "expected the channel to fire")
+	}
+}
+
+func assertNotFired(t *testing.T, ch <-chan time.Time) {
+	select {
+	case got := <-ch:
+		assert.Failf(t, "unexpected fire", "fired at %v", got)
+	default:
+	}
+}
+
+func TestFakeClockNow(t *testing.T) {
+	c := NewFakeClock(epoch)
+	assert.Equal(t, epoch, c.Now())
+	c.Advance(time.Minute)
+	assert.Equal(t, epoch.Add(time.Minute), c.Now())
+	c.Set(epoch)
+	assert.Equal(t, epoch, c.Now())
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	c := NewFakeClock(epoch)
+	timer := c.NewTimer(time.Second)
+	assert.Equal(t, 1, c.Waiters())
+
+	c.Advance(999 * time.Millisecond)
+	assertNotFired(t, timer.Chan())
+
+	c.Advance(time.Millisecond)
+	assertFired(t, timer.Chan(), epoch.Add(time.Second))
+	assert.Equal(t, 0, c.Waiters())
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	c.Advance(time.Hour)
+	assertNotFired(t, timer.Chan())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Reset(2*time.Second))
+	c.Advance(time.Second)
+	assertNotFired(t, timer.Chan())
+	c.Advance(time.Second)
+	assertFired(t, timer.Chan(), epoch.Add(time.Hour+3*time.Second))
+}
+
+func TestFakeClockTimerExpired(t *testing.T) {
+	c := NewFakeClock(epoch)
+	timer := c.NewTimer(0)
+	assertFired(t, timer.Chan(), epoch)
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func TestFakeClockTimersOrder(t *testing.T) {
+	c := NewFakeClock(epoch)
+	late := c.NewTimer(2 * time.Second)
+	early := c.NewTimer(time.Second)
+	c.Advance(time.Second)
+	assertFired(t, early.Chan(), epoch.Add(time.Second))
+	assertNotFired(t, late.Chan())
+	c.Advance(time.Second)
+	assertFired(t, late.Chan(), epoch.Add(2*time.Second))
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	c := NewFakeClock(epoch)
+	ticker := c.NewTicker(time.Second)
+	c.Advance(time.Second)
+	assertFired(t, ticker.Chan(), epoch.Add(time.Second))
+
+	// The ticks are dropped if the receiver does not keep up.
+	c.Advance(5 * time.Second)
+	assertFired(t, ticker.Chan(), epoch.Add(6*time.Second))
+	assertNotFired(t, ticker.Chan())
+	c.Advance(500 * time.Millisecond)
+	assertNotFired(t, ticker.Chan())
+	c.Advance(500 * time.Millisecond)
+	assertFired(t, ticker.Chan(), epoch.Add(7*time.Second))
+
+	ticker.Reset(time.Minute)
+	c.Advance(time.Second)
+	assertNotFired(t, ticker.Chan())
+	c.Advance(time.Minute)
+	assertFired(t, ticker.Chan(), epoch.Add(8*time.Second+time.Minute))
+
+	ticker.Stop()
+	assert.Equal(t, 0, c.Waiters())
+	c.Advance(time.Hour)
+	assertNotFired(t, ticker.Chan())
+
+	require.Panics(t, func() { c.NewTicker(0) })
+	require.Panics(t, func() { ticker.Reset(-time.Second) })
+}
@@ -42,16 +42,17 @@ type Config struct {
 
 // Distribution holds the parameters for the final binary
 type Distribution struct {
-	Module               string `mapstructure:"module"`
-	Name                 string `mapstructure:"name"`
-	Go                   string `mapstructure:"go"`
-	Description          string `mapstructure:"description"`
-	OtelColVersion       string `mapstructure:"otelcol_version"`
-	RequireOtelColModule bool   `mapstructure:"-"` // required for backwards-compatibility with builds older than 0.86.0
-	OutputPath           string `mapstructure:"output_path"`
-	Version              string `mapstructure:"version"`
-	BuildTags            string `mapstructure:"build_tags"`
-	DebugCompilation     bool   `mapstructure:"debug_compilation"`
+	Module               string            `mapstructure:"module"`
+	Name                 string            `mapstructure:"name"`
+	Go                   string            `mapstructure:"go"`
+	Description          string            `mapstructure:"description"`
+	OtelColVersion       string            `mapstructure:"otelcol_version"`
+	RequireOtelColModule bool              `mapstructure:"-"` // required for backwards-compatibility with builds older than 0.86.0
+	OutputPath           string            `mapstructure:"output_path"`
+	Version              string            `mapstructure:"version"`
+	CustomFields         map[string]string `mapstructure:"custom_fields"`
+	BuildTags            string            `mapstructure:"build_tags"`
+	DebugCompilation     bool              `mapstructure:"debug_compilation"`
 }
 
 // Module represents a receiver, exporter, processor or extension for the distribution
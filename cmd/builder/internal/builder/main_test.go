@@ -34,6 +34,19 @@ func TestGenerateInvalidOutputPath(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to create output path")
 }
 
+func TestGenerateCustomFields(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.CustomFields = map[string]string{"vendor": "acme", "channel": `"stable"`}
+	require.NoError(t, Generate(cfg))
+	main, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(main), `
+		component.WithBuildCustomField("channel", "\"stable\""),
+		component.WithBuildCustomField("vendor", "acme"),
+	)`)
+}
+
 func TestSkipGenerate(t *testing.T) {
 	cfg := NewDefaultConfig()
 	cfg.Distribution.OutputPath = t.TempDir()
@@ -56,9 +69,12 @@ func TestGenerateAndCompile(t *testing.T) {
 	workspaceDir := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(thisFile)))))
 	replaces := []string{fmt.Sprintf("go.opentelemetry.io/collector => %s", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/component => %s/component", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/confighumanize => %s/config/confighumanize", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/confignet => %s/config/confignet", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/config/configretry => %s/config/configretry", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/config/configtelemetry => %s/config/configtelemetry", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap => %s/confmap", workspaceDir),
+		fmt.Sprintf("go.opentelemetry.io/collector/confmap/converter/defaultsconverter => %s/confmap/converter/defaultsconverter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap/converter/expandconverter => %s/confmap/converter/expandconverter", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap/provider/envprovider => %s/confmap/provider/envprovider", workspaceDir),
 		fmt.Sprintf("go.opentelemetry.io/collector/confmap/provider/fileprovider => %s/confmap/provider/fileprovider", workspaceDir),
@@ -4,9 +4,7 @@
 package main
 
 import (
-	"errors"
 	"sort"
-	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
@@ -69,22 +67,11 @@ func (ms *StabilityMap) Unmarshal(parser *confmap.Conf) error {
 		return err
 	}
 	for k, v := range raw {
-		switch strings.ToLower(k) {
-		case strings.ToLower(component.StabilityLevelUnmaintained.String()):
-			(*ms)[component.StabilityLevelUnmaintained] = v
-		case strings.ToLower(component.StabilityLevelDeprecated.String()):
-			(*ms)[component.StabilityLevelDeprecated] = v
-		case strings.ToLower(component.StabilityLevelDevelopment.String()):
-			(*ms)[component.StabilityLevelDevelopment] = v
-		case strings.ToLower(component.StabilityLevelAlpha.String()):
-			(*ms)[component.StabilityLevelAlpha] = v
-		case strings.ToLower(component.StabilityLevelBeta.String()):
-			(*ms)[component.StabilityLevelBeta] = v
-		case strings.ToLower(component.StabilityLevelStable.String()):
-			(*ms)[component.StabilityLevelStable] = v
-		default:
-			return errors.New("invalid stability level: " + k)
+		var sl component.StabilityLevel
+		if err := sl.UnmarshalText([]byte(k)); err != nil {
+			return err
 		}
+		(*ms)[sl] = v
 	}
 	return nil
 }
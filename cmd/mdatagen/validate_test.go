@@ -40,7 +40,7 @@ func TestValidate(t *testing.T) {
 		},
 		{
 			name:    "testdata/invalid_stability.yaml",
-			wantErr: "1 error(s) decoding:\n\n* error decoding 'status.stability': invalid stability level: incorrectstability",
+			wantErr: "1 error(s) decoding:\n\n* error decoding 'status.stability': unknown stability level \"incorrectstability\"",
 		},
 		{
 			name:    "testdata/no_stability_component.yaml",
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// warning reports a use of a deprecated identifier that must be migrated manually.
+type warning struct {
+	pos token.Position
+	msg string
+}
+
+func (w warning) String() string {
+	return fmt.Sprintf("%s: %s", w.pos, w.msg)
+}
+
+// fix rewrites the source of a Go file using the deprecated model module to use the pdata modules.
+// It returns the rewritten source, whether it was changed, and the identifiers to migrate manually.
+// The imports of the deprecated packages are only removed once none of their identifiers is used.
+func fix(filename string, src []byte) ([]byte, bool, []warning, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	// The local names of the imported packages, and the imports of the deprecated packages by local name.
+	imported := map[string]string{}
+	deprecated := map[string]*ast.ImportSpec{}
+	var deprecatedSpecs []*ast.ImportSpec
+	changed := false
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if p, ok := newPath(importPath); ok {
+			spec.Path.Value = strconv.Quote(p)
+			importPath = p
+			changed = true
+		}
+		name := importName(spec, importPath)
+		imported[importPath] = name
+		if _, ok := rewrites[importPath]; ok {
+			deprecated[name] = spec
+			deprecatedSpecs = append(deprecatedSpecs, spec)
+		}
+	}
+	if len(deprecatedSpecs) == 0 {
+		res, err := printFile(fset, file)
+		return res, changed, nil, err
+	}
+
+	var warnings []warning
+	added := map[string]bool{}
+	// remaining counts the uses of the deprecated imports that are not rewritten.
+	remaining := map[*ast.ImportSpec]int{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		// Identifiers referring to local declarations shadow the packages.
+		if !ok || x.Obj != nil {
+			return true
+		}
+		spec, ok := deprecated[x.Name]
+		if !ok {
+			return true
+		}
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		rw, ok := rewrites[importPath][sel.Sel.Name]
+		if !ok || rw.path == "" {
+			remaining[spec]++
+			msg := fmt.Sprintf("%s.%s has no equivalent in the pdata modules", x.Name, sel.Sel.Name)
+			if rw.hint != "" {
+				msg += ", " + rw.hint
+			}
+			warnings = append(warnings, warning{pos: fset.Position(sel.Pos()), msg: msg})
+			return false
+		}
+		name, ok := imported[rw.path]
+		if !ok {
+			name = path.Base(rw.path)
+			imported[rw.path] = name
+			added[rw.path] = true
+		}
+		x.Name = name
+		sel.Sel.Name = rw.name
+		changed = true
+		return false
+	})
+
+	// Replace the deprecated imports no longer used by the new imports, to keep them in the same block.
+	var newPaths []string
+	for p := range added {
+		newPaths = append(newPaths, p)
+	}
+	sort.Strings(newPaths)
+	last := deprecatedSpecs[0]
+	for _, spec := range deprecatedSpecs {
+		switch {
+		case remaining[spec] > 0:
+		case len(newPaths) > 0:
+			spec.Name = nil
+			spec.Path.Value = strconv.Quote(newPaths[0])
+			newPaths = newPaths[1:]
+			last = spec
+		default:
+			deleteImport(fset, file, spec)
+		}
+	}
+	for i := len(newPaths) - 1; i >= 0; i-- {
+		insertImport(file, last, newPaths[i])
+	}
+	ast.SortImports(fset, file)
+
+	res, err := printFile(fset, file)
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].pos.Offset < warnings[j].pos.Offset })
+	return res, changed, warnings, err
+}
+
+// printFile formats the file.
+func printFile(fset *token.FileSet, file *ast.File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importName returns the local name of the imported package.
+func importName(spec *ast.ImportSpec, importPath string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	return path.Base(importPath)
+}
+
+// insertImport inserts the import of the path after the import spec, in the same declaration.
+func insertImport(file *ast.File, after *ast.ImportSpec, importPath string) {
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{ValuePos: after.Path.ValuePos, Kind: token.STRING, Value: strconv.Quote(importPath)}}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for i, s := range gen.Specs {
+			if s != after {
+				continue
+			}
+			gen.Specs = append(gen.Specs[:i+1], append([]ast.Spec{spec}, gen.Specs[i+1:]...)...)
+			if !gen.Lparen.IsValid() {
+				// Put the imports in parentheses.
+				gen.Lparen = gen.Pos()
+				gen.Rparen = after.End()
+			}
+			file.Imports = append(file.Imports, spec)
+			return
+		}
+	}
+}
+
+// deleteImport removes the import spec, and its declaration if it was the only one.
+func deleteImport(fset *token.FileSet, file *ast.File, spec *ast.ImportSpec) {
+	for i, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for j, s := range gen.Specs {
+			if s != spec {
+				continue
+			}
+			gen.Specs = append(gen.Specs[:j], gen.Specs[j+1:]...)
+			if len(gen.Specs) == 0 {
+				file.Decls = append(file.Decls[:i], file.Decls[i+1:]...)
+			} else if j > 0 && gen.Rparen.IsValid() {
+				// Close the hole left by the import, unless it was preceded by a blank line.
+				tf := fset.File(gen.Rparen)
+				line := tf.Line(spec.Pos())
+				if line-tf.Line(gen.Specs[j-1].Pos()) == 1 && line < tf.LineCount() {
+					tf.MergeLine(line)
+				}
+			}
+			break
+		}
+	}
+	for i, s := range file.Imports {
+		if s == spec {
+			file.Imports = append(file.Imports[:i], file.Imports[i+1:]...)
+			break
+		}
+	}
+}
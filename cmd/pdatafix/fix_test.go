@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedWarnings []string
+	}{
+		{
+			name: "model",
+		},
+		{
+			name: "mixed",
+		},
+		{
+			name: "unmapped",
+			expectedWarnings: []string{
+				"testdata/unmapped.go.input:11:17: otlp.NewProtobufTracesMarshaler has no equivalent in the pdata modules, use &ptrace.ProtoMarshaler{}",
+				"testdata/unmapped.go.input:13:34: pdata.TraceState has no equivalent in the pdata modules, the trace state is a pcommon.TraceState, set with FromRaw",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := filepath.Join("testdata", tt.name+".go.input")
+			src, err := os.ReadFile(input)
+			require.NoError(t, err)
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.name+".go.golden"))
+			require.NoError(t, err)
+
+			res, changed, warnings, err := fix(input, src)
+			require.NoError(t, err)
+			assert.True(t, changed)
+			assert.Equal(t, string(expected), string(res))
+			var msgs []string
+			for _, w := range warnings {
+				msgs = append(msgs, w.String())
+			}
+			assert.Equal(t, tt.expectedWarnings, msgs)
+		})
+	}
+}
+
+func TestFixUnchanged(t *testing.T) {
+	src := []byte("package sample\n\nimport \"go.opentelemetry.io/collector/pdata/ptrace\"\n\nvar td = ptrace.NewTraces()\n")
+	res, changed, warnings, err := fix("sample.go", src)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, warnings)
+	assert.Equal(t, string(src), string(res))
+
+	_, _, _, err = fix("invalid.go", []byte("package"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// pdatafix rewrites the Go code using the deprecated go.opentelemetry.io/collector/model module to use
+// the pdata and semconv modules.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write the result to the source files instead of the standard output")
+	list := flag.Bool("l", false, "list the files whose source is changed")
+	flag.Parse()
+	if err := run(flag.Args(), *write, *list, os.Stdout, os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run rewrites the Go files at the paths, walking the directories. The identifiers to migrate manually
+// are reported to stderr.
+func run(paths []string, write bool, list bool, stdout io.Writer, stderr io.Writer) error {
+	if len(paths) == 0 {
+		return errors.New("at least one file or directory must be given")
+	}
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (d.Name() == "vendor" || d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if path != root && !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			return fixFile(path, write, list, stdout, stderr)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fixFile(path string, write bool, list bool, stdout io.Writer, stderr io.Writer) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	res, changed, warnings, err := fix(path, src)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite %v: %w", path, err)
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(stderr, w)
+	}
+	changed = changed && !bytes.Equal(src, res)
+	if list && changed {
+		fmt.Fprintln(stdout, path)
+	}
+	switch {
+	case write && changed:
+		return os.WriteFile(path, res, 0600)
+	case !write && !list:
+		_, err = stdout.Write(res)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyTestFile(t *testing.T, dir string, name string) string {
+	src, err := os.ReadFile(filepath.Join("testdata", name+".go.input"))
+	require.NoError(t, err)
+	path := filepath.Join(dir, name+".go")
+	require.NoError(t, os.WriteFile(path, src, 0600))
+	return path
+}
+
+func TestRunWrite(t *testing.T) {
+	dir := t.TempDir()
+	model := copyTestFile(t, dir, "model")
+	unmapped := copyTestFile(t, dir, "unmapped")
+	// The vendored files are not rewritten.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "vendor"), 0700))
+	vendored := copyTestFile(t, filepath.Join(dir, "vendor"), "mixed")
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run([]string{dir}, true, true, &stdout, &stderr))
+	assert.Equal(t, model+"\n"+unmapped+"\n", stdout.String())
+	assert.Contains(t, stderr.String(), "otlp.NewProtobufTracesMarshaler has no equivalent in the pdata modules")
+
+	for path, golden := range map[string]string{model: "model.go.golden", unmapped: "unmapped.go.golden", vendored: "mixed.go.input"} {
+		expected, err := os.ReadFile(filepath.Join("testdata", golden))
+		require.NoError(t, err)
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), path)
+	}
+
+	// The files are not changed anymore.
+	stdout.Reset()
+	require.NoError(t, run([]string{dir}, true, true, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunStdout(t *testing.T) {
+	path := copyTestFile(t, t.TempDir(), "mixed")
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run([]string{path}, false, false, &stdout, &stderr))
+	expected, err := os.ReadFile(filepath.Join("testdata", "mixed.go.golden"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestRunErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.EqualError(t, run(nil, false, false, &stdout, &stderr), "at least one file or directory must be given")
+	assert.Error(t, run([]string{filepath.Join(t.TempDir(), "missing")}, false, false, &stdout, &stderr))
+
+	path := filepath.Join(t.TempDir(), "invalid.go")
+	require.NoError(t, os.WriteFile(path, []byte("package"), 0600))
+	assert.ErrorContains(t, run([]string{path}, false, false, &stdout, &stderr), "failed to rewrite "+path)
+}
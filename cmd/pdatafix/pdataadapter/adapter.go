@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pdataadapter converts the data between the types of the deprecated model/pdata package and the types of
+// the pdata module, for the code mixing both APIs until it is migrated, see the pdatafix tool. The data is
+// converted through its OTLP/protobuf encoding, which is the same for both APIs. The functions take the
+// marshaling functions of the model/otlp package, so that this package does not depend on the removed model module:
+//
+//	td, err := pdataadapter.TracesFromModel(otlp.NewProtobufTracesMarshaler().MarshalTraces, modelTraces)
+//	modelTraces, err := pdataadapter.TracesToModel(otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces, td)
+package pdataadapter // import "go.opentelemetry.io/collector/cmd/pdatafix/pdataadapter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	tracesMarshaler    = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsMarshaler   = &pmetric.ProtoMarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	logsMarshaler      = &plog.ProtoMarshaler{}
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+)
+
+// TracesFromModel converts the model traces td, encoded with marshal, to ptrace.Traces.
+func TracesFromModel[T any](marshal func(T) ([]byte, error), td T) (ptrace.Traces, error) {
+	buf, err := marshal(td)
+	if err != nil {
+		return ptrace.NewTraces(), err
+	}
+	return tracesUnmarshaler.UnmarshalTraces(buf)
+}
+
+// TracesToModel converts td to model traces, decoded with unmarshal.
+func TracesToModel[T any](unmarshal func([]byte) (T, error), td ptrace.Traces) (T, error) {
+	buf, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return unmarshal(buf)
+}
+
+// MetricsFromModel converts the model metrics md, encoded with marshal, to pmetric.Metrics.
+func MetricsFromModel[T any](marshal func(T) ([]byte, error), md T) (pmetric.Metrics, error) {
+	buf, err := marshal(md)
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+	return metricsUnmarshaler.UnmarshalMetrics(buf)
+}
+
+// MetricsToModel converts md to model metrics, decoded with unmarshal.
+func MetricsToModel[T any](unmarshal func([]byte) (T, error), md pmetric.Metrics) (T, error) {
+	buf, err := metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return unmarshal(buf)
+}
+
+// LogsFromModel converts the model logs ld, encoded with marshal, to plog.Logs.
+func LogsFromModel[T any](marshal func(T) ([]byte, error), ld T) (plog.Logs, error) {
+	buf, err := marshal(ld)
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+	return logsUnmarshaler.UnmarshalLogs(buf)
+}
+
+// LogsToModel converts ld to model logs, decoded with unmarshal.
+func LogsToModel[T any](unmarshal func([]byte) (T, error), ld plog.Logs) (T, error) {
+	buf, err := logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return unmarshal(buf)
+}
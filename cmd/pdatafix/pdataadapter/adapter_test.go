@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdataadapter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// modelData stands for the data of the model module, encoded in OTLP/protobuf by its marshalers.
+type modelData struct {
+	encoded []byte
+}
+
+func marshalModel(md modelData) ([]byte, error) {
+	return md.encoded, nil
+}
+
+func unmarshalModel(buf []byte) (modelData, error) {
+	return modelData{encoded: buf}, nil
+}
+
+var errModel = errors.New("invalid model data")
+
+func TestTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	model, err := TracesToModel(unmarshalModel, td)
+	require.NoError(t, err)
+	got, err := TracesFromModel(marshalModel, model)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	_, err = TracesFromModel(func(modelData) ([]byte, error) { return nil, errModel }, model)
+	assert.ErrorIs(t, err, errModel)
+	_, err = TracesToModel(func([]byte) (modelData, error) { return modelData{}, errModel }, td)
+	assert.ErrorIs(t, err, errModel)
+}
+
+func TestMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+	model, err := MetricsToModel(unmarshalModel, md)
+	require.NoError(t, err)
+	got, err := MetricsFromModel(marshalModel, model)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+
+	_, err = MetricsFromModel(func(modelData) ([]byte, error) { return nil, errModel }, model)
+	assert.ErrorIs(t, err, errModel)
+	_, err = MetricsToModel(func([]byte) (modelData, error) { return modelData{}, errModel }, md)
+	assert.ErrorIs(t, err, errModel)
+}
+
+func TestLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+
+	model, err := LogsToModel(unmarshalModel, ld)
+	require.NoError(t, err)
+	got, err := LogsFromModel(marshalModel, model)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+
+	_, err = LogsFromModel(func(modelData) ([]byte, error) { return nil, errModel }, model)
+	assert.ErrorIs(t, err, errModel)
+	_, err = LogsToModel(func([]byte) (modelData, error) { return modelData{}, errModel }, ld)
+	assert.ErrorIs(t, err, errModel)
+}
+
+func TestFromModelInvalidEncoding(t *testing.T) {
+	_, err := TracesFromModel(marshalModel, modelData{encoded: []byte("+$%")})
+	assert.Error(t, err)
+}
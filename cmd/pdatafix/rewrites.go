@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "strings"
+
+// Import paths of the deprecated model module.
+const (
+	modelPdataPath    = "go.opentelemetry.io/collector/model/pdata"
+	modelOtlpPath     = "go.opentelemetry.io/collector/model/otlp"
+	modelOtlpgrpcPath = "go.opentelemetry.io/collector/model/otlpgrpc"
+	modelSemconvPath  = "go.opentelemetry.io/collector/model/semconv/"
+)
+
+// Import paths of the pdata modules.
+const (
+	pcommonPath     = "go.opentelemetry.io/collector/pdata/pcommon"
+	plogPath        = "go.opentelemetry.io/collector/pdata/plog"
+	plogotlpPath    = "go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	pmetricPath     = "go.opentelemetry.io/collector/pdata/pmetric"
+	pmetricotlpPath = "go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	ptracePath      = "go.opentelemetry.io/collector/pdata/ptrace"
+	ptraceotlpPath  = "go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	semconvPath     = "go.opentelemetry.io/collector/semconv/"
+)
+
+// rewrite is the replacement of an identifier of a deprecated package.
+type rewrite struct {
+	// path is the import path of the package declaring the replacement, empty if the identifier has no
+	// equivalent that can be used as is.
+	path string
+	name string
+	// hint tells how to migrate the identifiers that have no equivalent.
+	hint string
+}
+
+// rewrites are the replacements of the identifiers of the deprecated packages, by import path.
+var rewrites = map[string]map[string]rewrite{
+	modelPdataPath:    pdataRewrites(),
+	modelOtlpPath:     otlpRewrites(),
+	modelOtlpgrpcPath: otlpgrpcRewrites(),
+}
+
+// newPath returns the import path replacing the deprecated import path, if it is only moved.
+func newPath(path string) (string, bool) {
+	if strings.HasPrefix(path, modelSemconvPath) {
+		return semconvPath + strings.TrimPrefix(path, modelSemconvPath), true
+	}
+	return "", false
+}
+
+// same adds the identifiers declared with the same name in the package at path.
+func same(res map[string]rewrite, path string, names ...string) {
+	for _, name := range names {
+		res[name] = rewrite{path: path, name: name}
+	}
+}
+
+// renamed adds the identifiers renamed in the package at path, given as pairs of old and new names.
+func renamed(res map[string]rewrite, path string, pairs ...string) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		res[pairs[i]] = rewrite{path: path, name: pairs[i+1]}
+	}
+}
+
+// withSlices returns the names, along with the names of their slices and of their constructors.
+func withSlices(names ...string) []string {
+	var res []string
+	for _, name := range names {
+		res = append(res, name, "New"+name, name+"Slice", "New"+name+"Slice")
+	}
+	return res
+}
+
+func pdataRewrites() map[string]rewrite {
+	res := map[string]rewrite{}
+
+	same(res, pcommonPath, "Resource", "NewResource", "InstrumentationScope", "NewInstrumentationScope",
+		"Timestamp", "NewTimestampFromTime", "TraceID", "SpanID")
+	renamed(res, pcommonPath,
+		"AttributeMap", "Map",
+		"NewAttributeMap", "NewMap",
+		"AttributeValueSlice", "Slice",
+		"NewAttributeValueSlice", "NewSlice",
+		"AttributeValue", "Value",
+		"NewAttributeValueEmpty", "NewValueEmpty",
+		"NewAttributeValueString", "NewValueStr",
+		"NewAttributeValueInt", "NewValueInt",
+		"NewAttributeValueDouble", "NewValueDouble",
+		"NewAttributeValueBool", "NewValueBool",
+		"NewAttributeValueMap", "NewValueMap",
+		"NewAttributeValueArray", "NewValueSlice",
+		"NewAttributeValueBytes", "NewValueBytes",
+		"AttributeValueType", "ValueType",
+		"AttributeValueTypeEmpty", "ValueTypeEmpty",
+		"AttributeValueTypeString", "ValueTypeStr",
+		"AttributeValueTypeInt", "ValueTypeInt",
+		"AttributeValueTypeDouble", "ValueTypeDouble",
+		"AttributeValueTypeBool", "ValueTypeBool",
+		"AttributeValueTypeMap", "ValueTypeMap",
+		"AttributeValueTypeArray", "ValueTypeSlice",
+		"AttributeValueTypeBytes", "ValueTypeBytes",
+		// The identifiers are arrays, the constructors are conversions.
+		"NewTraceID", "TraceID",
+		"NewSpanID", "SpanID",
+		"InvalidTraceID", "NewTraceIDEmpty",
+		"InvalidSpanID", "NewSpanIDEmpty",
+	)
+
+	same(res, ptracePath, "Traces", "NewTraces",
+		"SpanKind", "SpanKindUnspecified", "SpanKindInternal", "SpanKindServer", "SpanKindClient",
+		"SpanKindProducer", "SpanKindConsumer",
+		"StatusCode", "StatusCodeUnset", "StatusCodeOk", "StatusCodeError")
+	same(res, ptracePath, withSlices("ResourceSpans", "ScopeSpans", "Span", "SpanEvent", "SpanLink")...)
+	renamed(res, ptracePath,
+		"SpanStatus", "Status",
+		"NewSpanStatus", "NewStatus",
+		"TracesMarshaler", "Marshaler",
+		"TracesUnmarshaler", "Unmarshaler",
+		"TracesSizer", "MarshalSizer",
+	)
+
+	same(res, pmetricPath, "Metrics", "NewMetrics",
+		"Gauge", "NewGauge", "Sum", "NewSum", "Histogram", "NewHistogram",
+		"ExponentialHistogram", "NewExponentialHistogram", "Summary", "NewSummary")
+	same(res, pmetricPath, withSlices("ResourceMetrics", "ScopeMetrics", "Metric", "NumberDataPoint",
+		"HistogramDataPoint", "ExponentialHistogramDataPoint", "SummaryDataPoint", "Exemplar")...)
+	renamed(res, pmetricPath,
+		"MetricDataType", "MetricType",
+		"MetricDataTypeNone", "MetricTypeEmpty",
+		"MetricDataTypeGauge", "MetricTypeGauge",
+		"MetricDataTypeSum", "MetricTypeSum",
+		"MetricDataTypeHistogram", "MetricTypeHistogram",
+		"MetricDataTypeExponentialHistogram", "MetricTypeExponentialHistogram",
+		"MetricDataTypeSummary", "MetricTypeSummary",
+		"MetricAggregationTemporality", "AggregationTemporality",
+		"MetricAggregationTemporalityUnspecified", "AggregationTemporalityUnspecified",
+		"MetricAggregationTemporalityDelta", "AggregationTemporalityDelta",
+		"MetricAggregationTemporalityCumulative", "AggregationTemporalityCumulative",
+		"MetricValueType", "NumberDataPointValueType",
+		"MetricValueTypeNone", "NumberDataPointValueTypeEmpty",
+		"MetricValueTypeInt", "NumberDataPointValueTypeInt",
+		"MetricValueTypeDouble", "NumberDataPointValueTypeDouble",
+		"MetricDataPointFlags", "DataPointFlags",
+		"Buckets", "ExponentialHistogramDataPointBuckets",
+		"NewBuckets", "NewExponentialHistogramDataPointBuckets",
+		"ValueAtQuantile", "SummaryDataPointValueAtQuantile",
+		"NewValueAtQuantile", "NewSummaryDataPointValueAtQuantile",
+		"ValueAtQuantileSlice", "SummaryDataPointValueAtQuantileSlice",
+		"NewValueAtQuantileSlice", "NewSummaryDataPointValueAtQuantileSlice",
+		"MetricsMarshaler", "Marshaler",
+		"MetricsUnmarshaler", "Unmarshaler",
+		"MetricsSizer", "MarshalSizer",
+	)
+
+	same(res, plogPath, "Logs", "NewLogs", "SeverityNumber")
+	same(res, plogPath, withSlices("ResourceLogs", "ScopeLogs", "LogRecord")...)
+	renamed(res, plogPath,
+		"SeverityNumberUNDEFINED", "SeverityNumberUnspecified",
+		"LogsMarshaler", "Marshaler",
+		"LogsUnmarshaler", "Unmarshaler",
+		"LogsSizer", "MarshalSizer",
+	)
+	for _, level := range []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"} {
+		name := level[:1] + strings.ToLower(level[1:])
+		renamed(res, plogPath, "SeverityNumber"+level, "SeverityNumber"+name)
+		for i := 2; i <= 4; i++ {
+			suffix := string(rune('0' + i))
+			renamed(res, plogPath, "SeverityNumber"+level+suffix, "SeverityNumber"+name+suffix)
+		}
+	}
+
+	res["TraceState"] = rewrite{hint: "the trace state is a pcommon.TraceState, set with FromRaw"}
+	res["NewAttributeMapFromMap"] = rewrite{hint: "create a pcommon.NewMap and use FromRaw"}
+	res["ImmutableByteSlice"] = rewrite{hint: "use pcommon.ByteSlice"}
+	return res
+}
+
+func otlpRewrites() map[string]rewrite {
+	res := map[string]rewrite{}
+	for _, signal := range []struct{ name, pkg string }{{"Traces", "ptrace"}, {"Metrics", "pmetric"}, {"Logs", "plog"}} {
+		for _, encoding := range []struct{ name, pkg string }{{"Protobuf", "Proto"}, {"JSON", "JSON"}} {
+			for _, kind := range []string{"Marshaler", "Unmarshaler"} {
+				res["New"+encoding.name+signal.name+kind] = rewrite{
+					hint: "use &" + signal.pkg + "." + encoding.pkg + kind + "{}",
+				}
+			}
+		}
+	}
+	return res
+}
+
+func otlpgrpcRewrites() map[string]rewrite {
+	res := map[string]rewrite{}
+	for _, signal := range []struct{ name, path string }{{"Traces", ptraceotlpPath}, {"Metrics", pmetricotlpPath}, {"Logs", plogotlpPath}} {
+		renamed(res, signal.path,
+			signal.name+"Request", "ExportRequest",
+			"New"+signal.name+"Request", "NewExportRequest",
+			signal.name+"Response", "ExportResponse",
+			"New"+signal.name+"Response", "NewExportResponse",
+			signal.name+"Client", "GRPCClient",
+			"New"+signal.name+"Client", "NewGRPCClient",
+			signal.name+"Server", "GRPCServer",
+			"Register"+signal.name+"Server", "RegisterGRPCServer",
+		)
+	}
+	return res
+}
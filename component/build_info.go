@@ -3,6 +3,15 @@
 
 package component // import "go.opentelemetry.io/collector/component"
 
+import (
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
 // BuildInfo is the information that is logged at the application start and
 // passed into each component. This information can be overridden in custom build.
 type BuildInfo struct {
@@ -14,6 +23,31 @@ type BuildInfo struct {
 
 	// Version string.
 	Version string
+
+	// customFields holds the custom fields of the distribution, see CustomFields. It is a pointer so that
+	// BuildInfo stays comparable, the BuildInfos created with the same custom fields sharing it.
+	customFields *buildInfoFields
+}
+
+// BuildInfoField is a custom field of a distribution, see WithBuildCustomField.
+type BuildInfoField struct {
+	Key   string
+	Value string
+}
+
+// buildInfoFields are the immutable custom fields of a BuildInfo, sorted by key.
+type buildInfoFields struct {
+	fields []BuildInfoField
+}
+
+// CustomFields returns the additional fields identifying the distribution sorted by key, e.g. the
+// vendor or the distribution channel. They are added to the User-Agent sent by the exporters, and
+// to the resource of the telemetry of the collector.
+func (bi BuildInfo) CustomFields() []BuildInfoField {
+	if bi.customFields == nil {
+		return nil
+	}
+	return slices.Clone(bi.customFields.fields)
 }
 
 // NewDefaultBuildInfo returns a default BuildInfo.
@@ -24,3 +58,78 @@ func NewDefaultBuildInfo() BuildInfo {
 		Version:     "latest",
 	}
 }
+
+// BuildInfoOption applies changes to the BuildInfo created by NewBuildInfo.
+type BuildInfoOption func(*buildInfoSettings)
+
+type buildInfoSettings struct {
+	version      string
+	customFields []BuildInfoField
+}
+
+// WithBuildVersion sets the version of the BuildInfo.
+func WithBuildVersion(version string) BuildInfoOption {
+	return func(set *buildInfoSettings) {
+		set.version = version
+	}
+}
+
+// WithBuildCustomField adds a custom field to the BuildInfo, replacing the field with the same key.
+func WithBuildCustomField(key, value string) BuildInfoOption {
+	return func(set *buildInfoSettings) {
+		i := sort.Search(len(set.customFields), func(i int) bool { return set.customFields[i].Key >= key })
+		if i < len(set.customFields) && set.customFields[i].Key == key {
+			set.customFields[i].Value = value
+		} else {
+			set.customFields = slices.Insert(set.customFields, i, BuildInfoField{Key: key, Value: value})
+		}
+	}
+}
+
+// NewBuildInfo returns the BuildInfo of a distribution with the given command and description.
+// Unless set with WithBuildVersion, the version is the version of the main module of the binary,
+// as recorded by the Go toolchain, so that the distributions do not need to override it with
+// linker flags for every target platform. It is "latest" when the main module is not versioned,
+// e.g. when built from a local checkout.
+func NewBuildInfo(command, description string, options ...BuildInfoOption) BuildInfo {
+	set := buildInfoSettings{version: mainModuleVersion()}
+	for _, op := range options {
+		op(&set)
+	}
+	return BuildInfo{
+		Command:      command,
+		Description:  description,
+		Version:      set.version,
+		customFields: internBuildInfoFields(set.customFields),
+	}
+}
+
+// buildInfoFieldsByKey interns the custom fields, so that the BuildInfos created with the same custom
+// fields are equal.
+var buildInfoFieldsByKey sync.Map
+
+// internBuildInfoFields returns the shared buildInfoFields holding the given fields sorted by key,
+// nil if there are none.
+func internBuildInfoFields(fields []BuildInfoField) *buildInfoFields {
+	if len(fields) == 0 {
+		return nil
+	}
+	var key strings.Builder
+	for _, f := range fields {
+		// The lengths delimit the keys and the values, whatever characters they contain.
+		fmt.Fprintf(&key, "%d:%s%d:%s", len(f.Key), f.Key, len(f.Value), f.Value)
+	}
+	bif, _ := buildInfoFieldsByKey.LoadOrStore(key.String(), &buildInfoFields{fields: fields})
+	return bif.(*buildInfoFields)
+}
+
+// readBuildInfo reads the build information of the binary, overridden in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+func mainModuleVersion() string {
+	info, ok := readBuildInfo()
+	if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return NewDefaultBuildInfo().Version
+	}
+	return info.Main.Version
+}
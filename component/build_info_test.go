@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setReadBuildInfo(t *testing.T, version string, ok bool) {
+	old := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Path: "example.com/otelcol-custom", Version: version}}, ok
+	}
+	t.Cleanup(func() { readBuildInfo = old })
+}
+
+func TestNewBuildInfo(t *testing.T) {
+	setReadBuildInfo(t, "v1.2.3", true)
+	assert.Equal(t, BuildInfo{
+		Command:     "otelcol-custom",
+		Description: "Custom Collector",
+		Version:     "v1.2.3",
+	}, NewBuildInfo("otelcol-custom", "Custom Collector"))
+
+	bi := NewBuildInfo("otelcol-custom", "Custom Collector",
+		WithBuildVersion("2.0.0"),
+		WithBuildCustomField("vendor", "acme"),
+		WithBuildCustomField("channel", "beta"),
+		WithBuildCustomField("channel", "stable"))
+	assert.Equal(t, "2.0.0", bi.Version)
+	assert.Equal(t, []BuildInfoField{{Key: "channel", Value: "stable"}, {Key: "vendor", Value: "acme"}}, bi.CustomFields())
+	assert.Nil(t, NewDefaultBuildInfo().CustomFields())
+
+	// The BuildInfo stays comparable, whatever the order of the custom fields.
+	assert.True(t, bi == NewBuildInfo("otelcol-custom", "Custom Collector",
+		WithBuildVersion("2.0.0"),
+		WithBuildCustomField("channel", "stable"),
+		WithBuildCustomField("vendor", "acme")))
+	assert.False(t, bi == NewBuildInfo("otelcol-custom", "Custom Collector",
+		WithBuildVersion("2.0.0"),
+		WithBuildCustomField("channel", "beta"),
+		WithBuildCustomField("vendor", "acme")))
+
+	// The custom fields cannot be changed through the returned fields.
+	fields := bi.CustomFields()
+	fields[0].Value = "beta"
+	assert.Equal(t, "stable", bi.CustomFields()[0].Value)
+}
+
+func TestNewBuildInfoUnversioned(t *testing.T) {
+	setReadBuildInfo(t, "(devel)", true)
+	assert.Equal(t, "latest", NewBuildInfo("otelcol-custom", "Custom Collector").Version)
+
+	setReadBuildInfo(t, "", false)
+	assert.Equal(t, "latest", NewBuildInfo("otelcol-custom", "Custom Collector").Version)
+}
@@ -6,6 +6,8 @@ package component // import "go.opentelemetry.io/collector/component"
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
@@ -144,6 +146,18 @@ func (sl StabilityLevel) String() string {
 	return ""
 }
 
+// UnmarshalText parses a stability level from its case-insensitive name, e.g. "alpha" or "Stable".
+func (sl *StabilityLevel) UnmarshalText(text []byte) error {
+	str := strings.ToLower(string(text))
+	for level := StabilityLevelUnmaintained; level <= StabilityLevelStable; level++ {
+		if strings.ToLower(level.String()) == str {
+			*sl = level
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown stability level %q", string(text))
+}
+
 func (sl StabilityLevel) LogMessage() string {
 	switch sl {
 	case StabilityLevelUnmaintained:
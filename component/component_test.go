@@ -4,6 +4,7 @@
 package component
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,3 +30,24 @@ func TestStabilityLevelString(t *testing.T) {
 	assert.EqualValues(t, "Stable", StabilityLevelStable.String())
 	assert.EqualValues(t, "", StabilityLevel(100).String())
 }
+
+func TestStabilityLevelUnmarshalText(t *testing.T) {
+	for _, level := range []StabilityLevel{
+		StabilityLevelUnmaintained,
+		StabilityLevelDeprecated,
+		StabilityLevelDevelopment,
+		StabilityLevelAlpha,
+		StabilityLevelBeta,
+		StabilityLevelStable,
+	} {
+		var sl StabilityLevel
+		assert.NoError(t, sl.UnmarshalText([]byte(strings.ToLower(level.String()))))
+		assert.Equal(t, level, sl)
+		assert.NoError(t, sl.UnmarshalText([]byte(level.String())))
+		assert.Equal(t, level, sl)
+	}
+
+	var sl StabilityLevel
+	assert.EqualError(t, sl.UnmarshalText([]byte("undefined")), `unknown stability level "undefined"`)
+	assert.EqualError(t, sl.UnmarshalText([]byte("gamma")), `unknown stability level "gamma"`)
+}
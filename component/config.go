@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"go.uber.org/multierr"
 
@@ -38,6 +40,8 @@ func UnmarshalConfig(conf *confmap.Conf, intoCfg Config) error {
 }
 
 // ConfigValidator defines an optional interface for configurations to implement to do validation.
+// It is called by ValidateConfig on the config and on each of its nested values, so the cross-field
+// constraints are best checked by the struct declaring the fields, whose errors are then prefixed with its path.
 type ConfigValidator interface {
 	// Validate the configuration and returns an error if invalid.
 	Validate() error
@@ -45,10 +49,61 @@ type ConfigValidator interface {
 
 // ValidateConfig validates a config, by doing this:
 //   - Call Validate on the config itself if the config implements ConfigValidator.
+//   - Call Validate on the fields, elements and values of the config recursively, including the squashed
+//     and embedded structs, if they implement ConfigValidator.
+//
+// The errors of the nested values are prefixed with their path in the configuration, the keys being separated
+// by "::" as in the configuration sources, e.g. "sending_queue: queue size must be positive". The squashed
+// structs share the path of their parent. All the errors are combined, so that the components can validate
+// each part of their configuration, including the cross-field constraints, where it is declared.
 func ValidateConfig(cfg Config) error {
 	return validate(reflect.ValueOf(cfg))
 }
 
+// configPathSeparator separates the keys of the paths of the nested values in the validation errors.
+const configPathSeparator = "::"
+
+// configPathError is the error of a value nested in the validated config.
+type configPathError struct {
+	path string
+	err  error
+}
+
+func (e *configPathError) Error() string {
+	return e.path + ": " + e.err.Error()
+}
+
+func (e *configPathError) Unwrap() error {
+	return e.err
+}
+
+// withConfigPath prefixes the path of each of the errors with the key of their parent value.
+func withConfigPath(key string, err error) error {
+	var errs error
+	for _, e := range multierr.Errors(err) {
+		if pathErr, ok := e.(*configPathError); ok {
+			errs = multierr.Append(errs, &configPathError{path: key + configPathSeparator + pathErr.path, err: pathErr.err})
+			continue
+		}
+		errs = multierr.Append(errs, &configPathError{path: key, err: e})
+	}
+	return errs
+}
+
+// fieldKey returns the key of the struct field in the configuration, and false if the field is squashed.
+func fieldKey(field reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "squash" {
+			return "", false
+		}
+	}
+	if name == "" || name == "-" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, true
+}
+
 func validate(v reflect.Value) error {
 	// Validate the value itself.
 	switch v.Kind() {
@@ -61,10 +116,15 @@ func validate(v reflect.Value) error {
 		errs = multierr.Append(errs, callValidateIfPossible(v))
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.NumField(); i++ {
-			if !v.Type().Field(i).IsExported() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
 				continue
 			}
-			errs = multierr.Append(errs, validate(v.Field(i)))
+			err := validate(v.Field(i))
+			if key, ok := fieldKey(field); ok && err != nil {
+				err = withConfigPath(key, err)
+			}
+			errs = multierr.Append(errs, err)
 		}
 		return errs
 	case reflect.Slice, reflect.Array:
@@ -72,7 +132,9 @@ func validate(v reflect.Value) error {
 		errs = multierr.Append(errs, callValidateIfPossible(v))
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.Len(); i++ {
-			errs = multierr.Append(errs, validate(v.Index(i)))
+			if err := validate(v.Index(i)); err != nil {
+				errs = multierr.Append(errs, withConfigPath(strconv.Itoa(i), err))
+			}
 		}
 		return errs
 	case reflect.Map:
@@ -81,7 +143,9 @@ func validate(v reflect.Value) error {
 		iter := v.MapRange()
 		for iter.Next() {
 			errs = multierr.Append(errs, validate(iter.Key()))
-			errs = multierr.Append(errs, validate(iter.Value()))
+			if err := validate(iter.Value()); err != nil {
+				errs = multierr.Append(errs, withConfigPath(fmt.Sprint(iter.Key().Interface()), err))
+			}
 		}
 		return errs
 	default:
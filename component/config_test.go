@@ -43,6 +43,21 @@ type configChildTypeDef struct {
 	ChildPtr *errType
 }
 
+type configTaggedChild struct {
+	Child    errConfig                   `mapstructure:"tagged_child"`
+	Squashed configChildStruct           `mapstructure:",squash"`
+	Nested   map[string]configChildSlice `mapstructure:"nested"`
+}
+
+type configParentStruct struct {
+	err   error
+	Child configChildStruct `mapstructure:"child"`
+}
+
+func (p *configParentStruct) Validate() error {
+	return p.err
+}
+
 type errConfig struct {
 	err error
 }
@@ -120,84 +135,113 @@ func TestValidateConfig(t *testing.T) {
 		{
 			name:     "child struct",
 			cfg:      configChildStruct{Child: errConfig{err: errors.New("child struct")}},
-			expected: errors.New("child struct"),
+			expected: errors.New("child: child struct"),
 		},
 		{
 			name:     "pointer child struct",
 			cfg:      &configChildStruct{Child: errConfig{err: errors.New("pointer child struct")}},
-			expected: errors.New("pointer child struct"),
+			expected: errors.New("child: pointer child struct"),
 		},
 		{
 			name:     "child struct pointer",
 			cfg:      &configChildStruct{ChildPtr: &errConfig{err: errors.New("child struct pointer")}},
-			expected: errors.New("child struct pointer"),
+			expected: errors.New("childptr: child struct pointer"),
 		},
 		{
 			name:     "child slice",
 			cfg:      configChildSlice{Child: []errConfig{{}, {err: errors.New("child slice")}}},
-			expected: errors.New("child slice"),
+			expected: errors.New("child::1: child slice"),
 		},
 		{
 			name:     "pointer child slice",
 			cfg:      &configChildSlice{Child: []errConfig{{}, {err: errors.New("pointer child slice")}}},
-			expected: errors.New("pointer child slice"),
+			expected: errors.New("child::1: pointer child slice"),
 		},
 		{
 			name:     "child slice pointer",
 			cfg:      &configChildSlice{ChildPtr: []*errConfig{{}, {err: errors.New("child slice pointer")}}},
-			expected: errors.New("child slice pointer"),
+			expected: errors.New("childptr::1: child slice pointer"),
 		},
 		{
 			name:     "child map value",
 			cfg:      configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("child map")}}},
-			expected: errors.New("child map"),
+			expected: errors.New("child::test: child map"),
 		},
 		{
 			name:     "pointer child map value",
 			cfg:      &configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("pointer child map")}}},
-			expected: errors.New("pointer child map"),
+			expected: errors.New("child::test: pointer child map"),
 		},
 		{
 			name:     "child map value pointer",
 			cfg:      &configChildMapValue{ChildPtr: map[string]*errConfig{"test": {err: errors.New("child map pointer")}}},
-			expected: errors.New("child map pointer"),
+			expected: errors.New("childptr::test: child map pointer"),
 		},
 		{
 			name:     "child map key",
 			cfg:      configChildMapKey{Child: map[errType]string{"child map key": ""}},
-			expected: errors.New("child map key"),
+			expected: errors.New("child: child map key"),
 		},
 		{
 			name:     "pointer child map key",
 			cfg:      &configChildMapKey{Child: map[errType]string{"pointer child map key": ""}},
-			expected: errors.New("pointer child map key"),
+			expected: errors.New("child: pointer child map key"),
 		},
 		{
 			name:     "child map key pointer",
 			cfg:      &configChildMapKey{ChildPtr: map[*errType]string{newErrType("child map key pointer"): ""}},
-			expected: errors.New("child map key pointer"),
+			expected: errors.New("childptr: child map key pointer"),
 		},
 		{
 			name:     "child type",
 			cfg:      configChildTypeDef{Child: "child type"},
-			expected: errors.New("child type"),
+			expected: errors.New("child: child type"),
 		},
 		{
 			name:     "pointer child type",
 			cfg:      &configChildTypeDef{Child: "pointer child type"},
-			expected: errors.New("pointer child type"),
+			expected: errors.New("child: pointer child type"),
 		},
 		{
 			name:     "child type pointer",
 			cfg:      &configChildTypeDef{ChildPtr: newErrType("child type pointer")},
-			expected: errors.New("child type pointer"),
+			expected: errors.New("childptr: child type pointer"),
+		},
+		{
+			name:     "tagged child",
+			cfg:      configTaggedChild{Child: errConfig{err: errors.New("tagged child")}},
+			expected: errors.New("tagged_child: tagged child"),
+		},
+		{
+			name:     "squashed child",
+			cfg:      configTaggedChild{Squashed: configChildStruct{Child: errConfig{err: errors.New("squashed child")}}},
+			expected: errors.New("child: squashed child"),
+		},
+		{
+			name: "nested child",
+			cfg: configTaggedChild{Nested: map[string]configChildSlice{
+				"test": {ChildPtr: []*errConfig{{err: errors.New("nested child")}}},
+			}},
+			expected: errors.New("nested::test::childptr::0: nested child"),
+		},
+		{
+			name: "parent and children",
+			cfg: &configParentStruct{
+				err:   errors.New("parent"),
+				Child: configChildStruct{Child: errConfig{err: errors.New("child")}, ChildPtr: &errConfig{err: errors.New("child pointer")}},
+			},
+			expected: errors.New("parent; child::child: child; child::childptr: child pointer"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := validate(reflect.ValueOf(tt.cfg))
-			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected.Error())
 		})
 	}
 }
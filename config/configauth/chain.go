@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth // import "go.opentelemetry.io/collector/config/configauth"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+// serverChain is an auth.Server evaluating a chain of server authenticators.
+// The authenticators are extensions started and shut down by the service, so
+// the chain itself has nothing to start or shut down.
+type serverChain struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	ids     []component.ID
+	servers []auth.Server
+	all     bool
+}
+
+func (a Authentication) getServerChain(extensions map[component.ID]component.Component) (auth.Server, error) {
+	chain := &serverChain{
+		ids:     a.Authenticators,
+		servers: make([]auth.Server, 0, len(a.Authenticators)),
+		all:     a.Policy == PolicyAll,
+	}
+	for _, id := range a.Authenticators {
+		server, err := getServerAuthenticator(id, extensions)
+		if err != nil {
+			return nil, err
+		}
+		chain.servers = append(chain.servers, server)
+	}
+	return chain, nil
+}
+
+// Authenticate returns the context of the first authenticator succeeding, or with the "all" policy, the context
+// returned by the last authenticator, which is given the contexts returned by the previous ones.
+func (c *serverChain) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	if c.all {
+		for i, server := range c.servers {
+			var err error
+			if ctx, err = server.Authenticate(ctx, headers); err != nil {
+				return ctx, fmt.Errorf("authenticator %q: %w", c.ids[i], err)
+			}
+		}
+		return ctx, nil
+	}
+
+	errs := make([]error, 0, len(c.servers))
+	for i, server := range c.servers {
+		authCtx, err := server.Authenticate(ctx, headers)
+		if err == nil {
+			return authCtx, nil
+		}
+		errs = append(errs, fmt.Errorf("authenticator %q: %w", c.ids[i], err))
+	}
+	return ctx, errors.Join(errs...)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+)
+
+type identityKey struct{}
+
+var (
+	mtlsID   = component.MustNewID("mtls")
+	bearerID = component.MustNewID("bearer")
+	clientID = component.MustNewID("client")
+)
+
+// newTestServer returns an authenticator accepting the requests with the given header,
+// and appending its name to the identities of the context.
+func newTestServer(name, header string) auth.Server {
+	return auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		if _, ok := headers[header]; !ok {
+			return ctx, errors.New("missing " + header)
+		}
+		identities, _ := ctx.Value(identityKey{}).([]string)
+		return context.WithValue(ctx, identityKey{}, append(identities, name)), nil
+	}))
+}
+
+func testExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{
+		mtlsID:   newTestServer("mtls", "cert"),
+		bearerID: newTestServer("bearer", "token"),
+		clientID: auth.NewClient(),
+	}
+}
+
+func TestServerChain(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		policy      string
+		headers     map[string][]string
+		identities  []string
+		expectedErr string
+	}{
+		{
+			desc:       "any_first",
+			headers:    map[string][]string{"cert": nil, "token": nil},
+			identities: []string{"mtls"},
+		},
+		{
+			desc:       "any_second",
+			policy:     PolicyAny,
+			headers:    map[string][]string{"token": nil},
+			identities: []string{"bearer"},
+		},
+		{
+			desc:        "any_none",
+			policy:      PolicyAny,
+			headers:     map[string][]string{},
+			expectedErr: "authenticator \"mtls\": missing cert\nauthenticator \"bearer\": missing token",
+		},
+		{
+			desc:       "all",
+			policy:     PolicyAll,
+			headers:    map[string][]string{"cert": nil, "token": nil},
+			identities: []string{"mtls", "bearer"},
+		},
+		{
+			desc:        "all_missing_one",
+			policy:      PolicyAll,
+			headers:     map[string][]string{"cert": nil},
+			expectedErr: "authenticator \"bearer\": missing token",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Authentication{
+				Authenticators: []component.ID{mtlsID, bearerID},
+				Policy:         tC.policy,
+			}
+			require.NoError(t, cfg.Validate())
+			server, err := cfg.GetServerAuthenticator(testExtensions())
+			require.NoError(t, err)
+			require.NoError(t, server.Start(context.Background(), nil))
+			defer func() { assert.NoError(t, server.Shutdown(context.Background())) }()
+
+			ctx, err := server.Authenticate(context.Background(), tC.headers)
+			if tC.expectedErr != "" {
+				assert.EqualError(t, err, tC.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.identities, ctx.Value(identityKey{}))
+		})
+	}
+}
+
+func TestServerChainFails(t *testing.T) {
+	cfg := &Authentication{Authenticators: []component.ID{mtlsID, component.MustNewID("does_not_exist")}}
+	authenticator, err := cfg.GetServerAuthenticator(testExtensions())
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+	assert.Nil(t, authenticator)
+
+	cfg = &Authentication{Authenticators: []component.ID{mtlsID, clientID}}
+	authenticator, err = cfg.GetServerAuthenticator(testExtensions())
+	assert.ErrorIs(t, err, errNotServer)
+	assert.Nil(t, authenticator)
+
+	cfg = &Authentication{Authenticators: []component.ID{mtlsID}}
+	client, err := cfg.GetClientAuthenticator(testExtensions())
+	assert.ErrorIs(t, err, errChainNotClient)
+	assert.Nil(t, client)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cfg         Authentication
+		expectedErr string
+	}{
+		{
+			desc: "authenticator",
+			cfg:  Authentication{AuthenticatorID: mockID},
+		},
+		{
+			desc: "authenticators",
+			cfg:  Authentication{Authenticators: []component.ID{mtlsID, bearerID}, Policy: PolicyAll},
+		},
+		{
+			desc:        "both",
+			cfg:         Authentication{AuthenticatorID: mockID, Authenticators: []component.ID{mtlsID}},
+			expectedErr: "authenticator and authenticators cannot be both set",
+		},
+		{
+			desc:        "policy_without_authenticators",
+			cfg:         Authentication{AuthenticatorID: mockID, Policy: PolicyAny},
+			expectedErr: "policy requires authenticators to be set",
+		},
+		{
+			desc:        "invalid_policy",
+			cfg:         Authentication{Authenticators: []component.ID{mtlsID}, Policy: "first"},
+			expectedErr: `invalid policy "first", must be "any" or "all"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.cfg.Validate()
+			if tC.expectedErr != "" {
+				assert.EqualError(t, err, tC.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
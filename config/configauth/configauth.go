@@ -18,31 +18,77 @@ var (
 	errAuthenticatorNotFound = errors.New("authenticator not found")
 	errNotClient             = errors.New("requested authenticator is not a client authenticator")
 	errNotServer             = errors.New("requested authenticator is not a server authenticator")
+	errChainNotClient        = errors.New("chains of authenticators are only supported by servers")
+)
+
+const (
+	// PolicyAny accepts the requests authenticated by any authenticator of the chain.
+	PolicyAny = "any"
+	// PolicyAll accepts the requests authenticated by all the authenticators of the chain.
+	PolicyAll = "all"
 )
 
 // Authentication defines the auth settings for the receiver.
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID component.ID `mapstructure:"authenticator"`
+
+	// Authenticators specifies the names of the server authenticator extensions to evaluate in order, instead of
+	// a single authenticator. Cannot be combined with AuthenticatorID.
+	Authenticators []component.ID `mapstructure:"authenticators"`
+
+	// Policy specifies how the chain of Authenticators is evaluated:
+	//  - "any" (default): the authenticators are tried in order, and the first one succeeding authenticates the request.
+	//  - "all": all the authenticators must succeed, each one being given the context returned by the previous one.
+	Policy string `mapstructure:"policy"`
+}
+
+// Validate checks if the Authentication configuration is valid.
+func (a Authentication) Validate() error {
+	if len(a.Authenticators) == 0 {
+		if a.Policy != "" {
+			return errors.New("policy requires authenticators to be set")
+		}
+		return nil
+	}
+	if a.AuthenticatorID != (component.ID{}) {
+		return errors.New("authenticator and authenticators cannot be both set")
+	}
+	switch a.Policy {
+	case "", PolicyAny, PolicyAll:
+		return nil
+	}
+	return fmt.Errorf("invalid policy %q, must be %q or %q", a.Policy, PolicyAny, PolicyAll)
 }
 
 // GetServerAuthenticator attempts to select the appropriate auth.Server from the list of extensions,
 // based on the requested extension name. If an authenticator is not found, an error is returned.
+// When Authenticators is set, the returned auth.Server evaluates the chain according to the Policy.
 func (a Authentication) GetServerAuthenticator(extensions map[component.ID]component.Component) (auth.Server, error) {
-	if ext, found := extensions[a.AuthenticatorID]; found {
+	if len(a.Authenticators) > 0 {
+		return a.getServerChain(extensions)
+	}
+	return getServerAuthenticator(a.AuthenticatorID, extensions)
+}
+
+func getServerAuthenticator(id component.ID, extensions map[component.ID]component.Component) (auth.Server, error) {
+	if ext, found := extensions[id]; found {
 		if server, ok := ext.(auth.Server); ok {
 			return server, nil
 		}
 		return nil, errNotServer
 	}
 
-	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
+	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", id, errAuthenticatorNotFound)
 }
 
 // GetClientAuthenticator attempts to select the appropriate auth.Client from the list of extensions,
 // based on the component id of the extension. If an authenticator is not found, an error is returned.
 // This should be only used by HTTP clients.
 func (a Authentication) GetClientAuthenticator(extensions map[component.ID]component.Component) (auth.Client, error) {
+	if len(a.Authenticators) > 0 {
+		return nil, errChainNotClient
+	}
 	if ext, found := extensions[a.AuthenticatorID]; found {
 		if client, ok := ext.(auth.Client); ok {
 			return client, nil
@@ -39,3 +39,34 @@ func (ct *Type) UnmarshalText(in []byte) error {
 	return fmt.Errorf("unsupported compression type %q", typ)
 
 }
+
+// Level is the compression level of a compression type. The zero value is the default level of the type.
+type Level int
+
+// CompressionParams defines the parameters of a compression type.
+type CompressionParams struct {
+	// Level is the compression level: from 1 (best speed) to 9 (best compression) for gzip, zlib and deflate,
+	// and from 1 to 22 for zstd, mapped to the closest level of the encoder. Snappy has no levels.
+	// The default level of the type is used if zero.
+	Level Level `mapstructure:"level"`
+}
+
+// ValidateParams checks that the compression parameters are supported by the compression type.
+func (ct *Type) ValidateParams(p CompressionParams) error {
+	if p.Level == 0 {
+		return nil
+	}
+	switch *ct {
+	case TypeGzip, TypeZlib, TypeDeflate:
+		if p.Level < 1 || p.Level > 9 {
+			return fmt.Errorf("unsupported compression level %d for %q, must be between 1 and 9", p.Level, *ct)
+		}
+	case TypeZstd:
+		if p.Level < 1 || p.Level > 22 {
+			return fmt.Errorf("unsupported compression level %d for %q, must be between 1 and 22", p.Level, *ct)
+		}
+	default:
+		return fmt.Errorf("compression level is not supported for %q", *ct)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+type writeCloserReset interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+var (
+	_ writeCloserReset = (*gzip.Writer)(nil)
+	_ writeCloserReset = (*snappy.Writer)(nil)
+	_ writeCloserReset = (*zstd.Encoder)(nil)
+	_ writeCloserReset = (*zlib.Writer)(nil)
+)
+
+type encoderKey struct {
+	typ   Type
+	level Level
+}
+
+var (
+	encodersMu sync.Mutex
+	// encoders holds the encoder of each compression type and level, shared by all the components.
+	encoders = map[encoderKey]*Encoder{}
+)
+
+// Encoder compresses data with a pool of writers of a compression type and level.
+type Encoder struct {
+	pool sync.Pool
+}
+
+// NewEncoder returns the Encoder of the compression type with the parameters. The encoders, and their
+// pools of writers, are shared by all the callers using the same type and level.
+func NewEncoder(ct Type, p CompressionParams) (*Encoder, error) {
+	if err := ct.ValidateParams(p); err != nil {
+		return nil, err
+	}
+	newWriter, err := newWriterFunc(ct, p.Level)
+	if err != nil {
+		return nil, err
+	}
+	if ct == TypeDeflate {
+		// Deflate is compressed with zlib, share its encoders.
+		ct = TypeZlib
+	}
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	key := encoderKey{typ: ct, level: p.Level}
+	if e, ok := encoders[key]; ok {
+		return e, nil
+	}
+	e := &Encoder{pool: sync.Pool{New: func() any { return newWriter() }}}
+	encoders[key] = e
+	return e, nil
+}
+
+func newWriterFunc(ct Type, level Level) (func() writeCloserReset, error) {
+	switch ct {
+	case TypeGzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return func() writeCloserReset {
+			// The level is validated, the error cannot happen.
+			zw, _ := gzip.NewWriterLevel(nil, int(level))
+			return zw
+		}, nil
+	case TypeZlib, TypeDeflate:
+		if level == 0 {
+			level = zlib.DefaultCompression
+		}
+		return func() writeCloserReset {
+			zw, _ := zlib.NewWriterLevel(nil, int(level))
+			return zw
+		}, nil
+	case TypeSnappy:
+		return func() writeCloserReset { return snappy.NewBufferedWriter(nil) }, nil
+	case TypeZstd:
+		encoderLevel := zstd.SpeedDefault
+		if level != 0 {
+			encoderLevel = zstd.EncoderLevelFromZstd(int(level))
+		}
+		return func() writeCloserReset {
+			// Concurrency 1 disables async encoding via goroutines. This is useful to reduce memory usage
+			// and isn't a bottleneck for compression using sync.Pool.
+			zw, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(encoderLevel))
+			return zw
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported compression type %q", ct)
+}
+
+// Compress writes the compressed content of src to dst.
+func (e *Encoder) Compress(dst io.Writer, src io.Reader) error {
+	writer := e.pool.Get().(writeCloserReset)
+	defer e.pool.Put(writer)
+	writer.Reset(dst)
+
+	if src != nil {
+		if _, err := io.Copy(writer, src); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
@@ -1,9 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-// This file contains helper functions regarding compression/decompression for confighttp.
-
-package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+package configcompression
 
 import (
 	"bytes"
@@ -13,23 +11,21 @@ import (
 	"testing"
 
 	"github.com/klauspost/compress/zstd"
-
-	"go.opentelemetry.io/collector/config/configcompression"
 )
 
 func BenchmarkCompression(b *testing.B) {
 	benchmarks := []struct {
-		codec    configcompression.Type
+		codec    Type
 		name     string
-		function func(*testing.B, configcompression.Type, *bytes.Buffer, []byte)
+		function func(*testing.B, Type, *bytes.Buffer, []byte)
 	}{
 		{
-			codec:    configcompression.TypeZstd,
+			codec:    TypeZstd,
 			name:     "zstdWithConcurrency",
 			function: benchmarkCompression,
 		},
 		{
-			codec:    configcompression.TypeZstd,
+			codec:    TypeZstd,
 			name:     "zstdNoConcurrency",
 			function: benchmarkCompressionNoConcurrency,
 		},
@@ -53,7 +49,7 @@ func BenchmarkCompression(b *testing.B) {
 	}
 }
 
-func benchmarkCompression(b *testing.B, _ configcompression.Type, buf *bytes.Buffer, payload []byte) {
+func benchmarkCompression(b *testing.B, _ Type, buf *bytes.Buffer, payload []byte) {
 	// Concurrency Enabled
 
 	b.Run("compress", func(b *testing.B) {
@@ -74,7 +70,7 @@ func benchmarkCompression(b *testing.B, _ configcompression.Type, buf *bytes.Buf
 	})
 }
 
-func benchmarkCompressionNoConcurrency(b *testing.B, _ configcompression.Type, buf *bytes.Buffer, payload []byte) {
+func benchmarkCompressionNoConcurrency(b *testing.B, _ Type, buf *bytes.Buffer, payload []byte) {
 	// Concurrency Disabled
 
 	b.Run("compress", func(b *testing.B) {
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configcompression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParams(t *testing.T) {
+	tests := []struct {
+		typ     Type
+		level   Level
+		wantErr string
+	}{
+		{typ: TypeGzip},
+		{typ: TypeGzip, level: 9},
+		{typ: TypeGzip, level: 10, wantErr: `unsupported compression level 10 for "gzip", must be between 1 and 9`},
+		{typ: TypeDeflate, level: -1, wantErr: `unsupported compression level -1 for "deflate", must be between 1 and 9`},
+		{typ: TypeZlib, level: 1},
+		{typ: TypeZstd, level: 22},
+		{typ: TypeZstd, level: 23, wantErr: `unsupported compression level 23 for "zstd", must be between 1 and 22`},
+		{typ: TypeSnappy},
+		{typ: TypeSnappy, level: 1, wantErr: `compression level is not supported for "snappy"`},
+		{typ: typeNone, level: 1, wantErr: `compression level is not supported for "none"`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			err := tt.typ.ValidateParams(CompressionParams{Level: tt.level})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	decoders := map[Type]func(io.Reader) (io.Reader, error){
+		TypeGzip:    func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		TypeZlib:    func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		TypeDeflate: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		TypeSnappy:  func(r io.Reader) (io.Reader, error) { return snappy.NewReader(r), nil },
+		TypeZstd: func(r io.Reader) (io.Reader, error) {
+			zr, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return zr.IOReadCloser(), nil
+		},
+	}
+	payload := strings.Repeat("compressible payload ", 100)
+	for typ, decoder := range decoders {
+		levels := []Level{0}
+		if typ != TypeSnappy {
+			levels = append(levels, 1)
+		}
+		for _, level := range levels {
+			e, err := NewEncoder(typ, CompressionParams{Level: level})
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, e.Compress(&buf, strings.NewReader(payload)))
+			assert.Less(t, buf.Len(), len(payload))
+
+			r, err := decoder(&buf)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, payload, string(got))
+		}
+	}
+}
+
+func TestEncoderShared(t *testing.T) {
+	e1, err := NewEncoder(TypeZstd, CompressionParams{Level: 3})
+	require.NoError(t, err)
+	e2, err := NewEncoder(TypeZstd, CompressionParams{Level: 3})
+	require.NoError(t, err)
+	assert.Same(t, e1, e2)
+	e3, err := NewEncoder(TypeZstd, CompressionParams{})
+	require.NoError(t, err)
+	assert.NotSame(t, e1, e3)
+
+	_, err = NewEncoder(TypeGzip, CompressionParams{Level: 10})
+	assert.Error(t, err)
+	_, err = NewEncoder(typeNone, CompressionParams{})
+	assert.EqualError(t, err, `unsupported compression type "none"`)
+}
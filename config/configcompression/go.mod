@@ -3,6 +3,8 @@ module go.opentelemetry.io/collector/config/configcompression
 go 1.21
 
 require (
+	github.com/golang/snappy v0.0.4
+	github.com/klauspost/compress v1.17.8
 	github.com/stretchr/testify v1.9.0
 	go.uber.org/goleak v1.3.0
 )
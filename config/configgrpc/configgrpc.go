@@ -4,10 +4,13 @@
 package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"strings"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/alts"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
@@ -28,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -52,11 +57,15 @@ type ClientConfig struct {
 	// The target to which the exporter is going to send traces or metrics,
 	// using the gRPC protocol. The valid syntax is described at
 	// https://github.com/grpc/grpc/blob/master/doc/naming.md.
+	// The servers can also be discovered using DNS SRV records, e.g. "dns+srv:///_otlp._tcp.example.com".
 	Endpoint string `mapstructure:"endpoint"`
 
 	// The compression key for supported compression types within collector.
 	Compression configcompression.Type `mapstructure:"compression"`
 
+	// CompressionParams are the parameters of the compression, e.g. its level.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.ClientConfig `mapstructure:"tls"`
 
@@ -64,13 +73,13 @@ type ClientConfig struct {
 	// (https://godoc.org/google.golang.org/grpc#WithKeepaliveParams).
 	Keepalive *KeepaliveClientConfig `mapstructure:"keepalive"`
 
-	// ReadBufferSize for gRPC client. See grpc.WithReadBufferSize.
+	// ReadBufferSize for gRPC client, e.g. "512KiB". See grpc.WithReadBufferSize.
 	// (https://godoc.org/google.golang.org/grpc#WithReadBufferSize).
-	ReadBufferSize int `mapstructure:"read_buffer_size"`
+	ReadBufferSize confighumanize.Size `mapstructure:"read_buffer_size"`
 
-	// WriteBufferSize for gRPC gRPC. See grpc.WithWriteBufferSize.
+	// WriteBufferSize for gRPC gRPC, e.g. "512KiB". See grpc.WithWriteBufferSize.
 	// (https://godoc.org/google.golang.org/grpc#WithWriteBufferSize).
-	WriteBufferSize int `mapstructure:"write_buffer_size"`
+	WriteBufferSize confighumanize.Size `mapstructure:"write_buffer_size"`
 
 	// WaitForReady parameter configures client to wait for ready state before sending data.
 	// (https://github.com/grpc/grpc/blob/master/doc/wait-for-ready.md)
@@ -89,6 +98,39 @@ type ClientConfig struct {
 
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// Dialer configures how the connections to the endpoint are established, e.g. which address
+	// family is preferred when the host resolves to both IPv4 and IPv6 addresses.
+	// When set, the host name of the endpoint is resolved by the dialer instead of the gRPC
+	// name resolver, unless the endpoint uses an explicit scheme, e.g. "dns:///otelcol:4317".
+	// If not set, the default gRPC dialer is used.
+	Dialer *confignet.DialerConfig `mapstructure:"dialer"`
+
+	// ALTS configures the client to secure the connections with Google Application Layer Transport Security
+	// instead of TLS, the tls settings are then ignored. ALTS is only available on Google Cloud.
+	ALTS *ALTSClientConfig `mapstructure:"alts"`
+
+	// Credentials is the ID of the extension providing the credentials bundle of the connections, see
+	// CredentialsBundleProvider. The bundle replaces the tls settings, and cannot be used with alts.
+	Credentials *component.ID `mapstructure:"credentials"`
+}
+
+// ALTSClientConfig defines the configuration of the ALTS credentials of a gRPC client.
+type ALTSClientConfig struct {
+	// TargetServiceAccounts are the service accounts the server is expected to run as.
+	// If empty, any service account is accepted.
+	TargetServiceAccounts []string `mapstructure:"target_service_accounts"`
+
+	// HandshakerServiceAddress is the address of the ALTS handshaker service.
+	// If empty, the handshaker service of the Google Cloud metadata server is used.
+	HandshakerServiceAddress string `mapstructure:"handshaker_service_address"`
+}
+
+// CredentialsBundleProvider is the interface of the extensions providing the gRPC credentials bundle
+// of the clients, e.g. to use credentials specific to a platform.
+type CredentialsBundleProvider interface {
+	// CredentialsBundle returns the credentials bundle of the client connections.
+	CredentialsBundle() (credentials.Bundle, error)
 }
 
 // KeepaliveServerConfig is the configuration for keepalive.
@@ -125,20 +167,24 @@ type ServerConfig struct {
 	// The default value is nil, which will cause the protocol to not use TLS.
 	TLSSetting *configtls.ServerConfig `mapstructure:"tls"`
 
+	// MaxRecvMsgSize sets the maximum size of the messages accepted by the server, e.g. "4MiB".
+	MaxRecvMsgSize confighumanize.Size `mapstructure:"max_recv_msg_size"`
+
 	// MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.
+	// It cannot be set with MaxRecvMsgSize.
 	MaxRecvMsgSizeMiB uint64 `mapstructure:"max_recv_msg_size_mib"`
 
 	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport.
 	// It has effect only for streaming RPCs.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
 
-	// ReadBufferSize for gRPC server. See grpc.ReadBufferSize.
+	// ReadBufferSize for gRPC server, e.g. "512KiB". See grpc.ReadBufferSize.
 	// (https://godoc.org/google.golang.org/grpc#ReadBufferSize).
-	ReadBufferSize int `mapstructure:"read_buffer_size"`
+	ReadBufferSize confighumanize.Size `mapstructure:"read_buffer_size"`
 
-	// WriteBufferSize for gRPC server. See grpc.WriteBufferSize.
+	// WriteBufferSize for gRPC server, e.g. "512KiB". See grpc.WriteBufferSize.
 	// (https://godoc.org/google.golang.org/grpc#WriteBufferSize).
-	WriteBufferSize int `mapstructure:"write_buffer_size"`
+	WriteBufferSize confighumanize.Size `mapstructure:"write_buffer_size"`
 
 	// Keepalive anchor for all the settings related to keepalive.
 	Keepalive *KeepaliveServerConfig `mapstructure:"keepalive"`
@@ -151,6 +197,14 @@ type ServerConfig struct {
 	IncludeMetadata bool `mapstructure:"include_metadata"`
 }
 
+// Validate checks that the ServerConfig is valid.
+func (gss *ServerConfig) Validate() error {
+	if gss.MaxRecvMsgSize > 0 && gss.MaxRecvMsgSizeMiB > 0 {
+		return errors.New("max_recv_msg_size and max_recv_msg_size_mib cannot be both set")
+	}
+	return nil
+}
+
 // sanitizedEndpoint strips the prefix of either http:// or https:// from configgrpc.ClientConfig.Endpoint.
 func (gcs *ClientConfig) sanitizedEndpoint() string {
 	switch {
@@ -181,37 +235,54 @@ func (gcs *ClientConfig) ToClientConn(_ context.Context, host component.Host, se
 		return nil, err
 	}
 	opts = append(opts, extraOpts...)
-	return grpc.NewClient(gcs.sanitizedEndpoint(), opts...)
+	return grpc.NewClient(gcs.target(), opts...)
+}
+
+// target returns the target of the client connection. When a dialer is configured, the
+// endpoint is passed through to it as is, so it can select the address family to connect to.
+func (gcs *ClientConfig) target() string {
+	endpoint := gcs.sanitizedEndpoint()
+	if gcs.Dialer == nil || strings.Contains(endpoint, "://") || strings.HasPrefix(endpoint, "unix:") {
+		return endpoint
+	}
+	return "passthrough:///" + endpoint
 }
 
 func (gcs *ClientConfig) toDialOptions(host component.Host, settings component.TelemetrySettings) ([]grpc.DialOption, error) {
 	var opts []grpc.DialOption
+	if err := gcs.Compression.ValidateParams(gcs.CompressionParams); err != nil {
+		return nil, err
+	}
 	if gcs.Compression.IsCompressed() {
 		cp, err := getGRPCCompressionName(gcs.Compression)
 		if err != nil {
 			return nil, err
 		}
-		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
+		if gcs.CompressionParams.Level == 0 {
+			opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
+		} else {
+			encoder, err := configcompression.NewEncoder(gcs.Compression, gcs.CompressionParams)
+			if err != nil {
+				return nil, err
+			}
+			// The compressors registered in gRPC are selected by name and use their default level,
+			// grpc.WithCompressor is the only way to compress with another level per connection.
+			opts = append(opts, grpc.WithCompressor(levelCompressor{name: cp, encoder: encoder})) //nolint:staticcheck
+		}
 	}
 
-	tlsCfg, err := gcs.TLSSetting.LoadTLSConfig(context.Background())
+	credsOpt, err := gcs.toCredentialsOption(host)
 	if err != nil {
 		return nil, err
 	}
-	cred := insecure.NewCredentials()
-	if tlsCfg != nil {
-		cred = credentials.NewTLS(tlsCfg)
-	} else if gcs.isSchemeHTTPS() {
-		cred = credentials.NewTLS(&tls.Config{})
-	}
-	opts = append(opts, grpc.WithTransportCredentials(cred))
+	opts = append(opts, credsOpt)
 
 	if gcs.ReadBufferSize > 0 {
-		opts = append(opts, grpc.WithReadBufferSize(gcs.ReadBufferSize))
+		opts = append(opts, grpc.WithReadBufferSize(int(gcs.ReadBufferSize)))
 	}
 
 	if gcs.WriteBufferSize > 0 {
-		opts = append(opts, grpc.WithWriteBufferSize(gcs.WriteBufferSize))
+		opts = append(opts, grpc.WithWriteBufferSize(int(gcs.WriteBufferSize)))
 	}
 
 	if gcs.Keepalive != nil {
@@ -252,6 +323,16 @@ func (gcs *ClientConfig) toDialOptions(host component.Host, settings component.T
 		opts = append(opts, grpc.WithAuthority(gcs.Authority))
 	}
 
+	if _, ok := confignet.SRVName(gcs.Endpoint); ok {
+		opts = append(opts, grpc.WithResolvers(newSRVResolverBuilder()))
+	}
+
+	if gcs.Dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return gcs.Dialer.DialContext(ctx, string(confignet.TransportTypeTCP), addr)
+		}))
+	}
+
 	otelOpts := []otelgrpc.Option{
 		otelgrpc.WithTracerProvider(settings.TracerProvider),
 		otelgrpc.WithPropagators(otel.GetTextMapPropagator()),
@@ -296,7 +377,9 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 
-	if gss.MaxRecvMsgSizeMiB > 0 {
+	if gss.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(gss.MaxRecvMsgSize)))
+	} else if gss.MaxRecvMsgSizeMiB > 0 {
 		opts = append(opts, grpc.MaxRecvMsgSize(int(gss.MaxRecvMsgSizeMiB*1024*1024)))
 	}
 
@@ -305,11 +388,11 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 	}
 
 	if gss.ReadBufferSize > 0 {
-		opts = append(opts, grpc.ReadBufferSize(gss.ReadBufferSize))
+		opts = append(opts, grpc.ReadBufferSize(int(gss.ReadBufferSize)))
 	}
 
 	if gss.WriteBufferSize > 0 {
-		opts = append(opts, grpc.WriteBufferSize(gss.WriteBufferSize))
+		opts = append(opts, grpc.WriteBufferSize(int(gss.WriteBufferSize)))
 	}
 
 	// The default values referenced in the GRPC docs are set within the server, so this code doesn't need
@@ -389,6 +472,60 @@ func getGRPCCompressionName(compressionType configcompression.Type) (string, err
 	}
 }
 
+// toCredentialsOption returns the dial option securing the connections: the credentials bundle of the
+// extension or ALTS if configured, TLS otherwise.
+func (gcs *ClientConfig) toCredentialsOption(host component.Host) (grpc.DialOption, error) {
+	switch {
+	case gcs.Credentials != nil && gcs.ALTS != nil:
+		return nil, errors.New("credentials and alts cannot be both set")
+	case gcs.Credentials != nil:
+		ext, ok := host.GetExtensions()[*gcs.Credentials]
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve credentials %q: extension not found", gcs.Credentials)
+		}
+		provider, ok := ext.(CredentialsBundleProvider)
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve credentials %q: the extension does not provide a credentials bundle", gcs.Credentials)
+		}
+		bundle, err := provider.CredentialsBundle()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the credentials bundle of %q: %w", gcs.Credentials, err)
+		}
+		return grpc.WithCredentialsBundle(bundle), nil
+	case gcs.ALTS != nil:
+		return grpc.WithTransportCredentials(alts.NewClientCreds(&alts.ClientOptions{
+			TargetServiceAccounts:    gcs.ALTS.TargetServiceAccounts,
+			HandshakerServiceAddress: gcs.ALTS.HandshakerServiceAddress,
+		})), nil
+	}
+
+	tlsCfg, err := gcs.TLSSetting.LoadTLSConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	cred := insecure.NewCredentials()
+	if tlsCfg != nil {
+		cred = credentials.NewTLS(tlsCfg)
+	} else if gcs.isSchemeHTTPS() {
+		cred = credentials.NewTLS(&tls.Config{})
+	}
+	return grpc.WithTransportCredentials(cred), nil
+}
+
+// levelCompressor compresses the messages with the encoder of a compression level.
+type levelCompressor struct {
+	name    string
+	encoder *configcompression.Encoder
+}
+
+func (c levelCompressor) Do(w io.Writer, p []byte) error {
+	return c.encoder.Compress(w, bytes.NewReader(p))
+}
+
+func (c levelCompressor) Type() string {
+	return c.name
+}
+
 // enhanceWithClientInformation intercepts the incoming RPC, replacing the incoming context with one that includes
 // a client.Info, potentially with the peer's address.
 func enhanceWithClientInformation(includeMetadata bool) func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
@@ -4,8 +4,11 @@
 package configgrpc
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -19,14 +22,19 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -146,8 +154,9 @@ func TestAllGrpcClientSettings(t *testing.T) {
 				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
-				Endpoint:    "localhost:1234",
-				Compression: configcompression.TypeZstd,
+				Endpoint:          "localhost:1234",
+				Compression:       configcompression.TypeZstd,
+				CompressionParams: configcompression.CompressionParams{Level: 3},
 				TLSSetting: configtls.ClientConfig{
 					Insecure: false,
 				},
@@ -223,6 +232,22 @@ func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
 	assert.Len(t, opts, 10)
 }
 
+func TestGrpcServerMaxRecvMsgSize(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint: "0.0.0.0:1234",
+		},
+		MaxRecvMsgSize: 4 * confighumanize.Mebibyte,
+	}
+	assert.NoError(t, gss.Validate())
+	opts, err := gss.toServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, err)
+	assert.Len(t, opts, 4)
+
+	gss.MaxRecvMsgSizeMiB = 4
+	assert.EqualError(t, gss.Validate(), "max_recv_msg_size and max_recv_msg_size_mib cannot be both set")
+}
+
 func TestGrpcServerAuthSettings(t *testing.T) {
 	gss := &ServerConfig{
 		NetAddr: confignet.AddrConfig{
@@ -344,6 +369,53 @@ func TestGRPCClientSettingsError(t *testing.T) {
 			},
 			host: &mockHost{},
 		},
+		{
+			err: "unsupported compression level 10 for \"gzip\", must be between 1 and 9",
+			settings: ClientConfig{
+				Endpoint: "localhost:1234",
+				TLSSetting: configtls.ClientConfig{
+					Insecure: true,
+				},
+				Compression:       configcompression.TypeGzip,
+				CompressionParams: configcompression.CompressionParams{Level: 10},
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "credentials and alts cannot be both set",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				ALTS:        &ALTSClientConfig{},
+				Credentials: &testCredentialsID,
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "failed to resolve credentials \"credentials\": extension not found",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testCredentialsID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{}},
+		},
+		{
+			err: "failed to resolve credentials \"credentials\": the extension does not provide a credentials bundle",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testCredentialsID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{testCredentialsID: &authtest.MockClient{}}},
+		},
+		{
+			err: "failed to get the credentials bundle of \"credentials\": no credentials",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testCredentialsID,
+			},
+			host: &mockHost{ext: map[component.ID]component.Component{
+				testCredentialsID: &mockCredentialsProvider{err: errors.New("no credentials")},
+			}},
+		},
 		{
 			err: "unsupported compression type \"bad\"",
 			settings: ClientConfig{
@@ -691,6 +763,108 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	srv.Stop()
 }
 
+func TestReceiveDualStack(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+			DualStack: true,
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		Dialer: &confignet.DialerConfig{PreferIPv6: true},
+	}
+	assert.Equal(t, "passthrough:///"+ln.Addr().String(), gcs.target())
+	grpcClientConn, errClient := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, errClient)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	resp, errResp := c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, errResp)
+	assert.NotNil(t, resp)
+}
+
+func TestServerMaxConnectionAge(t *testing.T) {
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		Keepalive: &KeepaliveServerConfig{
+			ServerParameters: &KeepaliveServerParameters{
+				MaxConnectionAge:      100 * time.Millisecond,
+				MaxConnectionAgeGrace: 100 * time.Millisecond,
+			},
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	s, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	srv := &grpcTraceServer{}
+	ptraceotlp.RegisterGRPCServer(s, srv)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	conn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+	c := ptraceotlp.NewGRPCClient(conn)
+
+	export := func() string {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, errExport := c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		require.NoError(t, errExport)
+		p, ok := peer.FromContext(srv.recordedContext)
+		require.True(t, ok)
+		return p.Addr.String()
+	}
+
+	first := export()
+	// The server closes the connection once it reaches its max age, the client reconnects.
+	assert.Eventually(t, func() bool {
+		return export() != first
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestClientTarget(t *testing.T) {
+	gcs := &ClientConfig{Endpoint: "https://localhost:4317"}
+	assert.Equal(t, "localhost:4317", gcs.target())
+
+	gcs.Dialer = &confignet.DialerConfig{PreferIPv6: true}
+	assert.Equal(t, "passthrough:///localhost:4317", gcs.target())
+
+	gcs.Endpoint = "dns:///localhost:4317"
+	assert.Equal(t, "dns:///localhost:4317", gcs.target())
+
+	gcs.Endpoint = "unix:///tmp/otel.sock"
+	assert.Equal(t, "unix:///tmp/otel.sock", gcs.target())
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -1096,3 +1270,92 @@ type mockHost struct {
 func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 	return nh.ext
 }
+
+var testCredentialsID = component.MustNewID("credentials")
+
+type mockCredentialsProvider struct {
+	component.StartFunc
+	component.ShutdownFunc
+	err error
+}
+
+func (p *mockCredentialsProvider) CredentialsBundle() (credentials.Bundle, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return insecureBundle{}, nil
+}
+
+type insecureBundle struct{}
+
+func (insecureBundle) TransportCredentials() credentials.TransportCredentials {
+	return insecure.NewCredentials()
+}
+
+func (insecureBundle) PerRPCCredentials() credentials.PerRPCCredentials {
+	return nil
+}
+
+func (b insecureBundle) NewWithMode(string) (credentials.Bundle, error) {
+	return b, nil
+}
+
+func TestClientCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ClientConfig
+	}{
+		{
+			name: "alts",
+			settings: ClientConfig{
+				Endpoint: "localhost:1234",
+				ALTS:     &ALTSClientConfig{TargetServiceAccounts: []string{"collector@example.iam.gserviceaccount.com"}},
+			},
+		},
+		{
+			name: "credentials",
+			settings: ClientConfig{
+				Endpoint:    "localhost:1234",
+				Credentials: &testCredentialsID,
+			},
+		},
+	}
+	host := &mockHost{ext: map[component.ID]component.Component{testCredentialsID: &mockCredentialsProvider{}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := tt.settings.toDialOptions(host, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			assert.NotEmpty(t, opts)
+		})
+	}
+
+	// The connection to a server without ALTS is established with the credentials bundle.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+	gcs := ClientConfig{Endpoint: ln.Addr().String(), Credentials: &testCredentialsID}
+	conn, err := gcs.ToClientConn(context.Background(), host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	_, err = ptraceotlp.NewGRPCClient(conn).Export(context.Background(), ptraceotlp.NewExportRequest())
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestLevelCompressor(t *testing.T) {
+	encoder, err := configcompression.NewEncoder(configcompression.TypeGzip, configcompression.CompressionParams{Level: 9})
+	require.NoError(t, err)
+	cp := levelCompressor{name: "gzip", encoder: encoder}
+	assert.Equal(t, "gzip", cp.Type())
+
+	var buf bytes.Buffer
+	require.NoError(t, cp.Do(&buf, []byte("compressed payload")))
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	got, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "compressed payload", string(got))
+}
@@ -9,6 +9,7 @@ require (
 	go.opentelemetry.io/collector/component v0.98.0
 	go.opentelemetry.io/collector/config/configauth v0.98.0
 	go.opentelemetry.io/collector/config/configcompression v1.5.0
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0
 	go.opentelemetry.io/collector/config/confignet v0.98.0
 	go.opentelemetry.io/collector/config/configopaque v1.5.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.98.0
@@ -36,7 +37,7 @@ require (
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/hashicorp/go-version v1.6.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
-	github.com/klauspost/compress v1.17.2 // indirect
+	github.com/klauspost/compress v1.17.8 // indirect
 	github.com/knadh/koanf/maps v0.1.1 // indirect
 	github.com/knadh/koanf/providers/confmap v0.1.0 // indirect
 	github.com/knadh/koanf/v2 v2.1.1 // indirect
@@ -59,6 +60,7 @@ require (
 	go.opentelemetry.io/otel/trace v1.25.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sync v0.6.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240401170217-c3f982113cda // indirect
@@ -68,6 +70,8 @@ require (
 
 replace go.opentelemetry.io/collector => ../../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../confighumanize
+
 replace go.opentelemetry.io/collector/config/configauth => ../configauth
 
 replace go.opentelemetry.io/collector/config/configcompression => ../configcompression
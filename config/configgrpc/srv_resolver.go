@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// srvRefreshInterval is the interval at which the SRV records are resolved again.
+const srvRefreshInterval = 30 * time.Second
+
+// lookupSRVEndpoints resolves the SRV records, overridden in tests.
+var lookupSRVEndpoints = confignet.LookupSRVEndpoints
+
+// srvResolverBuilder builds resolvers discovering the addresses of the servers using DNS SRV records.
+// The addresses are sorted by priority, so the "pick_first" balancer fails over to the servers with
+// a lower priority, while the "round_robin" balancer spreads the load over all the servers.
+type srvResolverBuilder struct {
+	lookup   func(ctx context.Context, name string) ([]string, error)
+	interval time.Duration
+}
+
+func newSRVResolverBuilder() *srvResolverBuilder {
+	return &srvResolverBuilder{
+		lookup:   lookupSRVEndpoints,
+		interval: srvRefreshInterval,
+	}
+}
+
+func (b *srvResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	name := target.Endpoint()
+	if name == "" {
+		name = target.URL.Host
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &srvResolver{
+		name:       name,
+		cc:         cc,
+		lookup:     b.lookup,
+		interval:   b.interval,
+		ctx:        ctx,
+		cancel:     cancel,
+		resolveNow: make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+func (b *srvResolverBuilder) Scheme() string {
+	return confignet.SRVScheme
+}
+
+type srvResolver struct {
+	name     string
+	cc       resolver.ClientConn
+	lookup   func(ctx context.Context, name string) ([]string, error)
+	interval time.Duration
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	resolveNow chan struct{}
+	wg         sync.WaitGroup
+}
+
+// watch resolves the SRV records periodically, or when requested by the client connection,
+// e.g. after a connection failure, until the resolver is closed.
+func (r *srvResolver) watch() {
+	defer r.wg.Done()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-timer.C:
+		case <-r.resolveNow:
+		}
+
+		r.resolve()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(r.interval)
+	}
+}
+
+func (r *srvResolver) resolve() {
+	endpoints, err := r.lookup(r.ctx, r.name)
+	if err != nil {
+		r.cc.ReportError(err)
+		return
+	}
+	addrs := make([]resolver.Address, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		// The target of the SRV record is used to verify the certificate of the server,
+		// rather than the name of the SRV records.
+		host, _, _ := net.SplitHostPort(endpoint)
+		addrs = append(addrs, resolver.Address{Addr: endpoint, ServerName: host})
+	}
+	if err = r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		r.cc.ReportError(err)
+	}
+}
+
+func (r *srvResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *srvResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
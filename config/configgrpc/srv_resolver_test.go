@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+type fakeResolverClientConn struct {
+	mu     sync.Mutex
+	states []resolver.State
+	errs   []error
+}
+
+func (cc *fakeResolverClientConn) UpdateState(state resolver.State) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.states = append(cc.states, state)
+	return nil
+}
+
+func (cc *fakeResolverClientConn) ReportError(err error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.errs = append(cc.errs, err)
+}
+
+func (cc *fakeResolverClientConn) NewAddress([]resolver.Address) {}
+
+func (cc *fakeResolverClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult {
+	return nil
+}
+
+func (cc *fakeResolverClientConn) counts() (int, int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return len(cc.states), len(cc.errs)
+}
+
+func TestSRVResolver(t *testing.T) {
+	var mu sync.Mutex
+	endpoints := []string{"primary.example.com:4317", "backup.example.com:4317"}
+	var lookupErr error
+	b := &srvResolverBuilder{
+		lookup: func(_ context.Context, name string) ([]string, error) {
+			assert.Equal(t, "_otlp._tcp.example.com", name)
+			mu.Lock()
+			defer mu.Unlock()
+			return endpoints, lookupErr
+		},
+		interval: time.Hour,
+	}
+	assert.Equal(t, "dns+srv", b.Scheme())
+
+	cc := &fakeResolverClientConn{}
+	r, err := b.Build(resolver.Target{URL: url.URL{Scheme: "dns+srv", Path: "/_otlp._tcp.example.com"}}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Eventually(t, func() bool {
+		states, _ := cc.counts()
+		return states == 1
+	}, 10*time.Second, 5*time.Millisecond)
+	cc.mu.Lock()
+	assert.Equal(t, []resolver.Address{
+		{Addr: "primary.example.com:4317", ServerName: "primary.example.com"},
+		{Addr: "backup.example.com:4317", ServerName: "backup.example.com"},
+	}, cc.states[0].Addresses)
+	cc.mu.Unlock()
+
+	// Lookup errors are reported to the client connection.
+	mu.Lock()
+	lookupErr = errors.New("no such host")
+	mu.Unlock()
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	assert.Eventually(t, func() bool {
+		_, errs := cc.counts()
+		return errs == 1
+	}, 10*time.Second, 5*time.Millisecond)
+}
+
+func TestSRVResolverAuthority(t *testing.T) {
+	resolved := make(chan string, 1)
+	b := &srvResolverBuilder{
+		lookup: func(_ context.Context, name string) ([]string, error) {
+			resolved <- name
+			return nil, errors.New("no such host")
+		},
+		interval: time.Hour,
+	}
+	r, err := b.Build(resolver.Target{URL: url.URL{Scheme: "dns+srv", Host: "_otlp._tcp.example.com"}}, &fakeResolverClientConn{}, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, "_otlp._tcp.example.com", <-resolved)
+}
+
+func TestSRVClientConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	gcs := &ClientConfig{
+		Endpoint: "dns+srv:///_otlp._tcp.example.com",
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	// The SRV records point to the test server.
+	oldLookup := lookupSRVEndpoints
+	lookupSRVEndpoints = func(context.Context, string) ([]string, error) {
+		return []string{ln.Addr().String()}, nil
+	}
+	defer func() { lookupSRVEndpoints = oldLookup }()
+
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+}
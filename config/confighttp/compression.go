@@ -22,18 +22,18 @@ import (
 type compressRoundTripper struct {
 	rt              http.RoundTripper
 	compressionType configcompression.Type
-	compressor      *compressor
+	encoder         *configcompression.Encoder
 }
 
-func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.Type) (*compressRoundTripper, error) {
-	encoder, err := newCompressor(compressionType)
+func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.Type, params configcompression.CompressionParams) (*compressRoundTripper, error) {
+	encoder, err := configcompression.NewEncoder(compressionType, params)
 	if err != nil {
 		return nil, err
 	}
 	return &compressRoundTripper{
 		rt:              rt,
 		compressionType: compressionType,
-		compressor:      encoder,
+		encoder:         encoder,
 	}, nil
 }
 
@@ -48,7 +48,7 @@ func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 
 	// Compress the body.
 	buf := bytes.NewBuffer([]byte{})
-	if err := r.compressor.compress(buf, req.Body); err != nil {
+	if err := r.compress(buf, req.Body); err != nil {
 		return nil, err
 	}
 
@@ -66,6 +66,17 @@ func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 	return r.rt.RoundTrip(cReq)
 }
 
+func (r *compressRoundTripper) compress(buf *bytes.Buffer, body io.ReadCloser) error {
+	if body == nil {
+		return r.encoder.Compress(buf, nil)
+	}
+	err := r.encoder.Compress(buf, body)
+	if closeErr := body.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 type decompressor struct {
 	errHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
 	base       http.Handler
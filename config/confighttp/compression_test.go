@@ -116,6 +116,39 @@ func TestHTTPClientCompression(t *testing.T) {
 	}
 }
 
+func TestHTTPClientCompressionLevel(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	srv := httptest.NewServer(httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, testBody, body)
+		w.WriteHeader(http.StatusOK)
+	}), defaultErrorHandler, nil))
+	t.Cleanup(srv.Close)
+
+	for _, typ := range []configcompression.Type{configcompression.TypeGzip, configcompression.TypeZstd} {
+		clientSettings := ClientConfig{
+			Endpoint:          srv.URL,
+			Compression:       typ,
+			CompressionParams: configcompression.CompressionParams{Level: 9},
+		}
+		client, err := clientSettings.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+		require.NoError(t, err)
+		res, err := client.Post(srv.URL, "text/plain", bytes.NewReader(testBody))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	}
+
+	clientSettings := ClientConfig{
+		Endpoint:          srv.URL,
+		Compression:       configcompression.TypeSnappy,
+		CompressionParams: configcompression.CompressionParams{Level: 1},
+	}
+	_, err := clientSettings.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, `compression level is not supported for "snappy"`)
+}
+
 func TestHTTPCustomDecompression(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
@@ -288,7 +321,7 @@ func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	require.NoError(t, err, "failed to create request to test handler")
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip, configcompression.CompressionParams{})
 	require.NoError(t, err)
 	res, err := client.Do(req)
 	require.NoError(t, err)
@@ -319,7 +352,7 @@ func TestHTTPContentCompressionCopyError(t *testing.T) {
 	require.NoError(t, err)
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip, configcompression.CompressionParams{})
 	require.NoError(t, err)
 	_, err = client.Do(req)
 	require.Error(t, err)
@@ -343,7 +376,7 @@ func TestHTTPContentCompressionRequestBodyCloseError(t *testing.T) {
 	require.NoError(t, err)
 
 	client := http.Client{}
-	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip)
+	client.Transport, err = newCompressRoundTripper(http.DefaultTransport, configcompression.TypeGzip, configcompression.CompressionParams{})
 	require.NoError(t, err)
 	_, err = client.Do(req)
 	require.Error(t, err)
@@ -22,6 +22,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confighumanize"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -34,6 +36,8 @@ const headerContentEncoding = "Content-Encoding"
 // ClientConfig defines settings for creating an HTTP client.
 type ClientConfig struct {
 	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces).
+	// The servers can also be discovered using DNS SRV records, e.g. "dns+srv://_otlp._tcp.example.com/v1/traces",
+	// in which case HTTPS is used unless TLSSetting.Insecure is set.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// ProxyURL setting for the collector
@@ -42,11 +46,11 @@ type ClientConfig struct {
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.ClientConfig `mapstructure:"tls"`
 
-	// ReadBufferSize for HTTP client. See http.Transport.ReadBufferSize.
-	ReadBufferSize int `mapstructure:"read_buffer_size"`
+	// ReadBufferSize for HTTP client, e.g. "64KiB". See http.Transport.ReadBufferSize.
+	ReadBufferSize confighumanize.Size `mapstructure:"read_buffer_size"`
 
-	// WriteBufferSize for HTTP client. See http.Transport.WriteBufferSize.
-	WriteBufferSize int `mapstructure:"write_buffer_size"`
+	// WriteBufferSize for HTTP client, e.g. "64KiB". See http.Transport.WriteBufferSize.
+	WriteBufferSize confighumanize.Size `mapstructure:"write_buffer_size"`
 
 	// Timeout parameter configures `http.Client.Timeout`.
 	Timeout time.Duration `mapstructure:"timeout"`
@@ -65,6 +69,9 @@ type ClientConfig struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.Type `mapstructure:"compression"`
 
+	// CompressionParams are the parameters of the compression, e.g. its level.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params"`
+
 	// MaxIdleConns is used to set a limit to the maximum idle HTTP connections the client can keep open.
 	// There's an already set value, and we want to override it only if an explicit value provided
 	MaxIdleConns *int `mapstructure:"max_idle_conns"`
@@ -99,6 +106,26 @@ type ClientConfig struct {
 	// HTTP2PingTimeout if there's no response to the ping within the configured value, the connection will be closed.
 	// If not set or set to 0, it defaults to 15s.
 	HTTP2PingTimeout time.Duration `mapstructure:"http2_ping_timeout"`
+
+	// Dialer configures how the connections to the endpoint are established, e.g. which address
+	// family is preferred when the host resolves to both IPv4 and IPv6 addresses.
+	// If not set, the dialer of http.DefaultTransport is used.
+	Dialer *confignet.DialerConfig `mapstructure:"dialer"`
+
+	// MaxConcurrentDials limits the number of connections the client establishes concurrently, including
+	// the DNS resolution of the endpoint, so that an unresponsive resolver or endpoint does not pile up dials.
+	// The dials waiting for their turn fail when the request is canceled or times out.
+	// 0 means no limit.
+	MaxConcurrentDials int `mapstructure:"max_concurrent_dials"`
+
+	// TLSHandshakeTimeout is the maximum amount of time to wait for a TLS handshake.
+	// There's an already set value, and we want to override it only if an explicit value provided
+	TLSHandshakeTimeout *time.Duration `mapstructure:"tls_handshake_timeout"`
+
+	// ResponseHeaderTimeout is the maximum amount of time to wait for the response headers of the server
+	// after fully writing the request, so that slow endpoints fail before Timeout expires.
+	// 0 means no timeout.
+	ResponseHeaderTimeout time.Duration `mapstructure:"response_header_timeout"`
 }
 
 // NewDefaultClientConfig returns ClientConfig type object with
@@ -116,6 +143,33 @@ func NewDefaultClientConfig() ClientConfig {
 	}
 }
 
+// timeoutBounds are the bounds of the timeouts of the client.
+var timeoutBounds = confighumanize.DurationBounds{Min: time.Millisecond}
+
+// Validate checks that the timeouts of the ClientConfig are within their bounds.
+func (hcs *ClientConfig) Validate() error {
+	timeouts := []struct {
+		name    string
+		timeout *time.Duration
+	}{
+		{name: "timeout", timeout: &hcs.Timeout},
+		{name: "idle_conn_timeout", timeout: hcs.IdleConnTimeout},
+		{name: "http2_read_idle_timeout", timeout: &hcs.HTTP2ReadIdleTimeout},
+		{name: "http2_ping_timeout", timeout: &hcs.HTTP2PingTimeout},
+		{name: "tls_handshake_timeout", timeout: hcs.TLSHandshakeTimeout},
+		{name: "response_header_timeout", timeout: &hcs.ResponseHeaderTimeout},
+	}
+	for _, t := range timeouts {
+		if t.timeout == nil {
+			continue
+		}
+		if err := confighumanize.ValidateDuration(t.name, *t.timeout, timeoutBounds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ToClient creates an HTTP client.
 func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, settings component.TelemetrySettings) (*http.Client, error) {
 	tlsCfg, err := hcs.TLSSetting.LoadTLSConfig(ctx)
@@ -127,10 +181,10 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 		transport.TLSClientConfig = tlsCfg
 	}
 	if hcs.ReadBufferSize > 0 {
-		transport.ReadBufferSize = hcs.ReadBufferSize
+		transport.ReadBufferSize = int(hcs.ReadBufferSize)
 	}
 	if hcs.WriteBufferSize > 0 {
-		transport.WriteBufferSize = hcs.WriteBufferSize
+		transport.WriteBufferSize = int(hcs.WriteBufferSize)
 	}
 
 	if hcs.MaxIdleConns != nil {
@@ -158,8 +212,22 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 		transport.Proxy = http.ProxyURL(proxyURL)
 	}
 
+	if hcs.TLSHandshakeTimeout != nil {
+		transport.TLSHandshakeTimeout = *hcs.TLSHandshakeTimeout
+	}
+
+	transport.ResponseHeaderTimeout = hcs.ResponseHeaderTimeout
+
 	transport.DisableKeepAlives = hcs.DisableKeepAlives
 
+	if hcs.Dialer != nil {
+		transport.DialContext = hcs.Dialer.DialContext
+	}
+
+	if hcs.MaxConcurrentDials > 0 {
+		transport.DialContext = limitDials(transport.DialContext, hcs.MaxConcurrentDials)
+	}
+
 	if hcs.HTTP2ReadIdleTimeout > 0 {
 		transport2, transportErr := http2.ConfigureTransports(transport)
 		if transportErr != nil {
@@ -191,6 +259,16 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 		}
 	}
 
+	// The SRV records are resolved before the authentication, so that request signing-based
+	// auth mechanisms sign the requests sent to the discovered servers.
+	if _, ok := confignet.SRVName(hcs.Endpoint); ok {
+		scheme := "https"
+		if hcs.TLSSetting.Insecure {
+			scheme = "http"
+		}
+		clientTransport = newSRVRoundTripper(clientTransport, scheme)
+	}
+
 	if len(hcs.Headers) > 0 {
 		clientTransport = &headerRoundTripper{
 			transport: clientTransport,
@@ -200,8 +278,11 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 
 	// Compress the body using specified compression methods if non-empty string is provided.
 	// Supporting gzip, zlib, deflate, snappy, and zstd; none is treated as uncompressed.
+	if err = hcs.Compression.ValidateParams(hcs.CompressionParams); err != nil {
+		return nil, err
+	}
 	if hcs.Compression.IsCompressed() {
-		clientTransport, err = newCompressRoundTripper(clientTransport, hcs.Compression)
+		clientTransport, err = newCompressRoundTripper(clientTransport, hcs.Compression, hcs.CompressionParams)
 		if err != nil {
 			return nil, err
 		}
@@ -238,6 +319,22 @@ func (hcs *ClientConfig) ToClientContext(ctx context.Context, host component.Hos
 	return hcs.ToClient(ctx, host, settings)
 }
 
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// limitDials returns a dialFunc allowing at most limit concurrent dials.
+func limitDials(dial dialFunc, limit int) dialFunc {
+	sem := make(chan struct{}, limit)
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-sem }()
+		return dial(ctx, network, addr)
+	}
+}
+
 // Custom RoundTripper that adds headers.
 type headerRoundTripper struct {
 	transport http.RoundTripper
@@ -274,8 +371,8 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
-	// MaxRequestBodySize sets the maximum request body size in bytes
-	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+	// MaxRequestBodySize sets the maximum request body size in bytes, e.g. 20971520 or "20MiB".
+	MaxRequestBodySize confighumanize.Size `mapstructure:"max_request_body_size"`
 
 	// IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
@@ -284,6 +381,10 @@ type ServerConfig struct {
 	// Additional headers attached to each HTTP response sent to the client.
 	// Header values are opaque since they may be sensitive.
 	ResponseHeaders map[string]configopaque.String `mapstructure:"response_headers"`
+
+	// DualStack configures the server to listen on both the IPv4 and IPv6 addresses of the endpoint.
+	// See confignet.TCPAddrConfig for details.
+	DualStack bool `mapstructure:"dual_stack"`
 }
 
 // Deprecated: [v0.99.0] Use ToListener instead.
@@ -293,7 +394,8 @@ func (hss *ServerConfig) ToListenerContext(ctx context.Context) (net.Listener, e
 
 // ToListener creates a net.Listener.
 func (hss *ServerConfig) ToListener(ctx context.Context) (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	addr := confignet.TCPAddrConfig{Endpoint: hss.Endpoint, DualStack: hss.DualStack}
+	listener, err := addr.Listen(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -358,7 +460,7 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 	handler = httpContentDecompressor(handler, serverOpts.errHandler, serverOpts.decoders)
 
 	if hss.MaxRequestBodySize > 0 {
-		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
+		handler = maxRequestBodySizeInterceptor(handler, int64(hss.MaxRequestBodySize))
 	}
 
 	if hss.Auth != nil {
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -243,6 +244,20 @@ func TestDefaultHTTPClientSettings(t *testing.T) {
 	assert.EqualValues(t, 90*time.Second, *httpClientSettings.IdleConnTimeout)
 }
 
+func TestHTTPClientSettingsValidate(t *testing.T) {
+	hcs := NewDefaultClientConfig()
+	assert.NoError(t, hcs.Validate())
+
+	hcs.Timeout = 5
+	assert.EqualError(t, hcs.Validate(),
+		`'timeout' must be at least 1ms, got 5ns: the durations without unit are in nanoseconds, use e.g. "5s"`)
+
+	hcs.Timeout = 5 * time.Second
+	tlsHandshakeTimeout := -time.Second
+	hcs.TLSHandshakeTimeout = &tlsHandshakeTimeout
+	assert.EqualError(t, hcs.Validate(), "'tls_handshake_timeout' must be non-negative, got -1s")
+}
+
 func TestProxyURL(t *testing.T) {
 	testCases := []struct {
 		desc        string
@@ -1074,6 +1089,99 @@ func TestHttpClientHeaders(t *testing.T) {
 	}
 }
 
+func TestHttpDualStack(t *testing.T) {
+	hss := &ServerConfig{
+		Endpoint:  "localhost:0",
+		DualStack: true,
+	}
+	ln, err := hss.ToListener(context.Background())
+	require.NoError(t, err)
+	s, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer func() { assert.NoError(t, s.Close()) }()
+
+	hcs := ClientConfig{
+		Endpoint: "http://" + ln.Addr().String(),
+		Dialer:   &confignet.DialerConfig{PreferIPv6: true, FallbackDelay: 10 * time.Millisecond},
+	}
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	resp, err := client.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, resp.Body.Close())
+}
+
+func TestHTTPClientTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	tlsHandshakeTimeout := 2 * time.Second
+	hcs := ClientConfig{
+		Endpoint:              server.URL,
+		Timeout:               time.Minute,
+		TLSHandshakeTimeout:   &tlsHandshakeTimeout,
+		ResponseHeaderTimeout: 50 * time.Millisecond,
+		MaxConcurrentDials:    2,
+	}
+	tt := componenttest.NewNopTelemetrySettings()
+	tt.TracerProvider = nil
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), tt)
+	require.NoError(t, err)
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(t, 50*time.Millisecond, transport.ResponseHeaderTimeout)
+
+	// The request fails when the response headers time out, long before the client timeout.
+	start := time.Now()
+	_, err = client.Get(hcs.Endpoint)
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+	assert.Less(t, time.Since(start), hcs.Timeout)
+}
+
+func TestLimitDials(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	dial := limitDials(func(context.Context, string, string) (net.Conn, error) {
+		started <- struct{}{}
+		<-release
+		return nil, errors.New("dial failed")
+	}, 1)
+
+	errs := make(chan error)
+	go func() {
+		_, err := dial(context.Background(), "tcp", "localhost:1234")
+		errs <- err
+	}()
+	<-started
+
+	// The second dial waits for the first one, until its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := dial(ctx, "tcp", "localhost:1234")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	assert.EqualError(t, <-errs, "dial failed")
+
+	// The slot is released once the first dial returns.
+	go func() { <-started }()
+	_, err = dial(context.Background(), "tcp", "localhost:1234")
+	assert.EqualError(t, err, "dial failed")
+}
+
 func TestHttpClientHostHeader(t *testing.T) {
 	hostHeader := "th"
 	tt := struct {
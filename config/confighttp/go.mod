@@ -11,6 +11,8 @@ require (
 	go.opentelemetry.io/collector/component v0.98.0
 	go.opentelemetry.io/collector/config/configauth v0.98.0
 	go.opentelemetry.io/collector/config/configcompression v1.5.0
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0
+	go.opentelemetry.io/collector/config/confignet v0.98.0
 	go.opentelemetry.io/collector/config/configopaque v1.5.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.98.0
 	go.opentelemetry.io/collector/config/configtls v0.98.0
@@ -64,10 +66,14 @@ require (
 
 replace go.opentelemetry.io/collector => ../../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../confighumanize
+
 replace go.opentelemetry.io/collector/config/configauth => ../configauth
 
 replace go.opentelemetry.io/collector/config/configcompression => ../configcompression
 
+replace go.opentelemetry.io/collector/config/confignet => ../confignet
+
 replace go.opentelemetry.io/collector/config/configopaque => ../configopaque
 
 replace go.opentelemetry.io/collector/config/configtls => ../configtls
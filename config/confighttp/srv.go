@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// srvRefreshInterval is the interval at which the SRV records are resolved again.
+const srvRefreshInterval = 30 * time.Second
+
+// srvRoundTripper sends the requests with the confignet.SRVScheme to the servers discovered
+// using DNS SRV records. The servers are tried in the order of their priority, and the requests
+// are sent to the last server that succeeded until the records are resolved again.
+type srvRoundTripper struct {
+	transport http.RoundTripper
+	// scheme is the scheme used to send the requests to the servers, i.e. "http" or "https".
+	scheme   string
+	lookup   func(ctx context.Context, name string) ([]string, error)
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*srvRecords
+}
+
+type srvRecords struct {
+	endpoints []string
+	resolved  time.Time
+	// current is the index of the endpoint the requests are sent to.
+	current int
+}
+
+func newSRVRoundTripper(transport http.RoundTripper, scheme string) *srvRoundTripper {
+	return &srvRoundTripper{
+		transport: transport,
+		scheme:    scheme,
+		lookup:    confignet.LookupSRVEndpoints,
+		interval:  srvRefreshInterval,
+		now:       time.Now,
+		records:   map[string]*srvRecords{},
+	}
+}
+
+func (rt *srvRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != confignet.SRVScheme {
+		return rt.transport.RoundTrip(req)
+	}
+
+	// Both the "dns+srv://name/path" and "dns+srv:///name/path" forms are accepted.
+	name, path := req.URL.Host, req.URL.Path
+	if name == "" {
+		name, path, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		path = "/" + path
+	}
+
+	rec, current, err := rt.resolve(req.Context(), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve SRV records %q: %w", name, err)
+	}
+
+	var errs error
+	endpoints := rec.endpoints
+	for i := range endpoints {
+		idx := (current + i) % len(endpoints)
+		r := req.Clone(req.Context())
+		r.URL.Scheme = rt.scheme
+		r.URL.Host = endpoints[idx]
+		r.URL.Path, r.URL.RawPath = path, ""
+		if r.Host == req.URL.Host {
+			r.Host = ""
+		}
+		if i > 0 {
+			// The body was consumed by the previous attempt.
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					break
+				}
+				if r.Body, err = req.GetBody(); err != nil {
+					return nil, errors.Join(errs, err)
+				}
+			}
+		}
+
+		resp, rerr := rt.transport.RoundTrip(r)
+		if rerr == nil {
+			rt.setCurrent(name, rec, idx)
+			return resp, nil
+		}
+		errs = errors.Join(errs, rerr)
+		if req.Context().Err() != nil {
+			break
+		}
+	}
+	return nil, errs
+}
+
+// resolve returns the SRV records with the given name and the index of the current endpoint,
+// resolving the records if they were never resolved or are outdated.
+func (rt *srvRoundTripper) resolve(ctx context.Context, name string) (*srvRecords, int, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	now := rt.now()
+	rec, ok := rt.records[name]
+	if ok && now.Sub(rec.resolved) < rt.interval {
+		return rec, rec.current, nil
+	}
+
+	endpoints, err := rt.lookup(ctx, name)
+	if err != nil {
+		if ok {
+			// Keep using the previous records until they can be resolved again.
+			rec.resolved = now
+			return rec, rec.current, nil
+		}
+		return nil, 0, err
+	}
+	rec = &srvRecords{endpoints: endpoints, resolved: now}
+	rt.records[name] = rec
+	return rec, 0, nil
+}
+
+func (rt *srvRoundTripper) setCurrent(name string, rec *srvRecords, current int) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	// Ignore the result if the records were resolved again in the meantime.
+	if rt.records[name] == rec {
+		rec.current = current
+	}
+}
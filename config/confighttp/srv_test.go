@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func newTestSRVServer(t *testing.T, received chan<- string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		received <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return serverURL.Host
+}
+
+// unusedEndpoint returns an endpoint nothing listens on.
+func unusedEndpoint(t *testing.T) string {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return endpoint
+}
+
+func TestSRVRoundTripperFailover(t *testing.T) {
+	received := make(chan string, 10)
+	backup := newTestSRVServer(t, received)
+	primary := unusedEndpoint(t)
+
+	lookups := 0
+	now := time.Now()
+	rt := newSRVRoundTripper(http.DefaultTransport, "http")
+	rt.lookup = func(_ context.Context, name string) ([]string, error) {
+		assert.Equal(t, "_otlp._tcp.example.com", name)
+		lookups++
+		return []string{primary, backup}, nil
+	}
+	rt.now = func() time.Time { return now }
+	client := &http.Client{Transport: rt}
+
+	for _, endpoint := range []string{"dns+srv://_otlp._tcp.example.com/v1/traces", "dns+srv:///_otlp._tcp.example.com/v1/traces"} {
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader([]byte("data")))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, "data", <-received)
+	}
+	assert.Equal(t, 1, lookups)
+	assert.Equal(t, 1, rt.records["_otlp._tcp.example.com"].current)
+
+	// The records are resolved again after the refresh interval, and the primary server is tried first again.
+	now = now.Add(srvRefreshInterval)
+	req, err := http.NewRequest(http.MethodPost, "dns+srv://_otlp._tcp.example.com/v1/traces", bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, 2, lookups)
+}
+
+func TestSRVRoundTripperErrors(t *testing.T) {
+	rt := newSRVRoundTripper(http.DefaultTransport, "http")
+	rt.lookup = func(context.Context, string) ([]string, error) {
+		return nil, errors.New("no such host")
+	}
+	client := &http.Client{Transport: rt}
+	_, err := client.Post("dns+srv://_otlp._tcp.example.com/v1/traces", "text/plain", bytes.NewReader([]byte("data")))
+	assert.ErrorContains(t, err, `failed to resolve SRV records "_otlp._tcp.example.com": no such host`)
+
+	rt.lookup = func(context.Context, string) ([]string, error) {
+		return []string{unusedEndpoint(t), unusedEndpoint(t)}, nil
+	}
+	_, err = client.Post("dns+srv://_otlp._tcp.example.org/v1/traces", "text/plain", bytes.NewReader([]byte("data")))
+	var opErr *net.OpError
+	assert.ErrorAs(t, err, &opErr)
+}
+
+func TestSRVClient(t *testing.T) {
+	received := make(chan string, 1)
+	endpoint := newTestSRVServer(t, received)
+
+	hcs := ClientConfig{
+		Endpoint:   "dns+srv://_otlp._tcp.example.com",
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	// Omit TracerProvider and MeterProvider in TelemetrySettings as otelhttp.Transport cannot be introspected
+	client, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), component.TelemetrySettings{Logger: zap.NewNop(), MetricsLevel: configtelemetry.LevelNone})
+	require.NoError(t, err)
+	rt, ok := client.Transport.(*srvRoundTripper)
+	require.True(t, ok)
+	assert.Equal(t, "http", rt.scheme)
+	rt.lookup = func(context.Context, string) ([]string, error) {
+		return []string{endpoint}, nil
+	}
+
+	resp, err := client.Post(hcs.Endpoint+"/v1/traces", "text/plain", bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, "data", <-received)
+}
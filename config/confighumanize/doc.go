@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package confighumanize defines the configuration types accepting human-readable values,
+// e.g. confighumanize.Size for the sizes in bytes configured as "512kB" or "4MiB".
+package confighumanize // import "go.opentelemetry.io/collector/config/confighumanize"
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighumanize // import "go.opentelemetry.io/collector/config/confighumanize"
+
+import (
+	"fmt"
+	"time"
+)
+
+// DurationBounds are the bounds of a duration setting, e.g. of a timeout or of an interval.
+// A zero bound is not enforced.
+type DurationBounds struct {
+	// Min is the minimum of the positive durations.
+	Min time.Duration
+	// Max is the maximum of the durations.
+	Max time.Duration
+}
+
+// ValidateDuration checks that the duration d of the setting name is within the bounds.
+// The zero duration is always valid, since it usually means that the setting is disabled,
+// and the negative durations are never valid.
+//
+// The positive durations below the minimum are reported with a hint when they are below a
+// millisecond, since they are most often numbers configured without unit, e.g. "timeout: 5",
+// and then interpreted as nanoseconds.
+func ValidateDuration(name string, d time.Duration, bounds DurationBounds) error {
+	switch {
+	case d < 0:
+		return fmt.Errorf("'%s' must be non-negative, got %v", name, d)
+	case d > 0 && d < bounds.Min:
+		if d < time.Millisecond {
+			return fmt.Errorf("'%s' must be at least %v, got %v: the durations without unit are in nanoseconds, use e.g. \"5s\"", name, bounds.Min, d)
+		}
+		return fmt.Errorf("'%s' must be at least %v, got %v", name, bounds.Min, d)
+	case bounds.Max > 0 && d > bounds.Max:
+		return fmt.Errorf("'%s' must be at most %v, got %v", name, bounds.Max, d)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighumanize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDuration(t *testing.T) {
+	bounds := DurationBounds{Min: time.Millisecond, Max: time.Hour}
+	tests := []struct {
+		name        string
+		duration    time.Duration
+		bounds      DurationBounds
+		expectedErr string
+	}{
+		{name: "zero", duration: 0, bounds: bounds},
+		{name: "min", duration: time.Millisecond, bounds: bounds},
+		{name: "max", duration: time.Hour, bounds: bounds},
+		{name: "unbounded", duration: 1, bounds: DurationBounds{}},
+		{name: "negative", duration: -time.Second, bounds: bounds, expectedErr: "'timeout' must be non-negative, got -1s"},
+		{name: "negative unbounded", duration: -1, bounds: DurationBounds{}, expectedErr: "'timeout' must be non-negative, got -1ns"},
+		{
+			name:        "without unit",
+			duration:    5,
+			bounds:      bounds,
+			expectedErr: `'timeout' must be at least 1ms, got 5ns: the durations without unit are in nanoseconds, use e.g. "5s"`,
+		},
+		{
+			name:        "below min",
+			duration:    time.Millisecond,
+			bounds:      DurationBounds{Min: time.Second},
+			expectedErr: "'timeout' must be at least 1s, got 1ms",
+		},
+		{name: "above max", duration: 2 * time.Hour, bounds: bounds, expectedErr: "'timeout' must be at most 1h0m0s, got 2h0m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDuration("timeout", tt.duration, tt.bounds)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
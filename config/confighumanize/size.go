@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighumanize // import "go.opentelemetry.io/collector/config/confighumanize"
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Size is a number of bytes. It is configured either as an integer, or as a string made of a number
+// and a unit, e.g. "512kB" or "4MiB". The number can be decimal, e.g. "1.5GiB", and the unit is case-insensitive.
+//
+// The supported units are:
+//   - B, the bytes.
+//   - kB, MB, GB and TB, the multiples of 1000 bytes.
+//   - KiB, MiB, GiB and TiB, the multiples of 1024 bytes.
+type Size int64
+
+const (
+	Byte Size = 1
+
+	Kilobyte Size = 1000 * Byte
+	Megabyte Size = 1000 * Kilobyte
+	Gigabyte Size = 1000 * Megabyte
+	Terabyte Size = 1000 * Gigabyte
+
+	Kibibyte Size = 1024 * Byte
+	Mebibyte Size = 1024 * Kibibyte
+	Gibibyte Size = 1024 * Mebibyte
+	Tebibyte Size = 1024 * Gibibyte
+)
+
+var (
+	_ encoding.TextMarshaler   = Size(0)
+	_ encoding.TextUnmarshaler = (*Size)(nil)
+	_ fmt.Stringer             = Size(0)
+)
+
+// units maps the lowercase units to their number of bytes.
+var units = map[string]Size{
+	"":    Byte,
+	"b":   Byte,
+	"kb":  Kilobyte,
+	"mb":  Megabyte,
+	"gb":  Gigabyte,
+	"tb":  Terabyte,
+	"kib": Kibibyte,
+	"mib": Mebibyte,
+	"gib": Gibibyte,
+	"tib": Tebibyte,
+}
+
+// ParseSize parses a size made of a number and an optional unit, e.g. "512kB" or "4MiB".
+// The sizes without unit are in bytes.
+func ParseSize(s string) (Size, error) {
+	text := strings.TrimSpace(s)
+	if strings.HasPrefix(text, "-") {
+		return 0, fmt.Errorf("invalid size %q: must not be negative", s)
+	}
+	i := strings.IndexFunc(text, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(text)
+	}
+	unit, ok := units[strings.ToLower(strings.TrimSpace(text[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, strings.TrimSpace(text[i:]))
+	}
+	if text[:i] == "" {
+		return 0, fmt.Errorf("invalid size %q: missing number", s)
+	}
+	if n, err := strconv.ParseInt(text[:i], 10, 64); err == nil {
+		if n > math.MaxInt64/int64(unit) {
+			return 0, fmt.Errorf("invalid size %q: too large", s)
+		}
+		return Size(n) * unit, nil
+	}
+	f, err := strconv.ParseFloat(text[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	bytes := f * float64(unit)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: too large", s)
+	}
+	return Size(bytes), nil
+}
+
+// UnmarshalText parses the size, see ParseSize.
+func (s *Size) UnmarshalText(text []byte) error {
+	size, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+// MarshalText formats the size, see Size.String.
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// String formats the size with the largest binary unit dividing it, e.g. "4MiB", or in bytes, e.g. "1500B".
+func (s Size) String() string {
+	for _, u := range []struct {
+		name string
+		size Size
+	}{{"TiB", Tebibyte}, {"GiB", Gibibyte}, {"MiB", Mebibyte}, {"KiB", Kibibyte}} {
+		if s != 0 && s%u.size == 0 {
+			return strconv.FormatInt(int64(s/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(s), 10) + "B"
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighumanize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Size
+	}{
+		{text: "0", expected: 0},
+		{text: "1500", expected: 1500},
+		{text: "1500B", expected: 1500},
+		{text: "512kB", expected: 512000},
+		{text: "512KB", expected: 512000},
+		{text: "4MiB", expected: 4 * 1024 * 1024},
+		{text: "4mib", expected: 4 * 1024 * 1024},
+		{text: " 2 GB ", expected: 2000000000},
+		{text: "1.5GiB", expected: 1536 * Mebibyte},
+		{text: "1TiB", expected: Tebibyte},
+		{text: "3TB", expected: 3 * Terabyte},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			size, err := ParseSize(tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
+func TestParseSizeError(t *testing.T) {
+	tests := []struct {
+		text        string
+		expectedErr string
+	}{
+		{text: "", expectedErr: `invalid size "": missing number`},
+		{text: "MiB", expectedErr: `invalid size "MiB": missing number`},
+		{text: "4XB", expectedErr: `invalid size "4XB": unknown unit "XB"`},
+		{text: "-4MiB", expectedErr: `invalid size "-4MiB": must not be negative`},
+		{text: "1.2.3MB", expectedErr: `invalid size "1.2.3MB": strconv.ParseFloat: parsing "1.2.3": invalid syntax`},
+		{text: "9000000TiB", expectedErr: `invalid size "9000000TiB": too large`},
+		{text: "9000000.5TiB", expectedErr: `invalid size "9000000.5TiB": too large`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := ParseSize(tt.text)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestSizeText(t *testing.T) {
+	var s Size
+	require.NoError(t, s.UnmarshalText([]byte("512KiB")))
+	assert.Equal(t, 512*Kibibyte, s)
+	assert.Error(t, s.UnmarshalText([]byte("512 bits")))
+	assert.Equal(t, 512*Kibibyte, s)
+
+	for size, expected := range map[Size]string{
+		0:               "0B",
+		1500:            "1500B",
+		512 * Kilobyte:  "500KiB",
+		4 * Mebibyte:    "4MiB",
+		1536 * Mebibyte: "1536MiB",
+		2 * Tebibyte:    "2TiB",
+	} {
+		text, err := size.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(text))
+		parsed, err := ParseSize(string(text))
+		require.NoError(t, err)
+		assert.Equal(t, size, parsed)
+	}
+}
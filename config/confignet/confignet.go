@@ -60,6 +60,17 @@ type DialerConfig struct {
 	// Timeout is the maximum amount of time a dial will wait for
 	// a connect to complete. The default is no timeout.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FallbackDelay is the amount of time to wait before spawning a connection
+	// using the other address family when the host resolves to both IPv4 and IPv6
+	// addresses, as described by RFC 6555 ("Happy Eyeballs"). The default is 300ms.
+	// A negative value disables the fallback until the first connection failed.
+	FallbackDelay time.Duration `mapstructure:"fallback_delay"`
+
+	// PreferIPv6 configures the dialer to connect to the IPv6 addresses of the host first,
+	// then to fall back to its IPv4 addresses. By default, the addresses are tried in the
+	// order returned by the resolver.
+	PreferIPv6 bool `mapstructure:"prefer_ipv6"`
 }
 
 // NewDefaultDialerConfig creates a new DialerConfig with any default values set
@@ -67,6 +78,23 @@ func NewDefaultDialerConfig() DialerConfig {
 	return DialerConfig{}
 }
 
+// DialContext connects to the address on the named network using the dialer options.
+// See net.Dialer.DialContext for a description of the network and address parameters.
+func (dc DialerConfig) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d := &net.Dialer{Timeout: dc.Timeout, FallbackDelay: dc.FallbackDelay}
+	if !dc.PreferIPv6 {
+		return d.DialContext(ctx, network, address)
+	}
+	switch TransportType(network) {
+	case TransportTypeTCP:
+		return dialPreferred(ctx, d, string(TransportTypeTCP6), string(TransportTypeTCP4), address)
+	case TransportTypeUDP:
+		return dialPreferred(ctx, d, string(TransportTypeUDP6), string(TransportTypeUDP4), address)
+	default:
+		return d.DialContext(ctx, network, address)
+	}
+}
+
 // AddrConfig represents a network endpoint address.
 type AddrConfig struct {
 	// Endpoint configures the address for this network connection.
@@ -82,6 +110,12 @@ type AddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer"`
+
+	// DualStack configures the listener to accept connections on both the IPv4 and IPv6
+	// addresses of the endpoint, for the "tcp" transport only. An empty or unspecified host
+	// listens on all the IPv4 and IPv6 interfaces, and a host name listens on all the
+	// addresses it resolves to, e.g. both 127.0.0.1 and ::1 for "localhost".
+	DualStack bool `mapstructure:"dual_stack"`
 }
 
 // NewDefaultAddrConfig creates a new AddrConfig with any default values set
@@ -93,17 +127,24 @@ func NewDefaultAddrConfig() AddrConfig {
 
 // Dial equivalent with net.Dialer's DialContext for this address.
 func (na *AddrConfig) Dial(ctx context.Context) (net.Conn, error) {
-	d := net.Dialer{Timeout: na.DialerConfig.Timeout}
-	return d.DialContext(ctx, string(na.Transport), na.Endpoint)
+	return na.DialerConfig.DialContext(ctx, string(na.Transport), na.Endpoint)
 }
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *AddrConfig) Listen(ctx context.Context) (net.Listener, error) {
+	if na.DualStack && na.Transport == TransportTypeTCP {
+		ln, err := listenDualStack(ctx, na.Endpoint)
+		return ln, newListenError(string(na.Transport), na.Endpoint, err)
+	}
 	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(na.Transport), na.Endpoint)
+	ln, err := lc.Listen(ctx, string(na.Transport), na.Endpoint)
+	return ln, newListenError(string(na.Transport), na.Endpoint, err)
 }
 
 func (na *AddrConfig) Validate() error {
+	if na.DualStack && na.Transport != TransportTypeTCP {
+		return fmt.Errorf("dual_stack is not supported by the transport type %q", na.Transport)
+	}
 	switch na.Transport {
 	case TransportTypeTCP,
 		TransportTypeTCP4,
@@ -134,6 +175,12 @@ type TCPAddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer"`
+
+	// DualStack configures the listener to accept connections on both the IPv4 and IPv6
+	// addresses of the endpoint. An empty or unspecified host listens on all the IPv4 and
+	// IPv6 interfaces, and a host name listens on all the addresses it resolves to,
+	// e.g. both 127.0.0.1 and ::1 for "localhost".
+	DualStack bool `mapstructure:"dual_stack"`
 }
 
 // NewDefaultTCPAddrConfig creates a new TCPAddrConfig with any default values set
@@ -145,12 +192,16 @@ func NewDefaultTCPAddrConfig() TCPAddrConfig {
 
 // Dial equivalent with net.Dialer's DialContext for this address.
 func (na *TCPAddrConfig) Dial(ctx context.Context) (net.Conn, error) {
-	d := net.Dialer{Timeout: na.DialerConfig.Timeout}
-	return d.DialContext(ctx, string(TransportTypeTCP), na.Endpoint)
+	return na.DialerConfig.DialContext(ctx, string(TransportTypeTCP), na.Endpoint)
 }
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *TCPAddrConfig) Listen(ctx context.Context) (net.Listener, error) {
+	if na.DualStack {
+		ln, err := listenDualStack(ctx, na.Endpoint)
+		return ln, newListenError(string(TransportTypeTCP), na.Endpoint, err)
+	}
 	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(TransportTypeTCP), na.Endpoint)
+	ln, err := lc.Listen(ctx, string(TransportTypeTCP), na.Endpoint)
+	return ln, newListenError(string(TransportTypeTCP), na.Endpoint, err)
 }
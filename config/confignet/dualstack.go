@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultFallbackDelay is the delay used by net.Dialer before spawning a fallback connection.
+const defaultFallbackDelay = 300 * time.Millisecond
+
+// dialPreferred connects to the address using the primary network first, and races a connection
+// using the fallback network once the fallback delay expired or the primary connection failed.
+func dialPreferred(ctx context.Context, d *net.Dialer, primary, fallback, address string) (net.Conn, error) {
+	delay := d.FallbackDelay
+	if delay == 0 {
+		delay = defaultFallbackDelay
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialResult struct {
+		conn    net.Conn
+		err     error
+		primary bool
+	}
+	results := make(chan dialResult)
+	returned := make(chan struct{})
+	defer close(returned)
+
+	dial := func(network string, isPrimary bool) {
+		conn, err := d.DialContext(ctx, network, address)
+		select {
+		case results <- dialResult{conn: conn, err: err, primary: isPrimary}:
+		case <-returned:
+			if conn != nil {
+				conn.Close()
+			}
+		}
+	}
+	go dial(primary, true)
+
+	var fallbackTimer <-chan time.Time
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		fallbackTimer = timer.C
+	}
+
+	var primaryErr error
+	pending, fallbackStarted := 1, false
+	startFallback := func() {
+		if !fallbackStarted {
+			fallbackStarted = true
+			pending++
+			go dial(fallback, false)
+		}
+	}
+	for pending > 0 {
+		select {
+		case <-fallbackTimer:
+			startFallback()
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.conn, nil
+			}
+			if res.primary {
+				primaryErr = res.err
+				startFallback()
+			}
+		}
+	}
+	return nil, primaryErr
+}
+
+// listenDualStack listens on all the IPv4 and IPv6 addresses of the endpoint. It only fails
+// if none of the addresses can be listened on, e.g. so that the same configuration can be used
+// on hosts where IPv4 or IPv6 is disabled.
+func listenDualStack(ctx context.Context, endpoint string) (net.Listener, error) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	switch ip := net.ParseIP(host); {
+	case host == "" || (ip != nil && ip.IsUnspecified()):
+		ips = []net.IP{net.IPv4zero, net.IPv6unspecified}
+	case ip != nil:
+		ips = []net.IP{ip}
+	default:
+		addrs, lerr := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if lerr != nil {
+			return nil, lerr
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	lc := net.ListenConfig{}
+	var listeners []net.Listener
+	var errs error
+	for _, ip := range ips {
+		network := string(TransportTypeTCP6)
+		if ip.To4() != nil {
+			network = string(TransportTypeTCP4)
+		}
+		l, lerr := lc.Listen(ctx, network, net.JoinHostPort(ip.String(), port))
+		if lerr != nil {
+			errs = errors.Join(errs, lerr)
+			continue
+		}
+		if len(listeners) == 0 {
+			// A random port must be the same for all the addresses.
+			port = strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+		}
+		listeners = append(listeners, l)
+	}
+
+	switch len(listeners) {
+	case 0:
+		return nil, fmt.Errorf("failed to listen on %q: %w", endpoint, errs)
+	case 1:
+		return listeners[0], nil
+	default:
+		return newMultiListener(listeners), nil
+	}
+}
+
+// multiListener is a net.Listener accepting the connections of several listeners.
+type multiListener struct {
+	listeners []net.Listener
+	accepted  chan acceptResult
+	closed    chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+func newMultiListener(listeners []net.Listener) *multiListener {
+	ml := &multiListener{
+		listeners: listeners,
+		accepted:  make(chan acceptResult),
+		closed:    make(chan struct{}),
+	}
+	for _, l := range listeners {
+		ml.wg.Add(1)
+		go ml.acceptLoop(l)
+	}
+	return ml
+}
+
+func (ml *multiListener) acceptLoop(l net.Listener) {
+	defer ml.wg.Done()
+	for {
+		conn, err := l.Accept()
+		select {
+		case ml.accepted <- acceptResult{conn: conn, err: err}:
+		case <-ml.closed:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		// Errors are forwarded to the caller, which decides whether to retry,
+		// until the listener gets closed.
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+// Accept waits for and returns the next connection accepted by any of the listeners.
+func (ml *multiListener) Accept() (net.Conn, error) {
+	select {
+	case res := <-ml.accepted:
+		return res.conn, res.err
+	case <-ml.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close closes all the listeners.
+func (ml *multiListener) Close() error {
+	var errs error
+	ml.closeOnce.Do(func() {
+		close(ml.closed)
+		for _, l := range ml.listeners {
+			errs = errors.Join(errs, l.Close())
+		}
+		ml.wg.Wait()
+	})
+	return errs
+}
+
+// Addr returns the address of the first listener.
+func (ml *multiListener) Addr() net.Addr {
+	return ml.listeners[0].Addr()
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listenLoopback(t *testing.T, network, address string) net.Listener {
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		t.Skipf("%s loopback is not available: %v", network, err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	return ln
+}
+
+func TestDialerConfigPreferIPv6(t *testing.T) {
+	ln := listenLoopback(t, "tcp6", "[::1]:0")
+
+	dc := DialerConfig{PreferIPv6: true}
+	conn, err := dc.DialContext(context.Background(), "tcp", ln.Addr().String())
+	require.NoError(t, err)
+	assert.Equal(t, "::1", conn.RemoteAddr().(*net.TCPAddr).IP.String())
+	assert.NoError(t, conn.Close())
+}
+
+func TestDialerConfigPreferIPv6Fallback(t *testing.T) {
+	ln := listenLoopback(t, "tcp4", "127.0.0.1:0")
+
+	for _, delay := range []time.Duration{0, time.Hour, -1} {
+		dc := DialerConfig{PreferIPv6: true, FallbackDelay: delay}
+		conn, err := dc.DialContext(context.Background(), "tcp", ln.Addr().String())
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1", conn.RemoteAddr().(*net.TCPAddr).IP.String())
+		assert.NoError(t, conn.Close())
+	}
+}
+
+func TestDialerConfigPreferIPv6Error(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	dc := DialerConfig{PreferIPv6: true}
+	_, err = dc.DialContext(context.Background(), "tcp", addr)
+	var addrErr *net.AddrError
+	// The error of the preferred network is returned.
+	assert.True(t, errors.As(err, &addrErr), "unexpected error %v", err)
+}
+
+func TestListenDualStack(t *testing.T) {
+	probe, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 loopback is not available: %v", err)
+	}
+	require.NoError(t, probe.Close())
+
+	nas := &TCPAddrConfig{Endpoint: ":0", DualStack: true}
+	ln, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+
+	accepted := make(chan net.Addr, 2)
+	go func() {
+		for {
+			conn, errGo := ln.Accept()
+			if errGo != nil {
+				return
+			}
+			accepted <- conn.LocalAddr()
+			_ = conn.Close()
+		}
+	}()
+
+	for _, host := range []string{"127.0.0.1", "::1"} {
+		nac := &TCPAddrConfig{Endpoint: net.JoinHostPort(host, port)}
+		conn, errDial := nac.Dial(context.Background())
+		require.NoError(t, errDial)
+		assert.Equal(t, host, (<-accepted).(*net.TCPAddr).IP.String())
+		assert.NoError(t, conn.Close())
+	}
+
+	assert.NoError(t, ln.Close())
+	_, err = ln.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+func TestListenDualStackLiteral(t *testing.T) {
+	nas := &AddrConfig{Endpoint: "127.0.0.1:0", Transport: TransportTypeTCP, DualStack: true}
+	ln, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", ln.Addr().(*net.TCPAddr).IP.String())
+	assert.NoError(t, ln.Close())
+}
+
+func TestAddrConfigValidateDualStack(t *testing.T) {
+	na := &AddrConfig{Transport: TransportTypeTCP, DualStack: true}
+	assert.NoError(t, na.Validate())
+
+	na = &AddrConfig{Transport: TransportTypeUnix, DualStack: true}
+	assert.EqualError(t, na.Validate(), `dual_stack is not supported by the transport type "unix"`)
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// privilegedPortsEnd is the end of the range of the ports that can only be listened on with elevated privileges.
+const privilegedPortsEnd = 1024
+
+// ListenError is the error returned by Listen when the address cannot be listened on.
+// It gives the address, and a hint to fix the most common errors, e.g. so that
+// the status of the receiver failing to start explains how to fix the configuration.
+type ListenError struct {
+	// Transport is the transport of the listener, e.g. "tcp".
+	Transport string
+	// Address is the endpoint that could not be listened on.
+	Address string
+	// Err is the error returned when listening.
+	Err error
+}
+
+func newListenError(transport string, address string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ListenError{Transport: transport, Address: address, Err: err}
+}
+
+func (e *ListenError) Error() string {
+	if hint := e.Hint(); hint != "" {
+		return e.Err.Error() + " (" + hint + ")"
+	}
+	return e.Err.Error()
+}
+
+func (e *ListenError) Unwrap() error {
+	return e.Err
+}
+
+// Hint returns the suggested remediation of the error, or an empty string if there is none.
+func (e *ListenError) Hint() string {
+	switch {
+	case errors.Is(e.Err, errAddressInUse):
+		return fmt.Sprintf("the address %s is already in use by another process or another component, "+
+			"change the endpoint or stop the other listener", e.Address)
+	case errors.Is(e.Err, errPermissionDenied):
+		if port := e.port(); port > 0 && port < privilegedPortsEnd {
+			return fmt.Sprintf("listening on the port %d requires elevated privileges, use a port above %d "+
+				"or grant the CAP_NET_BIND_SERVICE capability to the collector", port, privilegedPortsEnd-1)
+		}
+		return fmt.Sprintf("the collector is not permitted to listen on %s", e.Address)
+	default:
+		return ""
+	}
+}
+
+// port returns the port of the address, or 0 if it has no numeric port.
+func (e *ListenError) port() int {
+	_, portStr, err := net.SplitHostPort(e.Address)
+	if err != nil {
+		return 0
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return 0
+	}
+	return port
+}
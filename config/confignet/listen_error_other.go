@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import "syscall"
+
+var (
+	// errAddressInUse is returned when listening on an address already in use.
+	errAddressInUse error = syscall.EADDRINUSE
+	// errPermissionDenied is returned when listening on an address requiring privileges.
+	errPermissionDenied error = syscall.EACCES
+)
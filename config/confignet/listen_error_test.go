@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenErrorAddressInUse(t *testing.T) {
+	ln, err := (&TCPAddrConfig{Endpoint: "localhost:0"}).Listen(context.Background())
+	require.NoError(t, err)
+	defer ln.Close()
+
+	addr := ln.Addr().String()
+	for _, listen := range []func() error{
+		func() error {
+			_, lerr := (&TCPAddrConfig{Endpoint: addr}).Listen(context.Background())
+			return lerr
+		},
+		func() error {
+			_, lerr := (&AddrConfig{Endpoint: addr, Transport: TransportTypeTCP}).Listen(context.Background())
+			return lerr
+		},
+	} {
+		err = listen()
+		var listenErr *ListenError
+		require.ErrorAs(t, err, &listenErr)
+		assert.Equal(t, "tcp", listenErr.Transport)
+		assert.Equal(t, addr, listenErr.Address)
+		assert.ErrorIs(t, err, errAddressInUse)
+		assert.Contains(t, listenErr.Hint(), "already in use")
+		assert.Contains(t, err.Error(), listenErr.Hint())
+	}
+}
+
+func TestListenErrorHint(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		err     error
+		hint    string
+	}{
+		{
+			name:    "privileged port",
+			address: "0.0.0.0:443",
+			err:     errPermissionDenied,
+			hint:    "listening on the port 443 requires elevated privileges, use a port above 1023 or grant the CAP_NET_BIND_SERVICE capability to the collector",
+		},
+		{
+			name:    "permission denied",
+			address: "/var/run/otel.sock",
+			err:     errPermissionDenied,
+			hint:    "the collector is not permitted to listen on /var/run/otel.sock",
+		},
+		{
+			name:    "other error",
+			address: "localhost:4317",
+			err:     errors.New("other"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &ListenError{Transport: "tcp", Address: tt.address, Err: tt.err}
+			assert.Equal(t, tt.hint, err.Hint())
+			if tt.hint == "" {
+				assert.Equal(t, tt.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestListenErrorNil(t *testing.T) {
+	assert.NoError(t, newListenError("tcp", "localhost:4317", nil))
+}
@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import "syscall"
+
+var (
+	// errAddressInUse is returned when listening on an address already in use (WSAEADDRINUSE).
+	errAddressInUse error = syscall.Errno(10048)
+	// errPermissionDenied is returned when listening on an address requiring privileges (WSAEACCES).
+	errPermissionDenied error = syscall.Errno(10013)
+)
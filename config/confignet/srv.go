@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// SRVScheme is the scheme of the endpoints discovered using DNS SRV records,
+// e.g. "dns+srv:///_otlp._tcp.example.com".
+const SRVScheme = "dns+srv"
+
+// SRVName returns the name of the SRV records of the endpoint, and whether the endpoint
+// uses the SRVScheme. Both the "dns+srv:///name" and "dns+srv://name" forms are accepted.
+func SRVName(endpoint string) (string, bool) {
+	rest, ok := strings.CutPrefix(endpoint, SRVScheme+"://")
+	if !ok {
+		return "", false
+	}
+	rest = strings.TrimPrefix(rest, "/")
+	name, _, _ := strings.Cut(rest, "/")
+	return name, true
+}
+
+// LookupSRVEndpoints resolves the SRV records of the given name, and returns the "host:port"
+// endpoints of the targets, sorted by priority and randomized by weight within a priority.
+func LookupSRVEndpoints(ctx context.Context, name string) ([]string, error) {
+	_, srvs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	if len(srvs) == 0 {
+		return nil, fmt.Errorf("no SRV records found for %q", name)
+	}
+	endpoints := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		endpoints = append(endpoints, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+	}
+	return endpoints, nil
+}
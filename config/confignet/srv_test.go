@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSRVName(t *testing.T) {
+	for _, tt := range []struct {
+		endpoint string
+		name     string
+		ok       bool
+	}{
+		{endpoint: "dns+srv:///_otlp._tcp.example.com", name: "_otlp._tcp.example.com", ok: true},
+		{endpoint: "dns+srv://_otlp._tcp.example.com", name: "_otlp._tcp.example.com", ok: true},
+		{endpoint: "dns+srv://_otlp._tcp.example.com/v1/traces", name: "_otlp._tcp.example.com", ok: true},
+		{endpoint: "dns:///example.com:4317"},
+		{endpoint: "example.com:4317"},
+	} {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			name, ok := SRVName(tt.endpoint)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
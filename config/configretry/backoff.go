@@ -8,8 +8,13 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+
+	"go.opentelemetry.io/collector/config/confighumanize"
 )
 
+// intervalBounds are the bounds of the intervals of the retries.
+var intervalBounds = confighumanize.DurationBounds{Min: time.Millisecond}
+
 // NewDefaultBackOffConfig returns the default settings for RetryConfig.
 func NewDefaultBackOffConfig() BackOffConfig {
 	return BackOffConfig{
@@ -19,6 +24,7 @@ func NewDefaultBackOffConfig() BackOffConfig {
 		Multiplier:          backoff.DefaultMultiplier,
 		MaxInterval:         30 * time.Second,
 		MaxElapsedTime:      5 * time.Minute,
+		MaxThrottleInterval: 5 * time.Minute,
 	}
 }
 
@@ -40,14 +46,17 @@ type BackOffConfig struct {
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
 	// Once this value is reached, the data is discarded. If set to 0, the retries are never stopped.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// MaxThrottleInterval is the upper bound on the delay requested by the backend before retrying, e.g. with
+	// a Retry-After header, so that an absurd value cannot stall the exports. If set to 0, the delay is not capped.
+	MaxThrottleInterval time.Duration `mapstructure:"max_throttle_interval"`
 }
 
 func (bs *BackOffConfig) Validate() error {
 	if !bs.Enabled {
 		return nil
 	}
-	if bs.InitialInterval < 0 {
-		return errors.New("'initial_interval' must be non-negative")
+	if err := confighumanize.ValidateDuration("initial_interval", bs.InitialInterval, intervalBounds); err != nil {
+		return err
 	}
 	if bs.RandomizationFactor < 0 || bs.RandomizationFactor > 1 {
 		return errors.New("'randomization_factor' must be within [0, 1]")
@@ -55,11 +64,14 @@ func (bs *BackOffConfig) Validate() error {
 	if bs.Multiplier < 0 {
 		return errors.New("'multiplier' must be non-negative")
 	}
-	if bs.MaxInterval < 0 {
-		return errors.New("'max_interval' must be non-negative")
+	if err := confighumanize.ValidateDuration("max_interval", bs.MaxInterval, intervalBounds); err != nil {
+		return err
+	}
+	if err := confighumanize.ValidateDuration("max_elapsed_time", bs.MaxElapsedTime, intervalBounds); err != nil {
+		return err
 	}
-	if bs.MaxElapsedTime < 0 {
-		return errors.New("'max_elapsed_time' must be non-negative")
+	if err := confighumanize.ValidateDuration("max_throttle_interval", bs.MaxThrottleInterval, intervalBounds); err != nil {
+		return err
 	}
 	if bs.MaxElapsedTime > 0 {
 		if bs.MaxElapsedTime < bs.InitialInterval {
@@ -21,6 +21,7 @@ func TestNewDefaultBackOffSettings(t *testing.T) {
 			Multiplier:          1.5,
 			MaxInterval:         30 * time.Second,
 			MaxElapsedTime:      5 * time.Minute,
+			MaxThrottleInterval: 5 * time.Minute,
 		}, cfg)
 }
 
@@ -66,20 +67,37 @@ func TestInvalidMaxElapsedTime(t *testing.T) {
 	assert.NoError(t, cfg.Validate())
 	cfg.MaxElapsedTime = -1
 	assert.Error(t, cfg.Validate())
-	cfg.MaxElapsedTime = 60
-	// MaxElapsedTime is 60, InitialInterval is 5s, so it should be invalid
+	cfg.MaxElapsedTime = 60 * time.Millisecond
+	// MaxElapsedTime is 60ms, InitialInterval is 5s, so it should be invalid
 	assert.Error(t, cfg.Validate())
 	cfg.InitialInterval = 0
-	// MaxElapsedTime is 60, MaxInterval is 30s, so it should be invalid
+	// MaxElapsedTime is 60ms, MaxInterval is 30s, so it should be invalid
 	assert.Error(t, cfg.Validate())
 	cfg.MaxInterval = 0
 	assert.NoError(t, cfg.Validate())
-	cfg.InitialInterval = 50
+	cfg.InitialInterval = 50 * time.Millisecond
 	// MaxElapsedTime is 0, so it should be valid
 	cfg.MaxElapsedTime = 0
 	assert.NoError(t, cfg.Validate())
 }
 
+func TestInvalidMaxThrottleInterval(t *testing.T) {
+	cfg := NewDefaultBackOffConfig()
+	assert.NoError(t, cfg.Validate())
+	cfg.MaxThrottleInterval = -1
+	assert.Error(t, cfg.Validate())
+	// MaxThrottleInterval is 0, so the delays requested by the backends are not capped.
+	cfg.MaxThrottleInterval = 0
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestIntervalWithoutUnit(t *testing.T) {
+	cfg := NewDefaultBackOffConfig()
+	cfg.InitialInterval = 5
+	assert.EqualError(t, cfg.Validate(),
+		`'initial_interval' must be at least 1ms, got 5ns: the durations without unit are in nanoseconds, use e.g. "5s"`)
+}
+
 func TestDisabledWithInvalidValues(t *testing.T) {
 	cfg := BackOffConfig{
 		Enabled:             false,
@@ -88,6 +106,7 @@ func TestDisabledWithInvalidValues(t *testing.T) {
 		Multiplier:          0,
 		MaxInterval:         -1,
 		MaxElapsedTime:      -1,
+		MaxThrottleInterval: -1,
 	}
 	assert.NoError(t, cfg.Validate())
 }
@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/cenkalti/backoff/v4 v4.3.0
 	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0
 	go.uber.org/goleak v1.3.0
 )
 
@@ -16,3 +17,5 @@ require (
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace go.opentelemetry.io/collector/config/confighumanize => ../confighumanize
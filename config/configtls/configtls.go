@@ -99,6 +99,14 @@ type ClientConfig struct {
 	// This sets the ServerName in the TLSConfig. Please refer to
 	// https://godoc.org/crypto/tls#Config for more information. (optional)
 	ServerName string `mapstructure:"server_name_override"`
+	// Path to a file of PEM or DER encoded certificate revocation lists (CRL). The connections
+	// to servers whose certificate chain is listed in the CRL of its issuer are rejected. (optional)
+	CRLFile string `mapstructure:"crl_file"`
+	// OCSPStapling enables the verification of the OCSP response stapled by the server. When
+	// set to "verify", the connections to servers whose certificate is revoked are rejected.
+	// When set to "require", the connections to servers not stapling a response, or whose
+	// certificate is unknown to the OCSP responder, are rejected too. (optional)
+	OCSPStapling string `mapstructure:"ocsp_stapling"`
 }
 
 // NewDefaultClientConfig creates a new TLSClientSetting with any default values set.
@@ -376,6 +384,14 @@ func (c Config) loadCert(caPath string) (*x509.CertPool, error) {
 	return certPool, nil
 }
 
+// Validate checks if the client TLS configuration is valid.
+func (c ClientConfig) Validate() error {
+	if err := c.Config.Validate(); err != nil {
+		return err
+	}
+	return validateOCSPStapling(c.OCSPStapling)
+}
+
 // LoadTLSConfigContext loads the TLS configuration.
 //
 // Deprecated: [v0.99.0] Use LoadTLSConfig instead.
@@ -395,6 +411,19 @@ func (c ClientConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
 	}
 	tlsCfg.ServerName = c.ServerName
 	tlsCfg.InsecureSkipVerify = c.InsecureSkipVerify
+
+	if c.CRLFile != "" || c.OCSPStapling != "" {
+		if err = validateOCSPStapling(c.OCSPStapling); err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		rc := &revocationChecker{ocspStapling: c.OCSPStapling, now: time.Now}
+		if c.CRLFile != "" {
+			if rc.crls, err = loadCRLs(c.CRLFile); err != nil {
+				return nil, fmt.Errorf("failed to load TLS config: %w", err)
+			}
+		}
+		tlsCfg.VerifyConnection = rc.verifyConnection
+	}
 	return tlsCfg, nil
 }
 
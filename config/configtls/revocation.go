@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// ocspStaplingVerify verifies the OCSP response stapled by the server, if any.
+	ocspStaplingVerify = "verify"
+	// ocspStaplingRequire verifies the OCSP response stapled by the server, and rejects the
+	// servers not stapling one.
+	ocspStaplingRequire = "require"
+)
+
+func validateOCSPStapling(mode string) error {
+	switch mode {
+	case "", ocspStaplingVerify, ocspStaplingRequire:
+		return nil
+	}
+	return fmt.Errorf("invalid ocsp_stapling %q, must be %q or %q", mode, ocspStaplingVerify, ocspStaplingRequire)
+}
+
+// revocationChecker rejects the connections to servers whose certificate chain is revoked,
+// according to the CRLs or to the OCSP response stapled by the server.
+type revocationChecker struct {
+	crls         []*x509.RevocationList
+	ocspStapling string
+	now          func() time.Time
+}
+
+// loadCRLs loads the PEM or DER encoded certificate revocation lists from the file.
+func loadCRLs(path string) ([]*x509.RevocationList, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CRL %s: %w", path, err)
+	}
+
+	var ders [][]byte
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type == "X509 CRL" {
+				ders = append(ders, block.Bytes)
+			}
+		}
+	} else {
+		ders = append(ders, data)
+	}
+	if len(ders) == 0 {
+		return nil, fmt.Errorf("failed to parse CRL %s: no X509 CRL PEM block", path)
+	}
+
+	crls := make([]*x509.RevocationList, 0, len(ders))
+	for _, der := range ders {
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CRL %s: %w", path, err)
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
+
+// verifyConnection is used as tls.Config.VerifyConnection, it is called after the certificate
+// chain of the server has been verified.
+func (rc *revocationChecker) verifyConnection(cs tls.ConnectionState) error {
+	// There are no verified chains when the verification is skipped.
+	if len(cs.VerifiedChains) == 0 {
+		return nil
+	}
+
+	for _, chain := range cs.VerifiedChains {
+		for i := 0; i+1 < len(chain); i++ {
+			if err := rc.checkCRLs(chain[i], chain[i+1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rc.ocspStapling == "" {
+		return nil
+	}
+	if len(cs.OCSPResponse) == 0 {
+		if rc.ocspStapling == ocspStaplingRequire {
+			return errors.New("the server did not staple an OCSP response")
+		}
+		return nil
+	}
+	chain := cs.VerifiedChains[0]
+	issuer := chain[0]
+	if len(chain) > 1 {
+		issuer = chain[1]
+	}
+	return rc.checkOCSPResponse(cs.OCSPResponse, chain[0], issuer)
+}
+
+// checkCRLs returns an error if the certificate is listed in a CRL of its issuer.
+func (rc *revocationChecker) checkCRLs(cert, issuer *x509.Certificate) error {
+	for _, crl := range rc.crls {
+		if !bytes.Equal(crl.RawIssuer, cert.RawIssuer) || crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %q was revoked at %v", cert.Subject, entry.RevocationTime)
+			}
+		}
+	}
+	return nil
+}
+
+// The structures of the OCSP responses, see RFC 6960.
+
+var oidOCSPBasicResponse = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+
+type ocspResponse struct {
+	Status   asn1.Enumerated
+	Response ocspResponseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type ocspBasicResponse struct {
+	TBSResponseData    asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+	Certificates       []asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseData struct {
+	Version            int `asn1:"optional,default:0,explicit,tag:0"`
+	RawResponderID     asn1.RawValue
+	ProducedAt         time.Time `asn1:"generalized"`
+	Responses          []ocspSingleResponse
+	ResponseExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspSingleResponse struct {
+	CertID           ocspCertID
+	Good             asn1.Flag        `asn1:"tag:0,optional"`
+	Revoked          ocspRevokedInfo  `asn1:"tag:1,optional"`
+	Unknown          asn1.Flag        `asn1:"tag:2,optional"`
+	ThisUpdate       time.Time        `asn1:"generalized"`
+	NextUpdate       time.Time        `asn1:"generalized,explicit,tag:0,optional"`
+	SingleExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspRevokedInfo struct {
+	RevocationTime time.Time       `asn1:"generalized"`
+	Reason         asn1.Enumerated `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+var ocspHashes = map[string]crypto.Hash{
+	"1.3.14.3.2.26":          crypto.SHA1,
+	"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+	"2.16.840.1.101.3.4.2.2": crypto.SHA384,
+	"2.16.840.1.101.3.4.2.3": crypto.SHA512,
+}
+
+var ocspSignatureAlgorithms = map[string]x509.SignatureAlgorithm{
+	"1.2.840.113549.1.1.5":  x509.SHA1WithRSA,
+	"1.2.840.113549.1.1.11": x509.SHA256WithRSA,
+	"1.2.840.113549.1.1.12": x509.SHA384WithRSA,
+	"1.2.840.113549.1.1.13": x509.SHA512WithRSA,
+	"1.2.840.10045.4.3.2":   x509.ECDSAWithSHA256,
+	"1.2.840.10045.4.3.3":   x509.ECDSAWithSHA384,
+	"1.2.840.10045.4.3.4":   x509.ECDSAWithSHA512,
+	"1.3.101.112":           x509.PureEd25519,
+}
+
+// checkOCSPResponse returns an error if the OCSP response is invalid, or if it does not report
+// the certificate as good.
+func (rc *revocationChecker) checkOCSPResponse(der []byte, cert, issuer *x509.Certificate) error {
+	var resp ocspResponse
+	if _, err := asn1.Unmarshal(der, &resp); err != nil {
+		return fmt.Errorf("failed to parse the OCSP response: %w", err)
+	}
+	if resp.Status != 0 {
+		return fmt.Errorf("unsuccessful OCSP response status %d", resp.Status)
+	}
+	if !resp.Response.ResponseType.Equal(oidOCSPBasicResponse) {
+		return fmt.Errorf("unsupported OCSP response type %v", resp.Response.ResponseType)
+	}
+	var basic ocspBasicResponse
+	if _, err := asn1.Unmarshal(resp.Response.Response, &basic); err != nil {
+		return fmt.Errorf("failed to parse the OCSP response: %w", err)
+	}
+	var data ocspResponseData
+	if _, err := asn1.Unmarshal(basic.TBSResponseData.FullBytes, &data); err != nil {
+		return fmt.Errorf("failed to parse the OCSP response: %w", err)
+	}
+
+	if err := rc.checkOCSPSignature(&basic, issuer); err != nil {
+		return err
+	}
+
+	for _, single := range data.Responses {
+		match, err := single.CertID.matches(cert, issuer)
+		if err != nil {
+			return err
+		}
+		if !match {
+			continue
+		}
+
+		now := rc.now()
+		if !single.NextUpdate.IsZero() && single.NextUpdate.Before(now) {
+			return fmt.Errorf("the OCSP response expired at %v", single.NextUpdate)
+		}
+		switch {
+		case bool(single.Good):
+			return nil
+		case bool(single.Unknown):
+			if rc.ocspStapling == ocspStaplingRequire {
+				return fmt.Errorf("the OCSP responder does not know the certificate %q", cert.Subject)
+			}
+			return nil
+		default:
+			return fmt.Errorf("certificate %q was revoked at %v", cert.Subject, single.Revoked.RevocationTime)
+		}
+	}
+	return fmt.Errorf("the OCSP response does not cover the certificate %q", cert.Subject)
+}
+
+// checkOCSPSignature verifies that the OCSP response is signed by the issuer, or by a responder
+// certificate issued by the issuer for this purpose.
+func (rc *revocationChecker) checkOCSPSignature(basic *ocspBasicResponse, issuer *x509.Certificate) error {
+	algo, ok := ocspSignatureAlgorithms[basic.SignatureAlgorithm.Algorithm.String()]
+	if !ok {
+		return fmt.Errorf("unsupported OCSP response signature algorithm %v", basic.SignatureAlgorithm.Algorithm)
+	}
+	signed, signature := basic.TBSResponseData.FullBytes, basic.Signature.RightAlign()
+	if issuer.CheckSignature(algo, signed, signature) == nil {
+		return nil
+	}
+
+	now := rc.now()
+	for _, raw := range basic.Certificates {
+		responder, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			continue
+		}
+		if now.Before(responder.NotBefore) || now.After(responder.NotAfter) ||
+			!hasExtKeyUsage(responder, x509.ExtKeyUsageOCSPSigning) ||
+			responder.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		if responder.CheckSignature(algo, signed, signature) == nil {
+			return nil
+		}
+	}
+	return errors.New("the OCSP response is not signed by the issuer of the certificate")
+}
+
+// matches returns whether the CertID identifies the certificate.
+func (id ocspCertID) matches(cert, issuer *x509.Certificate) (bool, error) {
+	if id.SerialNumber == nil || id.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		return false, nil
+	}
+	hash, ok := ocspHashes[id.HashAlgorithm.Algorithm.String()]
+	if !ok {
+		return false, fmt.Errorf("unsupported OCSP hash algorithm %v", id.HashAlgorithm.Algorithm)
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false, fmt.Errorf("failed to parse the public key of the issuer: %w", err)
+	}
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+	return bytes.Equal(nameHash, id.NameHash) && bytes.Equal(keyHash, id.IssuerKeyHash), nil
+}
+
+func hasExtKeyUsage(cert *x509.Certificate, usage x509.ExtKeyUsage) bool {
+	for _, u := range cert.ExtKeyUsage {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,379 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is the usual hash of the OCSP CertIDs.
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCert(t *testing.T, serial int64, name string, issuer *testCert, tmpl *x509.Certificate) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if tmpl == nil {
+		tmpl = &x509.Certificate{}
+	}
+	tmpl.SerialNumber = big.NewInt(serial)
+	tmpl.Subject = pkix.Name{CommonName: name}
+	tmpl.NotBefore = testNow.Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	parent, signer := tmpl, crypto.Signer(key)
+	if issuer == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	} else {
+		parent, signer = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func newTestCRL(t *testing.T, issuer *testCert, revoked ...*big.Int) []byte {
+	tmpl := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: testNow.Add(-time.Hour),
+		NextUpdate: testNow.Add(time.Hour),
+	}
+	for _, serial := range revoked {
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries,
+			x509.RevocationListEntry{SerialNumber: serial, RevocationTime: testNow.Add(-time.Minute)})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, issuer.cert, issuer.key)
+	require.NoError(t, err)
+	return der
+}
+
+type ocspStatus int
+
+const (
+	ocspGood ocspStatus = iota
+	ocspRevoked
+	ocspUnknown
+)
+
+// newTestOCSPResponse creates an OCSP response for the certificate, signed by the signer.
+func newTestOCSPResponse(t *testing.T, cert *x509.Certificate, issuer *x509.Certificate, signer *testCert, status ocspStatus, nextUpdate time.Time) []byte {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	_, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki)
+	require.NoError(t, err)
+	nameHash := sha1.Sum(issuer.RawSubject)          // #nosec G401
+	keyHash := sha1.Sum(spki.PublicKey.RightAlign()) // #nosec G401
+
+	single := ocspSingleResponse{
+		CertID: ocspCertID{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}, Parameters: asn1.NullRawValue},
+			NameHash:      nameHash[:],
+			IssuerKeyHash: keyHash[:],
+			SerialNumber:  cert.SerialNumber,
+		},
+		ThisUpdate: testNow.Add(-time.Minute),
+		NextUpdate: nextUpdate,
+	}
+	switch status {
+	case ocspGood:
+		single.Good = true
+	case ocspRevoked:
+		single.Revoked = ocspRevokedInfo{RevocationTime: testNow.Add(-time.Minute)}
+	case ocspUnknown:
+		single.Unknown = true
+	}
+	responderID, err := asn1.Marshal(keyHash[:])
+	require.NoError(t, err)
+	tbs, err := asn1.Marshal(ocspResponseData{
+		RawResponderID: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: responderID},
+		ProducedAt:     testNow,
+		Responses:      []ocspSingleResponse{single},
+	})
+	require.NoError(t, err)
+
+	digest := crypto.SHA256.New()
+	digest.Write(tbs)
+	signature, err := signer.key.Sign(rand.Reader, digest.Sum(nil), crypto.SHA256)
+	require.NoError(t, err)
+	basic := ocspBasicResponse{
+		TBSResponseData:    asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+		Signature:          asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	}
+	if signer.cert != issuer {
+		basic.Certificates = []asn1.RawValue{{FullBytes: signer.cert.Raw}}
+	}
+	basicDER, err := asn1.Marshal(basic)
+	require.NoError(t, err)
+	der, err := asn1.Marshal(ocspResponse{
+		Response: ocspResponseBytes{ResponseType: oidOCSPBasicResponse, Response: basicDER},
+	})
+	require.NoError(t, err)
+	return der
+}
+
+func TestLoadCRLs(t *testing.T) {
+	ca := newTestCert(t, 1, "ca", nil, nil)
+	other := newTestCert(t, 2, "other", nil, nil)
+	crl1 := newTestCRL(t, ca, big.NewInt(10))
+	crl2 := newTestCRL(t, other)
+	dir := t.TempDir()
+
+	pemPath := filepath.Join(dir, "crls.pem")
+	pemData := append(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl1}),
+		pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl2})...)
+	require.NoError(t, os.WriteFile(pemPath, pemData, 0600))
+	crls, err := loadCRLs(pemPath)
+	require.NoError(t, err)
+	require.Len(t, crls, 2)
+	assert.Equal(t, ca.cert.RawSubject, crls[0].RawIssuer)
+	assert.Equal(t, other.cert.RawSubject, crls[1].RawIssuer)
+
+	derPath := filepath.Join(dir, "crl.der")
+	require.NoError(t, os.WriteFile(derPath, crl1, 0600))
+	crls, err = loadCRLs(derPath)
+	require.NoError(t, err)
+	require.Len(t, crls, 1)
+
+	_, err = loadCRLs(filepath.Join(dir, "missing.pem"))
+	assert.ErrorContains(t, err, "failed to load CRL")
+
+	certPath := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	_, err = loadCRLs(certPath)
+	assert.ErrorContains(t, err, "no X509 CRL PEM block")
+
+	invalidPath := filepath.Join(dir, "invalid.der")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("invalid"), 0600))
+	_, err = loadCRLs(invalidPath)
+	assert.ErrorContains(t, err, "failed to parse CRL")
+}
+
+func TestRevocationCheckerCRL(t *testing.T) {
+	ca := newTestCert(t, 1, "ca", nil, nil)
+	leaf := newTestCert(t, 10, "leaf", ca, nil)
+	revokedLeaf := newTestCert(t, 11, "revoked", ca, nil)
+	// A CA with the same name, which must not be trusted to revoke the certificates.
+	impostor := newTestCert(t, 1, "ca", nil, nil)
+
+	crl, err := x509.ParseRevocationList(newTestCRL(t, ca, big.NewInt(11)))
+	require.NoError(t, err)
+	impostorCRL, err := x509.ParseRevocationList(newTestCRL(t, impostor, big.NewInt(10)))
+	require.NoError(t, err)
+	rc := &revocationChecker{crls: []*x509.RevocationList{crl, impostorCRL}, now: func() time.Time { return testNow }}
+
+	assert.NoError(t, rc.verifyConnection(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf.cert, ca.cert}}}))
+	assert.ErrorContains(t, rc.verifyConnection(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{revokedLeaf.cert, ca.cert}}}),
+		`certificate "CN=revoked" was revoked`)
+	// Nothing to check when the verification is skipped.
+	assert.NoError(t, rc.verifyConnection(tls.ConnectionState{}))
+}
+
+func TestRevocationCheckerOCSP(t *testing.T) {
+	ca := newTestCert(t, 1, "ca", nil, nil)
+	leaf := newTestCert(t, 10, "leaf", ca, nil)
+	otherLeaf := newTestCert(t, 11, "other", ca, nil)
+	responder := newTestCert(t, 20, "responder", ca, &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}})
+	notResponder := newTestCert(t, 21, "not-responder", ca, nil)
+	otherCA := newTestCert(t, 2, "other-ca", nil, nil)
+	chains := [][]*x509.Certificate{{leaf.cert, ca.cert}}
+	nextUpdate := testNow.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		ocspStapling string
+		response     []byte
+		expectedErr  string
+	}{
+		{
+			name:         "good",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspGood, nextUpdate),
+		},
+		{
+			name:         "good_without_next_update",
+			ocspStapling: ocspStaplingRequire,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspGood, time.Time{}),
+		},
+		{
+			name:         "revoked",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspRevoked, nextUpdate),
+			expectedErr:  `certificate "CN=leaf" was revoked`,
+		},
+		{
+			name:         "unknown",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspUnknown, nextUpdate),
+		},
+		{
+			name:         "unknown_required",
+			ocspStapling: ocspStaplingRequire,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspUnknown, nextUpdate),
+			expectedErr:  `the OCSP responder does not know the certificate "CN=leaf"`,
+		},
+		{
+			name:         "not_stapled",
+			ocspStapling: ocspStaplingVerify,
+		},
+		{
+			name:         "not_stapled_required",
+			ocspStapling: ocspStaplingRequire,
+			expectedErr:  "the server did not staple an OCSP response",
+		},
+		{
+			name:         "expired",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, ca, ocspGood, testNow.Add(-time.Second)),
+			expectedErr:  "the OCSP response expired",
+		},
+		{
+			name:         "delegated_responder",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, responder, ocspGood, nextUpdate),
+		},
+		{
+			name:         "responder_without_ocsp_signing",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, notResponder, ocspGood, nextUpdate),
+			expectedErr:  "the OCSP response is not signed by the issuer of the certificate",
+		},
+		{
+			name:         "signed_by_other_ca",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, leaf.cert, ca.cert, otherCA, ocspGood, nextUpdate),
+			expectedErr:  "the OCSP response is not signed by the issuer of the certificate",
+		},
+		{
+			name:         "other_certificate",
+			ocspStapling: ocspStaplingVerify,
+			response:     newTestOCSPResponse(t, otherLeaf.cert, ca.cert, ca, ocspGood, nextUpdate),
+			expectedErr:  `the OCSP response does not cover the certificate "CN=leaf"`,
+		},
+		{
+			name:         "invalid",
+			ocspStapling: ocspStaplingVerify,
+			response:     []byte("invalid"),
+			expectedErr:  "failed to parse the OCSP response",
+		},
+		{
+			name:         "unsuccessful",
+			ocspStapling: ocspStaplingVerify,
+			// tryLater
+			response:    []byte{0x30, 0x03, 0x0a, 0x01, 0x03},
+			expectedErr: "unsuccessful OCSP response status 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &revocationChecker{ocspStapling: tt.ocspStapling, now: func() time.Time { return testNow }}
+			err := rc.verifyConnection(tls.ConnectionState{VerifiedChains: chains, OCSPResponse: tt.response})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestClientConfigValidateOCSPStapling(t *testing.T) {
+	cfg := NewDefaultClientConfig()
+	assert.NoError(t, cfg.Validate())
+	cfg.OCSPStapling = ocspStaplingRequire
+	assert.NoError(t, cfg.Validate())
+	cfg.OCSPStapling = "always"
+	assert.EqualError(t, cfg.Validate(), `invalid ocsp_stapling "always", must be "verify" or "require"`)
+
+	_, err := cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "invalid ocsp_stapling")
+
+	cfg = NewDefaultClientConfig()
+	cfg.CRLFile = filepath.Join(t.TempDir(), "missing.pem")
+	_, err = cfg.LoadTLSConfig(context.Background())
+	assert.ErrorContains(t, err, "failed to load CRL")
+}
+
+func TestClientConfigRevocation(t *testing.T) {
+	ca := newTestCert(t, 1, "ca", nil, nil)
+	server := newTestCert(t, 10, "server", ca, &x509.Certificate{DNSNames: []string{"localhost"}})
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	crlPath := filepath.Join(dir, "crl.pem")
+	require.NoError(t, os.WriteFile(crlPath, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: newTestCRL(t, ca, big.NewInt(10))}), 0600))
+
+	handshake := func(cfg ClientConfig, staple []byte) error {
+		clientCfg, err := cfg.LoadTLSConfig(context.Background())
+		require.NoError(t, err)
+		clientCfg.ServerName = "localhost"
+		serverCfg := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{server.cert.Raw},
+				PrivateKey:  server.key,
+				OCSPStaple:  staple,
+			}},
+		}
+
+		ln, err := tls.Listen("tcp", "localhost:0", serverCfg)
+		require.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			conn, acceptErr := ln.Accept()
+			if acceptErr != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}()
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+		return tls.Client(conn, clientCfg).Handshake()
+	}
+
+	cfg := ClientConfig{Config: Config{CAFile: caPath}}
+	assert.NoError(t, handshake(cfg, nil))
+
+	cfg.CRLFile = crlPath
+	assert.ErrorContains(t, handshake(cfg, nil), `certificate "CN=server" was revoked`)
+
+	cfg.CRLFile = ""
+	cfg.OCSPStapling = ocspStaplingRequire
+	assert.ErrorContains(t, handshake(cfg, nil), "the server did not staple an OCSP response")
+	// The OCSP response is checked against the current time.
+	staple := newTestOCSPResponse(t, server.cert, ca.cert, ca, ocspGood, time.Time{})
+	assert.NoError(t, handshake(cfg, staple))
+	staple = newTestOCSPResponse(t, server.cert, ca.cert, ca, ocspRevoked, time.Time{})
+	assert.ErrorContains(t, handshake(cfg, staple), `certificate "CN=server" was revoked`)
+}
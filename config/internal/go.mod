@@ -13,6 +13,7 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/hashicorp/go-version v1.6.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.5.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -20,6 +21,8 @@ require (
 
 replace go.opentelemetry.io/collector => ../../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../confighumanize
+
 replace go.opentelemetry.io/collector/featuregate => ../../featuregate
 
 replace go.opentelemetry.io/collector/confmap => ../../confmap
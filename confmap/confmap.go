@@ -153,18 +153,7 @@ func decodeConfig(m *Conf, result any, errorUnused bool) error {
 		TagName:          "mapstructure",
 		WeaklyTypedInput: true,
 		MatchName:        caseSensitiveMatchName,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			expandNilStructPointersHookFunc(),
-			mapstructure.StringToSliceHookFunc(","),
-			mapKeyStringToMapKeyTextUnmarshalerHookFunc(),
-			mapstructure.StringToTimeDurationHookFunc(),
-			mapstructure.TextUnmarshallerHookFunc(),
-			unmarshalerHookFunc(result),
-			// after the main unmarshaler hook is called,
-			// we unmarshal the embedded structs if present to merge with the result:
-			unmarshalerEmbeddedStructsHookFunc(),
-			zeroSliceHookFunc(),
-		),
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(decodeHooks(result)...),
 	}
 	decoder, err := mapstructure.NewDecoder(dc)
 	if err != nil {
@@ -179,6 +168,24 @@ func decodeConfig(m *Conf, result any, errorUnused bool) error {
 	return nil
 }
 
+// decodeHooks returns the hooks used to decode the configuration: the hooks registered
+// in the global DecodeHookRegistry are applied before the built-in conversions.
+func decodeHooks(result any) []mapstructure.DecodeHookFunc {
+	hooks := []mapstructure.DecodeHookFunc{expandNilStructPointersHookFunc()}
+	hooks = append(hooks, globalDecodeHookRegistry.decodeHooks()...)
+	return append(hooks,
+		mapstructure.StringToSliceHookFunc(","),
+		mapKeyStringToMapKeyTextUnmarshalerHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.TextUnmarshallerHookFunc(),
+		unmarshalerHookFunc(result),
+		// after the main unmarshaler hook is called,
+		// we unmarshal the embedded structs if present to merge with the result:
+		unmarshalerEmbeddedStructsHookFunc(),
+		zeroSliceHookFunc(),
+	)
+}
+
 // encoderConfig returns a default encoder.EncoderConfig that includes
 // an EncodeHook that handles both TextMarshaller and Marshaler
 // interfaces.
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultsconverter // import "go.opentelemetry.io/collector/confmap/converter/defaultsconverter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// defaultsKey is the top-level key holding the default configurations.
+const defaultsKey = "defaults"
+
+// typeAndNameSeparator is the separator between the type and the name of a component ID.
+const typeAndNameSeparator = "/"
+
+// kinds are the top-level keys holding the components, for which defaults can be given.
+var kinds = map[string]struct{}{
+	"receivers":  {},
+	"processors": {},
+	"exporters":  {},
+	"connectors": {},
+	"extensions": {},
+}
+
+type converter struct{}
+
+// New returns a confmap.Converter, that applies the configurations of the "defaults" section to all
+// the components of the given kind and type, and removes the section.
+//
+// The defaults are given per component kind and type, e.g.
+//
+//	defaults:
+//	  exporters:
+//	    otlp:
+//	      retry_on_failure:
+//	        max_elapsed_time: 10m
+//
+// applies the retry settings to "otlp" and all the "otlp/<name>" exporters. The settings of
+// the components take precedence over the defaults, maps are merged and all other values,
+// including lists, are replaced.
+//
+// Notice: This API is experimental.
+func New(_ confmap.ConverterSettings) confmap.Converter {
+	return converter{}
+}
+
+func (converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if !conf.IsSet(defaultsKey) {
+		return nil
+	}
+	out := conf.ToStringMap()
+	defaults, ok := out[defaultsKey].(map[string]any)
+	if !ok && out[defaultsKey] != nil {
+		return fmt.Errorf("%q must be a map, got %T", defaultsKey, out[defaultsKey])
+	}
+	delete(out, defaultsKey)
+
+	for kind, kindDefaults := range defaults {
+		if _, ok = kinds[kind]; !ok {
+			return fmt.Errorf("unknown component kind %q in %q", kind, defaultsKey)
+		}
+		typeDefaults, ok := kindDefaults.(map[string]any)
+		if !ok && kindDefaults != nil {
+			return fmt.Errorf("%q must be a map, got %T", defaultsKey+confmap.KeyDelimiter+kind, kindDefaults)
+		}
+		components, ok := out[kind].(map[string]any)
+		if !ok {
+			// No components of this kind, or an invalid section reported when unmarshaling the configuration.
+			continue
+		}
+		for id, cfg := range components {
+			typeStr, _, _ := strings.Cut(id, typeAndNameSeparator)
+			def, ok := typeDefaults[strings.TrimSpace(typeStr)]
+			if !ok {
+				continue
+			}
+			components[id] = merge(def, cfg)
+		}
+	}
+
+	*conf = *confmap.NewFromStringMap(out)
+	return nil
+}
+
+// merge returns the value overridden applied over the defaults def. Maps are merged recursively,
+// all other values replace the default ones.
+func merge(def any, overridden any) any {
+	if overridden == nil {
+		return deepCopy(def)
+	}
+	defMap, ok := def.(map[string]any)
+	if !ok {
+		return overridden
+	}
+	overriddenMap, ok := overridden.(map[string]any)
+	if !ok {
+		return overridden
+	}
+	res := make(map[string]any, len(defMap)+len(overriddenMap))
+	for k, v := range defMap {
+		res[k] = deepCopy(v)
+	}
+	for k, v := range overriddenMap {
+		if d, ok := res[k]; ok {
+			res[k] = merge(d, v)
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// deepCopy copies the maps and lists of value, so that the defaults are not shared between components.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, mv := range v {
+			res[k] = deepCopy(mv)
+		}
+		return res
+	case []any:
+		res := make([]any, 0, len(v))
+		for _, sv := range v {
+			res = append(res, deepCopy(sv))
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultsconverter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestConvert(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "defaults.yaml"))
+	require.NoError(t, err)
+	expected, err := confmaptest.LoadConf(filepath.Join("testdata", "expected.yaml"))
+	require.NoError(t, err)
+
+	require.NoError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf))
+	assert.Equal(t, expected.ToStringMap(), conf.ToStringMap())
+	assert.False(t, conf.IsSet("defaults"))
+}
+
+func TestConvertNoDefaults(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "no-defaults.yaml"))
+	require.NoError(t, err)
+	expected := conf.ToStringMap()
+
+	require.NoError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf))
+	assert.Equal(t, expected, conf.ToStringMap())
+}
+
+func TestConvertDefaultsNotShared(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"defaults": map[string]any{
+			"exporters": map[string]any{
+				"otlp": map[string]any{"headers": map[string]any{"tenant": "default"}},
+			},
+		},
+		"exporters": map[string]any{
+			"otlp/1": nil,
+			"otlp/2": nil,
+		},
+	})
+	require.NoError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf))
+
+	exporters := conf.ToStringMap()["exporters"].(map[string]any)
+	exporters["otlp/1"].(map[string]any)["headers"].(map[string]any)["tenant"] = "changed"
+	assert.Equal(t, "default", exporters["otlp/2"].(map[string]any)["headers"].(map[string]any)["tenant"])
+}
+
+func TestConvertErrors(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "unknown-kind.yaml"))
+	require.NoError(t, err)
+	assert.EqualError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf), `unknown component kind "pipelines" in "defaults"`)
+
+	conf = confmap.NewFromStringMap(map[string]any{"defaults": "invalid"})
+	assert.EqualError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf), `"defaults" must be a map, got string`)
+
+	conf = confmap.NewFromStringMap(map[string]any{"defaults": map[string]any{"exporters": []any{"otlp"}}})
+	assert.EqualError(t, New(confmap.ConverterSettings{}).Convert(context.Background(), conf), `"defaults::exporters" must be a map, got []interface {}`)
+}
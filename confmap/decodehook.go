@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+var globalDecodeHookRegistry = newDecodeHookRegistry()
+
+// ErrDecodeHookAlreadyRegistered is returned when registering a decode hook with the ID of a registered one.
+var ErrDecodeHookAlreadyRegistered = errors.New("decode hook is already registered")
+
+// DecodeHook converts the data being unmarshalled into a value of the type `to`, e.g. a string
+// with a custom format into a time.Duration. The data of the types it does not handle is returned
+// unchanged. The returned value is then converted by the next hooks and the built-in conversions.
+type DecodeHook func(from reflect.Type, to reflect.Type, data any) (any, error)
+
+// NewStringDecodeHook returns a DecodeHook converting the strings unmarshalled into the values
+// of type T with the parse function. The other values are returned unchanged.
+// Since the hook applies to every value of type T, parse should also accept the values
+// converted by the built-in conversions, e.g. the duration strings for time.Duration.
+func NewStringDecodeHook[T any](parse func(string) (T, error)) DecodeHook {
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != target {
+			return data, nil
+		}
+		return parse(reflect.ValueOf(data).String())
+	}
+}
+
+// DecodeHookRegistry holds the decode hooks used when unmarshalling every Conf, so that the distributions
+// of the collector can support custom configuration formats uniformly across all their components.
+type DecodeHookRegistry struct {
+	mu    sync.RWMutex
+	ids   map[string]struct{}
+	hooks []DecodeHook
+}
+
+// newDecodeHookRegistry returns a new empty DecodeHookRegistry.
+func newDecodeHookRegistry() *DecodeHookRegistry {
+	return &DecodeHookRegistry{ids: map[string]struct{}{}}
+}
+
+// GlobalDecodeHookRegistry returns the global DecodeHookRegistry, whose hooks are used by Conf.Unmarshal.
+func GlobalDecodeHookRegistry() *DecodeHookRegistry {
+	return globalDecodeHookRegistry
+}
+
+// Register registers the hook with the given ID. The hooks are applied in the order of their registration,
+// before the built-in conversions, e.g. of the duration strings. They should be registered before the
+// configuration is unmarshalled, typically in the main function of the distribution.
+func (r *DecodeHookRegistry) Register(id string, hook DecodeHook) error {
+	if id == "" {
+		return errors.New("empty decode hook ID")
+	}
+	if hook == nil {
+		return fmt.Errorf("nil decode hook %q", id)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.ids[id]; ok {
+		return fmt.Errorf("failed to register %q: %w", id, ErrDecodeHookAlreadyRegistered)
+	}
+	r.ids[id] = struct{}{}
+	r.hooks = append(r.hooks, hook)
+	return nil
+}
+
+// MustRegister is like Register but panics if an error is returned.
+func (r *DecodeHookRegistry) MustRegister(id string, hook DecodeHook) {
+	if err := r.Register(id, hook); err != nil {
+		panic(err)
+	}
+}
+
+// decodeHooks returns the registered hooks as mapstructure hooks.
+func (r *DecodeHookRegistry) decodeHooks() []mapstructure.DecodeHookFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hooks := make([]mapstructure.DecodeHookFunc, 0, len(r.hooks))
+	for _, hook := range r.hooks {
+		hooks = append(hooks, mapstructure.DecodeHookFuncType(hook))
+	}
+	return hooks
+}
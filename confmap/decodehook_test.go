@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setGlobalDecodeHookRegistry replaces the global registry for the duration of the test.
+func setGlobalDecodeHookRegistry(t *testing.T, r *DecodeHookRegistry) {
+	prev := globalDecodeHookRegistry
+	globalDecodeHookRegistry = r
+	t.Cleanup(func() { globalDecodeHookRegistry = prev })
+}
+
+// parseDays parses the durations in days, e.g. "2d", and the duration strings.
+func parseDays(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		return time.Duration(n) * 24 * time.Hour, err
+	}
+	return time.ParseDuration(s)
+}
+
+type level int
+
+func TestDecodeHookRegistry(t *testing.T) {
+	r := newDecodeHookRegistry()
+	setGlobalDecodeHookRegistry(t, r)
+	r.MustRegister("days", NewStringDecodeHook(parseDays))
+	r.MustRegister("level", func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeOf(level(0)) {
+			return data, nil
+		}
+		switch data.(string) {
+		case "low":
+			return level(1), nil
+		case "high":
+			return level(2), nil
+		}
+		return nil, errors.New("unknown level")
+	})
+
+	var cfg struct {
+		Retention time.Duration `mapstructure:"retention"`
+		Interval  time.Duration `mapstructure:"interval"`
+		Levels    []level       `mapstructure:"levels"`
+		Nested    struct {
+			N int `mapstructure:"n"`
+		} `mapstructure:"nested"`
+	}
+	conf := NewFromStringMap(map[string]any{
+		"retention": "2d",
+		"interval":  "10s",
+		"levels":    []any{"low", "high", 3},
+		"nested":    map[string]any{"n": "5"},
+	})
+	require.NoError(t, conf.Unmarshal(&cfg))
+	assert.Equal(t, 48*time.Hour, cfg.Retention)
+	assert.Equal(t, 10*time.Second, cfg.Interval)
+	assert.Equal(t, []level{1, 2, 3}, cfg.Levels)
+	assert.Equal(t, 5, cfg.Nested.N)
+
+	conf = NewFromStringMap(map[string]any{"levels": []any{"medium"}})
+	assert.ErrorContains(t, conf.Unmarshal(&cfg), "unknown level")
+	conf = NewFromStringMap(map[string]any{"retention": "xd"})
+	assert.ErrorContains(t, conf.Unmarshal(&cfg), "invalid syntax")
+}
+
+func TestDecodeHookRegistryRegister(t *testing.T) {
+	r := newDecodeHookRegistry()
+	hook := NewStringDecodeHook(parseDays)
+	require.NoError(t, r.Register("days", hook))
+	assert.ErrorIs(t, r.Register("days", hook), ErrDecodeHookAlreadyRegistered)
+	assert.EqualError(t, r.Register("", hook), "empty decode hook ID")
+	assert.EqualError(t, r.Register("nil", nil), `nil decode hook "nil"`)
+	assert.Panics(t, func() { r.MustRegister("days", hook) })
+	assert.Len(t, r.decodeHooks(), 1)
+	assert.NotNil(t, GlobalDecodeHookRegistry())
+}
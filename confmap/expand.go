@@ -151,6 +151,7 @@ func (mr *Resolver) expandURI(ctx context.Context, uri string) (any, bool, error
 		return nil, false, err
 	}
 	mr.closers = append(mr.closers, ret.Close)
+	mr.expansions = append(mr.expansions, lURI.asString())
 	val, err := ret.AsRaw()
 	return val, true, err
 }
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KeyOrigin describes where the value of a key of the resolved configuration comes from.
+// It does not hold the value itself, which may be sensitive.
+type KeyOrigin struct {
+	// Key is the key of the value, e.g. "exporters::otlp::endpoint".
+	Key string
+
+	// URI is the URI of the configuration source that set the value, e.g. "file:/etc/otelcol/config.yaml".
+	// As the sources are merged in order, it is the last of the sources setting the key.
+	// It is empty if the value was only set by a converter.
+	URI string
+
+	// Overridden are the URIs of the sources that set the key before URI, and were overridden by it.
+	Overridden []string
+
+	// Expansions are the URIs expanded in the value, e.g. "env:OTLP_ENDPOINT".
+	Expansions []string
+
+	// Converters are the converters that set or changed the value, in the order they were applied.
+	Converters []string
+
+	// Default reports that the key is not set by the configuration, its value being the default value
+	// of the component, see AddDefaultOrigins. The other fields are then empty.
+	Default bool
+}
+
+// String returns a human-readable description of the origin, e.g.
+// "file:config.yaml (overrides file:base.yaml), expands env:ENDPOINT".
+func (o KeyOrigin) String() string {
+	if o.Default {
+		return "default"
+	}
+	var parts []string
+	if o.URI != "" {
+		src := o.URI
+		if len(o.Overridden) > 0 {
+			src += " (overrides " + strings.Join(o.Overridden, ", ") + ")"
+		}
+		parts = append(parts, src)
+	}
+	if len(o.Expansions) > 0 {
+		parts = append(parts, "expands "+strings.Join(o.Expansions, ", "))
+	}
+	if len(o.Converters) > 0 {
+		parts = append(parts, "converted by "+strings.Join(o.Converters, ", "))
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// origins tracks the origins of the keys while the configuration is resolved.
+type origins map[string]*KeyOrigin
+
+// get returns the origin of the key, created if missing.
+func (os origins) get(key string) *KeyOrigin {
+	o, ok := os[key]
+	if !ok {
+		o = &KeyOrigin{Key: key}
+		os[key] = o
+	}
+	return o
+}
+
+// setURI records that the keys of the conf were set by the source with the given URI.
+func (os origins) setURI(uri string, conf *Conf) {
+	for _, k := range conf.AllKeys() {
+		o := os.get(k)
+		if o.URI != "" {
+			o.Overridden = append(o.Overridden, o.URI)
+		}
+		o.URI = uri
+	}
+}
+
+// addExpansions records the URIs expanded in the value of the key.
+func (os origins) addExpansions(key string, uris []string) {
+	o := os.get(key)
+	for _, uri := range uris {
+		if !contains(o.Expansions, uri) {
+			o.Expansions = append(o.Expansions, uri)
+		}
+	}
+}
+
+// addConverter records the converter on the keys whose value was set or changed by the conversion.
+func (os origins) addConverter(name string, before map[string]any, conf *Conf) {
+	for _, k := range conf.AllKeys() {
+		if v, ok := before[k]; ok && reflect.DeepEqual(v, conf.Get(k)) {
+			continue
+		}
+		o := os.lookup(k)
+		os[k] = o
+		o.Converters = append(o.Converters, name)
+	}
+}
+
+// lookup returns a copy of the origin of the key, or of its closest parent, e.g. for the keys
+// of a map expanded from a URI.
+func (os origins) lookup(key string) *KeyOrigin {
+	for k := key; ; {
+		if o, ok := os[k]; ok {
+			return &KeyOrigin{
+				Key:        key,
+				URI:        o.URI,
+				Overridden: append([]string(nil), o.Overridden...),
+				Expansions: append([]string(nil), o.Expansions...),
+				Converters: append([]string(nil), o.Converters...),
+			}
+		}
+		i := strings.LastIndex(k, KeyDelimiter)
+		if i < 0 {
+			return &KeyOrigin{Key: key}
+		}
+		k = k[:i]
+	}
+}
+
+// list returns the origins of the keys of the conf, sorted by key.
+func (os origins) list(conf *Conf) []KeyOrigin {
+	keys := conf.AllKeys()
+	sort.Strings(keys)
+	ret := make([]KeyOrigin, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, *os.lookup(k))
+	}
+	return ret
+}
+
+// AddDefaultOrigins returns the origins completed with the keys of the effective configuration, e.g. the
+// resolved configuration unmarshalled into the configurations of the components and marshalled back, that
+// are not set by the resolved configuration: their values are the defaults of the components, and their
+// origin is Default. The returned origins are sorted by key.
+func AddDefaultOrigins(origins []KeyOrigin, effective *Conf) []KeyOrigin {
+	resolved := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		resolved[o.Key] = true
+	}
+	ret := append([]KeyOrigin(nil), origins...)
+	for _, k := range effective.AllKeys() {
+		if !resolved[k] {
+			ret = append(ret, KeyOrigin{Key: k, Default: true})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
+
+// leafValues returns the values of the keys of the conf.
+func leafValues(conf *Conf) map[string]any {
+	ret := make(map[string]any)
+	for _, k := range conf.AllKeys() {
+		ret[k] = conf.Get(k)
+	}
+	return ret
+}
+
+// converterName returns the name of the converter, the name of its package, e.g. "expandconverter".
+func converterName(c Converter) string {
+	t := reflect.TypeOf(c)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return path.Base(t.PkgPath())
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
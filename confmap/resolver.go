@@ -25,6 +25,11 @@ type Resolver struct {
 
 	closers []CloseFunc
 	watcher chan error
+
+	// origins are the origins of the keys of the last resolved configuration.
+	origins []KeyOrigin
+	// expansions collects the URIs expanded while expanding a value.
+	expansions []string
 }
 
 // ResolverSettings are the settings to configure the behavior of the Resolver.
@@ -114,6 +119,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
+	keyOrigins := origins{}
 	for _, uri := range mr.uris {
 		ret, err := mr.retrieveValue(ctx, uri)
 		if err != nil {
@@ -127,28 +133,43 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err = retMap.Merge(retCfgMap); err != nil {
 			return nil, err
 		}
+		keyOrigins.setURI(uri.asString(), retCfgMap)
 	}
 
 	cfgMap := make(map[string]any)
 	for _, k := range retMap.AllKeys() {
+		mr.expansions = nil
 		val, err := mr.expandValueRecursively(ctx, retMap.Get(k))
 		if err != nil {
 			return nil, err
 		}
 		cfgMap[k] = val
+		keyOrigins.addExpansions(k, mr.expansions)
 	}
+	mr.expansions = nil
 	retMap = NewFromStringMap(cfgMap)
 
 	// Apply the converters in the given order.
 	for _, confConv := range mr.converters {
+		before := leafValues(retMap)
 		if err := confConv.Convert(ctx, retMap); err != nil {
 			return nil, fmt.Errorf("cannot convert the confmap.Conf: %w", err)
 		}
+		keyOrigins.addConverter(converterName(confConv), before, retMap)
 	}
 
+	mr.origins = keyOrigins.list(retMap)
 	return retMap, nil
 }
 
+// Origins returns the origins of the keys of the configuration returned by the last successful Resolve,
+// sorted by key. The values of the keys are not part of the origins.
+//
+// Should never be called concurrently with Resolve.
+func (mr *Resolver) Origins() []KeyOrigin {
+	return mr.origins
+}
+
 // Watch blocks until any configuration change was detected or an unrecoverable error
 // happened during monitoring the configuration changes.
 //
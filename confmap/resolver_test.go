@@ -301,6 +301,71 @@ func TestResolver(t *testing.T) {
 	assert.Equal(t, int32(3), numCalls.Load())
 }
 
+type setConverter struct {
+	key   string
+	value any
+}
+
+func (c *setConverter) Convert(_ context.Context, conf *Conf) error {
+	return conf.Merge(NewFromStringMap(map[string]any{c.key: c.value}))
+}
+
+func TestResolverOrigins(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"base:", "mock:"},
+		Providers: makeMapProvidersMap(
+			newFakeProvider("base", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{
+					"exporters": map[string]any{"otlp": map[string]any{"endpoint": "localhost:4317", "compression": "gzip"}},
+				})
+			}),
+			newFakeProvider("mock", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{
+					"exporters": map[string]any{"otlp": map[string]any{"endpoint": "${env:ENDPOINT}", "headers": "${env:HEADERS}"}},
+				})
+			}),
+			newFakeProvider("env", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				if uri == "env:HEADERS" {
+					return NewRetrieved(map[string]any{"key": "secret"})
+				}
+				return NewRetrieved("collector:4317")
+			}),
+		),
+		Converters: []Converter{&setConverter{key: "exporters::otlp::compression", value: "zstd"}}})
+	require.NoError(t, err)
+	assert.Empty(t, resolver.Origins())
+
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []KeyOrigin{
+		{Key: "exporters::otlp::compression", URI: "base:", Converters: []string{"confmap"}},
+		{Key: "exporters::otlp::endpoint", URI: "mock:", Overridden: []string{"base:"}, Expansions: []string{"env:ENDPOINT"}},
+		{Key: "exporters::otlp::headers::key", URI: "mock:", Expansions: []string{"env:HEADERS"}},
+	}, resolver.Origins())
+	assert.Equal(t, "mock: (overrides base:), expands env:ENDPOINT", resolver.Origins()[1].String())
+	assert.Equal(t, "base:, converted by confmap", resolver.Origins()[0].String())
+	assert.Equal(t, "unknown", KeyOrigin{Key: "key"}.String())
+}
+
+func TestAddDefaultOrigins(t *testing.T) {
+	origins := []KeyOrigin{
+		{Key: "exporters::otlp::endpoint", URI: "file:config.yaml"},
+		{Key: "receivers::otlp::protocols::grpc::endpoint", URI: "file:config.yaml"},
+	}
+	effective := NewFromStringMap(map[string]any{
+		"exporters": map[string]any{"otlp": map[string]any{"endpoint": "collector:4317", "compression": "gzip"}},
+		"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{"endpoint": "localhost:4317"}}}},
+	})
+	got := AddDefaultOrigins(origins, effective)
+	assert.Equal(t, []KeyOrigin{
+		{Key: "exporters::otlp::compression", Default: true},
+		{Key: "exporters::otlp::endpoint", URI: "file:config.yaml"},
+		{Key: "receivers::otlp::protocols::grpc::endpoint", URI: "file:config.yaml"},
+	}, got)
+	assert.Equal(t, "default", got[0].String())
+	assert.Len(t, origins, 2)
+}
+
 func TestResolverNewLinesInOpaqueValue(t *testing.T) {
 	_, err := NewResolver(ResolverSettings{
 		URIs:       []string{"mock:receivers:\n nop:\n"},
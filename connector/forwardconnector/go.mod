@@ -37,6 +37,7 @@ require (
 	github.com/prometheus/common v0.52.3 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
 	go.opentelemetry.io/collector v0.98.0 // indirect
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.98.0 // indirect
 	go.opentelemetry.io/otel v1.25.0 // indirect
 	go.opentelemetry.io/otel/exporters/prometheus v0.47.0 // indirect
@@ -55,6 +56,8 @@ require (
 
 replace go.opentelemetry.io/collector => ../../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../../config/confighumanize
+
 replace go.opentelemetry.io/collector/component => ../../component
 
 replace go.opentelemetry.io/collector/connector => ../
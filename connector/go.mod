@@ -36,6 +36,7 @@ require (
 	github.com/prometheus/client_model v0.6.1 // indirect
 	github.com/prometheus/common v0.52.3 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.98.0 // indirect
 	go.opentelemetry.io/collector/confmap v0.98.0 // indirect
 	go.opentelemetry.io/otel v1.25.0 // indirect
@@ -55,6 +56,8 @@ require (
 
 replace go.opentelemetry.io/collector => ../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../config/confighumanize
+
 replace go.opentelemetry.io/collector/component => ../component
 
 replace go.opentelemetry.io/collector/config/configtelemetry => ../config/configtelemetry
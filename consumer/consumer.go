@@ -5,6 +5,8 @@ package consumer // import "go.opentelemetry.io/collector/consumer"
 
 import (
 	"errors"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 // Capabilities describes the capabilities of a Processor.
@@ -15,6 +17,37 @@ type Capabilities struct {
 	// does not modify the data it MUST set this flag to false. If the processor creates
 	// a copy of the data before modifying then this flag can be safely set to false.
 	MutatesData bool
+
+	// ReturnsResponse is set to true if the consumer implements TracesWithResponse, MetricsWithResponse
+	// or LogsWithResponse, returning the Response of the consumption of the items of the data.
+	// The consumers wrapping another one MUST only set this flag if they return the Response of the wrapped consumer,
+	// e.g. by calling ConsumeTracesWithResponse.
+	ReturnsResponse bool
+}
+
+// Response is the result of the consumption of the items of the data, i.e. the spans, the metric data points
+// or the log records, which allows the receivers to report a partial success precisely.
+type Response struct {
+	// Accepted is the number of items accepted by the consumer.
+	Accepted int
+	// Rejected is the number of items rejected by the consumer, which must not be sent again.
+	Rejected int
+	// ErrorMessage describes why the items were rejected, if any.
+	ErrorMessage string
+}
+
+// ResponseFromError returns the Response of the consumption of the items by a consumer not returning it, according to
+// the returned error: all the items are accepted without error, and rejected with a permanent error. The error is
+// returned as is. It is typically used by the consumers returning the Response for the data they refuse themselves.
+func ResponseFromError(items int, err error) (Response, error) {
+	switch {
+	case err == nil:
+		return Response{Accepted: items}, nil
+	case consumererror.IsPermanent(err):
+		return Response{Rejected: items, ErrorMessage: err.Error()}, err
+	default:
+		return Response{}, err
+	}
 }
 
 type baseConsumer interface {
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Category classifies the errors returned by the components, to handle them consistently
+// across the pipeline without knowing each individual error type.
+type Category int
+
+const (
+	// CategoryRetryable is the category of the errors that may not be returned if the same
+	// inputs are retried. The errors that are not classified otherwise are retryable.
+	CategoryRetryable Category = iota
+	// CategoryThrottled is the category of the retryable errors for which the destination
+	// requested to delay the retry, see NewThrottled.
+	CategoryThrottled
+	// CategoryPermanent is the category of the errors that are always returned if their source
+	// receives the same inputs, see NewPermanent.
+	CategoryPermanent
+	// CategoryShutdown is the category of the errors returned because the component is shutting
+	// down, see NewShutdown.
+	CategoryShutdown
+	// CategoryExpired is the category of the errors returned because the deadline of the
+	// operation expired, see NewExpired.
+	CategoryExpired
+)
+
+// String returns the name of the category, e.g. used as the value of the error category
+// attribute of the telemetry of the components.
+func (c Category) String() string {
+	switch c {
+	case CategoryRetryable:
+		return "retryable"
+	case CategoryThrottled:
+		return "throttled"
+	case CategoryPermanent:
+		return "permanent"
+	case CategoryShutdown:
+		return "shutdown"
+	case CategoryExpired:
+		return "expired"
+	}
+	return ""
+}
+
+// categorized is implemented by the errors classifying themselves, e.g. the errors of other packages.
+type categorized interface {
+	error
+	ErrorCategory() Category
+}
+
+// Classify returns the category of the error. The errors created by the functions of this package are
+// classified accordingly, as well as the errors implementing an ErrorCategory() Category method,
+// and the errors wrapping them. The errors wrapping context.DeadlineExceeded are expired, and all
+// the others are retryable. Classify must not be called with a nil error.
+func Classify(err error) Category {
+	var c categorized
+	switch {
+	case errors.As(err, &shutdown{}):
+		return CategoryShutdown
+	case IsPermanent(err):
+		return CategoryPermanent
+	case errors.As(err, &c):
+		return c.ErrorCategory()
+	case errors.As(err, &throttled{}):
+		return CategoryThrottled
+	case errors.As(err, &expired{}), errors.Is(err, context.DeadlineExceeded):
+		return CategoryExpired
+	}
+	return CategoryRetryable
+}
+
+// IsRetryable checks if the error is worth retrying with the same inputs, i.e. if it is either retryable or throttled.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	c := Classify(err)
+	return c == CategoryRetryable || c == CategoryThrottled
+}
+
+// throttled is a retryable error for which the destination requested to delay the retry.
+type throttled struct {
+	err   error
+	delay time.Duration
+}
+
+// NewThrottled wraps an error to indicate that the destination is throttling the requests,
+// and requested to delay the retry by the given duration.
+func NewThrottled(err error, delay time.Duration) error {
+	return throttled{err: err, delay: delay}
+}
+
+func (t throttled) Error() string {
+	return "Throttled (" + t.delay.String() + "): " + t.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (t throttled) Unwrap() error {
+	return t.err
+}
+
+// throttleDelayer is implemented by the throttled errors of other packages, e.g. the throttle errors of the exporters.
+type throttleDelayer interface {
+	error
+	ThrottleDelay() time.Duration
+}
+
+// ThrottleDelay returns the delay requested by the destination, if the error was wrapped with the NewThrottled
+// function, or wraps an error implementing a ThrottleDelay() time.Duration method.
+func ThrottleDelay(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	if t := (throttled{}); errors.As(err, &t) {
+		return t.delay, true
+	}
+	var d throttleDelayer
+	if errors.As(err, &d) {
+		return d.ThrottleDelay(), true
+	}
+	return 0, false
+}
+
+// shutdown is an error returned because the component is shutting down.
+type shutdown struct {
+	err error
+}
+
+// NewShutdown wraps an error to indicate that the operation was interrupted because the component is shutting down.
+func NewShutdown(err error) error {
+	return shutdown{err: err}
+}
+
+func (s shutdown) Error() string {
+	return "interrupted due to shutdown: " + s.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (s shutdown) Unwrap() error {
+	return s.err
+}
+
+// IsShutdown checks if an error was wrapped with the NewShutdown function.
+func IsShutdown(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &shutdown{})
+}
+
+// expired is an error returned because the deadline of the operation expired.
+type expired struct {
+	err error
+}
+
+// NewExpired wraps an error to indicate that the operation failed because its deadline expired,
+// e.g. the data was too old to be exported.
+func NewExpired(err error) error {
+	return expired{err: err}
+}
+
+func (e expired) Error() string {
+	return "Expired: " + e.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (e expired) Unwrap() error {
+	return e.err
+}
+
+// IsExpired checks if an error was wrapped with the NewExpired function, or wraps context.DeadlineExceeded.
+func IsExpired(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &expired{}) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type categorizedError struct {
+	category Category
+}
+
+func (e categorizedError) Error() string {
+	return "categorized"
+}
+
+func (e categorizedError) ErrorCategory() Category {
+	return e.category
+}
+
+type delayedError struct {
+	delay time.Duration
+}
+
+func (e delayedError) Error() string {
+	return "delayed"
+}
+
+func (e delayedError) ThrottleDelay() time.Duration {
+	return e.delay
+}
+
+func TestClassify(t *testing.T) {
+	err := errors.New("test error")
+	tests := []struct {
+		name     string
+		err      error
+		expected Category
+	}{
+		{name: "unclassified", err: err, expected: CategoryRetryable},
+		{name: "throttled", err: NewThrottled(err, time.Second), expected: CategoryThrottled},
+		{name: "permanent", err: NewPermanent(err), expected: CategoryPermanent},
+		{name: "shutdown", err: NewShutdown(err), expected: CategoryShutdown},
+		{name: "expired", err: NewExpired(err), expected: CategoryExpired},
+		{name: "deadline exceeded", err: fmt.Errorf("export failed: %w", context.DeadlineExceeded), expected: CategoryExpired},
+		{name: "wrapped", err: fmt.Errorf("export failed: %w", NewThrottled(err, time.Second)), expected: CategoryThrottled},
+		{name: "permanent throttled", err: NewPermanent(NewThrottled(err, time.Second)), expected: CategoryPermanent},
+		{name: "shutdown permanent", err: NewShutdown(NewPermanent(err)), expected: CategoryShutdown},
+		{name: "categorized", err: fmt.Errorf("%w", categorizedError{category: CategoryThrottled}), expected: CategoryThrottled},
+		{name: "signal error", err: NewTraces(NewExpired(err), testdata.GenerateTraces(1)), expected: CategoryExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Classify(tt.err))
+			assert.Equal(t, tt.expected == CategoryRetryable || tt.expected == CategoryThrottled, IsRetryable(tt.err))
+		})
+	}
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestCategoryString(t *testing.T) {
+	assert.Equal(t, "retryable", CategoryRetryable.String())
+	assert.Equal(t, "throttled", CategoryThrottled.String())
+	assert.Equal(t, "permanent", CategoryPermanent.String())
+	assert.Equal(t, "shutdown", CategoryShutdown.String())
+	assert.Equal(t, "expired", CategoryExpired.String())
+	assert.Equal(t, "", Category(100).String())
+}
+
+func TestThrottled(t *testing.T) {
+	err := errors.New("test error")
+	_, ok := ThrottleDelay(nil)
+	assert.False(t, ok)
+	_, ok = ThrottleDelay(err)
+	assert.False(t, ok)
+
+	throttledErr := fmt.Errorf("%w", NewThrottled(err, 5*time.Second))
+	delay, ok := ThrottleDelay(throttledErr)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+	assert.ErrorIs(t, throttledErr, err)
+	assert.EqualError(t, throttledErr, "Throttled (5s): test error")
+
+	// The throttled errors of other packages report their delay.
+	delay, ok = ThrottleDelay(fmt.Errorf("%w", delayedError{delay: time.Minute}))
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+}
+
+func TestShutdown(t *testing.T) {
+	err := errors.New("test error")
+	assert.False(t, IsShutdown(nil))
+	assert.False(t, IsShutdown(err))
+	shutdownErr := fmt.Errorf("%w", NewShutdown(err))
+	assert.True(t, IsShutdown(shutdownErr))
+	assert.ErrorIs(t, shutdownErr, err)
+	assert.EqualError(t, shutdownErr, "interrupted due to shutdown: test error")
+}
+
+func TestExpired(t *testing.T) {
+	err := errors.New("test error")
+	assert.False(t, IsExpired(nil))
+	assert.False(t, IsExpired(err))
+	assert.True(t, IsExpired(context.DeadlineExceeded))
+	expiredErr := fmt.Errorf("%w", NewExpired(err))
+	assert.True(t, IsExpired(expiredErr))
+	assert.ErrorIs(t, expiredErr, err)
+	assert.EqualError(t, expiredErr, "Expired: test error")
+}
@@ -31,12 +31,70 @@ type baseLogs struct {
 }
 
 // NewLogs returns a Logs configured with the provided options.
+// Its capabilities never report ReturnsResponse, see NewLogsWithResponse.
 func NewLogs(consume ConsumeLogsFunc, options ...Option) (Logs, error) {
 	if consume == nil {
 		return nil, errNilFunc
 	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = false
 	return &baseLogs{
-		baseImpl:        newBaseImpl(options...),
+		baseImpl:        bs,
 		ConsumeLogsFunc: consume,
 	}, nil
 }
+
+// LogsWithResponse is a Logs that returns the Response of the consumption of the log records.
+// Its capabilities MUST report ReturnsResponse.
+type LogsWithResponse interface {
+	Logs
+	// ConsumeLogsWithResponse receives plog.Logs for consumption, and returns the Response of the consumption
+	// of its log records. The error is returned when the data is not consumed, as by ConsumeLogs.
+	ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (Response, error)
+}
+
+// ConsumeLogsWithResponseFunc is a helper function that is similar to ConsumeLogsWithResponse.
+type ConsumeLogsWithResponseFunc func(ctx context.Context, ld plog.Logs) (Response, error)
+
+// ConsumeLogsWithResponse calls f(ctx, ld).
+func (f ConsumeLogsWithResponseFunc) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (Response, error) {
+	return f(ctx, ld)
+}
+
+// ConsumeLogs calls f(ctx, ld), and discards the Response.
+func (f ConsumeLogsWithResponseFunc) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	_, err := f(ctx, ld)
+	return err
+}
+
+type baseLogsWithResponse struct {
+	*baseImpl
+	ConsumeLogsWithResponseFunc
+}
+
+// NewLogsWithResponse returns a LogsWithResponse configured with the provided options.
+// Its capabilities report ReturnsResponse.
+func NewLogsWithResponse(consume ConsumeLogsWithResponseFunc, options ...Option) (LogsWithResponse, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = true
+	return &baseLogsWithResponse{
+		baseImpl:                    bs,
+		ConsumeLogsWithResponseFunc: consume,
+	}, nil
+}
+
+// ConsumeLogsWithResponse sends the plog.Logs to the next consumer, and returns the Response of the consumption of its
+// log records. If the next consumer does not return it, the Response is derived from the returned error.
+func ConsumeLogsWithResponse(ctx context.Context, next Logs, ld plog.Logs) (Response, error) {
+	if next.Capabilities().ReturnsResponse {
+		if nextWithResponse, ok := next.(LogsWithResponse); ok {
+			return nextWithResponse.ConsumeLogsWithResponse(ctx, ld)
+		}
+	}
+	// The items are counted before being consumed, as the next consumer may modify the data.
+	items := ld.LogRecordCount()
+	return ResponseFromError(items, next.ConsumeLogs(ctx, ld))
+}
@@ -9,8 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func TestDefaultLogs(t *testing.T) {
@@ -34,6 +37,15 @@ func TestWithCapabilitiesLogs(t *testing.T) {
 	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
 }
 
+func TestWithCapabilitiesLogsReturnsResponse(t *testing.T) {
+	// The consumers not returning the Response must not report it.
+	cp, err := NewLogs(
+		func(context.Context, plog.Logs) error { return nil },
+		WithCapabilities(Capabilities{MutatesData: true, ReturnsResponse: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
+}
+
 func TestConsumeLogs(t *testing.T) {
 	consumeCalled := false
 	cp, err := NewLogs(func(context.Context, plog.Logs) error { consumeCalled = true; return nil })
@@ -48,3 +60,51 @@ func TestConsumeLogs_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeLogs(context.Background(), plog.NewLogs()))
 }
+
+func TestLogsWithResponse(t *testing.T) {
+	want := Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}
+	cp, err := NewLogsWithResponse(func(context.Context, plog.Logs) (Response, error) { return want, nil })
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{ReturnsResponse: true}, cp.Capabilities())
+	assert.NoError(t, cp.ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	rsp, err := ConsumeLogsWithResponse(context.Background(), cp, plog.NewLogs())
+	require.NoError(t, err)
+	assert.Equal(t, want, rsp)
+
+	_, err = NewLogsWithResponse(nil)
+	assert.Equal(t, errNilFunc, err)
+}
+
+func TestConsumeLogsWithResponseDerived(t *testing.T) {
+	data := testdata.GenerateLogs(2)
+	items := data.LogRecordCount()
+	tests := []struct {
+		name    string
+		err     error
+		wantRsp Response
+	}{
+		{
+			name:    "accepted",
+			wantRsp: Response{Accepted: items},
+		},
+		{
+			name:    "rejected",
+			err:     consumererror.NewPermanent(errors.New("invalid")),
+			wantRsp: Response{Rejected: items, ErrorMessage: "Permanent error: invalid"},
+		},
+		{
+			name: "retryable",
+			err:  errors.New("unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := NewLogs(func(context.Context, plog.Logs) error { return tt.err })
+			require.NoError(t, err)
+			rsp, err := ConsumeLogsWithResponse(context.Background(), cp, data)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantRsp, rsp)
+		})
+	}
+}
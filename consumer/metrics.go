@@ -31,12 +31,70 @@ type baseMetrics struct {
 }
 
 // NewMetrics returns a Metrics configured with the provided options.
+// Its capabilities never report ReturnsResponse, see NewMetricsWithResponse.
 func NewMetrics(consume ConsumeMetricsFunc, options ...Option) (Metrics, error) {
 	if consume == nil {
 		return nil, errNilFunc
 	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = false
 	return &baseMetrics{
-		baseImpl:           newBaseImpl(options...),
+		baseImpl:           bs,
 		ConsumeMetricsFunc: consume,
 	}, nil
 }
+
+// MetricsWithResponse is a Metrics that returns the Response of the consumption of the metric data points.
+// Its capabilities MUST report ReturnsResponse.
+type MetricsWithResponse interface {
+	Metrics
+	// ConsumeMetricsWithResponse receives pmetric.Metrics for consumption, and returns the Response of the consumption
+	// of its metric data points. The error is returned when the data is not consumed, as by ConsumeMetrics.
+	ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (Response, error)
+}
+
+// ConsumeMetricsWithResponseFunc is a helper function that is similar to ConsumeMetricsWithResponse.
+type ConsumeMetricsWithResponseFunc func(ctx context.Context, md pmetric.Metrics) (Response, error)
+
+// ConsumeMetricsWithResponse calls f(ctx, md).
+func (f ConsumeMetricsWithResponseFunc) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (Response, error) {
+	return f(ctx, md)
+}
+
+// ConsumeMetrics calls f(ctx, md), and discards the Response.
+func (f ConsumeMetricsWithResponseFunc) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	_, err := f(ctx, md)
+	return err
+}
+
+type baseMetricsWithResponse struct {
+	*baseImpl
+	ConsumeMetricsWithResponseFunc
+}
+
+// NewMetricsWithResponse returns a MetricsWithResponse configured with the provided options.
+// Its capabilities report ReturnsResponse.
+func NewMetricsWithResponse(consume ConsumeMetricsWithResponseFunc, options ...Option) (MetricsWithResponse, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = true
+	return &baseMetricsWithResponse{
+		baseImpl:                       bs,
+		ConsumeMetricsWithResponseFunc: consume,
+	}, nil
+}
+
+// ConsumeMetricsWithResponse sends the pmetric.Metrics to the next consumer, and returns the Response of the consumption of its
+// metric data points. If the next consumer does not return it, the Response is derived from the returned error.
+func ConsumeMetricsWithResponse(ctx context.Context, next Metrics, md pmetric.Metrics) (Response, error) {
+	if next.Capabilities().ReturnsResponse {
+		if nextWithResponse, ok := next.(MetricsWithResponse); ok {
+			return nextWithResponse.ConsumeMetricsWithResponse(ctx, md)
+		}
+	}
+	// The items are counted before being consumed, as the next consumer may modify the data.
+	items := md.DataPointCount()
+	return ResponseFromError(items, next.ConsumeMetrics(ctx, md))
+}
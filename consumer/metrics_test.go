@@ -9,8 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func TestDefaultMetrics(t *testing.T) {
@@ -34,6 +37,15 @@ func TestWithCapabilitiesMetrics(t *testing.T) {
 	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
 }
 
+func TestWithCapabilitiesMetricsReturnsResponse(t *testing.T) {
+	// The consumers not returning the Response must not report it.
+	cp, err := NewMetrics(
+		func(context.Context, pmetric.Metrics) error { return nil },
+		WithCapabilities(Capabilities{MutatesData: true, ReturnsResponse: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
+}
+
 func TestConsumeMetrics(t *testing.T) {
 	consumeCalled := false
 	cp, err := NewMetrics(func(context.Context, pmetric.Metrics) error { consumeCalled = true; return nil })
@@ -48,3 +60,51 @@ func TestConsumeMetrics_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
 }
+
+func TestMetricsWithResponse(t *testing.T) {
+	want := Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}
+	cp, err := NewMetricsWithResponse(func(context.Context, pmetric.Metrics) (Response, error) { return want, nil })
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{ReturnsResponse: true}, cp.Capabilities())
+	assert.NoError(t, cp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+
+	rsp, err := ConsumeMetricsWithResponse(context.Background(), cp, pmetric.NewMetrics())
+	require.NoError(t, err)
+	assert.Equal(t, want, rsp)
+
+	_, err = NewMetricsWithResponse(nil)
+	assert.Equal(t, errNilFunc, err)
+}
+
+func TestConsumeMetricsWithResponseDerived(t *testing.T) {
+	data := testdata.GenerateMetrics(1)
+	items := data.DataPointCount()
+	tests := []struct {
+		name    string
+		err     error
+		wantRsp Response
+	}{
+		{
+			name:    "accepted",
+			wantRsp: Response{Accepted: items},
+		},
+		{
+			name:    "rejected",
+			err:     consumererror.NewPermanent(errors.New("invalid")),
+			wantRsp: Response{Rejected: items, ErrorMessage: "Permanent error: invalid"},
+		},
+		{
+			name: "retryable",
+			err:  errors.New("unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := NewMetrics(func(context.Context, pmetric.Metrics) error { return tt.err })
+			require.NoError(t, err)
+			rsp, err := ConsumeMetricsWithResponse(context.Background(), cp, data)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantRsp, rsp)
+		})
+	}
+}
@@ -31,12 +31,70 @@ type baseTraces struct {
 }
 
 // NewTraces returns a Traces configured with the provided options.
+// Its capabilities never report ReturnsResponse, see NewTracesWithResponse.
 func NewTraces(consume ConsumeTracesFunc, options ...Option) (Traces, error) {
 	if consume == nil {
 		return nil, errNilFunc
 	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = false
 	return &baseTraces{
-		baseImpl:          newBaseImpl(options...),
+		baseImpl:          bs,
 		ConsumeTracesFunc: consume,
 	}, nil
 }
+
+// TracesWithResponse is a Traces that returns the Response of the consumption of the spans.
+// Its capabilities MUST report ReturnsResponse.
+type TracesWithResponse interface {
+	Traces
+	// ConsumeTracesWithResponse receives ptrace.Traces for consumption, and returns the Response of the consumption
+	// of its spans. The error is returned when the data is not consumed, as by ConsumeTraces.
+	ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (Response, error)
+}
+
+// ConsumeTracesWithResponseFunc is a helper function that is similar to ConsumeTracesWithResponse.
+type ConsumeTracesWithResponseFunc func(ctx context.Context, td ptrace.Traces) (Response, error)
+
+// ConsumeTracesWithResponse calls f(ctx, td).
+func (f ConsumeTracesWithResponseFunc) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (Response, error) {
+	return f(ctx, td)
+}
+
+// ConsumeTraces calls f(ctx, td), and discards the Response.
+func (f ConsumeTracesWithResponseFunc) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	_, err := f(ctx, td)
+	return err
+}
+
+type baseTracesWithResponse struct {
+	*baseImpl
+	ConsumeTracesWithResponseFunc
+}
+
+// NewTracesWithResponse returns a TracesWithResponse configured with the provided options.
+// Its capabilities report ReturnsResponse.
+func NewTracesWithResponse(consume ConsumeTracesWithResponseFunc, options ...Option) (TracesWithResponse, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	bs := newBaseImpl(options...)
+	bs.capabilities.ReturnsResponse = true
+	return &baseTracesWithResponse{
+		baseImpl:                      bs,
+		ConsumeTracesWithResponseFunc: consume,
+	}, nil
+}
+
+// ConsumeTracesWithResponse sends the ptrace.Traces to the next consumer, and returns the Response of the consumption of its
+// spans. If the next consumer does not return it, the Response is derived from the returned error.
+func ConsumeTracesWithResponse(ctx context.Context, next Traces, td ptrace.Traces) (Response, error) {
+	if next.Capabilities().ReturnsResponse {
+		if nextWithResponse, ok := next.(TracesWithResponse); ok {
+			return nextWithResponse.ConsumeTracesWithResponse(ctx, td)
+		}
+	}
+	// The items are counted before being consumed, as the next consumer may modify the data.
+	items := td.SpanCount()
+	return ResponseFromError(items, next.ConsumeTraces(ctx, td))
+}
@@ -9,8 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func TestDefaultTraces(t *testing.T) {
@@ -34,6 +37,15 @@ func TestWithCapabilitiesTraces(t *testing.T) {
 	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
 }
 
+func TestWithCapabilitiesTracesReturnsResponse(t *testing.T) {
+	// The consumers not returning the Response must not report it.
+	cp, err := NewTraces(
+		func(context.Context, ptrace.Traces) error { return nil },
+		WithCapabilities(Capabilities{MutatesData: true, ReturnsResponse: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, Capabilities{MutatesData: true}, cp.Capabilities())
+}
+
 func TestConsumeTraces(t *testing.T) {
 	consumeCalled := false
 	cp, err := NewTraces(func(context.Context, ptrace.Traces) error { consumeCalled = true; return nil })
@@ -48,3 +60,51 @@ func TestConsumeTraces_ReturnError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, cp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
 }
+
+func TestTracesWithResponse(t *testing.T) {
+	want := Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}
+	cp, err := NewTracesWithResponse(func(context.Context, ptrace.Traces) (Response, error) { return want, nil })
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{ReturnsResponse: true}, cp.Capabilities())
+	assert.NoError(t, cp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	rsp, err := ConsumeTracesWithResponse(context.Background(), cp, ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Equal(t, want, rsp)
+
+	_, err = NewTracesWithResponse(nil)
+	assert.Equal(t, errNilFunc, err)
+}
+
+func TestConsumeTracesWithResponseDerived(t *testing.T) {
+	data := testdata.GenerateTraces(2)
+	items := data.SpanCount()
+	tests := []struct {
+		name    string
+		err     error
+		wantRsp Response
+	}{
+		{
+			name:    "accepted",
+			wantRsp: Response{Accepted: items},
+		},
+		{
+			name:    "rejected",
+			err:     consumererror.NewPermanent(errors.New("invalid")),
+			wantRsp: Response{Rejected: items, ErrorMessage: "Permanent error: invalid"},
+		},
+		{
+			name: "retryable",
+			err:  errors.New("unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := NewTraces(func(context.Context, ptrace.Traces) error { return tt.err })
+			require.NoError(t, err)
+			rsp, err := ConsumeTracesWithResponse(context.Background(), cp, data)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantRsp, rsp)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter // import "go.opentelemetry.io/collector/exporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// DeliveryObserver is notified of the outcome of the exports, e.g. to audit the data delivered to the backends.
+// The exporters started with a context carrying a DeliveryObserver call it once the export of the data
+// succeeded, or definitively failed after the retries, and when the data is rejected by their sending queue.
+type DeliveryObserver interface {
+	// Delivered is called with the context of the export, the ID and data type of the exporter, the number
+	// of items, i.e. spans, metric data points or log records, and the error of the export, nil if it succeeded.
+	Delivered(ctx context.Context, id component.ID, dataType component.DataType, items int, err error)
+}
+
+type deliveryObserverKey struct{}
+
+// ContextWithDeliveryObserver returns a new context carrying the given DeliveryObserver, to be passed to the
+// start of the exporters.
+func ContextWithDeliveryObserver(ctx context.Context, o DeliveryObserver) context.Context {
+	return context.WithValue(ctx, deliveryObserverKey{}, o)
+}
+
+// DeliveryObserverFromContext returns the DeliveryObserver carried by the context, or nil.
+func DeliveryObserverFromContext(ctx context.Context) DeliveryObserver {
+	o, _ := ctx.Value(deliveryObserverKey{}).(DeliveryObserver)
+	return o
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type nopDeliveryObserver struct{}
+
+func (nopDeliveryObserver) Delivered(context.Context, component.ID, component.DataType, int, error) {}
+
+func TestDeliveryObserverContext(t *testing.T) {
+	assert.Nil(t, DeliveryObserverFromContext(context.Background()))
+	o := nopDeliveryObserver{}
+	assert.Equal(t, o, DeliveryObserverFromContext(ContextWithDeliveryObserver(context.Background(), o)))
+}
@@ -265,13 +265,16 @@ func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
 
-// logStabilityLevel logs the stability level of a component. The log level is set to info for
-// undefined, unmaintained, deprecated and development. The log level is set to debug
-// for alpha, beta and stable.
+// logStabilityLevel logs the stability level of a component. The log level is set to warn for
+// unmaintained, deprecated, development and alpha, and to info for undefined. The log level is set
+// to debug for beta and stable.
 func logStabilityLevel(logger *zap.Logger, sl component.StabilityLevel) {
-	if sl >= component.StabilityLevelAlpha {
+	switch {
+	case sl >= component.StabilityLevelBeta:
 		logger.Debug(sl.LogMessage())
-	} else {
+	case sl == component.StabilityLevelUndefined:
 		logger.Info(sl.LogMessage())
+	default:
+		logger.Warn(sl.LogMessage())
 	}
 }
@@ -11,6 +11,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/clock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
@@ -39,6 +40,7 @@ type batchSender struct {
 	activeBatch *batch
 
 	logger *zap.Logger
+	clock  clock.Clock
 
 	shutdownCh chan struct{}
 	stopped    *atomic.Bool
@@ -51,6 +53,7 @@ func newBatchSender(cfg exporterbatcher.Config, set exporter.CreateSettings,
 		activeBatch:    newEmptyBatch(),
 		cfg:            cfg,
 		logger:         set.Logger,
+		clock:          clock.System(),
 		mergeFunc:      mf,
 		mergeSplitFunc: msf,
 		shutdownCh:     make(chan struct{}),
@@ -61,7 +64,7 @@ func newBatchSender(cfg exporterbatcher.Config, set exporter.CreateSettings,
 }
 
 func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
-	timer := time.NewTimer(bs.cfg.FlushTimeout)
+	timer := bs.clock.NewTimer(bs.cfg.FlushTimeout)
 	go func() {
 		for {
 			select {
@@ -72,10 +75,10 @@ func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
 				}
 				bs.mu.Unlock()
 				if !timer.Stop() {
-					<-timer.C
+					<-timer.Chan()
 				}
 				return
-			case <-timer.C:
+			case <-timer.Chan():
 				bs.mu.Lock()
 				if bs.activeBatch.request != nil {
 					bs.exportActiveBatch()
@@ -84,7 +87,7 @@ func (bs *batchSender) Start(_ context.Context, _ component.Host) error {
 				timer.Reset(bs.cfg.FlushTimeout)
 			case <-bs.resetTimerCh:
 				if !timer.Stop() {
-					<-timer.C
+					<-timer.Chan()
 				}
 				timer.Reset(bs.cfg.FlushTimeout)
 			}
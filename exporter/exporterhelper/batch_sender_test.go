@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/clock/clocktest"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
@@ -79,6 +80,34 @@ func TestBatchSender_Merge(t *testing.T) {
 	}
 }
 
+func TestBatchSender_FlushTimeoutWithClock(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Now())
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 10
+	cfg.FlushTimeout = time.Hour
+	be := queueBatchExporter(t, WithBatcher(cfg, WithRequestBatchFuncs(fakeBatchMergeFunc, fakeBatchMergeSplitFunc)), WithClock(clk))
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	sink := newFakeRequestSink()
+	require.NoError(t, be.send(context.Background(), &fakeRequest{items: 3, sink: sink}))
+	assert.Eventually(t, func() bool {
+		return be.batchSender.(*batchSender).activeRequests.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	clk.Advance(time.Hour - time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, uint64(0), sink.requestsCount.Load())
+
+	// The batch is flushed once the flush timeout elapsed.
+	clk.Advance(time.Second)
+	assert.Eventually(t, func() bool {
+		return sink.requestsCount.Load() == 1 && sink.itemsCount.Load() == 3
+	}, time.Second, time.Millisecond)
+}
+
 func TestBatchSender_BatchExportError(t *testing.T) {
 	cfg := exporterbatcher.NewDefaultConfig()
 	cfg.MinSizeItems = 10
@@ -439,9 +468,9 @@ func TestBatchSender_WithBatcherOption(t *testing.T) {
 	}
 }
 
-func queueBatchExporter(t *testing.T, batchOption Option) *baseExporter {
-	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, batchOption,
-		WithRequestQueue(exporterqueue.NewDefaultConfig(), exporterqueue.NewMemoryQueueFactory[Request]()))
+func queueBatchExporter(t *testing.T, batchOption Option, opts ...Option) *baseExporter {
+	opts = append([]Option{batchOption, WithRequestQueue(exporterqueue.NewDefaultConfig(), exporterqueue.NewMemoryQueueFactory[Request]())}, opts...)
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, opts...)
 	require.NotNil(t, be)
 	require.NoError(t, err)
 	return be
@@ -5,17 +5,22 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/clock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/droplog"
 )
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -86,6 +91,50 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	}
 }
 
+// WithDuplicateTracking enables the detection of the data exported more than once, e.g. when
+// a request that timed out after being delivered is retried. The detection requires retries
+// to be enabled using WithRetry.
+// This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
+func WithDuplicateTracking(config DuplicateTrackingSettings) Option {
+	return func(o *baseExporter) error {
+		if !config.Enabled {
+			return nil
+		}
+		if err := config.Validate(); err != nil {
+			return err
+		}
+		dt, err := newDuplicateTracker(config, o.set, o.signal, o.marshaler)
+		if err != nil {
+			return err
+		}
+		o.duplicateTracker = dt
+		return nil
+	}
+}
+
+// WithRetryBudget limits the retries of the exporter with the given RetryBudget, which can be shared
+// by several exporters to limit their retries together. The requests are not retried anymore while
+// the budget is exhausted. The budget requires retries to be enabled using WithRetry.
+// This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
+func WithRetryBudget(budget *RetryBudget) Option {
+	return func(o *baseExporter) error {
+		o.retryBudget = budget
+		return nil
+	}
+}
+
+// WithThrottleStats keeps the statistics of the responses of the destination throttling the exports in the given
+// ThrottleStats, in which the exporter records the responses with ThrottleStats.RecordResponse. The delays requested
+// by the destination and honored before retrying are recorded when retries are enabled using WithRetry.
+// The statistics are served by the zpages extension at /debug/throttlez/<exporter>/<data type>.
+func WithThrottleStats(stats *ThrottleStats, destination string) Option {
+	return func(o *baseExporter) error {
+		o.throttleStats = stats
+		o.throttleDestination = destination
+		return nil
+	}
+}
+
 // WithQueue overrides the default QueueSettings for an exporter.
 // The default QueueSettings is to disable queueing.
 // This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
@@ -98,25 +147,52 @@ func WithQueue(config QueueSettings) Option {
 			o.exportFailureMessage += " Try enabling sending_queue to survive temporary failures."
 			return nil
 		}
-		qf := exporterqueue.NewPersistentQueueFactory[Request](config.StorageID, exporterqueue.PersistentQueueSettings[Request]{
-			Marshaler:   o.marshaler,
-			Unmarshaler: o.unmarshaler,
-		})
-		q := qf(context.Background(), exporterqueue.Settings{
-			DataType:         o.signal,
-			ExporterSettings: o.set,
-		}, exporterqueue.Config{
-			Enabled:      config.Enabled,
-			NumConsumers: config.NumConsumers,
-			QueueSize:    config.QueueSize,
-		})
-		o.queueSender = newQueueSender(q, o.set, config.NumConsumers, o.exportFailureMessage)
+		var q exporterqueue.Queue[Request]
+		if config.Fairness.Enabled {
+			q = queue.NewFairQueue[Request](queue.FairQueueSettings[Request]{
+				Sizer:    &queue.RequestSizer[Request]{},
+				Capacity: config.QueueSize,
+				KeyFunc:  pipelineKey,
+				Weights:  config.Fairness.Weights,
+			})
+		} else if config.KeepLatestCumulative && o.signal == component.DataTypeMetrics {
+			// The compactor merges the points into the queued requests without claiming any capacity,
+			// so the queue must be sized by requests, whatever the default sizer of the queues.
+			q = queue.NewBoundedMemoryQueue[Request](queue.MemoryQueueSettings[Request]{
+				Sizer:    &queue.RequestSizer[Request]{},
+				Capacity: config.QueueSize,
+			})
+		} else {
+			qf := exporterqueue.NewPersistentQueueFactory[Request](config.StorageID, exporterqueue.PersistentQueueSettings[Request]{
+				Marshaler:   o.marshaler,
+				Unmarshaler: o.unmarshaler,
+			})
+			q = qf(context.Background(), exporterqueue.Settings{
+				DataType:         o.signal,
+				ExporterSettings: o.set,
+			}, exporterqueue.Config{
+				Enabled:      config.Enabled,
+				NumConsumers: config.NumConsumers,
+				QueueSize:    config.QueueSize,
+			})
+		}
+		qs := newQueueSender(q, o.set, o.signal, config.NumConsumers, config.DrainTimeout, o.exportFailureMessage)
+		if config.Admin.Enabled {
+			qs.admin = newQueueAdmin(o.set.ID, o.signal, q, o.set.Logger, func() time.Time { return qs.clock.Now() },
+				qs.dropQueued)
+		}
+		if config.KeepLatestCumulative && o.signal == component.DataTypeMetrics {
+			qs.compactor = newCumulativeCompactor()
+			// The compactor removes the points merged into the queued requests from the data.
+			o.consumerOptions = append(o.consumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		}
+		o.queueSender = qs
 		return nil
 	}
 }
 
 // WithRequestQueue enables queueing for an exporter.
-// This option should be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
+// This option should be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter and NewRequestExporter.
 // Experimental: This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Factory[Request]) Option {
@@ -132,7 +208,18 @@ func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Facto
 			DataType:         o.signal,
 			ExporterSettings: o.set,
 		}
-		o.queueSender = newQueueSender(queueFactory(context.Background(), set, cfg), o.set, cfg.NumConsumers, o.exportFailureMessage)
+		o.queueSender = newQueueSender(queueFactory(context.Background(), set, cfg), o.set, o.signal, cfg.NumConsumers,
+			cfg.DrainTimeout, o.exportFailureMessage)
+		return nil
+	}
+}
+
+// WithClock overrides the clock.Clock used to wait before retrying the requests, to flush the batches and
+// to time the draining of the queue on shutdown, e.g. to use a clocktest.FakeClock in tests. The default is clock.System().
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithClock(c clock.Clock) Option {
+	return func(o *baseExporter) error {
+		o.clock = c
 		return nil
 	}
 }
@@ -166,8 +253,8 @@ func WithRequestBatchFuncs(mf exporterbatcher.BatchMergeFunc[Request], msf expor
 }
 
 // WithBatcher enables batching for an exporter based on custom request types.
-// For now, it can be used only with the New[Traces|Metrics|Logs]RequestExporter exporter helpers and
-// WithRequestBatchFuncs provided.
+// For now, it can be used only with the New[Traces|Metrics|Logs]RequestExporter and NewRequestExporter exporter
+// helpers and WithRequestBatchFuncs provided.
 // This API is at the early stage of development and may change without backward compatibility
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func WithBatcher(cfg exporterbatcher.Config, opts ...BatcherOption) Option {
@@ -227,6 +314,22 @@ type baseExporter struct {
 	marshaler   exporterqueue.Marshaler[Request]
 	unmarshaler exporterqueue.Unmarshaler[Request]
 
+	duplicateTracker *duplicateTracker
+	retryBudget      *RetryBudget
+	dropLog          *droplog.Logger
+
+	throttleStats       *ThrottleStats
+	throttleDestination string
+
+	warmUp WarmUpSettings
+	// emptyRequest builds the request of empty data exported to warm up the exporter, see WithWarmUp.
+	emptyRequest func(context.Context) (Request, error)
+
+	clock clock.Clock
+
+	// panics recovers from the panics of the exports once enabled, see RecoverPanics.
+	panics *panicRecovery
+
 	set    exporter.CreateSettings
 	obsrep *ObsReport
 
@@ -236,11 +339,12 @@ type baseExporter struct {
 	// Chain of senders that the exporter helper applies before passing the data to the actual exporter.
 	// The data is handled by each sender in the respective order starting from the queueSender.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
-	batchSender   requestSender
-	queueSender   requestSender
-	obsrepSender  requestSender
-	retrySender   requestSender
-	timeoutSender *timeoutSender // timeoutSender is always initialized.
+	batchSender    requestSender
+	queueSender    requestSender
+	obsrepSender   requestSender
+	deliverySender *deliverySender // deliverySender is always initialized.
+	retrySender    requestSender
+	timeoutSender  *timeoutSender // timeoutSender is always initialized.
 
 	consumerOptions []consumer.Option
 }
@@ -251,17 +355,21 @@ func newBaseExporter(set exporter.CreateSettings, signal component.DataType, osf
 		return nil, err
 	}
 
+	panics := &panicRecovery{}
 	be := &baseExporter{
 		signal: signal,
-
-		batchSender:   &baseRequestSender{},
-		queueSender:   &baseRequestSender{},
-		obsrepSender:  osf(obsReport),
-		retrySender:   &baseRequestSender{},
-		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings()},
-
-		set:    set,
-		obsrep: obsReport,
+		panics: panics,
+
+		batchSender:    &baseRequestSender{},
+		queueSender:    &baseRequestSender{},
+		obsrepSender:   osf(obsReport),
+		deliverySender: &deliverySender{id: set.ID, signal: signal},
+		retrySender:    &baseRequestSender{},
+		timeoutSender:  &timeoutSender{cfg: NewDefaultTimeoutSettings(), panics: panics},
+
+		set:     set,
+		obsrep:  obsReport,
+		dropLog: droplog.New(set.Logger),
 	}
 
 	for _, op := range options {
@@ -273,7 +381,30 @@ func newBaseExporter(set exporter.CreateSettings, signal component.DataType, osf
 
 	be.connectSenders()
 
+	if rs, ok := be.retrySender.(*retrySender); ok {
+		rs.duplicates = be.duplicateTracker
+		rs.budget = be.retryBudget
+		rs.throttleStats = be.throttleStats
+		rs.throttleDestination = be.throttleDestination
+		if be.clock != nil {
+			rs.clock = be.clock
+		}
+	}
+
+	if qs, ok := be.queueSender.(*queueSender); ok {
+		qs.panics = be.panics
+		if be.clock != nil {
+			qs.clock = be.clock
+		}
+		if rs, ok := be.retrySender.(*retrySender); ok && qs.admin != nil {
+			qs.admin.retry = rs
+		}
+	}
+
 	if bs, ok := be.batchSender.(*batchSender); ok {
+		if be.clock != nil {
+			bs.clock = be.clock
+		}
 		// If queue sender is enabled assign to the batch sender the same number of workers.
 		if qs, ok := be.queueSender.(*queueSender); ok {
 			bs.concurrencyLimit = uint64(qs.numConsumers)
@@ -285,21 +416,50 @@ func newBaseExporter(set exporter.CreateSettings, signal component.DataType, osf
 	return be, nil
 }
 
-// send sends the request using the first sender in the chain.
+// send sends the request using the first sender in the chain, with the metadata of the request in the context.
 func (be *baseExporter) send(ctx context.Context, req Request) error {
+	now := time.Now
+	if be.clock != nil {
+		now = be.clock.Now
+	}
+	ctx = contextWithRequestMetadata(ctx, newRequestMetadata(ctx, now()))
 	err := be.queueSender.send(ctx, req)
 	if err != nil {
 		be.set.Logger.Error("Exporting failed. Rejecting data."+be.exportFailureMessage,
 			zap.Error(err), zap.Int("rejected_items", req.ItemsCount()))
+		reason := "exporting failed"
+		if errors.Is(err, queue.ErrQueueIsFull) {
+			reason = "sending queue is full"
+		}
+		logDropped(be.dropLog, reason, req)
+		if _, ok := be.queueSender.(*queueSender); ok {
+			// The data rejected by the queue did not reach the deliverySender.
+			be.deliverySender.report(ctx, req.ItemsCount(), err)
+		}
 	}
 	return err
 }
 
+// logDropped logs a sampled summary of the data of a dropped request, see droplog.Logger.
+func logDropped(dl *droplog.Logger, reason string, req Request) {
+	switch r := req.(type) {
+	case *tracesRequest:
+		dl.Traces(reason, r.td)
+	case *metricsRequest:
+		dl.Metrics(reason, r.md)
+	case *logsRequest:
+		dl.Logs(reason, r.ld)
+	default:
+		dl.Items(reason, req.ItemsCount())
+	}
+}
+
 // connectSenders connects the senders in the predefined order.
 func (be *baseExporter) connectSenders() {
 	be.queueSender.setNextSender(be.batchSender)
 	be.batchSender.setNextSender(be.obsrepSender)
-	be.obsrepSender.setNextSender(be.retrySender)
+	be.obsrepSender.setNextSender(be.deliverySender)
+	be.deliverySender.setNextSender(be.retrySender)
 	be.retrySender.setNextSender(be.timeoutSender)
 }
 
@@ -309,6 +469,17 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if be.throttleStats != nil {
+		if err := registerThrottleStats(host, be.throttleStats, be.set.ID, be.signal, be.set.Logger); err != nil {
+			return err
+		}
+	}
+
+	be.deliverySender.observer = exporter.DeliveryObserverFromContext(ctx)
+
+	// Establish the connections before the data is sent by the queue and batch senders.
+	be.warmUpExport(ctx)
+
 	// If no error then start the batchSender.
 	if err := be.batchSender.Start(ctx, host); err != nil {
 		return err
@@ -319,13 +490,24 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 }
 
 func (be *baseExporter) Shutdown(ctx context.Context) error {
-	return multierr.Combine(
+	err := multierr.Combine(
 		// First shutdown the retry sender, so the queue sender can flush the queue without retries.
 		be.retrySender.Shutdown(ctx),
 		// Then shutdown the batch sender
 		be.batchSender.Shutdown(ctx),
 		// Then shutdown the queue sender.
-		be.queueSender.Shutdown(ctx),
-		// Last shutdown the wrapped exporter itself.
-		be.ShutdownFunc.Shutdown(ctx))
+		be.queueSender.Shutdown(ctx))
+	// The exporter handing its queued requests over is kept until the handover is flushed,
+	// to send the requests that are not taken over.
+	if qs, ok := be.queueSender.(*queueSender); ok {
+		if h := qs.handover.Load(); h != nil {
+			h.Retire(be.set.ID, be.signal, func(ctx context.Context, items []any) error {
+				qs.flush(ctx, items)
+				return be.ShutdownFunc.Shutdown(ctx)
+			})
+			return err
+		}
+	}
+	// Last shutdown the wrapped exporter itself.
+	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -20,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 var (
@@ -94,4 +97,26 @@ func TestBaseExporterLogging(t *testing.T) {
 	require.Error(t, sendErr)
 
 	require.Len(t, observed.FilterLevelExact(zap.ErrorLevel).All(), 1)
+	dropped := observed.FilterMessage("Dropped data.").All()
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "exporting failed", dropped[0].ContextMap()["reason"])
+	assert.Equal(t, int64(7), dropped[0].ContextMap()["items"])
+}
+
+func TestBaseExporterLoggingDroppedTraces(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	logger, observed := observer.New(zap.DebugLevel)
+	set.Logger = zap.New(logger)
+	bs, err := newBaseExporter(set, defaultType, newNoopObsrepSender)
+	require.NoError(t, err)
+	req := newTracesRequest(testdata.GenerateTraces(2), func(context.Context, ptrace.Traces) error {
+		return errors.New("some error")
+	})
+	require.Error(t, bs.send(context.Background(), req))
+
+	dropped := observed.FilterMessage("Dropped data.").All()
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "exporting failed", dropped[0].ContextMap()["reason"])
+	assert.Equal(t, int64(2), dropped[0].ContextMap()["spans"])
+	assert.Equal(t, []any{"0102030405060708090a0b0c0d0e0f10"}, dropped[0].ContextMap()["trace_ids"])
 }
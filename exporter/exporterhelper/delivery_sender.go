@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+// deliverySender is a requestSender reporting the outcome of the exports, once retried, to the
+// exporter.DeliveryObserver given to the start of the exporter, if any.
+type deliverySender struct {
+	baseRequestSender
+	id       component.ID
+	signal   component.DataType
+	observer exporter.DeliveryObserver
+}
+
+func (ds *deliverySender) send(ctx context.Context, req Request) error {
+	// Count before exporting, the export may modify the request.
+	items := req.ItemsCount()
+	err := ds.nextSender.send(ctx, req)
+	ds.report(ctx, items, err)
+	return err
+}
+
+func (ds *deliverySender) report(ctx context.Context, items int, err error) {
+	if ds.observer != nil {
+		ds.observer.Delivered(ctx, ds.id, ds.signal, items, err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+type delivery struct {
+	items  int
+	failed bool
+}
+
+type recordingDeliveryObserver struct {
+	mu         sync.Mutex
+	deliveries []delivery
+}
+
+func (o *recordingDeliveryObserver) Delivered(ctx context.Context, id component.ID, dataType component.DataType, items int, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := RequestMetadataFromContext(ctx)
+	if ok && id == defaultID && dataType == defaultType {
+		o.deliveries = append(o.deliveries, delivery{items: items, failed: err != nil})
+	}
+}
+
+func (o *recordingDeliveryObserver) get() []delivery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.deliveries
+}
+
+func TestDeliverySender(t *testing.T) {
+	errExport := consumererror.NewPermanent(errors.New("export failed"))
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 1
+	qCfg.NumConsumers = 1
+
+	tests := []struct {
+		name     string
+		options  []Option
+		requests []*mockRequest
+		want     []delivery
+	}{
+		{
+			name:     "delivered",
+			requests: []*mockRequest{newMockRequest(2, nil)},
+			want:     []delivery{{items: 2}},
+		},
+		{
+			name:     "delivered_after_retry",
+			options:  []Option{WithRetry(rCfg)},
+			requests: []*mockRequest{newMockRequest(2, errors.New("transient error"))},
+			// The outcome is reported once, after the successful retry.
+			want: []delivery{{items: 2}},
+		},
+		{
+			name:     "failed",
+			options:  []Option{WithRetry(rCfg)},
+			requests: []*mockRequest{newMockRequest(3, errExport)},
+			want:     []delivery{{items: 3, failed: true}},
+		},
+		{
+			name: "queued",
+			options: []Option{withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(qCfg)},
+			requests: []*mockRequest{newMockRequest(2, nil)},
+			want:     []delivery{{items: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, tt.options...)
+			require.NoError(t, err)
+			observer := &recordingDeliveryObserver{}
+			ctx := exporter.ContextWithDeliveryObserver(context.Background(), observer)
+			require.NoError(t, be.Start(ctx, componenttest.NewNopHost()))
+			for _, r := range tt.requests {
+				_ = be.send(context.Background(), r)
+			}
+			require.NoError(t, be.Shutdown(context.Background()))
+			assert.Equal(t, tt.want, observer.get())
+		})
+	}
+}
+
+func TestDeliverySenderRejectedByQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 0
+	qCfg.NumConsumers = 0
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})), WithQueue(qCfg))
+	require.NoError(t, err)
+	observer := &recordingDeliveryObserver{}
+	require.NoError(t, be.Start(exporter.ContextWithDeliveryObserver(context.Background(), observer), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.Error(t, be.send(context.Background(), newMockRequest(2, nil)))
+	assert.Equal(t, []delivery{{items: 2, failed: true}}, observer.get())
+}
+
+func TestDeliverySenderNoObserver(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender)
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	mockR := newMockRequest(2, nil)
+	require.NoError(t, be.send(context.Background(), mockR))
+	mockR.checkNumRequests(t, 1)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// DuplicateTrackingSettings defines configuration for detecting data exported more than once,
+// e.g. when a request that timed out after being delivered is retried.
+type DuplicateTrackingSettings struct {
+	// Enabled indicates whether to track the requests exported more than once.
+	Enabled bool `mapstructure:"enabled"`
+	// SamplingRatio is the ratio of the requests being tracked, in the range (0, 1].
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+	// Capacity is the maximum number of tracked requests remembered to detect duplicates.
+	Capacity int `mapstructure:"capacity"`
+}
+
+// NewDefaultDuplicateTrackingSettings returns the default settings for DuplicateTrackingSettings.
+func NewDefaultDuplicateTrackingSettings() DuplicateTrackingSettings {
+	return DuplicateTrackingSettings{
+		Enabled:       false,
+		SamplingRatio: 0.1,
+		Capacity:      10000,
+	}
+}
+
+// Validate checks if the DuplicateTrackingSettings configuration is valid
+func (cfg *DuplicateTrackingSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.SamplingRatio <= 0 || cfg.SamplingRatio > 1 {
+		return errors.New("sampling ratio must be in the range (0, 1]")
+	}
+
+	if cfg.Capacity <= 0 {
+		return errors.New("capacity must be positive")
+	}
+
+	return nil
+}
+
+// duplicateTracker detects the requests exported more than once. A sampled request is identified
+// by the hash of its serialized form, which is remembered once an attempt to export it succeeded,
+// or timed out since the data may have been delivered anyway. Any later attempt to export a request
+// with the same hash is reported as a duplicate.
+type duplicateTracker struct {
+	samplingRatio float64
+	marshaler     exporterqueue.Marshaler[Request]
+	random        func() float64
+
+	duplicateItems metric.Int64Counter
+	otelAttrs      metric.MeasurementOption
+
+	mu        sync.Mutex
+	delivered map[uint64]struct{}
+	// hashes is a ring buffer of the delivered hashes, used to evict the oldest ones.
+	hashes []uint64
+	next   int
+}
+
+func newDuplicateTracker(cfg DuplicateTrackingSettings, set exporter.CreateSettings, signal component.DataType,
+	marshaler exporterqueue.Marshaler[Request]) (*duplicateTracker, error) {
+	if marshaler == nil {
+		return nil, errors.New("duplicate tracking is not supported by the new request exporters")
+	}
+
+	var key, desc string
+	switch signal {
+	case component.DataTypeTraces:
+		key, desc = obsmetrics.DuplicateSpansKey, "Number of spans exported more than once."
+	case component.DataTypeMetrics:
+		key, desc = obsmetrics.DuplicateMetricPointsKey, "Number of metric points exported more than once."
+	default:
+		key, desc = obsmetrics.DuplicateLogRecordsKey, "Number of log records exported more than once."
+	}
+
+	meter := set.MeterProvider.Meter(exporterScope)
+	duplicateItems, err := meter.Int64Counter(
+		obsmetrics.ExporterMetricPrefix+key,
+		metric.WithDescription(desc),
+		metric.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &duplicateTracker{
+		samplingRatio:  cfg.SamplingRatio,
+		marshaler:      marshaler,
+		random:         rand.Float64,
+		duplicateItems: duplicateItems,
+		otelAttrs:      metric.WithAttributes(attribute.String(obsmetrics.ExporterKey, set.ID.String())),
+		delivered:      make(map[uint64]struct{}, cfg.Capacity),
+		hashes:         make([]uint64, 0, cfg.Capacity),
+	}, nil
+}
+
+// sample decides whether the request is tracked, and returns its hash if so.
+func (dt *duplicateTracker) sample(req Request) (uint64, bool) {
+	if dt.random() >= dt.samplingRatio {
+		return 0, false
+	}
+	return dt.hash(req)
+}
+
+// hash returns the hash of the serialized request.
+func (dt *duplicateTracker) hash(req Request) (uint64, bool) {
+	buf, err := dt.marshaler(req)
+	if err != nil {
+		return 0, false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(buf)
+	return h.Sum64(), true
+}
+
+// recordAttempt reports the request as duplicate if a request with the same hash was already delivered.
+func (dt *duplicateTracker) recordAttempt(ctx context.Context, hash uint64, req Request) {
+	dt.mu.Lock()
+	_, ok := dt.delivered[hash]
+	dt.mu.Unlock()
+	if ok {
+		dt.duplicateItems.Add(ctx, int64(req.ItemsCount()), dt.otelAttrs)
+	}
+}
+
+// recordResult remembers the hash of the request if the export attempt may have delivered the data.
+func (dt *duplicateTracker) recordResult(hash uint64, err error) {
+	if err != nil && !isTimeout(err) {
+		return
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if _, ok := dt.delivered[hash]; ok {
+		return
+	}
+	if len(dt.hashes) < cap(dt.hashes) {
+		dt.hashes = append(dt.hashes, hash)
+	} else {
+		delete(dt.delivered, dt.hashes[dt.next])
+		dt.hashes[dt.next] = hash
+		dt.next = (dt.next + 1) % len(dt.hashes)
+	}
+	dt.delivered[hash] = struct{}{}
+}
+
+// isTimeout returns whether the error is caused by a timeout, in which case the data may have been delivered.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return true
+	}
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestDuplicateTrackingSettings_Validate(t *testing.T) {
+	cfg := NewDefaultDuplicateTrackingSettings()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SamplingRatio = 0
+	assert.EqualError(t, cfg.Validate(), "sampling ratio must be in the range (0, 1]")
+
+	cfg.SamplingRatio = 1.5
+	assert.EqualError(t, cfg.Validate(), "sampling ratio must be in the range (0, 1]")
+
+	cfg.SamplingRatio = 1
+	cfg.Capacity = 0
+	assert.EqualError(t, cfg.Validate(), "capacity must be positive")
+
+	// Invalid settings are ignored when disabled.
+	cfg.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func duplicateItems(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var total int64
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += dp.Value
+			}
+			return total
+		}
+	}
+	return 0
+}
+
+func TestDuplicateTracker(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	dt, err := newDuplicateTracker(DuplicateTrackingSettings{Enabled: true, SamplingRatio: 1, Capacity: 2},
+		set, component.DataTypeTraces, tracesRequestMarshaler)
+	require.NoError(t, err)
+	metricName := obsmetrics.ExporterMetricPrefix + obsmetrics.DuplicateSpansKey
+	ctx := context.Background()
+
+	req := newTracesRequest(testdata.GenerateTraces(2), nil)
+	hash, ok := dt.sample(req)
+	require.True(t, ok)
+
+	// A failed attempt does not deliver the data.
+	dt.recordAttempt(ctx, hash, req)
+	dt.recordResult(hash, errors.New("connection refused"))
+	dt.recordAttempt(ctx, hash, req)
+	assert.Zero(t, duplicateItems(t, reader, metricName))
+
+	// A timed out attempt may have delivered the data.
+	dt.recordResult(hash, status.Error(codes.DeadlineExceeded, "timeout"))
+	dt.recordAttempt(ctx, hash, req)
+	assert.EqualValues(t, 2, duplicateItems(t, reader, metricName))
+
+	// A request with the same content is a duplicate as well.
+	sameReq := newTracesRequest(testdata.GenerateTraces(2), nil)
+	sameHash, ok := dt.sample(sameReq)
+	require.True(t, ok)
+	assert.Equal(t, hash, sameHash)
+	dt.recordAttempt(ctx, sameHash, sameReq)
+	assert.EqualValues(t, 4, duplicateItems(t, reader, metricName))
+
+	// The oldest hashes are evicted when the capacity is reached.
+	for _, n := range []int{3, 4} {
+		otherReq := newTracesRequest(testdata.GenerateTraces(n), nil)
+		otherHash, ok := dt.sample(otherReq)
+		require.True(t, ok)
+		dt.recordResult(otherHash, nil)
+	}
+	dt.recordAttempt(ctx, hash, req)
+	assert.EqualValues(t, 4, duplicateItems(t, reader, metricName))
+}
+
+func TestDuplicateTrackerSampling(t *testing.T) {
+	dt, err := newDuplicateTracker(DuplicateTrackingSettings{Enabled: true, SamplingRatio: 0.5, Capacity: 2},
+		exportertest.NewNopCreateSettings(), component.DataTypeLogs, logsRequestMarshaler)
+	require.NoError(t, err)
+	req := newLogsRequest(testdata.GenerateLogs(1), nil)
+
+	dt.random = func() float64 { return 0.7 }
+	_, ok := dt.sample(req)
+	assert.False(t, ok)
+
+	dt.random = func() float64 { return 0.2 }
+	_, ok = dt.sample(req)
+	assert.True(t, ok)
+}
+
+func TestDuplicateTrackingRequestExporter(t *testing.T) {
+	_, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		WithDuplicateTracking(DuplicateTrackingSettings{Enabled: true, SamplingRatio: 1, Capacity: 10}))
+	assert.Error(t, err)
+}
+
+func TestQueuedRetry_DuplicateTracking(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopCreateSettings()
+	set.ID = defaultID
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	be, err := newBaseExporter(set, component.DataTypeMetrics, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithRetry(rCfg), WithDuplicateTracking(DuplicateTrackingSettings{Enabled: true, SamplingRatio: 1, Capacity: 10}))
+	require.NoError(t, err)
+
+	// The first attempt times out, the retry of the remaining item is reported as a duplicate.
+	mockR := newMockRequest(3, context.DeadlineExceeded)
+	require.NoError(t, be.send(context.Background(), mockR))
+	mockR.checkNumRequests(t, 2)
+	assert.EqualValues(t, 1, duplicateItems(t, reader, obsmetrics.ExporterMetricPrefix+obsmetrics.DuplicateMetricPointsKey))
+}
@@ -122,6 +122,9 @@ func NewLogsRequestExporter(
 	if err != nil {
 		return nil, err
 	}
+	be.emptyRequest = func(ctx context.Context) (Request, error) {
+		return converter(ctx, plog.NewLogs())
+	}
 
 	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		req, cErr := converter(ctx, ld)
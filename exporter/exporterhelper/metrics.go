@@ -122,6 +122,9 @@ func NewMetricsRequestExporter(
 	if err != nil {
 		return nil, err
 	}
+	be.emptyRequest = func(ctx context.Context) (Request, error) {
+		return converter(ctx, pmetric.NewMetrics())
+	}
 
 	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		req, cErr := converter(ctx, md)
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// streamKey identifies a stream of cumulative points: the resource, the scope, the metric,
+// the attributes and the start timestamp of the points. Including the start timestamp keeps
+// the points of a restarted stream, since the newer points do not include their values.
+type streamKey [16]byte
+
+// cumulativeCompactor keeps only the latest cumulative point of each stream in the queue:
+// when a request is enqueued, its points of the streams already queued replace the older
+// points in the queued requests, and only the points of the new streams are enqueued. Since
+// a cumulative point includes the values of the previous points of its stream, the backend
+// receives the same totals once the queue is drained, and the queue does not grow while the
+// same streams are reported during an outage.
+//
+// The queue must be sized by requests, see WithQueue, since the points merged into a queued
+// request do not claim any capacity of the queue.
+type cumulativeCompactor struct {
+	mu sync.Mutex
+	// latest maps the streams to their point in the queued requests.
+	latest map[streamKey]queuedPoint
+	// queued maps the queued requests to the streams of their cumulative points.
+	queued map[*metricsRequest][]streamKey
+}
+
+// queuedPoint is the point of a stream in a queued request.
+type queuedPoint struct {
+	req *metricsRequest
+	// point is a pmetric.NumberDataPoint, HistogramDataPoint, ExponentialHistogramDataPoint or SummaryDataPoint.
+	point any
+}
+
+func newCumulativeCompactor() *cumulativeCompactor {
+	return &cumulativeCompactor{
+		latest: map[streamKey]queuedPoint{},
+		queued: map[*metricsRequest][]streamKey{},
+	}
+}
+
+// enqueue replaces the points of the queued requests by the points of the request of the same
+// streams, then enqueues the rest of the request with the offer function. It returns false if
+// the request was entirely merged into the queued requests, and was not enqueued. The requests
+// other than metricsRequest are enqueued as is.
+func (c *cumulativeCompactor) enqueue(req Request, offer func() error) (bool, error) {
+	mr, ok := req.(*metricsRequest)
+	if !ok {
+		return true, offer()
+	}
+	// The lock is held while offering, so that the request is not dequeued before it is tracked.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := fnv.New128a()
+	numPoints := mr.md.DataPointCount()
+	forEachCumulativePoint(mr.md, h, func(key streamKey, point any) bool {
+		prev, ok := c.latest[key]
+		if !ok {
+			return true
+		}
+		copyPoint(point, prev.point)
+		return false
+	})
+	if numPoints > 0 && mr.md.DataPointCount() == 0 {
+		return false, nil
+	}
+
+	if err := offer(); err != nil {
+		return true, err
+	}
+	var keys []streamKey
+	forEachCumulativePoint(mr.md, h, func(key streamKey, point any) bool {
+		if _, ok := c.latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		// The last point of a stream repeated in the request is the latest one.
+		c.latest[key] = queuedPoint{req: mr, point: point}
+		return true
+	})
+	if len(keys) > 0 {
+		c.queued[mr] = keys
+	}
+	return true, nil
+}
+
+// dequeue stops tracking the request, which is about to be exported.
+func (c *cumulativeCompactor) dequeue(req Request) {
+	mr, ok := req.(*metricsRequest)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range c.queued[mr] {
+		if c.latest[key].req == mr {
+			delete(c.latest, key)
+		}
+	}
+	delete(c.queued, mr)
+}
+
+// copyPoint replaces the queued point dst by the newer point src of the same stream.
+func copyPoint(src, dst any) {
+	switch dst := dst.(type) {
+	case pmetric.NumberDataPoint:
+		src.(pmetric.NumberDataPoint).CopyTo(dst)
+	case pmetric.HistogramDataPoint:
+		src.(pmetric.HistogramDataPoint).CopyTo(dst)
+	case pmetric.ExponentialHistogramDataPoint:
+		src.(pmetric.ExponentialHistogramDataPoint).CopyTo(dst)
+	case pmetric.SummaryDataPoint:
+		src.(pmetric.SummaryDataPoint).CopyTo(dst)
+	}
+}
+
+// forEachCumulativePoint calls keep with the stream and the point of each cumulative point of the metrics, i.e. the points of
+// the cumulative sums, histograms and exponential histograms, and of the summaries. The points for which keep
+// returns false are removed, as well as the metrics, scopes and resources left without points by the removal.
+func forEachCumulativePoint(md pmetric.Metrics, h hash.Hash, keep func(streamKey, any) bool) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		sms := rm.ScopeMetrics()
+		numScopes := sms.Len()
+		sms.RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			ms := sm.Metrics()
+			numMetrics := ms.Len()
+			ms.RemoveIf(func(m pmetric.Metric) bool {
+				keepPoint := func(point any, attrs pcommon.Map, start pcommon.Timestamp) bool {
+					h.Reset()
+					writeMap(h, rm.Resource().Attributes())
+					writeString(h, sm.Scope().Name())
+					writeString(h, sm.Scope().Version())
+					writeMap(h, sm.Scope().Attributes())
+					writeString(h, m.Name())
+					writeString(h, m.Type().String())
+					writeMap(h, attrs)
+					_, _ = h.Write(binary.LittleEndian.AppendUint64(nil, uint64(start)))
+					var key streamKey
+					h.Sum(key[:0])
+					return keep(key, point)
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					if m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+					dps := m.Sum().DataPoints()
+					numPoints := dps.Len()
+					dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return !keepPoint(dp, dp.Attributes(), dp.StartTimestamp())
+					})
+					return numPoints > 0 && dps.Len() == 0
+				case pmetric.MetricTypeHistogram:
+					if m.Histogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+					dps := m.Histogram().DataPoints()
+					numPoints := dps.Len()
+					dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						return !keepPoint(dp, dp.Attributes(), dp.StartTimestamp())
+					})
+					return numPoints > 0 && dps.Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					if m.ExponentialHistogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+					dps := m.ExponentialHistogram().DataPoints()
+					numPoints := dps.Len()
+					dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						return !keepPoint(dp, dp.Attributes(), dp.StartTimestamp())
+					})
+					return numPoints > 0 && dps.Len() == 0
+				case pmetric.MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					numPoints := dps.Len()
+					dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+						return !keepPoint(dp, dp.Attributes(), dp.StartTimestamp())
+					})
+					return numPoints > 0 && dps.Len() == 0
+				}
+				return false
+			})
+			return numMetrics > 0 && ms.Len() == 0
+		})
+		return numScopes > 0 && sms.Len() == 0
+	})
+}
+
+// writeMap writes the attributes sorted by key, so that their order does not matter.
+func writeMap(h hash.Hash, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		writeString(h, k)
+		writeString(h, v.AsString())
+	}
+	// Separate the attributes from the next fields.
+	_, _ = h.Write([]byte{0xff})
+}
+
+func writeString(h hash.Hash, s string) {
+	_, _ = h.Write([]byte(s))
+	_, _ = h.Write([]byte{0})
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newCumulativeMetrics returns metrics with a point of a cumulative sum for each host, a point of a gauge,
+// and a point of a delta sum.
+func newCumulativeMetrics(value int64, start pcommon.Timestamp, hosts ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, host := range hosts {
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", host)
+		dp.SetStartTimestamp(start)
+		dp.SetIntValue(value)
+	}
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(value)
+	delta := sm.Metrics().AppendEmpty()
+	delta.SetName("errors")
+	delta.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	delta.Sum().DataPoints().AppendEmpty().SetIntValue(value)
+	return md
+}
+
+// newOnlyCumulativeMetrics returns metrics with a point of a cumulative sum for each host.
+func newOnlyCumulativeMetrics(value int64, start pcommon.Timestamp, hosts ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	newCumulativeMetrics(value, start, hosts...).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).CopyTo(
+		md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty())
+	return md
+}
+
+func sumValue(md pmetric.Metrics, i int) int64 {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(i).IntValue()
+}
+
+func TestCumulativeCompactor(t *testing.T) {
+	c := newCumulativeCompactor()
+	var queued []Request
+	offer := func(req Request) func() error {
+		return func() error {
+			queued = append(queued, req)
+			return nil
+		}
+	}
+
+	first := newMetricsRequest(newCumulativeMetrics(1, 10, "a", "b"), nil)
+	enqueued, err := c.enqueue(first, offer(first))
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+	second := newMetricsRequest(newCumulativeMetrics(2, 10, "a"), nil)
+	enqueued, err = c.enqueue(second, offer(second))
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+	// The point of the stream "a" replaces the queued point, the gauge and delta points are enqueued.
+	assert.Equal(t, 4, first.ItemsCount())
+	assert.Equal(t, int64(2), sumValue(first.(*metricsRequest).md, 0))
+	assert.Equal(t, int64(1), sumValue(first.(*metricsRequest).md, 1))
+	assert.Equal(t, 2, second.ItemsCount())
+
+	// The points of a restarted stream do not replace the previous points.
+	restarted := newMetricsRequest(newCumulativeMetrics(1, 20, "a", "b"), nil)
+	_, err = c.enqueue(restarted, offer(restarted))
+	require.NoError(t, err)
+	assert.Equal(t, 4, restarted.ItemsCount())
+
+	// A dequeued request is not modified anymore.
+	c.dequeue(restarted)
+	third := newMetricsRequest(newCumulativeMetrics(2, 20, "a", "b"), nil)
+	_, err = c.enqueue(third, offer(third))
+	require.NoError(t, err)
+	assert.Equal(t, 4, third.ItemsCount())
+	assert.Equal(t, int64(1), sumValue(restarted.(*metricsRequest).md, 0))
+	assert.Len(t, queued, 4)
+
+	// The points merged into the queued requests are kept if the rest of the request is not enqueued.
+	errFull := errors.New("queue is full")
+	fourth := newMetricsRequest(newCumulativeMetrics(3, 20, "a", "b"), nil)
+	_, err = c.enqueue(fourth, func() error { return errFull })
+	assert.Equal(t, errFull, err)
+	assert.Equal(t, 2, fourth.ItemsCount())
+	assert.Equal(t, int64(3), sumValue(third.(*metricsRequest).md, 0))
+
+	// A request entirely merged into the queued requests is not enqueued.
+	onlyCumulative := newMetricsRequest(newOnlyCumulativeMetrics(4, 20, "a"), nil)
+	enqueued, err = c.enqueue(onlyCumulative, func() error {
+		require.Fail(t, "the request must not be enqueued")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, enqueued)
+	assert.Equal(t, 0, onlyCumulative.(*metricsRequest).md.ResourceMetrics().Len())
+	assert.Equal(t, int64(4), sumValue(third.(*metricsRequest).md, 0))
+}
+
+func TestCumulativeCompactorAttributesOrder(t *testing.T) {
+	c := newCumulativeCompactor()
+	first := newCumulativeMetrics(1, 10, "a")
+	first.ResourceMetrics().At(0).Resource().Attributes().PutStr("k1", "v1")
+	first.ResourceMetrics().At(0).Resource().Attributes().PutStr("k2", "v2")
+	second := newCumulativeMetrics(2, 10, "a")
+	second.ResourceMetrics().At(0).Resource().Attributes().PutStr("k2", "v2")
+	second.ResourceMetrics().At(0).Resource().Attributes().PutStr("k1", "v1")
+
+	_, err := c.enqueue(newMetricsRequest(first, nil), func() error { return nil })
+	require.NoError(t, err)
+	secondReq := newMetricsRequest(second, nil)
+	_, err = c.enqueue(secondReq, func() error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, 2, secondReq.ItemsCount())
+	assert.Equal(t, int64(2), sumValue(first, 0))
+}
+
+func newBlockedMetricsExporter(t *testing.T, qCfg QueueSettings) (*metricsExporter, chan struct{}, func() []pmetric.Metrics) {
+	var mu sync.Mutex
+	var exported []pmetric.Metrics
+	started := make(chan struct{})
+	gate := make(chan struct{})
+	pusher := func(_ context.Context, md pmetric.Metrics) error {
+		mu.Lock()
+		first := len(exported) == 0
+		exported = append(exported, md)
+		mu.Unlock()
+		if first {
+			close(started)
+			<-gate
+		}
+		return nil
+	}
+
+	qCfg.NumConsumers = 1
+	qCfg.KeepLatestCumulative = true
+	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeMetricsExporterConfig,
+		pusher, WithQueue(qCfg))
+	require.NoError(t, err)
+	assert.True(t, me.Capabilities().MutatesData)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+	return me.(*metricsExporter), gate, func() []pmetric.Metrics {
+		<-started
+		mu.Lock()
+		defer mu.Unlock()
+		return exported
+	}
+}
+
+func TestMetricsExporter_KeepLatestCumulative(t *testing.T) {
+	me, gate, exported := newBlockedMetricsExporter(t, NewDefaultQueueSettings())
+
+	// The first request is being exported while the others are queued.
+	require.NoError(t, me.ConsumeMetrics(context.Background(), newCumulativeMetrics(1, 10, "a", "b")))
+	require.Len(t, exported(), 1)
+	for i := int64(2); i <= 4; i++ {
+		require.NoError(t, me.ConsumeMetrics(context.Background(), newCumulativeMetrics(i, 10, "a", "b")))
+	}
+	close(gate)
+	require.NoError(t, me.Shutdown(context.Background()))
+
+	// The cumulative points of the third and fourth requests replaced the points of the second request,
+	// their gauge and delta points are enqueued.
+	mds := exported()
+	require.Len(t, mds, 4)
+	assert.Equal(t, 4, mds[0].DataPointCount())
+	assert.Equal(t, 4, mds[1].DataPointCount())
+	assert.Equal(t, int64(4), sumValue(mds[1], 0))
+	assert.Equal(t, int64(4), sumValue(mds[1], 1))
+	assert.Equal(t, 2, mds[2].DataPointCount())
+	assert.Equal(t, 2, mds[3].DataPointCount())
+}
+
+func TestMetricsExporter_KeepLatestCumulativeBoundsQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.QueueSize = 3
+	me, gate, exported := newBlockedMetricsExporter(t, qCfg)
+
+	// The queue does not grow while the same streams are reported, past queue_size intervals.
+	require.NoError(t, me.ConsumeMetrics(context.Background(), newOnlyCumulativeMetrics(1, 10, "a", "b")))
+	require.Len(t, exported(), 1)
+	for i := int64(2); i <= 10*int64(qCfg.QueueSize); i++ {
+		require.NoError(t, me.ConsumeMetrics(context.Background(), newOnlyCumulativeMetrics(i, 10, "a", "b")))
+	}
+	assert.Equal(t, 1, me.queueSender.(*queueSender).queue.Size())
+	// The points of the new streams are still enqueued.
+	require.NoError(t, me.ConsumeMetrics(context.Background(), newOnlyCumulativeMetrics(1, 10, "c")))
+	assert.Equal(t, 2, me.queueSender.(*queueSender).queue.Size())
+
+	close(gate)
+	require.NoError(t, me.Shutdown(context.Background()))
+	mds := exported()
+	require.Len(t, mds, 3)
+	assert.Equal(t, int64(30), sumValue(mds[1], 0))
+	assert.Equal(t, int64(30), sumValue(mds[1], 1))
+	assert.Equal(t, 1, mds[2].DataPointCount())
+}
@@ -5,6 +5,7 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -15,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
@@ -40,6 +42,8 @@ type ObsReport struct {
 	sentLogRecords              metric.Int64Counter
 	failedToSendLogRecords      metric.Int64Counter
 	failedToEnqueueLogRecords   metric.Int64Counter
+	failedToSendRequests        metric.Int64Counter
+	sendLatency                 metric.Float64Histogram
 }
 
 // ObsReportSettings are settings for creating an ObsReport.
@@ -131,6 +135,18 @@ func (or *ObsReport) createOtelMetrics(cfg ObsReportSettings) error {
 		metric.WithUnit("1"))
 	errors = multierr.Append(errors, err)
 
+	or.failedToSendRequests, err = meter.Int64Counter(
+		obsmetrics.ExporterMetricPrefix+obsmetrics.FailedToSendRequestsKey,
+		metric.WithDescription("Number of requests failed to be sent to destination, by error category."),
+		metric.WithUnit("1"))
+	errors = multierr.Append(errors, err)
+
+	or.sendLatency, err = meter.Float64Histogram(
+		obsmetrics.ExporterMetricPrefix+obsmetrics.SendLatencyKey,
+		metric.WithDescription("Duration of the operations to send data to destination, including retries."),
+		metric.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -144,7 +160,7 @@ func (or *ObsReport) StartTracesOp(ctx context.Context) context.Context {
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (or *ObsReport) EndTracesOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeTraces, numSent, numFailedToSend)
+	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeTraces, numSent, numFailedToSend, err)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
 }
 
@@ -159,7 +175,7 @@ func (or *ObsReport) StartMetricsOp(ctx context.Context) context.Context {
 // StartMetricsOp.
 func (or *ObsReport) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeMetrics, numSent, numFailedToSend)
+	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeMetrics, numSent, numFailedToSend, err)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
@@ -173,19 +189,21 @@ func (or *ObsReport) StartLogsOp(ctx context.Context) context.Context {
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (or *ObsReport) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeLogs, numSent, numFailedToSend)
+	or.recordMetrics(noCancellationContext{Context: ctx}, component.DataTypeLogs, numSent, numFailedToSend, err)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
+type startTimeKey struct{}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (or *ObsReport) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := or.spanNamePrefix + operationSuffix
 	ctx, _ = or.tracer.Start(ctx, spanName)
-	return ctx
+	return context.WithValue(ctx, startTimeKey{}, time.Now())
 }
 
-func (or *ObsReport) recordMetrics(ctx context.Context, dataType component.DataType, sent, failed int64) {
+func (or *ObsReport) recordMetrics(ctx context.Context, dataType component.DataType, sent, failed int64, err error) {
 	if or.level == configtelemetry.LevelNone {
 		return
 	}
@@ -204,6 +222,18 @@ func (or *ObsReport) recordMetrics(ctx context.Context, dataType component.DataT
 
 	sentMeasure.Add(ctx, sent, metric.WithAttributes(or.otelAttrs...))
 	failedMeasure.Add(ctx, failed, metric.WithAttributes(or.otelAttrs...))
+	if err != nil {
+		attrs := make([]attribute.KeyValue, 0, len(or.otelAttrs)+1)
+		attrs = append(attrs, or.otelAttrs...)
+		attrs = append(attrs, attribute.String(obsmetrics.ErrorCategoryKey, consumererror.Classify(err).String()))
+		or.failedToSendRequests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	// The context carries the span of the operation, so the latency can be linked to
+	// the corresponding trace through an exemplar when exemplars are enabled.
+	if start, ok := ctx.Value(startTimeKey{}).(time.Time); ok {
+		or.sendLatency.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), metric.WithAttributes(or.otelAttrs...))
+	}
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
@@ -5,17 +5,26 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -231,6 +240,73 @@ func TestCheckExporterLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckExporterLogs(0, 7))
 }
 
+func TestExportSendLatency(t *testing.T) {
+	t.Setenv("OTEL_GO_X_EXEMPLAR", "true")
+	reader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelNormal
+	set.TracerProvider = sdktrace.NewTracerProvider()
+
+	obsrep, err := NewObsReport(ObsReportSettings{ExporterID: exporterID, ExporterCreateSettings: set})
+	require.NoError(t, err)
+	ctx := obsrep.StartTracesOp(context.Background())
+	obsrep.EndTracesOp(ctx, 7, nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var latency *metricdata.Histogram[float64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == obsmetrics.ExporterMetricPrefix+obsmetrics.SendLatencyKey {
+				h := m.Data.(metricdata.Histogram[float64])
+				latency = &h
+			}
+		}
+	}
+	require.NotNil(t, latency)
+	require.Len(t, latency.DataPoints, 1)
+	assert.Equal(t, uint64(1), latency.DataPoints[0].Count)
+
+	// The latency is linked to the trace of the operation.
+	spanCtx := trace.SpanContextFromContext(ctx)
+	require.Len(t, latency.DataPoints[0].Exemplars, 1)
+	assert.Equal(t, spanCtx.TraceID().String(), hex.EncodeToString(latency.DataPoints[0].Exemplars[0].TraceID))
+}
+
+func TestExportFailedRequestsByCategory(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelNormal
+
+	obsrep, err := NewObsReport(ObsReportSettings{ExporterID: exporterID, ExporterCreateSettings: set})
+	require.NoError(t, err)
+	obsrep.EndTracesOp(obsrep.StartTracesOp(context.Background()), 7, nil)
+	obsrep.EndTracesOp(obsrep.StartTracesOp(context.Background()), 7, errFake)
+	obsrep.EndMetricsOp(obsrep.StartMetricsOp(context.Background()), 7, consumererror.NewPermanent(errFake))
+	obsrep.EndLogsOp(obsrep.StartLogsOp(context.Background()), 7, NewThrottleRetry(errFake, time.Second))
+	obsrep.EndLogsOp(obsrep.StartLogsOp(context.Background()), 7, consumererror.NewPermanent(errFake))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	failed := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != obsmetrics.ExporterMetricPrefix+obsmetrics.FailedToSendRequestsKey {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				exporterAttr, _ := dp.Attributes.Value(obsmetrics.ExporterKey)
+				assert.Equal(t, exporterID.String(), exporterAttr.AsString())
+				category, _ := dp.Attributes.Value(obsmetrics.ErrorCategoryKey)
+				failed[category.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"retryable": 1, "permanent": 2, "throttled": 1}, failed)
+}
+
 type testParams struct {
 	items int
 	err   error
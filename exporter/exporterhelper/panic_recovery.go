@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// errPanicked refuses the requests once the exporter panicked, since its state may have been corrupted by the panic.
+var errPanicked = errors.New("the exporter refuses the data after a panic")
+
+// panicRecovery recovers from the panics raised while the requests are exported, once enabled with RecoverPanics.
+// It is shared by the queue consumers and the timeout sender, so that the panics raised in the goroutines of the
+// sending queue are recovered as well as the panics raised while exporting synchronously.
+type panicRecovery struct {
+	onPanic  atomic.Pointer[func(any)]
+	panicked atomic.Bool
+}
+
+// call calls export, and recovers from its panic if enabled: the request is then failed with a permanent error,
+// and the following requests are refused without calling export until the exporter is restarted. A nil
+// panicRecovery never recovers.
+func (pr *panicRecovery) call(export func() error) (err error) {
+	if pr == nil {
+		return export()
+	}
+	onPanic := pr.onPanic.Load()
+	if onPanic == nil {
+		return export()
+	}
+	if pr.panicked.Load() {
+		return consumererror.NewPermanent(errPanicked)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = consumererror.NewPermanent(fmt.Errorf("panic while exporting: %v", r))
+			// Only the first panic is reported, the concurrent ones are most likely caused by the same problem.
+			if pr.panicked.CompareAndSwap(false, true) {
+				(*onPanic)(r)
+			}
+		}
+	}()
+	return export()
+}
+
+// RecoverPanics makes the exporter recover from the panics raised while exporting the data, including in the
+// goroutines of the sending queue, instead of crashing the collector. onPanic is called with the recovered value
+// from the goroutine which panicked, and the exporter refuses the data afterwards, until it is restarted, since its
+// state may have been corrupted by the panic. It is called by the service when the panic isolation is enabled, before the exporter
+// is started.
+func (be *baseExporter) RecoverPanics(onPanic func(recovered any)) {
+	be.panics.onPanic.Store(&onPanic)
+}
+
+// Restart shuts down the wrapped exporter and starts it again, see WithShutdown and WithStart, and makes the
+// exporter accept the data again after a panic. The sending queue and the other senders keep running, the queued
+// requests being refused until the exporter is restarted. It is called by the service when the panic isolation
+// restarts the panicking components.
+func (be *baseExporter) Restart(ctx context.Context, host component.Host) error {
+	if err := be.ShutdownFunc.Shutdown(ctx); err != nil {
+		return err
+	}
+	if err := be.StartFunc.Start(ctx, host); err != nil {
+		return err
+	}
+	be.panics.panicked.Store(false)
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestRecoverPanicsSync(t *testing.T) {
+	var pushes atomic.Int32
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error {
+			pushes.Add(1)
+			panic("boom")
+		})
+	require.NoError(t, err)
+	var recovered atomic.Value
+	te.(interface{ RecoverPanics(func(any)) }).RecoverPanics(func(r any) { recovered.Store(r) })
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	err = te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "panic while exporting: boom")
+	assert.Equal(t, "boom", recovered.Load())
+
+	// The following data is refused without calling the push function.
+	err = te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.ErrorIs(t, err, errPanicked)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, int32(1), pushes.Load())
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestRecoverPanicsQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	var pushes atomic.Int32
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error {
+			pushes.Add(1)
+			panic("boom")
+		}, WithQueue(qCfg))
+	require.NoError(t, err)
+	panics := make(chan any, 1)
+	te.(interface{ RecoverPanics(func(any)) }).RecoverPanics(func(r any) { panics <- r })
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The data is exported by the goroutines of the queue, the panics raised there are recovered.
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	select {
+	case r := <-panics:
+		assert.Equal(t, "boom", r)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the panic of the queue consumer was not recovered")
+	}
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, int32(1), pushes.Load())
+	assert.Empty(t, panics)
+}
+
+func TestPanicRecoveryDisabled(t *testing.T) {
+	var pr *panicRecovery
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = pr.call(func() error { panic("boom") })
+	})
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = (&panicRecovery{}).call(func() error { panic("boom") })
+	})
+}
+
+func TestRestartAfterPanic(t *testing.T) {
+	var starts, shutdowns atomic.Int32
+	var panics atomic.Bool
+	panics.Store(true)
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error {
+			if panics.Load() {
+				panic("boom")
+			}
+			return nil
+		},
+		WithStart(func(context.Context, component.Host) error {
+			starts.Add(1)
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			shutdowns.Add(1)
+			return nil
+		}))
+	require.NoError(t, err)
+	te.(interface{ RecoverPanics(func(any)) }).RecoverPanics(func(any) {})
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.ErrorIs(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)), errPanicked)
+
+	// The wrapped exporter is shut down and started again, and the data is accepted again.
+	panics.Store(false)
+	restartable := te.(interface {
+		Restart(context.Context, component.Host) error
+	})
+	require.NoError(t, restartable.Restart(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, int32(2), starts.Load())
+	assert.Equal(t, int32(1), shutdowns.Load())
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestRestartFailure(t *testing.T) {
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error { panic("boom") },
+		WithShutdown(func(context.Context) error { return errors.New("cannot shut down") }))
+	require.NoError(t, err)
+	te.(interface{ RecoverPanics(func(any)) }).RecoverPanics(func(any) {})
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	restartable := te.(interface {
+		Restart(context.Context, component.Host) error
+	})
+	require.EqualError(t, restartable.Restart(context.Background(), componenttest.NewNopHost()), "cannot shut down")
+	// The exporter was not restarted, it keeps refusing the data.
+	assert.ErrorIs(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)), errPanicked)
+}
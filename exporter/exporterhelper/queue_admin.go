@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/zpagesregistry"
+)
+
+const (
+	// queueAdminActionDrop drops the requests waiting in the queue.
+	queueAdminActionDrop = "drop"
+	// queueAdminActionFlush retries immediately the requests waiting for their retry back-off.
+	queueAdminActionFlush = "flush"
+
+	defaultQueueAdminHead = 10
+)
+
+// QueueAdminSettings defines the configuration of the admin operations of the sending queue.
+// When enabled, the zpages extension serves a snapshot of the queue at /debug/queuez/<exporter>/<data type>,
+// and the operations dropping or flushing the queued data during incidents. The operations are logged
+// with the remote address of their caller, the zpages endpoint should not be reachable by untrusted clients.
+// The admin operations are not available with the persistent queue.
+type QueueAdminSettings struct {
+	// Enabled enables the admin operations.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// queueAdmin keeps track of the requests in the queue to serve their snapshot, and to drop them on demand.
+type queueAdmin struct {
+	id       component.ID
+	dataType component.DataType
+	queue    exporterqueue.Queue[Request]
+	logger   *zap.Logger
+	now      func() time.Time
+	// dropped is called with the requests removed from the queue by the drop operation.
+	dropped func(context.Context, Request)
+	// retry is set when the retries are enabled, to interrupt their back-off on flush.
+	retry *retrySender
+
+	mu      sync.Mutex
+	nextID  uint64
+	entries map[uint64]*queuedEntry
+}
+
+type queuedEntry struct {
+	id         uint64
+	enqueuedAt time.Time
+	items      int
+	pipeline   component.ID
+	receiver   component.ID
+	inFlight   bool
+}
+
+type queuedEntryKey struct{}
+
+func newQueueAdmin(id component.ID, dataType component.DataType, q exporterqueue.Queue[Request],
+	logger *zap.Logger, now func() time.Time, dropped func(context.Context, Request)) *queueAdmin {
+	return &queueAdmin{
+		id:       id,
+		dataType: dataType,
+		queue:    q,
+		logger:   logger,
+		now:      now,
+		dropped:  dropped,
+		entries:  map[uint64]*queuedEntry{},
+	}
+}
+
+// register serves the admin operations with the zpages extension, if enabled.
+func (qa *queueAdmin) register(host component.Host) error {
+	name := path.Join("queuez", qa.id.String(), qa.dataType.String())
+	if ok, err := zpagesregistry.Register(host, name, qa); ok {
+		qa.logger.Info("Serving the admin operations of the sending queue", zap.String("zpage", name))
+		return err
+	}
+	qa.logger.Warn("The admin operations of the sending queue are not served, the zpages extension is not enabled")
+	return nil
+}
+
+// track records the request being offered to the queue, and returns the context to queue it with.
+func (qa *queueAdmin) track(ctx context.Context, req Request) context.Context {
+	md, _ := RequestMetadataFromContext(ctx)
+	qa.mu.Lock()
+	defer qa.mu.Unlock()
+	qa.nextID++
+	e := &queuedEntry{
+		id:         qa.nextID,
+		enqueuedAt: qa.now(),
+		items:      req.ItemsCount(),
+		pipeline:   pipelineKey(ctx),
+		receiver:   md.Receiver,
+	}
+	qa.entries[e.id] = e
+	return context.WithValue(ctx, queuedEntryKey{}, e.id)
+}
+
+// consume marks the request queued with the context as being exported.
+func (qa *queueAdmin) consume(ctx context.Context) {
+	id, _ := ctx.Value(queuedEntryKey{}).(uint64)
+	qa.mu.Lock()
+	defer qa.mu.Unlock()
+	if e, ok := qa.entries[id]; ok {
+		e.inFlight = true
+	}
+}
+
+// untrack forgets the request queued with the context, once exported or rejected by the queue.
+func (qa *queueAdmin) untrack(ctx context.Context) {
+	id, _ := ctx.Value(queuedEntryKey{}).(uint64)
+	qa.mu.Lock()
+	defer qa.mu.Unlock()
+	delete(qa.entries, id)
+}
+
+// queueSnapshot is the state of the queue served by the admin zPage.
+type queueSnapshot struct {
+	Exporter string `json:"exporter"`
+	DataType string `json:"data_type"`
+	// Size and Capacity are measured in requests.
+	Size     int `json:"size"`
+	Capacity int `json:"capacity"`
+	// QueuedRequests and QueuedItems do not include the requests being exported.
+	QueuedRequests   int    `json:"queued_requests"`
+	QueuedItems      int    `json:"queued_items"`
+	InFlightRequests int    `json:"in_flight_requests"`
+	OldestAge        string `json:"oldest_age,omitempty"`
+	// Pipelines counts the queued requests of each pipeline the exporter is used by.
+	Pipelines map[string]pipelineSnapshot `json:"pipelines"`
+	// Head details the oldest queued requests.
+	Head []queuedRequestSnapshot `json:"head"`
+}
+
+type pipelineSnapshot struct {
+	Requests int `json:"requests"`
+	Items    int `json:"items"`
+}
+
+type queuedRequestSnapshot struct {
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	Age        string    `json:"age"`
+	Items      int       `json:"items"`
+	Pipeline   string    `json:"pipeline,omitempty"`
+	Receiver   string    `json:"receiver,omitempty"`
+	InFlight   bool      `json:"in_flight"`
+}
+
+func (qa *queueAdmin) snapshot(head int) queueSnapshot {
+	now := qa.now()
+	s := queueSnapshot{
+		Exporter:  qa.id.String(),
+		DataType:  qa.dataType.String(),
+		Size:      qa.queue.Size(),
+		Capacity:  qa.queue.Capacity(),
+		Pipelines: map[string]pipelineSnapshot{},
+		Head:      []queuedRequestSnapshot{},
+	}
+
+	qa.mu.Lock()
+	entries := make([]queuedEntry, 0, len(qa.entries))
+	for _, e := range qa.entries {
+		entries = append(entries, *e)
+	}
+	qa.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].id < entries[j].id })
+
+	for _, e := range entries {
+		if e.inFlight {
+			s.InFlightRequests++
+		} else {
+			s.QueuedRequests++
+			s.QueuedItems += e.items
+			p := s.Pipelines[e.pipeline.String()]
+			p.Requests++
+			p.Items += e.items
+			s.Pipelines[e.pipeline.String()] = p
+		}
+		if len(s.Head) < head {
+			s.Head = append(s.Head, queuedRequestSnapshot{
+				EnqueuedAt: e.enqueuedAt,
+				Age:        now.Sub(e.enqueuedAt).String(),
+				Items:      e.items,
+				Pipeline:   e.pipeline.String(),
+				Receiver:   e.receiver.String(),
+				InFlight:   e.inFlight,
+			})
+		}
+	}
+	if len(entries) > 0 {
+		s.OldestAge = now.Sub(entries[0].enqueuedAt).String()
+	}
+	return s
+}
+
+// drop removes the queued requests from the queue, releasing their capacity. The requests being exported
+// are not affected.
+func (qa *queueAdmin) drop() (requests int, items int) {
+	p, ok := qa.queue.(queue.Purger[Request])
+	if !ok {
+		return 0, 0
+	}
+	p.Purge(func(ctx context.Context, req Request) {
+		qa.untrack(ctx)
+		qa.dropped(ctx, req)
+		requests++
+		items += req.ItemsCount()
+	})
+	return requests, items
+}
+
+// queueAdminResult is the result of an admin operation served by the admin zPage.
+type queueAdminResult struct {
+	Action          string `json:"action"`
+	DroppedRequests int    `json:"dropped_requests,omitempty"`
+	DroppedItems    int    `json:"dropped_items,omitempty"`
+	RetriedRequests int    `json:"retried_requests,omitempty"`
+}
+
+// ServeHTTP serves the snapshot of the queue on GET, and the admin operations on POST. The operations
+// require the "action", "drop" or "flush", and are logged with the remote address of the caller for auditing.
+func (qa *queueAdmin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		head := defaultQueueAdminHead
+		if h := r.URL.Query().Get("head"); h != "" {
+			var err error
+			if head, err = strconv.Atoi(h); err != nil || head < 0 {
+				http.Error(w, "invalid head "+strconv.Quote(h)+", must be a non-negative integer", http.StatusBadRequest)
+				return
+			}
+		}
+		writeJSON(w, qa.snapshot(head))
+	case http.MethodPost:
+		action := r.FormValue("action")
+		res := queueAdminResult{Action: action}
+		switch action {
+		case queueAdminActionDrop:
+			res.DroppedRequests, res.DroppedItems = qa.drop()
+		case queueAdminActionFlush:
+			if qa.retry != nil {
+				res.RetriedRequests = qa.retry.retryNow()
+			}
+		default:
+			http.Error(w, "invalid action "+strconv.Quote(action)+`, must be "drop" or "flush"`, http.StatusBadRequest)
+			return
+		}
+		qa.logger.Warn("Admin operation performed on the sending queue.",
+			zap.String("action", action),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.Int("dropped_requests", res.DroppedRequests),
+			zap.Int("dropped_items", res.DroppedItems),
+			zap.Int("retried_requests", res.RetriedRequests))
+		writeJSON(w, res)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
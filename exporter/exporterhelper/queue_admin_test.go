@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+// gatedRequest is exported once its gate is closed.
+type gatedRequest struct {
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (r *gatedRequest) Export(context.Context) error {
+	r.started <- struct{}{}
+	<-r.gate
+	return nil
+}
+
+func (r *gatedRequest) ItemsCount() int {
+	return 1
+}
+
+type fakeZPagesRegistry struct {
+	component.StartFunc
+	component.ShutdownFunc
+	handlers map[string]http.Handler
+}
+
+func (r *fakeZPagesRegistry) RegisterZPage(name string, handler http.Handler) error {
+	r.handlers[name] = handler
+	return nil
+}
+
+func newAdminExporter(t *testing.T, set exporter.CreateSettings, opts ...Option) (*baseExporter, http.Handler) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.Admin.Enabled = true
+	opts = append([]Option{withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg)}, opts...)
+	be, err := newBaseExporter(set, component.DataTypeTraces, newNoopObsrepSender, opts...)
+	require.NoError(t, err)
+
+	registry := &fakeZPagesRegistry{handlers: map[string]http.Handler{}}
+	host := &mockHost{ext: map[component.ID]component.Component{component.MustNewID("zpages"): registry}}
+	require.NoError(t, be.Start(context.Background(), host))
+	require.Contains(t, registry.handlers, "queuez/test/traces")
+	return be, registry.handlers["queuez/test/traces"]
+}
+
+func postAction(t *testing.T, h http.Handler, form url.Values) (int, queueAdminResult) {
+	req := httptest.NewRequest(http.MethodPost, "/debug/queuez/test/traces", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var res queueAdminResult
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	}
+	return rec.Code, res
+}
+
+func TestQueueAdminSnapshotAndDrop(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	set.ID = defaultID
+	logger, observed := observer.New(zap.WarnLevel)
+	set.Logger = zap.New(logger)
+	be, h := newAdminExporter(t, set)
+
+	gated := &gatedRequest{started: make(chan struct{}, 1), gate: make(chan struct{})}
+	require.NoError(t, be.send(context.Background(), gated))
+	<-gated.started
+
+	pipelineID := component.MustNewIDWithName("traces", "critical")
+	mockR := newMockRequest(2, nil)
+	require.NoError(t, be.send(exporter.ContextWithPipeline(context.Background(), pipelineID), mockR))
+	require.NoError(t, be.send(exporter.ContextWithPipeline(context.Background(), pipelineID), mockR))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/queuez/test/traces?head=2", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var snapshot queueSnapshot
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	assert.Equal(t, "test", snapshot.Exporter)
+	assert.Equal(t, "traces", snapshot.DataType)
+	assert.Equal(t, 2, snapshot.Size)
+	assert.Equal(t, 2, snapshot.QueuedRequests)
+	assert.Equal(t, 4, snapshot.QueuedItems)
+	assert.Equal(t, 1, snapshot.InFlightRequests)
+	assert.NotEmpty(t, snapshot.OldestAge)
+	assert.Equal(t, map[string]pipelineSnapshot{"traces/critical": {Requests: 2, Items: 4}}, snapshot.Pipelines)
+	require.Len(t, snapshot.Head, 2)
+	assert.True(t, snapshot.Head[0].InFlight)
+	assert.Equal(t, queuedRequestSnapshot{
+		EnqueuedAt: snapshot.Head[1].EnqueuedAt,
+		Age:        snapshot.Head[1].Age,
+		Items:      2,
+		Pipeline:   "traces/critical",
+	}, snapshot.Head[1])
+
+	code, res := postAction(t, h, url.Values{"action": {"drop"}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, queueAdminResult{Action: "drop", DroppedRequests: 2, DroppedItems: 4}, res)
+	require.Equal(t, 1, observed.Len())
+	entry := observed.All()[0]
+	assert.Equal(t, "Admin operation performed on the sending queue.", entry.Message)
+	assert.Equal(t, "drop", entry.ContextMap()["action"])
+	assert.Equal(t, "192.0.2.1:1234", entry.ContextMap()["remote_addr"])
+
+	// The capacity of the dropped requests is released while the in-flight request is still being exported.
+	qs := be.queueSender.(*queueSender)
+	assert.Equal(t, 0, qs.queue.Size())
+	assert.Len(t, qs.admin.entries, 1)
+	require.NoError(t, be.send(context.Background(), mockR))
+	assert.Equal(t, 1, qs.queue.Size())
+
+	close(gated.gate)
+	mockR.checkNumRequests(t, 1)
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.EqualValues(t, 1, mockR.requestCount.Load())
+	assert.Empty(t, be.queueSender.(*queueSender).admin.entries)
+}
+
+func TestQueueAdminFlush(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	set.ID = defaultID
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Minute
+	rCfg.MaxInterval = time.Minute
+	be, h := newAdminExporter(t, set, WithRetry(rCfg))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(2, errors.New("transient error"))
+	require.NoError(t, be.send(context.Background(), mockR))
+	mockR.checkNumRequests(t, 1)
+	rs := be.retrySender.(*retrySender)
+	assert.Eventually(t, func() bool {
+		return rs.waiting.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	code, res := postAction(t, h, url.Values{"action": {"flush"}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, queueAdminResult{Action: "flush", RetriedRequests: 1}, res)
+	mockR.checkNumRequests(t, 2)
+}
+
+func TestQueueAdminInvalidRequests(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	set.ID = defaultID
+	be, h := newAdminExporter(t, set)
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	code, _ := postAction(t, h, url.Values{"action": {"requeue"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/queuez/test/traces?head=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/debug/queuez/test/traces", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+func newHandoverExporter(t *testing.T, qCfg QueueSettings, shutdowns *atomic.Int64) *baseExporter {
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg), WithShutdown(func(context.Context) error {
+			shutdowns.Add(1)
+			return nil
+		}))
+	require.NoError(t, err)
+	return be
+}
+
+// handOverQueuedRequest starts an exporter, queues a request behind a blocked one and shuts the exporter down
+// with the handover, it returns the queued request.
+func handOverQueuedRequest(t *testing.T, ctx context.Context, be *baseExporter) *mockRequest {
+	require.NoError(t, be.Start(ctx, componenttest.NewNopHost()))
+
+	// Block the only consumer while exporting the first request, so the second one stays in the queue.
+	inFlightR := newMockRequest(2, nil)
+	inFlightR.mu.Lock()
+	require.NoError(t, be.send(context.Background(), inFlightR))
+	inFlightR.checkNumRequests(t, 1)
+	queuedR := newMockRequest(3, nil)
+	require.NoError(t, be.send(context.Background(), queuedR))
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- be.Shutdown(ctx)
+	}()
+	assert.Eventually(t, func() bool {
+		return be.queueSender.(*queueSender).handover.Load() != nil
+	}, time.Second, time.Millisecond)
+	inFlightR.mu.Unlock()
+	require.NoError(t, <-shutdownErr)
+	return queuedR
+}
+
+func TestQueueHandover(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	handover := exporter.NewQueueHandover()
+	ctx := exporter.ContextWithQueueHandover(context.Background(), handover)
+
+	oldShutdowns := &atomic.Int64{}
+	queuedR := handOverQueuedRequest(t, ctx, newHandoverExporter(t, qCfg, oldShutdowns))
+	// The in-flight request was exported by the old instance, the queued one was handed over,
+	// and the old instance is kept until the handover is flushed.
+	assert.EqualValues(t, 0, queuedR.requestCount.Load())
+	assert.EqualValues(t, 0, oldShutdowns.Load())
+
+	newBe := newHandoverExporter(t, qCfg, &atomic.Int64{})
+	require.NoError(t, newBe.Start(ctx, componenttest.NewNopHost()))
+	queuedR.checkNumRequests(t, 1)
+
+	require.NoError(t, handover.Flush(context.Background()))
+	assert.EqualValues(t, 1, oldShutdowns.Load())
+	assert.NoError(t, newBe.Shutdown(context.Background()))
+	assert.EqualValues(t, 1, queuedR.requestCount.Load())
+}
+
+func TestQueueHandoverFlush(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	handover := exporter.NewQueueHandover()
+	ctx := exporter.ContextWithQueueHandover(context.Background(), handover)
+
+	oldShutdowns := &atomic.Int64{}
+	queuedR := handOverQueuedRequest(t, ctx, newHandoverExporter(t, qCfg, oldShutdowns))
+
+	// The handed over requests are only taken over by exporters with the same ID and data type.
+	set := defaultSettings
+	set.ID = component.MustNewID("other")
+	otherBe, err := newBaseExporter(set, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, otherBe.Start(ctx, componenttest.NewNopHost()))
+	assert.NoError(t, otherBe.Shutdown(context.Background()))
+
+	// The request that was not taken over is sent by the old instance, e.g. if the new configuration failed to start.
+	require.NoError(t, handover.Flush(context.Background()))
+	assert.EqualValues(t, 1, queuedR.requestCount.Load())
+	assert.EqualValues(t, 1, oldShutdowns.Load())
+}
+
+func TestQueueHandoverPersistentQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: queue.NewMockStorageExtension(nil)}}
+	handover := exporter.NewQueueHandover()
+	ctx := exporter.ContextWithQueueHandover(context.Background(), handover)
+
+	// The persistent queue keeps its requests in the storage instead of handing them over.
+	shutdowns := &atomic.Int64{}
+	be := newHandoverExporter(t, qCfg, shutdowns)
+	require.NoError(t, be.Start(ctx, host))
+	require.NoError(t, be.Shutdown(ctx))
+	assert.Nil(t, be.queueSender.(*queueSender).handover.Load())
+	assert.EqualValues(t, 1, shutdowns.Load())
+	require.NoError(t, handover.Flush(context.Background()))
+	assert.EqualValues(t, 1, shutdowns.Load())
+}
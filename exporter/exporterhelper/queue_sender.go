@@ -6,6 +6,8 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -14,10 +16,13 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/clock"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/droplog"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -27,6 +32,10 @@ var (
 	scopeName = "go.opentelemetry.io/collector/exporterhelper"
 )
 
+// ErrShuttingDown is the cause of the cancellation of the context of the exports still in flight
+// when the queue is not drained within the drain timeout, see context.Cause.
+var ErrShuttingDown = errors.New("the exporter is shutting down")
+
 // QueueSettings defines configuration for queueing batches before sending to the consumerSender.
 type QueueSettings struct {
 	// Enabled indicates whether to not enqueue batches before sending to the consumerSender.
@@ -40,6 +49,34 @@ type QueueSettings struct {
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// DrainTimeout is the maximum time to wait for the queue to be drained when shutting down, the
+	// exports still in flight are then canceled. If the queue is persistent, their requests are kept
+	// in the storage to be exported after the restart. Zero means no timeout.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+	// Fairness configures the fair queuing of the pipelines sharing the exporter.
+	Fairness FairnessSettings `mapstructure:"fairness"`
+	// Admin configures the admin operations of the queue, served by the zpages extension.
+	Admin QueueAdminSettings `mapstructure:"admin"`
+	// KeepLatestCumulative keeps only the latest point of each stream of cumulative sums, histograms and
+	// exponential histograms, and of summaries in the queue: a newer point of a stream still queued replaces
+	// the older point in the queued request instead of being enqueued, bounding the number of queued requests
+	// and the memory used by the queue during long outages.
+	// Since a cumulative point includes the values of the previous ones, the backend receives the same
+	// totals after the recovery, with a lower resolution. It only applies to the metrics exporters, and
+	// is not available with the persistent queue.
+	KeepLatestCumulative bool `mapstructure:"keep_latest_cumulative"`
+}
+
+// FairnessSettings defines the configuration of the fair queuing of the pipelines sharing an exporter.
+// The queue holds the batches of each pipeline separately, and the consumers serve the pipelines in a
+// weighted round-robin, so that a busy pipeline cannot starve the others. The queue size is shared
+// by the pipelines. The fair queuing is not available with the persistent queue.
+type FairnessSettings struct {
+	// Enabled enables the fair queuing.
+	Enabled bool `mapstructure:"enabled"`
+	// Weights are the numbers of batches of each pipeline consumed in turn. The pipelines without
+	// a weight have a weight of 1.
+	Weights map[component.ID]int `mapstructure:"weights"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -68,6 +105,29 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("number of queue consumers must be positive")
 	}
 
+	if qCfg.DrainTimeout < 0 {
+		return errors.New("drain timeout must be non-negative")
+	}
+
+	if qCfg.Fairness.Enabled {
+		if qCfg.StorageID != nil {
+			return errors.New("fairness cannot be enabled with the persistent queue")
+		}
+		for pipelineID, weight := range qCfg.Fairness.Weights {
+			if weight <= 0 {
+				return fmt.Errorf("fairness weight of the pipeline %q must be positive", pipelineID)
+			}
+		}
+	}
+
+	if qCfg.Admin.Enabled && qCfg.StorageID != nil {
+		return errors.New("admin cannot be enabled with the persistent queue")
+	}
+
+	if qCfg.KeepLatestCumulative && qCfg.StorageID != nil {
+		return errors.New("keep_latest_cumulative cannot be enabled with the persistent queue")
+	}
+
 	return nil
 }
 
@@ -76,34 +136,102 @@ type queueSender struct {
 	fullName       string
 	queue          exporterqueue.Queue[Request]
 	numConsumers   int
+	drainTimeout   time.Duration
+	clock          clock.Clock
 	traceAttribute attribute.KeyValue
 	logger         *zap.Logger
+	dropLog        *droplog.Logger
 	meter          otelmetric.Meter
 	consumers      *queue.Consumers[Request]
+	id             component.ID
+	signal         component.DataType
+	// handover is set when the queue sender is shut down with an exporter.QueueHandover,
+	// the requests remaining in the queue are then handed over instead of exported.
+	handover atomic.Pointer[exporter.QueueHandover]
+	// exportsCtx is canceled with ErrShuttingDown when the queue is not drained in time,
+	// to cancel the exports still in flight.
+	exportsCtx    context.Context
+	cancelExports context.CancelCauseFunc
+	// admin is set when the admin operations of the queue are enabled.
+	admin *queueAdmin
+	// compactor is set when only the latest cumulative points are kept in the queue.
+	compactor *cumulativeCompactor
+	// panics recovers from the panics of the queue consumers once enabled, see baseExporter.RecoverPanics.
+	panics *panicRecovery
 
 	metricCapacity otelmetric.Int64ObservableGauge
 	metricSize     otelmetric.Int64ObservableGauge
 }
 
-func newQueueSender(q exporterqueue.Queue[Request], set exporter.CreateSettings, numConsumers int,
-	exportFailureMessage string) *queueSender {
+func newQueueSender(q exporterqueue.Queue[Request], set exporter.CreateSettings, signal component.DataType, numConsumers int,
+	drainTimeout time.Duration, exportFailureMessage string) *queueSender {
 	qs := &queueSender{
 		fullName:       set.ID.String(),
+		id:             set.ID,
+		signal:         signal,
 		queue:          q,
 		numConsumers:   numConsumers,
+		drainTimeout:   drainTimeout,
+		clock:          clock.System(),
 		traceAttribute: attribute.String(obsmetrics.ExporterKey, set.ID.String()),
 		logger:         set.TelemetrySettings.Logger,
+		dropLog:        droplog.New(set.TelemetrySettings.Logger),
 		meter:          set.TelemetrySettings.MeterProvider.Meter(scopeName),
 	}
+	qs.exportsCtx, qs.cancelExports = context.WithCancelCause(context.Background())
 	consumeFunc := func(ctx context.Context, req Request) error {
-		err := qs.nextSender.send(ctx, req)
+		if qs.admin != nil {
+			qs.admin.consume(ctx)
+			defer qs.admin.untrack(ctx)
+		}
+		if qs.compactor != nil {
+			qs.compactor.dequeue(req)
+		}
+		if h := qs.handover.Load(); h != nil {
+			h.HandOver(qs.id, qs.signal, handoverRequest{ctx: ctx, req: req})
+			return nil
+		}
+		exportCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		stop := context.AfterFunc(qs.exportsCtx, func() {
+			cancel(context.Cause(qs.exportsCtx))
+		})
+		defer stop()
+		err := qs.nextSender.send(exportCtx, req)
+		if err != nil && errors.Is(context.Cause(exportCtx), ErrShuttingDown) {
+			if h := qs.handover.Load(); h != nil {
+				h.HandOver(qs.id, qs.signal, handoverRequest{ctx: ctx, req: req})
+				return nil
+			}
+			set.Logger.Warn("Exporting was canceled on shutdown. The data is dropped unless the queue is persistent.",
+				zap.Error(err), zap.Int("items", req.ItemsCount()))
+			logDropped(qs.dropLog, "exporting was canceled on shutdown", req)
+			// The persistent queue keeps the requests interrupted by the shutdown.
+			return consumererror.NewShutdown(err)
+		}
 		if err != nil {
 			set.Logger.Error("Exporting failed. Dropping data."+exportFailureMessage,
 				zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
+			logDropped(qs.dropLog, "exporting failed", req)
+		}
+		return err
+	}
+	recoveringConsumeFunc := func(ctx context.Context, req Request) error {
+		var returned bool
+		err := qs.panics.call(func() error {
+			err := consumeFunc(ctx, req)
+			returned = true
+			return err
+		})
+		if err != nil && !returned {
+			// The request was refused after a panic, or consuming it panicked, before its failure was logged.
+			set.Logger.Error("Exporting failed. Dropping data."+exportFailureMessage,
+				zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
+			logDropped(qs.dropLog, "exporting failed", req)
 		}
 		return err
 	}
-	qs.consumers = queue.NewQueueConsumers[Request](q, numConsumers, consumeFunc)
+	qs.consumers = queue.NewQueueConsumers[Request](q, numConsumers, recoveringConsumeFunc)
 	return qs
 }
 
@@ -113,6 +241,16 @@ func (qs *queueSender) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if h := exporter.QueueHandoverFromContext(ctx); h != nil && !queue.IsPersistent(qs.queue) {
+		qs.takeOver(h)
+	}
+
+	if qs.admin != nil {
+		if err := qs.admin.register(host); err != nil {
+			return err
+		}
+	}
+
 	var err, errs error
 
 	attrs := otelmetric.WithAttributeSet(attribute.NewSet(attribute.String(obsmetrics.ExporterKey, qs.fullName)))
@@ -141,21 +279,110 @@ func (qs *queueSender) Start(ctx context.Context, host component.Host) error {
 	return errs
 }
 
+// handoverRequest is a request handed over to the next instance of the exporter, with its context.
+type handoverRequest struct {
+	ctx context.Context
+	req Request
+}
+
+// takeOver offers to the queue the requests handed over by the previous instance of the exporter.
+// The requests that do not fit in the queue are handed back, to be sent by the previous instance.
+func (qs *queueSender) takeOver(h *exporter.QueueHandover) {
+	items := h.TakeOver(qs.id, qs.signal)
+	if len(items) == 0 {
+		return
+	}
+	handedBack := 0
+	for _, item := range items {
+		r := item.(handoverRequest)
+		if err := qs.queue.Offer(r.ctx, r.req); err != nil {
+			h.HandOver(qs.id, qs.signal, r)
+			handedBack++
+		}
+	}
+	qs.logger.Info("Took over requests queued by the previous exporter instance.",
+		zap.Int("requests", len(items)-handedBack), zap.Int("handed_back_requests", handedBack))
+}
+
+// flush sends, with a single attempt, the requests handed over by the exporter that were not taken over
+// by a new instance, e.g. because the exporter was removed from the configuration.
+func (qs *queueSender) flush(ctx context.Context, items []any) {
+	dropped := 0
+	for _, item := range items {
+		r := item.(handoverRequest)
+		if ctx.Err() == nil && qs.nextSender.send(r.ctx, r.req) == nil {
+			continue
+		}
+		dropped += r.req.ItemsCount()
+		logDropped(qs.dropLog, "exporting the handed over data failed", r.req)
+	}
+	if dropped > 0 {
+		qs.logger.Warn("Failed to send the data handed over by the exporter and not taken over. Dropping data.",
+			zap.Int("dropped_items", dropped))
+	}
+}
+
 // Shutdown is invoked during service shutdown.
 func (qs *queueSender) Shutdown(ctx context.Context) error {
+	// If a QueueHandover is provided, hand the queued requests over to the next instance of the exporter.
+	// The persistent queues keep their requests in the storage, for the next instance to restore them.
+	if h := exporter.QueueHandoverFromContext(ctx); h != nil && !queue.IsPersistent(qs.queue) {
+		qs.handover.Store(h)
+	}
 	// Stop the queue and consumers, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
-	return qs.consumers.Shutdown(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- qs.consumers.Shutdown(ctx)
+	}()
+
+	var timeout <-chan time.Time
+	if qs.drainTimeout > 0 {
+		timer := qs.clock.NewTimer(qs.drainTimeout)
+		defer timer.Stop()
+		timeout = timer.Chan()
+	}
+	select {
+	case err := <-done:
+		qs.cancelExports(nil)
+		return err
+	case <-timeout:
+	case <-ctx.Done():
+	}
+	qs.logger.Warn("The queue was not drained in time, canceling the exports in flight.")
+	qs.cancelExports(ErrShuttingDown)
+	return <-done
 }
 
 // send implements the requestSender interface. It puts the request in the queue.
 func (qs *queueSender) send(ctx context.Context, req Request) error {
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
 	// The grpc/http based receivers will cancel the request context after this function returns.
-	c := noCancellationContext{Context: ctx}
+	var c context.Context = noCancellationContext{Context: ctx}
+	if qs.admin != nil {
+		c = qs.admin.track(c, req)
+	}
 
 	span := trace.SpanFromContext(c)
-	if err := qs.queue.Offer(c, req); err != nil {
+	var err error
+	enqueued := true
+	if qs.compactor != nil {
+		enqueued, err = qs.compactor.enqueue(req, func() error { return qs.queue.Offer(c, req) })
+	} else {
+		err = qs.queue.Offer(c, req)
+	}
+	if !enqueued {
+		// The points of the request replaced the older points of the queued requests.
+		if qs.admin != nil {
+			qs.admin.untrack(c)
+		}
+		span.AddEvent("Merged item into the queued items.", trace.WithAttributes(qs.traceAttribute))
+		return nil
+	}
+	if err != nil {
+		if qs.admin != nil {
+			qs.admin.untrack(c)
+		}
 		span.AddEvent("Failed to enqueue item.", trace.WithAttributes(qs.traceAttribute))
 		return err
 	}
@@ -164,6 +391,14 @@ func (qs *queueSender) send(ctx context.Context, req Request) error {
 	return nil
 }
 
+// dropQueued discards a request removed from the queue by an admin operation.
+func (qs *queueSender) dropQueued(_ context.Context, req Request) {
+	if qs.compactor != nil {
+		qs.compactor.dequeue(req)
+	}
+	logDropped(qs.dropLog, "dropped by an admin operation", req)
+}
+
 type noCancellationContext struct {
 	context.Context
 }
@@ -179,3 +414,9 @@ func (noCancellationContext) Done() <-chan struct{} {
 func (noCancellationContext) Err() error {
 	return nil
 }
+
+// pipelineKey returns the pipeline of the request, to queue the requests of each pipeline separately.
+func pipelineKey(ctx context.Context) component.ID {
+	pipelineID, _ := exporter.PipelineFromContext(ctx)
+	return pipelineID
+}
@@ -6,6 +6,7 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"go.opentelemetry.io/collector/clock/clocktest"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -125,6 +127,20 @@ func TestQueuedRetryHappyPath(t *testing.T) {
 				WithRetry(configretry.NewDefaultBackOffConfig()),
 			},
 		},
+		{
+			name: "WithQueue/Fairness",
+			queueOptions: []Option{
+				withMarshaler(mockRequestMarshaler),
+				withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+				WithQueue(QueueSettings{
+					Enabled:      true,
+					QueueSize:    10,
+					NumConsumers: 1,
+					Fairness:     FairnessSettings{Enabled: true},
+				}),
+				WithRetry(configretry.NewDefaultBackOffConfig()),
+			},
+		},
 		{
 			name: "WithRequestQueue/MemoryQueueFactory",
 			queueOptions: []Option{
@@ -253,11 +269,74 @@ func TestQueueSettings_Validate(t *testing.T) {
 
 	assert.EqualError(t, qCfg.Validate(), "number of queue consumers must be positive")
 
+	qCfg = NewDefaultQueueSettings()
+	qCfg.DrainTimeout = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "drain timeout must be non-negative")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.Fairness = FairnessSettings{Enabled: true, Weights: map[component.ID]int{component.MustNewID("traces"): 0}}
+	assert.EqualError(t, qCfg.Validate(), `fairness weight of the pipeline "traces" must be positive`)
+
+	storageID := component.MustNewID("file_storage")
+	qCfg.Fairness.Weights = nil
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "fairness cannot be enabled with the persistent queue")
+
+	qCfg.Fairness = FairnessSettings{}
+	qCfg.Admin = QueueAdminSettings{Enabled: true}
+	assert.EqualError(t, qCfg.Validate(), "admin cannot be enabled with the persistent queue")
+
+	qCfg.Admin = QueueAdminSettings{}
+	qCfg.KeepLatestCumulative = true
+	assert.EqualError(t, qCfg.Validate(), "keep_latest_cumulative cannot be enabled with the persistent queue")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
 }
 
+// pipelineRequest records the pipeline it is exported from.
+type pipelineRequest struct {
+	mu        *sync.Mutex
+	pipelines *[]string
+}
+
+func (r *pipelineRequest) Export(ctx context.Context) error {
+	pipelineID, _ := exporter.PipelineFromContext(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.pipelines = append(*r.pipelines, pipelineID.String())
+	return nil
+}
+
+func (r *pipelineRequest) ItemsCount() int {
+	return 1
+}
+
+func TestQueueSenderFairness(t *testing.T) {
+	busy := component.MustNewIDWithName("traces", "busy")
+	quiet := component.MustNewIDWithName("traces", "quiet")
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(QueueSettings{
+			Enabled:      true,
+			QueueSize:    10,
+			NumConsumers: 1,
+			Fairness:     FairnessSettings{Enabled: true, Weights: map[component.ID]int{busy: 2}},
+		}))
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var pipelines []string
+	for _, pipelineID := range []component.ID{busy, busy, busy, busy, quiet, quiet} {
+		req := &pipelineRequest{mu: &mu, pipelines: &pipelines}
+		require.NoError(t, be.send(exporter.ContextWithPipeline(context.Background(), pipelineID), req))
+	}
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.Equal(t, []string{"traces/busy", "traces/busy", "traces/quiet", "traces/busy", "traces/busy", "traces/quiet"}, pipelines)
+}
+
 func TestQueueRetryWithDisabledQueue(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -422,9 +501,99 @@ func TestQueuedRetryPersistentEnabled_NoDataLossOnShutdown(t *testing.T) {
 	replacedReq.checkNumRequests(t, 1)
 }
 
+func TestQueueSenderDrainTimeout(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.DrainTimeout = time.Minute
+	clk := clocktest.NewFakeClock(time.Now())
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg), WithClock(clk))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	req := newBlockingRequest()
+	require.NoError(t, be.send(context.Background(), req))
+	<-req.started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- be.Shutdown(context.Background())
+	}()
+	// Wait for the drain timer.
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	clk.Advance(time.Minute - time.Second)
+	select {
+	case <-req.cause:
+		t.Fatal("the export was canceled before the drain timeout")
+	default:
+	}
+
+	clk.Advance(time.Second)
+	assert.ErrorIs(t, <-req.cause, ErrShuttingDown)
+	assert.NoError(t, <-shutdownErr)
+}
+
+func TestQueueSenderShutdownContextDone(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	req := newBlockingRequest()
+	require.NoError(t, be.send(context.Background(), req))
+	<-req.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, be.Shutdown(ctx))
+	assert.ErrorIs(t, <-req.cause, ErrShuttingDown)
+}
+
+func TestQueuedRetryPersistentEnabled_RequeuedOnDrainTimeout(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.DrainTimeout = time.Minute
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	host := &mockHost{ext: map[component.ID]component.Component{
+		storageID: queue.NewMockStorageExtension(nil),
+	}}
+
+	clk := clocktest.NewFakeClock(time.Now())
+	req := newBlockingRequest()
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, withMarshaler(mockRequestMarshaler),
+		withUnmarshaler(mockRequestUnmarshaler(req)), WithQueue(qCfg), WithClock(clk))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	require.NoError(t, be.send(context.Background(), req))
+	<-req.started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- be.Shutdown(context.Background())
+	}()
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	clk.Advance(time.Minute)
+	assert.ErrorIs(t, <-req.cause, ErrShuttingDown)
+	require.NoError(t, <-shutdownErr)
+
+	// The canceled request is exported again after the restart.
+	replacedReq := newMockRequest(1, nil)
+	be, err = newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, withMarshaler(mockRequestMarshaler),
+		withUnmarshaler(mockRequestUnmarshaler(replacedReq)), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, be.Shutdown(context.Background())) })
+	replacedReq.checkNumRequests(t, 1)
+}
+
 func TestQueueSenderNoStartShutdown(t *testing.T) {
 	queue := queue.NewBoundedMemoryQueue[Request](queue.MemoryQueueSettings[Request]{})
-	qs := newQueueSender(queue, exportertest.NewNopCreateSettings(), 1, "")
+	qs := newQueueSender(queue, exportertest.NewNopCreateSettings(), defaultType, 1, 0, "")
 	assert.NoError(t, qs.Shutdown(context.Background()))
 }
 
@@ -436,3 +605,24 @@ type mockHost struct {
 func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 	return nh.ext
 }
+
+// blockingRequest is exported until its context is canceled.
+type blockingRequest struct {
+	started chan struct{}
+	cause   chan error
+}
+
+func newBlockingRequest() *blockingRequest {
+	return &blockingRequest{started: make(chan struct{}, 1), cause: make(chan error, 1)}
+}
+
+func (r *blockingRequest) Export(ctx context.Context) error {
+	r.started <- struct{}{}
+	<-ctx.Done()
+	r.cause <- context.Cause(ctx)
+	return ctx.Err()
+}
+
+func (r *blockingRequest) ItemsCount() int {
+	return 1
+}
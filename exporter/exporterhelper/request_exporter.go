@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// errNilRequest is returned when a nil Request is exported.
+var errNilRequest = errors.New("nil Request")
+
+// RequestExporter exports the custom requests built by the exporter itself, independently of the pdata,
+// e.g. the payloads already serialized in the format of a vendor. The requests go through the same queue,
+// batcher, retry and timeout senders as the ones of the New[Traces|Metrics|Logs]RequestExporter helpers:
+//   - The queue is sized with Request.ItemsCount and persisted with the exporterqueue.Marshaler[Request] and
+//     exporterqueue.Unmarshaler[Request] given to exporterqueue.NewPersistentQueueFactory, see WithRequestQueue.
+//   - The requests are merged and split with the functions given to WithRequestBatchFuncs, see WithBatcher.
+//   - The partial failures are retried with the requests returned by RequestErrorHandler.
+//
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type RequestExporter interface {
+	component.Component
+	// ExportRequest sends the request with the exporter helper. It returns once the request is queued,
+	// or once it is exported if the queue is disabled.
+	ExportRequest(ctx context.Context, req Request) error
+}
+
+type requestExporter struct {
+	*baseExporter
+}
+
+// NewRequestExporter creates a new exporter of custom requests. The data type is only used to report the
+// telemetry of the exporter and of its queue, e.g. component.DataTypeLogs for the log lines of a vendor
+// format, the request items then being counted as log records.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func NewRequestExporter(
+	_ context.Context,
+	set exporter.CreateSettings,
+	dataType component.DataType,
+	options ...Option,
+) (RequestExporter, error) {
+	if set.Logger == nil {
+		return nil, errNilLogger
+	}
+
+	var osf obsrepSenderFactory
+	switch dataType {
+	case component.DataTypeTraces:
+		osf = newTracesExporterWithObservability
+	case component.DataTypeMetrics:
+		osf = newMetricsSenderWithObservability
+	case component.DataTypeLogs:
+		osf = newLogsExporterWithObservability
+	default:
+		return nil, fmt.Errorf("unsupported data type %q", dataType)
+	}
+
+	be, err := newBaseExporter(set, dataType, osf, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &requestExporter{baseExporter: be}, nil
+}
+
+func (re *requestExporter) ExportRequest(ctx context.Context, req Request) error {
+	if req == nil {
+		return consumererror.NewPermanent(errNilRequest)
+	}
+	err := re.send(ctx, req)
+	if errors.Is(err, queue.ErrQueueIsFull) {
+		re.obsrep.recordEnqueueFailure(ctx, re.signal, int64(req.ItemsCount()))
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// linesRequest is a request of log lines already serialized by the exporter.
+type linesRequest struct {
+	payload []byte
+	sink    *linesSink
+}
+
+type linesSink struct {
+	mu       sync.Mutex
+	payloads []string
+}
+
+func (r *linesRequest) Export(context.Context) error {
+	r.sink.mu.Lock()
+	defer r.sink.mu.Unlock()
+	r.sink.payloads = append(r.sink.payloads, string(r.payload))
+	return nil
+}
+
+func (r *linesRequest) ItemsCount() int {
+	return bytes.Count(r.payload, []byte("\n"))
+}
+
+func (s *linesSink) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.payloads...)
+}
+
+func TestRequestExporter(t *testing.T) {
+	sink := &linesSink{}
+	mergeFunc := func(_ context.Context, r1 Request, r2 Request) (Request, error) {
+		return &linesRequest{payload: append(r1.(*linesRequest).payload, r2.(*linesRequest).payload...), sink: sink}, nil
+	}
+	mergeSplitFunc := func(ctx context.Context, _ exporterbatcher.MaxSizeConfig, r1 Request, r2 Request) ([]Request, error) {
+		if r1 == nil {
+			return []Request{r2}, nil
+		}
+		r, err := mergeFunc(ctx, r1, r2)
+		return []Request{r}, err
+	}
+	cfg := exporterbatcher.NewDefaultConfig()
+	cfg.MinSizeItems = 3
+	cfg.FlushTimeout = time.Hour
+
+	re, err := NewRequestExporter(context.Background(), exportertest.NewNopCreateSettings(), component.DataTypeLogs,
+		WithBatcher(cfg, WithRequestBatchFuncs(mergeFunc, mergeSplitFunc)))
+	require.NoError(t, err)
+	require.NoError(t, re.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, re.Shutdown(context.Background()))
+	})
+
+	go func() {
+		assert.NoError(t, re.ExportRequest(context.Background(), &linesRequest{payload: []byte("a\nb\n"), sink: sink}))
+	}()
+	assert.Eventually(t, func() bool {
+		return re.(*requestExporter).batchSender.(*batchSender).activeRequests.Load() == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, re.ExportRequest(context.Background(), &linesRequest{payload: []byte("c\n"), sink: sink}))
+	assert.Equal(t, []string{"a\nb\nc\n"}, sink.get())
+}
+
+func TestRequestExporterPersistentQueue(t *testing.T) {
+	sink := &linesSink{}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qf := exporterqueue.NewPersistentQueueFactory[Request](&storageID, exporterqueue.PersistentQueueSettings[Request]{
+		Marshaler: func(req Request) ([]byte, error) {
+			return req.(*linesRequest).payload, nil
+		},
+		Unmarshaler: func(data []byte) (Request, error) {
+			return &linesRequest{payload: data, sink: sink}, nil
+		},
+	})
+	re, err := NewRequestExporter(context.Background(), exportertest.NewNopCreateSettings(), component.DataTypeLogs,
+		WithRequestQueue(exporterqueue.NewDefaultConfig(), qf))
+	require.NoError(t, err)
+	host := &mockHost{ext: map[component.ID]component.Component{storageID: queue.NewMockStorageExtension(nil)}}
+	require.NoError(t, re.Start(context.Background(), host))
+	t.Cleanup(func() {
+		require.NoError(t, re.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, re.ExportRequest(context.Background(), &linesRequest{payload: []byte("a\nb\n")}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"a\nb\n"}, sink.get())
+	}, time.Second, time.Millisecond)
+}
+
+func TestRequestExporterErrors(t *testing.T) {
+	_, err := NewRequestExporter(context.Background(), exportertest.NewNopCreateSettings(), component.MustNewType("profiles"))
+	assert.EqualError(t, err, `unsupported data type "profiles"`)
+
+	set := exportertest.NewNopCreateSettings()
+	set.Logger = nil
+	_, err = NewRequestExporter(context.Background(), set, component.DataTypeLogs)
+	assert.Equal(t, errNilLogger, err)
+
+	re, err := NewRequestExporter(context.Background(), exportertest.NewNopCreateSettings(), component.DataTypeTraces)
+	require.NoError(t, err)
+	err = re.ExportRequest(context.Background(), nil)
+	assert.True(t, consumererror.IsPermanent(err))
+}
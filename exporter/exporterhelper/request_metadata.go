@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// RequestMetadata is the metadata of the request being exported, given to the push functions of the exporters
+// through their context, see RequestMetadataFromContext. The batches get the metadata of their first request.
+type RequestMetadata struct {
+	// ReceivedTime is the time the exporter received the data, before it was queued or batched.
+	// It is zero for the requests going through a persistent queue, which does not store their context.
+	ReceivedTime time.Time
+	// Receiver is the ID of the receiver the data comes from, see receiver.ContextWithReceiver.
+	// It is the zero value if unknown, e.g. when the data is generated by a connector or when the
+	// request goes through a persistent queue.
+	Receiver component.ID
+	// ClientMetadata is the metadata of the client that sent the data, e.g. the tenant keys kept by
+	// the metadata_keys of the batch processor.
+	ClientMetadata client.Metadata
+	// Attempt is the number of the export attempt, 1 for the first one, incremented by every retry.
+	Attempt int
+}
+
+type requestMetadataKey struct{}
+
+// RequestMetadataFromContext returns the metadata of the request being exported.
+// It returns false if the context does not carry the metadata, e.g. outside the push functions.
+func RequestMetadataFromContext(ctx context.Context) (RequestMetadata, bool) {
+	md, ok := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return md, ok
+}
+
+func contextWithRequestMetadata(ctx context.Context, md RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, md)
+}
+
+// newRequestMetadata returns the metadata of a request received at the given time with the context.
+func newRequestMetadata(ctx context.Context, receivedTime time.Time) RequestMetadata {
+	receiverID, _ := receiver.ReceiverFromContext(ctx)
+	return RequestMetadata{
+		ReceivedTime:   receivedTime,
+		Receiver:       receiverID,
+		ClientMetadata: client.FromContext(ctx).Metadata,
+		Attempt:        1,
+	}
+}
+
+// contextWithAttempt returns a context carrying the metadata of the request with the given attempt number.
+func contextWithAttempt(ctx context.Context, attempt int) context.Context {
+	md, _ := RequestMetadataFromContext(ctx)
+	md.Attempt = attempt
+	return contextWithRequestMetadata(ctx, md)
+}
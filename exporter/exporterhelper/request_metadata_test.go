@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/clock/clocktest"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func TestRequestMetadata(t *testing.T) {
+	_, ok := RequestMetadataFromContext(context.Background())
+	assert.False(t, ok)
+
+	clk := clocktest.NewFakeClock(time.Now())
+	receivedTime := clk.Now()
+	var got []RequestMetadata
+	pusher := func(ctx context.Context, _ ptrace.Traces) error {
+		md, ok := RequestMetadataFromContext(ctx)
+		assert.True(t, ok)
+		got = append(got, md)
+		if len(got) == 1 {
+			return errors.New("transient error")
+		}
+		return nil
+	}
+	rCfg := configretry.NewDefaultBackOffConfig()
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		pusher, WithRetry(rCfg), WithClock(clk))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	receiverID := component.MustNewID("otlp")
+	ctx := receiver.ContextWithReceiver(context.Background(), receiverID)
+	ctx = client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}})})
+	done := make(chan error)
+	go func() {
+		done <- te.ConsumeTraces(ctx, ptrace.NewTraces())
+	}()
+	assert.Eventually(t, func() bool {
+		return clk.Waiters() == 1
+	}, time.Second, time.Millisecond)
+	clk.Advance(time.Minute)
+	require.NoError(t, <-done)
+
+	require.Len(t, got, 2)
+	for i, md := range got {
+		assert.Equal(t, receivedTime, md.ReceivedTime)
+		assert.Equal(t, receiverID, md.Receiver)
+		assert.Equal(t, []string{"acme"}, md.ClientMetadata.Get("tenant"))
+		assert.Equal(t, i+1, md.Attempt)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/clock"
+)
+
+// RetryBudgetSettings defines the configuration of a retry budget, a token bucket limiting the rate of the retries.
+type RetryBudgetSettings struct {
+	// Enabled indicates whether to limit the retries with a retry budget.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxRetries is the capacity of the bucket, the number of retries allowed in a burst.
+	MaxRetries int `mapstructure:"max_retries"`
+	// RetriesPerSecond is the rate at which the bucket is refilled.
+	RetriesPerSecond float64 `mapstructure:"retries_per_second"`
+}
+
+// NewDefaultRetryBudgetSettings returns the default settings for RetryBudgetSettings.
+func NewDefaultRetryBudgetSettings() RetryBudgetSettings {
+	return RetryBudgetSettings{
+		Enabled:          false,
+		MaxRetries:       100,
+		RetriesPerSecond: 10,
+	}
+}
+
+// Validate checks if the RetryBudgetSettings configuration is valid
+func (cfg *RetryBudgetSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.MaxRetries <= 0 {
+		return errors.New("max retries must be positive")
+	}
+
+	if cfg.RetriesPerSecond <= 0 {
+		return errors.New("retries per second must be positive")
+	}
+
+	return nil
+}
+
+// RetryBudget is a token bucket limiting the retries of the exporters it is given to with WithRetryBudget.
+// Every retry takes a token from the bucket, and the requests are not retried anymore once it is empty,
+// so that exporters sharing a budget do not multiply the pressure on a failing backend.
+type RetryBudget struct {
+	capacity float64
+	rate     float64
+	clock    clock.Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRetryBudget creates a RetryBudget from the given settings, the bucket being initially full.
+func NewRetryBudget(cfg RetryBudgetSettings) *RetryBudget {
+	b := &RetryBudget{
+		capacity: float64(cfg.MaxRetries),
+		rate:     cfg.RetriesPerSecond,
+		clock:    clock.System(),
+		tokens:   float64(cfg.MaxRetries),
+	}
+	b.last = b.clock.Now()
+	return b
+}
+
+// tryAcquire takes a token from the bucket, it returns false if the bucket is empty.
+func (b *RetryBudget) tryAcquire() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
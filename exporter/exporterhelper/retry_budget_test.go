@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/clock/clocktest"
+)
+
+func TestRetryBudgetSettings_Validate(t *testing.T) {
+	cfg := NewDefaultRetryBudgetSettings()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxRetries = 0
+	assert.NoError(t, cfg.Validate(), "must not fail when disabled")
+
+	cfg.Enabled = true
+	assert.EqualError(t, cfg.Validate(), "max retries must be positive")
+
+	cfg.MaxRetries = 10
+	cfg.RetriesPerSecond = 0
+	assert.EqualError(t, cfg.Validate(), "retries per second must be positive")
+
+	cfg.RetriesPerSecond = 0.5
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestRetryBudget(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Now())
+	b := NewRetryBudget(RetryBudgetSettings{Enabled: true, MaxRetries: 2, RetriesPerSecond: 2})
+	b.clock = clk
+	b.last = clk.Now()
+
+	assert.True(t, b.tryAcquire())
+	assert.True(t, b.tryAcquire())
+	assert.False(t, b.tryAcquire())
+
+	clk.Advance(250 * time.Millisecond)
+	assert.False(t, b.tryAcquire(), "half a token must not allow a retry")
+
+	clk.Advance(250 * time.Millisecond)
+	assert.True(t, b.tryAcquire())
+	assert.False(t, b.tryAcquire())
+
+	clk.Advance(time.Hour)
+	assert.True(t, b.tryAcquire())
+	assert.True(t, b.tryAcquire())
+	assert.False(t, b.tryAcquire(), "the tokens must be capped to the max retries")
+}
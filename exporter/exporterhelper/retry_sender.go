@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -14,10 +16,10 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/clock"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -25,9 +27,15 @@ import (
 type throttleRetry struct {
 	err   error
 	delay time.Duration
+	// retryAt and serverNow are set instead of delay by NewThrottleRetryAt.
+	retryAt   time.Time
+	serverNow time.Time
 }
 
 func (t throttleRetry) Error() string {
+	if !t.retryAt.IsZero() {
+		return "Throttle (until " + t.retryAt.UTC().Format(time.RFC3339) + "), error: " + t.err.Error()
+	}
 	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
 }
 
@@ -35,7 +43,31 @@ func (t throttleRetry) Unwrap() error {
 	return t.err
 }
 
-// NewThrottleRetry creates a new throttle retry error.
+// ErrorCategory classifies the error as throttled for consumererror.Classify.
+func (t throttleRetry) ErrorCategory() consumererror.Category {
+	return consumererror.CategoryThrottled
+}
+
+// ThrottleDelay returns the delay requested by the backend, for consumererror.ThrottleDelay. The delay
+// until the retry time requested with NewThrottleRetryAt is computed against the clock of the collector
+// if the time of the backend is not known.
+func (t throttleRetry) ThrottleDelay() time.Duration {
+	return t.delayFrom(time.Now())
+}
+
+// delayFrom returns the delay requested by the backend, with now the current time of the collector.
+func (t throttleRetry) delayFrom(now time.Time) time.Duration {
+	if t.retryAt.IsZero() {
+		return t.delay
+	}
+	if !t.serverNow.IsZero() {
+		now = t.serverNow
+	}
+	return t.retryAt.Sub(now)
+}
+
+// NewThrottleRetry creates a new throttle retry error. It is classified as throttled by consumererror.Classify,
+// with the delay returned by consumererror.ThrottleDelay, like the errors created with consumererror.NewThrottled.
 func NewThrottleRetry(err error, delay time.Duration) error {
 	return throttleRetry{
 		err:   err,
@@ -43,12 +75,37 @@ func NewThrottleRetry(err error, delay time.Duration) error {
 	}
 }
 
+// NewThrottleRetryAt creates a new throttle retry error, for a backend requesting to retry at the given time,
+// e.g. with the HTTP-date form of the Retry-After header. serverNow is the time of the backend when it responded,
+// e.g. from the Date header, so that the delay does not depend on the clock skew between the backend and
+// the collector. If serverNow is zero, the delay is computed against the clock of the collector.
+func NewThrottleRetryAt(err error, retryAt time.Time, serverNow time.Time) error {
+	return throttleRetry{
+		err:       err,
+		retryAt:   retryAt,
+		serverNow: serverNow,
+	}
+}
+
 type retrySender struct {
 	baseRequestSender
 	traceAttribute attribute.KeyValue
 	cfg            configretry.BackOffConfig
 	stopCh         chan struct{}
 	logger         *zap.Logger
+	clock          clock.Clock
+	// duplicates is set when the duplicate tracking is enabled.
+	duplicates *duplicateTracker
+	// budget is set when the retries are limited by a retry budget.
+	budget *RetryBudget
+	// throttleStats is set when the delays honored for the destination are recorded.
+	throttleStats       *ThrottleStats
+	throttleDestination string
+
+	// retryNowCh is closed to interrupt the back-off of the requests waiting to be retried.
+	retryNowMu sync.Mutex
+	retryNowCh chan struct{}
+	waiting    atomic.Int64
 }
 
 func newRetrySender(config configretry.BackOffConfig, set exporter.CreateSettings) *retrySender {
@@ -57,9 +114,27 @@ func newRetrySender(config configretry.BackOffConfig, set exporter.CreateSetting
 		cfg:            config,
 		stopCh:         make(chan struct{}),
 		logger:         set.Logger,
+		clock:          clock.System(),
+		retryNowCh:     make(chan struct{}),
 	}
 }
 
+// retryNow interrupts the back-off of the requests waiting to be retried, so they are retried immediately,
+// and returns the number of requests interrupted.
+func (rs *retrySender) retryNow() int {
+	rs.retryNowMu.Lock()
+	defer rs.retryNowMu.Unlock()
+	close(rs.retryNowCh)
+	rs.retryNowCh = make(chan struct{})
+	return int(rs.waiting.Load())
+}
+
+func (rs *retrySender) retryNowChan() <-chan struct{} {
+	rs.retryNowMu.Lock()
+	defer rs.retryNowMu.Unlock()
+	return rs.retryNowCh
+}
+
 func (rs *retrySender) Shutdown(context.Context) error {
 	close(rs.stopCh)
 	return nil
@@ -76,17 +151,28 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 		MaxInterval:         rs.cfg.MaxInterval,
 		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
 		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
+		Clock:               rs.clock,
 	}
 	expBackoff.Reset()
 	span := trace.SpanFromContext(ctx)
 	retryNum := int64(0)
+	var hash uint64
+	tracked := false
+	if rs.duplicates != nil {
+		hash, tracked = rs.duplicates.sample(req)
+	}
 	for {
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
 
-		err := rs.nextSender.send(ctx, req)
+		if tracked {
+			rs.duplicates.recordAttempt(ctx, hash, req)
+		}
+		err := rs.nextSender.send(contextWithAttempt(ctx, int(retryNum)+1), req)
+		if tracked {
+			rs.duplicates.recordResult(hash, err)
+		}
 		if err == nil {
 			return nil
 		}
@@ -97,15 +183,27 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 		}
 
 		req = extractPartialRequest(req, err)
+		if tracked {
+			// The request may have been replaced by the data that failed to be exported.
+			hash, tracked = rs.duplicates.hash(req)
+		}
 
 		backoffDelay := expBackoff.NextBackOff()
 		if backoffDelay == backoff.Stop {
 			return fmt.Errorf("no more retries left: %w", err)
 		}
 
-		throttleErr := throttleRetry{}
-		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		if rs.budget != nil && !rs.budget.tryAcquire() {
+			rs.logger.Warn("Exporting failed and the retry budget is exhausted. Not retrying the request.",
+				zap.Error(err), zap.Int("items", req.ItemsCount()))
+			return fmt.Errorf("retry budget exhausted: %w", err)
+		}
+
+		if throttleDelay, throttled := rs.throttleDelay(err); throttled {
+			backoffDelay = max(backoffDelay, throttleDelay)
+			if rs.throttleStats != nil && throttleDelay > 0 {
+				rs.throttleStats.recordDelay(rs.throttleDestination, throttleDelay)
+			}
 		}
 
 		backoffDelayStr := backoffDelay.String()
@@ -123,14 +221,51 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 		retryNum++
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
+		retryNowCh := rs.retryNowChan()
+		rs.waiting.Add(1)
+		timer := rs.clock.NewTimer(backoffDelay)
+		var waitErr error
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("request is cancelled or timed out %w", err)
+			waitErr = fmt.Errorf("request is cancelled or timed out %w", err)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				waitErr = consumererror.NewExpired(waitErr)
+			}
 		case <-rs.stopCh:
-			return experr.NewShutdownErr(err)
-		case <-time.After(backoffDelay):
+			waitErr = consumererror.NewShutdown(err)
+		case <-retryNowCh:
+		case <-timer.Chan():
 		}
+		timer.Stop()
+		rs.waiting.Add(-1)
+		if waitErr != nil {
+			return waitErr
+		}
+	}
+}
+
+// throttleDelay returns the delay requested by the backend, as a duration waited on the monotonic clock,
+// if the error is throttled, either created with NewThrottleRetry or consumererror.NewThrottled.
+// The delays in the past, e.g. due to clock skew, are ignored, and the delays longer than the
+// max_throttle_interval are capped.
+func (rs *retrySender) throttleDelay(err error) (time.Duration, bool) {
+	delay, throttled := consumererror.ThrottleDelay(err)
+	if t := (throttleRetry{}); errors.As(err, &t) {
+		delay, throttled = t.delayFrom(rs.clock.Now()), true
+	}
+	if !throttled {
+		return 0, false
+	}
+	if delay < 0 {
+		return 0, true
+	}
+	if rs.cfg.MaxThrottleInterval > 0 && delay > rs.cfg.MaxThrottleInterval {
+		rs.logger.Warn("The delay requested by the backend before retrying is capped to the max_throttle_interval.",
+			zap.Duration("requested", delay),
+			zap.Duration("max_throttle_interval", rs.cfg.MaxThrottleInterval))
+		return rs.cfg.MaxThrottleInterval, true
 	}
+	return delay, true
 }
 
 // max returns the larger of x or y.
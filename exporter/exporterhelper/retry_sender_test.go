@@ -6,6 +6,7 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"go.opentelemetry.io/collector/clock/clocktest"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -196,6 +198,168 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
 }
 
+func TestQueuedRetry_ThrottleErrorWithClock(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Now())
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.MaxThrottleInterval = 0
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, WithRetry(rCfg), WithClock(clk))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	retry := NewThrottleRetry(errors.New("throttle error"), time.Hour)
+	mockR := newMockRequest(2, wrappedError{retry})
+	done := make(chan error)
+	go func() {
+		done <- be.send(context.Background(), mockR)
+	}()
+
+	// Wait for the retry sender to wait for the throttle delay.
+	assert.Eventually(t, func() bool {
+		return clk.Waiters() == 1
+	}, time.Second, time.Millisecond)
+	mockR.checkNumRequests(t, 1)
+
+	clk.Advance(time.Hour - time.Second)
+	select {
+	case <-done:
+		assert.Fail(t, "the request must be retried after the throttle delay")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Advance(time.Second)
+	assert.NoError(t, <-done)
+	mockR.checkNumRequests(t, 2)
+}
+
+func TestQueuedRetry_ThrottleErrorCapped(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Now())
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultType, newNoopObsrepSender, WithRetry(rCfg), WithClock(clk))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// A backend requesting to retry after a day does not stall the exporter for a day.
+	retry := NewThrottleRetry(errors.New("throttle error"), 24*time.Hour)
+	mockR := newMockRequest(2, wrappedError{retry})
+	done := make(chan error)
+	go func() {
+		done <- be.send(context.Background(), mockR)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return clk.Waiters() == 1
+	}, time.Second, time.Millisecond)
+	clk.Advance(rCfg.MaxThrottleInterval)
+	assert.NoError(t, <-done)
+	mockR.checkNumRequests(t, 2)
+}
+
+func TestRetrySenderThrottleDelay(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		err           error
+		maxDelay      time.Duration
+		want          time.Duration
+		wantThrottled bool
+	}{
+		{
+			name:          "delay",
+			err:           throttleRetry{delay: time.Minute},
+			maxDelay:      5 * time.Minute,
+			want:          time.Minute,
+			wantThrottled: true,
+		},
+		{
+			name:          "negative_delay",
+			err:           throttleRetry{delay: -time.Minute},
+			maxDelay:      5 * time.Minute,
+			want:          0,
+			wantThrottled: true,
+		},
+		{
+			name:          "capped_delay",
+			err:           throttleRetry{delay: 24 * time.Hour},
+			maxDelay:      5 * time.Minute,
+			want:          5 * time.Minute,
+			wantThrottled: true,
+		},
+		{
+			name:          "not_capped_delay",
+			err:           throttleRetry{delay: 24 * time.Hour},
+			want:          24 * time.Hour,
+			wantThrottled: true,
+		},
+		{
+			name:          "retry_at_local_clock",
+			err:           throttleRetry{retryAt: now.Add(2 * time.Minute)},
+			maxDelay:      5 * time.Minute,
+			want:          2 * time.Minute,
+			wantThrottled: true,
+		},
+		{
+			// The clock of the backend is an hour ahead, the delay is relative to it.
+			name:          "retry_at_server_clock",
+			err:           throttleRetry{retryAt: now.Add(time.Hour + time.Minute), serverNow: now.Add(time.Hour)},
+			maxDelay:      5 * time.Minute,
+			want:          time.Minute,
+			wantThrottled: true,
+		},
+		{
+			// The clock of the backend is an hour behind, and the Date header is missing.
+			name:          "retry_at_in_the_past",
+			err:           throttleRetry{retryAt: now.Add(-time.Hour + time.Minute)},
+			maxDelay:      5 * time.Minute,
+			want:          0,
+			wantThrottled: true,
+		},
+		{
+			name:          "consumererror_throttled",
+			err:           fmt.Errorf("export failed: %w", consumererror.NewThrottled(errors.New("throttled"), 2*time.Minute)),
+			maxDelay:      5 * time.Minute,
+			want:          2 * time.Minute,
+			wantThrottled: true,
+		},
+		{
+			name:     "not_throttled",
+			err:      errors.New("transient error"),
+			maxDelay: 5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rCfg := configretry.NewDefaultBackOffConfig()
+			rCfg.MaxThrottleInterval = tt.maxDelay
+			rs := newRetrySender(rCfg, defaultSettings)
+			rs.clock = clocktest.NewFakeClock(now)
+			delay, throttled := rs.throttleDelay(tt.err)
+			assert.Equal(t, tt.want, delay)
+			assert.Equal(t, tt.wantThrottled, throttled)
+		})
+	}
+}
+
+func TestThrottleRetryConsumerError(t *testing.T) {
+	err := fmt.Errorf("export failed: %w", NewThrottleRetry(errors.New("throttled"), time.Minute))
+	assert.Equal(t, consumererror.CategoryThrottled, consumererror.Classify(err))
+	delay, ok := consumererror.ThrottleDelay(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+
+	retryAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	delay, ok = consumererror.ThrottleDelay(NewThrottleRetryAt(errors.New("throttled"), retryAt, retryAt.Add(-time.Minute)))
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -226,6 +390,47 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
 }
 
+func TestRetryBudgetSharedByExporters(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	budget := NewRetryBudget(RetryBudgetSettings{Enabled: true, MaxRetries: 1, RetriesPerSecond: 0.001})
+
+	logger, observed := observer.New(zap.WarnLevel)
+	set := exportertest.NewNopCreateSettings()
+	set.Logger = zap.New(logger)
+	var exporters []*baseExporter
+	for _, signal := range []component.DataType{component.DataTypeTraces, component.DataTypeLogs} {
+		be, err := newBaseExporter(set, signal, newObservabilityConsumerSender,
+			WithRetry(rCfg), WithRetryBudget(budget))
+		require.NoError(t, err)
+		require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			assert.NoError(t, be.Shutdown(context.Background()))
+		})
+		exporters = append(exporters, be)
+	}
+
+	// The first exporter retries its request, taking the only token of the budget.
+	ocs := exporters[0].obsrepSender.(*observabilityConsumerSender)
+	mockR := newMockRequest(2, errors.New("transient error"))
+	ocs.run(func() {
+		require.NoError(t, exporters[0].send(context.Background(), mockR))
+	})
+	ocs.awaitAsyncProcessing()
+	mockR.checkNumRequests(t, 2)
+
+	// The second exporter cannot retry anymore.
+	ocs = exporters[1].obsrepSender.(*observabilityConsumerSender)
+	mockR = newMockRequest(2, errors.New("transient error"))
+	ocs.run(func() {
+		assert.ErrorContains(t, exporters[1].send(context.Background(), mockR), "retry budget exhausted")
+	})
+	ocs.awaitAsyncProcessing()
+	mockR.checkNumRequests(t, 1)
+	ocs.checkDroppedItemsCount(t, 2)
+	assert.Equal(t, 1, observed.FilterMessage("Exporting failed and the retry budget is exhausted. Not retrying the request.").Len())
+}
+
 func TestQueueRetryWithNoQueue(t *testing.T) {
 	rCfg := configretry.NewDefaultBackOffConfig()
 	rCfg.MaxElapsedTime = time.Nanosecond // fail fast
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/clock"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/zpagesregistry"
+)
+
+// throttleStatsBuckets is the number of buckets the window of the statistics is divided in,
+// the statistics roll over by a bucket at a time.
+const throttleStatsBuckets = 60
+
+// ThrottleStatsSettings defines the configuration of the rolling-window statistics of the responses
+// of the destinations throttling the exports.
+type ThrottleStatsSettings struct {
+	// Enabled indicates whether to keep the statistics.
+	Enabled bool `mapstructure:"enabled"`
+	// Window is the duration the statistics are computed over.
+	Window time.Duration `mapstructure:"window"`
+}
+
+// NewDefaultThrottleStatsSettings returns the default settings for ThrottleStatsSettings.
+func NewDefaultThrottleStatsSettings() ThrottleStatsSettings {
+	return ThrottleStatsSettings{
+		Enabled: false,
+		Window:  time.Minute,
+	}
+}
+
+// Validate checks if the ThrottleStatsSettings configuration is valid
+func (cfg *ThrottleStatsSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Window < time.Second {
+		return errors.New("window must be at least 1s")
+	}
+
+	return nil
+}
+
+// ThrottleStats keeps the statistics of the responses of the destinations of an exporter over a rolling window:
+// the responses throttling the exports, e.g. the HTTP 429 and 503 responses, the delays honored before
+// retrying them, and the items accepted, from which the capacity of the destinations is estimated.
+// The exporters record the responses with RecordResponse, and give the ThrottleStats to the exporter helper
+// with WithThrottleStats to record the honored delays and to serve the statistics.
+type ThrottleStats struct {
+	window     time.Duration
+	bucketSize time.Duration
+	clock      clock.Clock
+
+	mu           sync.Mutex
+	destinations map[string]*destinationThrottleStats
+}
+
+type destinationThrottleStats struct {
+	buckets [throttleStatsBuckets]throttleStatsBucket
+}
+
+type throttleStatsBucket struct {
+	// index is the number of bucket sizes since the epoch, the bucket is stale when it is not the current index.
+	index         int64
+	responses     int64
+	throttled     map[string]int64
+	acceptedItems int64
+	delays        int64
+	delayTotal    time.Duration
+	delayMax      time.Duration
+}
+
+// DestinationThrottleStats are the statistics of the responses of a destination over the window.
+type DestinationThrottleStats struct {
+	// Destination identifies the destination, e.g. the URL of the endpoint.
+	Destination string `json:"destination"`
+	// Responses is the number of responses of the destination.
+	Responses int64 `json:"responses"`
+	// ThrottledResponses is the number of responses throttling the exports.
+	ThrottledResponses int64 `json:"throttled_responses"`
+	// ThrottledByStatus counts the responses throttling the exports by status, e.g. "429" or "503".
+	ThrottledByStatus map[string]int64 `json:"throttled_by_status"`
+	// AcceptedItems is the number of items accepted by the destination.
+	AcceptedItems int64 `json:"accepted_items"`
+	// HonoredDelays is the number of delays requested by the destination and waited before retrying.
+	HonoredDelays int64 `json:"honored_delays"`
+	// HonoredDelaySeconds is the total duration of the honored delays, in seconds.
+	HonoredDelaySeconds float64 `json:"honored_delay_seconds"`
+	// MaxHonoredDelaySeconds is the longest honored delay, in seconds.
+	MaxHonoredDelaySeconds float64 `json:"max_honored_delay_seconds"`
+	// EstimatedCapacity is the number of items per second accepted by the destination while it throttled
+	// the exports, an estimation of the capacity of the backend: only the items accepted since the first
+	// throttled response of the window are counted, with a precision of 1/60th of the window. It is zero
+	// if the destination did not throttle the exports during the window, the capacity being then above the load.
+	EstimatedCapacity float64 `json:"estimated_capacity"`
+}
+
+// NewThrottleStats creates a ThrottleStats from the given settings.
+func NewThrottleStats(cfg ThrottleStatsSettings) *ThrottleStats {
+	return &ThrottleStats{
+		window:       cfg.Window,
+		bucketSize:   cfg.Window / throttleStatsBuckets,
+		clock:        clock.System(),
+		destinations: map[string]*destinationThrottleStats{},
+	}
+}
+
+// RecordResponse records a response of the destination to an export. The status is the status of the response
+// in the protocol of the exporter, e.g. "429", throttled reports whether the response throttles the export, and
+// acceptedItems is the number of items accepted by the destination.
+func (s *ThrottleStats) RecordResponse(destination string, status string, throttled bool, acceptedItems int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.bucket(destination)
+	b.responses++
+	b.acceptedItems += int64(acceptedItems)
+	if throttled {
+		if b.throttled == nil {
+			b.throttled = map[string]int64{}
+		}
+		b.throttled[status]++
+	}
+}
+
+// recordDelay records a delay requested by the destination before retrying, capped by the max_throttle_interval.
+func (s *ThrottleStats) recordDelay(destination string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.bucket(destination)
+	b.delays++
+	b.delayTotal += delay
+	if delay > b.delayMax {
+		b.delayMax = delay
+	}
+}
+
+// bucket returns the current bucket of the destination. It must be called with s.mu held.
+func (s *ThrottleStats) bucket(destination string) *throttleStatsBucket {
+	now := s.clock.Now()
+	d, ok := s.destinations[destination]
+	if !ok {
+		d = &destinationThrottleStats{}
+		s.destinations[destination] = d
+	}
+	index := now.UnixNano() / int64(s.bucketSize)
+	b := &d.buckets[index%throttleStatsBuckets]
+	if b.index != index {
+		*b = throttleStatsBucket{index: index}
+	}
+	return b
+}
+
+// Snapshot returns the statistics of the destinations over the window, sorted by destination.
+func (s *ThrottleStats) Snapshot() []DestinationThrottleStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	current := now.UnixNano() / int64(s.bucketSize)
+	snapshot := make([]DestinationThrottleStats, 0, len(s.destinations))
+	for destination, d := range s.destinations {
+		ds := DestinationThrottleStats{Destination: destination, ThrottledByStatus: map[string]int64{}}
+		var delayTotal, delayMax time.Duration
+		// The first bucket with a throttled response, and the items accepted by all the buckets of the window.
+		firstThrottled := int64(-1)
+		var items [throttleStatsBuckets]int64
+		for i := range d.buckets {
+			b := &d.buckets[i]
+			if b.index <= current-throttleStatsBuckets || b.index > current {
+				continue
+			}
+			ds.Responses += b.responses
+			ds.AcceptedItems += b.acceptedItems
+			for status, count := range b.throttled {
+				ds.ThrottledResponses += count
+				ds.ThrottledByStatus[status] += count
+			}
+			items[current-b.index] = b.acceptedItems
+			if len(b.throttled) > 0 && (firstThrottled < 0 || b.index < firstThrottled) {
+				firstThrottled = b.index
+			}
+			ds.HonoredDelays += b.delays
+			delayTotal += b.delayTotal
+			if b.delayMax > delayMax {
+				delayMax = b.delayMax
+			}
+		}
+		ds.HonoredDelaySeconds = delayTotal.Seconds()
+		ds.MaxHonoredDelaySeconds = delayMax.Seconds()
+		if firstThrottled >= 0 {
+			var throttledItems int64
+			for i := int64(0); i <= current-firstThrottled; i++ {
+				throttledItems += items[i]
+			}
+			elapsed := max(now.Sub(time.Unix(0, firstThrottled*int64(s.bucketSize))), s.bucketSize)
+			ds.EstimatedCapacity = float64(throttledItems) / elapsed.Seconds()
+		}
+		snapshot = append(snapshot, ds)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Destination < snapshot[j].Destination })
+	return snapshot
+}
+
+// throttleStatsPage serves the statistics of an exporter as a zPage.
+type throttleStatsPage struct {
+	stats    *ThrottleStats
+	id       component.ID
+	dataType component.DataType
+}
+
+type throttleStatsSnapshot struct {
+	Exporter      string                     `json:"exporter"`
+	DataType      string                     `json:"data_type"`
+	WindowSeconds float64                    `json:"window_seconds"`
+	Destinations  []DestinationThrottleStats `json:"destinations"`
+}
+
+func (p *throttleStatsPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, throttleStatsSnapshot{
+		Exporter:      p.id.String(),
+		DataType:      p.dataType.String(),
+		WindowSeconds: p.stats.window.Seconds(),
+		Destinations:  p.stats.Snapshot(),
+	})
+}
+
+// registerThrottleStats serves the statistics with the zpages extension, if enabled.
+func registerThrottleStats(host component.Host, stats *ThrottleStats, id component.ID, dataType component.DataType, logger *zap.Logger) error {
+	name := path.Join("throttlez", id.String(), dataType.String())
+	if ok, err := zpagesregistry.Register(host, name, &throttleStatsPage{stats: stats, id: id, dataType: dataType}); ok {
+		logger.Info("Serving the throttling statistics", zap.String("zpage", name))
+		return err
+	}
+	logger.Warn("The throttling statistics are not served, the zpages extension is not enabled")
+	return nil
+}
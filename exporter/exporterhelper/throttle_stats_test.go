@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/clock/clocktest"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+func TestThrottleStatsSettings_Validate(t *testing.T) {
+	cfg := NewDefaultThrottleStatsSettings()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Window = time.Millisecond
+	assert.NoError(t, cfg.Validate(), "must not fail when disabled")
+
+	cfg.Enabled = true
+	assert.EqualError(t, cfg.Validate(), "window must be at least 1s")
+
+	cfg.Window = time.Minute
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestThrottleStats(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Unix(1700000000, 0))
+	s := NewThrottleStats(ThrottleStatsSettings{Enabled: true, Window: time.Minute})
+	s.clock = clk
+	assert.Empty(t, s.Snapshot())
+
+	s.RecordResponse("http://a", "200", false, 100)
+	assert.Equal(t, []DestinationThrottleStats{{
+		Destination:       "http://a",
+		Responses:         1,
+		ThrottledByStatus: map[string]int64{},
+		AcceptedItems:     100,
+	}}, s.Snapshot(), "the capacity must not be estimated without throttling")
+
+	clk.Advance(10 * time.Second)
+	s.RecordResponse("http://a", "429", true, 0)
+	s.recordDelay("http://a", 2*time.Second)
+	s.RecordResponse("http://a", "503", true, 0)
+	s.recordDelay("http://a", 5*time.Second)
+	s.RecordResponse("http://a", "200", false, 100)
+	s.RecordResponse("http://b", "503", true, 0)
+	assert.Equal(t, []DestinationThrottleStats{
+		{
+			Destination:            "http://a",
+			Responses:              4,
+			ThrottledResponses:     2,
+			ThrottledByStatus:      map[string]int64{"429": 1, "503": 1},
+			AcceptedItems:          200,
+			HonoredDelays:          2,
+			HonoredDelaySeconds:    7,
+			MaxHonoredDelaySeconds: 5,
+			// Only the items accepted since the first throttled response are counted.
+			EstimatedCapacity: 100,
+		},
+		{
+			Destination:        "http://b",
+			Responses:          1,
+			ThrottledResponses: 1,
+			ThrottledByStatus:  map[string]int64{"503": 1},
+			EstimatedCapacity:  0,
+		},
+	}, s.Snapshot())
+
+	clk.Advance(2 * time.Second)
+	s.RecordResponse("http://a", "200", false, 1000)
+	s.RecordResponse("http://a", "429", true, 0)
+	s.RecordResponse("http://a", "200", false, 200)
+	clk.Advance(time.Second)
+	s.RecordResponse("http://a", "200", false, 1000)
+	snapshot := s.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.InDelta(t, (100.0+1200+1000)/3, snapshot[0].EstimatedCapacity, 1e-9)
+
+	// The first responses roll out of the window.
+	clk.Advance(58 * time.Second)
+	snapshot = s.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, int64(4), snapshot[0].Responses)
+	assert.Equal(t, int64(2200), snapshot[0].AcceptedItems)
+	assert.InDelta(t, 2200.0/59, snapshot[0].EstimatedCapacity, 1e-9)
+
+	clk.Advance(time.Minute)
+	snapshot = s.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, DestinationThrottleStats{Destination: "http://a", ThrottledByStatus: map[string]int64{}}, snapshot[0])
+}
+
+func TestThrottleStatsHonoredDelays(t *testing.T) {
+	clk := clocktest.NewFakeClock(time.Now())
+	stats := NewThrottleStats(ThrottleStatsSettings{Enabled: true, Window: time.Hour})
+	stats.clock = clk
+	rCfg := configretry.NewDefaultBackOffConfig()
+	// The back-off is longer than the delay requested by the backend, which is recorded nonetheless.
+	rCfg.InitialInterval = time.Minute
+	rCfg.MaxInterval = time.Minute
+	rCfg.RandomizationFactor = 0
+	be, err := newBaseExporter(defaultSettings, component.DataTypeTraces, newNoopObsrepSender, WithRetry(rCfg),
+		WithClock(clk), WithThrottleStats(stats, "http://backend"))
+	require.NoError(t, err)
+
+	registry := &fakeZPagesRegistry{handlers: map[string]http.Handler{}}
+	host := &mockHost{ext: map[component.ID]component.Component{component.MustNewID("zpages"): registry}}
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(2, wrappedError{NewThrottleRetry(errors.New("throttle error"), 30*time.Second)})
+	done := make(chan error)
+	go func() {
+		done <- be.send(context.Background(), mockR)
+	}()
+	assert.Eventually(t, func() bool {
+		return clk.Waiters() == 1
+	}, time.Second, time.Millisecond)
+	clk.Advance(time.Minute)
+	require.NoError(t, <-done)
+
+	require.Contains(t, registry.handlers, "throttlez/test/traces")
+	rec := httptest.NewRecorder()
+	registry.handlers["throttlez/test/traces"].ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/throttlez/test/traces", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var snapshot throttleStatsSnapshot
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	assert.Equal(t, throttleStatsSnapshot{
+		Exporter:      "test",
+		DataType:      "traces",
+		WindowSeconds: 3600,
+		Destinations: []DestinationThrottleStats{{
+			Destination:            "http://backend",
+			ThrottledByStatus:      map[string]int64{},
+			HonoredDelays:          1,
+			HonoredDelaySeconds:    30,
+			MaxHonoredDelaySeconds: 30,
+		}},
+	}, snapshot)
+
+	rec = httptest.NewRecorder()
+	registry.handlers["throttlez/test/traces"].ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/throttlez/test/traces", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
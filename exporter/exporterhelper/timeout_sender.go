@@ -5,10 +5,14 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
-	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/config/confighumanize"
 )
 
+// timeoutBounds are the bounds of the timeout of the exports.
+var timeoutBounds = confighumanize.DurationBounds{Min: time.Millisecond}
+
 // TimeoutSettings for timeout. The timeout applies to individual attempts to send data to the backend.
 type TimeoutSettings struct {
 	// Timeout is the timeout for every attempt to send data to the backend.
@@ -18,10 +22,7 @@ type TimeoutSettings struct {
 
 func (ts *TimeoutSettings) Validate() error {
 	// Negative timeouts are not acceptable, since all sends will fail.
-	if ts.Timeout < 0 {
-		return errors.New("'timeout' must be non-negative")
-	}
-	return nil
+	return confighumanize.ValidateDuration("timeout", ts.Timeout, timeoutBounds)
 }
 
 // NewDefaultTimeoutSettings returns the default settings for TimeoutSettings.
@@ -35,9 +36,17 @@ func NewDefaultTimeoutSettings() TimeoutSettings {
 type timeoutSender struct {
 	baseRequestSender
 	cfg TimeoutSettings
+	// panics recovers from the panics of the exports once enabled, see baseExporter.RecoverPanics.
+	panics *panicRecovery
 }
 
 func (ts *timeoutSender) send(ctx context.Context, req Request) error {
+	return ts.panics.call(func() error {
+		return ts.export(ctx, req)
+	})
+}
+
+func (ts *timeoutSender) export(ctx context.Context, req Request) error {
 	// TODO: Remove this by avoiding to create the timeout sender if timeout is 0.
 	if ts.cfg.Timeout == 0 {
 		return req.Export(ctx)
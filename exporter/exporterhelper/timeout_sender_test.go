@@ -22,3 +22,9 @@ func TestInvalidTimeout(t *testing.T) {
 	cfg.Timeout = -1
 	assert.Error(t, cfg.Validate())
 }
+
+func TestTimeoutWithoutUnit(t *testing.T) {
+	cfg := TimeoutSettings{Timeout: 5}
+	assert.EqualError(t, cfg.Validate(),
+		`'timeout' must be at least 1ms, got 5ns: the durations without unit are in nanoseconds, use e.g. "5s"`)
+}
@@ -122,6 +122,9 @@ func NewTracesRequestExporter(
 	if err != nil {
 		return nil, err
 	}
+	be.emptyRequest = func(ctx context.Context) (Request, error) {
+		return converter(ctx, ptrace.NewTraces())
+	}
 
 	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		req, cErr := converter(ctx, td)
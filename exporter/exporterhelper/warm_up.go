@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WarmUpSettings defines configuration for warming up the exporter during its start, by exporting an empty request.
+// The request establishes the connections to the destination, e.g. the TLS handshakes and the fetch of the
+// authentication tokens, so that the first data exported after the start does not wait for them.
+type WarmUpSettings struct {
+	// Enabled indicates whether to warm up the exporter during its start.
+	Enabled bool `mapstructure:"enabled"`
+	// Timeout is the maximum time to wait for the warm-up, the exporter starts anyway once elapsed.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// NewDefaultWarmUpSettings returns the default settings for WarmUpSettings.
+func NewDefaultWarmUpSettings() WarmUpSettings {
+	return WarmUpSettings{
+		Enabled: false,
+		Timeout: 10 * time.Second,
+	}
+}
+
+// Validate checks if the WarmUpSettings configuration is valid
+func (cfg *WarmUpSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("warm-up timeout must be positive")
+	}
+	return nil
+}
+
+// WithWarmUp warms up the exporter during its start, by exporting an empty request to the destination.
+// The exporter completes its start, and is reported ready, once the warm-up succeeded, failed or timed out.
+// Since the exporters are started before the receivers, no data waits for the connections to be established.
+// A failed warm-up does not fail the start: the connections are then established by the first export.
+func WithWarmUp(cfg WarmUpSettings) Option {
+	return func(o *baseExporter) error {
+		if !cfg.Enabled {
+			return nil
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		o.warmUp = cfg
+		return nil
+	}
+}
+
+// warmUpExport exports the empty request built by emptyRequest, bypassing the senders of the exporter.
+func (be *baseExporter) warmUpExport(ctx context.Context) {
+	if !be.warmUp.Enabled || be.emptyRequest == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, be.warmUp.Timeout)
+	defer cancel()
+	start := time.Now()
+	req, err := be.emptyRequest(ctx)
+	if err == nil {
+		err = req.Export(ctx)
+	}
+	if err != nil {
+		be.set.Logger.Warn("Failed to warm up the exporter, the connections will be established by the first export.",
+			zap.Error(err))
+		return
+	}
+	be.set.Logger.Info("Exporter warmed up", zap.Duration("duration", time.Since(start)))
+}
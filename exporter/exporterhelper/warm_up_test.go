@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestWarmUpSettings_Validate(t *testing.T) {
+	cfg := NewDefaultWarmUpSettings()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Timeout = 0
+	assert.NoError(t, cfg.Validate(), "must not fail when disabled")
+
+	cfg.Enabled = true
+	assert.EqualError(t, cfg.Validate(), "warm-up timeout must be positive")
+
+	cfg.Timeout = time.Second
+	assert.NoError(t, cfg.Validate())
+
+	_, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(nil), WithWarmUp(WarmUpSettings{Enabled: true}))
+	assert.EqualError(t, err, "warm-up timeout must be positive")
+}
+
+func TestWarmUp(t *testing.T) {
+	warmUp := WarmUpSettings{Enabled: true, Timeout: time.Second}
+	var pushed []int
+	pusher := func(_ context.Context, td ptrace.Traces) error {
+		pushed = append(pushed, td.SpanCount())
+		return nil
+	}
+
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		pusher, WithWarmUp(warmUp), WithQueue(NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	// The empty request is exported before the start completes.
+	assert.Equal(t, []int{0}, pushed)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	pushed = nil
+	te, err = NewTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeTracesExporterConfig,
+		pusher, WithWarmUp(NewDefaultWarmUpSettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Empty(t, pushed)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	var metricPoints, logRecords []int
+	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeMetricsExporterConfig,
+		func(_ context.Context, md pmetric.Metrics) error {
+			metricPoints = append(metricPoints, md.DataPointCount())
+			return nil
+		}, WithWarmUp(warmUp))
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []int{0}, metricPoints)
+	require.NoError(t, me.Shutdown(context.Background()))
+
+	le, err := NewLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), &fakeLogsExporterConfig,
+		func(_ context.Context, ld plog.Logs) error {
+			logRecords = append(logRecords, ld.LogRecordCount())
+			return nil
+		}, WithWarmUp(warmUp))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []int{0}, logRecords)
+	require.NoError(t, le.Shutdown(context.Background()))
+}
+
+func TestWarmUpFailure(t *testing.T) {
+	set := exportertest.NewNopCreateSettings()
+	logger, observed := observer.New(zap.InfoLevel)
+	set.Logger = zap.New(logger)
+	pusher := func(ctx context.Context, _ ptrace.Traces) error {
+		<-ctx.Done()
+		return errors.New("connection refused")
+	}
+
+	te, err := NewTracesExporter(context.Background(), set, &fakeTracesExporterConfig, pusher,
+		WithWarmUp(WarmUpSettings{Enabled: true, Timeout: 10 * time.Millisecond}))
+	require.NoError(t, err)
+	// A failed warm-up does not fail the start.
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	logs := observed.FilterMessageSnippet("Failed to warm up the exporter").All()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "connection refused", logs[0].ContextMap()["error"])
+}
@@ -5,6 +5,7 @@ package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporter
 
 import (
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -20,6 +21,9 @@ type Config struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of requests allowed in queue at any given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// DrainTimeout is the maximum time to wait for the queue to be drained when shutting down, the
+	// exports still in flight are then canceled. Zero means no timeout.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
 }
 
 // NewDefaultConfig returns the default Config.
@@ -44,6 +48,9 @@ func (qCfg *Config) Validate() error {
 	if qCfg.QueueSize <= 0 {
 		return errors.New("queue size must be positive")
 	}
+	if qCfg.DrainTimeout < 0 {
+		return errors.New("drain timeout must be non-negative")
+	}
 	return nil
 }
 
@@ -43,6 +43,7 @@ func (textLogsMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
 				buf.logEntry("Trace ID: %s", lr.TraceID())
 				buf.logEntry("Span ID: %s", lr.SpanID())
 				buf.logEntry("Flags: %d", lr.Flags())
+				buf.logEntry("EventName: %s", lr.EventName())
 			}
 		}
 	}
@@ -58,6 +58,27 @@ func (q *boundedMemoryQueue[T]) Consume(consumeFunc func(context.Context, T) err
 	return true
 }
 
+// Purge removes the items waiting in the queue without consuming them.
+func (q *boundedMemoryQueue[T]) Purge(purgeFunc func(context.Context, T)) {
+	var purged []queueRequest[T]
+	for done := false; !done; {
+		select {
+		case item, ok := <-q.items:
+			if !ok {
+				done = true
+				break
+			}
+			q.queueCapacityLimiter.release(item.req)
+			purged = append(purged, item)
+		default:
+			done = true
+		}
+	}
+	for _, item := range purged {
+		purgeFunc(item.ctx, item.req)
+	}
+}
+
 // Shutdown closes the queue channel to initiate draining of the queue.
 func (q *boundedMemoryQueue[T]) Shutdown(context.Context) error {
 	close(q.items)
@@ -96,6 +96,31 @@ func TestShutdownWhileNotEmpty(t *testing.T) {
 	}))
 }
 
+func TestBoundedQueuePurge(t *testing.T) {
+	q := NewBoundedMemoryQueue[string](MemoryQueueSettings[string]{Sizer: &RequestSizer[string]{}, Capacity: 2})
+	assert.NoError(t, q.Offer(context.Background(), "a"))
+	assert.NoError(t, q.Offer(context.Background(), "b"))
+	assert.ErrorIs(t, q.Offer(context.Background(), "c"), ErrQueueIsFull)
+
+	var purged []string
+	q.(Purger[string]).Purge(func(_ context.Context, item string) {
+		purged = append(purged, item)
+	})
+	assert.Equal(t, []string{"a", "b"}, purged)
+	assert.Equal(t, 0, q.Size())
+
+	// The capacity of the purged items is released.
+	assert.NoError(t, q.Offer(context.Background(), "d"))
+	assert.NoError(t, q.Shutdown(context.Background()))
+	assert.True(t, q.Consume(func(_ context.Context, item string) error {
+		assert.Equal(t, "d", item)
+		return nil
+	}))
+	q.(Purger[string]).Purge(func(_ context.Context, item string) {
+		panic(item)
+	})
+}
+
 func Benchmark_QueueUsage_1000_requests(b *testing.B) {
 	benchmarkQueueUsage(b, &RequestSizer[fakeReq]{}, 1000)
 }
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errQueueStopped = errors.New("sending queue is stopped")
+
+// fairQueue is a bounded memory queue holding a FIFO queue per key, e.g. per pipeline. The keys are served
+// in a weighted round-robin: up to weight items of a key are consumed before moving to the next key, so that
+// a key with many items cannot starve the others. The capacity is shared by all the keys.
+type fairQueue[T any] struct {
+	component.StartFunc
+	*queueCapacityLimiter[T]
+	keyFunc func(context.Context) component.ID
+	weights map[component.ID]int
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// subQueues holds the FIFO queue of each key, active the keys with items in the order they are served.
+	subQueues map[component.ID]*fairSubQueue[T]
+	active    []component.ID
+	current   int
+	stopped   bool
+}
+
+type fairSubQueue[T any] struct {
+	items  []queueRequest[T]
+	weight int
+	// served is the number of items consumed during the current turn of the key.
+	served int
+}
+
+// FairQueueSettings defines internal parameters for fairQueue creation.
+type FairQueueSettings[T any] struct {
+	Sizer    Sizer[T]
+	Capacity int
+	// KeyFunc returns the key of the items offered with the context.
+	KeyFunc func(context.Context) component.ID
+	// Weights are the weights of the keys, the keys without a weight have a weight of 1.
+	Weights map[component.ID]int
+}
+
+// NewFairQueue constructs a queue of the specified capacity serving the keys of the items fairly.
+func NewFairQueue[T any](set FairQueueSettings[T]) Queue[T] {
+	q := &fairQueue[T]{
+		queueCapacityLimiter: newQueueCapacityLimiter[T](set.Sizer, set.Capacity),
+		keyFunc:              set.KeyFunc,
+		weights:              set.Weights,
+		subQueues:            map[component.ID]*fairSubQueue[T]{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Offer is used by the producer to submit new item to the queue of its key.
+func (q *fairQueue[T]) Offer(ctx context.Context, req T) error {
+	if !q.queueCapacityLimiter.claim(req) {
+		return ErrQueueIsFull
+	}
+	key := q.keyFunc(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		q.queueCapacityLimiter.release(req)
+		return errQueueStopped
+	}
+	sq, ok := q.subQueues[key]
+	if !ok {
+		sq = &fairSubQueue[T]{weight: 1}
+		if w, ok := q.weights[key]; ok && w > 0 {
+			sq.weight = w
+		}
+		q.subQueues[key] = sq
+	}
+	if len(sq.items) == 0 {
+		q.active = append(q.active, key)
+	}
+	sq.items = append(sq.items, queueRequest[T]{ctx: ctx, req: req})
+	q.cond.Signal()
+	return nil
+}
+
+// Consume applies the provided function on the next item of the key being served.
+// The call blocks until there is an item available or the queue is stopped.
+// The function returns true when an item is consumed or false if the queue is stopped and emptied.
+func (q *fairQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
+	q.mu.Lock()
+	for len(q.active) == 0 && !q.stopped {
+		q.cond.Wait()
+	}
+	if len(q.active) == 0 {
+		q.mu.Unlock()
+		return false
+	}
+	item := q.pop()
+	q.mu.Unlock()
+
+	q.queueCapacityLimiter.release(item.req)
+	// the memory queue doesn't handle consume errors
+	_ = consumeFunc(item.ctx, item.req)
+	return true
+}
+
+// pop removes the next item of the key being served, and moves to the next key if the current one
+// is emptied or has been served its weight. It must be called with q.mu held and a key active.
+func (q *fairQueue[T]) pop() queueRequest[T] {
+	key := q.active[q.current]
+	sq := q.subQueues[key]
+	item := sq.items[0]
+	var zero queueRequest[T]
+	sq.items[0] = zero
+	sq.items = sq.items[1:]
+	sq.served++
+
+	switch {
+	case len(sq.items) == 0:
+		sq.served = 0
+		// Release the backing array, the key is likely to be idle for a while.
+		sq.items = nil
+		q.active = append(q.active[:q.current], q.active[q.current+1:]...)
+		if q.current >= len(q.active) {
+			q.current = 0
+		}
+	case sq.served >= sq.weight:
+		sq.served = 0
+		q.current = (q.current + 1) % len(q.active)
+	}
+	return item
+}
+
+// Purge removes the items waiting in the queues of all the keys without consuming them.
+func (q *fairQueue[T]) Purge(purgeFunc func(context.Context, T)) {
+	q.mu.Lock()
+	var purged []queueRequest[T]
+	for _, key := range q.active {
+		sq := q.subQueues[key]
+		purged = append(purged, sq.items...)
+		sq.items = nil
+		sq.served = 0
+	}
+	q.active = nil
+	q.current = 0
+	q.mu.Unlock()
+
+	for _, item := range purged {
+		q.queueCapacityLimiter.release(item.req)
+		purgeFunc(item.ctx, item.req)
+	}
+}
+
+// Shutdown stops the queue, the remaining items are still consumed.
+func (q *fairQueue[T]) Shutdown(context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopped = true
+	q.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type fairKey struct{}
+
+var (
+	busyKey  = component.MustNewIDWithName("traces", "busy")
+	quietKey = component.MustNewIDWithName("traces", "quiet")
+)
+
+func fairContext(key component.ID) context.Context {
+	return context.WithValue(context.Background(), fairKey{}, key)
+}
+
+func newTestFairQueue(capacity int, weights map[component.ID]int) Queue[string] {
+	return NewFairQueue[string](FairQueueSettings[string]{
+		Sizer:    &RequestSizer[string]{},
+		Capacity: capacity,
+		KeyFunc: func(ctx context.Context) component.ID {
+			key, _ := ctx.Value(fairKey{}).(component.ID)
+			return key
+		},
+		Weights: weights,
+	})
+}
+
+func consumeAll(t *testing.T, q Queue[string], n int) []string {
+	var consumed []string
+	for i := 0; i < n; i++ {
+		require.True(t, q.Consume(func(_ context.Context, item string) error {
+			consumed = append(consumed, item)
+			return nil
+		}))
+	}
+	return consumed
+}
+
+func TestFairQueue(t *testing.T) {
+	q := newTestFairQueue(100, nil)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, item := range []string{"b1", "b2", "b3", "b4"} {
+		require.NoError(t, q.Offer(fairContext(busyKey), item))
+	}
+	require.NoError(t, q.Offer(fairContext(quietKey), "q1"))
+	require.NoError(t, q.Offer(fairContext(quietKey), "q2"))
+	require.NoError(t, q.Offer(context.Background(), "u1"))
+	assert.Equal(t, 7, q.Size())
+
+	// The quiet key and the unknown key are served in turn with the busy key.
+	assert.Equal(t, []string{"b1", "q1", "u1", "b2", "q2", "b3", "b4"}, consumeAll(t, q, 7))
+	assert.Equal(t, 0, q.Size())
+
+	// The emptied keys are served again when new items are offered.
+	require.NoError(t, q.Offer(fairContext(quietKey), "q3"))
+	require.NoError(t, q.Offer(fairContext(busyKey), "b5"))
+	assert.Equal(t, []string{"q3", "b5"}, consumeAll(t, q, 2))
+
+	require.NoError(t, q.Shutdown(context.Background()))
+	assert.False(t, q.Consume(func(_ context.Context, item string) error {
+		panic(item)
+	}))
+	assert.ErrorIs(t, q.Offer(fairContext(busyKey), "b6"), errQueueStopped)
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestFairQueueWeights(t *testing.T) {
+	q := newTestFairQueue(100, map[component.ID]int{busyKey: 3})
+	for _, item := range []string{"b1", "b2", "b3", "b4", "b5"} {
+		require.NoError(t, q.Offer(fairContext(busyKey), item))
+	}
+	for _, item := range []string{"q1", "q2", "q3"} {
+		require.NoError(t, q.Offer(fairContext(quietKey), item))
+	}
+	assert.Equal(t, []string{"b1", "b2", "b3", "q1", "b4", "b5", "q2", "q3"}, consumeAll(t, q, 8))
+}
+
+func TestFairQueueCapacity(t *testing.T) {
+	q := newTestFairQueue(2, nil)
+	require.NoError(t, q.Offer(fairContext(busyKey), "b1"))
+	require.NoError(t, q.Offer(fairContext(quietKey), "q1"))
+	assert.ErrorIs(t, q.Offer(fairContext(quietKey), "q2"), ErrQueueIsFull)
+	assert.Equal(t, 2, q.Capacity())
+	assert.Equal(t, 2, q.Size())
+}
+
+func TestFairQueueShutdownWhileNotEmpty(t *testing.T) {
+	q := newTestFairQueue(100, nil)
+	consumers := NewQueueConsumers(q, 4, func(context.Context, string) error { return nil })
+	require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+
+	var wg sync.WaitGroup
+	for _, key := range []component.ID{busyKey, quietKey} {
+		wg.Add(1)
+		go func(key component.ID) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				assert.NoError(t, q.Offer(fairContext(key), key.String()))
+			}
+		}(key)
+	}
+	wg.Wait()
+	require.NoError(t, consumers.Shutdown(context.Background()))
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestFairQueuePurge(t *testing.T) {
+	q := newTestFairQueue(3, nil)
+	require.NoError(t, q.Offer(fairContext(busyKey), "b1"))
+	require.NoError(t, q.Offer(fairContext(busyKey), "b2"))
+	require.NoError(t, q.Offer(fairContext(quietKey), "q1"))
+
+	var purged []string
+	q.(Purger[string]).Purge(func(_ context.Context, item string) {
+		purged = append(purged, item)
+	})
+	assert.ElementsMatch(t, []string{"b1", "b2", "q1"}, purged)
+	assert.Equal(t, 0, q.Size())
+
+	// The purged keys are served again when new items are offered.
+	require.NoError(t, q.Offer(fairContext(quietKey), "q2"))
+	require.NoError(t, q.Offer(fairContext(busyKey), "b3"))
+	assert.Equal(t, []string{"q2", "b3"}, consumeAll(t, q, 2))
+}
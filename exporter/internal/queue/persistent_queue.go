@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -16,8 +17,8 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 )
 
@@ -28,7 +29,9 @@ import (
 // When Write index = Read index, no elements are in the queue.
 //
 // The items currently dispatched by consumers are not deleted until the processing is finished.
-// Their list is stored under a separate key.
+// Their list is stored under a separate key. On start, the items left in the list, e.g. by a crash
+// while they were exported or retried, are moved back to the queue: every item accepted by the queue
+// is exported at least once, and only the items whose export was not acknowledged can be duplicated.
 //
 //	┌───────file extension-backed queue───────┐
 //	│                                         │
@@ -68,7 +71,7 @@ type persistentQueue[T any] struct {
 
 const (
 	zapKey           = "key"
-	zapErrorCount    = "errorCount"
+	zapKeptItems     = "keptItems"
 	zapNumberOfItems = "numberOfItems"
 
 	readIndexKey                = "ri"
@@ -107,6 +110,12 @@ func NewPersistentQueue[T any](set PersistentQueueSettings[T]) Queue[T] {
 	}
 }
 
+// IsPersistent returns whether the queue keeps its items in a storage, so they survive a restart.
+func IsPersistent[T any](q Queue[T]) bool {
+	_, ok := q.(*persistentQueue[T])
+	return ok
+}
+
 // Start starts the persistentQueue with the given number of consumers.
 func (pq *persistentQueue[T]) Start(ctx context.Context, host component.Host) error {
 	storageClient, err := toStorageClient(ctx, pq.set.StorageID, host, pq.set.ExporterSettings.ID, pq.set.DataType)
@@ -133,6 +142,10 @@ func (pq *persistentQueue[T]) initPersistentContiguousStorage(ctx context.Contex
 	err := pq.client.Batch(ctx, riOp, wiOp)
 	if err == nil {
 		pq.readIndex, err = bytesToItemIndex(riOp.Value)
+		// The read index is not set until the first item is read, the items written before must not be lost.
+		if errors.Is(err, errValueNotSet) && wiOp.Value != nil {
+			pq.readIndex, err = 0, nil
+		}
 	}
 
 	if err == nil {
@@ -361,7 +374,7 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (T, func(error),
 			pq.mu.Unlock()
 		}()
 
-		if experr.IsShutdownErr(consumeErr) {
+		if consumererror.IsShutdown(consumeErr) {
 			// The queue is shutting down, don't mark the item as dispatched, so it's picked up again after restart.
 			// TODO: Handle partially delivered requests by updating their values in the storage.
 			return
@@ -396,8 +409,11 @@ func (pq *persistentQueue[T]) releaseCapacity(req T) {
 	pq.queueCapacityLimiter.release(req)
 }
 
-// retrieveAndEnqueueNotDispatchedReqs gets the items for which sending was not finished, cleans the storage
-// and moves the items at the back of the queue.
+// retrieveAndEnqueueNotDispatchedReqs gets the items for which sending was not finished, and moves them at the
+// back of the queue. The items are moved, and the list of the currently dispatched items cleared, in a single
+// storage batch: if the collector crashes or the storage fails before it completes, the items are still listed
+// and are moved on the next start, so that they are neither lost nor duplicated. The items which do not fit in
+// the queue are also kept listed until a start with enough capacity.
 func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Context) {
 	var dispatchedItems []uint64
 
@@ -421,44 +437,72 @@ func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Co
 	pq.logger.Info("Fetching items left for dispatch by consumers", zap.Int(zapNumberOfItems,
 		len(dispatchedItems)))
 	retrieveBatch := make([]storage.Operation, len(dispatchedItems))
-	cleanupBatch := make([]storage.Operation, len(dispatchedItems))
 	for i, it := range dispatchedItems {
-		key := getItemKey(it)
-		retrieveBatch[i] = storage.GetOperation(key)
-		cleanupBatch[i] = storage.DeleteOperation(key)
-	}
-	retrieveErr := pq.client.Batch(ctx, retrieveBatch...)
-	cleanupErr := pq.client.Batch(ctx, cleanupBatch...)
-
-	if cleanupErr != nil {
-		pq.logger.Debug("Failed cleaning items left by consumers", zap.Error(cleanupErr))
+		retrieveBatch[i] = storage.GetOperation(getItemKey(it))
 	}
-
-	if retrieveErr != nil {
-		pq.logger.Warn("Failed retrieving items left by consumers", zap.Error(retrieveErr))
+	if retrieveErr := pq.client.Batch(ctx, retrieveBatch...); retrieveErr != nil {
+		pq.logger.Warn("Failed retrieving items left by consumers, they will be moved on the next start", zap.Error(retrieveErr))
+		// Keep the items listed, otherwise the next update of the list by getNextItem would drop them.
+		pq.currentlyDispatchedItems = append(pq.currentlyDispatchedItems, dispatchedItems...)
 		return
 	}
 
-	errCount := 0
-	for _, op := range retrieveBatch {
+	// The items are deleted, and set again at the back of the queue if they can be decoded and fit in it.
+	// The items which do not fit are kept, and still listed, so that they are moved on the next start.
+	moveBatch := make([]storage.Operation, 0, 2*len(retrieveBatch)+2)
+	var moved []T
+	var kept []uint64
+	newIndex := pq.writeIndex
+	for i, op := range retrieveBatch {
 		if op.Value == nil {
 			pq.logger.Warn("Failed retrieving item", zap.String(zapKey, op.Key), zap.Error(errValueNotSet))
+			moveBatch = append(moveBatch, storage.DeleteOperation(op.Key))
 			continue
 		}
 		req, err := pq.set.Unmarshaler(op.Value)
 		// If error happened or item is nil, it will be efficiently ignored
 		if err != nil {
 			pq.logger.Warn("Failed unmarshalling item", zap.String(zapKey, op.Key), zap.Error(err))
+			moveBatch = append(moveBatch, storage.DeleteOperation(op.Key))
 			continue
 		}
-		if pq.putInternal(ctx, req) != nil {
-			errCount++
+		// The capacity limiter does not account for the items restored from the storage, the channel does.
+		if len(pq.putChan)+len(moved) == cap(pq.putChan) || !pq.queueCapacityLimiter.claim(req) {
+			kept = append(kept, dispatchedItems[i])
+			continue
 		}
+		moveBatch = append(moveBatch,
+			storage.DeleteOperation(op.Key),
+			storage.SetOperation(getItemKey(newIndex), op.Value))
+		newIndex++
+		moved = append(moved, req)
+	}
+	stillDispatched := append(slices.Clone(pq.currentlyDispatchedItems), kept...)
+	moveBatch = append(moveBatch,
+		storage.SetOperation(writeIndexKey, itemIndexToBytes(newIndex)),
+		storage.SetOperation(currentlyDispatchedItemsKey, itemIndexArrayToBytes(stillDispatched)))
+
+	if err = pq.client.Batch(ctx, moveBatch...); err != nil {
+		for _, req := range moved {
+			pq.queueCapacityLimiter.release(req)
+		}
+		// Keep the items listed, otherwise the next update of the list by getNextItem would drop them.
+		pq.currentlyDispatchedItems = append(pq.currentlyDispatchedItems, dispatchedItems...)
+		pq.logger.Error("Failed moving items left by consumers back to queue, they will be moved on the next start",
+			zap.Int(zapNumberOfItems, len(retrieveBatch)), zap.Error(err))
+		return
+	}
+	pq.currentlyDispatchedItems = stillDispatched
+
+	pq.writeIndex = newIndex
+	for range moved {
+		// Inform the loop that there's some data to process
+		pq.putChan <- struct{}{}
 	}
 
-	if errCount > 0 {
-		pq.logger.Error("Errors occurred while moving items for dispatching back to queue",
-			zap.Int(zapNumberOfItems, len(retrieveBatch)), zap.Int(zapErrorCount, errCount))
+	if len(kept) > 0 {
+		pq.logger.Warn("Maximum queue capacity reached, some items left by consumers will be moved on the next start",
+			zap.Int(zapNumberOfItems, len(retrieveBatch)), zap.Int(zapKeptItems, len(kept)))
 	} else {
 		pq.logger.Info("Moved items for dispatching back to queue",
 			zap.Int(zapNumberOfItems, len(retrieveBatch)))
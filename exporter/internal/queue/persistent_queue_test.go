@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync/atomic"
 	"syscall"
@@ -17,8 +18,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exportertest"
-	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -412,7 +413,7 @@ func TestPersistentQueue_CorruptedData(t *testing.T) {
 			}
 			assert.Equal(t, 3, ps.Size())
 			require.True(t, ps.Consume(func(context.Context, tracesRequest) error {
-				return experr.NewShutdownErr(nil)
+				return consumererror.NewShutdown(nil)
 			}))
 			assert.Equal(t, 2, ps.Size())
 
@@ -524,7 +525,7 @@ func TestPersistentQueueStartWithNonDispatched(t *testing.T) {
 		// put one more item in
 		require.NoError(t, ps.Offer(context.Background(), req))
 		require.Equal(t, 5, ps.Size())
-		return experr.NewShutdownErr(nil)
+		return consumererror.NewShutdown(nil)
 	}))
 	assert.NoError(t, ps.Shutdown(context.Background()))
 
@@ -533,6 +534,171 @@ func TestPersistentQueueStartWithNonDispatched(t *testing.T) {
 	require.Equal(t, 6, newPs.Size())
 }
 
+var errCrash = errors.New("crashed")
+
+// crashingStorageClient simulates a crash of the collector after a number of storage batches:
+// the following batches are not applied. The client never crashes if the number is negative.
+type crashingStorageClient struct {
+	storage.Client
+	remaining int
+	crashed   bool
+}
+
+func (c *crashingStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *crashingStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *crashingStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *crashingStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	if c.remaining == 0 {
+		c.crashed = true
+		return errCrash
+	}
+	c.remaining--
+	return c.Client.Batch(ctx, ops...)
+}
+
+func TestPersistentQueue_CrashRecovery(t *testing.T) {
+	// The number of storage batches of the first run, and of the recovery, to crash after.
+	const runBatches, recoveryBatches = 12, 5
+	for crashRun := 0; crashRun <= runBatches; crashRun++ {
+		for crashRecovery := 0; crashRecovery <= recoveryBatches; crashRecovery++ {
+			t.Run(fmt.Sprintf("run_%d_recovery_%d", crashRun, crashRecovery), func(t *testing.T) {
+				ext := NewMockStorageExtension(nil)
+				newClient := func(remaining int) *crashingStorageClient {
+					client, err := ext.GetClient(context.Background(), component.KindExporter, component.ID{}, "")
+					require.NoError(t, err)
+					return &crashingStorageClient{Client: client, remaining: remaining}
+				}
+
+				// The requests are identified by their number of spans.
+				client := newClient(crashRun)
+				pq := createTestPersistentQueueWithClient(client)
+				var expected []int
+				for i := 1; i <= 4; i++ {
+					if pq.Offer(context.Background(), newTracesRequest(1, i)) == nil {
+						expected = append(expected, i)
+					}
+				}
+				// Export two requests, and keep the third one in flight.
+				for i := 0; i < 3; i++ {
+					req, onProcessingFinished, found := pq.getNextItem(context.Background())
+					if !found || i == 2 {
+						continue
+					}
+					onProcessingFinished(nil)
+					if !client.crashed {
+						// The export is acknowledged, the request must not be exported again.
+						expected = slices.DeleteFunc(expected, func(n int) bool { return n == req.ItemsCount() })
+					}
+				}
+
+				// Crash while moving the requests left for dispatch back to the queue.
+				createTestPersistentQueueWithClient(newClient(crashRecovery))
+
+				recovered := createTestPersistentQueueWithClient(newClient(-1))
+				var exported []int
+				for recovered.Size() > 0 {
+					recovered.Consume(func(_ context.Context, req tracesRequest) error {
+						exported = append(exported, req.ItemsCount())
+						return nil
+					})
+				}
+				assert.ElementsMatch(t, expected, exported)
+				requireCurrentlyDispatchedItemsEqual(t, recovered, []uint64{})
+				assert.NoError(t, recovered.Shutdown(context.Background()))
+			})
+		}
+	}
+}
+
+// failingMoveStorageClient fails the storage batches moving the items left for dispatch back to the queue,
+// i.e. the batches updating both the write index and the list of the currently dispatched items.
+type failingMoveStorageClient struct {
+	storage.Client
+}
+
+func (c *failingMoveStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	keys := map[string]bool{}
+	for _, op := range ops {
+		keys[op.Key] = true
+	}
+	if keys[writeIndexKey] && keys[currentlyDispatchedItemsKey] {
+		return errors.New("move failed")
+	}
+	return c.Client.Batch(ctx, ops...)
+}
+
+func TestPersistentQueue_MoveFailureKeepsItems(t *testing.T) {
+	ext := NewMockStorageExtension(nil)
+	client, err := ext.GetClient(context.Background(), component.KindExporter, component.ID{}, "")
+	require.NoError(t, err)
+
+	// The requests are identified by their number of spans, keep the first one in flight.
+	pq := createTestPersistentQueueWithClient(client)
+	require.NoError(t, pq.Offer(context.Background(), newTracesRequest(1, 1)))
+	_, _, found := pq.getNextItem(context.Background())
+	require.True(t, found)
+
+	// The move fails, and the queue keeps running: dispatching another item must not drop the first one
+	// from the stored list of the currently dispatched items.
+	failing := createTestPersistentQueueWithClient(&failingMoveStorageClient{Client: client})
+	requireCurrentlyDispatchedItemsEqual(t, failing, []uint64{0})
+	require.NoError(t, failing.Offer(context.Background(), newTracesRequest(1, 2)))
+	require.True(t, failing.Consume(func(context.Context, tracesRequest) error {
+		return nil
+	}))
+	requireCurrentlyDispatchedItemsEqual(t, failing, []uint64{0})
+
+	recovered := createTestPersistentQueueWithClient(client)
+	require.Equal(t, 1, recovered.Size())
+	require.True(t, recovered.Consume(func(_ context.Context, req tracesRequest) error {
+		assert.Equal(t, 1, req.ItemsCount())
+		return nil
+	}))
+	requireCurrentlyDispatchedItemsEqual(t, recovered, []uint64{})
+}
+
+func TestPersistentQueue_FullQueueKeepsItems(t *testing.T) {
+	req := newTracesRequest(5, 10)
+	ext := NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsCapacity(t, ext, 5)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, ps.Offer(context.Background(), req))
+	}
+	// Keep one item in flight, and fill the queue again.
+	require.True(t, ps.Consume(func(context.Context, tracesRequest) error {
+		require.NoError(t, ps.Offer(context.Background(), req))
+		return consumererror.NewShutdown(nil)
+	}))
+	require.NoError(t, ps.Shutdown(context.Background()))
+
+	// The item in flight does not fit in the queue, it is kept for the next start.
+	full := createTestPersistentQueueWithRequestsCapacity(t, ext, 5)
+	require.Equal(t, 5, full.Size())
+	requireCurrentlyDispatchedItemsEqual(t, full, []uint64{0})
+	for i := 0; i < 5; i++ {
+		require.True(t, full.Consume(func(context.Context, tracesRequest) error {
+			return nil
+		}))
+	}
+	requireCurrentlyDispatchedItemsEqual(t, full, []uint64{0})
+	require.NoError(t, full.Shutdown(context.Background()))
+
+	recovered := createTestPersistentQueueWithRequestsCapacity(t, ext, 5)
+	require.Equal(t, 1, recovered.Size())
+	requireCurrentlyDispatchedItemsEqual(t, recovered, []uint64{})
+}
+
 func TestPersistentQueue_PutCloseReadClose(t *testing.T) {
 	req := newTracesRequest(5, 10)
 	ext := NewMockStorageExtension(nil)
@@ -543,8 +709,6 @@ func TestPersistentQueue_PutCloseReadClose(t *testing.T) {
 	assert.NoError(t, ps.Offer(context.Background(), req))
 	assert.NoError(t, ps.Offer(context.Background(), req))
 	assert.Equal(t, 2, ps.Size())
-	// TODO: Remove this, after the initialization writes the readIndex.
-	_, _, _ = ps.getNextItem(context.Background())
 	assert.NoError(t, ps.Shutdown(context.Background()))
 
 	newPs := createTestPersistentQueueWithRequestsCapacity(t, ext, 1000)
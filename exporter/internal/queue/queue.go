@@ -34,3 +34,10 @@ type Queue[T any] interface {
 	// Capacity returns the capacity of the queue.
 	Capacity() int
 }
+
+// Purger is implemented by the memory queues, whose items can be removed without being consumed.
+type Purger[T any] interface {
+	// Purge removes the items waiting in the queue and releases their capacity,
+	// the provided function is then called with each removed item.
+	Purge(func(ctx context.Context, item T))
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package useragent renders the identification of the collector, i.e. the
+// User-Agent and other configured headers, sent by the exporters.
+package useragent // import "go.opentelemetry.io/collector/exporter/internal/useragent"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// hostname returns the name of the host, overridden in tests.
+var hostname = os.Hostname
+
+// Fields are the fields available in the templates.
+type Fields struct {
+	// Description is the description of the collector distribution, e.g. "OpenTelemetry Collector".
+	Description string
+	// Command is the command name of the collector distribution, e.g. "otelcol".
+	Command string
+	// Version is the version of the collector distribution.
+	Version string
+	// Hostname is the name of the host running the collector.
+	Hostname string
+	// OS is the operating system running the collector.
+	OS string
+	// Arch is the architecture of the host running the collector.
+	Arch string
+	// CustomFields are the custom fields of the collector distribution, e.g. {{.CustomFields.vendor}},
+	// with their control characters replaced.
+	CustomFields map[string]string
+	// Default is the User-Agent sent when none is configured, e.g. "OpenTelemetry Collector/0.98.0 (linux/amd64)".
+	Default string
+}
+
+// Default returns the User-Agent sent when none is configured. The custom fields of the
+// distribution are appended to the comment, sorted by key, e.g. "(linux/amd64; vendor=acme)".
+func Default(info component.BuildInfo) string {
+	comment := runtime.GOOS + "/" + runtime.GOARCH
+	for _, f := range info.CustomFields() {
+		comment += "; " + sanitizeComment(f.Key) + "=" + sanitizeComment(f.Value)
+	}
+	return fmt.Sprintf("%s/%s (%s)", info.Description, info.Version, comment)
+}
+
+// sanitizeComment replaces the characters delimiting the comment of the User-Agent or its
+// elements, and the control characters, which are not allowed in header values.
+func sanitizeComment(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '(' || r == ')' || r == ';' || r == '\\' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// sanitizeHeaderValue replaces the control characters, which are not allowed in header values.
+func sanitizeHeaderValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// sampleFields are the fields the templates are executed with to be validated, before the build
+// information of the collector is known.
+var sampleFields = Fields{
+	Description: "OpenTelemetry Collector",
+	Command:     "otelcol",
+	Version:     "0.0.0",
+	Hostname:    "localhost",
+	OS:          runtime.GOOS,
+	Arch:        runtime.GOARCH,
+	Default:     "OpenTelemetry Collector/0.0.0 (" + runtime.GOOS + "/" + runtime.GOARCH + ")",
+}
+
+// Validate checks that the given template is valid, by executing it with sample fields. The custom fields
+// are not checked, as they are only known when the template is rendered.
+func Validate(tmpl string) error {
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	return t.Execute(io.Discard, sampleFields)
+}
+
+// Render renders the User-Agent from the given template, returning the default one when the template is empty.
+func Render(tmpl string, info component.BuildInfo) (string, error) {
+	if tmpl == "" {
+		return Default(info), nil
+	}
+	fields, err := newFields(info)
+	if err != nil {
+		return "", err
+	}
+	return render(tmpl, fields)
+}
+
+// RenderHeaders returns the given headers with the rendered templated headers added. The headers are
+// returned unchanged when there is no templated header.
+func RenderHeaders[S ~string](headers map[string]S, templated map[string]string, info component.BuildInfo) (map[string]S, error) {
+	if len(templated) == 0 {
+		return headers, nil
+	}
+
+	fields, err := newFields(info)
+	if err != nil {
+		return nil, err
+	}
+	rendered := make(map[string]S, len(headers)+len(templated))
+	for k, v := range headers {
+		rendered[k] = v
+	}
+	for k, tmpl := range templated {
+		s, err := render(tmpl, fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render templated header %q: %w", k, err)
+		}
+		rendered[k] = S(s)
+	}
+	return rendered, nil
+}
+
+// ValidateHeaders checks that the templates of the templated headers are valid, and that they are
+// not set in the static headers too.
+func ValidateHeaders[S ~string](headers map[string]S, templated map[string]string) error {
+	for k, tmpl := range templated {
+		for h := range headers {
+			if strings.EqualFold(h, k) {
+				return fmt.Errorf("header %q is set in both headers and templated_headers", k)
+			}
+		}
+		if err := Validate(tmpl); err != nil {
+			return fmt.Errorf("invalid templated header %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func newFields(info component.BuildInfo) (Fields, error) {
+	host, err := hostname()
+	if err != nil {
+		return Fields{}, fmt.Errorf("failed to get hostname: %w", err)
+	}
+	var customFields map[string]string
+	if fields := info.CustomFields(); len(fields) > 0 {
+		customFields = make(map[string]string, len(fields))
+		for _, f := range fields {
+			customFields[f.Key] = sanitizeHeaderValue(f.Value)
+		}
+	}
+	return Fields{
+		Description:  info.Description,
+		Command:      info.Command,
+		Version:      info.Version,
+		Hostname:     host,
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		CustomFields: customFields,
+		Default:      Default(info),
+	}, nil
+}
+
+func render(tmpl string, fields Fields) (string, error) {
+	t, err := template.New("").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err = t.Execute(&sb, fields); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package useragent
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var buildInfo = component.BuildInfo{Command: "otelcol", Description: "Collector", Version: "1.2.3"}
+
+func setHostname(t *testing.T, name string, err error) {
+	old := hostname
+	hostname = func() (string, error) { return name, err }
+	t.Cleanup(func() { hostname = old })
+}
+
+func TestRender(t *testing.T) {
+	setHostname(t, "myhost", nil)
+	defaultUA := "Collector/1.2.3 (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+
+	tests := []struct {
+		name     string
+		tmpl     string
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: defaultUA,
+		},
+		{
+			name:     "static",
+			tmpl:     "my-agent",
+			expected: "my-agent",
+		},
+		{
+			name:     "fields",
+			tmpl:     "{{.Command}}/{{.Version}} ({{.Hostname}})",
+			expected: "otelcol/1.2.3 (myhost)",
+		},
+		{
+			name:     "augmented",
+			tmpl:     "{{.Default}} team-a",
+			expected: defaultUA + " team-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, Validate(tt.tmpl))
+			ua, err := Render(tt.tmpl, buildInfo)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ua)
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	assert.Error(t, Validate("{{.Version"))
+	// The templates are executed to be validated.
+	assert.ErrorContains(t, Validate("{{.Unknown}}"), "can't evaluate field Unknown")
+	assert.ErrorContains(t, Validate("{{.Version.Major}}"), "can't evaluate field Major")
+	assert.NoError(t, Validate("{{.CustomFields.vendor}}"))
+
+	_, err := Render("{{.Unknown}}", buildInfo)
+	assert.Error(t, err)
+
+	setHostname(t, "", errors.New("no hostname"))
+	_, err = Render("{{.Hostname}}", buildInfo)
+	assert.EqualError(t, err, "failed to get hostname: no hostname")
+}
+
+func TestRenderCustomFields(t *testing.T) {
+	setHostname(t, "myhost", nil)
+	info := component.NewBuildInfo(buildInfo.Command, buildInfo.Description,
+		component.WithBuildVersion(buildInfo.Version),
+		component.WithBuildCustomField("vendor", "acme"),
+		component.WithBuildCustomField("channel", "stable"))
+
+	ua, err := Render("", info)
+	require.NoError(t, err)
+	assert.Equal(t, "Collector/1.2.3 ("+runtime.GOOS+"/"+runtime.GOARCH+"; channel=stable; vendor=acme)", ua)
+
+	ua, err = Render("{{.Command}}/{{.Version}} {{.CustomFields.vendor}}", info)
+	require.NoError(t, err)
+	assert.Equal(t, "otelcol/1.2.3 acme", ua)
+
+	_, err = Render("{{.CustomFields.unknown}}", info)
+	assert.Error(t, err)
+
+	// The custom fields cannot break the comment of the User-Agent or the header.
+	info = component.NewBuildInfo(buildInfo.Command, buildInfo.Description,
+		component.WithBuildVersion(buildInfo.Version),
+		component.WithBuildCustomField("vendor", "acme); evil=(1\r\n"))
+	ua, err = Render("", info)
+	require.NoError(t, err)
+	assert.Equal(t, "Collector/1.2.3 ("+runtime.GOOS+"/"+runtime.GOARCH+"; vendor=acme__ evil=_1__)", ua)
+	ua, err = Render("{{.CustomFields.vendor}}", info)
+	require.NoError(t, err)
+	assert.Equal(t, "acme); evil=(1__", ua)
+}
+
+func TestRenderHeaders(t *testing.T) {
+	setHostname(t, "myhost", nil)
+
+	headers := map[string]string{"X-Scope": "tenant-a", "Authorization": "Bearer {{secret}}"}
+	templated := map[string]string{"X-Agent": "{{.Hostname}}-{{.Version}}"}
+	require.NoError(t, ValidateHeaders(headers, templated))
+	rendered, err := RenderHeaders(headers, templated, buildInfo)
+	require.NoError(t, err)
+	// The static headers are not templates, even if they contain template actions.
+	assert.Equal(t, map[string]string{"X-Scope": "tenant-a", "Authorization": "Bearer {{secret}}", "X-Agent": "myhost-1.2.3"}, rendered)
+	assert.Len(t, headers, 2)
+
+	rendered, err = RenderHeaders(headers, nil, buildInfo)
+	require.NoError(t, err)
+	assert.Equal(t, headers, rendered)
+
+	assert.EqualError(t, ValidateHeaders(headers, map[string]string{"X-Agent": "{{.Hostname"}),
+		`invalid templated header "X-Agent": template: :1: unclosed action`)
+	assert.ErrorContains(t, ValidateHeaders(headers, map[string]string{"X-Agent": "{{.Unknown}}"}),
+		`invalid templated header "X-Agent"`)
+	assert.EqualError(t, ValidateHeaders(headers, map[string]string{"x-scope": "{{.Hostname}}"}),
+		`header "x-scope" is set in both headers and templated_headers`)
+	_, err = RenderHeaders(headers, map[string]string{"X-Agent": "{{.CustomFields.unknown}}"}, buildInfo)
+	assert.ErrorContains(t, err, `failed to render templated header "X-Agent"`)
+}
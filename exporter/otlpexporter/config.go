@@ -12,17 +12,29 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/useragent"
 )
 
 // Config defines configuration for OTLP exporter.
 type Config struct {
-	exporterhelper.TimeoutSettings `mapstructure:",squash"`     // squash ensures fields are correctly decoded in embedded struct.
-	QueueConfig                    exporterhelper.QueueSettings `mapstructure:"sending_queue"`
-	RetryConfig                    configretry.BackOffConfig    `mapstructure:"retry_on_failure"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"`                 // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig                    exporterhelper.QueueSettings             `mapstructure:"sending_queue"`
+	RetryConfig                    configretry.BackOffConfig                `mapstructure:"retry_on_failure"`
+	DuplicateTracking              exporterhelper.DuplicateTrackingSettings `mapstructure:"duplicate_tracking"`
+	WarmUp                         exporterhelper.WarmUpSettings            `mapstructure:"warm_up"`
 
 	configgrpc.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// UserAgent is the template of the User-Agent sent with every request, e.g.
+	// "{{.Default}} {{.Hostname}}". If omitted, the description and version of the collector are used.
+	UserAgent string `mapstructure:"user_agent"`
+
+	// TemplatedHeaders are the headers whose values are templates rendered with the same fields as the
+	// UserAgent, e.g. "{{.Version}}". The values of the headers setting are sent as is.
+	TemplatedHeaders map[string]string `mapstructure:"templated_headers"`
 }
 
 func (c *Config) Validate() error {
@@ -31,7 +43,24 @@ func (c *Config) Validate() error {
 		return errors.New(`requires a non-empty "endpoint"`)
 	}
 
-	// Validate that the port is in the address
+	if name, ok := confignet.SRVName(endpoint); ok {
+		// The ports are discovered from the SRV records.
+		if name == "" {
+			return errors.New(`requires the name of the SRV records in the "endpoint"`)
+		}
+	} else if err := validatePort(endpoint); err != nil {
+		return err
+	}
+
+	if err := useragent.Validate(c.UserAgent); err != nil {
+		return fmt.Errorf("invalid user_agent: %w", err)
+	}
+
+	return useragent.ValidateHeaders(c.Headers, c.TemplatedHeaders)
+}
+
+// validatePort validates that the port is in the address.
+func validatePort(endpoint string) error {
 	_, port, err := net.SplitHostPort(endpoint)
 	if err != nil {
 		return err
@@ -39,7 +68,6 @@ func (c *Config) Validate() error {
 	if _, err := strconv.Atoi(port); err != nil {
 		return fmt.Errorf(`invalid port "%s"`, port)
 	}
-
 	return nil
 }
 
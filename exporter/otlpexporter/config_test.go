@@ -47,12 +47,23 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				MaxThrottleInterval: 2 * time.Minute,
 			},
 			QueueConfig: exporterhelper.QueueSettings{
 				Enabled:      true,
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			DuplicateTracking: exporterhelper.DuplicateTrackingSettings{
+				Enabled:       true,
+				SamplingRatio: 0.5,
+				Capacity:      100,
+			},
+			WarmUp: exporterhelper.WarmUpSettings{
+				Enabled: true,
+				Timeout: 5 * time.Second,
+			},
+			UserAgent: "{{.Default}} {{.Hostname}}",
 			ClientConfig: configgrpc.ClientConfig{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -119,6 +130,18 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_port",
 			errorMsg: `invalid port "port"`,
 		},
+		{
+			name:     "missing_srv_name",
+			errorMsg: `requires the name of the SRV records in the "endpoint"`,
+		},
+		{
+			name:     "invalid_user_agent",
+			errorMsg: `invalid user_agent: template: :1: unclosed action`,
+		},
+		{
+			name:     "invalid_header_template",
+			errorMsg: `invalid templated header "x-collector": template: :1: unclosed action`,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
@@ -130,3 +153,12 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 	}
 
 }
+
+func TestValidateSRVEndpoint(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "dns+srv:///_otlp._tcp.example.com"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.Endpoint = "dns+srv://_otlp._tcp.example.com"
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
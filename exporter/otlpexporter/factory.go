@@ -30,9 +30,11 @@ func NewFactory() exporter.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		TimeoutSettings: exporterhelper.NewDefaultTimeoutSettings(),
-		RetryConfig:     configretry.NewDefaultBackOffConfig(),
-		QueueConfig:     exporterhelper.NewDefaultQueueSettings(),
+		TimeoutSettings:   exporterhelper.NewDefaultTimeoutSettings(),
+		RetryConfig:       configretry.NewDefaultBackOffConfig(),
+		QueueConfig:       exporterhelper.NewDefaultQueueSettings(),
+		DuplicateTracking: exporterhelper.NewDefaultDuplicateTrackingSettings(),
+		WarmUp:            exporterhelper.NewDefaultWarmUpSettings(),
 		ClientConfig: configgrpc.ClientConfig{
 			Headers: map[string]configopaque.String{},
 			// Default to gzip compression
@@ -55,6 +57,8 @@ func createTracesExporter(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown))
@@ -72,6 +76,8 @@ func createMetricsExporter(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
@@ -90,6 +96,8 @@ func createLogsExporter(
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
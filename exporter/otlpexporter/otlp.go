@@ -5,8 +5,6 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
-	"fmt"
-	"runtime"
 	"time"
 
 	"go.uber.org/zap"
@@ -20,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/useragent"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -40,32 +39,35 @@ type baseExporter struct {
 	metadata       metadata.MD
 	callOptions    []grpc.CallOption
 
-	settings component.TelemetrySettings
-
-	// Default user-agent header.
-	userAgent string
+	settings  component.TelemetrySettings
+	buildInfo component.BuildInfo
 }
 
 func newExporter(cfg component.Config, set exporter.CreateSettings) *baseExporter {
 	oCfg := cfg.(*Config)
 
-	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
-		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
-
-	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}
+	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, buildInfo: set.BuildInfo}
 }
 
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) (err error) {
-	if e.clientConn, err = e.config.ClientConfig.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
+	userAgent, err := useragent.Render(e.config.UserAgent, e.buildInfo)
+	if err != nil {
+		return err
+	}
+	configHeaders, err := useragent.RenderHeaders(e.config.ClientConfig.Headers, e.config.TemplatedHeaders, e.buildInfo)
+	if err != nil {
+		return err
+	}
+	if e.clientConn, err = e.config.ClientConfig.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(userAgent)); err != nil {
 		return err
 	}
 	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
 	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
 	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
 	headers := map[string]string{}
-	for k, v := range e.config.ClientConfig.Headers {
+	for k, v := range configHeaders {
 		headers[k] = string(v)
 	}
 	e.metadata = metadata.New(headers)
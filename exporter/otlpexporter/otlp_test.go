@@ -8,6 +8,7 @@ import (
 	"net"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -395,6 +396,49 @@ func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	}
 }
 
+func TestSendTracesUserAgentTemplate(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.UserAgent = "{{.Command}}/{{.Version}} custom"
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		Headers: map[string]configopaque.String{
+			"authorization": "Bearer {{token}}",
+		},
+	}
+	cfg.TemplatedHeaders = map[string]string{"collector-version": "{{.Version}}"}
+	set := exportertest.NewNopCreateSettings()
+	set.BuildInfo.Command = "collector"
+	set.BuildInfo.Version = "1.2.3test"
+	exp, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 0
+	}, 10*time.Second, 5*time.Millisecond)
+
+	md := rcv.getMetadata()
+	require.EqualValues(t, []string{"1.2.3test"}, md.Get("collector-version"))
+	// The static headers are not templates.
+	require.EqualValues(t, []string{"Bearer {{token}}"}, md.Get("authorization"))
+	require.Len(t, md.Get("User-Agent"), 1)
+	require.True(t, strings.HasPrefix(md.Get("User-Agent")[0], "collector/1.2.3test custom"))
+}
+
 func TestSendMetrics(t *testing.T) {
 	// Start an OTLP-compatible receiver.
 	ln, err := net.Listen("tcp", "localhost:")
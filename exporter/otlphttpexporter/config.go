@@ -7,11 +7,15 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/useragent"
 )
 
 // EncodingType defines the type for content encoding
@@ -45,9 +49,18 @@ func (e *EncodingType) UnmarshalText(text []byte) error {
 
 // Config defines configuration for OTLP/HTTP exporter.
 type Config struct {
-	confighttp.ClientConfig `mapstructure:",squash"`     // squash ensures fields are correctly decoded in embedded struct.
-	QueueConfig             exporterhelper.QueueSettings `mapstructure:"sending_queue"`
-	RetryConfig             configretry.BackOffConfig    `mapstructure:"retry_on_failure"`
+	confighttp.ClientConfig `mapstructure:",squash"`                 // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig             exporterhelper.QueueSettings             `mapstructure:"sending_queue"`
+	RetryConfig             configretry.BackOffConfig                `mapstructure:"retry_on_failure"`
+	DuplicateTracking       exporterhelper.DuplicateTrackingSettings `mapstructure:"duplicate_tracking"`
+	WarmUp                  exporterhelper.WarmUpSettings            `mapstructure:"warm_up"`
+
+	// RetryBudget limits the retries of the traces, metrics and logs together, so that a failing
+	// backend does not receive the retries of the three signals at full rate.
+	RetryBudget exporterhelper.RetryBudgetSettings `mapstructure:"retry_budget"`
+
+	// ThrottleStats keeps the rolling-window statistics of the responses of the endpoints throttling the exports.
+	ThrottleStats exporterhelper.ThrottleStatsSettings `mapstructure:"throttle_stats"`
 
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
@@ -58,16 +71,134 @@ type Config struct {
 	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
 
+	// Traces, Metrics and Logs enable the export of each signal. The disabled signals have no URL,
+	// and the exporter cannot be used in the pipelines of their data type.
+	Traces  SignalConfig `mapstructure:"traces"`
+	Metrics SignalConfig `mapstructure:"metrics"`
+	Logs    SignalConfig `mapstructure:"logs"`
+
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// UserAgent is the template of the User-Agent header sent with every request, e.g.
+	// "{{.Default}} {{.Hostname}}". If omitted, the description and version of the collector are used.
+	// A User-Agent set in the headers takes precedence.
+	UserAgent string `mapstructure:"user_agent"`
+
+	// TemplatedHeaders are the headers whose values are templates rendered with the same fields as the
+	// UserAgent, e.g. "{{.Version}}". The values of the headers setting are sent as is.
+	TemplatedHeaders map[string]string `mapstructure:"templated_headers"`
+
+	// DeduplicateResources merges the identical resources, and the identical scopes within them,
+	// of every request, so that they are sent once. It reduces the size of the requests when
+	// the data received from the same sources is batched together.
+	DeduplicateResources bool `mapstructure:"deduplicate_resources"`
+
+	// ValidateResponses checks that the non-empty bodies of the successful responses are ExportResponse
+	// messages with the Content-Type of the requests, e.g. to detect the HTML error pages returned with
+	// a 200 status by some proxies. The invalid responses are retried.
+	ValidateResponses bool `mapstructure:"validate_responses"`
+
+	// DebugPayloads logs the text of the exported data at the debug level, to inspect the data of this
+	// exporter. The payloads are only logged when the debug level of the logger is enabled.
+	DebugPayloads bool `mapstructure:"debug_payloads"`
+
+	// WebSocket sends the requests over WebSocket connections instead of HTTP requests.
+	WebSocket WebSocketConfig `mapstructure:"websocket"`
+}
+
+// WebSocketConfig defines the configuration of the export over WebSocket connections, for the networks
+// only allowing WebSocket through their proxies. A connection is dialed per signal URL, with the http and
+// https schemes replaced by ws and wss, and the requests are sent as OTLP/protobuf binary messages.
+// The broken connections are dialed again by the next requests, the failed requests being retried.
+type WebSocketConfig struct {
+	// Enabled enables the export over WebSocket (default: false).
+	Enabled bool `mapstructure:"enabled"`
+
+	// PingInterval is the interval of the pings sent to keep the connections alive (default: 30s).
+	PingInterval time.Duration `mapstructure:"ping_interval"`
+
+	// PongTimeout is the time to wait for the pong after a ping, the connection is closed once elapsed (default: 10s).
+	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+}
+
+// Validate checks if the WebSocket configuration is valid.
+func (cfg *WebSocketConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.PingInterval <= 0 {
+		return fmt.Errorf("invalid websocket ping_interval %v, must be positive", cfg.PingInterval)
+	}
+	if cfg.PongTimeout <= 0 {
+		return fmt.Errorf("invalid websocket pong_timeout %v, must be positive", cfg.PongTimeout)
+	}
+	return nil
+}
+
+// SignalConfig defines the configuration of the export of a signal.
+type SignalConfig struct {
+	// Enabled enables the export of the signal (default: true).
+	Enabled *bool `mapstructure:"enabled"`
+}
+
+func (cfg SignalConfig) enabled() bool {
+	return cfg.Enabled == nil || *cfg.Enabled
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if !cfg.Traces.enabled() && !cfg.Metrics.enabled() && !cfg.Logs.enabled() {
+		return errors.New("at least one signal must be enabled")
+	}
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
-	return nil
+	for _, s := range cfg.signals() {
+		if !s.enabled && s.endpoint != "" {
+			return fmt.Errorf("%s_endpoint is specified but %s are disabled", s.dataType, s.dataType)
+		}
+	}
+	if cfg.WebSocket.Enabled {
+		if cfg.Encoding != EncodingProto {
+			return fmt.Errorf("encoding %q is not supported by websocket, only %q is", cfg.Encoding, EncodingProto)
+		}
+		if cfg.Auth != nil {
+			return errors.New("auth is not supported by websocket, use headers instead")
+		}
+		if cfg.Compression.IsCompressed() {
+			switch cfg.Compression {
+			case configcompression.TypeGzip, configcompression.TypeZlib, configcompression.TypeDeflate:
+				// The messages are compressed with the per-message deflate extension of WebSocket.
+			default:
+				return fmt.Errorf("compression %q is not supported by websocket, only the deflate based %q, %q and %q are",
+					cfg.Compression, configcompression.TypeGzip, configcompression.TypeZlib, configcompression.TypeDeflate)
+			}
+		}
+		for _, endpoint := range []string{cfg.Endpoint, cfg.TracesEndpoint, cfg.MetricsEndpoint, cfg.LogsEndpoint} {
+			if _, ok := confignet.SRVName(endpoint); ok {
+				return fmt.Errorf("the %s scheme is not supported by websocket", confignet.SRVScheme)
+			}
+		}
+	}
+	if err := useragent.Validate(cfg.UserAgent); err != nil {
+		return fmt.Errorf("invalid user_agent: %w", err)
+	}
+	return useragent.ValidateHeaders(cfg.Headers, cfg.TemplatedHeaders)
+}
+
+type signal struct {
+	dataType component.DataType
+	enabled  bool
+	endpoint string
+}
+
+func (cfg *Config) signals() []signal {
+	return []signal{
+		{dataType: component.DataTypeTraces, enabled: cfg.Traces.enabled(), endpoint: cfg.TracesEndpoint},
+		{dataType: component.DataTypeMetrics, enabled: cfg.Metrics.enabled(), endpoint: cfg.MetricsEndpoint},
+		{dataType: component.DataTypeLogs, enabled: cfg.Logs.enabled(), endpoint: cfg.LogsEndpoint},
+	}
 }
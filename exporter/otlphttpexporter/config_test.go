@@ -36,6 +36,7 @@ func TestUnmarshalConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	disabled := false
 	assert.Equal(t,
 		&Config{
 			RetryConfig: configretry.BackOffConfig{
@@ -45,13 +46,41 @@ func TestUnmarshalConfig(t *testing.T) {
 				Multiplier:          1.3,
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
+				MaxThrottleInterval: 2 * time.Minute,
 			},
 			QueueConfig: exporterhelper.QueueSettings{
 				Enabled:      true,
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
-			Encoding: EncodingProto,
+			DuplicateTracking: exporterhelper.DuplicateTrackingSettings{
+				Enabled:       true,
+				SamplingRatio: 0.5,
+				Capacity:      100,
+			},
+			WarmUp: exporterhelper.WarmUpSettings{
+				Enabled: true,
+				Timeout: 5 * time.Second,
+			},
+			RetryBudget: exporterhelper.RetryBudgetSettings{
+				Enabled:          true,
+				MaxRetries:       50,
+				RetriesPerSecond: 5,
+			},
+			ThrottleStats: exporterhelper.ThrottleStatsSettings{
+				Enabled: true,
+				Window:  5 * time.Minute,
+			},
+			Logs:                 SignalConfig{Enabled: &disabled},
+			Encoding:             EncodingProto,
+			UserAgent:            "{{.Default}} {{.Hostname}}",
+			DeduplicateResources: true,
+			ValidateResponses:    true,
+			DebugPayloads:        true,
+			WebSocket: WebSocketConfig{
+				PingInterval: 30 * time.Second,
+				PongTimeout:  10 * time.Second,
+			},
 			ClientConfig: confighttp.ClientConfig{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -128,3 +157,40 @@ func TestUnmarshalEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUserAgent(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://localhost:4318"
+	cfg.UserAgent = "{{.Default}} {{.Hostname}}"
+	cfg.TemplatedHeaders = map[string]string{"x-collector": "{{.Version}}"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.UserAgent = "{{.Version"
+	assert.EqualError(t, component.ValidateConfig(cfg), "invalid user_agent: template: :1: unclosed action")
+
+	cfg.UserAgent = ""
+	cfg.TemplatedHeaders = map[string]string{"x-collector": "{{.Version"}
+	assert.EqualError(t, component.ValidateConfig(cfg), `invalid templated header "x-collector": template: :1: unclosed action`)
+
+	cfg.TemplatedHeaders = map[string]string{"x-collector": "{{.Version}}"}
+	cfg.Headers = map[string]configopaque.String{"X-Collector": "static"}
+	assert.EqualError(t, component.ValidateConfig(cfg), `header "x-collector" is set in both headers and templated_headers`)
+}
+
+func TestValidateSignals(t *testing.T) {
+	// The signals are enabled unless explicitly disabled.
+	assert.True(t, (&Config{}).Traces.enabled())
+
+	disabled := false
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesEndpoint = "http://localhost:4318/v1/traces"
+	cfg.Metrics.Enabled = &disabled
+	cfg.Logs.Enabled = &disabled
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.MetricsEndpoint = "http://localhost:4318/v1/metrics"
+	assert.EqualError(t, component.ValidateConfig(cfg), "metrics_endpoint is specified but metrics are disabled")
+
+	cfg.Traces.Enabled = &disabled
+	assert.EqualError(t, component.ValidateConfig(cfg), "at least one signal must be enabled")
+}
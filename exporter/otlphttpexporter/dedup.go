@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// scopeBlockKey identifies a scope block within a resource block.
+type scopeBlockKey struct {
+	resource string
+	scope    string
+}
+
+// hasDuplicateBlocks returns whether some of the resource blocks are identical, or some of the
+// scope blocks of a resource block.
+func hasDuplicateBlocks(resources int, resourceKey func(i int) string, scopes func(i int) int, scopeKey func(i, j int) string) bool {
+	resourceKeys := make(map[string]bool, resources)
+	for i := 0; i < resources; i++ {
+		rk := resourceKey(i)
+		if resourceKeys[rk] {
+			return true
+		}
+		resourceKeys[rk] = true
+		scopeKeys := make(map[string]bool, scopes(i))
+		for j := 0; j < scopes(i); j++ {
+			sk := scopeKey(i, j)
+			if scopeKeys[sk] {
+				return true
+			}
+			scopeKeys[sk] = true
+		}
+	}
+	return false
+}
+
+// dedupTraces returns the traces with the identical resource blocks, and the identical scope
+// blocks within them, merged. The traces are returned as is if there is nothing to merge,
+// otherwise they are copied and left unmodified.
+func dedupTraces(td ptrace.Traces) ptrace.Traces {
+	rss := td.ResourceSpans()
+	if !hasDuplicateBlocks(rss.Len(),
+		func(i int) string { return resourceKey(rss.At(i).Resource(), rss.At(i).SchemaUrl()) },
+		func(i int) int { return rss.At(i).ScopeSpans().Len() },
+		func(i, j int) string {
+			ss := rss.At(i).ScopeSpans().At(j)
+			return scopeKey(ss.Scope(), ss.SchemaUrl())
+		}) {
+		return td
+	}
+
+	dest := ptrace.NewTraces()
+	resources := map[string]ptrace.ResourceSpans{}
+	scopes := map[scopeBlockKey]ptrace.ScopeSpans{}
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		rk := resourceKey(rs.Resource(), rs.SchemaUrl())
+		destRS, ok := resources[rk]
+		if !ok {
+			destRS = dest.ResourceSpans().AppendEmpty()
+			rs.Resource().CopyTo(destRS.Resource())
+			destRS.SetSchemaUrl(rs.SchemaUrl())
+			resources[rk] = destRS
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			sk := scopeBlockKey{resource: rk, scope: scopeKey(ss.Scope(), ss.SchemaUrl())}
+			destSS, ok := scopes[sk]
+			if !ok {
+				destSS = destRS.ScopeSpans().AppendEmpty()
+				ss.Scope().CopyTo(destSS.Scope())
+				destSS.SetSchemaUrl(ss.SchemaUrl())
+				scopes[sk] = destSS
+			}
+			destSS.Spans().EnsureCapacity(destSS.Spans().Len() + ss.Spans().Len())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				ss.Spans().At(k).CopyTo(destSS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return dest
+}
+
+// dedupMetrics is the equivalent of dedupTraces for metrics.
+func dedupMetrics(md pmetric.Metrics) pmetric.Metrics {
+	rms := md.ResourceMetrics()
+	if !hasDuplicateBlocks(rms.Len(),
+		func(i int) string { return resourceKey(rms.At(i).Resource(), rms.At(i).SchemaUrl()) },
+		func(i int) int { return rms.At(i).ScopeMetrics().Len() },
+		func(i, j int) string {
+			sm := rms.At(i).ScopeMetrics().At(j)
+			return scopeKey(sm.Scope(), sm.SchemaUrl())
+		}) {
+		return md
+	}
+
+	dest := pmetric.NewMetrics()
+	resources := map[string]pmetric.ResourceMetrics{}
+	scopes := map[scopeBlockKey]pmetric.ScopeMetrics{}
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		rk := resourceKey(rm.Resource(), rm.SchemaUrl())
+		destRM, ok := resources[rk]
+		if !ok {
+			destRM = dest.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(destRM.Resource())
+			destRM.SetSchemaUrl(rm.SchemaUrl())
+			resources[rk] = destRM
+		}
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			sk := scopeBlockKey{resource: rk, scope: scopeKey(sm.Scope(), sm.SchemaUrl())}
+			destSM, ok := scopes[sk]
+			if !ok {
+				destSM = destRM.ScopeMetrics().AppendEmpty()
+				sm.Scope().CopyTo(destSM.Scope())
+				destSM.SetSchemaUrl(sm.SchemaUrl())
+				scopes[sk] = destSM
+			}
+			destSM.Metrics().EnsureCapacity(destSM.Metrics().Len() + sm.Metrics().Len())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				sm.Metrics().At(k).CopyTo(destSM.Metrics().AppendEmpty())
+			}
+		}
+	}
+	return dest
+}
+
+// dedupLogs is the equivalent of dedupTraces for logs.
+func dedupLogs(ld plog.Logs) plog.Logs {
+	rls := ld.ResourceLogs()
+	if !hasDuplicateBlocks(rls.Len(),
+		func(i int) string { return resourceKey(rls.At(i).Resource(), rls.At(i).SchemaUrl()) },
+		func(i int) int { return rls.At(i).ScopeLogs().Len() },
+		func(i, j int) string {
+			sl := rls.At(i).ScopeLogs().At(j)
+			return scopeKey(sl.Scope(), sl.SchemaUrl())
+		}) {
+		return ld
+	}
+
+	dest := plog.NewLogs()
+	resources := map[string]plog.ResourceLogs{}
+	scopes := map[scopeBlockKey]plog.ScopeLogs{}
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		rk := resourceKey(rl.Resource(), rl.SchemaUrl())
+		destRL, ok := resources[rk]
+		if !ok {
+			destRL = dest.ResourceLogs().AppendEmpty()
+			rl.Resource().CopyTo(destRL.Resource())
+			destRL.SetSchemaUrl(rl.SchemaUrl())
+			resources[rk] = destRL
+		}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			sk := scopeBlockKey{resource: rk, scope: scopeKey(sl.Scope(), sl.SchemaUrl())}
+			destSL, ok := scopes[sk]
+			if !ok {
+				destSL = destRL.ScopeLogs().AppendEmpty()
+				sl.Scope().CopyTo(destSL.Scope())
+				destSL.SetSchemaUrl(sl.SchemaUrl())
+				scopes[sk] = destSL
+			}
+			destSL.LogRecords().EnsureCapacity(destSL.LogRecords().Len() + sl.LogRecords().Len())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				sl.LogRecords().At(k).CopyTo(destSL.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return dest
+}
+
+// resourceKey returns a string identifying the resource and the schema URL of a resource block.
+func resourceKey(r pcommon.Resource, schemaURL string) string {
+	var b strings.Builder
+	b.WriteString(strconv.Quote(schemaURL))
+	b.WriteString(strconv.FormatUint(uint64(r.DroppedAttributesCount()), 10))
+	writeMap(&b, r.Attributes())
+	return b.String()
+}
+
+// scopeKey returns a string identifying the scope and the schema URL of a scope block.
+func scopeKey(s pcommon.InstrumentationScope, schemaURL string) string {
+	var b strings.Builder
+	b.WriteString(strconv.Quote(schemaURL))
+	b.WriteString(strconv.Quote(s.Name()))
+	b.WriteString(strconv.Quote(s.Version()))
+	b.WriteString(strconv.FormatUint(uint64(s.DroppedAttributesCount()), 10))
+	writeMap(&b, s.Attributes())
+	return b.String()
+}
+
+// writeMap writes the attributes sorted by key, so that the same attributes in a different
+// order are identical.
+func writeMap(b *strings.Builder, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	b.WriteByte('{')
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		b.WriteString(strconv.Quote(k))
+		writeValue(b, v)
+	}
+	b.WriteByte('}')
+}
+
+// writeValue writes the type along with the value, so that e.g. the int 1 and the double 1
+// are different.
+func writeValue(b *strings.Builder, v pcommon.Value) {
+	b.WriteString(strconv.Itoa(int(v.Type())))
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		b.WriteString(strconv.Quote(v.Str()))
+	case pcommon.ValueTypeInt:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case pcommon.ValueTypeDouble:
+		b.WriteString(strconv.FormatFloat(v.Double(), 'g', -1, 64))
+	case pcommon.ValueTypeBool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case pcommon.ValueTypeBytes:
+		b.WriteString(strconv.Quote(string(v.Bytes().AsRaw())))
+	case pcommon.ValueTypeMap:
+		writeMap(b, v.Map())
+	case pcommon.ValueTypeSlice:
+		b.WriteByte('[')
+		for i := 0; i < v.Slice().Len(); i++ {
+			writeValue(b, v.Slice().At(i))
+			b.WriteByte(',')
+		}
+		b.WriteByte(']')
+	}
+	b.WriteByte(';')
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestResourceKey(t *testing.T) {
+	newResource := func(fill func(m pcommon.Map)) pcommon.Resource {
+		r := pcommon.NewResource()
+		fill(r.Attributes())
+		return r
+	}
+
+	a := newResource(func(m pcommon.Map) {
+		m.PutStr("a", "1")
+		m.PutInt("b", 2)
+	})
+	reordered := newResource(func(m pcommon.Map) {
+		m.PutInt("b", 2)
+		m.PutStr("a", "1")
+	})
+	assert.Equal(t, resourceKey(a, ""), resourceKey(reordered, ""))
+	assert.NotEqual(t, resourceKey(a, ""), resourceKey(a, "https://opentelemetry.io/schemas/1.21.0"))
+
+	double := newResource(func(m pcommon.Map) {
+		m.PutStr("a", "1")
+		m.PutDouble("b", 2)
+	})
+	assert.NotEqual(t, resourceKey(a, ""), resourceKey(double, ""))
+
+	// The keys and values are quoted so that they cannot be confused.
+	ambiguous := newResource(func(m pcommon.Map) {
+		m.PutStr("a", `1"3"b`)
+	})
+	assert.NotEqual(t, resourceKey(a, ""), resourceKey(ambiguous, ""))
+
+	nested := newResource(func(m pcommon.Map) {
+		m.PutEmptyMap("m").PutStr("k", "v")
+		s := m.PutEmptySlice("s")
+		s.AppendEmpty().SetBool(true)
+		s.AppendEmpty().SetEmptyBytes().FromRaw([]byte{1, 2})
+	})
+	other := newResource(func(m pcommon.Map) {
+		m.PutEmptyMap("m").PutStr("k", "v")
+		s := m.PutEmptySlice("s")
+		s.AppendEmpty().SetBool(true)
+		s.AppendEmpty().SetEmptyBytes().FromRaw([]byte{1, 3})
+	})
+	assert.Equal(t, resourceKey(nested, ""), resourceKey(nested, ""))
+	assert.NotEqual(t, resourceKey(nested, ""), resourceKey(other, ""))
+
+	dropped := newResource(func(m pcommon.Map) {
+		m.PutStr("a", "1")
+		m.PutInt("b", 2)
+	})
+	dropped.SetDroppedAttributesCount(1)
+	assert.NotEqual(t, resourceKey(a, ""), resourceKey(dropped, ""))
+}
+
+func TestScopeKey(t *testing.T) {
+	s := pcommon.NewInstrumentationScope()
+	s.SetName("scope")
+	s.SetVersion("1.0")
+	other := pcommon.NewInstrumentationScope()
+	s.CopyTo(other)
+	assert.Equal(t, scopeKey(s, ""), scopeKey(other, ""))
+
+	other.SetVersion("2.0")
+	assert.NotEqual(t, scopeKey(s, ""), scopeKey(other, ""))
+
+	s.CopyTo(other)
+	other.Attributes().PutStr("a", "b")
+	assert.NotEqual(t, scopeKey(s, ""), scopeKey(other, ""))
+}
+
+func TestDedupTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, r := range []struct{ service, scope, span string }{
+		{"a", "s1", "1"},
+		{"b", "s1", "2"},
+		{"a", "s2", "3"},
+		{"a", "s1", "4"},
+	} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", r.service)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(r.scope)
+		ss.Spans().AppendEmpty().SetName(r.span)
+	}
+	orig := ptrace.NewTraces()
+	td.CopyTo(orig)
+
+	got := dedupTraces(td)
+	assert.Equal(t, orig, td)
+	require.Equal(t, 2, got.ResourceSpans().Len())
+	assert.Equal(t, 4, got.SpanCount())
+
+	a := got.ResourceSpans().At(0)
+	assert.Equal(t, map[string]any{"service.name": "a"}, a.Resource().Attributes().AsRaw())
+	require.Equal(t, 2, a.ScopeSpans().Len())
+	assert.Equal(t, "s1", a.ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, []string{"1", "4"}, spanNames(a.ScopeSpans().At(0).Spans()))
+	assert.Equal(t, "s2", a.ScopeSpans().At(1).Scope().Name())
+	assert.Equal(t, []string{"3"}, spanNames(a.ScopeSpans().At(1).Spans()))
+
+	b := got.ResourceSpans().At(1)
+	assert.Equal(t, map[string]any{"service.name": "b"}, b.Resource().Attributes().AsRaw())
+	assert.Equal(t, []string{"2"}, spanNames(b.ScopeSpans().At(0).Spans()))
+
+	// Nothing to merge.
+	assert.Equal(t, got, dedupTraces(got))
+}
+
+func TestDedupTracesDuplicateScopes(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("1")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("2")
+
+	got := dedupTraces(td)
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	require.Equal(t, 1, got.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, []string{"1", "2"}, spanNames(got.ResourceSpans().At(0).ScopeSpans().At(0).Spans()))
+}
+
+func TestDedupMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, name := range []string{"m1", "m2"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "a")
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(name)
+	}
+	orig := pmetric.NewMetrics()
+	md.CopyTo(orig)
+
+	got := dedupMetrics(md)
+	assert.Equal(t, orig, md)
+	require.Equal(t, 1, got.ResourceMetrics().Len())
+	require.Equal(t, 1, got.ResourceMetrics().At(0).ScopeMetrics().Len())
+	metrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "m1", metrics.At(0).Name())
+	assert.Equal(t, "m2", metrics.At(1).Name())
+
+	assert.Equal(t, got, dedupMetrics(got))
+}
+
+func TestDedupLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, body := range []string{"l1", "l2"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", "a")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	}
+	orig := plog.NewLogs()
+	ld.CopyTo(orig)
+
+	got := dedupLogs(ld)
+	assert.Equal(t, orig, ld)
+	require.Equal(t, 1, got.ResourceLogs().Len())
+	require.Equal(t, 1, got.ResourceLogs().At(0).ScopeLogs().Len())
+	records := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, "l1", records.At(0).Body().Str())
+	assert.Equal(t, "l2", records.At(1).Body().Str())
+
+	assert.Equal(t, got, dedupLogs(got))
+}
+
+func spanNames(spans ptrace.SpanSlice) []string {
+	var names []string
+	for i := 0; i < spans.Len(); i++ {
+		names = append(names, spans.At(i).Name())
+	}
+	return names
+}
@@ -34,9 +34,17 @@ func NewFactory() exporter.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		RetryConfig: configretry.NewDefaultBackOffConfig(),
-		QueueConfig: exporterhelper.NewDefaultQueueSettings(),
-		Encoding:    EncodingProto,
+		RetryConfig:       configretry.NewDefaultBackOffConfig(),
+		QueueConfig:       exporterhelper.NewDefaultQueueSettings(),
+		DuplicateTracking: exporterhelper.NewDefaultDuplicateTrackingSettings(),
+		WarmUp:            exporterhelper.NewDefaultWarmUpSettings(),
+		RetryBudget:       exporterhelper.NewDefaultRetryBudgetSettings(),
+		ThrottleStats:     exporterhelper.NewDefaultThrottleStatsSettings(),
+		Encoding:          EncodingProto,
+		WebSocket: WebSocketConfig{
+			PingInterval: 30 * time.Second,
+			PongTimeout:  10 * time.Second,
+		},
 		ClientConfig: confighttp.ClientConfig{
 			Endpoint: "",
 			Timeout:  30 * time.Second,
@@ -77,20 +85,34 @@ func createTracesExporter(
 		return nil, err
 	}
 	oCfg := cfg.(*Config)
+	if !oCfg.Traces.enabled() {
+		return nil, fmt.Errorf("traces are disabled: %w", component.ErrDataTypeIsNotSupported)
+	}
 
 	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
 	if err != nil {
 		return nil, err
 	}
+	oce.retryBudget = retryBudgets.acquire(oCfg)
 
-	return exporterhelper.NewTracesExporter(ctx, set, cfg,
+	exp, err := exporterhelper.NewTracesExporter(ctx, set, cfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
-		// explicitly disable since we rely on http.Client timeout logic.
+		// explicitly disable since we rely on http.Client timeout logic, and on the timeout of the WebSocket requests.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithRetryBudget(oce.retryBudget),
+		exporterhelper.WithThrottleStats(oce.throttleStats, oce.tracesURL),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
+	if err != nil {
+		// The exporter is not shut down if it fails to be created.
+		oce.release()
+	}
+	return exp, err
 }
 
 func createMetricsExporter(
@@ -103,20 +125,34 @@ func createMetricsExporter(
 		return nil, err
 	}
 	oCfg := cfg.(*Config)
+	if !oCfg.Metrics.enabled() {
+		return nil, fmt.Errorf("metrics are disabled: %w", component.ErrDataTypeIsNotSupported)
+	}
 
 	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
 	if err != nil {
 		return nil, err
 	}
+	oce.retryBudget = retryBudgets.acquire(oCfg)
 
-	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
+	exp, err := exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
-		// explicitly disable since we rely on http.Client timeout logic.
+		// explicitly disable since we rely on http.Client timeout logic, and on the timeout of the WebSocket requests.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithRetryBudget(oce.retryBudget),
+		exporterhelper.WithThrottleStats(oce.throttleStats, oce.metricsURL),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
+	if err != nil {
+		// The exporter is not shut down if it fails to be created.
+		oce.release()
+	}
+	return exp, err
 }
 
 func createLogsExporter(
@@ -129,18 +165,32 @@ func createLogsExporter(
 		return nil, err
 	}
 	oCfg := cfg.(*Config)
+	if !oCfg.Logs.enabled() {
+		return nil, fmt.Errorf("logs are disabled: %w", component.ErrDataTypeIsNotSupported)
+	}
 
 	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
 	if err != nil {
 		return nil, err
 	}
+	oce.retryBudget = retryBudgets.acquire(oCfg)
 
-	return exporterhelper.NewLogsExporter(ctx, set, cfg,
+	exp, err := exporterhelper.NewLogsExporter(ctx, set, cfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
-		// explicitly disable since we rely on http.Client timeout logic.
+		// explicitly disable since we rely on http.Client timeout logic, and on the timeout of the WebSocket requests.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
+		exporterhelper.WithRetryBudget(oce.retryBudget),
+		exporterhelper.WithThrottleStats(oce.throttleStats, oce.logsURL),
+		exporterhelper.WithDuplicateTracking(oCfg.DuplicateTracking),
+		exporterhelper.WithWarmUp(oCfg.WarmUp),
 		exporterhelper.WithQueue(oCfg.QueueConfig))
+	if err != nil {
+		// The exporter is not shut down if it fails to be created.
+		oce.release()
+	}
+	return exp, err
 }
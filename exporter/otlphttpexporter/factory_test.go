@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -209,6 +210,24 @@ func TestCreateLogsExporter(t *testing.T) {
 	require.NotNil(t, oexp)
 }
 
+func TestCreateDisabledSignalExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "http://" + testutil.GetAvailableLocalAddress(t)
+	disabled := false
+	cfg.Traces.Enabled = &disabled
+	cfg.Metrics.Enabled = &disabled
+	cfg.Logs.Enabled = &disabled
+
+	set := exportertest.NewNopCreateSettings()
+	_, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	assert.ErrorIs(t, err, component.ErrDataTypeIsNotSupported)
+	_, err = factory.CreateMetricsExporter(context.Background(), set, cfg)
+	assert.ErrorIs(t, err, component.ErrDataTypeIsNotSupported)
+	_, err = factory.CreateLogsExporter(context.Background(), set, cfg)
+	assert.ErrorIs(t, err, component.ErrDataTypeIsNotSupported)
+}
+
 func TestComposeSignalURL(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// exporterTelemetry records the size of the bodies of the requests, before and after their compression,
+// to measure the network cost of the exports and tune the compression with data.
+type exporterTelemetry struct {
+	exporterAttrs metric.MeasurementOption
+
+	uncompressedBytes  metric.Int64Counter
+	compressedBytes    metric.Int64Counter
+	retransmittedBytes metric.Int64Counter
+}
+
+func newExporterTelemetry(set exporter.CreateSettings) (*exporterTelemetry, error) {
+	et := &exporterTelemetry{
+		exporterAttrs: metric.WithAttributes(attribute.String(obsmetrics.ExporterKey, set.ID.String())),
+	}
+
+	var meter metric.Meter
+	// The size metrics are emitted starting from Normal level only.
+	if set.MetricsLevel >= configtelemetry.LevelNormal {
+		meter = metadata.Meter(set.TelemetrySettings)
+	} else {
+		meter = noopmetric.Meter{}
+	}
+
+	var errors, err error
+	et.uncompressedBytes, err = meter.Int64Counter(
+		metricName("uncompressed_bytes"),
+		metric.WithDescription("Number of bytes of the bodies of the requests sent, before their compression"),
+		metric.WithUnit("By"),
+	)
+	errors = multierr.Append(errors, err)
+
+	et.compressedBytes, err = meter.Int64Counter(
+		metricName("compressed_bytes"),
+		metric.WithDescription("Number of bytes of the bodies of the requests sent, after their compression"),
+		metric.WithUnit("By"),
+	)
+	errors = multierr.Append(errors, err)
+
+	et.retransmittedBytes, err = meter.Int64Counter(
+		metricName("retransmitted_bytes"),
+		metric.WithDescription("Number of bytes of the bodies of the requests sent again by the retries, after their compression"),
+		metric.WithUnit("By"),
+	)
+	errors = multierr.Append(errors, err)
+
+	if errors != nil {
+		return nil, errors
+	}
+	return et, nil
+}
+
+func metricName(name string) string {
+	return obsmetrics.ExporterMetricPrefix + metadata.Type.String() + obsmetrics.MetricNameSep + name
+}
+
+// record records the body of size bytes of a request sent with the response resp. The size of the body
+// on the wire is the length of the request sent by the innermost transport, i.e. after the compression.
+// The requests sent by the retries, i.e. after the first attempt, are also recorded as retransmitted.
+func (et *exporterTelemetry) record(ctx context.Context, size int, resp *http.Response) {
+	if et == nil {
+		return
+	}
+	compressed := int64(size)
+	if resp.Request != nil && resp.Request.ContentLength >= 0 {
+		compressed = resp.Request.ContentLength
+	}
+	et.uncompressedBytes.Add(ctx, int64(size), et.exporterAttrs)
+	et.compressedBytes.Add(ctx, compressed, et.exporterAttrs)
+	if md, ok := exporterhelper.RequestMetadataFromContext(ctx); ok && md.Attempt > 1 {
+		et.retransmittedBytes.Add(ctx, compressed, et.exporterAttrs)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// sumValue returns the value of the sum with the given name, 0 if not recorded.
+func sumValue(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var sum int64
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				sum += dp.Value
+			}
+			return sum
+		}
+	}
+	return 0
+}
+
+func TestSizeMetrics(t *testing.T) {
+	for _, compression := range []configcompression.Type{configcompression.TypeGzip, ""} {
+		t.Run(fmt.Sprintf("compression=%q", compression), func(t *testing.T) {
+			var mu sync.Mutex
+			var received []int64
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				received = append(received, request.ContentLength)
+				if len(received) == 1 {
+					writer.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				writer.WriteHeader(http.StatusOK)
+			})
+			defer srv.Close()
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.TracesEndpoint = srv.URL + "/v1/traces"
+			cfg.Compression = compression
+			cfg.QueueConfig.Enabled = false
+			cfg.RetryConfig.InitialInterval = time.Millisecond
+			reader := sdkmetric.NewManualReader()
+			set := exportertest.NewNopCreateSettings()
+			set.ID = component.MustNewID("otlphttp")
+			set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			set.MetricsLevel = configtelemetry.LevelNormal
+			exp, err := createTracesExporter(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			traces := ptrace.NewTraces()
+			spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			for i := 0; i < 100; i++ {
+				spans.AppendEmpty().SetName("the same span name, compressed well")
+			}
+			require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+			body, err := ptraceotlp.NewExportRequestFromTraces(traces).MarshalProto()
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Len(t, received, 2)
+			assert.Equal(t, int64(2*len(body)), sumValue(t, reader, "exporter_otlphttp_uncompressed_bytes"))
+			assert.Equal(t, received[0]+received[1], sumValue(t, reader, "exporter_otlphttp_compressed_bytes"))
+			assert.Equal(t, received[1], sumValue(t, reader, "exporter_otlphttp_retransmitted_bytes"))
+			if compression == "" {
+				assert.Equal(t, int64(len(body)), received[0])
+			} else {
+				assert.Less(t, received[0], int64(len(body)))
+			}
+		})
+	}
+}
@@ -9,13 +9,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
-	"runtime"
 	"strconv"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/proto"
 
@@ -23,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/internal/otlptext"
+	"go.opentelemetry.io/collector/exporter/internal/useragent"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -33,20 +36,32 @@ import (
 
 type baseExporter struct {
 	// Input configuration.
-	config     *Config
-	client     *http.Client
+	config *Config
+	client *http.Client
+	// ws sends the requests instead of client when the export over WebSocket is enabled.
+	ws         *wsClient
 	tracesURL  string
 	metricsURL string
 	logsURL    string
 	logger     *zap.Logger
 	settings   component.TelemetrySettings
+	buildInfo  component.BuildInfo
 	// Default user-agent header.
 	userAgent string
+	// retryBudget is shared with the exporters of the other signals, it is nil if disabled.
+	retryBudget *exporterhelper.RetryBudget
+	// throttleStats records the responses of the URL of the signal, it is nil if disabled.
+	throttleStats *exporterhelper.ThrottleStats
+	// telemetry records the size of the requests sent over HTTP.
+	telemetry *exporterTelemetry
+	id        component.ID
 }
 
 const (
 	headerRetryAfter         = "Retry-After"
+	headerDate               = "Date"
 	maxHTTPResponseReadBytes = 64 * 1024
+	maxResponseSnippetBytes  = 256
 
 	jsonContentType     = "application/json"
 	protobufContentType = "application/x-protobuf"
@@ -63,22 +78,43 @@ func newExporter(cfg component.Config, set exporter.CreateSettings) (*baseExport
 		}
 	}
 
-	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
-		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
+	userAgent, err := useragent.Render(oCfg.UserAgent, set.BuildInfo)
+	if err != nil {
+		return nil, err
+	}
 
 	// client construction is deferred to start
-	return &baseExporter{
+	e := &baseExporter{
 		config:    oCfg,
 		logger:    set.Logger,
 		userAgent: userAgent,
 		settings:  set.TelemetrySettings,
-	}, nil
+		buildInfo: set.BuildInfo,
+		id:        set.ID,
+	}
+	if oCfg.ThrottleStats.Enabled {
+		e.throttleStats = exporterhelper.NewThrottleStats(oCfg.ThrottleStats)
+	}
+	if e.telemetry, err = newExporterTelemetry(set); err != nil {
+		return nil, err
+	}
+	return e, nil
 }
 
 // start actually creates the HTTP client. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) error {
-	client, err := e.config.ClientConfig.ToClient(ctx, host, e.settings)
+	clientConfig := e.config.ClientConfig
+	headers, err := useragent.RenderHeaders(clientConfig.Headers, e.config.TemplatedHeaders, e.buildInfo)
+	if err != nil {
+		return err
+	}
+	clientConfig.Headers = headers
+	if e.config.WebSocket.Enabled {
+		e.ws, err = newWSClient(ctx, e.config, headers, e.userAgent, e.logger)
+		return err
+	}
+	client, err := clientConfig.ToClient(ctx, host, e.settings)
 	if err != nil {
 		return err
 	}
@@ -86,7 +122,45 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.ws != nil {
+		e.ws.close()
+	}
+	e.release()
+	return nil
+}
+
+// release releases the state shared with the exporters of the other signals, on shutdown or if the exporter
+// fails to be created.
+func (e *baseExporter) release() {
+	if e.retryBudget != nil {
+		retryBudgets.release(e.config)
+	}
+}
+
+// debugPayloads returns true if the payloads must be logged, see Config.DebugPayloads. The payloads are
+// not marshaled to text unless the debug level is enabled.
+func (e *baseExporter) debugPayloads() bool {
+	return e.config.DebugPayloads && e.logger.Core().Enabled(zapcore.DebugLevel)
+}
+
+// logPayload logs the text of the exported data when the payload logging is enabled, see Config.DebugPayloads.
+func (e *baseExporter) logPayload(url string, text []byte, err error) {
+	if err != nil {
+		e.logger.Warn("Failed to log the exported payload", zap.String("url", url), zap.Error(err))
+		return
+	}
+	e.logger.Debug("Exporting payload", zap.String("url", url), zap.String("payload", string(text)))
+}
+
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	if e.config.DeduplicateResources {
+		td = dedupTraces(td)
+	}
+	if e.debugPayloads() {
+		text, err := otlptext.NewTextTracesMarshaler().MarshalTraces(td)
+		e.logPayload(e.tracesURL, text, err)
+	}
 	tr := ptraceotlp.NewExportRequestFromTraces(td)
 
 	var err error
@@ -104,10 +178,17 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request, e.tracesPartialSuccessHandler)
+	return e.export(ctx, e.tracesURL, request, td.SpanCount(), e.tracesPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.config.DeduplicateResources {
+		md = dedupMetrics(md)
+	}
+	if e.debugPayloads() {
+		text, err := otlptext.NewTextMetricsMarshaler().MarshalMetrics(md)
+		e.logPayload(e.metricsURL, text, err)
+	}
 	tr := pmetricotlp.NewExportRequestFromMetrics(md)
 
 	var err error
@@ -124,10 +205,17 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request, e.metricsPartialSuccessHandler)
+	return e.export(ctx, e.metricsURL, request, md.DataPointCount(), e.metricsPartialSuccessHandler)
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	if e.config.DeduplicateResources {
+		ld = dedupLogs(ld)
+	}
+	if e.debugPayloads() {
+		text, err := otlptext.NewTextLogsMarshaler().MarshalLogs(ld)
+		e.logPayload(e.logsURL, text, err)
+	}
 	tr := plogotlp.NewExportRequestFromLogs(ld)
 
 	var err error
@@ -145,24 +233,30 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request, e.logsPartialSuccessHandler)
+	return e.export(ctx, e.logsURL, request, ld.LogRecordCount(), e.logsPartialSuccessHandler)
 }
 
-func (e *baseExporter) export(ctx context.Context, url string, request []byte, partialSuccessHandler partialSuccessHandler) error {
+func (e *baseExporter) export(ctx context.Context, url string, request []byte, items int, partialSuccessHandler partialSuccessHandler) error {
+	if e.ws != nil {
+		return e.ws.export(ctx, url, request)
+	}
+
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
 
+	var contentType string
 	switch e.config.Encoding {
 	case EncodingJSON:
-		req.Header.Set("Content-Type", jsonContentType)
+		contentType = jsonContentType
 	case EncodingProto:
-		req.Header.Set("Content-Type", protobufContentType)
+		contentType = protobufContentType
 	default:
 		return fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
+	req.Header.Set("Content-Type", contentType)
 
 	req.Header.Set("User-Agent", e.userAgent)
 
@@ -176,8 +270,13 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 		io.CopyN(io.Discard, resp.Body, maxHTTPResponseReadBytes) // nolint:errcheck
 		resp.Body.Close()
 	}()
+	e.recordResponse(url, resp.StatusCode, items)
+	e.telemetry.record(ctx, len(request), resp)
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		if e.config.ValidateResponses {
+			return handleValidatedSuccessResponse(url, resp, contentType, partialSuccessHandler)
+		}
 		return handlePartialSuccessResponse(resp, partialSuccessHandler)
 	}
 
@@ -206,6 +305,11 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 		if val := resp.Header.Get(headerRetryAfter); isThrottleError && val != "" {
 			if seconds, err2 := strconv.Atoi(val); err2 == nil {
 				retryAfter = seconds
+			} else if retryAt, err2 := http.ParseTime(val); err2 == nil {
+				// The date is compared to the Date header of the response, to not depend on the clock skew.
+				// The retry handler falls back to the local clock if the Date header is missing or invalid.
+				serverNow, _ := http.ParseTime(resp.Header.Get(headerDate))
+				return exporterhelper.NewThrottleRetryAt(formattedErr, retryAt, serverNow)
 			}
 		}
 
@@ -215,6 +319,20 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	return consumererror.NewPermanent(formattedErr)
 }
 
+// recordResponse records the response in the throttling statistics, if enabled. The 429 and 503 responses
+// throttle the exports, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-throttling
+func (e *baseExporter) recordResponse(url string, statusCode int, items int) {
+	if e.throttleStats == nil {
+		return
+	}
+	throttled := statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+	accepted := 0
+	if statusCode >= 200 && statusCode <= 299 {
+		accepted = items
+	}
+	e.throttleStats.RecordResponse(url, strconv.Itoa(statusCode), throttled, accepted)
+}
+
 // Determine if the status code is retryable according to the specification.
 // For more, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures-1
 func isRetryableStatusCode(code int) bool {
@@ -298,6 +416,40 @@ func handlePartialSuccessResponse(resp *http.Response, partialSuccessHandler par
 	return partialSuccessHandler(bodyBytes, resp.Header.Get("Content-Type"))
 }
 
+// handleValidatedSuccessResponse handles the successful response like handlePartialSuccessResponse, but returns
+// a retryable error if its body is neither empty nor an ExportResponse with the Content-Type of the request.
+// The error gives the beginning of the body, which is often enough to identify the proxy that responded.
+func handleValidatedSuccessResponse(url string, resp *http.Response, contentType string, partialSuccessHandler partialSuccessHandler) error {
+	bodyBytes, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+	if len(bodyBytes) == 0 {
+		return nil
+	}
+
+	respContentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(respContentType); err != nil || mediaType != contentType {
+		return fmt.Errorf(
+			"invalid response to %s with HTTP Status Code %d, Content-Type %q instead of %q, Body=%q",
+			url, resp.StatusCode, respContentType, contentType, responseSnippet(bodyBytes))
+	}
+	if err := partialSuccessHandler(bodyBytes, contentType); err != nil {
+		return fmt.Errorf(
+			"invalid response to %s with HTTP Status Code %d, %w, Body=%q",
+			url, resp.StatusCode, err, responseSnippet(bodyBytes))
+	}
+	return nil
+}
+
+// responseSnippet returns the beginning of the body of a response, to be included in the errors.
+func responseSnippet(body []byte) string {
+	if len(body) > maxResponseSnippetBytes {
+		return string(body[:maxResponseSnippetBytes]) + "..."
+	}
+	return string(body)
+}
+
 type partialSuccessHandler func(bytes []byte, contentType string) error
 
 func (e *baseExporter) tracesPartialSuccessHandler(protoBytes []byte, contentType string) error {
@@ -6,23 +6,27 @@ package otlphttpexporter
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 	codes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -174,6 +178,21 @@ func TestErrorResponses(t *testing.T) {
 					time.Duration(30)*time.Second)
 			},
 		},
+		{
+			name:           "503-Retry-After-date",
+			responseStatus: http.StatusServiceUnavailable,
+			responseBody:   status.New(codes.InvalidArgument, "Server overloaded"),
+			headers: map[string]string{
+				"Retry-After": "Mon, 01 Apr 2024 12:00:30 GMT",
+				"Date":        "Mon, 01 Apr 2024 12:00:00 GMT",
+			},
+			err: func(srv *httptest.Server) error {
+				return exporterhelper.NewThrottleRetryAt(
+					errors.New(errMsgPrefix(srv)+"503, Message=Server overloaded, Details=[]"),
+					time.Date(2024, 4, 1, 12, 0, 30, 0, time.UTC),
+					time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC))
+			},
+		},
 		{
 			name:           "504",
 			responseStatus: http.StatusGatewayTimeout,
@@ -254,13 +273,15 @@ func TestErrorResponseInvalidResponseBody(t *testing.T) {
 
 func TestUserAgent(t *testing.T) {
 	set := exportertest.NewNopCreateSettings()
-	set.BuildInfo.Description = "Collector"
-	set.BuildInfo.Version = "1.2.3test"
+	set.BuildInfo = component.NewBuildInfo(set.BuildInfo.Command, "Collector",
+		component.WithBuildVersion("1.2.3test"), component.WithBuildCustomField("vendor", "acme"))
 
 	tests := []struct {
-		name       string
-		headers    map[string]configopaque.String
-		expectedUA string
+		name             string
+		userAgent        string
+		headers          map[string]configopaque.String
+		templatedHeaders map[string]string
+		expectedUA       string
 	}{
 		{
 			name:       "default_user_agent",
@@ -276,6 +297,26 @@ func TestUserAgent(t *testing.T) {
 			headers:    map[string]configopaque.String{"user-agent": "My Custom Agent"},
 			expectedUA: "My Custom Agent",
 		},
+		{
+			name:       "user_agent_template",
+			userAgent:  "{{.Description}} {{.Version}} custom",
+			expectedUA: "Collector 1.2.3test custom",
+		},
+		{
+			name:             "templated_header",
+			templatedHeaders: map[string]string{"User-Agent": "{{.Description}}-{{.Version}}"},
+			expectedUA:       "Collector-1.2.3test",
+		},
+		{
+			name:       "static_header",
+			headers:    map[string]configopaque.String{"User-Agent": "{{.Description}}-{{.Version}}"},
+			expectedUA: "{{.Description}}-{{.Version}}",
+		},
+		{
+			name:       "custom_fields",
+			userAgent:  "{{.Default}} {{.CustomFields.vendor}}",
+			expectedUA: "; vendor=acme) acme",
+		},
 	}
 
 	t.Run("traces", func(t *testing.T) {
@@ -293,6 +334,8 @@ func TestUserAgent(t *testing.T) {
 					ClientConfig: confighttp.ClientConfig{
 						Headers: test.headers,
 					},
+					UserAgent:        test.userAgent,
+					TemplatedHeaders: test.templatedHeaders,
 				}
 				exp, err := createTracesExporter(context.Background(), set, cfg)
 				require.NoError(t, err)
@@ -327,6 +370,8 @@ func TestUserAgent(t *testing.T) {
 					ClientConfig: confighttp.ClientConfig{
 						Headers: test.headers,
 					},
+					UserAgent:        test.userAgent,
+					TemplatedHeaders: test.templatedHeaders,
 				}
 				exp, err := createMetricsExporter(context.Background(), set, cfg)
 				require.NoError(t, err)
@@ -361,6 +406,8 @@ func TestUserAgent(t *testing.T) {
 					ClientConfig: confighttp.ClientConfig{
 						Headers: test.headers,
 					},
+					UserAgent:        test.userAgent,
+					TemplatedHeaders: test.templatedHeaders,
 				}
 				exp, err := createLogsExporter(context.Background(), set, cfg)
 				require.NoError(t, err)
@@ -992,6 +1039,135 @@ func TestEncoding(t *testing.T) {
 	})
 }
 
+func TestDeduplicateResources(t *testing.T) {
+	received := make(chan ptraceotlp.ExportRequest, 1)
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		req := ptraceotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received <- req
+		writer.WriteHeader(200)
+	})
+	defer srv.Close()
+
+	cfg := &Config{
+		TracesEndpoint:       fmt.Sprintf("%s/v1/traces", srv.URL),
+		Encoding:             EncodingProto,
+		DeduplicateResources: true,
+	}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	traces := ptrace.NewTraces()
+	for i := 0; i < 3; i++ {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", "svc")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		ss.Spans().AppendEmpty().SetName(fmt.Sprintf("span%d", i))
+	}
+	require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+
+	req := <-received
+	require.Equal(t, 1, req.Traces().ResourceSpans().Len())
+	require.Equal(t, 1, req.Traces().ResourceSpans().At(0).ScopeSpans().Len())
+	spans := req.Traces().ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 3, spans.Len())
+	assert.Equal(t, "span2", spans.At(2).Name())
+	// The consumed data is not modified.
+	assert.Equal(t, 3, traces.ResourceSpans().Len())
+}
+
+func TestValidateResponses(t *testing.T) {
+	response := ptraceotlp.NewExportResponse()
+	response.PartialSuccess().SetErrorMessage("accepted")
+	validResponse, err := response.MarshalProto()
+	require.NoError(t, err)
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		errMsg      string
+	}{
+		{
+			name: "empty body",
+		},
+		{
+			name:        "export response",
+			contentType: protobufContentType,
+			body:        validResponse,
+		},
+		{
+			name:        "content type with parameters",
+			contentType: protobufContentType + "; charset=binary",
+			body:        validResponse,
+		},
+		{
+			name:        "html error page",
+			contentType: "text/html; charset=utf-8",
+			body:        []byte("<html><body>Proxy error</body></html>"),
+			errMsg:      `Content-Type "text/html; charset=utf-8" instead of "application/x-protobuf", Body="<html><body>Proxy error</body></html>"`,
+		},
+		{
+			name:   "missing content type",
+			body:   validResponse,
+			errMsg: `Content-Type "" instead of "application/x-protobuf"`,
+		},
+		{
+			name:        "invalid export response",
+			contentType: protobufContentType,
+			body:        []byte(strings.Repeat("\xff", 2*maxResponseSnippetBytes)),
+			errMsg:      "error parsing protobuf response",
+		},
+		{
+			name:        "truncated body",
+			contentType: "text/html",
+			body:        []byte(strings.Repeat("a", 2*maxResponseSnippetBytes)),
+			errMsg:      `Body="` + strings.Repeat("a", maxResponseSnippetBytes) + `..."`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+				// A nil Content-Type prevents the server from detecting it.
+				writer.Header()["Content-Type"] = nil
+				if tt.contentType != "" {
+					writer.Header().Set("Content-Type", tt.contentType)
+				}
+				_, err := writer.Write(tt.body)
+				assert.NoError(t, err)
+			})
+			defer srv.Close()
+
+			cfg := &Config{
+				Encoding:          EncodingProto,
+				TracesEndpoint:    fmt.Sprintf("%s/v1/traces", srv.URL),
+				ValidateResponses: true,
+			}
+			exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errMsg)
+			assert.ErrorContains(t, err, "invalid response to "+srv.URL+"/v1/traces with HTTP Status Code 200")
+			assert.False(t, consumererror.IsPermanent(err))
+		})
+	}
+}
+
 func createBackend(endpoint string, handler func(writer http.ResponseWriter, request *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
 	mux.HandleFunc(endpoint, handler)
@@ -1006,3 +1182,116 @@ type badReader struct{}
 func (b badReader) Read([]byte) (int, error) {
 	return 0, errors.New("Bad read")
 }
+
+type fakeZPagesExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	pages map[string]http.Handler
+}
+
+func (e *fakeZPagesExtension) RegisterZPage(name string, handler http.Handler) error {
+	if _, ok := e.pages[name]; ok {
+		return fmt.Errorf("zPage %q is already registered", name)
+	}
+	e.pages[name] = handler
+	return nil
+}
+
+type zPagesHost struct {
+	component.Host
+	ext *fakeZPagesExtension
+}
+
+func (h *zPagesHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{component.MustNewID("zpages"): h.ext}
+}
+
+func TestThrottleStats(t *testing.T) {
+	var requests atomic.Int64
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			writer.Header().Set(headerRetryAfter, "1")
+			writer.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.TracesEndpoint = fmt.Sprintf("%s/v1/traces", srv.URL)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.InitialInterval = time.Millisecond
+	cfg.ThrottleStats.Enabled = true
+	ext := &fakeZPagesExtension{pages: map[string]http.Handler{}}
+	set := exportertest.NewNopCreateSettings()
+	set.ID = component.MustNewID("otlphttp")
+	exp, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), &zPagesHost{Host: componenttest.NewNopHost(), ext: ext}))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+
+	require.Contains(t, ext.pages, "throttlez/otlphttp/traces")
+	rec := httptest.NewRecorder()
+	ext.pages["throttlez/otlphttp/traces"].ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/throttlez/otlphttp/traces", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var snapshot struct {
+		Destinations []exporterhelper.DestinationThrottleStats `json:"destinations"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	require.Len(t, snapshot.Destinations, 1)
+	stats := snapshot.Destinations[0]
+	assert.Equal(t, cfg.TracesEndpoint, stats.Destination)
+	assert.Equal(t, int64(2), stats.Responses)
+	assert.Equal(t, map[string]int64{"429": 1}, stats.ThrottledByStatus)
+	assert.Equal(t, int64(1), stats.AcceptedItems)
+	assert.Equal(t, int64(1), stats.HonoredDelays)
+	assert.GreaterOrEqual(t, stats.HonoredDelaySeconds, 1.0)
+	assert.Positive(t, stats.EstimatedCapacity)
+}
+
+func TestDebugPayloads(t *testing.T) {
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	for _, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel} {
+		t.Run(level.String(), func(t *testing.T) {
+			cfg := &Config{
+				Encoding:       EncodingProto,
+				TracesEndpoint: fmt.Sprintf("%s/v1/traces", srv.URL),
+				DebugPayloads:  true,
+			}
+			set := exportertest.NewNopCreateSettings()
+			core, observed := observer.New(level)
+			set.TelemetrySettings.Logger = zap.New(core)
+			exp, err := createTracesExporter(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+			logs := observed.FilterMessage("Exporting payload").All()
+			if level != zapcore.DebugLevel {
+				// The payloads are only logged when the debug level is enabled.
+				assert.Empty(t, logs)
+				return
+			}
+			require.Len(t, logs, 1)
+			assert.Equal(t, cfg.TracesEndpoint, logs[0].ContextMap()["url"])
+			assert.Contains(t, logs[0].ContextMap()["payload"], "checkout")
+		})
+	}
+}
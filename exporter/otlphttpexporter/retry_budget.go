@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// retryBudgets holds the retry budgets shared by the traces, metrics and logs exporters created from
+// the same configuration, that is by the same exporter instance.
+var retryBudgets = &sharedRetryBudgets{budgets: map[*Config]*sharedRetryBudget{}}
+
+type sharedRetryBudgets struct {
+	mu      sync.Mutex
+	budgets map[*Config]*sharedRetryBudget
+}
+
+type sharedRetryBudget struct {
+	budget *exporterhelper.RetryBudget
+	refs   int
+}
+
+// acquire returns the retry budget of the given configuration, created by the first exporter of the
+// instance. It returns nil if the retry budget is disabled.
+func (s *sharedRetryBudgets) acquire(cfg *Config) *exporterhelper.RetryBudget {
+	if !cfg.RetryBudget.Enabled {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.budgets[cfg]
+	if !ok {
+		b = &sharedRetryBudget{budget: exporterhelper.NewRetryBudget(cfg.RetryBudget)}
+		s.budgets[cfg] = b
+	}
+	b.refs++
+	return b.budget
+}
+
+// release releases the retry budget of the given configuration, which is removed once released by all the exporters.
+func (s *sharedRetryBudgets) release(cfg *Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.budgets[cfg]
+	if !ok {
+		return
+	}
+	b.refs--
+	if b.refs == 0 {
+		delete(s.budgets, cfg)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestRetryBudgetSharedBySignals(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "http://" + testutil.GetAvailableLocalAddress(t)
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryBudget.Enabled = true
+
+	set := exportertest.NewNopCreateSettings()
+	texp, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	mexp, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	lexp, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	retryBudgets.mu.Lock()
+	shared, ok := retryBudgets.budgets[cfg]
+	retryBudgets.mu.Unlock()
+	require.True(t, ok)
+	assert.Equal(t, 3, shared.refs)
+
+	for _, exp := range []component.Component{texp, mexp, lexp} {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+	retryBudgets.mu.Lock()
+	defer retryBudgets.mu.Unlock()
+	assert.NotContains(t, retryBudgets.budgets, cfg)
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Nil(t, retryBudgets.acquire(cfg))
+	retryBudgets.release(cfg)
+	assert.NotContains(t, retryBudgets.budgets, cfg)
+}
+
+func TestRetryBudgetReleasedOnCreateError(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = "http://" + testutil.GetAvailableLocalAddress(t)
+	cfg.RetryBudget.Enabled = true
+	// The invalid duplicate tracking fails the creation of the exporter after the retry budget is acquired.
+	cfg.DuplicateTracking.Enabled = true
+	cfg.DuplicateTracking.Capacity = 0
+
+	_, err := factory.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.Error(t, err)
+	retryBudgets.mu.Lock()
+	defer retryBudgets.mu.Unlock()
+	assert.NotContains(t, retryBudgets.budgets, cfg)
+}
@@ -0,0 +1,398 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+var errWSClosed = errors.New("websocket connection closed")
+
+// wsClient exports the requests over a WebSocket connection per URL. The connections are dialed
+// by the first requests to their URL, and dialed again by the next requests once broken.
+type wsClient struct {
+	dialer       *websocket.Dialer
+	header       http.Header
+	timeout      time.Duration
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	// compressionLevel is the level of the compressed messages, 0 for the default level.
+	compressionLevel int
+	logger           *zap.Logger
+
+	mu    sync.Mutex
+	conns map[string]*wsConn
+	// dials are the dials in progress, the requests to their URL wait for them instead of dialing.
+	dials  map[string]*wsDial
+	closed bool
+}
+
+// wsDial is a dial in progress, done is closed once conn or err is set.
+type wsDial struct {
+	done chan struct{}
+	conn *wsConn
+	err  error
+}
+
+func newWSClient(ctx context.Context, cfg *Config, headers map[string]configopaque.String, userAgent string, logger *zap.Logger) (*wsClient, error) {
+	tlsCfg, err := cfg.TLSSetting.LoadTLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	// The User-Agent set in the headers takes precedence, as for the HTTP requests.
+	header := http.Header{}
+	header.Set("User-Agent", userAgent)
+	for k, v := range headers {
+		header.Set(k, string(v))
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            proxy,
+		TLSClientConfig:  tlsCfg,
+		HandshakeTimeout: cfg.Timeout,
+		ReadBufferSize:   int(cfg.ReadBufferSize),
+		WriteBufferSize:  int(cfg.WriteBufferSize),
+		// The messages are compressed with the per-message deflate extension, see Config.Validate.
+		EnableCompression: cfg.Compression.IsCompressed(),
+	}
+	if cfg.Dialer != nil {
+		dialer.NetDialContext = cfg.Dialer.DialContext
+	}
+	if cfg.MaxConcurrentDials > 0 {
+		if dialer.NetDialContext == nil {
+			dialer.NetDialContext = (&net.Dialer{}).DialContext
+		}
+		dialer.NetDialContext = limitDials(dialer.NetDialContext, cfg.MaxConcurrentDials)
+	}
+
+	return &wsClient{
+		dialer:           dialer,
+		header:           header,
+		timeout:          cfg.Timeout,
+		pingInterval:     cfg.WebSocket.PingInterval,
+		pongTimeout:      cfg.WebSocket.PongTimeout,
+		compressionLevel: int(cfg.CompressionParams.Level),
+		logger:           logger,
+		conns:            map[string]*wsConn{},
+		dials:            map[string]*wsDial{},
+	}, nil
+}
+
+// limitDials limits the number of concurrent dials, as the max_concurrent_dials of the HTTP client.
+func limitDials(dial func(context.Context, string, string) (net.Conn, error), limit int) func(context.Context, string, string) (net.Conn, error) {
+	sem := make(chan struct{}, limit)
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-sem }()
+		return dial(ctx, network, addr)
+	}
+}
+
+// export sends the OTLP/protobuf request to the signal URL, and waits for the response status.
+func (c *wsClient) export(ctx context.Context, signalURL string, request []byte) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	wsURL := toWebSocketURL(signalURL)
+	conn, err := c.conn(ctx, wsURL)
+	if err != nil {
+		return fmt.Errorf("failed to dial the WebSocket connection to %s: %w", wsURL, err)
+	}
+	rsp, err := conn.roundTrip(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to make a WebSocket request to %s: %w", wsURL, err)
+	}
+	return wsResponseError(wsURL, rsp)
+}
+
+// conn returns the connection to the URL, dialing it if there is none or if it is broken. The dial
+// happens without holding the lock, the concurrent requests to the same URL wait for it.
+func (c *wsClient) conn(ctx context.Context, wsURL string) (*wsConn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, errWSClosed
+	}
+	if conn, ok := c.conns[wsURL]; ok && !conn.closed() {
+		c.mu.Unlock()
+		return conn, nil
+	}
+	d, dialing := c.dials[wsURL]
+	if !dialing {
+		d = &wsDial{done: make(chan struct{})}
+		c.dials[wsURL] = d
+	}
+	c.mu.Unlock()
+
+	if dialing {
+		select {
+		case <-d.done:
+			return d.conn, d.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	d.conn, d.err = c.dial(ctx, wsURL)
+	c.mu.Lock()
+	delete(c.dials, wsURL)
+	if d.err == nil {
+		if c.closed {
+			// The client was closed while dialing.
+			d.conn.shutdown()
+			d.conn, d.err = nil, errWSClosed
+		} else {
+			c.conns[wsURL] = d.conn
+		}
+	}
+	c.mu.Unlock()
+	close(d.done)
+	return d.conn, d.err
+}
+
+func (c *wsClient) dial(ctx context.Context, wsURL string) (*wsConn, error) {
+	c.logger.Debug("Dialing WebSocket connection", zap.String("url", wsURL))
+	ws, resp, err := c.dialer.DialContext(ctx, wsURL, c.header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("%w, handshake responded with HTTP Status Code %d", err, resp.StatusCode)
+		}
+		return nil, err
+	}
+	if c.compressionLevel != 0 {
+		if err = ws.SetCompressionLevel(c.compressionLevel); err != nil {
+			_ = ws.Close()
+			return nil, err
+		}
+	}
+	return newWSConn(ws, c.pingInterval, c.pongTimeout), nil
+}
+
+// close closes the connections, the requests waiting for a response fail.
+func (c *wsClient) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for wsURL, conn := range c.conns {
+		conn.shutdown()
+		delete(c.conns, wsURL)
+	}
+}
+
+// wsConn is a WebSocket connection on which the requests are pipelined. The responses are received
+// in the order of the requests, and are matched to the requests waiting for them in that order.
+type wsConn struct {
+	conn         *websocket.Conn
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+
+	// writeMu serializes the writes of the requests, with the registration of their response.
+	writeMu sync.Mutex
+
+	mu sync.Mutex
+	// pending are the channels of the requests waiting for a response, in the order of the requests.
+	pending []chan []byte
+
+	// done is closed once the connection is broken or closed, err is the reason.
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+func newWSConn(ws *websocket.Conn, pingInterval, pongTimeout time.Duration) *wsConn {
+	c := &wsConn{
+		conn:         ws,
+		pingInterval: pingInterval,
+		pongTimeout:  pongTimeout,
+		done:         make(chan struct{}),
+	}
+	_ = c.extendReadDeadline()
+	ws.SetPongHandler(func(string) error { return c.extendReadDeadline() })
+	go c.readLoop()
+	go c.keepAlive()
+	return c
+}
+
+// roundTrip sends the request, and waits for its response. A request abandoned when the context is done
+// keeps its place in the order of the responses, the connection remains usable.
+func (c *wsConn) roundTrip(ctx context.Context, request []byte) ([]byte, error) {
+	rspCh := make(chan []byte, 1)
+
+	c.writeMu.Lock()
+	if c.closed() {
+		c.writeMu.Unlock()
+		return nil, c.err
+	}
+	c.mu.Lock()
+	c.pending = append(c.pending, rspCh)
+	c.mu.Unlock()
+	deadline, _ := ctx.Deadline()
+	_ = c.conn.SetWriteDeadline(deadline)
+	err := c.conn.WriteMessage(websocket.BinaryMessage, request)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.close(err)
+		return nil, err
+	}
+
+	select {
+	case rsp := <-rspCh:
+		return rsp, nil
+	case <-c.done:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *wsConn) readLoop() {
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			c.close(err)
+			return
+		}
+		_ = c.extendReadDeadline()
+
+		c.mu.Lock()
+		if len(c.pending) == 0 {
+			c.mu.Unlock()
+			c.close(errors.New("received a WebSocket response without request"))
+			return
+		}
+		rspCh := c.pending[0]
+		c.pending[0] = nil
+		c.pending = c.pending[1:]
+		c.mu.Unlock()
+		rspCh <- msg
+	}
+}
+
+// keepAlive pings the server, the connection is broken if no pong is received in time.
+func (c *wsConn) keepAlive() {
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.pongTimeout)); err != nil {
+				c.close(err)
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// extendReadDeadline expects the next response or pong before the next ping is unanswered.
+func (c *wsConn) extendReadDeadline() error {
+	return c.conn.SetReadDeadline(time.Now().Add(c.pingInterval + c.pongTimeout))
+}
+
+func (c *wsConn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *wsConn) close(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
+
+// shutdown notifies the server before closing the connection.
+func (c *wsConn) shutdown() {
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(c.pongTimeout))
+	c.close(errWSClosed)
+}
+
+// toWebSocketURL replaces the http and https schemes of the signal URL by ws and wss.
+func toWebSocketURL(signalURL string) string {
+	switch {
+	case strings.HasPrefix(signalURL, "https://"):
+		return "wss://" + strings.TrimPrefix(signalURL, "https://")
+	case strings.HasPrefix(signalURL, "http://"):
+		return "ws://" + strings.TrimPrefix(signalURL, "http://")
+	default:
+		return signalURL
+	}
+}
+
+// wsResponseError decodes the google.rpc.Status of a response, and returns the error of the failed requests.
+func wsResponseError(wsURL string, rsp []byte) error {
+	respStatus := &status.Status{}
+	if err := proto.Unmarshal(rsp, respStatus); err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to decode the response of %s: %w", wsURL, err))
+	}
+	code := codes.Code(respStatus.Code)
+	if code == codes.OK {
+		return nil
+	}
+
+	err := fmt.Errorf(
+		"error exporting items, request to %s responded with Status Code %s, Message=%s, Details=%v",
+		wsURL, code, respStatus.Message, respStatus.Details)
+	if isRetryableCode(code) {
+		return err
+	}
+	return consumererror.NewPermanent(err)
+}
+
+// Determine if the status code is retryable according to the specification, as for OTLP/gRPC.
+// For more, see https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures
+func isRetryableCode(code codes.Code) bool {
+	switch code {
+	case codes.Canceled,
+		codes.DeadlineExceeded,
+		codes.ResourceExhausted,
+		codes.Aborted,
+		codes.OutOfRange,
+		codes.Unavailable,
+		codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
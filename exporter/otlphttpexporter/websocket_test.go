@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// createWebSocketBackend answers the requests received on a connection with the statuses returned by respond,
+// and closes the connection after closeAfter requests if positive.
+func createWebSocketBackend(t *testing.T, closeAfter int, respond func(ptraceotlp.ExportRequest) *status.Status) (string, *atomic.Int64) {
+	endpoint, conns, _ := createWebSocketBackendWithExtensions(t, closeAfter, respond)
+	return endpoint, conns
+}
+
+// createWebSocketBackendWithExtensions is createWebSocketBackend also returning the extensions requested by the
+// last connection.
+func createWebSocketBackendWithExtensions(t *testing.T, closeAfter int, respond func(ptraceotlp.ExportRequest) *status.Status) (string, *atomic.Int64, *atomic.Value) {
+	var conns atomic.Int64
+	var extensions atomic.Value
+	upgrader := websocket.Upgrader{EnableCompression: true}
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "value", request.Header.Get("X-Test"))
+		extensions.Store(request.Header.Get("Sec-WebSocket-Extensions"))
+		conn, err := upgrader.Upgrade(writer, request, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		conns.Add(1)
+		for i := 1; ; i++ {
+			msgType, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			assert.Equal(t, websocket.BinaryMessage, msgType)
+			req := ptraceotlp.NewExportRequest()
+			assert.NoError(t, req.UnmarshalProto(msg))
+			rsp, err := proto.Marshal(respond(req).Proto())
+			assert.NoError(t, err)
+			assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, rsp))
+			if i == closeAfter {
+				return
+			}
+		}
+	})
+	t.Cleanup(srv.Close)
+	return fmt.Sprintf("%s/v1/traces", srv.URL), &conns, &extensions
+}
+
+func newWebSocketTracesExporter(t *testing.T, tracesEndpoint string, opts ...func(*Config)) *baseExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesEndpoint = tracesEndpoint
+	cfg.Headers = map[string]configopaque.String{"X-Test": "value"}
+	cfg.WebSocket.Enabled = true
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	exp, err := newExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	exp.tracesURL = tracesEndpoint
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, exp.shutdown(context.Background())) })
+	return exp
+}
+
+func TestWebSocketExport(t *testing.T) {
+	var received atomic.Int64
+	endpoint, conns := createWebSocketBackend(t, 0, func(req ptraceotlp.ExportRequest) *status.Status {
+		received.Add(int64(req.Traces().SpanCount()))
+		return status.New(codes.OK, "")
+	})
+	exp := newWebSocketTracesExporter(t, endpoint)
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, exp.pushTraces(context.Background(), traces))
+	}
+	assert.Equal(t, int64(5), received.Load())
+	// The requests are sent over the same connection.
+	assert.Equal(t, int64(1), conns.Load())
+}
+
+func TestWebSocketCompression(t *testing.T) {
+	tests := []struct {
+		name           string
+		compression    configcompression.Type
+		wantExtensions string
+	}{
+		{
+			name:           "gzip",
+			compression:    configcompression.TypeGzip,
+			wantExtensions: "permessage-deflate; server_no_context_takeover; client_no_context_takeover",
+		},
+		{
+			name:        "none",
+			compression: "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received atomic.Int64
+			endpoint, _, extensions := createWebSocketBackendWithExtensions(t, 0, func(req ptraceotlp.ExportRequest) *status.Status {
+				received.Add(int64(req.Traces().SpanCount()))
+				return status.New(codes.OK, "")
+			})
+			exp := newWebSocketTracesExporter(t, endpoint, func(cfg *Config) {
+				cfg.Compression = tt.compression
+				cfg.CompressionParams.Level = 9
+			})
+
+			traces := ptrace.NewTraces()
+			traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			require.NoError(t, exp.pushTraces(context.Background(), traces))
+			assert.Equal(t, int64(1), received.Load())
+			assert.Equal(t, tt.wantExtensions, extensions.Load())
+		})
+	}
+}
+
+func TestWebSocketDialWithoutLock(t *testing.T) {
+	// The handshake with this listener never completes.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, ln.Close()) })
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	endpoint, _ := createWebSocketBackend(t, 0, func(ptraceotlp.ExportRequest) *status.Status {
+		return status.New(codes.OK, "")
+	})
+	exp := newWebSocketTracesExporter(t, endpoint, func(cfg *Config) {
+		cfg.MaxConcurrentDials = 1
+	})
+
+	blocked := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		blocked <- exp.ws.export(ctx, fmt.Sprintf("http://%s/v1/traces", ln.Addr()), nil)
+	}()
+
+	conn := <-accepted
+	defer conn.Close()
+
+	// The blocked dial neither holds the lock nor the dial limit while waiting for the handshake.
+	request, err := ptraceotlp.NewExportRequest().MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, exp.ws.export(context.Background(), endpoint, request))
+	select {
+	case err = <-blocked:
+		t.Fatalf("the dial of the unresponsive endpoint completed before the export: %v", err)
+	default:
+	}
+	assert.Error(t, <-blocked)
+}
+
+func TestLimitDials(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	dial := limitDials(func(context.Context, string, string) (net.Conn, error) {
+		close(started)
+		<-release
+		return nil, errors.New("dial failed")
+	}, 1)
+
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := dial(context.Background(), "tcp", "localhost:1")
+		firstErr <- err
+	}()
+	<-started
+	// The second dial waits for the first one, until its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := dial(ctx, "tcp", "localhost:1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	close(release)
+	assert.EqualError(t, <-firstErr, "dial failed")
+}
+
+func TestWebSocketExportErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        *status.Status
+		wantPermanent bool
+	}{
+		{
+			name:   "unavailable",
+			status: status.New(codes.Unavailable, "try again"),
+		},
+		{
+			name:          "invalid_argument",
+			status:        status.New(codes.InvalidArgument, "bad request"),
+			wantPermanent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, _ := createWebSocketBackend(t, 0, func(ptraceotlp.ExportRequest) *status.Status {
+				return tt.status
+			})
+			exp := newWebSocketTracesExporter(t, endpoint)
+
+			err := exp.pushTraces(context.Background(), ptrace.NewTraces())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.status.Message())
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestWebSocketReconnect(t *testing.T) {
+	// The backend closes the connections after every request.
+	endpoint, conns := createWebSocketBackend(t, 1, func(ptraceotlp.ExportRequest) *status.Status {
+		return status.New(codes.OK, "")
+	})
+	exp := newWebSocketTracesExporter(t, endpoint)
+
+	require.NoError(t, exp.pushTraces(context.Background(), ptrace.NewTraces()))
+	// The next request dials again once the broken connection is detected.
+	require.Eventually(t, func() bool {
+		return exp.pushTraces(context.Background(), ptrace.NewTraces()) == nil && conns.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocketDialError(t *testing.T) {
+	exp := newWebSocketTracesExporter(t, "http://localhost:1/v1/traces")
+	err := exp.pushTraces(context.Background(), ptrace.NewTraces())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to dial the WebSocket connection to ws://localhost:1/v1/traces")
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestValidateWebSocket(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://localhost:4318"
+	cfg.WebSocket.Enabled = true
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.WebSocket.PongTimeout = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "websocket: invalid websocket pong_timeout 0s, must be positive")
+
+	cfg.WebSocket.PongTimeout = time.Second
+	cfg.Encoding = EncodingJSON
+	assert.EqualError(t, component.ValidateConfig(cfg), `encoding "json" is not supported by websocket, only "proto" is`)
+
+	cfg.Encoding = EncodingProto
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: component.MustNewID("auth")}
+	assert.EqualError(t, component.ValidateConfig(cfg), "auth is not supported by websocket, use headers instead")
+
+	cfg.Auth = nil
+	cfg.Compression = configcompression.TypeZstd
+	assert.EqualError(t, component.ValidateConfig(cfg), `compression "zstd" is not supported by websocket, only the deflate based "gzip", "zlib" and "deflate" are`)
+
+	cfg.Compression = configcompression.TypeDeflate
+	cfg.Endpoint = "dns+srv://_otlp._tcp.example.com"
+	assert.EqualError(t, component.ValidateConfig(cfg), "the dns+srv scheme is not supported by websocket")
+}
+
+func TestToWebSocketURL(t *testing.T) {
+	assert.Equal(t, "ws://localhost:4318/v1/traces", toWebSocketURL("http://localhost:4318/v1/traces"))
+	assert.Equal(t, "wss://example.com/v1/logs", toWebSocketURL("https://example.com/v1/logs"))
+	assert.Equal(t, "wss://example.com/v1/logs", toWebSocketURL("wss://example.com/v1/logs"))
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter // import "go.opentelemetry.io/collector/exporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type pipelineKey struct{}
+
+// ContextWithPipeline returns a context carrying the ID of the pipeline the data is exported from.
+// It is set by the service before calling the exporters, which can be shared by several pipelines.
+func ContextWithPipeline(ctx context.Context, pipelineID component.ID) context.Context {
+	return context.WithValue(ctx, pipelineKey{}, pipelineID)
+}
+
+// PipelineFromContext returns the ID of the pipeline the data is exported from, see ContextWithPipeline.
+// It returns false if the context does not carry a pipeline ID.
+func PipelineFromContext(ctx context.Context) (component.ID, bool) {
+	pipelineID, ok := ctx.Value(pipelineKey{}).(component.ID)
+	return pipelineID, ok
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestPipelineContext(t *testing.T) {
+	_, ok := PipelineFromContext(context.Background())
+	assert.False(t, ok)
+
+	pipelineID := component.MustNewIDWithName("traces", "foo")
+	got, ok := PipelineFromContext(ContextWithPipeline(context.Background(), pipelineID))
+	assert.True(t, ok)
+	assert.Equal(t, pipelineID, got)
+}
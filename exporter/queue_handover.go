@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter // import "go.opentelemetry.io/collector/exporter"
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// QueueHandover transfers the data left in the sending queues of the exporters being shut down to the
+// new instances of the same exporters, instead of draining it to the old endpoints. This is typically
+// used when reloading the configuration, which may change the endpoints of the exporters: the exporters
+// shut down with a context carrying the QueueHandover hand their queued data over, and the exporters
+// started with it take over the data handed over by the exporters with the same component.ID and data type.
+//
+// The exporters handing their data over are only shut down by Flush, which sends the data that was not
+// taken over, e.g. because the exporter was removed from the configuration or the new configuration
+// failed to start, with the exporters that queued it. Flush must be called once the new configuration
+// is started, or failed to start.
+type QueueHandover struct {
+	mu       sync.Mutex
+	queued   map[handoverKey][]any
+	retiring []retiringExporter
+}
+
+type handoverKey struct {
+	id     component.ID
+	signal component.DataType
+}
+
+type retiringExporter struct {
+	key   handoverKey
+	flush func(context.Context, []any) error
+}
+
+// NewQueueHandover returns a new empty QueueHandover.
+func NewQueueHandover() *QueueHandover {
+	return &QueueHandover{queued: map[handoverKey][]any{}}
+}
+
+type queueHandoverKey struct{}
+
+// ContextWithQueueHandover returns a new context carrying the given QueueHandover, to be passed to the
+// shutdown of the old components and to the start of the new ones.
+func ContextWithQueueHandover(ctx context.Context, h *QueueHandover) context.Context {
+	return context.WithValue(ctx, queueHandoverKey{}, h)
+}
+
+// QueueHandoverFromContext returns the QueueHandover carried by the context, or nil.
+func QueueHandoverFromContext(ctx context.Context) *QueueHandover {
+	h, _ := ctx.Value(queueHandoverKey{}).(*QueueHandover)
+	return h
+}
+
+// HandOver adds an item queued by the exporter with the given ID and data type.
+func (h *QueueHandover) HandOver(id component.ID, signal component.DataType, item any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := handoverKey{id: id, signal: signal}
+	h.queued[key] = append(h.queued[key], item)
+}
+
+// TakeOver returns the items handed over by the exporters with the given ID and data type, and removes them.
+func (h *QueueHandover) TakeOver(id component.ID, signal component.DataType) []any {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := handoverKey{id: id, signal: signal}
+	items := h.queued[key]
+	delete(h.queued, key)
+	return items
+}
+
+// Retire registers an exporter that handed its data over. Flush calls flush with the items handed over
+// by the exporters with the same ID and data type that were not taken over, flush must send them and
+// complete the shutdown of the exporter.
+func (h *QueueHandover) Retire(id component.ID, signal component.DataType, flush func(ctx context.Context, items []any) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.retiring = append(h.retiring, retiringExporter{key: handoverKey{id: id, signal: signal}, flush: flush})
+}
+
+// Flush sends the items that were not taken over with the retiring exporters, and completes their shutdown.
+func (h *QueueHandover) Flush(ctx context.Context) error {
+	h.mu.Lock()
+	retiring := h.retiring
+	h.retiring = nil
+	h.mu.Unlock()
+
+	var errs error
+	for _, r := range retiring {
+		errs = multierr.Append(errs, r.flush(ctx, h.TakeOver(r.key.id, r.key.signal)))
+	}
+	return errs
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestQueueHandover(t *testing.T) {
+	assert.Nil(t, QueueHandoverFromContext(context.Background()))
+	h := NewQueueHandover()
+	assert.Same(t, h, QueueHandoverFromContext(ContextWithQueueHandover(context.Background(), h)))
+
+	id := component.MustNewID("otlp")
+	h.HandOver(id, component.DataTypeTraces, 1)
+	h.HandOver(id, component.DataTypeTraces, 2)
+	h.HandOver(id, component.DataTypeMetrics, 3)
+	assert.Equal(t, []any{1, 2}, h.TakeOver(id, component.DataTypeTraces))
+	assert.Empty(t, h.TakeOver(id, component.DataTypeTraces))
+
+	var flushed []any
+	errShutdown := errors.New("shutdown failed")
+	h.Retire(id, component.DataTypeMetrics, func(_ context.Context, items []any) error {
+		flushed = append(flushed, items...)
+		return errShutdown
+	})
+	assert.ErrorIs(t, h.Flush(context.Background()), errShutdown)
+	assert.Equal(t, []any{3}, flushed)
+
+	// The retiring exporters are only flushed once.
+	assert.NoError(t, h.Flush(context.Background()))
+	assert.Equal(t, []any{3}, flushed)
+}
@@ -62,6 +62,17 @@ type StatusWatcher interface {
 	ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent)
 }
 
+// FaultInjector is an optional interface for Extension hosted by the OpenTelemetry Collector
+// that is to be implemented by extensions injecting faults in the pipelines, e.g. to validate
+// the alerting and the backpressure behavior of a deployment in a staging environment.
+type FaultInjector interface {
+	// InjectFault is called before the data of the given type is consumed by the processor or the
+	// exporter with the given ID, and may delay it. It returns true if the data must be dropped
+	// instead of being consumed, or an error to fail the consumption of the data.
+	// The function may be called concurrently with itself.
+	InjectFault(ctx context.Context, kind component.Kind, id component.ID, dataType component.DataType) (bool, error)
+}
+
 // CreateSettings is passed to Factory.Create(...) function.
 type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -160,11 +171,13 @@ func (b *Builder) Create(ctx context.Context, set CreateSettings) (Extension, er
 		return nil, fmt.Errorf("extension factory not available for: %q", set.ID)
 	}
 
-	sl := f.ExtensionStability()
-	if sl >= component.StabilityLevelAlpha {
+	switch sl := f.ExtensionStability(); {
+	case sl >= component.StabilityLevelBeta:
 		set.Logger.Debug(sl.LogMessage())
-	} else {
+	case sl == component.StabilityLevelUndefined:
 		set.Logger.Info(sl.LogMessage())
+	default:
+		set.Logger.Warn(sl.LogMessage())
 	}
 	return f.CreateExtension(ctx, set, cfg)
 }
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjectionextension // import "go.opentelemetry.io/collector/extension/faultinjectionextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	kindProcessor = "processor"
+	kindExporter  = "exporter"
+)
+
+// Config defines the configuration of the faults injected by the extension.
+type Config struct {
+	// Faults are the faults injected before the data is consumed by the processors and the exporters.
+	Faults []FaultConfig `mapstructure:"faults"`
+}
+
+// FaultConfig defines a fault injected before the data is consumed by a processor or an exporter.
+type FaultConfig struct {
+	// Component is the ID of the processor or the exporter the fault is injected in.
+	Component component.ID `mapstructure:"component"`
+
+	// Kind restricts the fault to the processor or to the exporter with the ID, "processor" or "exporter".
+	// The fault is injected in both if empty.
+	Kind string `mapstructure:"kind"`
+
+	// DataTypes restricts the fault to the data of the given types, "traces", "metrics" or "logs".
+	// The fault is injected for all the data types if empty.
+	DataTypes []string `mapstructure:"data_types"`
+
+	// Percent is the percentage of the batches the fault is injected for, between 0 (excluded) and 100.
+	Percent float64 `mapstructure:"percent"`
+
+	// Latency delays the batches before they are consumed, or before the error is returned.
+	Latency time.Duration `mapstructure:"latency"`
+
+	// Error is the message of the error returned instead of consuming the batches.
+	Error string `mapstructure:"error"`
+
+	// Permanent makes the error permanent, so that it is not retried by the exporters.
+	Permanent bool `mapstructure:"permanent"`
+
+	// Drop drops the batches instead of consuming them, without returning an error.
+	Drop bool `mapstructure:"drop"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the fault configuration is valid.
+func (cfg *FaultConfig) Validate() error {
+	if cfg.Component == (component.ID{}) {
+		return errors.New("component must be set")
+	}
+	if cfg.Kind != "" && cfg.Kind != kindProcessor && cfg.Kind != kindExporter {
+		return fmt.Errorf("invalid kind %q, must be %q or %q", cfg.Kind, kindProcessor, kindExporter)
+	}
+	for _, dataType := range cfg.DataTypes {
+		if dataType != component.DataTypeTraces.String() && dataType != component.DataTypeMetrics.String() &&
+			dataType != component.DataTypeLogs.String() {
+			return fmt.Errorf("invalid data type %q, must be %q, %q or %q", dataType,
+				component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs)
+		}
+	}
+	if cfg.Percent <= 0 || cfg.Percent > 100 {
+		return fmt.Errorf("percent must be in (0, 100], got %v", cfg.Percent)
+	}
+	if cfg.Latency < 0 {
+		return fmt.Errorf("invalid latency %v, must be positive", cfg.Latency)
+	}
+	if cfg.Drop && cfg.Error != "" {
+		return errors.New("drop and error are mutually exclusive")
+	}
+	if cfg.Permanent && cfg.Error == "" {
+		return errors.New("permanent requires the error to be set")
+	}
+	if cfg.Latency == 0 && cfg.Error == "" && !cfg.Drop {
+		return errors.New("at least one of latency, error or drop must be set")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjectionextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			Faults: []FaultConfig{
+				{Component: component.MustNewID("otlp"), Kind: "exporter", Percent: 50, Latency: 2 * time.Second},
+				{Component: component.MustNewID("batch"), Kind: "processor", DataTypes: []string{"traces"}, Percent: 10, Error: "injected failure"},
+				{Component: component.MustNewID("otlphttp"), DataTypes: []string{"metrics"}, Percent: 100, Drop: true},
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    FaultConfig
+		expect string
+	}{
+		{
+			name:   "missing component",
+			cfg:    FaultConfig{Percent: 100, Drop: true},
+			expect: "component must be set",
+		},
+		{
+			name:   "invalid kind",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Kind: "receiver", Percent: 100, Drop: true},
+			expect: `invalid kind "receiver", must be "processor" or "exporter"`,
+		},
+		{
+			name:   "invalid data type",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), DataTypes: []string{"profiles"}, Percent: 100, Drop: true},
+			expect: `invalid data type "profiles", must be "traces", "metrics" or "logs"`,
+		},
+		{
+			name:   "missing percent",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Drop: true},
+			expect: "percent must be in (0, 100], got 0",
+		},
+		{
+			name:   "invalid percent",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Percent: 150, Drop: true},
+			expect: "percent must be in (0, 100], got 150",
+		},
+		{
+			name:   "negative latency",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Percent: 100, Latency: -time.Second},
+			expect: "invalid latency -1s, must be positive",
+		},
+		{
+			name:   "drop and error",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Percent: 100, Drop: true, Error: "failure"},
+			expect: "drop and error are mutually exclusive",
+		},
+		{
+			name:   "permanent without error",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Percent: 100, Drop: true, Permanent: true},
+			expect: "permanent requires the error to be set",
+		},
+		{
+			name:   "no fault",
+			cfg:    FaultConfig{Component: component.MustNewID("otlp"), Percent: 100},
+			expect: "at least one of latency, error or drop must be set",
+		},
+		{
+			name: "valid",
+			cfg:  FaultConfig{Component: component.MustNewID("otlp"), Percent: 100, Error: "failure", Permanent: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expect == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expect)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjectionextension // import "go.opentelemetry.io/collector/extension/faultinjectionextension"
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// faultInjectionFeatureGate guards the injection of the faults, so that a configuration enabling the
+// extension by mistake does not affect the production deployments.
+var faultInjectionFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"extension.faultInjection",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the faultinjection extension injects the configured "+
+		"faults in the processors and the exporters. The extension does nothing when the gate is disabled."))
+
+var _ extension.FaultInjector = (*faultInjection)(nil)
+
+type faultInjection struct {
+	component.ShutdownFunc
+	logger *zap.Logger
+	faults []FaultConfig
+	// percent returns a random number in [0, 100).
+	percent func() float64
+}
+
+func newFaultInjection(cfg *Config, logger *zap.Logger) *faultInjection {
+	return &faultInjection{
+		logger: logger,
+		faults: cfg.Faults,
+		percent: func() float64 {
+			return rand.Float64() * 100
+		},
+	}
+}
+
+func (fi *faultInjection) Start(context.Context, component.Host) error {
+	if !faultInjectionFeatureGate.IsEnabled() {
+		fi.logger.Warn("The faults are not injected, the feature gate is disabled", zap.String("feature_gate", faultInjectionFeatureGate.ID()))
+		return nil
+	}
+	fi.logger.Warn("Injecting faults in the pipelines, the extension must not be used in production", zap.Int("faults", len(fi.faults)))
+	return nil
+}
+
+// InjectFault injects the faults matching the component and the data type. The latencies of the faults add up,
+// and the first error or drop ends the injection.
+func (fi *faultInjection) InjectFault(ctx context.Context, kind component.Kind, id component.ID, dataType component.DataType) (bool, error) {
+	if !faultInjectionFeatureGate.IsEnabled() {
+		return false, nil
+	}
+	for i := range fi.faults {
+		f := &fi.faults[i]
+		if !f.matches(kind, id, dataType) || fi.percent() >= f.Percent {
+			continue
+		}
+		if f.Latency > 0 {
+			timer := time.NewTimer(f.Latency)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return false, ctx.Err()
+			case <-timer.C:
+			}
+		}
+		switch {
+		case f.Drop:
+			fi.logger.Debug("Dropping the data", zap.Stringer("component", id), zap.Stringer("data_type", dataType))
+			return true, nil
+		case f.Error != "":
+			fi.logger.Debug("Failing the data", zap.Stringer("component", id), zap.Stringer("data_type", dataType))
+			err := errors.New(f.Error)
+			if f.Permanent {
+				err = consumererror.NewPermanent(err)
+			}
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// matches returns true if the fault is injected in the component of the given kind and ID, for the data type.
+func (cfg *FaultConfig) matches(kind component.Kind, id component.ID, dataType component.DataType) bool {
+	if cfg.Component != id {
+		return false
+	}
+	switch cfg.Kind {
+	case kindProcessor:
+		if kind != component.KindProcessor {
+			return false
+		}
+	case kindExporter:
+		if kind != component.KindExporter {
+			return false
+		}
+	}
+	if len(cfg.DataTypes) == 0 {
+		return true
+	}
+	for _, dt := range cfg.DataTypes {
+		if dt == dataType.String() {
+			return true
+		}
+	}
+	return false
+}
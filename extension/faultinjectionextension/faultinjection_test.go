@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjectionextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+var (
+	otlpID  = component.MustNewID("otlp")
+	batchID = component.MustNewID("batch")
+)
+
+func enableFeatureGate(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(faultInjectionFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(faultInjectionFeatureGate.ID(), false))
+	})
+}
+
+func TestInjectFaultDisabled(t *testing.T) {
+	fi := newFaultInjection(&Config{Faults: []FaultConfig{{Component: otlpID, Percent: 100, Error: "failure"}}}, zap.NewNop())
+	require.NoError(t, fi.Start(context.Background(), componenttest.NewNopHost()))
+	drop, err := fi.InjectFault(context.Background(), component.KindExporter, otlpID, component.DataTypeTraces)
+	assert.False(t, drop)
+	assert.NoError(t, err)
+}
+
+func TestInjectFault(t *testing.T) {
+	enableFeatureGate(t)
+	fi := newFaultInjection(&Config{Faults: []FaultConfig{
+		{Component: otlpID, Kind: kindExporter, DataTypes: []string{"traces"}, Percent: 100, Error: "retryable failure"},
+		{Component: otlpID, Kind: kindExporter, DataTypes: []string{"metrics"}, Percent: 100, Error: "permanent failure", Permanent: true},
+		{Component: otlpID, Kind: kindExporter, DataTypes: []string{"logs"}, Percent: 50, Drop: true},
+		{Component: batchID, Kind: kindProcessor, Percent: 100, Latency: time.Millisecond},
+	}}, zap.NewNop())
+	percent := 0.0
+	fi.percent = func() float64 { return percent }
+	require.NoError(t, fi.Start(context.Background(), componenttest.NewNopHost()))
+
+	drop, err := fi.InjectFault(context.Background(), component.KindExporter, otlpID, component.DataTypeTraces)
+	assert.False(t, drop)
+	assert.EqualError(t, err, "retryable failure")
+	assert.False(t, consumererror.IsPermanent(err))
+
+	drop, err = fi.InjectFault(context.Background(), component.KindExporter, otlpID, component.DataTypeMetrics)
+	assert.False(t, drop)
+	assert.EqualError(t, err, "Permanent error: permanent failure")
+	assert.True(t, consumererror.IsPermanent(err))
+
+	drop, err = fi.InjectFault(context.Background(), component.KindExporter, otlpID, component.DataTypeLogs)
+	assert.True(t, drop)
+	assert.NoError(t, err)
+
+	// The logs are only dropped for the given percentage of the batches.
+	percent = 50
+	drop, err = fi.InjectFault(context.Background(), component.KindExporter, otlpID, component.DataTypeLogs)
+	assert.False(t, drop)
+	assert.NoError(t, err)
+
+	// The faults of the exporter are not injected in the processor with the same ID.
+	drop, err = fi.InjectFault(context.Background(), component.KindProcessor, otlpID, component.DataTypeTraces)
+	assert.False(t, drop)
+	assert.NoError(t, err)
+
+	drop, err = fi.InjectFault(context.Background(), component.KindProcessor, batchID, component.DataTypeTraces)
+	assert.False(t, drop)
+	assert.NoError(t, err)
+}
+
+func TestInjectLatencyCanceled(t *testing.T) {
+	enableFeatureGate(t)
+	fi := newFaultInjection(&Config{Faults: []FaultConfig{{Component: otlpID, Percent: 100, Latency: time.Hour}}}, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	drop, err := fi.InjectFault(ctx, component.KindExporter, otlpID, component.DataTypeTraces)
+	assert.False(t, drop)
+	assert.ErrorIs(t, err, context.Canceled)
+}
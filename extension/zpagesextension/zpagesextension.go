@@ -6,8 +6,10 @@ package zpagesextension // import "go.opentelemetry.io/collector/extension/zpage
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
+	"sync"
 
 	"go.opentelemetry.io/contrib/zpages"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -18,6 +20,7 @@ import (
 
 const (
 	tracezPath = "tracez"
+	pathPrefix = "/debug"
 )
 
 type zpagesExtension struct {
@@ -26,6 +29,11 @@ type zpagesExtension struct {
 	zpagesSpanProcessor *zpages.SpanProcessor
 	server              http.Server
 	stopCh              chan struct{}
+
+	// componentsMux serves the zPages registered by the components.
+	componentsMux *http.ServeMux
+	pagesMu       sync.Mutex
+	pages         map[string]bool
 }
 
 // registerableTracerProvider is a tracer that supports
@@ -45,11 +53,12 @@ type registerableTracerProvider interface {
 
 func (zpe *zpagesExtension) Start(_ context.Context, host component.Host) error {
 	zPagesMux := http.NewServeMux()
+	zPagesMux.Handle(pathPrefix+"/", zpe.componentsMux)
 
 	sdktracer, ok := zpe.telemetry.TracerProvider.(registerableTracerProvider)
 	if ok {
 		sdktracer.RegisterSpanProcessor(zpe.zpagesSpanProcessor)
-		zPagesMux.Handle(path.Join("/debug", tracezPath), zpages.NewTracezHandler(zpe.zpagesSpanProcessor))
+		zPagesMux.Handle(path.Join(pathPrefix, tracezPath), zpages.NewTracezHandler(zpe.zpagesSpanProcessor))
 		zpe.telemetry.Logger.Info("Registered zPages span processor on tracer provider")
 	} else {
 		zpe.telemetry.Logger.Warn("zPages span processor registration is not available")
@@ -59,7 +68,7 @@ func (zpe *zpagesExtension) Start(_ context.Context, host component.Host) error
 		RegisterZPages(mux *http.ServeMux, pathPrefix string)
 	})
 	if ok {
-		hostZPages.RegisterZPages(zPagesMux, "/debug")
+		hostZPages.RegisterZPages(zPagesMux, pathPrefix)
 		zpe.telemetry.Logger.Info("Registered Host's zPages")
 	} else {
 		zpe.telemetry.Logger.Warn("Host's zPages not available")
@@ -103,10 +112,27 @@ func (zpe *zpagesExtension) Shutdown(context.Context) error {
 	return err
 }
 
+// RegisterZPage registers the handler serving a zPage of a component at the given path under "/debug".
+// Components, which are started after the extensions, can look for an extension implementing this
+// method using component.Host.GetExtensions to expose debugging information.
+func (zpe *zpagesExtension) RegisterZPage(name string, handler http.Handler) error {
+	p := path.Join(pathPrefix, name)
+	zpe.pagesMu.Lock()
+	defer zpe.pagesMu.Unlock()
+	if zpe.pages[p] {
+		return fmt.Errorf("zPage %q is already registered", p)
+	}
+	zpe.pages[p] = true
+	zpe.componentsMux.Handle(p, handler)
+	return nil
+}
+
 func newServer(config *Config, telemetry component.TelemetrySettings) *zpagesExtension {
 	return &zpagesExtension{
 		config:              config,
 		telemetry:           telemetry,
 		zpagesSpanProcessor: zpages.NewSpanProcessor(),
+		componentsMux:       http.NewServeMux(),
+		pages:               map[string]bool{},
 	}
 }
@@ -5,6 +5,7 @@ package zpagesextension
 
 import (
 	"context"
+	"io"
 	"net"
 	"net/http"
 	"runtime"
@@ -73,6 +74,37 @@ func TestZPagesExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestZPagesExtensionRegisterZPage(t *testing.T) {
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddrConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+
+	zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+	require.NoError(t, zpagesExt.Start(context.Background(), newZPagesHost()))
+	t.Cleanup(func() { require.NoError(t, zpagesExt.Shutdown(context.Background())) })
+
+	// The components register their zPages after the extensions are started.
+	require.NoError(t, zpagesExt.RegisterZPage("capturez/otlp", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("captured"))
+	})))
+	require.EqualError(t, zpagesExt.RegisterZPage("capturez/otlp", http.NotFoundHandler()), `zPage "/debug/capturez/otlp" is already registered`)
+
+	resp, err := http.Get("http://" + cfg.TCPAddr.Endpoint + "/debug/capturez/otlp")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "captured", string(body))
+
+	resp, err = http.Get("http://" + cfg.TCPAddr.Endpoint + "/debug/unknownz")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestZPagesExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", endpoint)
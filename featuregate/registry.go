@@ -35,6 +35,10 @@ func GlobalRegistry() *Registry {
 
 type Registry struct {
 	gates sync.Map
+	// removed holds the gates removed from the code, to explain why they cannot be set anymore.
+	removed sync.Map
+	// version is the version of the collector, used to refuse the gates past their removal version.
+	version atomic.Pointer[version.Version]
 }
 
 // NewRegistry returns a new empty Registry.
@@ -162,14 +166,91 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 		return nil, fmt.Errorf("toVersion %q is before fromVersion %q", g.toVersion, g.fromVersion)
 	}
 
+	if _, loaded := r.removed.Load(id); loaded {
+		return nil, fmt.Errorf("failed to register %q: %w", id, ErrAlreadyRegistered)
+	}
 	if _, loaded := r.gates.LoadOrStore(id, g); loaded {
 		return nil, fmt.Errorf("failed to register %q: %w", id, ErrAlreadyRegistered)
 	}
 	return g, nil
 }
 
+// MustRegisterRemoved like RegisterRemoved but panics if an invalid ID or gate options are provided.
+func (r *Registry) MustRegisterRemoved(id string, stage Stage, opts ...RegisterOption) {
+	if err := r.RegisterRemoved(id, stage, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterRemoved registers a Gate removed from the code, so that setting it returns an error explaining
+// that it was removed, instead of an unknown gate error. The stage is the last stage of the Gate, either
+// StageStable if the feature is now always enabled, or StageDeprecated if the feature was discontinued.
+// The removal version must be set with WithRegisterToVersion.
+func (r *Registry) RegisterRemoved(id string, stage Stage, opts ...RegisterOption) error {
+	if err := validateID(id); err != nil {
+		return fmt.Errorf("invalid ID %q: %w", id, err)
+	}
+	if stage != StageStable && stage != StageDeprecated {
+		return fmt.Errorf("invalid stage %v for removed gate %q, must be %v or %v", stage, id, StageStable, StageDeprecated)
+	}
+
+	g := &Gate{
+		id:      id,
+		stage:   stage,
+		enabled: &atomic.Bool{},
+	}
+	for _, opt := range opts {
+		if err := opt.apply(g); err != nil {
+			return fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if g.toVersion == nil {
+		return fmt.Errorf("no removal version set for removed gate %q", id)
+	}
+	g.enabled.Store(stage == StageStable)
+
+	if _, loaded := r.gates.Load(id); loaded {
+		return fmt.Errorf("failed to register %q: %w", id, ErrAlreadyRegistered)
+	}
+	if _, loaded := r.removed.LoadOrStore(id, g); loaded {
+		return fmt.Errorf("failed to register %q: %w", id, ErrAlreadyRegistered)
+	}
+	return nil
+}
+
+// SetVersion sets the version of the collector. Once set, setting a stable or deprecated Gate whose removal
+// version is lower than or equal to the version of the collector returns an error, as the Gate should have
+// been removed.
+// version must be a valid version string: it may start with 'v' and must be in the format Major.Minor.Patch[-PreRelease].
+func (r *Registry) SetVersion(collectorVersion string) error {
+	v, err := version.NewVersion(collectorVersion)
+	if err != nil {
+		return fmt.Errorf("invalid version %q: %w", collectorVersion, err)
+	}
+	r.version.Store(v)
+	return nil
+}
+
+// removedError returns the error explaining that the Gate was removed in the given version.
+func removedError(g *Gate) error {
+	var msg string
+	if g.stage == StageStable {
+		msg = fmt.Sprintf("feature gate %q is stable and was removed in version v%v, the feature is always enabled: remove it from the --feature-gates flag", g.id, g.toVersion)
+	} else {
+		msg = fmt.Sprintf("feature gate %q is deprecated and was removed in version v%v, the feature is no longer available: remove it from the --feature-gates flag", g.id, g.toVersion)
+	}
+	if g.referenceURL != "" {
+		msg += ", see " + g.referenceURL
+	}
+	return errors.New(msg)
+}
+
 // Set the enabled valued for a Gate identified by the given id.
 func (r *Registry) Set(id string, enabled bool) error {
+	if v, ok := r.removed.Load(id); ok {
+		g := v.(*Gate)
+		return removedError(g)
+	}
 	v, ok := r.gates.Load(id)
 	if !ok {
 		validGates := []string{}
@@ -180,6 +261,11 @@ func (r *Registry) Set(id string, enabled bool) error {
 	}
 	g := v.(*Gate)
 
+	if current := r.version.Load(); current != nil && g.toVersion != nil &&
+		(g.stage == StageStable || g.stage == StageDeprecated) && !current.LessThan(g.toVersion) {
+		return removedError(g)
+	}
+
 	switch g.stage {
 	case StageStable:
 		if !enabled {
@@ -199,8 +285,17 @@ func (r *Registry) Set(id string, enabled bool) error {
 
 // VisitAll visits all the gates in lexicographical order, calling fn for each.
 func (r *Registry) VisitAll(fn func(*Gate)) {
+	visit(&r.gates, fn)
+}
+
+// VisitRemoved visits all the removed gates in lexicographical order, calling fn for each.
+func (r *Registry) VisitRemoved(fn func(*Gate)) {
+	visit(&r.removed, fn)
+}
+
+func visit(m *sync.Map, fn func(*Gate)) {
 	var gates []*Gate
-	r.gates.Range(func(_, value any) bool {
+	m.Range(func(_, value any) bool {
 		gates = append(gates, value.(*Gate))
 		return true
 	})
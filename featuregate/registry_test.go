@@ -207,3 +207,64 @@ func TestRegisterGateLifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryRemoved(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.RegisterRemoved("stable", StageStable, WithRegisterToVersion("v0.90.0"),
+		WithRegisterReferenceURL("https://example.com/stable")))
+	r.MustRegisterRemoved("deprecated", StageDeprecated, WithRegisterToVersion("v0.91.0"))
+
+	assert.EqualError(t, r.Set("stable", true), `feature gate "stable" is stable and was removed in version v0.90.0, `+
+		`the feature is always enabled: remove it from the --feature-gates flag, see https://example.com/stable`)
+	assert.EqualError(t, r.Set("deprecated", false), `feature gate "deprecated" is deprecated and was removed in version v0.91.0, `+
+		`the feature is no longer available: remove it from the --feature-gates flag`)
+
+	// The removed gates are not visited with the registered ones.
+	r.VisitAll(func(*Gate) {
+		t.FailNow()
+	})
+	var removed []string
+	r.VisitRemoved(func(g *Gate) {
+		removed = append(removed, g.ID())
+	})
+	assert.Equal(t, []string{"deprecated", "stable"}, removed)
+
+	_, err := r.Register("stable", StageAlpha)
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+	r.MustRegister("alpha", StageAlpha)
+	assert.ErrorIs(t, r.RegisterRemoved("alpha", StageStable, WithRegisterToVersion("v0.90.0")), ErrAlreadyRegistered)
+	assert.ErrorIs(t, r.RegisterRemoved("stable", StageStable, WithRegisterToVersion("v0.90.0")), ErrAlreadyRegistered)
+}
+
+func TestRegistryRemovedErrors(t *testing.T) {
+	r := NewRegistry()
+	assert.EqualError(t, r.RegisterRemoved("", StageStable), `invalid ID "": empty ID`)
+	assert.EqualError(t, r.RegisterRemoved("foo", StageBeta, WithRegisterToVersion("v0.90.0")),
+		`invalid stage Beta for removed gate "foo", must be Stable or Deprecated`)
+	assert.EqualError(t, r.RegisterRemoved("foo", StageStable), `no removal version set for removed gate "foo"`)
+	assert.Error(t, r.RegisterRemoved("foo", StageStable, WithRegisterToVersion("invalid")))
+	assert.Panics(t, func() {
+		r.MustRegisterRemoved("foo", StageStable)
+	})
+}
+
+func TestRegistrySetVersion(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("stable", StageStable, WithRegisterToVersion("v0.90.0"))
+	r.MustRegister("deprecated", StageDeprecated, WithRegisterToVersion("v0.95.0"))
+	r.MustRegister("beta", StageBeta, WithRegisterToVersion("v0.90.0"))
+
+	assert.Error(t, r.SetVersion("latest"))
+	assert.NoError(t, r.Set("stable", true))
+
+	require.NoError(t, r.SetVersion("v0.90.0"))
+	assert.EqualError(t, r.Set("stable", true), `feature gate "stable" is stable and was removed in version v0.90.0, `+
+		`the feature is always enabled: remove it from the --feature-gates flag`)
+	assert.NoError(t, r.Set("deprecated", false))
+	// The alpha and beta gates can still be used after their ToVersion.
+	assert.NoError(t, r.Set("beta", false))
+
+	require.NoError(t, r.SetVersion("0.96.0-dev"))
+	assert.EqualError(t, r.Set("deprecated", false), `feature gate "deprecated" is deprecated and was removed in version v0.95.0, `+
+		`the feature is no longer available: remove it from the --feature-gates flag`)
+}
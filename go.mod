@@ -6,6 +6,7 @@ require (
 	github.com/shirou/gopsutil/v3 v3.24.3
 	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/collector/component v0.98.0
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0
 	go.opentelemetry.io/collector/confmap v0.98.0
 	go.opentelemetry.io/collector/consumer v0.98.0
 	go.opentelemetry.io/collector/featuregate v1.5.0
@@ -75,6 +76,8 @@ replace go.opentelemetry.io/collector/confmap => ./confmap
 
 replace go.opentelemetry.io/collector/config/configtelemetry => ./config/configtelemetry
 
+replace go.opentelemetry.io/collector/config/confighumanize => ./config/confighumanize
+
 replace go.opentelemetry.io/collector/consumer => ./consumer
 
 replace go.opentelemetry.io/collector/featuregate => ./featuregate
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package droplog logs sampled summaries of the data dropped by the components, to help investigating data loss.
+package droplog // import "go.opentelemetry.io/collector/internal/droplog"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// serviceNameKey is the resource attribute naming the service sending the data.
+	serviceNameKey = "service.name"
+	// maxServiceNames is the maximum number of distinct service names in a summary.
+	maxServiceNames = 5
+	// maxTraceIDs is the maximum number of trace IDs in a summary.
+	maxTraceIDs = 3
+	// maxMetricNames is the maximum number of distinct metric names in a summary.
+	maxMetricNames = 5
+	// defaultInterval is the minimum interval between two summaries logged by the same Logger.
+	defaultInterval = time.Second
+)
+
+// Logger logs a summary of the dropped data at the debug level: the number of items, the service names
+// of the resources, and the first trace IDs or metric names. The summaries are only computed when the
+// debug level is enabled, and at most one summary is logged per second, the number of drops not
+// summarized since the previous summary being logged with the next one.
+type Logger struct {
+	logger   *zap.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	last    time.Time
+	skipped int
+}
+
+// New returns a Logger logging the summaries with the given logger.
+func New(logger *zap.Logger) *Logger {
+	return &Logger{
+		logger:   logger,
+		interval: defaultInterval,
+		now:      time.Now,
+	}
+}
+
+// sample returns true and the number of drops skipped since the last summary if a summary must be logged.
+func (l *Logger) sample() (bool, int) {
+	if l == nil || !l.logger.Core().Enabled(zapcore.DebugLevel) {
+		return false, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() && now.Sub(l.last) < l.interval {
+		l.skipped++
+		return false, 0
+	}
+	l.last = now
+	skipped := l.skipped
+	l.skipped = 0
+	return true, skipped
+}
+
+func (l *Logger) log(reason string, skipped int, fields ...zap.Field) {
+	l.logger.Debug("Dropped data.", append([]zap.Field{
+		zap.String("reason", reason),
+		zap.Int("skipped_summaries", skipped),
+	}, fields...)...)
+}
+
+// Traces logs a summary of the dropped traces td, if sampled.
+func (l *Logger) Traces(reason string, td ptrace.Traces) {
+	ok, skipped := l.sample()
+	if !ok {
+		return
+	}
+	services := newStringSet(maxServiceNames)
+	traceIDs := newStringSet(maxTraceIDs)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		services.addServiceName(rss.At(i).Resource())
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len() && !traceIDs.full(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len() && !traceIDs.full(); k++ {
+				traceIDs.addTraceID(spans.At(k).TraceID())
+			}
+		}
+	}
+	l.log(reason, skipped,
+		zap.Int("spans", td.SpanCount()),
+		zap.Strings("service_names", services.values),
+		zap.Strings("trace_ids", traceIDs.values))
+}
+
+// Metrics logs a summary of the dropped metrics md, if sampled.
+func (l *Logger) Metrics(reason string, md pmetric.Metrics) {
+	ok, skipped := l.sample()
+	if !ok {
+		return
+	}
+	services := newStringSet(maxServiceNames)
+	names := newStringSet(maxMetricNames)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		services.addServiceName(rms.At(i).Resource())
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len() && !names.full(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len() && !names.full(); k++ {
+				names.add(metrics.At(k).Name())
+			}
+		}
+	}
+	l.log(reason, skipped,
+		zap.Int("data_points", md.DataPointCount()),
+		zap.Strings("service_names", services.values),
+		zap.Strings("metric_names", names.values))
+}
+
+// Logs logs a summary of the dropped logs ld, if sampled.
+func (l *Logger) Logs(reason string, ld plog.Logs) {
+	ok, skipped := l.sample()
+	if !ok {
+		return
+	}
+	services := newStringSet(maxServiceNames)
+	traceIDs := newStringSet(maxTraceIDs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		services.addServiceName(rls.At(i).Resource())
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len() && !traceIDs.full(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len() && !traceIDs.full(); k++ {
+				traceIDs.addTraceID(records.At(k).TraceID())
+			}
+		}
+	}
+	l.log(reason, skipped,
+		zap.Int("log_records", ld.LogRecordCount()),
+		zap.Strings("service_names", services.values),
+		zap.Strings("trace_ids", traceIDs.values))
+}
+
+// Items logs the number of dropped items, if sampled, for the data that cannot be summarized.
+func (l *Logger) Items(reason string, items int) {
+	ok, skipped := l.sample()
+	if !ok {
+		return
+	}
+	l.log(reason, skipped, zap.Int("items", items))
+}
+
+// stringSet keeps the first distinct values added, up to its capacity.
+type stringSet struct {
+	capacity int
+	seen     map[string]struct{}
+	values   []string
+}
+
+func newStringSet(capacity int) *stringSet {
+	return &stringSet{capacity: capacity, seen: map[string]struct{}{}, values: []string{}}
+}
+
+func (s *stringSet) full() bool {
+	return len(s.values) >= s.capacity
+}
+
+func (s *stringSet) add(v string) {
+	if s.full() {
+		return
+	}
+	if _, ok := s.seen[v]; ok {
+		return
+	}
+	s.seen[v] = struct{}{}
+	s.values = append(s.values, v)
+}
+
+func (s *stringSet) addServiceName(res pcommon.Resource) {
+	if v, ok := res.Attributes().Get(serviceNameKey); ok {
+		s.add(v.AsString())
+	}
+}
+
+func (s *stringSet) addTraceID(id pcommon.TraceID) {
+	if !id.IsEmpty() {
+		s.add(id.String())
+	}
+}
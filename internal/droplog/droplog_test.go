@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package droplog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestLogger(level zapcore.Level) (*Logger, *observer.ObservedLogs, *time.Time) {
+	core, logs := observer.New(level)
+	l := New(zap.New(core))
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	return l, logs, &now
+}
+
+func generateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for i, service := range []string{"frontend", "backend", "frontend"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for j := 0; j < 2; j++ {
+			spans.AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{byte(i + 1)}))
+		}
+	}
+	return td
+}
+
+func TestTraces(t *testing.T) {
+	l, logs, _ := newTestLogger(zapcore.DebugLevel)
+	l.Traces("sending queue is full", generateTraces())
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Dropped data.", entry.Message)
+	assert.Equal(t, map[string]any{
+		"reason":            "sending queue is full",
+		"skipped_summaries": int64(0),
+		"spans":             int64(6),
+		"service_names":     []any{"frontend", "backend"},
+		"trace_ids": []any{
+			"01000000000000000000000000000000",
+			"02000000000000000000000000000000",
+			"03000000000000000000000000000000",
+		},
+	}, entry.ContextMap())
+}
+
+func TestMetrics(t *testing.T) {
+	l, logs, _ := newTestLogger(zapcore.DebugLevel)
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "backend")
+	for _, name := range []string{"requests", "latency", "requests"} {
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName(name)
+		m.SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+	l.Metrics("memory limit reached", md)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{
+		"reason":            "memory limit reached",
+		"skipped_summaries": int64(0),
+		"data_points":       int64(3),
+		"service_names":     []any{"backend"},
+		"metric_names":      []any{"requests", "latency"},
+	}, logs.All()[0].ContextMap())
+}
+
+func TestLogs(t *testing.T) {
+	l, logs, _ := newTestLogger(zapcore.DebugLevel)
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty()
+	records.AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{1}))
+	l.Logs("export failed", ld)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{
+		"reason":            "export failed",
+		"skipped_summaries": int64(0),
+		"log_records":       int64(2),
+		"service_names":     []any{},
+		"trace_ids":         []any{"01000000000000000000000000000000"},
+	}, logs.All()[0].ContextMap())
+}
+
+func TestSampling(t *testing.T) {
+	l, logs, now := newTestLogger(zapcore.DebugLevel)
+	l.Items("export failed", 1)
+	l.Items("export failed", 2)
+	l.Items("export failed", 3)
+	require.Equal(t, 1, logs.Len())
+
+	*now = now.Add(time.Second)
+	l.Items("export failed", 4)
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, map[string]any{
+		"reason":            "export failed",
+		"skipped_summaries": int64(2),
+		"items":             int64(4),
+	}, logs.All()[1].ContextMap())
+}
+
+func TestDebugDisabled(t *testing.T) {
+	l, logs, _ := newTestLogger(zapcore.InfoLevel)
+	l.Traces("export failed", generateTraces())
+	assert.Equal(t, 0, logs.Len())
+
+	var nilLogger *Logger
+	nilLogger.Items("export failed", 1)
+}
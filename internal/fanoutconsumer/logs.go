@@ -26,6 +26,9 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 
 	lc := &logsConsumer{}
 	for i := 0; i < len(lcs); i++ {
+		if lcs[i].Capabilities().ReturnsResponse {
+			lc.returnsResponse = true
+		}
 		if lcs[i].Capabilities().MutatesData {
 			lc.mutable = append(lc.mutable, lcs[i])
 		} else {
@@ -38,30 +41,59 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 type logsConsumer struct {
 	mutable  []consumer.Logs
 	readonly []consumer.Logs
+	// returnsResponse is set if one of the consumers returns the Response of the consumption.
+	returnsResponse bool
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
 	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(lsc.mutable) > 0 && len(lsc.readonly) == 0}
+	return consumer.Capabilities{
+		MutatesData:     len(lsc.mutable) > 0 && len(lsc.readonly) == 0,
+		ReturnsResponse: lsc.returnsResponse,
+	}
 }
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return lsc.consume(ctx, ld, func(ctx context.Context, lc consumer.Logs, ld plog.Logs) error {
+		return lc.ConsumeLogs(ctx, ld)
+	})
+}
+
+// ConsumeLogsWithResponse exports the plog.Logs to all consumers wrapped by the current one, and returns
+// the Response of the consumer accepting the fewest log records: the log records are only accepted if all the
+// consumers accepted them.
+func (lsc *logsConsumer) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	// The items are counted before being consumed, as the consumers may modify the data.
+	rsp := consumer.Response{Accepted: ld.LogRecordCount()}
+	err := lsc.consume(ctx, ld, func(ctx context.Context, lc consumer.Logs, ld plog.Logs) error {
+		lcRsp, err := consumer.ConsumeLogsWithResponse(ctx, lc, ld)
+		rsp.Accepted = min(rsp.Accepted, lcRsp.Accepted)
+		if lcRsp.Rejected > rsp.Rejected {
+			rsp.Rejected = lcRsp.Rejected
+			rsp.ErrorMessage = lcRsp.ErrorMessage
+		}
+		return err
+	})
+	return rsp, err
+}
+
+func (lsc *logsConsumer) consume(ctx context.Context, ld plog.Logs, consume func(context.Context, consumer.Logs, plog.Logs) error) error {
 	var errs error
 
 	if len(lsc.mutable) > 0 {
 		// Clone the data before sending to all mutating consumers except the last one.
 		for i := 0; i < len(lsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, lsc.mutable[i].ConsumeLogs(ctx, cloneLogs(ld)))
+			errs = multierr.Append(errs, consume(ctx, lsc.mutable[i], cloneLogs(ld)))
 		}
 		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
 		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
 		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
 		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
 		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, ld))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, ld))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, cloneLogs(ld)))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, cloneLogs(ld)))
 		}
 	}
 
@@ -70,7 +102,7 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		ld.MarkReadOnly()
 	}
 	for _, lc := range lsc.readonly {
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+		errs = multierr.Append(errs, consume(ctx, lc, ld))
 	}
 
 	return errs
@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -245,3 +247,28 @@ type mutatingErr struct {
 func (mts mutatingErr) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+func TestLogsWithResponse(t *testing.T) {
+	ld := testdata.GenerateLogs(2)
+	items := ld.LogRecordCount()
+	p1 := new(consumertest.LogsSink)
+	p2, err := consumer.NewLogsWithResponse(func(context.Context, plog.Logs) (consumer.Response, error) {
+		return consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, NewLogs([]consumer.Logs{p1, p1}).Capabilities().ReturnsResponse)
+
+	// The items are only accepted if all the consumers accepted them.
+	fc := NewLogs([]consumer.Logs{p1, p2})
+	assert.True(t, fc.Capabilities().ReturnsResponse)
+	rsp, err := consumer.ConsumeLogsWithResponse(context.Background(), fc, ld)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, rsp)
+	assert.Len(t, p1.AllLogs(), 1)
+
+	// The data is not accepted if one of the consumers failed.
+	fc = NewLogs([]consumer.Logs{p2, consumertest.NewErr(errors.New("my error"))})
+	rsp, err = consumer.ConsumeLogsWithResponse(context.Background(), fc, ld)
+	assert.Error(t, err)
+	assert.Equal(t, consumer.Response{Rejected: 1, ErrorMessage: "rejected"}, rsp)
+}
@@ -24,6 +24,9 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 
 	mc := &metricsConsumer{}
 	for i := 0; i < len(mcs); i++ {
+		if mcs[i].Capabilities().ReturnsResponse {
+			mc.returnsResponse = true
+		}
 		if mcs[i].Capabilities().MutatesData {
 			mc.mutable = append(mc.mutable, mcs[i])
 		} else {
@@ -36,30 +39,59 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 type metricsConsumer struct {
 	mutable  []consumer.Metrics
 	readonly []consumer.Metrics
+	// returnsResponse is set if one of the consumers returns the Response of the consumption.
+	returnsResponse bool
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
 	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(msc.mutable) > 0 && len(msc.readonly) == 0}
+	return consumer.Capabilities{
+		MutatesData:     len(msc.mutable) > 0 && len(msc.readonly) == 0,
+		ReturnsResponse: msc.returnsResponse,
+	}
 }
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return msc.consume(ctx, md, func(ctx context.Context, mc consumer.Metrics, md pmetric.Metrics) error {
+		return mc.ConsumeMetrics(ctx, md)
+	})
+}
+
+// ConsumeMetricsWithResponse exports the pmetric.Metrics to all consumers wrapped by the current one, and returns
+// the Response of the consumer accepting the fewest data points: the data points are only accepted if all the
+// consumers accepted them.
+func (msc *metricsConsumer) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	// The items are counted before being consumed, as the consumers may modify the data.
+	rsp := consumer.Response{Accepted: md.DataPointCount()}
+	err := msc.consume(ctx, md, func(ctx context.Context, mc consumer.Metrics, md pmetric.Metrics) error {
+		mcRsp, err := consumer.ConsumeMetricsWithResponse(ctx, mc, md)
+		rsp.Accepted = min(rsp.Accepted, mcRsp.Accepted)
+		if mcRsp.Rejected > rsp.Rejected {
+			rsp.Rejected = mcRsp.Rejected
+			rsp.ErrorMessage = mcRsp.ErrorMessage
+		}
+		return err
+	})
+	return rsp, err
+}
+
+func (msc *metricsConsumer) consume(ctx context.Context, md pmetric.Metrics, consume func(context.Context, consumer.Metrics, pmetric.Metrics) error) error {
 	var errs error
 
 	if len(msc.mutable) > 0 {
 		// Clone the data before sending to all mutating consumers except the last one.
 		for i := 0; i < len(msc.mutable)-1; i++ {
-			errs = multierr.Append(errs, msc.mutable[i].ConsumeMetrics(ctx, cloneMetrics(md)))
+			errs = multierr.Append(errs, consume(ctx, msc.mutable[i], cloneMetrics(md)))
 		}
 		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
 		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
 		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
 		lastConsumer := msc.mutable[len(msc.mutable)-1]
 		if len(msc.readonly) == 0 && !md.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, md))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, md))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, cloneMetrics(md)))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, cloneMetrics(md)))
 		}
 	}
 
@@ -68,7 +100,7 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		md.MarkReadOnly()
 	}
 	for _, mc := range msc.readonly {
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
+		errs = multierr.Append(errs, consume(ctx, mc, md))
 	}
 
 	return errs
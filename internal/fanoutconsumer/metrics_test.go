@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -237,3 +239,28 @@ type mutatingMetricsSink struct {
 func (mts *mutatingMetricsSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+func TestMetricsWithResponse(t *testing.T) {
+	md := testdata.GenerateMetrics(2)
+	items := md.DataPointCount()
+	p1 := new(consumertest.MetricsSink)
+	p2, err := consumer.NewMetricsWithResponse(func(context.Context, pmetric.Metrics) (consumer.Response, error) {
+		return consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, NewMetrics([]consumer.Metrics{p1, p1}).Capabilities().ReturnsResponse)
+
+	// The items are only accepted if all the consumers accepted them.
+	fc := NewMetrics([]consumer.Metrics{p1, p2})
+	assert.True(t, fc.Capabilities().ReturnsResponse)
+	rsp, err := consumer.ConsumeMetricsWithResponse(context.Background(), fc, md)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, rsp)
+	assert.Len(t, p1.AllMetrics(), 1)
+
+	// The data is not accepted if one of the consumers failed.
+	fc = NewMetrics([]consumer.Metrics{p2, consumertest.NewErr(errors.New("my error"))})
+	rsp, err = consumer.ConsumeMetricsWithResponse(context.Background(), fc, md)
+	assert.Error(t, err)
+	assert.Equal(t, consumer.Response{Rejected: 1, ErrorMessage: "rejected"}, rsp)
+}
@@ -24,6 +24,9 @@ func NewTraces(tcs []consumer.Traces) consumer.Traces {
 
 	tc := &tracesConsumer{}
 	for i := 0; i < len(tcs); i++ {
+		if tcs[i].Capabilities().ReturnsResponse {
+			tc.returnsResponse = true
+		}
 		if tcs[i].Capabilities().MutatesData {
 			tc.mutable = append(tc.mutable, tcs[i])
 		} else {
@@ -36,30 +39,59 @@ func NewTraces(tcs []consumer.Traces) consumer.Traces {
 type tracesConsumer struct {
 	mutable  []consumer.Traces
 	readonly []consumer.Traces
+	// returnsResponse is set if one of the consumers returns the Response of the consumption.
+	returnsResponse bool
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
 	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
+	return consumer.Capabilities{
+		MutatesData:     len(tsc.mutable) > 0 && len(tsc.readonly) == 0,
+		ReturnsResponse: tsc.returnsResponse,
+	}
 }
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return tsc.consume(ctx, td, func(ctx context.Context, tc consumer.Traces, td ptrace.Traces) error {
+		return tc.ConsumeTraces(ctx, td)
+	})
+}
+
+// ConsumeTracesWithResponse exports the ptrace.Traces to all consumers wrapped by the current one, and returns
+// the Response of the consumer accepting the fewest spans: the spans are only accepted if all the
+// consumers accepted them.
+func (tsc *tracesConsumer) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	// The items are counted before being consumed, as the consumers may modify the data.
+	rsp := consumer.Response{Accepted: td.SpanCount()}
+	err := tsc.consume(ctx, td, func(ctx context.Context, tc consumer.Traces, td ptrace.Traces) error {
+		tcRsp, err := consumer.ConsumeTracesWithResponse(ctx, tc, td)
+		rsp.Accepted = min(rsp.Accepted, tcRsp.Accepted)
+		if tcRsp.Rejected > rsp.Rejected {
+			rsp.Rejected = tcRsp.Rejected
+			rsp.ErrorMessage = tcRsp.ErrorMessage
+		}
+		return err
+	})
+	return rsp, err
+}
+
+func (tsc *tracesConsumer) consume(ctx context.Context, td ptrace.Traces, consume func(context.Context, consumer.Traces, ptrace.Traces) error) error {
 	var errs error
 
 	if len(tsc.mutable) > 0 {
 		// Clone the data before sending to all mutating consumers except the last one.
 		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeTraces(ctx, cloneTraces(td)))
+			errs = multierr.Append(errs, consume(ctx, tsc.mutable[i], cloneTraces(td)))
 		}
 		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
 		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
 		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
 		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
 		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, td))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, cloneTraces(td)))
+			errs = multierr.Append(errs, consume(ctx, lastConsumer, cloneTraces(td)))
 		}
 	}
 
@@ -68,7 +100,7 @@ func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 		td.MarkReadOnly()
 	}
 	for _, tc := range tsc.readonly {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
+		errs = multierr.Append(errs, consume(ctx, tc, td))
 	}
 
 	return errs
@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -238,3 +240,28 @@ type mutatingTracesSink struct {
 func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+func TestTracesWithResponse(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	items := td.SpanCount()
+	p1 := new(consumertest.TracesSink)
+	p2, err := consumer.NewTracesWithResponse(func(context.Context, ptrace.Traces) (consumer.Response, error) {
+		return consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, NewTraces([]consumer.Traces{p1, p1}).Capabilities().ReturnsResponse)
+
+	// The items are only accepted if all the consumers accepted them.
+	fc := NewTraces([]consumer.Traces{p1, p2})
+	assert.True(t, fc.Capabilities().ReturnsResponse)
+	rsp, err := consumer.ConsumeTracesWithResponse(context.Background(), fc, td)
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Response{Accepted: items - 1, Rejected: 1, ErrorMessage: "rejected"}, rsp)
+	assert.Len(t, p1.AllTraces(), 1)
+
+	// The data is not accepted if one of the consumers failed.
+	fc = NewTraces([]consumer.Traces{p2, consumertest.NewErr(errors.New("my error"))})
+	rsp, err = consumer.ConsumeTracesWithResponse(context.Background(), fc, td)
+	assert.Error(t, err)
+	assert.Equal(t, consumer.Response{Rejected: 1, ErrorMessage: "rejected"}, rsp)
+}
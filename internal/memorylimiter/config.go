@@ -8,12 +8,15 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighumanize"
 )
 
 var (
 	errCheckIntervalOutOfRange        = errors.New("'check_interval' must be greater than zero")
-	errLimitOutOfRange                = errors.New("'limit_mib' or 'limit_percentage' must be greater than zero")
-	errSpikeLimitOutOfRange           = errors.New("'spike_limit_mib' must be smaller than 'limit_mib'")
+	errLimitOutOfRange                = errors.New("'limit', 'limit_mib' or 'limit_percentage' must be greater than zero")
+	errSpikeLimitOutOfRange           = errors.New("'spike_limit' or 'spike_limit_mib' must be smaller than 'limit' or 'limit_mib'")
+	errLimitSetTwice                  = errors.New("'limit' and 'limit_mib' cannot be both set")
+	errSpikeLimitSetTwice             = errors.New("'spike_limit' and 'spike_limit_mib' cannot be both set")
 	errSpikeLimitPercentageOutOfRange = errors.New("'spike_limit_percentage' must be smaller than 'limit_percentage'")
 	errLimitPercentageOutOfRange      = errors.New(
 		"'limit_percentage' and 'spike_limit_percentage' must be greater than zero and less than or equal to hundred")
@@ -26,6 +29,14 @@ type Config struct {
 	// checks will be performed.
 	CheckInterval time.Duration `mapstructure:"check_interval"`
 
+	// MemoryLimit is the maximum amount of memory targeted to be allocated
+	// by the process, e.g. "4GiB". It cannot be set with MemoryLimitMiB.
+	MemoryLimit confighumanize.Size `mapstructure:"limit"`
+
+	// MemorySpikeLimit is the maximum spike expected between the measurements
+	// of memory usage, e.g. "800MiB". It cannot be set with MemorySpikeLimitMiB.
+	MemorySpikeLimit confighumanize.Size `mapstructure:"spike_limit"`
+
 	// MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be
 	// allocated by the process.
 	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
@@ -35,7 +46,7 @@ type Config struct {
 	MemorySpikeLimitMiB uint32 `mapstructure:"spike_limit_mib"`
 
 	// MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be
-	// allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.
+	// allocated by the process. The fixed memory settings MemoryLimit and MemoryLimitMiB have a higher precedence.
 	MemoryLimitPercentage uint32 `mapstructure:"limit_percentage"`
 
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
@@ -50,13 +61,19 @@ func (cfg *Config) Validate() error {
 	if cfg.CheckInterval <= 0 {
 		return errCheckIntervalOutOfRange
 	}
-	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+	if cfg.MemoryLimit > 0 && cfg.MemoryLimitMiB > 0 {
+		return errLimitSetTwice
+	}
+	if cfg.MemorySpikeLimit > 0 && cfg.MemorySpikeLimitMiB > 0 {
+		return errSpikeLimitSetTwice
+	}
+	if cfg.memoryLimit() == 0 && cfg.MemoryLimitPercentage == 0 {
 		return errLimitOutOfRange
 	}
 	if cfg.MemoryLimitPercentage > 100 || cfg.MemorySpikePercentage > 100 {
 		return errLimitPercentageOutOfRange
 	}
-	if cfg.MemoryLimitMiB > 0 && cfg.MemoryLimitMiB <= cfg.MemorySpikeLimitMiB {
+	if cfg.memoryLimit() > 0 && cfg.memoryLimit() <= cfg.memorySpikeLimit() {
 		return errSpikeLimitOutOfRange
 	}
 	if cfg.MemoryLimitPercentage > 0 && cfg.MemoryLimitPercentage <= cfg.MemorySpikePercentage {
@@ -64,3 +81,19 @@ func (cfg *Config) Validate() error {
 	}
 	return nil
 }
+
+// memoryLimit returns the fixed memory limit in bytes, set either by MemoryLimit or MemoryLimitMiB.
+func (cfg *Config) memoryLimit() uint64 {
+	if cfg.MemoryLimit > 0 {
+		return uint64(cfg.MemoryLimit)
+	}
+	return uint64(cfg.MemoryLimitMiB) * mibBytes
+}
+
+// memorySpikeLimit returns the fixed spike limit in bytes, set either by MemorySpikeLimit or MemorySpikeLimitMiB.
+func (cfg *Config) memorySpikeLimit() uint64 {
+	if cfg.MemorySpikeLimit > 0 {
+		return uint64(cfg.MemorySpikeLimit)
+	}
+	return uint64(cfg.MemorySpikeLimitMiB) * mibBytes
+}
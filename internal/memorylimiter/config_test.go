@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -68,6 +69,43 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: errSpikeLimitOutOfRange,
 		},
+		{
+			name: "valid memory size limit",
+			cfg: &Config{
+				CheckInterval:       1 * time.Second,
+				MemoryLimit:         4 * confighumanize.Gibibyte,
+				MemorySpikeLimitMiB: 800,
+			},
+			err: nil,
+		},
+		{
+			name: "invalid memory spike size limit",
+			cfg: &Config{
+				CheckInterval:    1 * time.Second,
+				MemoryLimitMiB:   1024,
+				MemorySpikeLimit: confighumanize.Gibibyte,
+			},
+			err: errSpikeLimitOutOfRange,
+		},
+		{
+			name: "memory limit set twice",
+			cfg: &Config{
+				CheckInterval:  1 * time.Second,
+				MemoryLimit:    4 * confighumanize.Gibibyte,
+				MemoryLimitMiB: 4096,
+			},
+			err: errLimitSetTwice,
+		},
+		{
+			name: "memory spike limit set twice",
+			cfg: &Config{
+				CheckInterval:       1 * time.Second,
+				MemoryLimit:         4 * confighumanize.Gibibyte,
+				MemorySpikeLimit:    confighumanize.Gibibyte,
+				MemorySpikeLimitMiB: 1024,
+			},
+			err: errSpikeLimitSetTwice,
+		},
 		{
 			name: "invalid memory percentage limit",
 			cfg: &Config{
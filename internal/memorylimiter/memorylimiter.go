@@ -148,14 +148,12 @@ func (ml *MemoryLimiter) MustRefuse() bool {
 }
 
 func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
-	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
-	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
-	if cfg.MemoryLimitMiB != 0 {
-		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit), nil
+	if memAllocLimit := cfg.memoryLimit(); memAllocLimit != 0 {
+		return newFixedMemUsageChecker(memAllocLimit, cfg.memorySpikeLimit()), nil
 	}
 	totalMemory, err := GetMemoryFn()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
+		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit or limit_mib): %w", err)
 	}
 	logger.Info("Using percentage memory limiter",
 		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
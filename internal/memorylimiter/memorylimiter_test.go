@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/internal/iruntime"
 )
 
@@ -83,6 +84,15 @@ func TestGetDecision(t *testing.T) {
 		}, d)
 	})
 
+	t.Run("fixed_size_limit", func(t *testing.T) {
+		d, err := getMemUsageChecker(&Config{MemoryLimit: 100 * confighumanize.Mebibyte, MemorySpikeLimitMiB: 20}, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, &memUsageChecker{
+			memAllocLimit: 100 * mibBytes,
+			memSpikeLimit: 20 * mibBytes,
+		}, d)
+	})
+
 	t.Cleanup(func() {
 		GetMemoryFn = iruntime.TotalMemory
 	})
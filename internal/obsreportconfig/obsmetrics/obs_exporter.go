@@ -13,6 +13,8 @@ const (
 	FailedToSendSpansKey = "send_failed_spans"
 	// FailedToEnqueueSpansKey used to track spans that failed to be enqueued by exporters.
 	FailedToEnqueueSpansKey = "enqueue_failed_spans"
+	// DuplicateSpansKey used to track spans exported more than once by exporters.
+	DuplicateSpansKey = "duplicate_spans"
 
 	// SentMetricPointsKey used to track metric points sent by exporters.
 	SentMetricPointsKey = "sent_metric_points"
@@ -20,6 +22,8 @@ const (
 	FailedToSendMetricPointsKey = "send_failed_metric_points"
 	// FailedToEnqueueMetricPointsKey used to track metric points that failed to be enqueued by exporters.
 	FailedToEnqueueMetricPointsKey = "enqueue_failed_metric_points"
+	// DuplicateMetricPointsKey used to track metric points exported more than once by exporters.
+	DuplicateMetricPointsKey = "duplicate_metric_points"
 
 	// SentLogRecordsKey used to track logs sent by exporters.
 	SentLogRecordsKey = "sent_log_records"
@@ -27,6 +31,14 @@ const (
 	FailedToSendLogRecordsKey = "send_failed_log_records"
 	// FailedToEnqueueLogRecordsKey used to track logs that failed to be enqueued by exporters.
 	FailedToEnqueueLogRecordsKey = "enqueue_failed_log_records"
+	// DuplicateLogRecordsKey used to track logs exported more than once by exporters.
+	DuplicateLogRecordsKey = "duplicate_log_records"
+
+	// SendLatencyKey used to track the duration of the export operations of exporters.
+	SendLatencyKey = "send_latency"
+
+	// FailedToSendRequestsKey used to track the requests that failed to be sent by exporters, by error category.
+	FailedToSendRequestsKey = "send_failed_requests"
 )
 
 var (
@@ -6,6 +6,8 @@ package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportco
 const (
 	// ProcessorKey is the key used to identify processors in metrics and traces.
 	ProcessorKey = "processor"
+	// PipelineKey is the key used to identify the pipeline of the processors in metrics and traces.
+	PipelineKey = "pipeline"
 
 	// DroppedSpansKey is the key used to identify spans dropped by the Collector.
 	DroppedSpansKey = "dropped_spans"
@@ -27,6 +27,9 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// RefusedRequestsKey used to identify the requests refused by the Collector, by error category.
+	RefusedRequestsKey = "refused_requests"
 )
 
 var (
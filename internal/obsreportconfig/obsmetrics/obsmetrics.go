@@ -10,4 +10,7 @@ const (
 	SpanNameSep   = "/"
 	MetricNameSep = "_"
 	Scope         = "go.opentelemetry.io/collector/obsreport"
+
+	// ErrorCategoryKey used to identify the category of the errors, see consumererror.Classify.
+	ErrorCategoryKey = "error_category"
 )
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package zpagesregistry serves the zPages of the components with the zpages extension.
+package zpagesregistry // import "go.opentelemetry.io/collector/internal/zpagesregistry"
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Registry is implemented by the zpages extension to serve the zPages of the components.
+type Registry interface {
+	RegisterZPage(name string, handler http.Handler) error
+}
+
+// Register serves the handler at the zPage of the given name, it returns false if the zpages extension is not enabled.
+func Register(host component.Host, name string, handler http.Handler) (bool, error) {
+	for _, ext := range host.GetExtensions() {
+		if registry, ok := ext.(Registry); ok {
+			return true, registry.RegisterZPage(name, handler)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zpagesregistry
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type fakeZPagesExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	pages map[string]http.Handler
+	err   error
+}
+
+func (e *fakeZPagesExtension) RegisterZPage(name string, handler http.Handler) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.pages[name] = handler
+	return nil
+}
+
+type zPagesHost struct {
+	component.Host
+	ext component.Component
+}
+
+func (h *zPagesHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{component.MustNewID("zpages"): h.ext}
+}
+
+func TestRegister(t *testing.T) {
+	handler := http.NotFoundHandler()
+	ok, err := Register(componenttest.NewNopHost(), "capturez/otlp", handler)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ext := &fakeZPagesExtension{pages: map[string]http.Handler{}}
+	ok, err = Register(&zPagesHost{Host: componenttest.NewNopHost(), ext: ext}, "capturez/otlp", handler)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, ext.pages, "capturez/otlp")
+
+	errRegister := errors.New("already registered")
+	ok, err = Register(&zPagesHost{Host: componenttest.NewNopHost(), ext: &fakeZPagesExtension{err: errRegister}}, "capturez/otlp", handler)
+	assert.ErrorIs(t, err, errRegister)
+	assert.True(t, ok)
+}
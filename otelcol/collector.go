@@ -183,10 +183,16 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	var origins []confmap.KeyOrigin
+	if op, ok := col.configProvider.(confmapOriginsProvider); ok {
+		origins = withDefaultOrigins(op.ConfmapOrigins(), cfg)
+	}
+
 	col.serviceConfig = &cfg.Service
 	col.service, err = service.New(ctx, service.Settings{
 		BuildInfo:         col.set.BuildInfo,
 		CollectorConf:     conf,
+		ConfigOrigins:     origins,
 		Receivers:         receiver.NewBuilder(cfg.Receivers, factories.Receivers),
 		Processors:        processor.NewBuilder(cfg.Processors, factories.Processors),
 		Exporters:         exporter.NewBuilder(cfg.Exporters, factories.Exporters),
@@ -215,12 +221,22 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	col.service.Logger().Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
+	// Hand the data queued by the retiring exporters over to their new instances, so it is sent according
+	// to the new configuration. The retiring exporters send the data that was not taken over, whether the
+	// new configuration started or not.
+	handover := exporter.NewQueueHandover()
+	ctx = exporter.ContextWithQueueHandover(ctx, handover)
+
 	if err := col.service.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
+		return multierr.Append(fmt.Errorf("failed to shutdown the retiring config: %w", err), handover.Flush(ctx))
 	}
 
 	if err := col.setupConfigurationComponents(ctx); err != nil {
-		return fmt.Errorf("failed to setup configuration components: %w", err)
+		return multierr.Append(fmt.Errorf("failed to setup configuration components: %w", err), handover.Flush(ctx))
+	}
+
+	if err := handover.Flush(ctx); err != nil {
+		col.service.Logger().Error("Failed to shutdown the retiring exporters", zap.Error(err))
 	}
 
 	return nil
@@ -338,3 +354,14 @@ func (col *Collector) validatePipelineCfg(ctx context.Context, cfg *Config, fact
 
 	return nil
 }
+
+// withDefaultOrigins completes the origins of the resolved configuration with the keys taking the default
+// values of the components, found by marshaling the unmarshalled configuration. The origins are returned
+// unchanged if the configuration cannot be marshaled.
+func withDefaultOrigins(origins []confmap.KeyOrigin, cfg *Config) []confmap.KeyOrigin {
+	effective := confmap.New()
+	if err := effective.Marshal(cfg); err != nil {
+		return origins
+	}
+	return confmap.AddDefaultOrigins(origins, effective)
+}
@@ -18,6 +18,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -503,3 +505,27 @@ func (*failureProvider) Scheme() string {
 func (*failureProvider) Shutdown(context.Context) error {
 	return nil
 }
+
+func TestWithDefaultOrigins(t *testing.T) {
+	provider := fileprovider.NewWithSettings(confmaptest.NewNopProviderSettings())
+	cp, err := NewConfigProvider(ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:      []string{"file:" + filepath.Join("testdata", "otelcol-nop.yaml")},
+			Providers: map[string]confmap.Provider{provider.Scheme(): provider},
+		},
+	})
+	require.NoError(t, err)
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	cfg, err := cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+
+	origins := withDefaultOrigins(cp.(confmapOriginsProvider).ConfmapOrigins(), cfg)
+	byKey := map[string]confmap.KeyOrigin{}
+	for _, o := range origins {
+		byKey[o.Key] = o
+	}
+	assert.Equal(t, "file:"+filepath.Join("testdata", "otelcol-nop.yaml"), byKey["service::telemetry::metrics::address"].URI)
+	assert.False(t, byKey["service::telemetry::metrics::address"].Default)
+	assert.Equal(t, confmap.KeyOrigin{Key: "service::telemetry::logs::level", Default: true}, byKey["service::telemetry::logs::level"])
+}
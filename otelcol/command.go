@@ -6,6 +6,7 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"flag"
+	"runtime/debug"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +18,11 @@ import (
 // are considered defaults and will be overwritten by config flags passed as
 // command-line arguments to the executable.
 func NewCommand(set CollectorSettings) *cobra.Command {
+	// Refuse the feature gates past their removal version. The gates are tagged with the versions of the core
+	// modules, not of the distribution, and the version is unknown in development builds.
+	if v, ok := coreVersion(); ok {
+		_ = featuregate.GlobalRegistry().SetVersion(v)
+	}
 	flagSet := flags(featuregate.GlobalRegistry())
 	rootCmd := &cobra.Command{
 		Use:          set.BuildInfo.Command,
@@ -41,6 +47,41 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	return rootCmd
 }
 
+// otelcolModule is the path of this module, released with the other core modules.
+const otelcolModule = "go.opentelemetry.io/collector/otelcol"
+
+// readBuildInfo reads the build information of the binary, overridden in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// coreVersion returns the version of the core modules the collector is built with, or false if it is unknown,
+// e.g. when the module is replaced by a local checkout.
+func coreVersion() (string, bool) {
+	info, ok := readBuildInfo()
+	if !ok {
+		return "", false
+	}
+	mod := &info.Main
+	if mod.Path != otelcolModule {
+		mod = nil
+		for _, dep := range info.Deps {
+			if dep.Path == otelcolModule {
+				mod = dep
+				break
+			}
+		}
+	}
+	if mod == nil {
+		return "", false
+	}
+	if mod.Replace != nil {
+		mod = mod.Replace
+	}
+	if mod.Version == "" || mod.Version == "(devel)" {
+		return "", false
+	}
+	return mod.Version, true
+}
+
 func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error {
 	if set.ConfigProvider == nil {
 		resolverSet := &set.ConfigProviderSettings.ResolverSettings
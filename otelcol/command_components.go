@@ -4,8 +4,10 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -18,28 +20,46 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 )
 
+const (
+	componentsFormatYAML = "yaml"
+	componentsFormatJSON = "json"
+)
+
 type componentWithStability struct {
-	Name      component.Type
-	Stability map[string]string
+	Name      component.Type    `json:"name"`
+	Stability map[string]string `json:"stability"`
+	// ConfigSchema holds the JSON schema of the configuration of the component, only set if requested.
+	ConfigSchema map[string]any `json:"config_schema,omitempty" yaml:"config_schema,omitempty"`
+}
+
+type buildInfoOutput struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
 }
 
 type componentsOutput struct {
-	BuildInfo  component.BuildInfo
-	Receivers  []componentWithStability
-	Processors []componentWithStability
-	Exporters  []componentWithStability
-	Connectors []componentWithStability
-	Extensions []componentWithStability
+	BuildInfo  buildInfoOutput          `json:"buildinfo"`
+	Receivers  []componentWithStability `json:"receivers"`
+	Processors []componentWithStability `json:"processors"`
+	Exporters  []componentWithStability `json:"exporters"`
+	Connectors []componentWithStability `json:"connectors"`
+	Extensions []componentWithStability `json:"extensions"`
 }
 
 // newComponentsCommand constructs a new components command using the given CollectorSettings.
 func newComponentsCommand(set CollectorSettings) *cobra.Command {
-	return &cobra.Command{
+	var format string
+	var includeConfigSchema bool
+	cmd := &cobra.Command{
 		Use:   "components",
 		Short: "Outputs available components in this collector distribution",
-		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.",
+		Long:  "Outputs available components in this collector distribution including their stability levels and, optionally, the JSON schema of their configuration. The output format is not stable and can change between releases.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if format != componentsFormatYAML && format != componentsFormatJSON {
+				return fmt.Errorf("unsupported output format %q, must be one of %q or %q", format, componentsFormatYAML, componentsFormatJSON)
+			}
 
 			factories, err := set.Factories()
 			if err != nil {
@@ -103,15 +123,70 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 					},
 				})
 			}
-			components.BuildInfo = set.BuildInfo
-			yamlData, err := yaml.Marshal(components)
+			if includeConfigSchema {
+				if err = addConfigSchemas(&components, factories); err != nil {
+					return err
+				}
+			}
+			components.BuildInfo = buildInfoOutput{
+				Command:     set.BuildInfo.Command,
+				Description: set.BuildInfo.Description,
+				Version:     set.BuildInfo.Version,
+			}
+
+			var data []byte
+			if format == componentsFormatJSON {
+				data, err = json.MarshalIndent(components, "", "  ")
+				data = append(data, '\n')
+			} else {
+				data, err = yaml.Marshal(components)
+			}
 			if err != nil {
 				return err
 			}
-			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+			fmt.Fprint(cmd.OutOrStdout(), string(data))
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&format, "format", componentsFormatYAML, "Output format, one of \"yaml\" or \"json\"")
+	cmd.Flags().BoolVar(&includeConfigSchema, "include-config-schema", false,
+		"Include the JSON schema of the configuration of every component, with the default values")
+	return cmd
+}
+
+// addConfigSchemas sets the schema of the configuration of every component in the output.
+// The components in the output are expected to be sorted by type, see sortFactoriesByType.
+func addConfigSchemas(components *componentsOutput, factories Factories) error {
+	kinds := []struct {
+		kind       component.Kind
+		output     []componentWithStability
+		components []component.Factory
+	}{
+		{kind: component.KindReceiver, output: components.Receivers, components: toFactories(factories.Receivers)},
+		{kind: component.KindProcessor, output: components.Processors, components: toFactories(factories.Processors)},
+		{kind: component.KindExporter, output: components.Exporters, components: toFactories(factories.Exporters)},
+		{kind: component.KindConnector, output: components.Connectors, components: toFactories(factories.Connectors)},
+		{kind: component.KindExtension, output: components.Extensions, components: toFactories(factories.Extensions)},
+	}
+	for _, k := range kinds {
+		for i, f := range k.components {
+			schema, err := configSchema(f.CreateDefaultConfig())
+			if err != nil {
+				return fmt.Errorf("failed to generate the config schema of %s %q: %w", strings.ToLower(k.kind.String()), f.Type(), err)
+			}
+			k.output[i].ConfigSchema = schema
+		}
+	}
+	return nil
+}
+
+func toFactories[T component.Factory](factories map[component.Type]T) []component.Factory {
+	sorted := sortFactoriesByType[T](factories)
+	ret := make([]component.Factory, 0, len(sorted))
+	for _, f := range sorted {
+		ret = append(ret, f)
+	}
+	return ret
 }
 
 func sortFactoriesByType[T component.Factory](factories map[component.Type]T) []T {
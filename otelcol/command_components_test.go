@@ -5,15 +5,18 @@ package otelcol
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
 )
 
 func TestNewBuildSubCommand(t *testing.T) {
@@ -40,3 +43,82 @@ func TestNewBuildSubCommand(t *testing.T) {
 	// line that makes the test fail.
 	assert.Equal(t, strings.ReplaceAll(strings.ReplaceAll(string(ExpectedOutput), "\n", ""), "\r", ""), strings.ReplaceAll(strings.ReplaceAll(b.String(), "\n", ""), "\r", ""))
 }
+
+type schemaTestConfig struct {
+	SchemaTestServerConfig `mapstructure:",squash"`
+	Timeout                time.Duration     `mapstructure:"timeout"`
+	Headers                map[string]string `mapstructure:"headers"`
+	Exporters              []component.ID    `mapstructure:"exporters"`
+	Retries                *int              `mapstructure:"retries"`
+	Verbose                bool
+	internal               string
+}
+
+type SchemaTestServerConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+func TestComponentsSubCommandJSON(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) {
+			factories, err := nopFactories()
+			if err != nil {
+				return Factories{}, err
+			}
+			f := receiver.NewFactory(component.MustNewType("schema"), func() component.Config {
+				return &schemaTestConfig{
+					SchemaTestServerConfig: SchemaTestServerConfig{Endpoint: "localhost:4317"},
+					Timeout:                5 * time.Second,
+					internal:               "internal",
+				}
+			})
+			factories.Receivers[f.Type()] = f
+			return factories, nil
+		},
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"components", "--format", "json", "--include-config-schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &got))
+	assert.Equal(t, map[string]any{"command": "otelcol", "description": "OpenTelemetry Collector", "version": "latest"}, got["buildinfo"])
+	receivers := got["receivers"].([]any)
+	require.Len(t, receivers, 3)
+	assert.Equal(t, map[string]any{
+		"name":      "nop",
+		"stability": map[string]any{"logs": "Stable", "metrics": "Stable", "traces": "Stable"},
+		"config_schema": map[string]any{
+			"$schema":    "https://json-schema.org/draft/2020-12/schema",
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+	}, receivers[0])
+	assert.Equal(t, map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"endpoint":  map[string]any{"type": "string", "default": "localhost:4317"},
+			"timeout":   map[string]any{"type": "string", "format": "duration", "default": "5s"},
+			"headers":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"exporters": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"retries":   map[string]any{"type": "integer"},
+			"verbose":   map[string]any{"type": "boolean", "default": false},
+		},
+	}, receivers[2].(map[string]any)["config_schema"])
+}
+
+func TestComponentsSubCommandInvalidFormat(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"components", "--format", "xml"})
+	cmd.SetOut(bytes.NewBufferString(""))
+	assert.EqualError(t, cmd.Execute(), `unsupported output format "xml", must be one of "yaml" or "json"`)
+}
@@ -5,6 +5,7 @@ package otelcol
 
 import (
 	"path/filepath"
+	"runtime/debug"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -24,6 +25,46 @@ func TestNewCommandVersion(t *testing.T) {
 	assert.Equal(t, "test_version", cmd.Version)
 }
 
+func TestCoreVersion(t *testing.T) {
+	old := readBuildInfo
+	t.Cleanup(func() { readBuildInfo = old })
+	setBuildInfo := func(info *debug.BuildInfo, ok bool) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return info, ok }
+	}
+	distribution := debug.Module{Path: "example.com/otelcol-custom", Version: "v2.0.0"}
+
+	setBuildInfo(&debug.BuildInfo{Main: distribution, Deps: []*debug.Module{
+		{Path: "go.opentelemetry.io/collector/featuregate", Version: "v1.3.0"},
+		{Path: otelcolModule, Version: "v0.95.0"},
+	}}, true)
+	v, ok := coreVersion()
+	assert.True(t, ok)
+	assert.Equal(t, "v0.95.0", v)
+
+	setBuildInfo(&debug.BuildInfo{Main: distribution, Deps: []*debug.Module{
+		{Path: otelcolModule, Version: "v0.95.0", Replace: &debug.Module{Path: otelcolModule, Version: "v0.96.0"}},
+	}}, true)
+	v, ok = coreVersion()
+	assert.True(t, ok)
+	assert.Equal(t, "v0.96.0", v)
+
+	// The versions of the local checkouts and development builds are unknown.
+	setBuildInfo(&debug.BuildInfo{Main: distribution, Deps: []*debug.Module{
+		{Path: otelcolModule, Version: "v0.95.0", Replace: &debug.Module{Path: "../otelcol"}},
+	}}, true)
+	_, ok = coreVersion()
+	assert.False(t, ok)
+	setBuildInfo(&debug.BuildInfo{Main: debug.Module{Path: otelcolModule, Version: "(devel)"}}, true)
+	_, ok = coreVersion()
+	assert.False(t, ok)
+	setBuildInfo(&debug.BuildInfo{Main: distribution}, true)
+	_, ok = coreVersion()
+	assert.False(t, ok)
+	setBuildInfo(nil, false)
+	_, ok = coreVersion()
+	assert.False(t, ok)
+}
+
 func TestNewCommandNoConfigURI(t *testing.T) {
 	cmd := NewCommand(CollectorSettings{Factories: nopFactories})
 	require.Error(t, cmd.Execute())
@@ -81,7 +122,7 @@ func TestAddDefaultConfmapModules(t *testing.T) {
 	err = updateSettingsUsingFlags(&set, flgs)
 	require.NoError(t, err)
 	require.Len(t, set.ConfigProviderSettings.ResolverSettings.URIs, 1)
-	require.Len(t, set.ConfigProviderSettings.ResolverSettings.Converters, 1)
+	require.Len(t, set.ConfigProviderSettings.ResolverSettings.Converters, 2)
 	require.Len(t, set.ConfigProviderSettings.ResolverSettings.Providers, 5)
 }
 
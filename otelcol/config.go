@@ -152,6 +152,14 @@ func (cfg *Config) Validate() error {
 			}
 			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID, ref)
 		}
+
+		// Validate pipeline mirror exporter name reference, connectors cannot be mirrored to.
+		if pipeline.Mirror != nil {
+			if _, ok := cfg.Exporters[pipeline.Mirror.Exporter]; !ok {
+				return fmt.Errorf("service::pipelines::%s: references mirror exporter %q which is not configured", pipelineID, pipeline.Mirror.Exporter)
+			}
+		}
 	}
+
 	return nil
 }
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// configSchema returns the JSON schema of the configuration of a component, derived from the mapstructure
+// tags of its configuration struct. The values of the default configuration are set as the defaults of the
+// properties. The schema does not describe the custom unmarshaling of the configurations, if any.
+func configSchema(cfg component.Config) (map[string]any, error) {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return nil, err
+	}
+	s := typeSchema(reflect.TypeOf(cfg), conf.ToStringMap(), map[reflect.Type]bool{})
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	return s, nil
+}
+
+// typeSchema returns the schema of the given type, with the given default value. path holds the struct types
+// being described, a type referring to itself is described by the empty schema, allowing any value, from its
+// second occurrence on the path.
+func typeSchema(t reflect.Type, def any, path map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s := map[string]any{}
+	if path[t] {
+		return s
+	}
+	switch {
+	case t == durationType:
+		s["type"] = "string"
+		s["format"] = "duration"
+		if d, ok := def.(time.Duration); ok {
+			def = d.String()
+		} else if d, ok := def.(int64); ok {
+			def = time.Duration(d).String()
+		}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		s["type"] = "string"
+	default:
+		switch t.Kind() {
+		case reflect.Bool:
+			s["type"] = "boolean"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s["type"] = "integer"
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s["type"] = "integer"
+			s["minimum"] = 0
+		case reflect.Float32, reflect.Float64:
+			s["type"] = "number"
+		case reflect.String:
+			s["type"] = "string"
+		case reflect.Slice, reflect.Array:
+			s["type"] = "array"
+			s["items"] = typeSchema(t.Elem(), nil, path)
+		case reflect.Map:
+			s["type"] = "object"
+			s["additionalProperties"] = typeSchema(t.Elem(), nil, path)
+		case reflect.Struct:
+			s["type"] = "object"
+			defaults, _ := def.(map[string]any)
+			properties := map[string]any{}
+			path[t] = true
+			addProperties(properties, t, defaults, path)
+			delete(path, t)
+			s["properties"] = properties
+			// The defaults of the struct are set on its properties.
+			return s
+		}
+	}
+	if def != nil && !isEmpty(def) {
+		s["default"] = def
+	}
+	return s
+}
+
+// addProperties adds the schemas of the fields of the struct type to properties, the squashed
+// fields being added to the properties of the struct itself.
+func addProperties(properties map[string]any, t reflect.Type, defaults map[string]any, path map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.ToLower(field.Name)
+		squash := false
+		if tag, ok := field.Tag.Lookup("mapstructure"); ok {
+			options := strings.Split(tag, ",")
+			name = options[0]
+			for _, option := range options[1:] {
+				squash = squash || option == "squash" || option == "remain"
+			}
+		}
+		if name == "-" {
+			continue
+		}
+		if squash {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !path[ft] {
+				path[ft] = true
+				addProperties(properties, ft, defaults, path)
+				delete(path, ft)
+			}
+			continue
+		}
+		properties[name] = typeSchema(field.Type, defaults[name], path)
+	}
+}
+
+func isEmpty(v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recursiveConfig refers to itself directly, through a slice and through a map.
+type recursiveConfig struct {
+	Name     string                      `mapstructure:"name"`
+	Parent   *recursiveConfig            `mapstructure:"parent"`
+	Children []recursiveConfig           `mapstructure:"children"`
+	ByName   map[string]*recursiveConfig `mapstructure:"by_name"`
+}
+
+func TestConfigSchemaRecursive(t *testing.T) {
+	s, err := configSchema(&recursiveConfig{Name: "root"})
+	require.NoError(t, err)
+
+	// The type is described once, its occurrences in itself allow any value.
+	assert.Equal(t, map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string", "default": "root"},
+			"parent":   map[string]any{},
+			"children": map[string]any{"type": "array", "items": map[string]any{}},
+			"by_name":  map[string]any{"type": "object", "additionalProperties": map[string]any{}},
+		},
+	}, s)
+}
+
+type squashedRecursiveConfig struct {
+	*squashedRecursiveConfig `mapstructure:",squash"`
+	Endpoint                 string                 `mapstructure:"endpoint"`
+	Nested                   *recursiveNestedConfig `mapstructure:"nested"`
+}
+
+type recursiveNestedConfig struct {
+	Next *squashedRecursiveConfig `mapstructure:"next"`
+}
+
+func TestConfigSchemaRecursiveSquashed(t *testing.T) {
+	s, err := configSchema(&squashedRecursiveConfig{})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"endpoint": map[string]any{"type": "string", "default": ""},
+			"nested": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"next": map[string]any{},
+				},
+			},
+		},
+	}, s)
+}
@@ -225,6 +225,16 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service::pipelines::traces: references exporter "nop/conn2" which is not configured`),
 		},
+		{
+			name: "invalid-mirror-exporter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines[component.MustNewID("traces")]
+				pipe.Mirror = &pipelines.MirrorConfig{Exporter: component.MustNewIDWithName("nop", "mirror"), Percent: 10}
+				return cfg
+			},
+			expected: errors.New(`service::pipelines::traces: references mirror exporter "nop/mirror" which is not configured`),
+		},
 		{
 			name: "invalid-service-config",
 			cfgFn: func() *Config {
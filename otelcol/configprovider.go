@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/converter/defaultsconverter"
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
@@ -64,12 +65,24 @@ type ConfmapProvider interface {
 	GetConfmap(ctx context.Context) (*confmap.Conf, error)
 }
 
+// confmapOriginsProvider is implemented by the ConfigProviders reporting where the keys
+// of the resolved configuration come from.
+type confmapOriginsProvider interface {
+	// ConfmapOrigins returns the origins of the keys of the last resolved configuration.
+	ConfmapOrigins() []confmap.KeyOrigin
+}
+
 type configProvider struct {
 	mapResolver *confmap.Resolver
+
+	// resolved is the configuration resolved by GetConfmap, used by the next call to Get
+	// instead of resolving the configuration again.
+	resolved *confmap.Conf
 }
 
 var _ ConfigProvider = &configProvider{}
 var _ ConfmapProvider = &configProvider{}
+var _ confmapOriginsProvider = &configProvider{}
 
 // ConfigProviderSettings are the settings to configure the behavior of the ConfigProvider.
 type ConfigProviderSettings struct {
@@ -95,11 +108,16 @@ func NewConfigProvider(set ConfigProviderSettings) (ConfigProvider, error) {
 }
 
 func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config, error) {
-	conf, err := cm.mapResolver.Resolve(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+	conf := cm.resolved
+	cm.resolved = nil
+	if conf == nil {
+		var err error
+		if conf, err = cm.mapResolver.Resolve(ctx); err != nil {
+			return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+		}
 	}
 
+	var err error
 	var cfg *configSettings
 	if cfg, err = unmarshal(conf, factories); err != nil {
 		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
@@ -123,15 +141,23 @@ func (cm *configProvider) Shutdown(ctx context.Context) error {
 	return cm.mapResolver.Shutdown(ctx)
 }
 
+// GetConfmap resolves the configuration, which is then used by the next call to Get: the collector
+// gets both the confmap.Conf and the Config of every configuration, resolving it once saves fetching
+// the configuration sources and running the converters again, and ensures they are consistent.
 func (cm *configProvider) GetConfmap(ctx context.Context) (*confmap.Conf, error) {
 	conf, err := cm.mapResolver.Resolve(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
 
+	cm.resolved = conf
 	return conf, nil
 }
 
+func (cm *configProvider) ConfmapOrigins() []confmap.KeyOrigin {
+	return cm.mapResolver.Origins()
+}
+
 func newDefaultConfigProviderSettings(uris []string) ConfigProviderSettings {
 	converterSet := confmap.ConverterSettings{}
 	providerSet := confmaptest.NewNopProviderSettings()
@@ -145,7 +171,7 @@ func newDefaultConfigProviderSettings(uris []string) ConfigProviderSettings {
 				httpprovider.NewWithSettings(providerSet),
 				httpsprovider.NewWithSettings(providerSet),
 			),
-			Converters: []confmap.Converter{expandconverter.New(converterSet)},
+			Converters: []confmap.Converter{expandconverter.New(converterSet), defaultsconverter.New(converterSet)},
 		},
 	}
 }
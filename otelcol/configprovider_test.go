@@ -128,3 +128,70 @@ func TestGetConfmap(t *testing.T) {
 
 	assert.EqualValues(t, yamlMap, cmap.ToStringMap())
 }
+
+// countingProvider counts the retrievals of the configuration by the wrapped provider.
+type countingProvider struct {
+	confmap.Provider
+	retrievals int
+}
+
+func (cp *countingProvider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	cp.retrievals++
+	return cp.Provider.Retrieve(ctx, uri, watcher)
+}
+
+func TestGetConfmapResolvesOnce(t *testing.T) {
+	provider := &countingProvider{Provider: fileprovider.NewWithSettings(confmaptest.NewNopProviderSettings())}
+	set := ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:      []string{"file:" + filepath.Join("testdata", "otelcol-nop.yaml")},
+			Providers: map[string]confmap.Provider{provider.Scheme(): provider},
+		},
+	}
+	cp, err := NewConfigProvider(set)
+	require.NoError(t, err)
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	_, err = cp.(ConfmapProvider).GetConfmap(context.Background())
+	require.NoError(t, err)
+	cfg, err := cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.retrievals)
+
+	yamlBytes, err := os.ReadFile(filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	configNop, err := newConfig(yamlBytes, factories)
+	require.NoError(t, err)
+	assert.EqualValues(t, configNop, cfg)
+
+	// The configuration resolved by GetConfmap is only used once.
+	_, err = cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.retrievals)
+}
+
+func TestDefaultConfigProviderDefaults(t *testing.T) {
+	uriLocation := "yaml:" + `
+defaults:
+  exporters:
+    nop:
+      timeout: 5s
+exporters:
+  nop:
+  nop/overridden:
+    timeout: 1s
+`
+	cp, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{uriLocation}))
+	require.NoError(t, err)
+
+	cmap, err := cp.(ConfmapProvider).GetConfmap(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"nop":            map[string]any{"timeout": "5s"},
+			"nop/overridden": map[string]any{"timeout": "1s"},
+		},
+	}, cmap.ToStringMap())
+}
@@ -8,6 +8,7 @@ require (
 	go.opentelemetry.io/collector/component v0.98.0
 	go.opentelemetry.io/collector/config/configtelemetry v0.98.0
 	go.opentelemetry.io/collector/confmap v0.98.0
+	go.opentelemetry.io/collector/confmap/converter/defaultsconverter v0.98.0
 	go.opentelemetry.io/collector/confmap/converter/expandconverter v0.98.0
 	go.opentelemetry.io/collector/confmap/provider/envprovider v0.98.0
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v0.98.0
@@ -40,7 +41,6 @@ require (
 	github.com/go-ole/go-ole v1.2.6 // indirect
 	github.com/go-viper/mapstructure/v2 v2.0.0-alpha.1 // indirect
 	github.com/gogo/protobuf v1.3.2 // indirect
-	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.0 // indirect
 	github.com/hashicorp/go-version v1.6.0 // indirect
@@ -66,8 +66,10 @@ require (
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
-	go.opencensus.io v0.24.0 // indirect
 	go.opentelemetry.io/collector v0.98.0 // indirect
+	go.opentelemetry.io/collector/config/confighumanize v0.98.0 // indirect
+	go.opentelemetry.io/collector/config/confignet v0.98.0 // indirect
+	go.opentelemetry.io/collector/config/configretry v0.98.0 // indirect
 	go.opentelemetry.io/collector/consumer v0.98.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.5.0 // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.98.0 // indirect
@@ -75,7 +77,6 @@ require (
 	go.opentelemetry.io/contrib/config v0.5.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.25.0 // indirect
 	go.opentelemetry.io/otel v1.25.0 // indirect
-	go.opentelemetry.io/otel/bridge/opencensus v1.25.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc v1.25.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp v1.25.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.25.0 // indirect
@@ -99,6 +100,8 @@ require (
 
 replace go.opentelemetry.io/collector => ../
 
+replace go.opentelemetry.io/collector/config/confighumanize => ../config/confighumanize
+
 replace go.opentelemetry.io/collector/service => ../service
 
 replace go.opentelemetry.io/collector/connector => ../connector
@@ -117,6 +120,8 @@ replace go.opentelemetry.io/collector/exporter => ../exporter
 
 replace go.opentelemetry.io/collector/confmap => ../confmap
 
+replace go.opentelemetry.io/collector/confmap/converter/defaultsconverter => ../confmap/converter/defaultsconverter
+
 replace go.opentelemetry.io/collector/confmap/converter/expandconverter => ../confmap/converter/expandconverter
 
 replace go.opentelemetry.io/collector/confmap/provider/envprovider => ../confmap/provider/envprovider
@@ -5,13 +5,26 @@ package configunmarshaler // import "go.opentelemetry.io/collector/otelcol/inter
 
 import (
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
 
 	"golang.org/x/exp/maps"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
+// parallelUnmarshalFeatureGate guards the parallel unmarshalling of the configs, which calls CreateDefaultConfig
+// and the custom Unmarshal methods of the factories concurrently: the factories must be safe for concurrent use.
+var parallelUnmarshalFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"otelcol.parallelConfigUnmarshal",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("controls whether the configurations of the components are unmarshalled in "+
+		"parallel, which requires the factories and the custom Unmarshal methods of the configurations to be safe "+
+		"for concurrent use."))
+
 type Configs[F component.Factory] struct {
 	cfgs map[component.ID]component.Config
 
@@ -28,31 +41,75 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 		return err
 	}
 
-	// Prepare resulting map.
-	c.cfgs = make(map[component.ID]component.Config)
-	// Iterate over raw configs and create a config for each.
-	for id, value := range rawCfgs {
+	// Find the factories first, so that an unknown type is reported before any config is unmarshalled.
+	ids := maps.Keys(rawCfgs)
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	factories := make([]F, len(ids))
+	for i, id := range ids {
 		// Find factory based on component kind and type that we read from config source.
 		factory, ok := c.factories[id.Type()]
 		if !ok {
 			return errorUnknownType(id, maps.Keys(c.factories))
 		}
+		factories[i] = factory
+	}
 
+	cfgs := make([]component.Config, len(ids))
+	errs := make([]error, len(ids))
+	unmarshal := func(i int) {
 		// Create the default config for this component.
-		cfg := factory.CreateDefaultConfig()
+		cfg := factories[i].CreateDefaultConfig()
 
 		// Now that the default config struct is created we can Unmarshal into it,
 		// and it will apply user-defined config on top of the default.
-		if err := component.UnmarshalConfig(confmap.NewFromStringMap(value), cfg); err != nil {
-			return errorUnmarshalError(id, err)
+		if err := component.UnmarshalConfig(confmap.NewFromStringMap(rawCfgs[ids[i]]), cfg); err != nil {
+			errs[i] = errorUnmarshalError(ids[i], err)
+			return
 		}
+		cfgs[i] = cfg
+	}
+	if parallelUnmarshalFeatureGate.IsEnabled() {
+		unmarshalParallel(len(ids), unmarshal)
+	} else {
+		for i := range ids {
+			unmarshal(i)
+		}
+	}
 
-		c.cfgs[id] = cfg
+	// Report the error of the first component in the order of their IDs, regardless of the scheduling.
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Prepare resulting map.
+	c.cfgs = make(map[component.ID]component.Config, len(ids))
+	for i, id := range ids {
+		c.cfgs[id] = cfgs[i]
 	}
 
 	return nil
 }
 
+// unmarshalParallel calls unmarshal with the indexes of the n configs, from GOMAXPROCS goroutines.
+func unmarshalParallel(n int, unmarshal func(i int)) {
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			unmarshal(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
 func (c *Configs[F]) Configs() map[component.ID]component.Config {
 	return c.cfgs
 }
@@ -4,6 +4,7 @@
 package configunmarshaler
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,8 +13,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
@@ -143,3 +146,63 @@ func TestUnmarshalError(t *testing.T) {
 		})
 	}
 }
+
+// setParallelUnmarshal sets the feature gate enabling the parallel unmarshalling for the test.
+func setParallelUnmarshal(tb testing.TB, enabled bool) {
+	require.NoError(tb, featuregate.GlobalRegistry().Set(parallelUnmarshalFeatureGate.ID(), enabled))
+	tb.Cleanup(func() {
+		require.NoError(tb, featuregate.GlobalRegistry().Set(parallelUnmarshalFeatureGate.ID(), false))
+	})
+}
+
+// manyComponents returns the raw configs of n components of the kind.
+func manyComponents(kind string, n int) map[string]any {
+	raw := map[string]any{}
+	for i := 0; i < n; i++ {
+		raw[fmt.Sprintf("nop/%s%d", kind, i)] = nil
+	}
+	return raw
+}
+
+func TestUnmarshalManyComponents(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		for _, tk := range testKinds {
+			t.Run(fmt.Sprintf("%s/parallel=%v", tk.kind, parallel), func(t *testing.T) {
+				setParallelUnmarshal(t, parallel)
+				raw := manyComponents(tk.kind, 200)
+				expected := map[component.ID]component.Config{}
+				for i := 0; i < 200; i++ {
+					expected[component.NewIDWithName(nopType, fmt.Sprintf("%s%d", tk.kind, i))] = tk.factories[nopType].CreateDefaultConfig()
+				}
+				cfgs := NewConfigs(tk.factories)
+				require.NoError(t, cfgs.Unmarshal(confmap.NewFromStringMap(raw)))
+				assert.Equal(t, expected, cfgs.Configs())
+
+				// The error of the first component in the order of the IDs is reported.
+				raw["nop/a"] = map[string]any{"unknown_section": tk.kind}
+				raw["nop/b"] = map[string]any{"unknown_section": tk.kind}
+				for i := 0; i < 10; i++ {
+					cfgs = NewConfigs(tk.factories)
+					assert.ErrorContains(t, cfgs.Unmarshal(confmap.NewFromStringMap(raw)), "error reading configuration for \"nop/a\"")
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	factories := map[component.Type]exporter.Factory{nopType: exportertest.NewNopFactory()}
+	raw := manyComponents("exporter", 200)
+	for _, parallel := range []bool{false, true} {
+		b.Run(fmt.Sprintf("components=200/parallel=%v", parallel), func(b *testing.B) {
+			setParallelUnmarshal(b, parallel)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cfgs := NewConfigs(factories)
+				if err := cfgs.Unmarshal(confmap.NewFromStringMap(raw)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
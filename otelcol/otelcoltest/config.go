@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/converter/defaultsconverter"
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
@@ -28,7 +29,7 @@ func LoadConfig(fileName string, factories otelcol.Factories) (*otelcol.Config,
 				yamlprovider.NewWithSettings(confmaptest.NewNopProviderSettings()),
 				httpprovider.NewWithSettings(confmaptest.NewNopProviderSettings()),
 			),
-			Converters: []confmap.Converter{expandconverter.New(confmap.ConverterSettings{})},
+			Converters: []confmap.Converter{expandconverter.New(confmap.ConverterSettings{}), defaultsconverter.New(confmap.ConverterSettings{})},
 		},
 	})
 	if err != nil {
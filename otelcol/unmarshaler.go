@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -63,6 +64,10 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 					Address: ":8888",
 				},
 			},
+			PanicIsolation: panicisolation.Config{
+				RestartPolicy: panicisolation.RestartNever,
+				MaxRestarts:   panicisolation.DefaultMaxRestarts,
+			},
 		},
 	}
 
@@ -20,6 +20,9 @@ func (ms {{ .structName }}) {{ .fieldName }}() {{ .packageName }}{{ .returnType
 	{{- end -}}
 	))
 	{{- else }}
+	{{- if .copyOnWrite }}
+	ms.state.CopyIfShared(&ms.{{ .origAccessor }}.{{ .fieldName }})
+	{{- end }}
 	return new{{ .returnType }}(&ms.{{ .origAccessor }}.{{ .fieldName }}, ms.state)
 	{{- end }}
 }`
@@ -304,6 +307,7 @@ func (sf *sliceField) templateFields(ms *messageValueStruct) map[string]any {
 		"stateAccessor":      stateAccessor(ms),
 		"isCommon":           usedByOtherDataTypes(sf.returnSlice.getPackageName()),
 		"isBaseStructCommon": usedByOtherDataTypes(ms.packageName),
+		"copyOnWrite":        ms.copyOnWrite,
 	}
 }
 
@@ -81,12 +81,40 @@ func (es {{ .structName }}) AppendEmpty() {{ .elementName }} {
 	return es.At(es.Len() - 1)
 }
 
+// AppendEmptyN will append to the end of the slice n empty {{ .elementName }}, allocated at once.
+// The newly added elements are at the indexes [Len()-n, Len()), e.g.:
+//   es.AppendEmptyN(len(events))
+//   for i, ev := range events {
+//       e := es.At(es.Len() - len(events) + i)
+//       // Here should set all the values for e from ev.
+//   }
+func (es {{ .structName }}) AppendEmptyN(n int) {
+	es.state.AssertMutable()
+	if n <= 0 {
+		return
+	}
+	{{- if eq .type "sliceOfPtrs" }}
+	oldLen := len(*es.orig)
+	*es.orig = append(*es.orig, make([]{{ .originElementType }}, n)...)
+	origs := make([]{{ .originName }}, n)
+	for i := range origs {
+		(*es.orig)[oldLen+i] = &origs[i]
+	}
+	{{- else }}
+	*es.orig = append(*es.orig, make([]{{ .originElementType }}, n)...)
+	{{- end }}
+}
+
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es {{ .structName }}) MoveAndAppendTo(dest {{ .structName }}) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
-	if *dest.orig == nil {
+	{{- if .copyOnWrite }}
+	// The subtrees shared with another instance cannot be moved out of this one.
+	es.state.CopyAllShared()
+	{{- end }}
+	if len(*dest.orig) == 0 && cap(*dest.orig) < len(*es.orig) {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
 	} else {
@@ -181,6 +209,7 @@ func Test{{ .structName }}ReadOnly(t *testing.T) {
 	es := new{{ .structName }}(&[]{{ .originElementType }}{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := New{{ .structName }}()
 	es.CopyTo(es2)
@@ -222,6 +251,25 @@ func Test{{ .structName }}_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTest{{ .structName }}(), es)
 }
 
+func Test{{ .structName }}_AppendEmptyN(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTest{{ .structName }}(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := New{{ .elementName }}()
+	testVal := generateTest{{ .elementName }}()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTest{{ .elementName }}(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTest{{ .structName }}().At(i), es.At(i))
+	}
+}
+
 func Test{{ .structName }}_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTest{{ .structName }}()
@@ -245,6 +293,15 @@ func Test{{ .structName }}_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = New{{ .structName }}()
+	dest.EnsureCapacity(1)
+	src = generateTest{{ .structName }}()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTest{{ .structName }}(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func Test{{ .structName }}_RemoveIf(t *testing.T) {
@@ -347,6 +404,7 @@ func (ss *sliceOfPtrs) templateFields() map[string]any {
 		"originElementType":  "*" + ss.element.originFullName,
 		"emptyOriginElement": "&" + ss.element.originFullName + "{}",
 		"newElement":         "new" + ss.element.structName + "((*es.orig)[i], es.state)",
+		"copyOnWrite":        ss.element.copyOnWrite,
 	}
 }
 
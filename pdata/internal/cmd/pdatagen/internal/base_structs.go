@@ -47,6 +47,10 @@ func New{{ .structName }}() {{ .structName }} {
 func (ms {{ .structName }}) MoveTo(dest {{ .structName }}) {
 	ms.{{- if .isCommon }}getState(){{ else }}state{{ end }}.AssertMutable()
 	dest.{{- if .isCommon }}getState(){{ else }}state{{ end }}.AssertMutable()
+	{{- if .copyOnWrite }}
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
+	{{- end }}
 	*dest.{{ .origAccessor }} = *ms.{{ .origAccessor }}
 	*ms.{{ .origAccessor }} = {{ .originName }}{}
 }
@@ -71,6 +75,13 @@ func (ms {{ .structName }}) CopyTo(dest {{ .structName }}) {
 	{{- range .fields }}
 	{{ .GenerateCopyToValue $.messageStruct }}
 	{{- end }}
+}
+
+// Clone returns a deep copy of the {{ .structName }} in a new instance.
+func (ms {{ .structName }}) Clone() {{ .structName }} {
+	dest := New{{ .structName }}()
+	ms.CopyTo(dest)
+	return dest
 }`
 
 const messageValueTestTemplate = `
@@ -97,6 +108,14 @@ func Test{{ .structName }}_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(new{{ .structName }}(&{{ .originName }}{}, &sharedState)) })
 }
 
+func Test{{ .structName }}_Clone(t *testing.T) {
+	ms := {{ .generateTestData }}
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	New{{ .structName }}().CopyTo(ms)
+	assert.Equal(t, {{ .generateTestData }}, clone)
+}
+
 {{ range .fields }}
 {{ .GenerateAccessorsTest $.messageStruct }}
 {{ end }}`
@@ -151,6 +170,9 @@ type messageValueStruct struct {
 	description    string
 	originFullName string
 	fields         []baseField
+	// copyOnWrite indicates that the subtrees held by the slice fields can be shared with another instance,
+	// see ptrace.Traces.CloneResource for instance.
+	copyOnWrite bool
 }
 
 func (ms *messageValueStruct) getName() string {
@@ -208,6 +230,7 @@ func (ms *messageValueStruct) templateFields() map[string]any {
 		"description":  ms.description,
 		"isCommon":     usedByOtherDataTypes(ms.packageName),
 		"origAccessor": origAccessor(ms),
+		"copyOnWrite":  ms.copyOnWrite,
 	}
 }
 
@@ -44,6 +44,7 @@ var resourceLogs = &messageValueStruct{
 	structName:     "ResourceLogs",
 	description:    "// ResourceLogs is a collection of logs from a Resource.",
 	originFullName: "otlplogs.ResourceLogs",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -63,6 +64,7 @@ var scopeLogs = &messageValueStruct{
 	structName:     "ScopeLogs",
 	description:    "// ScopeLogs is a collection of logs from a LibraryInstrumentation.",
 	originFullName: "otlplogs.ScopeLogs",
+	copyOnWrite:    true,
 	fields: []baseField{
 		scopeField,
 		schemaURLField,
@@ -122,6 +124,12 @@ var logRecord = &messageValueStruct{
 		bodyField,
 		attributes,
 		droppedAttributesCount,
+		&primitiveField{
+			fieldName:  "EventName",
+			returnType: "string",
+			defaultVal: `""`,
+			testVal:    `"test_event"`,
+		},
 	},
 }
 
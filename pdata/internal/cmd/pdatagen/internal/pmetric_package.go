@@ -62,6 +62,7 @@ var resourceMetrics = &messageValueStruct{
 	structName:     "ResourceMetrics",
 	description:    "// ResourceMetrics is a collection of metrics from a Resource.",
 	originFullName: "otlpmetrics.ResourceMetrics",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -81,6 +82,7 @@ var scopeMetrics = &messageValueStruct{
 	structName:     "ScopeMetrics",
 	description:    "// ScopeMetrics is a collection of metrics from a LibraryInstrumentation.",
 	originFullName: "otlpmetrics.ScopeMetrics",
+	copyOnWrite:    true,
 	fields: []baseField{
 		scopeField,
 		schemaURLField,
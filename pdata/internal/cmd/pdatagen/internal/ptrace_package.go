@@ -49,6 +49,7 @@ var resourceSpans = &messageValueStruct{
 	structName:     "ResourceSpans",
 	description:    "// ResourceSpans is a collection of spans from a Resource.",
 	originFullName: "otlptrace.ResourceSpans",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -68,6 +69,7 @@ var scopeSpans = &messageValueStruct{
 	structName:     "ScopeSpans",
 	description:    "// ScopeSpans is a collection of spans from a LibraryInstrumentation.",
 	originFullName: "otlptrace.ScopeSpans",
+	copyOnWrite:    true,
 	fields: []baseField{
 		scopeField,
 		schemaURLField,
@@ -434,6 +434,17 @@ type LogRecord struct {
 	//   - the field is not present,
 	//   - the field contains an invalid value.
 	SpanId go_opentelemetry_io_collector_pdata_internal_data.SpanID `protobuf:"bytes,10,opt,name=span_id,json=spanId,proto3,customtype=go.opentelemetry.io/collector/pdata/internal/data.SpanID" json:"span_id"`
+	// A unique identifier of event category/type.
+	// All events with the same event_name are expected to conform to the same
+	// schema for both their attributes and their body.
+	//
+	// Recommended to be fully qualified and short (no longer than 256 characters).
+	//
+	// Presence of event_name on the log record identifies this record
+	// as an event.
+	//
+	// [Optional].
+	EventName string `protobuf:"bytes,12,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
 }
 
 func (m *LogRecord) Reset()         { *m = LogRecord{} }
@@ -525,6 +536,13 @@ func (m *LogRecord) GetFlags() uint32 {
 	return 0
 }
 
+func (m *LogRecord) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("opentelemetry.proto.logs.v1.SeverityNumber", SeverityNumber_name, SeverityNumber_value)
 	proto.RegisterEnum("opentelemetry.proto.logs.v1.LogRecordFlags", LogRecordFlags_name, LogRecordFlags_value)
@@ -539,67 +557,67 @@ func init() {
 }
 
 var fileDescriptor_d1c030a3ec7e961e = []byte{
-	// 951 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xcf, 0x6e, 0xea, 0x46,
-	0x14, 0xc6, 0x31, 0xe1, 0xef, 0x84, 0x70, 0xa7, 0x73, 0x49, 0xae, 0x9b, 0xa8, 0x84, 0xa6, 0x55,
-	0x4a, 0x53, 0x09, 0x14, 0xa0, 0xd2, 0xed, 0xae, 0x26, 0x98, 0x88, 0x86, 0x40, 0x34, 0x40, 0xaa,
-	0xdc, 0x56, 0xb2, 0x0c, 0x9e, 0x52, 0x4b, 0xc6, 0x63, 0xd9, 0x03, 0x4a, 0xf6, 0x7d, 0x80, 0x3e,
-	0x41, 0xb7, 0x95, 0xfa, 0x1a, 0xed, 0xe2, 0x2e, 0xef, 0xb2, 0xea, 0x22, 0xaa, 0x92, 0x4d, 0xdf,
-	0xa2, 0xd5, 0x0c, 0x86, 0x90, 0xd4, 0x4e, 0x6e, 0x56, 0x99, 0x39, 0xbf, 0xef, 0x7c, 0xe7, 0x8c,
-	0x4f, 0x3c, 0x18, 0xec, 0x53, 0x87, 0xd8, 0x8c, 0x58, 0x64, 0x42, 0x98, 0x7b, 0x55, 0x76, 0x5c,
-	0xca, 0x68, 0xd9, 0xa2, 0x63, 0xaf, 0x3c, 0x3b, 0x14, 0x7f, 0x4b, 0x22, 0x84, 0x76, 0xee, 0xe9,
-	0xe6, 0xc1, 0x92, 0xe0, 0xb3, 0xc3, 0xed, 0xdc, 0x98, 0x8e, 0xe9, 0x3c, 0x95, 0xaf, 0xe6, 0x74,
-	0xfb, 0x20, 0xc8, 0x7a, 0x44, 0x27, 0x13, 0x6a, 0x73, 0xf3, 0xf9, 0xca, 0xd7, 0x96, 0x82, 0xb4,
-	0x2e, 0xf1, 0xe8, 0xd4, 0x1d, 0x11, 0xae, 0x5e, 0xac, 0xe7, 0xfa, 0xbd, 0x37, 0x20, 0xd5, 0xa6,
-	0x63, 0xaf, 0xa1, 0x33, 0x1d, 0x75, 0xc0, 0xc6, 0x82, 0x6a, 0xbc, 0x23, 0x59, 0x2a, 0xac, 0x15,
-	0xd7, 0x2b, 0x9f, 0x97, 0x1e, 0x69, 0xb9, 0x84, 0xfd, 0x0c, 0xee, 0x82, 0x33, 0xee, 0xca, 0x6e,
-	0xef, 0x97, 0x28, 0xc8, 0xac, 0x62, 0xf4, 0x1d, 0xd8, 0x34, 0x88, 0xe3, 0x92, 0x91, 0xce, 0x88,
-	0xa1, 0x79, 0x23, 0xea, 0xf8, 0x85, 0xfe, 0x49, 0x8a, 0x4a, 0xfb, 0x8f, 0x56, 0xea, 0x71, 0xbd,
-	0x28, 0xf3, 0xf2, 0xce, 0x65, 0x19, 0x44, 0x27, 0x20, 0xb5, 0xa8, 0x2e, 0x4b, 0x05, 0x29, 0xb4,
-	0xf1, 0xe5, 0x03, 0x58, 0x69, 0xbe, 0x1e, 0x7b, 0x7b, 0xbd, 0x1b, 0xc1, 0x4b, 0x03, 0xa4, 0x02,
-	0xb0, 0xd2, 0x5e, 0xf4, 0x59, 0xdd, 0xa5, 0xbd, 0x65, 0x4f, 0x1f, 0x71, 0x9b, 0x1f, 0xc9, 0x44,
-	0xd7, 0xa6, 0xae, 0x25, 0xaf, 0x15, 0xa4, 0x62, 0x9a, 0x63, 0x1e, 0x19, 0xb8, 0xd6, 0xde, 0x1f,
-	0x12, 0x48, 0xdf, 0x1d, 0xa0, 0x0b, 0xe2, 0x22, 0xd3, 0xef, 0xbe, 0x1a, 0x58, 0xce, 0x1f, 0xf6,
-	0xec, 0xb0, 0xd4, 0xb2, 0x3d, 0xe6, 0x4e, 0x27, 0xc4, 0x66, 0x3a, 0x33, 0xa9, 0x2d, 0x7c, 0xfc,
-	0x73, 0xcc, 0x7d, 0xd0, 0x31, 0x58, 0xb7, 0xe8, 0x58, 0x73, 0xc9, 0x88, 0xba, 0xc6, 0xfb, 0x9d,
-	0xa2, 0x4d, 0xc7, 0x58, 0xc8, 0x31, 0xb0, 0x16, 0xcb, 0x27, 0x8f, 0xf1, 0x53, 0x1c, 0xa4, 0x97,
-	0x89, 0xe8, 0x53, 0x90, 0x65, 0xe6, 0x84, 0x68, 0x53, 0xdb, 0xbc, 0xd4, 0x6c, 0xdd, 0xa6, 0xe2,
-	0x3c, 0x09, 0x9c, 0xe1, 0xd1, 0x81, 0x6d, 0x5e, 0x76, 0x74, 0x9b, 0xa2, 0x2f, 0xc1, 0x2b, 0x3a,
-	0xf4, 0x88, 0x3b, 0x23, 0x86, 0xf6, 0x40, 0xbe, 0x2e, 0xe4, 0xb9, 0x05, 0xee, 0xaf, 0xa6, 0xf5,
-	0xc1, 0x0b, 0x8f, 0xcc, 0x88, 0x6b, 0xb2, 0x2b, 0xcd, 0x9e, 0x4e, 0x86, 0xc4, 0x95, 0xa3, 0x05,
-	0xa9, 0x98, 0xad, 0x7c, 0xf1, 0xf8, 0x70, 0xfc, 0x9c, 0x8e, 0x48, 0xc1, 0x59, 0xef, 0xde, 0x1e,
-	0x7d, 0x02, 0x36, 0x96, 0xae, 0x8c, 0x5c, 0x32, 0xff, 0x88, 0x99, 0x45, 0xb0, 0x4f, 0x2e, 0x19,
-	0x52, 0x40, 0x6c, 0x48, 0x8d, 0x2b, 0x39, 0x2e, 0xa6, 0xf3, 0xd9, 0x13, 0xd3, 0x51, 0xec, 0xab,
-	0x73, 0xdd, 0x9a, 0x2e, 0x26, 0x22, 0x52, 0xd1, 0x29, 0x00, 0x3a, 0x63, 0xae, 0x39, 0x9c, 0x32,
-	0xe2, 0xc9, 0x09, 0x31, 0x8f, 0xa7, 0x8c, 0x4e, 0xc8, 0x3d, 0xa3, 0x15, 0x03, 0xf4, 0x1a, 0xc8,
-	0x86, 0x4b, 0x1d, 0x87, 0x18, 0xda, 0x5d, 0x54, 0x1b, 0xd1, 0xa9, 0xcd, 0xe4, 0x64, 0x41, 0x2a,
-	0x6e, 0xe0, 0x2d, 0x9f, 0x2b, 0x4b, 0x7c, 0xc4, 0x29, 0xca, 0x81, 0xf8, 0x0f, 0x96, 0x3e, 0xf6,
-	0xe4, 0x54, 0x41, 0x2a, 0x26, 0xf1, 0x7c, 0x83, 0xbe, 0x07, 0x29, 0xe6, 0xea, 0x23, 0xa2, 0x99,
-	0x86, 0x9c, 0x2e, 0x48, 0xc5, 0x4c, 0x5d, 0xe1, 0x35, 0xff, 0xba, 0xde, 0xfd, 0x6a, 0x4c, 0x1f,
-	0xb4, 0x69, 0xf2, 0x1b, 0xc8, 0xb2, 0xc8, 0x88, 0x51, 0xb7, 0xec, 0x18, 0x3a, 0xd3, 0xcb, 0xa6,
-	0xcd, 0x88, 0x6b, 0xeb, 0x56, 0x99, 0xef, 0x4a, 0x7d, 0xee, 0xd4, 0x6a, 0xe0, 0xa4, 0xb0, 0x6c,
-	0x19, 0xe8, 0x02, 0x24, 0x3d, 0x47, 0xb7, 0xb9, 0x39, 0x10, 0xe6, 0x5f, 0xfb, 0xe6, 0xaf, 0x9f,
-	0x6f, 0xde, 0x73, 0x74, 0xbb, 0xd5, 0xc0, 0x09, 0x6e, 0xd8, 0x32, 0xbe, 0x89, 0xa5, 0x62, 0x30,
-	0x7e, 0xf0, 0x7b, 0x1c, 0x64, 0xef, 0x0f, 0x1a, 0xed, 0x82, 0x9d, 0x9e, 0x7a, 0xae, 0xe2, 0x56,
-	0xff, 0x42, 0xeb, 0x0c, 0x4e, 0xeb, 0x2a, 0xd6, 0x06, 0x9d, 0xde, 0x99, 0x7a, 0xd4, 0x6a, 0xb6,
-	0xd4, 0x06, 0x8c, 0xa0, 0x0f, 0xc1, 0xe6, 0x43, 0x41, 0x1f, 0x2b, 0x47, 0x2a, 0x94, 0xd0, 0x36,
-	0xd8, 0x0a, 0x44, 0x15, 0x18, 0x0d, 0x65, 0x55, 0xb8, 0x16, 0xca, 0x6a, 0x30, 0x16, 0x54, 0xae,
-	0xa1, 0xd6, 0x07, 0xc7, 0x30, 0x1e, 0x94, 0x26, 0x50, 0x05, 0x26, 0x42, 0x59, 0x15, 0x26, 0x43,
-	0x59, 0x0d, 0xa6, 0x90, 0x0c, 0x72, 0x0f, 0x59, 0xab, 0xd3, 0xec, 0xc2, 0x74, 0x50, 0x23, 0x9c,
-	0x54, 0x20, 0x08, 0x43, 0x55, 0xb8, 0x1e, 0x86, 0x6a, 0x30, 0x13, 0x54, 0xea, 0x5b, 0x05, 0x77,
-	0xe0, 0x46, 0x50, 0x12, 0x27, 0x15, 0x98, 0x0d, 0x43, 0x55, 0xf8, 0x22, 0x0c, 0xd5, 0x20, 0x0c,
-	0x42, 0x2a, 0xc6, 0x5d, 0x0c, 0x3f, 0x08, 0x7a, 0x18, 0x02, 0x55, 0x20, 0x0a, 0x65, 0x55, 0xf8,
-	0x32, 0x94, 0xd5, 0x60, 0x2e, 0xa8, 0x5c, 0x53, 0xe9, 0x2b, 0x6d, 0xb8, 0x19, 0x94, 0x26, 0x50,
-	0x05, 0x6e, 0x85, 0xb2, 0x2a, 0x7c, 0x15, 0xca, 0x6a, 0x50, 0x3e, 0xb8, 0x00, 0xd9, 0xe5, 0x5d,
-	0xda, 0x14, 0xaf, 0xe5, 0x2e, 0xd8, 0x69, 0x77, 0x8f, 0x35, 0xac, 0x1e, 0x75, 0x71, 0x43, 0x6b,
-	0xb6, 0x95, 0xe3, 0x9e, 0xd6, 0xe8, 0x6a, 0x9d, 0x6e, 0x5f, 0x1b, 0xf4, 0x54, 0x18, 0x41, 0xfb,
-	0xe0, 0xe3, 0xff, 0x09, 0xc4, 0xbf, 0x9c, 0xbf, 0x3e, 0x55, 0x7a, 0x27, 0xf0, 0x5f, 0xa9, 0xfe,
-	0xab, 0xf4, 0xf6, 0x26, 0x2f, 0xbd, 0xbb, 0xc9, 0x4b, 0x7f, 0xdf, 0xe4, 0xa5, 0x9f, 0x6f, 0xf3,
-	0x91, 0x77, 0xb7, 0xf9, 0xc8, 0x9f, 0xb7, 0xf9, 0x08, 0xc8, 0x9b, 0xf4, 0xb1, 0x0b, 0xb4, 0xce,
-	0xef, 0x77, 0xef, 0x8c, 0x87, 0xce, 0xa4, 0x37, 0xf5, 0x67, 0xbf, 0xb0, 0xf3, 0xef, 0x90, 0x31,
-	0xb1, 0x17, 0x5f, 0x44, 0xbf, 0x45, 0x77, 0xba, 0x0e, 0xb1, 0xfb, 0x4b, 0x07, 0xe1, 0xcd, 0x7f,
-	0x7e, 0xbc, 0xd2, 0xf9, 0xe1, 0x30, 0x21, 0xf4, 0xd5, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x48,
-	0x86, 0x67, 0x14, 0x55, 0x09, 0x00, 0x00,
+	// 953 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x95, 0x96, 0xcf, 0x6f, 0xe3, 0x44,
+	0x14, 0xc7, 0x9b, 0x36, 0x3f, 0xa7, 0x69, 0x76, 0x98, 0x4d, 0xbb, 0xa6, 0x15, 0x2d, 0x14, 0x54,
+	0x96, 0xae, 0x94, 0xa8, 0x49, 0x90, 0x96, 0x1b, 0x4e, 0xe3, 0x54, 0xa1, 0xad, 0x53, 0x4d, 0x9c,
+	0xa2, 0xee, 0xae, 0x64, 0x39, 0xc9, 0x6c, 0xb1, 0xe4, 0xd8, 0x91, 0xed, 0x54, 0xdb, 0xff, 0x82,
+	0xbf, 0x80, 0x0b, 0x07, 0x24, 0xfe, 0x0d, 0x38, 0xec, 0x71, 0x8f, 0x88, 0xc3, 0x0a, 0xc1, 0x85,
+	0xff, 0x02, 0xde, 0x8c, 0x1d, 0x37, 0x29, 0x76, 0x4b, 0x0f, 0xa3, 0xcc, 0xbc, 0xcf, 0x7b, 0xdf,
+	0xf7, 0xc6, 0xf3, 0x3c, 0x31, 0xda, 0x73, 0x26, 0xcc, 0xf6, 0x99, 0xc5, 0xc6, 0xcc, 0x77, 0xaf,
+	0xab, 0x13, 0xd7, 0xf1, 0x9d, 0xaa, 0xe5, 0x5c, 0x7a, 0xd5, 0xab, 0x03, 0xf1, 0x5b, 0x11, 0x26,
+	0xb2, 0xb5, 0xe0, 0x17, 0x18, 0x2b, 0x82, 0x5f, 0x1d, 0x6c, 0x96, 0x2f, 0x9d, 0x4b, 0x27, 0x08,
+	0xe5, 0xb3, 0x80, 0x6e, 0xee, 0xc7, 0x49, 0x0f, 0x9d, 0xf1, 0xd8, 0xb1, 0xb9, 0x78, 0x30, 0x0b,
+	0x7d, 0x2b, 0x71, 0xbe, 0x2e, 0xf3, 0x9c, 0xa9, 0x3b, 0x64, 0xdc, 0x7b, 0x36, 0x0f, 0xfc, 0x77,
+	0x5f, 0xa0, 0xfc, 0x09, 0x24, 0x6f, 0x19, 0xbe, 0x41, 0x54, 0xb4, 0x36, 0xa3, 0x3a, 0xaf, 0x48,
+	0x4a, 0x7d, 0xbc, 0xf2, 0x74, 0xb5, 0xf6, 0x45, 0xe5, 0x8e, 0x92, 0x2b, 0x34, 0x8c, 0xe0, 0x2a,
+	0xb4, 0xe8, 0xce, 0xad, 0x76, 0x7f, 0x58, 0x46, 0xc5, 0x79, 0x4c, 0x5e, 0xa2, 0xf5, 0x11, 0x9b,
+	0xb8, 0x6c, 0x68, 0xf8, 0x6c, 0xa4, 0x7b, 0x43, 0xd0, 0x0d, 0x12, 0xfd, 0x9d, 0x13, 0x99, 0xf6,
+	0xee, 0xcc, 0xd4, 0xe3, 0xfe, 0x22, 0xcd, 0xe3, 0x1b, 0x95, 0xc8, 0x48, 0x8e, 0x51, 0x7e, 0x96,
+	0x1d, 0x0a, 0x4f, 0x25, 0x16, 0x1e, 0x3d, 0x80, 0xb9, 0xe2, 0x9b, 0xe9, 0xb7, 0xef, 0x77, 0x96,
+	0x68, 0x24, 0x40, 0x14, 0x84, 0xe6, 0xca, 0x5b, 0x7e, 0x50, 0x75, 0x05, 0x2f, 0xaa, 0xe9, 0x23,
+	0x2e, 0xf3, 0x1d, 0x1b, 0x1b, 0xfa, 0xd4, 0xb5, 0xa4, 0x15, 0xa8, 0xaa, 0xc0, 0x31, 0xb7, 0xf4,
+	0x5d, 0x6b, 0xf7, 0xd7, 0x14, 0x2a, 0xdc, 0x6c, 0xa0, 0x8b, 0x32, 0x22, 0x32, 0xac, 0xbe, 0x1e,
+	0x9b, 0x2e, 0x3c, 0x6c, 0x48, 0xd8, 0xb1, 0x3d, 0xdf, 0x9d, 0x8e, 0xc1, 0xc5, 0xf0, 0x4d, 0xc7,
+	0x16, 0x3a, 0xe1, 0x3e, 0x02, 0x1d, 0x72, 0x84, 0x56, 0xa1, 0x3a, 0x1d, 0x9e, 0x94, 0xe3, 0x8e,
+	0xfe, 0xdf, 0x2e, 0xa0, 0x10, 0x2a, 0xdc, 0x29, 0xb2, 0x66, 0xd3, 0x7b, 0xb7, 0xf1, 0x63, 0x06,
+	0x15, 0xa2, 0x40, 0xf2, 0x19, 0x2a, 0xf9, 0xe6, 0x98, 0xe9, 0x53, 0xdb, 0x7c, 0xa3, 0xdb, 0x86,
+	0xed, 0x88, 0xfd, 0x64, 0x69, 0x91, 0x5b, 0xfb, 0x60, 0x54, 0xc1, 0x46, 0xbe, 0x44, 0x4f, 0x9c,
+	0x81, 0xc7, 0xdc, 0x2b, 0x68, 0x84, 0x5b, 0xee, 0xab, 0xc2, 0xbd, 0x3c, 0xc3, 0xda, 0x7c, 0x98,
+	0x86, 0x1e, 0x79, 0xec, 0x8a, 0xb9, 0xa6, 0x7f, 0xad, 0xdb, 0xd3, 0xf1, 0x80, 0xb9, 0xb0, 0xad,
+	0xd4, 0xd3, 0x52, 0xed, 0xd9, 0xdd, 0x87, 0x13, 0xc6, 0xa8, 0x22, 0x84, 0x96, 0xbc, 0x85, 0x35,
+	0xf9, 0x14, 0xad, 0x45, 0xaa, 0x3e, 0x7b, 0xe3, 0x87, 0x5b, 0x2c, 0xce, 0x8c, 0x1a, 0xd8, 0x88,
+	0x8c, 0xd2, 0x03, 0x67, 0x74, 0x2d, 0x65, 0xc4, 0xe9, 0x7c, 0x7e, 0xcf, 0xe9, 0xc8, 0xf6, 0xf5,
+	0xb9, 0x61, 0x4d, 0x67, 0x27, 0x22, 0x42, 0xc9, 0x29, 0x42, 0x86, 0xef, 0xbb, 0xe6, 0x60, 0xea,
+	0x33, 0x4f, 0xca, 0x8a, 0xf3, 0xb8, 0x4f, 0xe8, 0x98, 0x2d, 0x08, 0xcd, 0x09, 0x90, 0xe7, 0x48,
+	0x1a, 0xb9, 0xce, 0x64, 0x02, 0x8f, 0xf0, 0xc6, 0xaa, 0x0f, 0x9d, 0xa9, 0xed, 0x4b, 0x39, 0xa8,
+	0x72, 0x8d, 0x6e, 0x84, 0x5c, 0x8e, 0xf0, 0x21, 0xa7, 0xa4, 0x8c, 0x32, 0xaf, 0x2d, 0x03, 0x3a,
+	0x3b, 0x0f, 0x6e, 0x39, 0x1a, 0x2c, 0xc8, 0x2b, 0x94, 0xf7, 0x5d, 0x03, 0x5e, 0x7e, 0x73, 0x24,
+	0x15, 0x00, 0x14, 0x9b, 0x32, 0xcf, 0xf9, 0xfb, 0xfb, 0x9d, 0xaf, 0xe0, 0x2e, 0x5a, 0x2c, 0xd3,
+	0xe4, 0x37, 0x90, 0x65, 0xb1, 0xa1, 0xef, 0xb8, 0xd5, 0xc9, 0x08, 0xae, 0x8f, 0xaa, 0x09, 0xd8,
+	0xb5, 0x0d, 0xab, 0xca, 0x57, 0x15, 0x8d, 0x2b, 0x75, 0x5a, 0x34, 0x27, 0x24, 0x3b, 0x23, 0x72,
+	0x81, 0x72, 0xde, 0xc4, 0xb0, 0xb9, 0x38, 0x12, 0xe2, 0x5f, 0x87, 0xe2, 0xcf, 0x1f, 0x2e, 0xde,
+	0x03, 0x21, 0xd0, 0xce, 0x72, 0x41, 0x90, 0x86, 0xfe, 0x84, 0x93, 0xb2, 0x7d, 0xe8, 0x9f, 0x31,
+	0x93, 0x8a, 0x41, 0x7f, 0x0a, 0x8b, 0x0a, 0x86, 0x6f, 0xd2, 0xf9, 0x34, 0xce, 0xec, 0xff, 0x92,
+	0x41, 0xa5, 0xc5, 0x3e, 0x20, 0x3b, 0x68, 0xab, 0xa7, 0x9c, 0x2b, 0xb4, 0xa3, 0x5d, 0xe8, 0x6a,
+	0xff, 0xb4, 0xa9, 0x50, 0xbd, 0xaf, 0xf6, 0xce, 0x94, 0xc3, 0x4e, 0xbb, 0xa3, 0xb4, 0xf0, 0x12,
+	0xf9, 0x10, 0xad, 0xdf, 0x76, 0xd0, 0xa8, 0x7c, 0xa8, 0xe0, 0x14, 0xd9, 0x44, 0x1b, 0xb1, 0xa8,
+	0x86, 0x97, 0x13, 0x59, 0x1d, 0xaf, 0x24, 0xb2, 0x06, 0x4e, 0xc7, 0xa5, 0x6b, 0x29, 0xcd, 0xfe,
+	0x11, 0xce, 0xc4, 0x85, 0x09, 0x54, 0xc3, 0xd9, 0x44, 0x56, 0xc7, 0xb9, 0x44, 0xd6, 0xc0, 0x79,
+	0x22, 0xa1, 0xf2, 0x6d, 0xd6, 0x51, 0xdb, 0x5d, 0x5c, 0x88, 0x2b, 0x84, 0x93, 0x1a, 0x46, 0x49,
+	0xa8, 0x8e, 0x57, 0x93, 0x50, 0x03, 0x17, 0xe3, 0x52, 0x7d, 0x2b, 0x53, 0x15, 0xaf, 0xc5, 0x05,
+	0x71, 0x52, 0xc3, 0xa5, 0x24, 0x54, 0xc7, 0x8f, 0x92, 0x50, 0x03, 0xe3, 0x38, 0xa4, 0x50, 0xda,
+	0xa5, 0xf8, 0x83, 0xb8, 0x87, 0x21, 0x50, 0x0d, 0x93, 0x44, 0x56, 0xc7, 0x8f, 0x13, 0x59, 0x03,
+	0x97, 0xe3, 0xd2, 0xb5, 0x65, 0x4d, 0x3e, 0xc1, 0xeb, 0x71, 0x61, 0x02, 0xd5, 0xf0, 0x46, 0x22,
+	0xab, 0xe3, 0x27, 0x89, 0xac, 0x81, 0xa5, 0xfd, 0x0b, 0x54, 0x8a, 0xae, 0xda, 0xb6, 0x78, 0x6b,
+	0xa1, 0x89, 0x4f, 0xba, 0x47, 0x3a, 0x55, 0x0e, 0xbb, 0xb4, 0xa5, 0xb7, 0x4f, 0xe4, 0xa3, 0x9e,
+	0xde, 0xea, 0xea, 0x6a, 0x57, 0xd3, 0xfb, 0x3d, 0x05, 0x9a, 0x78, 0x0f, 0x7d, 0xf2, 0x1f, 0x07,
+	0xd1, 0x72, 0xe1, 0xfc, 0x54, 0xee, 0x1d, 0xe3, 0x7f, 0x52, 0xcd, 0x9f, 0x52, 0x68, 0xdb, 0x74,
+	0xee, 0xba, 0x47, 0x9b, 0xfc, 0x9a, 0xf7, 0xce, 0xb8, 0xe9, 0x2c, 0xf5, 0xa2, 0xf9, 0xe0, 0xf7,
+	0x36, 0xf8, 0x1c, 0xb9, 0x64, 0xf6, 0xec, 0xc3, 0xe8, 0xe7, 0xe5, 0xad, 0x2e, 0x28, 0x68, 0x91,
+	0x82, 0xd0, 0xe6, 0xff, 0x42, 0x5e, 0xe5, 0xfc, 0xe0, 0xed, 0x9f, 0xdb, 0xa9, 0x77, 0x30, 0xfe,
+	0x80, 0xf1, 0xfd, 0x5f, 0xdb, 0x4b, 0xef, 0x60, 0xfc, 0x06, 0x63, 0x90, 0x15, 0x3a, 0xf5, 0x7f,
+	0x01, 0x0a, 0x41, 0x3b, 0x1d, 0x74, 0x09, 0x00, 0x00,
 }
 
 func (m *LogsData) Marshal() (dAtA []byte, err error) {
@@ -783,6 +801,13 @@ func (m *LogRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.EventName) > 0 {
+		i -= len(m.EventName)
+		copy(dAtA[i:], m.EventName)
+		i = encodeVarintLogs(dAtA, i, uint64(len(m.EventName)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.ObservedTimeUnixNano != 0 {
 		i -= 8
 		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ObservedTimeUnixNano))
@@ -976,6 +1001,10 @@ func (m *LogRecord) Size() (n int) {
 	if m.ObservedTimeUnixNano != 0 {
 		n += 9
 	}
+	l = len(m.EventName)
+	if l > 0 {
+		n += 1 + l + sovLogs(uint64(l))
+	}
 	return n
 }
 
@@ -1663,6 +1692,38 @@ func (m *LogRecord) Unmarshal(dAtA []byte) error {
 			}
 			m.ObservedTimeUnixNano = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogs(dAtA[iNdEx:])
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp // import "go.opentelemetry.io/collector/pdata/internal/otlp"
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	pbContentType   = "application/x-protobuf"
+	jsonContentType = "application/json"
+
+	// DefaultMaxRequestBodySize is the default maximum size of the decompressed bodies of the requests,
+	// the same as the default of the confighttp servers.
+	DefaultMaxRequestBodySize = 20 * 1024 * 1024
+)
+
+// Pre-computed status with code=Internal to be used in case of a marshaling error.
+var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error message"}`)
+
+// HTTPEncoding is the encoding of the bodies of an OTLP/HTTP request and of its response.
+type HTTPEncoding int
+
+const (
+	HTTPEncodingProto HTTPEncoding = iota
+	HTTPEncodingJSON
+)
+
+// ContentType returns the media type of the encoding.
+func (e HTTPEncoding) ContentType() string {
+	if e == HTTPEncodingJSON {
+		return jsonContentType
+	}
+	return pbContentType
+}
+
+// ReadHTTPRequest checks the method and the Content-Type of an OTLP/HTTP request, and returns its
+// decompressed body, which must not be larger than maxBodySize bytes. If the request cannot be served,
+// the error response is written and false is returned.
+func ReadHTTPRequest(w http.ResponseWriter, r *http.Request, maxBodySize int64) (HTTPEncoding, []byte, bool) {
+	if r.Method != http.MethodPost {
+		writeText(w, http.StatusMethodNotAllowed, fmt.Sprintf("%v method not allowed, supported: [POST]", http.StatusMethodNotAllowed))
+		return 0, nil, false
+	}
+
+	var enc HTTPEncoding
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case pbContentType:
+		enc = HTTPEncodingProto
+	case jsonContentType:
+		enc = HTTPEncodingJSON
+	default:
+		writeText(w, http.StatusUnsupportedMediaType, fmt.Sprintf("%v unsupported media type, supported: [%s, %s]",
+			http.StatusUnsupportedMediaType, jsonContentType, pbContentType))
+		return 0, nil, false
+	}
+
+	defer r.Body.Close()
+	var body io.Reader
+	switch ce := r.Header.Get("Content-Encoding"); ce {
+	case "", "identity":
+		body = r.Body
+	case "gzip":
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			WriteHTTPError(w, enc, status.Error(codes.InvalidArgument, err.Error()))
+			return 0, nil, false
+		}
+		defer gr.Close()
+		body = gr
+	case "deflate":
+		zr, err := zlib.NewReader(r.Body)
+		if err != nil {
+			WriteHTTPError(w, enc, status.Error(codes.InvalidArgument, err.Error()))
+			return 0, nil, false
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		writeText(w, http.StatusUnsupportedMediaType, fmt.Sprintf("%v unsupported content encoding %q, supported: [gzip, deflate]",
+			http.StatusUnsupportedMediaType, ce))
+		return 0, nil, false
+	}
+
+	// Read one more byte than allowed to detect the larger bodies, without decompressing them entirely.
+	buf, err := io.ReadAll(io.LimitReader(body, maxBodySize+1))
+	if err != nil {
+		WriteHTTPError(w, enc, status.Error(codes.InvalidArgument, err.Error()))
+		return 0, nil, false
+	}
+	if int64(len(buf)) > maxBodySize {
+		writeText(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("%v request body too large, the limit is %d bytes",
+			http.StatusRequestEntityTooLarge, maxBodySize))
+		return 0, nil, false
+	}
+	return enc, buf, true
+}
+
+// WriteHTTPResponse writes the encoded response of a successful OTLP/HTTP request.
+func WriteHTTPResponse(w http.ResponseWriter, enc HTTPEncoding, msg []byte) {
+	writeResponse(w, enc.ContentType(), http.StatusOK, msg)
+}
+
+// WriteHTTPError writes the error of an OTLP/HTTP request as an encoded rpc.Status message, as required by
+// the OTLP protocol. The errors with a gRPC status are converted to the matching HTTP status code,
+// the other errors are considered as Unknown, as the gRPC servers do.
+func WriteHTTPError(w http.ResponseWriter, enc HTTPEncoding, err error) {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+	statusCode := HTTPStatusCodeFromCode(s.Code())
+	if statusCode == http.StatusTooManyRequests {
+		for _, detail := range s.Details() {
+			if ri, ok := detail.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(ri.GetRetryDelay().AsDuration().Seconds()))))
+				break
+			}
+		}
+	}
+
+	var msg []byte
+	if enc == HTTPEncodingJSON {
+		msg, err = protojson.Marshal(s.Proto())
+	} else {
+		msg, err = proto.Marshal(s.Proto())
+	}
+	if err != nil {
+		writeResponse(w, jsonContentType, http.StatusInternalServerError, fallbackMsg)
+		return
+	}
+	writeResponse(w, enc.ContentType(), statusCode, msg)
+}
+
+// HTTPStatusCodeFromCode returns the OTLP/HTTP status code matching the gRPC code, see
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#failures-1.
+func HTTPStatusCodeFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	// Not Retryable
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	// Retryable
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return http.StatusServiceUnavailable
+	// Retryable
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	// Not Retryable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeText(w http.ResponseWriter, statusCode int, msg string) {
+	writeResponse(w, "text/plain", statusCode, []byte(msg))
+}
+
+func writeResponse(w http.ResponseWriter, contentType string, statusCode int, msg []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	// Nothing we can do with the error if we cannot write to the response.
+	_, _ = w.Write(msg)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp // import "go.opentelemetry.io/collector/pdata/internal/otlp"
+
+import (
+	"encoding/binary"
+	"io"
+	"slices"
+)
+
+// SizedMarshaler is implemented by the generated protobuf messages.
+type SizedMarshaler interface {
+	Size() int
+	MarshalToSizedBuffer(dAtA []byte) (int, error)
+}
+
+// WriteProtoFieldOne writes into w the OTLP/protobuf encoding of a message whose only field is the repeated
+// message field 1 holding msgs, e.g. the ResourceSpans of TracesData. The messages are encoded one at a time,
+// each preceded by the tag and the length of the field, so that only the encoding of the largest message is held
+// in memory instead of the whole encoding.
+func WriteProtoFieldOne[T SizedMarshaler](w io.Writer, msgs []T) error {
+	var buf []byte
+	for _, msg := range msgs {
+		size := msg.Size()
+		// The tag of the field 1 with the length-delimited wire type, followed by the length of the message.
+		buf = binary.AppendUvarint(append(buf[:0], 0xa), uint64(size))
+		n := len(buf)
+		buf = slices.Grow(buf, size)[:n+size]
+		if _, err := msg.MarshalToSizedBuffer(buf[n:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,19 +4,65 @@
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
 // State defines an ownership state of pmetric.Metrics, plog.Logs or ptrace.Traces.
-type State int32
+type State struct {
+	readOnly bool
+	// shared holds the copy functions of the subtrees shared with another instance, keyed by the pointer
+	// to the field holding them. A shared subtree is copied before being accessed for the first time.
+	shared map[any]func()
+}
 
-const (
+var (
 	// StateMutable indicates that the data is exclusive to the current consumer.
-	StateMutable State = iota
+	StateMutable = State{}
 
 	// StateReadOnly indicates that the data is shared with other consumers.
-	StateReadOnly
+	StateReadOnly = State{readOnly: true}
 )
 
 // AssertMutable panics if the state is not StateMutable.
 func (state *State) AssertMutable() {
-	if *state != StateMutable {
+	if state.readOnly {
 		panic("invalid access to shared data")
 	}
 }
+
+// IsReadOnly returns true if the state is StateReadOnly.
+func (state *State) IsReadOnly() bool {
+	return state.readOnly
+}
+
+// Share registers the subtree held by the field orig as shared with another instance,
+// copyShared replaces it by a copy once the subtree is accessed.
+func (state *State) Share(orig any, copyShared func()) {
+	if state.shared == nil {
+		state.shared = map[any]func(){}
+	}
+	state.shared[orig] = copyShared
+}
+
+// CopyIfShared copies the subtree held by the field orig if it is still shared, so that it can be modified.
+// The read-only data is never modified, so the subtrees keep being shared.
+func (state *State) CopyIfShared(orig any) {
+	if len(state.shared) == 0 || state.readOnly {
+		return
+	}
+	if copyShared, ok := state.shared[orig]; ok {
+		delete(state.shared, orig)
+		if len(state.shared) == 0 {
+			state.shared = nil
+		}
+		copyShared()
+	}
+}
+
+// CopyAllShared copies all the subtrees still shared, before the data is moved to another instance.
+func (state *State) CopyAllShared() {
+	if state.readOnly {
+		return
+	}
+	shared := state.shared
+	state.shared = nil
+	for _, copyShared := range shared {
+		copyShared()
+	}
+}
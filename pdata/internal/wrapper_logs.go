@@ -11,6 +11,8 @@ import (
 type Logs struct {
 	orig  *otlpcollectorlog.ExportLogsServiceRequest
 	state *State
+	// raw holds the OTLP/protobuf encoding this Logs was decoded from, see NewLogsWithRaw.
+	raw *[]byte
 }
 
 func GetOrigLogs(ms Logs) *otlpcollectorlog.ExportLogsServiceRequest {
@@ -25,6 +27,25 @@ func SetLogsState(ms Logs, state State) {
 	*ms.state = state
 }
 
+// NewLogsWithRaw returns a Logs that retains in raw the OTLP/protobuf encoding it was decoded from.
+// The retained encoding is only used while the Logs is read-only, see LogsRawProto.
+func NewLogsWithRaw(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State, raw *[]byte) Logs {
+	return Logs{orig: orig, state: state, raw: raw}
+}
+
+func GetLogsRaw(ms Logs) *[]byte {
+	return ms.raw
+}
+
+// LogsRawProto returns the retained OTLP/protobuf encoding of the given Logs, or nil if none is retained.
+// Since read-only data cannot be modified, the encoding is only returned for read-only Logs.
+func LogsRawProto(ms Logs) []byte {
+	if ms.raw == nil || ms.state == nil || !ms.state.IsReadOnly() {
+		return nil
+	}
+	return *ms.raw
+}
+
 func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State) Logs {
 	return Logs{orig: orig, state: state}
 }
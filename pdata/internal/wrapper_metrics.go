@@ -11,6 +11,8 @@ import (
 type Metrics struct {
 	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
 	state *State
+	// raw holds the OTLP/protobuf encoding this Metrics was decoded from, see NewMetricsWithRaw.
+	raw *[]byte
 }
 
 func GetOrigMetrics(ms Metrics) *otlpcollectormetrics.ExportMetricsServiceRequest {
@@ -25,6 +27,25 @@ func SetMetricsState(ms Metrics, state State) {
 	*ms.state = state
 }
 
+// NewMetricsWithRaw returns a Metrics that retains in raw the OTLP/protobuf encoding it was decoded from.
+// The retained encoding is only used while the Metrics is read-only, see MetricsRawProto.
+func NewMetricsWithRaw(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State, raw *[]byte) Metrics {
+	return Metrics{orig: orig, state: state, raw: raw}
+}
+
+func GetMetricsRaw(ms Metrics) *[]byte {
+	return ms.raw
+}
+
+// MetricsRawProto returns the retained OTLP/protobuf encoding of the given Metrics, or nil if none is retained.
+// Since read-only data cannot be modified, the encoding is only returned for read-only Metrics.
+func MetricsRawProto(ms Metrics) []byte {
+	if ms.raw == nil || ms.state == nil || !ms.state.IsReadOnly() {
+		return nil
+	}
+	return *ms.raw
+}
+
 func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State) Metrics {
 	return Metrics{orig: orig, state: state}
 }
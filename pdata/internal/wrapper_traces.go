@@ -11,6 +11,8 @@ import (
 type Traces struct {
 	orig  *otlpcollectortrace.ExportTraceServiceRequest
 	state *State
+	// raw holds the OTLP/protobuf encoding this Traces was decoded from, see NewTracesWithRaw.
+	raw *[]byte
 }
 
 func GetOrigTraces(ms Traces) *otlpcollectortrace.ExportTraceServiceRequest {
@@ -25,6 +27,25 @@ func SetTracesState(ms Traces, state State) {
 	*ms.state = state
 }
 
+// NewTracesWithRaw returns a Traces that retains in raw the OTLP/protobuf encoding it was decoded from.
+// The retained encoding is only used while the Traces is read-only, see TracesRawProto.
+func NewTracesWithRaw(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State, raw *[]byte) Traces {
+	return Traces{orig: orig, state: state, raw: raw}
+}
+
+func GetTracesRaw(ms Traces) *[]byte {
+	return ms.raw
+}
+
+// TracesRawProto returns the retained OTLP/protobuf encoding of the given Traces, or nil if none is retained.
+// Since read-only data cannot be modified, the encoding is only returned for read-only Traces.
+func TracesRawProto(ms Traces) []byte {
+	if ms.raw == nil || ms.state == nil || !ms.state.IsReadOnly() {
+		return nil
+	}
+	return *ms.raw
+}
+
 func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State) Traces {
 	return Traces{orig: orig, state: state}
 }
@@ -96,3 +96,10 @@ func (ms InstrumentationScope) CopyTo(dest InstrumentationScope) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Clone returns a deep copy of the InstrumentationScope in a new instance.
+func (ms InstrumentationScope) Clone() InstrumentationScope {
+	dest := NewInstrumentationScope()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestInstrumentationScope_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState)) })
 }
 
+func TestInstrumentationScope_Clone(t *testing.T) {
+	ms := InstrumentationScope(internal.GenerateTestInstrumentationScope())
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewInstrumentationScope().CopyTo(ms)
+	assert.Equal(t, InstrumentationScope(internal.GenerateTestInstrumentationScope()), clone)
+}
+
 func TestInstrumentationScope_Name(t *testing.T) {
 	ms := NewInstrumentationScope()
 	assert.Equal(t, "", ms.Name())
@@ -72,3 +72,10 @@ func (ms Resource) CopyTo(dest Resource) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Clone returns a deep copy of the Resource in a new instance.
+func (ms Resource) Clone() Resource {
+	dest := NewResource()
+	ms.CopyTo(dest)
+	return dest
+}
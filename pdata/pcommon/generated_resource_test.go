@@ -38,6 +38,14 @@ func TestResource_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newResource(&otlpresource.Resource{}, &sharedState)) })
 }
 
+func TestResource_Clone(t *testing.T) {
+	ms := Resource(internal.GenerateTestResource())
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewResource().CopyTo(ms)
+	assert.Equal(t, Resource(internal.GenerateTestResource()), clone)
+}
+
 func TestResource_Attributes(t *testing.T) {
 	ms := NewResource()
 	assert.Equal(t, NewMap(), ms.Attributes())
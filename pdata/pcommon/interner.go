@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"container/list"
+	"sync"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+// StringInterner deduplicates the strings of the attributes, so that the data holding the same keys and values,
+// e.g. the service names or the Kubernetes labels, references a single copy of them instead of the copies
+// allocated when decoding every request. It keeps up to a given number of strings, the least recently used
+// ones being evicted, which bounds its memory while keeping the strings repeated the most.
+//
+// The interning is opt-in, it is worth it when the data is held for a while, e.g. in the queues of a gateway
+// receiving the data of many similar sources. It is safe for concurrent use.
+type StringInterner struct {
+	capacity int
+
+	mu sync.Mutex
+	// strings maps the interned strings to their element in lru.
+	strings map[string]*list.Element
+	// lru holds the interned strings, the most recently used first.
+	lru *list.List
+}
+
+// NewStringInterner returns a StringInterner keeping up to capacity strings.
+func NewStringInterner(capacity int) *StringInterner {
+	return &StringInterner{
+		capacity: capacity,
+		strings:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Intern returns the interned string equal to s, s being interned if none is.
+func (si *StringInterner) Intern(s string) string {
+	if s == "" || si.capacity <= 0 {
+		return s
+	}
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if elem, ok := si.strings[s]; ok {
+		si.lru.MoveToFront(elem)
+		return elem.Value.(string)
+	}
+	if si.lru.Len() >= si.capacity {
+		oldest := si.lru.Back()
+		si.lru.Remove(oldest)
+		delete(si.strings, oldest.Value.(string))
+	}
+	si.strings[s] = si.lru.PushFront(s)
+	return s
+}
+
+// Len returns the number of interned strings.
+func (si *StringInterner) Len() int {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return si.lru.Len()
+}
+
+// Intern replaces the keys and the string values of the Map, including the ones of the nested maps
+// and slices, with the strings interned by si.
+func (m Map) Intern(si *StringInterner) {
+	m.getState().AssertMutable()
+	internKeyValues(si, *m.getOrig())
+}
+
+func internKeyValues(si *StringInterner, kvs []otlpcommon.KeyValue) {
+	for i := range kvs {
+		kvs[i].Key = si.Intern(kvs[i].Key)
+		internValue(si, &kvs[i].Value)
+	}
+}
+
+func internValue(si *StringInterner, orig *otlpcommon.AnyValue) {
+	switch v := orig.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		v.StringValue = si.Intern(v.StringValue)
+	case *otlpcommon.AnyValue_KvlistValue:
+		if v.KvlistValue != nil {
+			internKeyValues(si, v.KvlistValue.Values)
+		}
+	case *otlpcommon.AnyValue_ArrayValue:
+		if v.ArrayValue != nil {
+			for i := range v.ArrayValue.Values {
+				internValue(si, &v.ArrayValue.Values[i])
+			}
+		}
+	}
+}
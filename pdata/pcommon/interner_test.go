@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+func TestStringInterner(t *testing.T) {
+	si := NewStringInterner(2)
+	first := strings.Clone("service")
+	assert.Same(t, unsafe.StringData(first), unsafe.StringData(si.Intern(first)))
+	assert.Same(t, unsafe.StringData(first), unsafe.StringData(si.Intern(strings.Clone("service"))))
+	assert.Equal(t, 1, si.Len())
+	assert.Equal(t, "", si.Intern(""))
+	assert.Equal(t, 1, si.Len())
+
+	// The least recently used string is evicted.
+	si.Intern("namespace")
+	si.Intern(strings.Clone("service"))
+	si.Intern("pod")
+	assert.Equal(t, 2, si.Len())
+	assert.Same(t, unsafe.StringData(first), unsafe.StringData(si.Intern(strings.Clone("service"))))
+	other := strings.Clone("namespace")
+	assert.Same(t, unsafe.StringData(other), unsafe.StringData(si.Intern(other)))
+}
+
+func TestStringInternerDisabled(t *testing.T) {
+	si := NewStringInterner(0)
+	s := strings.Clone("service")
+	assert.Same(t, unsafe.StringData(s), unsafe.StringData(si.Intern(s)))
+	assert.NotSame(t, unsafe.StringData(s), unsafe.StringData(si.Intern(strings.Clone("service"))))
+	assert.Equal(t, 0, si.Len())
+}
+
+func TestStringInternerConcurrency(t *testing.T) {
+	si := NewStringInterner(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, "value", si.Intern(strings.Clone("value")))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, si.Len())
+}
+
+func TestMapIntern(t *testing.T) {
+	si := NewStringInterner(10)
+	interned := si.Intern(strings.Clone("value"))
+
+	m := NewMap()
+	m.PutStr(strings.Clone("key"), strings.Clone("value"))
+	m.PutInt("int", 1)
+	m.PutEmptyMap("map").PutStr("nested", strings.Clone("value"))
+	m.PutEmptySlice("slice").AppendEmpty().SetStr(strings.Clone("value"))
+	m.Intern(si)
+
+	assert.Equal(t, map[string]any{
+		"key":   "value",
+		"int":   int64(1),
+		"map":   map[string]any{"nested": "value"},
+		"slice": []any{"value"},
+	}, m.AsRaw())
+	for _, v := range []string{
+		(*m.getOrig())[0].Value.GetStringValue(),
+		(*m.getOrig())[2].Value.GetKvlistValue().Values[0].Value.GetStringValue(),
+		(*m.getOrig())[3].Value.GetArrayValue().Values[0].GetStringValue(),
+	} {
+		assert.Same(t, unsafe.StringData(interned), unsafe.StringData(v))
+	}
+	assert.Same(t, unsafe.StringData(si.Intern(strings.Clone("key"))), unsafe.StringData((*m.getOrig())[0].Key))
+
+	state := internal.StateReadOnly
+	readOnly := newMap(&[]otlpcommon.KeyValue{}, &state)
+	assert.Panics(t, func() { readOnly.Intern(si) })
+}
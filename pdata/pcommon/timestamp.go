@@ -4,9 +4,18 @@
 package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
+var (
+	// ErrZeroTimestamp is returned by Timestamp.Validate for the zero timestamps.
+	ErrZeroTimestamp = errors.New("timestamp is zero")
+	// ErrFutureTimestamp is returned by Timestamp.Validate for the timestamps too far in the future.
+	ErrFutureTimestamp = errors.New("timestamp is in the future")
+)
+
 // Timestamp is a time specified as UNIX Epoch time in nanoseconds since
 // 1970-01-01 00:00:00 +0000 UTC.
 type Timestamp uint64
@@ -25,3 +34,36 @@ func (ts Timestamp) AsTime() time.Time {
 func (ts Timestamp) String() string {
 	return ts.AsTime().String()
 }
+
+// Validate returns ErrZeroTimestamp if the timestamp is zero, or an error wrapping ErrFutureTimestamp if
+// the timestamp is later than now plus the maxFuture tolerance.
+func (ts Timestamp) Validate(now time.Time, maxFuture time.Duration) error {
+	if ts == 0 {
+		return ErrZeroTimestamp
+	}
+	if limit := NewTimestampFromTime(now.Add(maxFuture)); ts > limit {
+		return fmt.Errorf("%w: %v is later than %v", ErrFutureTimestamp, ts, limit)
+	}
+	return nil
+}
+
+// Clamp returns the timestamp limited to the [lower, upper] range.
+func (ts Timestamp) Clamp(lower, upper Timestamp) Timestamp {
+	if ts < lower {
+		return lower
+	}
+	if ts > upper {
+		return upper
+	}
+	return ts
+}
+
+// AdjustStartTimestamp returns the start timestamp, moved back to ts if it is later than ts, so that
+// the start timestamp of a data point is never later than its timestamp. A zero start timestamp is unset,
+// and returned as is.
+func AdjustStartTimestamp(start, ts Timestamp) Timestamp {
+	if start > ts {
+		return ts
+	}
+	return start
+}
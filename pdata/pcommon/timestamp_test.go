@@ -25,3 +25,27 @@ func TestZeroTimestamp(t *testing.T) {
 	assert.Zero(t, NewTimestampFromTime(time.Unix(0, 0).UTC()))
 	assert.Equal(t, "1970-01-01 00:00:00 +0000 UTC", Timestamp(0).String())
 }
+
+func TestTimestampValidate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, Timestamp(0).Validate(now, time.Minute), ErrZeroTimestamp)
+	assert.NoError(t, NewTimestampFromTime(now.Add(-time.Hour)).Validate(now, 0))
+	assert.NoError(t, NewTimestampFromTime(now.Add(time.Minute)).Validate(now, time.Minute))
+
+	err := NewTimestampFromTime(now.Add(time.Minute+1)).Validate(now, time.Minute)
+	assert.ErrorIs(t, err, ErrFutureTimestamp)
+	assert.EqualError(t, err, "timestamp is in the future: 2024-01-01 00:01:00.000000001 +0000 UTC is later than 2024-01-01 00:01:00 +0000 UTC")
+}
+
+func TestTimestampClamp(t *testing.T) {
+	assert.Equal(t, Timestamp(10), Timestamp(5).Clamp(10, 20))
+	assert.Equal(t, Timestamp(15), Timestamp(15).Clamp(10, 20))
+	assert.Equal(t, Timestamp(20), Timestamp(25).Clamp(10, 20))
+}
+
+func TestAdjustStartTimestamp(t *testing.T) {
+	assert.Equal(t, Timestamp(0), AdjustStartTimestamp(0, 10))
+	assert.Equal(t, Timestamp(5), AdjustStartTimestamp(5, 10))
+	assert.Equal(t, Timestamp(10), AdjustStartTimestamp(10, 10))
+	assert.Equal(t, Timestamp(10), AdjustStartTimestamp(15, 10))
+}
@@ -3,6 +3,8 @@
 
 package plog // import "go.opentelemetry.io/collector/pdata/plog"
 
+import "io"
+
 // MarshalSizer is the interface that groups the basic Marshal and Size methods
 type MarshalSizer interface {
 	Marshaler
@@ -29,3 +31,11 @@ type Sizer interface {
 	// LogsSize returns the size in bytes of a marshaled Logs.
 	LogsSize(ld Logs) int
 }
+
+// StreamMarshaler is an optional interface implemented by the Marshaler,
+// that writes a marshaled Logs directly into an io.Writer.
+type StreamMarshaler interface {
+	// MarshalLogsTo marshals the given pdata.Logs and writes the result into w.
+	// If the error is not nil, the data written to w cannot be used.
+	MarshalLogsTo(w io.Writer, ld Logs) error
+}
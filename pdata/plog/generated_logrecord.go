@@ -145,6 +145,17 @@ func (ms LogRecord) SetDroppedAttributesCount(v uint32) {
 	ms.orig.DroppedAttributesCount = v
 }
 
+// EventName returns the eventname associated with this LogRecord.
+func (ms LogRecord) EventName() string {
+	return ms.orig.EventName
+}
+
+// SetEventName replaces the eventname associated with this LogRecord.
+func (ms LogRecord) SetEventName(v string) {
+	ms.state.AssertMutable()
+	ms.orig.EventName = v
+}
+
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms LogRecord) CopyTo(dest LogRecord) {
 	dest.state.AssertMutable()
@@ -158,4 +169,12 @@ func (ms LogRecord) CopyTo(dest LogRecord) {
 	ms.Body().CopyTo(dest.Body())
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
+	dest.SetEventName(ms.EventName())
+}
+
+// Clone returns a deep copy of the LogRecord in a new instance.
+func (ms LogRecord) Clone() LogRecord {
+	dest := NewLogRecord()
+	ms.CopyTo(dest)
+	return dest
 }
@@ -40,6 +40,14 @@ func TestLogRecord_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newLogRecord(&otlplogs.LogRecord{}, &sharedState)) })
 }
 
+func TestLogRecord_Clone(t *testing.T) {
+	ms := generateTestLogRecord()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewLogRecord().CopyTo(ms)
+	assert.Equal(t, generateTestLogRecord(), clone)
+}
+
 func TestLogRecord_ObservedTimestamp(t *testing.T) {
 	ms := NewLogRecord()
 	assert.Equal(t, pcommon.Timestamp(0), ms.ObservedTimestamp())
@@ -119,6 +127,15 @@ func TestLogRecord_DroppedAttributesCount(t *testing.T) {
 	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetDroppedAttributesCount(uint32(17)) })
 }
 
+func TestLogRecord_EventName(t *testing.T) {
+	ms := NewLogRecord()
+	assert.Equal(t, "", ms.EventName())
+	ms.SetEventName("test_event")
+	assert.Equal(t, "test_event", ms.EventName())
+	sharedState := internal.StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetEventName("test_event") })
+}
+
 func generateTestLogRecord() LogRecord {
 	tv := NewLogRecord()
 	fillTestLogRecord(tv)
@@ -136,4 +153,5 @@ func fillTestLogRecord(tv LogRecord) {
 	internal.FillTestValue(internal.NewValue(&tv.orig.Body, tv.state))
 	internal.FillTestMap(internal.NewMap(&tv.orig.Attributes, tv.state))
 	tv.orig.DroppedAttributesCount = uint32(17)
+	tv.orig.EventName = "test_event"
 }
@@ -88,12 +88,33 @@ func (es LogRecordSlice) AppendEmpty() LogRecord {
 	return es.At(es.Len() - 1)
 }
 
+// AppendEmptyN will append to the end of the slice n empty LogRecord, allocated at once.
+// The newly added elements are at the indexes [Len()-n, Len()), e.g.:
+//
+//	es.AppendEmptyN(len(events))
+//	for i, ev := range events {
+//	    e := es.At(es.Len() - len(events) + i)
+//	    // Here should set all the values for e from ev.
+//	}
+func (es LogRecordSlice) AppendEmptyN(n int) {
+	es.state.AssertMutable()
+	if n <= 0 {
+		return
+	}
+	oldLen := len(*es.orig)
+	*es.orig = append(*es.orig, make([]*otlplogs.LogRecord, n)...)
+	origs := make([]otlplogs.LogRecord, n)
+	for i := range origs {
+		(*es.orig)[oldLen+i] = &origs[i]
+	}
+}
+
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es LogRecordSlice) MoveAndAppendTo(dest LogRecordSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
-	if *dest.orig == nil {
+	if len(*dest.orig) == 0 && cap(*dest.orig) < len(*es.orig) {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
 	} else {
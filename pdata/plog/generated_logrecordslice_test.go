@@ -39,6 +39,7 @@ func TestLogRecordSliceReadOnly(t *testing.T) {
 	es := newLogRecordSlice(&[]*otlplogs.LogRecord{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewLogRecordSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestLogRecordSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestLogRecordSlice(), es)
 }
 
+func TestLogRecordSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestLogRecordSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestLogRecordSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewLogRecord()
+	testVal := generateTestLogRecord()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestLogRecord(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestLogRecordSlice().At(i), es.At(i))
+	}
+}
+
 func TestLogRecordSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestLogRecordSlice()
@@ -103,6 +123,15 @@ func TestLogRecordSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewLogRecordSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestLogRecordSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestLogRecordSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestLogRecordSlice_RemoveIf(t *testing.T) {
@@ -42,6 +42,8 @@ func NewResourceLogs() ResourceLogs {
 func (ms ResourceLogs) MoveTo(dest ResourceLogs) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ResourceLogs{}
 }
@@ -64,6 +66,7 @@ func (ms ResourceLogs) SetSchemaUrl(v string) {
 
 // ScopeLogs returns the ScopeLogs associated with this ResourceLogs.
 func (ms ResourceLogs) ScopeLogs() ScopeLogsSlice {
+	ms.state.CopyIfShared(&ms.orig.ScopeLogs)
 	return newScopeLogsSlice(&ms.orig.ScopeLogs, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeLogs().CopyTo(dest.ScopeLogs())
 }
+
+// Clone returns a deep copy of the ResourceLogs in a new instance.
+func (ms ResourceLogs) Clone() ResourceLogs {
+	dest := NewResourceLogs()
+	ms.CopyTo(dest)
+	return dest
+}
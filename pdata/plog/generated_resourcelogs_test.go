@@ -39,6 +39,14 @@ func TestResourceLogs_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newResourceLogs(&otlplogs.ResourceLogs{}, &sharedState)) })
 }
 
+func TestResourceLogs_Clone(t *testing.T) {
+	ms := generateTestResourceLogs()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewResourceLogs().CopyTo(ms)
+	assert.Equal(t, generateTestResourceLogs(), clone)
+}
+
 func TestResourceLogs_Resource(t *testing.T) {
 	ms := NewResourceLogs()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
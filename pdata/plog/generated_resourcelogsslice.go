@@ -88,12 +88,35 @@ func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
 	return es.At(es.Len() - 1)
 }
 
+// AppendEmptyN will append to the end of the slice n empty ResourceLogs, allocated at once.
+// The newly added elements are at the indexes [Len()-n, Len()), e.g.:
+//
+//	es.AppendEmptyN(len(events))
+//	for i, ev := range events {
+//	    e := es.At(es.Len() - len(events) + i)
+//	    // Here should set all the values for e from ev.
+//	}
+func (es ResourceLogsSlice) AppendEmptyN(n int) {
+	es.state.AssertMutable()
+	if n <= 0 {
+		return
+	}
+	oldLen := len(*es.orig)
+	*es.orig = append(*es.orig, make([]*otlplogs.ResourceLogs, n)...)
+	origs := make([]otlplogs.ResourceLogs, n)
+	for i := range origs {
+		(*es.orig)[oldLen+i] = &origs[i]
+	}
+}
+
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
-	if *dest.orig == nil {
+	// The subtrees shared with another instance cannot be moved out of this one.
+	es.state.CopyAllShared()
+	if len(*dest.orig) == 0 && cap(*dest.orig) < len(*es.orig) {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
 	} else {
@@ -39,6 +39,7 @@ func TestResourceLogsSliceReadOnly(t *testing.T) {
 	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewResourceLogsSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestResourceLogsSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestResourceLogsSlice(), es)
 }
 
+func TestResourceLogsSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestResourceLogsSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewResourceLogs()
+	testVal := generateTestResourceLogs()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestResourceLogs(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestResourceLogsSlice().At(i), es.At(i))
+	}
+}
+
 func TestResourceLogsSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestResourceLogsSlice()
@@ -103,6 +123,15 @@ func TestResourceLogsSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewResourceLogsSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestResourceLogsSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestResourceLogsSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestResourceLogsSlice_RemoveIf(t *testing.T) {
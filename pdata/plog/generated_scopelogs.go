@@ -42,6 +42,8 @@ func NewScopeLogs() ScopeLogs {
 func (ms ScopeLogs) MoveTo(dest ScopeLogs) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ScopeLogs{}
 }
@@ -64,6 +66,7 @@ func (ms ScopeLogs) SetSchemaUrl(v string) {
 
 // LogRecords returns the LogRecords associated with this ScopeLogs.
 func (ms ScopeLogs) LogRecords() LogRecordSlice {
+	ms.state.CopyIfShared(&ms.orig.LogRecords)
 	return newLogRecordSlice(&ms.orig.LogRecords, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ScopeLogs) CopyTo(dest ScopeLogs) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.LogRecords().CopyTo(dest.LogRecords())
 }
+
+// Clone returns a deep copy of the ScopeLogs in a new instance.
+func (ms ScopeLogs) Clone() ScopeLogs {
+	dest := NewScopeLogs()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -39,6 +39,14 @@ func TestScopeLogs_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newScopeLogs(&otlplogs.ScopeLogs{}, &sharedState)) })
 }
 
+func TestScopeLogs_Clone(t *testing.T) {
+	ms := generateTestScopeLogs()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewScopeLogs().CopyTo(ms)
+	assert.Equal(t, generateTestScopeLogs(), clone)
+}
+
 func TestScopeLogs_Scope(t *testing.T) {
 	ms := NewScopeLogs()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
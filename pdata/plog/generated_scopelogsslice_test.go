@@ -39,6 +39,7 @@ func TestScopeLogsSliceReadOnly(t *testing.T) {
 	es := newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewScopeLogsSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestScopeLogsSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestScopeLogsSlice(), es)
 }
 
+func TestScopeLogsSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestScopeLogsSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestScopeLogsSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewScopeLogs()
+	testVal := generateTestScopeLogs()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestScopeLogs(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestScopeLogsSlice().At(i), es.At(i))
+	}
+}
+
 func TestScopeLogsSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestScopeLogsSlice()
@@ -103,6 +123,15 @@ func TestScopeLogsSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewScopeLogsSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestScopeLogsSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestScopeLogsSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestScopeLogsSlice_RemoveIf(t *testing.T) {
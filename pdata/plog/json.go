@@ -6,6 +6,7 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -15,6 +16,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
+var _ StreamMarshaler = (*JSONMarshaler)(nil)
+
 // JSONMarshaler marshals pdata.Logs to JSON bytes using the OTLP/JSON format.
 type JSONMarshaler struct{}
 
@@ -26,6 +29,12 @@ func (*JSONMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalLogsTo writes the OTLP/JSON encoding of the given pdata.Logs into w.
+func (*JSONMarshaler) MarshalLogsTo(w io.Writer, ld Logs) error {
+	pb := internal.LogsToProto(internal.Logs(ld))
+	return json.Marshal(w, &pb)
+}
+
 var _ Unmarshaler = (*JSONUnmarshaler)(nil)
 
 // JSONUnmarshaler unmarshals OTLP/JSON formatted-bytes to pdata.Logs.
@@ -127,6 +136,8 @@ func (ms LogRecord) unmarshalJsoniter(iter *jsoniter.Iterator) {
 			if err := ms.orig.SpanId.UnmarshalJSON([]byte(iter.ReadString())); err != nil {
 				iter.ReportError("readLog.spanId", fmt.Sprintf("parse span_id:%v", err))
 			}
+		case "eventName", "event_name":
+			ms.orig.EventName = iter.ReadString()
 		default:
 			iter.Skip()
 		}
@@ -6,6 +6,8 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
@@ -32,7 +34,7 @@ func NewLogs() Logs {
 
 // IsReadOnly returns true if this Logs instance is read-only.
 func (ms Logs) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Logs instance overriding the destination.
@@ -43,7 +45,7 @@ func (ms Logs) CopyTo(dest Logs) {
 // LogRecordCount calculates the total number of log records.
 func (ms Logs) LogRecordCount() int {
 	logCount := 0
-	rss := ms.ResourceLogs()
+	rss := ms.readOnlyResourceLogs()
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
 		ill := rs.ScopeLogs()
@@ -64,3 +66,110 @@ func (ms Logs) ResourceLogs() ResourceLogsSlice {
 func (ms Logs) MarkReadOnly() {
 	internal.SetLogsState(internal.Logs(ms), internal.StateReadOnly)
 }
+
+// Clone returns a deep copy of the Logs in a new instance.
+func (ms Logs) Clone() Logs {
+	dest := NewLogs()
+	ms.CopyTo(dest)
+	return dest
+}
+
+// CloneResource returns a copy of the Logs in which only the resources are copied, the ScopeLogs are
+// shared with the Logs and copied on write, i.e. the ScopeLogs of a resource are copied when first
+// accessed in the returned Logs. This makes modifying the resources, e.g. adding a resource attribute
+// to read-only data, much cheaper than with Clone. Since the ScopeLogs are shared, the Logs is marked
+// as read-only.
+func (ms Logs) CloneResource() Logs {
+	dest := NewLogs()
+	state := dest.getState()
+	srcResourceLogs := ms.readOnlyResourceLogs()
+	destResourceLogs := dest.ResourceLogs()
+	destResourceLogs.EnsureCapacity(srcResourceLogs.Len())
+	for i := 0; i < srcResourceLogs.Len(); i++ {
+		srcR := srcResourceLogs.At(i)
+		destR := destResourceLogs.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		destR.orig.ScopeLogs = srcR.orig.ScopeLogs
+		shareScopeLogs(state, destR.orig)
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// CloneScope returns a copy of the Logs in which only the resources and the scopes are copied, the log records are
+// shared with the Logs and copied on write, i.e. the log records of a scope are copied when first accessed in the
+// returned Logs. This makes modifying the resources or the scopes much cheaper than with Clone.
+// Since the log records are shared, the Logs is marked as read-only.
+func (ms Logs) CloneScope() Logs {
+	dest := NewLogs()
+	state := dest.getState()
+	srcResourceLogs := ms.readOnlyResourceLogs()
+	destResourceLogs := dest.ResourceLogs()
+	destResourceLogs.EnsureCapacity(srcResourceLogs.Len())
+	for i := 0; i < srcResourceLogs.Len(); i++ {
+		srcR := srcResourceLogs.At(i)
+		destR := destResourceLogs.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		srcScopeLogs := srcR.ScopeLogs()
+		destScopeLogs := destR.ScopeLogs()
+		destScopeLogs.EnsureCapacity(srcScopeLogs.Len())
+		for j := 0; j < srcScopeLogs.Len(); j++ {
+			srcS := srcScopeLogs.At(j)
+			destS := destScopeLogs.AppendEmpty()
+			srcS.Scope().CopyTo(destS.Scope())
+			destS.SetSchemaUrl(srcS.SchemaUrl())
+			destS.orig.LogRecords = srcS.orig.LogRecords
+			shareLogRecords(state, destS.orig)
+		}
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// InternAttributes replaces the attribute keys and string values of the resources, scopes and log records
+// with the strings interned by si, so that the data repeating them shares a single copy.
+func (ms Logs) InternAttributes(si *pcommon.StringInterner) {
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		rl.Resource().Attributes().Intern(si)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			sl.Scope().Attributes().Intern(si)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lrs.At(k).Attributes().Intern(si)
+			}
+		}
+	}
+}
+
+// readOnlyResourceLogs returns the ResourceLogsSlice through a read-only state,
+// so that the subtrees shared with another instance are read without being copied.
+func (ms Logs) readOnlyResourceLogs() ResourceLogsSlice {
+	state := internal.StateReadOnly
+	return newResourceLogsSlice(&ms.getOrig().ResourceLogs, &state)
+}
+
+// shareScopeLogs registers the ScopeLogs of the ResourceLogs as shared, they are copied when first accessed.
+func shareScopeLogs(state *internal.State, orig *otlplogs.ResourceLogs) {
+	state.Share(&orig.ScopeLogs, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.ScopeLogs
+		orig.ScopeLogs = nil
+		newScopeLogsSlice(&shared, &sharedState).CopyTo(newScopeLogsSlice(&orig.ScopeLogs, state))
+	})
+}
+
+// shareLogRecords registers the log records of the ScopeLogs as shared, they are copied when first accessed.
+func shareLogRecords(state *internal.State, orig *otlplogs.ScopeLogs) {
+	state.Share(&orig.LogRecords, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.LogRecords
+		orig.LogRecords = nil
+		newLogRecordSlice(&shared, &sharedState).CopyTo(newLogRecordSlice(&orig.LogRecords, state))
+	})
+}
@@ -4,8 +4,10 @@
 package plog
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +127,59 @@ func TestReadOnlyLogsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestLogsCloneResource(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	clone := logs.CloneResource()
+	assert.True(t, logs.IsReadOnly())
+	assert.Equal(t, logs.LogRecordCount(), clone.LogRecordCount())
+	assert.Same(t, &logs.getOrig().ResourceLogs[0].ScopeLogs[0], &clone.getOrig().ResourceLogs[0].ScopeLogs[0])
+
+	clone.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).CopyTo(clone.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+	assert.NotSame(t, &logs.getOrig().ResourceLogs[0].ScopeLogs[0], &clone.getOrig().ResourceLogs[0].ScopeLogs[0])
+	assert.Equal(t, generateTestResourceLogsSlice().orig, logs.ResourceLogs().orig)
+}
+
+func TestLogsCloneScope(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	clone := logs.CloneScope()
+	assert.True(t, logs.IsReadOnly())
+	assert.Equal(t, logs.getOrig(), clone.getOrig())
+	assert.Same(t, &logs.getOrig().ResourceLogs[0].ScopeLogs[0].LogRecords[0], &clone.getOrig().ResourceLogs[0].ScopeLogs[0].LogRecords[0])
+
+	clone.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).CopyTo(clone.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+	assert.NotSame(t, &logs.getOrig().ResourceLogs[0].ScopeLogs[0].LogRecords[0], &clone.getOrig().ResourceLogs[0].ScopeLogs[0].LogRecords[0])
+	assert.Equal(t, generateTestResourceLogsSlice().orig, logs.ResourceLogs().orig)
+}
+
+func TestLogsInternAttributes(t *testing.T) {
+	logs := NewLogs()
+	for i := 0; i < 2; i++ {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr(strings.Clone("service.name"), strings.Clone("svc"))
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().Attributes().PutStr("scope", strings.Clone("svc"))
+		sl.LogRecords().AppendEmpty().Attributes().PutStr("record", strings.Clone("svc"))
+	}
+	expected := NewLogs()
+	logs.CopyTo(expected)
+	si := pcommon.NewStringInterner(10)
+	logs.InternAttributes(si)
+	assert.Equal(t, expected, logs)
+
+	svc := unsafe.StringData(si.Intern(strings.Clone("svc")))
+	for _, rl := range logs.getOrig().ResourceLogs {
+		assert.Same(t, svc, unsafe.StringData(rl.Resource.Attributes[0].Value.GetStringValue()))
+		sl := rl.ScopeLogs[0]
+		assert.Same(t, svc, unsafe.StringData(sl.Scope.Attributes[0].Value.GetStringValue()))
+		assert.Same(t, svc, unsafe.StringData(sl.LogRecords[0].Attributes[0].Value.GetStringValue()))
+	}
+
+	logs.MarkReadOnly()
+	assert.Panics(t, func() { logs.InternAttributes(si) })
+}
+
 func BenchmarkLogsUsage(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
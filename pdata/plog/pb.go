@@ -4,20 +4,44 @@
 package plog // import "go.opentelemetry.io/collector/pdata/plog"
 
 import (
+	"io"
+
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
+var _ StreamMarshaler = (*ProtoMarshaler)(nil)
 
 type ProtoMarshaler struct{}
 
+// MarshalLogs to the OTLP/protobuf format.
+// If the read-only ld retains the encoding it was decoded from, that encoding is returned as is.
 func (e *ProtoMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
+	if raw := internal.LogsRawProto(internal.Logs(ld)); raw != nil {
+		return raw, nil
+	}
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Marshal()
 }
 
+// MarshalLogsTo writes the OTLP/protobuf encoding of the given pdata.Logs into w. The ResourceLogs are encoded
+// and written one at a time, so that only the encoding of the largest one is held in memory.
+// If the read-only ld retains the encoding it was decoded from, that encoding is written as is.
+func (e *ProtoMarshaler) MarshalLogsTo(w io.Writer, ld Logs) error {
+	if raw := internal.LogsRawProto(internal.Logs(ld)); raw != nil {
+		_, err := w.Write(raw)
+		return err
+	}
+	pb := internal.LogsToProto(internal.Logs(ld))
+	return otlp.WriteProtoFieldOne(w, pb.ResourceLogs)
+}
+
 func (e *ProtoMarshaler) LogsSize(ld Logs) int {
+	if raw := internal.LogsRawProto(internal.Logs(ld)); raw != nil {
+		return len(raw)
+	}
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Size()
 }
@@ -4,6 +4,9 @@
 package plog
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +35,67 @@ func TestProtoSizer(t *testing.T) {
 
 }
 
+func TestProtoEventName(t *testing.T) {
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetEventName("device.app.lifecycle")
+
+	marshaler := &ProtoMarshaler{}
+	buf, err := marshaler.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, len(buf), marshaler.LogsSize(ld))
+
+	got, err := (&ProtoUnmarshaler{}).UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestProtoMarshalTo(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("foo", "bar")
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("foo", strings.Repeat("x", 200))
+	ld.ResourceLogs().AppendEmpty()
+
+	expected, err := marshaler.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	// The ResourceLogs are written one at a time.
+	w := &recordingWriter{}
+	require.NoError(t, marshaler.MarshalLogsTo(w, ld))
+	assert.Equal(t, expected, w.Bytes())
+	assert.Len(t, w.writes, 3)
+	assert.Less(t, w.writes[1], len(expected))
+
+	w = &recordingWriter{}
+	require.NoError(t, marshaler.MarshalLogsTo(w, NewLogs()))
+	assert.Empty(t, w.Bytes())
+}
+
+func TestProtoMarshalToWriterError(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	assert.Error(t, marshaler.MarshalLogsTo(errWriter{}, ld))
+}
+
+// recordingWriter records the sizes of the writes.
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return w.Buffer.Write(p)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
 func TestProtoSizerEmptyLogs(t *testing.T) {
 	sizer := &ProtoMarshaler{}
 	assert.Equal(t, 0, sizer.LogsSize(NewLogs()))
@@ -73,3 +73,10 @@ func (ms ExportPartialSuccess) CopyTo(dest ExportPartialSuccess) {
 	dest.SetRejectedLogRecords(ms.RejectedLogRecords())
 	dest.SetErrorMessage(ms.ErrorMessage())
 }
+
+// Clone returns a deep copy of the ExportPartialSuccess in a new instance.
+func (ms ExportPartialSuccess) Clone() ExportPartialSuccess {
+	dest := NewExportPartialSuccess()
+	ms.CopyTo(dest)
+	return dest
+}
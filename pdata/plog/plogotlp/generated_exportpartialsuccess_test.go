@@ -40,6 +40,14 @@ func TestExportPartialSuccess_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newExportPartialSuccess(&otlpcollectorlog.ExportLogsPartialSuccess{}, &sharedState)) })
 }
 
+func TestExportPartialSuccess_Clone(t *testing.T) {
+	ms := generateTestExportPartialSuccess()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExportPartialSuccess().CopyTo(ms)
+	assert.Equal(t, generateTestExportPartialSuccess(), clone)
+}
+
 func TestExportPartialSuccess_RejectedLogRecords(t *testing.T) {
 	ms := NewExportPartialSuccess()
 	assert.Equal(t, int64(0), ms.RejectedLogRecords())
@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -53,3 +56,47 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(logsRequestJSON)), ""), string(got))
 }
+
+func TestRequestProtoPassthrough(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityText("test_log")
+	buf, err := NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+	// Append an unknown field, which is dropped when the data is encoded again.
+	buf = append(buf, 0x98, 0x06, 0x01)
+
+	tr := NewExportRequest()
+	require.NoError(t, tr.UnmarshalProtoPassthrough(buf))
+	assert.True(t, tr.Logs().IsReadOnly())
+	assert.Equal(t, 1, tr.Logs().LogRecordCount())
+
+	got, err := tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+
+	got, err = (&plog.ProtoMarshaler{}).MarshalLogs(tr.Logs())
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	assert.Equal(t, len(buf), (&plog.ProtoMarshaler{}).LogsSize(tr.Logs()))
+
+	// A copy of the data does not retain the original encoding.
+	cp := plog.NewLogs()
+	tr.Logs().CopyTo(cp)
+	got, err = NewExportRequestFromLogs(cp).MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+
+	// Regular unmarshaling does not retain the original encoding.
+	tr = NewExportRequest()
+	require.NoError(t, tr.UnmarshalProto(buf))
+	got, err = tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+	assert.Equal(t, cp, tr.Logs())
+}
+
+func TestRequestProtoPassthroughError(t *testing.T) {
+	tr := NewExportRequest()
+	assert.Error(t, tr.UnmarshalProtoPassthrough([]byte("+$%")))
+	assert.False(t, tr.Logs().IsReadOnly())
+}
@@ -3,6 +3,8 @@
 
 package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
+import "io"
+
 // MarshalSizer is the interface that groups the basic Marshal and Size methods
 type MarshalSizer interface {
 	Marshaler
@@ -28,3 +30,11 @@ type Sizer interface {
 	// MetricsSize returns the size in bytes of a marshaled Metrics.
 	MetricsSize(md Metrics) int
 }
+
+// StreamMarshaler is an optional interface implemented by the Marshaler,
+// that writes a marshaled Metrics directly into an io.Writer.
+type StreamMarshaler interface {
+	// MarshalMetricsTo marshals the given pdata.Metrics and writes the result into w.
+	// If the error is not nil, the data written to w cannot be used.
+	MarshalMetricsTo(w io.Writer, md Metrics) error
+}
@@ -141,3 +141,10 @@ func (ms Exemplar) CopyTo(dest Exemplar) {
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 }
+
+// Clone returns a deep copy of the Exemplar in a new instance.
+func (ms Exemplar) Clone() Exemplar {
+	dest := NewExemplar()
+	ms.CopyTo(dest)
+	return dest
+}
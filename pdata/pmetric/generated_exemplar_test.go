@@ -40,6 +40,14 @@ func TestExemplar_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newExemplar(&otlpmetrics.Exemplar{}, &sharedState)) })
 }
 
+func TestExemplar_Clone(t *testing.T) {
+	ms := generateTestExemplar()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExemplar().CopyTo(ms)
+	assert.Equal(t, generateTestExemplar(), clone)
+}
+
 func TestExemplar_Timestamp(t *testing.T) {
 	ms := NewExemplar()
 	assert.Equal(t, pcommon.Timestamp(0), ms.Timestamp())
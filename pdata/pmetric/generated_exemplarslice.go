@@ -86,12 +86,28 @@ func (es ExemplarSlice) AppendEmpty() Exemplar {
 	return es.At(es.Len() - 1)
 }
 
+// AppendEmptyN will append to the end of the slice n empty Exemplar, allocated at once.
+// The newly added elements are at the indexes [Len()-n, Len()), e.g.:
+//
+//	es.AppendEmptyN(len(events))
+//	for i, ev := range events {
+//	    e := es.At(es.Len() - len(events) + i)
+//	    // Here should set all the values for e from ev.
+//	}
+func (es ExemplarSlice) AppendEmptyN(n int) {
+	es.state.AssertMutable()
+	if n <= 0 {
+		return
+	}
+	*es.orig = append(*es.orig, make([]otlpmetrics.Exemplar, n)...)
+}
+
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ExemplarSlice) MoveAndAppendTo(dest ExemplarSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
-	if *dest.orig == nil {
+	if len(*dest.orig) == 0 && cap(*dest.orig) < len(*es.orig) {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
 	} else {
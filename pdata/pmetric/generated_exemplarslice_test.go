@@ -38,6 +38,7 @@ func TestExemplarSliceReadOnly(t *testing.T) {
 	es := newExemplarSlice(&[]otlpmetrics.Exemplar{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewExemplarSlice()
 	es.CopyTo(es2)
@@ -79,6 +80,25 @@ func TestExemplarSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestExemplarSlice(), es)
 }
 
+func TestExemplarSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestExemplarSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestExemplarSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewExemplar()
+	testVal := generateTestExemplar()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestExemplar(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestExemplarSlice().At(i), es.At(i))
+	}
+}
+
 func TestExemplarSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestExemplarSlice()
@@ -102,6 +122,15 @@ func TestExemplarSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewExemplarSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestExemplarSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestExemplarSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestExemplarSlice_RemoveIf(t *testing.T) {
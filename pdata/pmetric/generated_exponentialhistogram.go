@@ -68,3 +68,10 @@ func (ms ExponentialHistogram) CopyTo(dest ExponentialHistogram) {
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Clone returns a deep copy of the ExponentialHistogram in a new instance.
+func (ms ExponentialHistogram) Clone() ExponentialHistogram {
+	dest := NewExponentialHistogram()
+	ms.CopyTo(dest)
+	return dest
+}
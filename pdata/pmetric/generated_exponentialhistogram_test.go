@@ -38,6 +38,14 @@ func TestExponentialHistogram_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &sharedState)) })
 }
 
+func TestExponentialHistogram_Clone(t *testing.T) {
+	ms := generateTestExponentialHistogram()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExponentialHistogram().CopyTo(ms)
+	assert.Equal(t, generateTestExponentialHistogram(), clone)
+}
+
 func TestExponentialHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewExponentialHistogram()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
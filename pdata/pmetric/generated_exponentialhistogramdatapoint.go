@@ -242,3 +242,10 @@ func (ms ExponentialHistogramDataPoint) CopyTo(dest ExponentialHistogramDataPoin
 
 	dest.SetZeroThreshold(ms.ZeroThreshold())
 }
+
+// Clone returns a deep copy of the ExponentialHistogramDataPoint in a new instance.
+func (ms ExponentialHistogramDataPoint) Clone() ExponentialHistogramDataPoint {
+	dest := NewExponentialHistogramDataPoint()
+	ms.CopyTo(dest)
+	return dest
+}
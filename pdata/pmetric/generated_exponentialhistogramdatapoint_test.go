@@ -45,6 +45,14 @@ func TestExponentialHistogramDataPoint_CopyTo(t *testing.T) {
 	})
 }
 
+func TestExponentialHistogramDataPoint_Clone(t *testing.T) {
+	ms := generateTestExponentialHistogramDataPoint()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExponentialHistogramDataPoint().CopyTo(ms)
+	assert.Equal(t, generateTestExponentialHistogramDataPoint(), clone)
+}
+
 func TestExponentialHistogramDataPoint_Attributes(t *testing.T) {
 	ms := NewExponentialHistogramDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
@@ -68,3 +68,10 @@ func (ms ExponentialHistogramDataPointBuckets) CopyTo(dest ExponentialHistogramD
 	dest.SetOffset(ms.Offset())
 	ms.BucketCounts().CopyTo(dest.BucketCounts())
 }
+
+// Clone returns a deep copy of the ExponentialHistogramDataPointBuckets in a new instance.
+func (ms ExponentialHistogramDataPointBuckets) Clone() ExponentialHistogramDataPointBuckets {
+	dest := NewExponentialHistogramDataPointBuckets()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -44,6 +44,14 @@ func TestExponentialHistogramDataPointBuckets_CopyTo(t *testing.T) {
 	})
 }
 
+func TestExponentialHistogramDataPointBuckets_Clone(t *testing.T) {
+	ms := generateTestExponentialHistogramDataPointBuckets()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExponentialHistogramDataPointBuckets().CopyTo(ms)
+	assert.Equal(t, generateTestExponentialHistogramDataPointBuckets(), clone)
+}
+
 func TestExponentialHistogramDataPointBuckets_Offset(t *testing.T) {
 	ms := NewExponentialHistogramDataPointBuckets()
 	assert.Equal(t, int32(0), ms.Offset())
@@ -39,6 +39,7 @@ func TestExponentialHistogramDataPointSliceReadOnly(t *testing.T) {
 	es := newExponentialHistogramDataPointSlice(&[]*otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewExponentialHistogramDataPointSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestExponentialHistogramDataPointSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestExponentialHistogramDataPointSlice(), es)
 }
 
+func TestExponentialHistogramDataPointSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestExponentialHistogramDataPointSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestExponentialHistogramDataPointSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewExponentialHistogramDataPoint()
+	testVal := generateTestExponentialHistogramDataPoint()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestExponentialHistogramDataPoint(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestExponentialHistogramDataPointSlice().At(i), es.At(i))
+	}
+}
+
 func TestExponentialHistogramDataPointSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestExponentialHistogramDataPointSlice()
@@ -103,6 +123,15 @@ func TestExponentialHistogramDataPointSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewExponentialHistogramDataPointSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestExponentialHistogramDataPointSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestExponentialHistogramDataPointSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestExponentialHistogramDataPointSlice_RemoveIf(t *testing.T) {
@@ -55,3 +55,10 @@ func (ms Gauge) CopyTo(dest Gauge) {
 	dest.state.AssertMutable()
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Clone returns a deep copy of the Gauge in a new instance.
+func (ms Gauge) Clone() Gauge {
+	dest := NewGauge()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestGauge_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newGauge(&otlpmetrics.Gauge{}, &sharedState)) })
 }
 
+func TestGauge_Clone(t *testing.T) {
+	ms := generateTestGauge()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewGauge().CopyTo(ms)
+	assert.Equal(t, generateTestGauge(), clone)
+}
+
 func TestGauge_DataPoints(t *testing.T) {
 	ms := NewGauge()
 	assert.Equal(t, NewNumberDataPointSlice(), ms.DataPoints())
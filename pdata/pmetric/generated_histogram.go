@@ -67,3 +67,10 @@ func (ms Histogram) CopyTo(dest Histogram) {
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Clone returns a deep copy of the Histogram in a new instance.
+func (ms Histogram) Clone() Histogram {
+	dest := NewHistogram()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestHistogram_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newHistogram(&otlpmetrics.Histogram{}, &sharedState)) })
 }
 
+func TestHistogram_Clone(t *testing.T) {
+	ms := generateTestHistogram()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewHistogram().CopyTo(ms)
+	assert.Equal(t, generateTestHistogram(), clone)
+}
+
 func TestHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewHistogram()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
@@ -203,3 +203,10 @@ func (ms HistogramDataPoint) CopyTo(dest HistogramDataPoint) {
 	}
 
 }
+
+// Clone returns a deep copy of the HistogramDataPoint in a new instance.
+func (ms HistogramDataPoint) Clone() HistogramDataPoint {
+	dest := NewHistogramDataPoint()
+	ms.CopyTo(dest)
+	return dest
+}
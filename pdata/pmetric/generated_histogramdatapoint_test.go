@@ -39,6 +39,14 @@ func TestHistogramDataPoint_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState)) })
 }
 
+func TestHistogramDataPoint_Clone(t *testing.T) {
+	ms := generateTestHistogramDataPoint()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewHistogramDataPoint().CopyTo(ms)
+	assert.Equal(t, generateTestHistogramDataPoint(), clone)
+}
+
 func TestHistogramDataPoint_Attributes(t *testing.T) {
 	ms := NewHistogramDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
@@ -39,6 +39,7 @@ func TestHistogramDataPointSliceReadOnly(t *testing.T) {
 	es := newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewHistogramDataPointSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestHistogramDataPointSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestHistogramDataPointSlice(), es)
 }
 
+func TestHistogramDataPointSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestHistogramDataPointSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestHistogramDataPointSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewHistogramDataPoint()
+	testVal := generateTestHistogramDataPoint()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestHistogramDataPoint(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestHistogramDataPointSlice().At(i), es.At(i))
+	}
+}
+
 func TestHistogramDataPointSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestHistogramDataPointSlice()
@@ -103,6 +123,15 @@ func TestHistogramDataPointSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewHistogramDataPointSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestHistogramDataPointSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestHistogramDataPointSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestHistogramDataPointSlice_RemoveIf(t *testing.T) {
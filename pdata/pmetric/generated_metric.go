@@ -247,3 +247,10 @@ func (ms Metric) CopyTo(dest Metric) {
 	}
 
 }
+
+// Clone returns a deep copy of the Metric in a new instance.
+func (ms Metric) Clone() Metric {
+	dest := NewMetric()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestMetric_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
+func TestMetric_Clone(t *testing.T) {
+	ms := generateTestMetric()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewMetric().CopyTo(ms)
+	assert.Equal(t, generateTestMetric(), clone)
+}
+
 func TestMetric_Name(t *testing.T) {
 	ms := NewMetric()
 	assert.Equal(t, "", ms.Name())
@@ -39,6 +39,7 @@ func TestMetricSliceReadOnly(t *testing.T) {
 	es := newMetricSlice(&[]*otlpmetrics.Metric{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewMetricSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestMetricSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestMetricSlice(), es)
 }
 
+func TestMetricSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestMetricSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestMetricSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewMetric()
+	testVal := generateTestMetric()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestMetric(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestMetricSlice().At(i), es.At(i))
+	}
+}
+
 func TestMetricSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestMetricSlice()
@@ -103,6 +123,15 @@ func TestMetricSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewMetricSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestMetricSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestMetricSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestMetricSlice_RemoveIf(t *testing.T) {
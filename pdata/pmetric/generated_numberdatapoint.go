@@ -143,3 +143,10 @@ func (ms NumberDataPoint) CopyTo(dest NumberDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 	dest.SetFlags(ms.Flags())
 }
+
+// Clone returns a deep copy of the NumberDataPoint in a new instance.
+func (ms NumberDataPoint) Clone() NumberDataPoint {
+	dest := NewNumberDataPoint()
+	ms.CopyTo(dest)
+	return dest
+}
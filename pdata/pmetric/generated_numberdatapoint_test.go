@@ -39,6 +39,14 @@ func TestNumberDataPoint_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState)) })
 }
 
+func TestNumberDataPoint_Clone(t *testing.T) {
+	ms := generateTestNumberDataPoint()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewNumberDataPoint().CopyTo(ms)
+	assert.Equal(t, generateTestNumberDataPoint(), clone)
+}
+
 func TestNumberDataPoint_Attributes(t *testing.T) {
 	ms := NewNumberDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
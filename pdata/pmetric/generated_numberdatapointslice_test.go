@@ -39,6 +39,7 @@ func TestNumberDataPointSliceReadOnly(t *testing.T) {
 	es := newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewNumberDataPointSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestNumberDataPointSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestNumberDataPointSlice(), es)
 }
 
+func TestNumberDataPointSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestNumberDataPointSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestNumberDataPointSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewNumberDataPoint()
+	testVal := generateTestNumberDataPoint()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestNumberDataPoint(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestNumberDataPointSlice().At(i), es.At(i))
+	}
+}
+
 func TestNumberDataPointSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestNumberDataPointSlice()
@@ -103,6 +123,15 @@ func TestNumberDataPointSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewNumberDataPointSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestNumberDataPointSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestNumberDataPointSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestNumberDataPointSlice_RemoveIf(t *testing.T) {
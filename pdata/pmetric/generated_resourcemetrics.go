@@ -42,6 +42,8 @@ func NewResourceMetrics() ResourceMetrics {
 func (ms ResourceMetrics) MoveTo(dest ResourceMetrics) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.ResourceMetrics{}
 }
@@ -64,6 +66,7 @@ func (ms ResourceMetrics) SetSchemaUrl(v string) {
 
 // ScopeMetrics returns the ScopeMetrics associated with this ResourceMetrics.
 func (ms ResourceMetrics) ScopeMetrics() ScopeMetricsSlice {
+	ms.state.CopyIfShared(&ms.orig.ScopeMetrics)
 	return newScopeMetricsSlice(&ms.orig.ScopeMetrics, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeMetrics().CopyTo(dest.ScopeMetrics())
 }
+
+// Clone returns a deep copy of the ResourceMetrics in a new instance.
+func (ms ResourceMetrics) Clone() ResourceMetrics {
+	dest := NewResourceMetrics()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -39,6 +39,14 @@ func TestResourceMetrics_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &sharedState)) })
 }
 
+func TestResourceMetrics_Clone(t *testing.T) {
+	ms := generateTestResourceMetrics()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewResourceMetrics().CopyTo(ms)
+	assert.Equal(t, generateTestResourceMetrics(), clone)
+}
+
 func TestResourceMetrics_Resource(t *testing.T) {
 	ms := NewResourceMetrics()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
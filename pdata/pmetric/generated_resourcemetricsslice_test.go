@@ -39,6 +39,7 @@ func TestResourceMetricsSliceReadOnly(t *testing.T) {
 	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewResourceMetricsSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestResourceMetricsSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestResourceMetricsSlice(), es)
 }
 
+func TestResourceMetricsSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestResourceMetricsSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewResourceMetrics()
+	testVal := generateTestResourceMetrics()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestResourceMetrics(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestResourceMetricsSlice().At(i), es.At(i))
+	}
+}
+
 func TestResourceMetricsSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestResourceMetricsSlice()
@@ -103,6 +123,15 @@ func TestResourceMetricsSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewResourceMetricsSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestResourceMetricsSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestResourceMetricsSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestResourceMetricsSlice_RemoveIf(t *testing.T) {
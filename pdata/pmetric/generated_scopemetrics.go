@@ -42,6 +42,8 @@ func NewScopeMetrics() ScopeMetrics {
 func (ms ScopeMetrics) MoveTo(dest ScopeMetrics) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.ScopeMetrics{}
 }
@@ -64,6 +66,7 @@ func (ms ScopeMetrics) SetSchemaUrl(v string) {
 
 // Metrics returns the Metrics associated with this ScopeMetrics.
 func (ms ScopeMetrics) Metrics() MetricSlice {
+	ms.state.CopyIfShared(&ms.orig.Metrics)
 	return newMetricSlice(&ms.orig.Metrics, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ScopeMetrics) CopyTo(dest ScopeMetrics) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Metrics().CopyTo(dest.Metrics())
 }
+
+// Clone returns a deep copy of the ScopeMetrics in a new instance.
+func (ms ScopeMetrics) Clone() ScopeMetrics {
+	dest := NewScopeMetrics()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -39,6 +39,14 @@ func TestScopeMetrics_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &sharedState)) })
 }
 
+func TestScopeMetrics_Clone(t *testing.T) {
+	ms := generateTestScopeMetrics()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewScopeMetrics().CopyTo(ms)
+	assert.Equal(t, generateTestScopeMetrics(), clone)
+}
+
 func TestScopeMetrics_Scope(t *testing.T) {
 	ms := NewScopeMetrics()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
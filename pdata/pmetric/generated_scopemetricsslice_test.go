@@ -39,6 +39,7 @@ func TestScopeMetricsSliceReadOnly(t *testing.T) {
 	es := newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewScopeMetricsSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestScopeMetricsSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestScopeMetricsSlice(), es)
 }
 
+func TestScopeMetricsSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestScopeMetricsSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestScopeMetricsSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewScopeMetrics()
+	testVal := generateTestScopeMetrics()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestScopeMetrics(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestScopeMetricsSlice().At(i), es.At(i))
+	}
+}
+
 func TestScopeMetricsSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestScopeMetricsSlice()
@@ -103,6 +123,15 @@ func TestScopeMetricsSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewScopeMetricsSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestScopeMetricsSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestScopeMetricsSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestScopeMetricsSlice_RemoveIf(t *testing.T) {
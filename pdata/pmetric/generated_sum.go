@@ -79,3 +79,10 @@ func (ms Sum) CopyTo(dest Sum) {
 	dest.SetIsMonotonic(ms.IsMonotonic())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Clone returns a deep copy of the Sum in a new instance.
+func (ms Sum) Clone() Sum {
+	dest := NewSum()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestSum_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
 }
 
+func TestSum_Clone(t *testing.T) {
+	ms := generateTestSum()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSum().CopyTo(ms)
+	assert.Equal(t, generateTestSum(), clone)
+}
+
 func TestSum_AggregationTemporality(t *testing.T) {
 	ms := NewSum()
 	assert.Equal(t, AggregationTemporality(otlpmetrics.AggregationTemporality(0)), ms.AggregationTemporality())
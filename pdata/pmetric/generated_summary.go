@@ -55,3 +55,10 @@ func (ms Summary) CopyTo(dest Summary) {
 	dest.state.AssertMutable()
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
+
+// Clone returns a deep copy of the Summary in a new instance.
+func (ms Summary) Clone() Summary {
+	dest := NewSummary()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestSummary_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSummary(&otlpmetrics.Summary{}, &sharedState)) })
 }
 
+func TestSummary_Clone(t *testing.T) {
+	ms := generateTestSummary()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSummary().CopyTo(ms)
+	assert.Equal(t, generateTestSummary(), clone)
+}
+
 func TestSummary_DataPoints(t *testing.T) {
 	ms := NewSummary()
 	assert.Equal(t, NewSummaryDataPointSlice(), ms.DataPoints())
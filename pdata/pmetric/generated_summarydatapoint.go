@@ -122,3 +122,10 @@ func (ms SummaryDataPoint) CopyTo(dest SummaryDataPoint) {
 	ms.QuantileValues().CopyTo(dest.QuantileValues())
 	dest.SetFlags(ms.Flags())
 }
+
+// Clone returns a deep copy of the SummaryDataPoint in a new instance.
+func (ms SummaryDataPoint) Clone() SummaryDataPoint {
+	dest := NewSummaryDataPoint()
+	ms.CopyTo(dest)
+	return dest
+}
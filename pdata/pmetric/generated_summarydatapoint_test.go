@@ -39,6 +39,14 @@ func TestSummaryDataPoint_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState)) })
 }
 
+func TestSummaryDataPoint_Clone(t *testing.T) {
+	ms := generateTestSummaryDataPoint()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSummaryDataPoint().CopyTo(ms)
+	assert.Equal(t, generateTestSummaryDataPoint(), clone)
+}
+
 func TestSummaryDataPoint_Attributes(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	assert.Equal(t, pcommon.NewMap(), ms.Attributes())
@@ -39,6 +39,7 @@ func TestSummaryDataPointSliceReadOnly(t *testing.T) {
 	es := newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewSummaryDataPointSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestSummaryDataPointSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestSummaryDataPointSlice(), es)
 }
 
+func TestSummaryDataPointSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestSummaryDataPointSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestSummaryDataPointSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewSummaryDataPoint()
+	testVal := generateTestSummaryDataPoint()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestSummaryDataPoint(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestSummaryDataPointSlice().At(i), es.At(i))
+	}
+}
+
 func TestSummaryDataPointSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestSummaryDataPointSlice()
@@ -103,6 +123,15 @@ func TestSummaryDataPointSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewSummaryDataPointSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestSummaryDataPointSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestSummaryDataPointSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestSummaryDataPointSlice_RemoveIf(t *testing.T) {
@@ -73,3 +73,10 @@ func (ms SummaryDataPointValueAtQuantile) CopyTo(dest SummaryDataPointValueAtQua
 	dest.SetQuantile(ms.Quantile())
 	dest.SetValue(ms.Value())
 }
+
+// Clone returns a deep copy of the SummaryDataPointValueAtQuantile in a new instance.
+func (ms SummaryDataPointValueAtQuantile) Clone() SummaryDataPointValueAtQuantile {
+	dest := NewSummaryDataPointValueAtQuantile()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -44,6 +44,14 @@ func TestSummaryDataPointValueAtQuantile_CopyTo(t *testing.T) {
 	})
 }
 
+func TestSummaryDataPointValueAtQuantile_Clone(t *testing.T) {
+	ms := generateTestSummaryDataPointValueAtQuantile()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSummaryDataPointValueAtQuantile().CopyTo(ms)
+	assert.Equal(t, generateTestSummaryDataPointValueAtQuantile(), clone)
+}
+
 func TestSummaryDataPointValueAtQuantile_Quantile(t *testing.T) {
 	ms := NewSummaryDataPointValueAtQuantile()
 	assert.Equal(t, float64(0.0), ms.Quantile())
@@ -39,6 +39,7 @@ func TestSummaryDataPointValueAtQuantileSliceReadOnly(t *testing.T) {
 	es := newSummaryDataPointValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewSummaryDataPointValueAtQuantileSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestSummaryDataPointValueAtQuantileSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestSummaryDataPointValueAtQuantileSlice(), es)
 }
 
+func TestSummaryDataPointValueAtQuantileSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestSummaryDataPointValueAtQuantileSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestSummaryDataPointValueAtQuantileSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewSummaryDataPointValueAtQuantile()
+	testVal := generateTestSummaryDataPointValueAtQuantile()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestSummaryDataPointValueAtQuantile(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestSummaryDataPointValueAtQuantileSlice().At(i), es.At(i))
+	}
+}
+
 func TestSummaryDataPointValueAtQuantileSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestSummaryDataPointValueAtQuantileSlice()
@@ -103,6 +123,15 @@ func TestSummaryDataPointValueAtQuantileSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewSummaryDataPointValueAtQuantileSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestSummaryDataPointValueAtQuantileSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestSummaryDataPointValueAtQuantileSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestSummaryDataPointValueAtQuantileSlice_RemoveIf(t *testing.T) {
@@ -6,6 +6,7 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -17,6 +18,8 @@ import (
 
 var _ Marshaler = (*JSONMarshaler)(nil)
 
+var _ StreamMarshaler = (*JSONMarshaler)(nil)
+
 // JSONMarshaler marshals pdata.Metrics to JSON bytes using the OTLP/JSON format.
 type JSONMarshaler struct{}
 
@@ -28,6 +31,12 @@ func (*JSONMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalMetricsTo writes the OTLP/JSON encoding of the given pdata.Metrics into w.
+func (*JSONMarshaler) MarshalMetricsTo(w io.Writer, md Metrics) error {
+	pb := internal.MetricsToProto(internal.Metrics(md))
+	return json.Marshal(w, &pb)
+}
+
 // JSONUnmarshaler unmarshals OTLP/JSON formatted-bytes to pdata.Metrics.
 type JSONUnmarshaler struct{}
 
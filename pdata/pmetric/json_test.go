@@ -4,6 +4,7 @@
 package pmetric
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -46,6 +47,13 @@ func TestMetricsJSON_Marshal(t *testing.T) {
 	assert.Equal(t, metricsJSON, string(jsonBuf))
 }
 
+func TestJSONMarshalTo(t *testing.T) {
+	encoder := &JSONMarshaler{}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, encoder.MarshalMetricsTo(buf, metricsOTLP))
+	assert.Equal(t, metricsJSON, buf.String())
+}
+
 var metricsSumOTLPFull = func() Metrics {
 	metric := NewMetrics()
 	rs := metric.ResourceMetrics().AppendEmpty()
@@ -6,6 +6,8 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
@@ -32,7 +34,7 @@ func NewMetrics() Metrics {
 
 // IsReadOnly returns true if this Metrics instance is read-only.
 func (ms Metrics) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Metrics instance overriding the destination.
@@ -48,7 +50,7 @@ func (ms Metrics) ResourceMetrics() ResourceMetricsSlice {
 // MetricCount calculates the total number of metrics.
 func (ms Metrics) MetricCount() int {
 	metricCount := 0
-	rms := ms.ResourceMetrics()
+	rms := ms.readOnlyResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
 		ilms := rm.ScopeMetrics()
@@ -62,7 +64,7 @@ func (ms Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (ms Metrics) DataPointCount() (dataPointCount int) {
-	rms := ms.ResourceMetrics()
+	rms := ms.readOnlyResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
 		ilms := rm.ScopeMetrics()
@@ -93,3 +95,149 @@ func (ms Metrics) DataPointCount() (dataPointCount int) {
 func (ms Metrics) MarkReadOnly() {
 	internal.SetMetricsState(internal.Metrics(ms), internal.StateReadOnly)
 }
+
+// Clone returns a deep copy of the Metrics in a new instance.
+func (ms Metrics) Clone() Metrics {
+	dest := NewMetrics()
+	ms.CopyTo(dest)
+	return dest
+}
+
+// CloneResource returns a copy of the Metrics in which only the resources are copied, the ScopeMetrics are
+// shared with the Metrics and copied on write, i.e. the ScopeMetrics of a resource are copied when first
+// accessed in the returned Metrics. This makes modifying the resources, e.g. adding a resource attribute
+// to read-only data, much cheaper than with Clone. Since the ScopeMetrics are shared, the Metrics is marked
+// as read-only.
+func (ms Metrics) CloneResource() Metrics {
+	dest := NewMetrics()
+	state := dest.getState()
+	srcResourceMetrics := ms.readOnlyResourceMetrics()
+	destResourceMetrics := dest.ResourceMetrics()
+	destResourceMetrics.EnsureCapacity(srcResourceMetrics.Len())
+	for i := 0; i < srcResourceMetrics.Len(); i++ {
+		srcR := srcResourceMetrics.At(i)
+		destR := destResourceMetrics.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		destR.orig.ScopeMetrics = srcR.orig.ScopeMetrics
+		shareScopeMetrics(state, destR.orig)
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// CloneScope returns a copy of the Metrics in which only the resources and the scopes are copied, the metrics are
+// shared with the Metrics and copied on write, i.e. the metrics of a scope are copied when first accessed in the
+// returned Metrics. This makes modifying the resources or the scopes much cheaper than with Clone.
+// Since the metrics are shared, the Metrics is marked as read-only.
+func (ms Metrics) CloneScope() Metrics {
+	dest := NewMetrics()
+	state := dest.getState()
+	srcResourceMetrics := ms.readOnlyResourceMetrics()
+	destResourceMetrics := dest.ResourceMetrics()
+	destResourceMetrics.EnsureCapacity(srcResourceMetrics.Len())
+	for i := 0; i < srcResourceMetrics.Len(); i++ {
+		srcR := srcResourceMetrics.At(i)
+		destR := destResourceMetrics.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		srcScopeMetrics := srcR.ScopeMetrics()
+		destScopeMetrics := destR.ScopeMetrics()
+		destScopeMetrics.EnsureCapacity(srcScopeMetrics.Len())
+		for j := 0; j < srcScopeMetrics.Len(); j++ {
+			srcS := srcScopeMetrics.At(j)
+			destS := destScopeMetrics.AppendEmpty()
+			srcS.Scope().CopyTo(destS.Scope())
+			destS.SetSchemaUrl(srcS.SchemaUrl())
+			destS.orig.Metrics = srcS.orig.Metrics
+			shareMetrics(state, destS.orig)
+		}
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// InternAttributes replaces the attribute keys and string values of the resources, scopes, data points
+// and exemplars with the strings interned by si, so that the data repeating them shares a single copy.
+func (ms Metrics) InternAttributes(si *pcommon.StringInterner) {
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		rm.Resource().Attributes().Intern(si)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			sm.Scope().Attributes().Intern(si)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				internMetricAttributes(si, metrics.At(k))
+			}
+		}
+	}
+}
+
+func internMetricAttributes(si *pcommon.StringInterner, m Metric) {
+	switch m.Type() {
+	case MetricTypeGauge:
+		internNumberDataPointsAttributes(si, m.Gauge().DataPoints())
+	case MetricTypeSum:
+		internNumberDataPointsAttributes(si, m.Sum().DataPoints())
+	case MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Attributes().Intern(si)
+			internExemplarsAttributes(si, dps.At(i).Exemplars())
+		}
+	case MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Attributes().Intern(si)
+			internExemplarsAttributes(si, dps.At(i).Exemplars())
+		}
+	case MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dps.At(i).Attributes().Intern(si)
+		}
+	}
+}
+
+func internNumberDataPointsAttributes(si *pcommon.StringInterner, dps NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).Attributes().Intern(si)
+		internExemplarsAttributes(si, dps.At(i).Exemplars())
+	}
+}
+
+func internExemplarsAttributes(si *pcommon.StringInterner, exemplars ExemplarSlice) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplars.At(i).FilteredAttributes().Intern(si)
+	}
+}
+
+// readOnlyResourceMetrics returns the ResourceMetricsSlice through a read-only state,
+// so that the subtrees shared with another instance are read without being copied.
+func (ms Metrics) readOnlyResourceMetrics() ResourceMetricsSlice {
+	state := internal.StateReadOnly
+	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics, &state)
+}
+
+// shareScopeMetrics registers the ScopeMetrics of the ResourceMetrics as shared, they are copied when first accessed.
+func shareScopeMetrics(state *internal.State, orig *otlpmetrics.ResourceMetrics) {
+	state.Share(&orig.ScopeMetrics, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.ScopeMetrics
+		orig.ScopeMetrics = nil
+		newScopeMetricsSlice(&shared, &sharedState).CopyTo(newScopeMetricsSlice(&orig.ScopeMetrics, state))
+	})
+}
+
+// shareMetrics registers the metrics of the ScopeMetrics as shared, they are copied when first accessed.
+func shareMetrics(state *internal.State, orig *otlpmetrics.ScopeMetrics) {
+	state.Share(&orig.Metrics, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.Metrics
+		orig.Metrics = nil
+		newMetricSlice(&shared, &sharedState).CopyTo(newMetricSlice(&orig.Metrics, state))
+	})
+}
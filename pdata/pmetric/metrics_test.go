@@ -4,8 +4,10 @@
 package pmetric
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -646,6 +648,70 @@ func TestReadOnlyMetricsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestMetricsCloneResource(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	clone := metrics.CloneResource()
+	assert.True(t, metrics.IsReadOnly())
+	assert.Equal(t, metrics.DataPointCount(), clone.DataPointCount())
+	assert.Same(t, &metrics.getOrig().ResourceMetrics[0].ScopeMetrics[0], &clone.getOrig().ResourceMetrics[0].ScopeMetrics[0])
+
+	clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).CopyTo(clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty())
+	assert.NotSame(t, &metrics.getOrig().ResourceMetrics[0].ScopeMetrics[0], &clone.getOrig().ResourceMetrics[0].ScopeMetrics[0])
+	assert.Equal(t, generateTestResourceMetricsSlice().orig, metrics.ResourceMetrics().orig)
+}
+
+func TestMetricsCloneScope(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	clone := metrics.CloneScope()
+	assert.True(t, metrics.IsReadOnly())
+	assert.Equal(t, metrics.getOrig(), clone.getOrig())
+	assert.Same(t, &metrics.getOrig().ResourceMetrics[0].ScopeMetrics[0].Metrics[0], &clone.getOrig().ResourceMetrics[0].ScopeMetrics[0].Metrics[0])
+
+	clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).CopyTo(clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty())
+	assert.NotSame(t, &metrics.getOrig().ResourceMetrics[0].ScopeMetrics[0].Metrics[0], &clone.getOrig().ResourceMetrics[0].ScopeMetrics[0].Metrics[0])
+	assert.Equal(t, generateTestResourceMetricsSlice().orig, metrics.ResourceMetrics().orig)
+}
+
+func TestMetricsInternAttributes(t *testing.T) {
+	metrics := NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(strings.Clone("service.name"), strings.Clone("svc"))
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	gauge.Attributes().PutStr("gauge", strings.Clone("svc"))
+	gauge.Exemplars().AppendEmpty().FilteredAttributes().PutStr("exemplar", strings.Clone("svc"))
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("sum", strings.Clone("svc"))
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("histogram", strings.Clone("svc"))
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("exp", strings.Clone("svc"))
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes().PutStr("summary", strings.Clone("svc"))
+	expected := NewMetrics()
+	metrics.CopyTo(expected)
+	si := pcommon.NewStringInterner(10)
+	metrics.InternAttributes(si)
+	assert.Equal(t, expected, metrics)
+
+	svc := unsafe.StringData(si.Intern(strings.Clone("svc")))
+	orig := metrics.getOrig().ResourceMetrics[0]
+	assert.Same(t, svc, unsafe.StringData(orig.Resource.Attributes[0].Value.GetStringValue()))
+	origMetrics := orig.ScopeMetrics[0].Metrics
+	gaugeDataPoint := origMetrics[0].GetGauge().DataPoints[0]
+	for _, attrs := range [][]otlpcommon.KeyValue{
+		gaugeDataPoint.Attributes,
+		gaugeDataPoint.Exemplars[0].FilteredAttributes,
+		origMetrics[1].GetSum().DataPoints[0].Attributes,
+		origMetrics[2].GetHistogram().DataPoints[0].Attributes,
+		origMetrics[3].GetExponentialHistogram().DataPoints[0].Attributes,
+		origMetrics[4].GetSummary().DataPoints[0].Attributes,
+	} {
+		assert.Same(t, svc, unsafe.StringData(attrs[0].Value.GetStringValue()))
+	}
+
+	metrics.MarkReadOnly()
+	assert.Panics(t, func() { metrics.InternAttributes(si) })
+}
+
 func BenchmarkOtlpToFromInternal_PassThrough(b *testing.B) {
 	req := &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
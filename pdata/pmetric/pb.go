@@ -4,20 +4,44 @@
 package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
 import (
+	"io"
+
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
+var _ StreamMarshaler = (*ProtoMarshaler)(nil)
 
 type ProtoMarshaler struct{}
 
+// MarshalMetrics to the OTLP/protobuf format.
+// If the read-only md retains the encoding it was decoded from, that encoding is returned as is.
 func (e *ProtoMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	if raw := internal.MetricsRawProto(internal.Metrics(md)); raw != nil {
+		return raw, nil
+	}
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Marshal()
 }
 
+// MarshalMetricsTo writes the OTLP/protobuf encoding of the given pdata.Metrics into w. The ResourceMetrics are encoded
+// and written one at a time, so that only the encoding of the largest one is held in memory.
+// If the read-only md retains the encoding it was decoded from, that encoding is written as is.
+func (e *ProtoMarshaler) MarshalMetricsTo(w io.Writer, md Metrics) error {
+	if raw := internal.MetricsRawProto(internal.Metrics(md)); raw != nil {
+		_, err := w.Write(raw)
+		return err
+	}
+	pb := internal.MetricsToProto(internal.Metrics(md))
+	return otlp.WriteProtoFieldOne(w, pb.ResourceMetrics)
+}
+
 func (e *ProtoMarshaler) MetricsSize(md Metrics) int {
+	if raw := internal.MetricsRawProto(internal.Metrics(md)); raw != nil {
+		return len(raw)
+	}
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Size()
 }
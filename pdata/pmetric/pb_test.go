@@ -4,6 +4,9 @@
 package pmetric
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +34,52 @@ func TestProtoSizer(t *testing.T) {
 	assert.Equal(t, len(bytes), size)
 }
 
+func TestProtoMarshalTo(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	md := NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("foo", "bar")
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("foo", strings.Repeat("x", 200))
+	md.ResourceMetrics().AppendEmpty()
+
+	expected, err := marshaler.MarshalMetrics(md)
+	require.NoError(t, err)
+
+	// The ResourceMetrics are written one at a time.
+	w := &recordingWriter{}
+	require.NoError(t, marshaler.MarshalMetricsTo(w, md))
+	assert.Equal(t, expected, w.Bytes())
+	assert.Len(t, w.writes, 3)
+	assert.Less(t, w.writes[1], len(expected))
+
+	w = &recordingWriter{}
+	require.NoError(t, marshaler.MarshalMetricsTo(w, NewMetrics()))
+	assert.Empty(t, w.Bytes())
+}
+
+func TestProtoMarshalToWriterError(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	md := NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	assert.Error(t, marshaler.MarshalMetricsTo(errWriter{}, md))
+}
+
+// recordingWriter records the sizes of the writes.
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return w.Buffer.Write(p)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
 func TestProtoSizerEmptyMetrics(t *testing.T) {
 	sizer := &ProtoMarshaler{}
 	assert.Equal(t, 0, sizer.MetricsSize(NewMetrics()))
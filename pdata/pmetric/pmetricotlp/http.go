@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetricotlp // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+)
+
+// HTTPHandlerOption applies an option to the handler returned by NewHTTPHandler.
+type HTTPHandlerOption func(*httpHandlerSettings)
+
+type httpHandlerSettings struct {
+	maxRequestBodySize int64
+}
+
+// WithMaxRequestBodySize sets the maximum size of the decompressed bodies of the requests, in bytes.
+// The larger requests are rejected with a 413 Request Entity Too Large response.
+func WithMaxRequestBodySize(size int64) HTTPHandlerOption {
+	return func(set *httpHandlerSettings) {
+		set.maxRequestBodySize = size
+	}
+}
+
+// NewHTTPHandler returns an http.Handler serving the OTLP/HTTP metrics requests, sent by default to the
+// "/v1/metrics" path, with the Export method of the given GRPCServer. This allows implementing a custom
+// OTLP metrics endpoint, e.g. a mock backend, once for both the transports with the same semantics:
+//   - the requests are decoded from OTLP/protobuf or OTLP/JSON according to their Content-Type, and
+//     decompressed according to their Content-Encoding, either gzip or deflate;
+//   - the response is encoded like the request;
+//   - the errors returned by Export are converted to the HTTP status codes of their gRPC status as defined
+//     by the OTLP specification, e.g. status.Error(codes.Unavailable, ...) results in a retryable 503 response.
+//     The errors without a gRPC status are Unknown, as with RegisterGRPCServer.
+//
+// The decompressed bodies of the requests are limited to 20MiB by default, see WithMaxRequestBodySize.
+func NewHTTPHandler(srv GRPCServer, opts ...HTTPHandlerOption) http.Handler {
+	set := httpHandlerSettings{maxRequestBodySize: otlp.DefaultMaxRequestBodySize}
+	for _, opt := range opts {
+		opt(&set)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc, body, ok := otlp.ReadHTTPRequest(w, r, set.maxRequestBodySize)
+		if !ok {
+			return
+		}
+
+		state := internal.StateMutable
+		req := ExportRequest{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, state: &state}
+		var err error
+		if enc == otlp.HTTPEncodingJSON {
+			err = req.UnmarshalJSON(body)
+		} else {
+			err = req.UnmarshalProto(body)
+		}
+		if err != nil {
+			otlp.WriteHTTPError(w, enc, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		rsp, err := srv.Export(r.Context(), req)
+		if err != nil {
+			otlp.WriteHTTPError(w, enc, err)
+			return
+		}
+		if rsp.orig == nil {
+			rsp = NewExportResponse()
+		}
+
+		var msg []byte
+		if enc == otlp.HTTPEncodingJSON {
+			msg, err = rsp.MarshalJSON()
+		} else {
+			msg, err = rsp.MarshalProto()
+		}
+		if err != nil {
+			otlp.WriteHTTPError(w, enc, status.Error(codes.Internal, err.Error()))
+			return
+		}
+		otlp.WriteHTTPResponse(w, enc, msg)
+	})
+}
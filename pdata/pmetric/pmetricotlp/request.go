@@ -19,6 +19,7 @@ var jsonUnmarshaler = &pmetric.JSONUnmarshaler{}
 type ExportRequest struct {
 	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
 	state *internal.State
+	raw   *[]byte
 }
 
 // NewExportRequest returns an empty ExportRequest.
@@ -27,6 +28,7 @@ func NewExportRequest() ExportRequest {
 	return ExportRequest{
 		orig:  &otlpcollectormetrics.ExportMetricsServiceRequest{},
 		state: &state,
+		raw:   new([]byte),
 	}
 }
 
@@ -37,19 +39,48 @@ func NewExportRequestFromMetrics(md pmetric.Metrics) ExportRequest {
 	return ExportRequest{
 		orig:  internal.GetOrigMetrics(internal.Metrics(md)),
 		state: internal.GetMetricsState(internal.Metrics(md)),
+		raw:   internal.GetMetricsRaw(internal.Metrics(md)),
 	}
 }
 
 // MarshalProto marshals ExportRequest into proto bytes.
 func (ms ExportRequest) MarshalProto() ([]byte, error) {
+	if raw := internal.MetricsRawProto(internal.Metrics(ms.Metrics())); raw != nil {
+		return raw, nil
+	}
 	return ms.orig.Marshal()
 }
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
+	ms.resetRaw()
 	return ms.orig.Unmarshal(data)
 }
 
+// UnmarshalProtoPassthrough unmarshalls ExportRequest from proto bytes and marks it as read-only.
+// The given bytes are retained, so that marshaling the request or its pmetric.Metrics to OTLP/protobuf
+// returns them as is instead of encoding the data again. Because of that, data must not be modified
+// by the caller after this call.
+// Data that needs to be modified down the pipeline has to be copied, which also drops the retained bytes.
+func (ms ExportRequest) UnmarshalProtoPassthrough(data []byte) error {
+	ms.resetRaw()
+	if err := ms.orig.Unmarshal(data); err != nil {
+		return err
+	}
+	retainRaw := ms.raw != nil && !hasDeprecatedFields(ms.orig)
+	ms.Metrics().MarkReadOnly()
+	if retainRaw {
+		*ms.raw = data
+	}
+	return nil
+}
+
+func (ms ExportRequest) resetRaw() {
+	if ms.raw != nil {
+		*ms.raw = nil
+	}
+}
+
 // MarshalJSON marshals ExportRequest into JSON bytes.
 func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
@@ -61,6 +92,7 @@ func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls ExportRequest from JSON bytes.
 func (ms ExportRequest) UnmarshalJSON(data []byte) error {
+	ms.resetRaw()
 	md, err := jsonUnmarshaler.UnmarshalMetrics(data)
 	if err != nil {
 		return err
@@ -70,5 +102,19 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Metrics() pmetric.Metrics {
-	return pmetric.Metrics(internal.NewMetrics(ms.orig, ms.state))
+	if ms.raw == nil || *ms.raw == nil {
+		return pmetric.Metrics(internal.NewMetrics(ms.orig, ms.state))
+	}
+	return pmetric.Metrics(internal.NewMetricsWithRaw(ms.orig, ms.state, ms.raw))
+}
+
+// hasDeprecatedFields returns true if the request uses deprecated fields that are migrated
+// after unmarshaling, in which case the original encoding does not match the data anymore.
+func hasDeprecatedFields(orig *otlpcollectormetrics.ExportMetricsServiceRequest) bool {
+	for _, r := range orig.ResourceMetrics {
+		if len(r.DeprecatedScopeMetrics) > 0 {
+			return true
+		}
+	}
+	return false
 }
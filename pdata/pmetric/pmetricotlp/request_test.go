@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -49,3 +52,47 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(metricsRequestJSON)), ""), string(got))
 }
+
+func TestRequestProtoPassthrough(t *testing.T) {
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test_metric")
+	buf, err := NewExportRequestFromMetrics(md).MarshalProto()
+	require.NoError(t, err)
+	// Append an unknown field, which is dropped when the data is encoded again.
+	buf = append(buf, 0x98, 0x06, 0x01)
+
+	tr := NewExportRequest()
+	require.NoError(t, tr.UnmarshalProtoPassthrough(buf))
+	assert.True(t, tr.Metrics().IsReadOnly())
+	assert.Equal(t, 1, tr.Metrics().MetricCount())
+
+	got, err := tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+
+	got, err = (&pmetric.ProtoMarshaler{}).MarshalMetrics(tr.Metrics())
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	assert.Equal(t, len(buf), (&pmetric.ProtoMarshaler{}).MetricsSize(tr.Metrics()))
+
+	// A copy of the data does not retain the original encoding.
+	cp := pmetric.NewMetrics()
+	tr.Metrics().CopyTo(cp)
+	got, err = NewExportRequestFromMetrics(cp).MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+
+	// Regular unmarshaling does not retain the original encoding.
+	tr = NewExportRequest()
+	require.NoError(t, tr.UnmarshalProto(buf))
+	got, err = tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+	assert.Equal(t, cp, tr.Metrics())
+}
+
+func TestRequestProtoPassthroughError(t *testing.T) {
+	tr := NewExportRequest()
+	assert.Error(t, tr.UnmarshalProtoPassthrough([]byte("+$%")))
+	assert.False(t, tr.Metrics().IsReadOnly())
+}
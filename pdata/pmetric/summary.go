@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SummaryPassThroughAttribute is the data point attribute set to true by MarkSummaryPassThrough, to signal
+// that the summary data points were deliberately not converted to histogram data points.
+const SummaryPassThroughAttribute = "otel.summary.pass_through"
+
+// CopyToHistogram converts the summary data point to a histogram data point, overriding the destination.
+//
+// The quantile values are assumed to be the boundaries of the histogram buckets: the quantile q of value v means
+// that q*Count observations are lower or equal to v. The quantiles 0 and 1 give the minimum and the maximum.
+// An error is returned if the quantiles are not in [0, 1], are duplicated, or if their values are NaN or are
+// not increasing with the quantiles. The destination is not modified in this case.
+func (ms SummaryDataPoint) CopyToHistogram(dest HistogramDataPoint) error {
+	dest.state.AssertMutable()
+	quantiles, err := sortedQuantiles(ms.QuantileValues())
+	if err != nil {
+		return err
+	}
+
+	count := ms.Count()
+	var bounds []float64
+	var bucketCounts []uint64
+	hasMin, hasMax := false, false
+	var minValue, maxValue float64
+	prev := uint64(0)
+	for _, q := range quantiles {
+		switch q.Quantile() {
+		case 0:
+			hasMin, minValue = true, q.Value()
+			continue
+		case 1:
+			hasMax, maxValue = true, q.Value()
+			continue
+		}
+		cum := uint64(math.Round(q.Quantile() * float64(count)))
+		if len(bounds) > 0 && bounds[len(bounds)-1] == q.Value() {
+			// The bounds must be strictly increasing, merge the buckets with the same upper bound.
+			bucketCounts[len(bucketCounts)-1] += cum - prev
+		} else {
+			bounds = append(bounds, q.Value())
+			bucketCounts = append(bucketCounts, cum-prev)
+		}
+		prev = cum
+	}
+	bucketCounts = append(bucketCounts, count-prev)
+
+	ms.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(ms.StartTimestamp())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetFlags(ms.Flags())
+	dest.SetCount(count)
+	dest.SetSum(ms.Sum())
+	dest.ExplicitBounds().FromRaw(bounds)
+	dest.BucketCounts().FromRaw(bucketCounts)
+	dest.Exemplars().RemoveIf(func(Exemplar) bool { return true })
+	if hasMin {
+		dest.SetMin(minValue)
+	} else {
+		dest.RemoveMin()
+	}
+	if hasMax {
+		dest.SetMax(maxValue)
+	} else {
+		dest.RemoveMax()
+	}
+	return nil
+}
+
+func sortedQuantiles(qs SummaryDataPointValueAtQuantileSlice) ([]SummaryDataPointValueAtQuantile, error) {
+	res := make([]SummaryDataPointValueAtQuantile, 0, qs.Len())
+	for i := 0; i < qs.Len(); i++ {
+		q := qs.At(i)
+		if math.IsNaN(q.Quantile()) || q.Quantile() < 0 || q.Quantile() > 1 {
+			return nil, fmt.Errorf("invalid quantile %v, must be in [0, 1]", q.Quantile())
+		}
+		if math.IsNaN(q.Value()) {
+			return nil, fmt.Errorf("invalid NaN value for the quantile %v", q.Quantile())
+		}
+		res = append(res, q)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Quantile() < res[j].Quantile() })
+	for i := 1; i < len(res); i++ {
+		if res[i].Quantile() == res[i-1].Quantile() {
+			return nil, fmt.Errorf("duplicate quantile %v", res[i].Quantile())
+		}
+		if res[i].Value() < res[i-1].Value() {
+			return nil, fmt.Errorf("the value %v of the quantile %v is lower than the value %v of the quantile %v",
+				res[i].Value(), res[i].Quantile(), res[i-1].Value(), res[i-1].Quantile())
+		}
+	}
+	return res, nil
+}
+
+// ConvertSummaryToHistogram converts the summary metric to a histogram metric with the cumulative temporality,
+// see SummaryDataPoint.CopyToHistogram. The metrics of other types are left unchanged.
+// If a data point cannot be converted, an error is returned and the metric is left unchanged.
+func (ms Metric) ConvertSummaryToHistogram() error {
+	ms.state.AssertMutable()
+	if ms.Type() != MetricTypeSummary {
+		return nil
+	}
+
+	summaryDps := ms.Summary().DataPoints()
+	histogram := NewHistogram()
+	histogram.SetAggregationTemporality(AggregationTemporalityCumulative)
+	histogramDps := histogram.DataPoints()
+	histogramDps.EnsureCapacity(summaryDps.Len())
+	for i := 0; i < summaryDps.Len(); i++ {
+		if err := summaryDps.At(i).CopyToHistogram(histogramDps.AppendEmpty()); err != nil {
+			return fmt.Errorf("failed to convert the summary %q: %w", ms.Name(), err)
+		}
+	}
+	histogram.MoveTo(ms.SetEmptyHistogram())
+	return nil
+}
+
+// MarkSummaryPassThrough sets the SummaryPassThroughAttribute attribute on the data points of the summary metric,
+// to signal to the next components that the summary is passed through as is. The metrics of other types are
+// left unchanged.
+func (ms Metric) MarkSummaryPassThrough() {
+	ms.state.AssertMutable()
+	if ms.Type() != MetricTypeSummary {
+		return
+	}
+	dps := ms.Summary().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).Attributes().PutBool(SummaryPassThroughAttribute, true)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newTestSummaryDataPoint(count uint64, quantiles ...float64) SummaryDataPoint {
+	dp := NewSummaryDataPoint()
+	dp.Attributes().PutStr("k", "v")
+	dp.SetStartTimestamp(pcommon.Timestamp(1))
+	dp.SetTimestamp(pcommon.Timestamp(2))
+	dp.SetCount(count)
+	dp.SetSum(123.5)
+	for i := 0; i+1 < len(quantiles); i += 2 {
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(quantiles[i])
+		q.SetValue(quantiles[i+1])
+	}
+	return dp
+}
+
+func TestSummaryDataPointCopyToHistogram(t *testing.T) {
+	tests := []struct {
+		name          string
+		dp            SummaryDataPoint
+		bounds        []float64
+		bucketCounts  []uint64
+		minValue      float64
+		maxValue      float64
+		expectedError string
+	}{
+		{
+			name:         "no_quantiles",
+			dp:           newTestSummaryDataPoint(10),
+			bucketCounts: []uint64{10},
+		},
+		{
+			name:         "quantiles",
+			dp:           newTestSummaryDataPoint(100, 0.5, 10, 0.9, 20, 0.99, 50),
+			bounds:       []float64{10, 20, 50},
+			bucketCounts: []uint64{50, 40, 9, 1},
+		},
+		{
+			name:         "unsorted_quantiles",
+			dp:           newTestSummaryDataPoint(100, 0.9, 20, 0.5, 10),
+			bounds:       []float64{10, 20},
+			bucketCounts: []uint64{50, 40, 10},
+		},
+		{
+			name:         "min_max",
+			dp:           newTestSummaryDataPoint(10, 0, 1, 0.5, 5, 1, 9),
+			bounds:       []float64{5},
+			bucketCounts: []uint64{5, 5},
+			minValue:     1,
+			maxValue:     9,
+		},
+		{
+			name:         "same_values",
+			dp:           newTestSummaryDataPoint(10, 0.25, 5, 0.5, 5, 0.75, 7),
+			bounds:       []float64{5, 7},
+			bucketCounts: []uint64{5, 3, 2},
+		},
+		{
+			name:          "invalid_quantile",
+			dp:            newTestSummaryDataPoint(10, 1.5, 5),
+			expectedError: "invalid quantile 1.5, must be in [0, 1]",
+		},
+		{
+			name:          "nan_value",
+			dp:            newTestSummaryDataPoint(10, 0.5, math.NaN()),
+			expectedError: "invalid NaN value for the quantile 0.5",
+		},
+		{
+			name:          "duplicate_quantile",
+			dp:            newTestSummaryDataPoint(10, 0.5, 5, 0.5, 5),
+			expectedError: "duplicate quantile 0.5",
+		},
+		{
+			name:          "decreasing_values",
+			dp:            newTestSummaryDataPoint(10, 0.5, 5, 0.9, 4),
+			expectedError: "the value 4 of the quantile 0.9 is lower than the value 5 of the quantile 0.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := NewHistogramDataPoint()
+			dest.Exemplars().AppendEmpty()
+			dest.SetMin(-1)
+			err := tt.dp.CopyToHistogram(dest)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, 1, dest.Exemplars().Len())
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]any{"k": "v"}, dest.Attributes().AsRaw())
+			assert.Equal(t, pcommon.Timestamp(1), dest.StartTimestamp())
+			assert.Equal(t, pcommon.Timestamp(2), dest.Timestamp())
+			assert.Equal(t, tt.dp.Count(), dest.Count())
+			assert.Equal(t, 123.5, dest.Sum())
+			assert.Equal(t, tt.bounds, dest.ExplicitBounds().AsRaw())
+			assert.Equal(t, tt.bucketCounts, dest.BucketCounts().AsRaw())
+			assert.Equal(t, 0, dest.Exemplars().Len())
+			assert.Equal(t, tt.minValue != 0, dest.HasMin())
+			assert.Equal(t, tt.minValue, dest.Min())
+			assert.Equal(t, tt.maxValue != 0, dest.HasMax())
+			assert.Equal(t, tt.maxValue, dest.Max())
+		})
+	}
+}
+
+func TestMetricConvertSummaryToHistogram(t *testing.T) {
+	m := NewMetric()
+	m.SetName("latency")
+	newTestSummaryDataPoint(100, 0.5, 10, 0.9, 20).CopyTo(m.SetEmptySummary().DataPoints().AppendEmpty())
+	newTestSummaryDataPoint(10).CopyTo(m.Summary().DataPoints().AppendEmpty())
+
+	require.NoError(t, m.ConvertSummaryToHistogram())
+	require.Equal(t, MetricTypeHistogram, m.Type())
+	assert.Equal(t, "latency", m.Name())
+	assert.Equal(t, AggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	require.Equal(t, 2, m.Histogram().DataPoints().Len())
+	assert.Equal(t, []uint64{50, 40, 10}, m.Histogram().DataPoints().At(0).BucketCounts().AsRaw())
+	assert.Equal(t, []uint64{10}, m.Histogram().DataPoints().At(1).BucketCounts().AsRaw())
+
+	// The other types are left unchanged.
+	require.NoError(t, m.ConvertSummaryToHistogram())
+	assert.Equal(t, MetricTypeHistogram, m.Type())
+}
+
+func TestMetricConvertSummaryToHistogramError(t *testing.T) {
+	m := NewMetric()
+	m.SetName("latency")
+	newTestSummaryDataPoint(10, 0.5, 5).CopyTo(m.SetEmptySummary().DataPoints().AppendEmpty())
+	newTestSummaryDataPoint(10, 2, 5).CopyTo(m.Summary().DataPoints().AppendEmpty())
+
+	require.EqualError(t, m.ConvertSummaryToHistogram(), `failed to convert the summary "latency": invalid quantile 2, must be in [0, 1]`)
+	require.Equal(t, MetricTypeSummary, m.Type())
+	assert.Equal(t, 2, m.Summary().DataPoints().Len())
+}
+
+func TestMetricMarkSummaryPassThrough(t *testing.T) {
+	m := NewMetric()
+	newTestSummaryDataPoint(10, 0.5, 5).CopyTo(m.SetEmptySummary().DataPoints().AppendEmpty())
+	m.MarkSummaryPassThrough()
+	assert.Equal(t, map[string]any{"k": "v", SummaryPassThroughAttribute: true}, m.Summary().DataPoints().At(0).Attributes().AsRaw())
+
+	g := NewMetric()
+	g.SetEmptyGauge().DataPoints().AppendEmpty()
+	g.MarkSummaryPassThrough()
+	assert.Equal(t, 0, g.Gauge().DataPoints().At(0).Attributes().Len())
+}
+
+func TestMetricSummaryReadOnly(t *testing.T) {
+	state := internal.StateReadOnly
+	m := newMetric(&otlpmetrics.Metric{}, &state)
+	assert.Panics(t, func() { _ = m.ConvertSummaryToHistogram() })
+	assert.Panics(t, func() { m.MarkSummaryPassThrough() })
+}
@@ -3,6 +3,8 @@
 
 package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 
+import "io"
+
 // MarshalSizer is the interface that groups the basic Marshal and Size methods
 type MarshalSizer interface {
 	Marshaler
@@ -29,3 +31,11 @@ type Sizer interface {
 	// TracesSize returns the size in bytes of a marshaled Traces.
 	TracesSize(td Traces) int
 }
+
+// StreamMarshaler is an optional interface implemented by the Marshaler,
+// that writes a marshaled Traces directly into an io.Writer.
+type StreamMarshaler interface {
+	// MarshalTracesTo marshals the given pdata.Traces and writes the result into w.
+	// If the error is not nil, the data written to w cannot be used.
+	MarshalTracesTo(w io.Writer, td Traces) error
+}
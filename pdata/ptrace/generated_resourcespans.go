@@ -42,6 +42,8 @@ func NewResourceSpans() ResourceSpans {
 func (ms ResourceSpans) MoveTo(dest ResourceSpans) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ResourceSpans{}
 }
@@ -64,6 +66,7 @@ func (ms ResourceSpans) SetSchemaUrl(v string) {
 
 // ScopeSpans returns the ScopeSpans associated with this ResourceSpans.
 func (ms ResourceSpans) ScopeSpans() ScopeSpansSlice {
+	ms.state.CopyIfShared(&ms.orig.ScopeSpans)
 	return newScopeSpansSlice(&ms.orig.ScopeSpans, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeSpans().CopyTo(dest.ScopeSpans())
 }
+
+// Clone returns a deep copy of the ResourceSpans in a new instance.
+func (ms ResourceSpans) Clone() ResourceSpans {
+	dest := NewResourceSpans()
+	ms.CopyTo(dest)
+	return dest
+}
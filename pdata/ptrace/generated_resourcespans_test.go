@@ -39,6 +39,14 @@ func TestResourceSpans_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newResourceSpans(&otlptrace.ResourceSpans{}, &sharedState)) })
 }
 
+func TestResourceSpans_Clone(t *testing.T) {
+	ms := generateTestResourceSpans()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewResourceSpans().CopyTo(ms)
+	assert.Equal(t, generateTestResourceSpans(), clone)
+}
+
 func TestResourceSpans_Resource(t *testing.T) {
 	ms := NewResourceSpans()
 	internal.FillTestResource(internal.Resource(ms.Resource()))
@@ -39,6 +39,7 @@ func TestResourceSpansSliceReadOnly(t *testing.T) {
 	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewResourceSpansSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestResourceSpansSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestResourceSpansSlice(), es)
 }
 
+func TestResourceSpansSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestResourceSpansSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewResourceSpans()
+	testVal := generateTestResourceSpans()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestResourceSpans(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestResourceSpansSlice().At(i), es.At(i))
+	}
+}
+
 func TestResourceSpansSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestResourceSpansSlice()
@@ -103,6 +123,15 @@ func TestResourceSpansSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewResourceSpansSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestResourceSpansSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestResourceSpansSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestResourceSpansSlice_RemoveIf(t *testing.T) {
@@ -42,6 +42,8 @@ func NewScopeSpans() ScopeSpans {
 func (ms ScopeSpans) MoveTo(dest ScopeSpans) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
+	// The subtrees shared with another instance cannot be moved out of this one.
+	ms.state.CopyAllShared()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ScopeSpans{}
 }
@@ -64,6 +66,7 @@ func (ms ScopeSpans) SetSchemaUrl(v string) {
 
 // Spans returns the Spans associated with this ScopeSpans.
 func (ms ScopeSpans) Spans() SpanSlice {
+	ms.state.CopyIfShared(&ms.orig.Spans)
 	return newSpanSlice(&ms.orig.Spans, ms.state)
 }
 
@@ -74,3 +77,10 @@ func (ms ScopeSpans) CopyTo(dest ScopeSpans) {
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Spans().CopyTo(dest.Spans())
 }
+
+// Clone returns a deep copy of the ScopeSpans in a new instance.
+func (ms ScopeSpans) Clone() ScopeSpans {
+	dest := NewScopeSpans()
+	ms.CopyTo(dest)
+	return dest
+}
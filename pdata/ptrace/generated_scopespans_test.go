@@ -39,6 +39,14 @@ func TestScopeSpans_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newScopeSpans(&otlptrace.ScopeSpans{}, &sharedState)) })
 }
 
+func TestScopeSpans_Clone(t *testing.T) {
+	ms := generateTestScopeSpans()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewScopeSpans().CopyTo(ms)
+	assert.Equal(t, generateTestScopeSpans(), clone)
+}
+
 func TestScopeSpans_Scope(t *testing.T) {
 	ms := NewScopeSpans()
 	internal.FillTestInstrumentationScope(internal.InstrumentationScope(ms.Scope()))
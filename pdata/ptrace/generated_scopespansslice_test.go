@@ -39,6 +39,7 @@ func TestScopeSpansSliceReadOnly(t *testing.T) {
 	es := newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewScopeSpansSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestScopeSpansSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestScopeSpansSlice(), es)
 }
 
+func TestScopeSpansSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestScopeSpansSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestScopeSpansSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewScopeSpans()
+	testVal := generateTestScopeSpans()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestScopeSpans(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestScopeSpansSlice().At(i), es.At(i))
+	}
+}
+
 func TestScopeSpansSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestScopeSpansSlice()
@@ -103,6 +123,15 @@ func TestScopeSpansSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewScopeSpansSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestScopeSpansSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestScopeSpansSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestScopeSpansSlice_RemoveIf(t *testing.T) {
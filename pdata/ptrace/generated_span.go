@@ -214,3 +214,10 @@ func (ms Span) CopyTo(dest Span) {
 	dest.SetDroppedLinksCount(ms.DroppedLinksCount())
 	ms.Status().CopyTo(dest.Status())
 }
+
+// Clone returns a deep copy of the Span in a new instance.
+func (ms Span) Clone() Span {
+	dest := NewSpan()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -40,6 +40,14 @@ func TestSpan_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSpan(&otlptrace.Span{}, &sharedState)) })
 }
 
+func TestSpan_Clone(t *testing.T) {
+	ms := generateTestSpan()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSpan().CopyTo(ms)
+	assert.Equal(t, generateTestSpan(), clone)
+}
+
 func TestSpan_TraceID(t *testing.T) {
 	ms := NewSpan()
 	assert.Equal(t, pcommon.TraceID(data.TraceID([16]byte{})), ms.TraceID())
@@ -93,3 +93,10 @@ func (ms SpanEvent) CopyTo(dest SpanEvent) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Clone returns a deep copy of the SpanEvent in a new instance.
+func (ms SpanEvent) Clone() SpanEvent {
+	dest := NewSpanEvent()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -39,6 +39,14 @@ func TestSpanEvent_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSpanEvent(&otlptrace.Span_Event{}, &sharedState)) })
 }
 
+func TestSpanEvent_Clone(t *testing.T) {
+	ms := generateTestSpanEvent()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSpanEvent().CopyTo(ms)
+	assert.Equal(t, generateTestSpanEvent(), clone)
+}
+
 func TestSpanEvent_Timestamp(t *testing.T) {
 	ms := NewSpanEvent()
 	assert.Equal(t, pcommon.Timestamp(0), ms.Timestamp())
@@ -39,6 +39,7 @@ func TestSpanEventSliceReadOnly(t *testing.T) {
 	es := newSpanEventSlice(&[]*otlptrace.Span_Event{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewSpanEventSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestSpanEventSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestSpanEventSlice(), es)
 }
 
+func TestSpanEventSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestSpanEventSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewSpanEvent()
+	testVal := generateTestSpanEvent()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestSpanEvent(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestSpanEventSlice().At(i), es.At(i))
+	}
+}
+
 func TestSpanEventSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestSpanEventSlice()
@@ -103,6 +123,15 @@ func TestSpanEventSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewSpanEventSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestSpanEventSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestSpanEventSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestSpanEventSlice_RemoveIf(t *testing.T) {
@@ -113,3 +113,10 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Clone returns a deep copy of the SpanLink in a new instance.
+func (ms SpanLink) Clone() SpanLink {
+	dest := NewSpanLink()
+	ms.CopyTo(dest)
+	return dest
+}
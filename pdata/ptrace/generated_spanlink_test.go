@@ -40,6 +40,14 @@ func TestSpanLink_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newSpanLink(&otlptrace.Span_Link{}, &sharedState)) })
 }
 
+func TestSpanLink_Clone(t *testing.T) {
+	ms := generateTestSpanLink()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewSpanLink().CopyTo(ms)
+	assert.Equal(t, generateTestSpanLink(), clone)
+}
+
 func TestSpanLink_TraceID(t *testing.T) {
 	ms := NewSpanLink()
 	assert.Equal(t, pcommon.TraceID(data.TraceID([16]byte{})), ms.TraceID())
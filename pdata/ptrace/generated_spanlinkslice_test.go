@@ -39,6 +39,7 @@ func TestSpanLinkSliceReadOnly(t *testing.T) {
 	es := newSpanLinkSlice(&[]*otlptrace.Span_Link{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewSpanLinkSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestSpanLinkSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestSpanLinkSlice(), es)
 }
 
+func TestSpanLinkSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestSpanLinkSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewSpanLink()
+	testVal := generateTestSpanLink()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestSpanLink(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestSpanLinkSlice().At(i), es.At(i))
+	}
+}
+
 func TestSpanLinkSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestSpanLinkSlice()
@@ -103,6 +123,15 @@ func TestSpanLinkSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewSpanLinkSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestSpanLinkSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestSpanLinkSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestSpanLinkSlice_RemoveIf(t *testing.T) {
@@ -39,6 +39,7 @@ func TestSpanSliceReadOnly(t *testing.T) {
 	es := newSpanSlice(&[]*otlptrace.Span{}, &sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.AppendEmptyN(2) })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
 	es2 := NewSpanSlice()
 	es.CopyTo(es2)
@@ -80,6 +81,25 @@ func TestSpanSlice_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, generateTestSpanSlice(), es)
 }
 
+func TestSpanSlice_AppendEmptyN(t *testing.T) {
+	es := generateTestSpanSlice()
+	es.AppendEmptyN(0)
+	assert.Equal(t, generateTestSpanSlice(), es)
+
+	es.AppendEmptyN(3)
+	assert.Equal(t, 10, es.Len())
+	emptyVal := NewSpan()
+	testVal := generateTestSpan()
+	for i := 7; i < es.Len(); i++ {
+		assert.Equal(t, emptyVal, es.At(i))
+		fillTestSpan(es.At(i))
+		assert.Equal(t, testVal, es.At(i))
+	}
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, generateTestSpanSlice().At(i), es.At(i))
+	}
+}
+
 func TestSpanSlice_MoveAndAppendTo(t *testing.T) {
 	// Test MoveAndAppendTo to empty
 	expectedSlice := generateTestSpanSlice()
@@ -103,6 +123,15 @@ func TestSpanSlice_MoveAndAppendTo(t *testing.T) {
 		assert.Equal(t, expectedSlice.At(i), dest.At(i))
 		assert.Equal(t, expectedSlice.At(i), dest.At(i+expectedSlice.Len()))
 	}
+
+	// Test MoveAndAppendTo to empty slice with a smaller capacity reuses the moved vector
+	dest = NewSpanSlice()
+	dest.EnsureCapacity(1)
+	src = generateTestSpanSlice()
+	origs := *src.orig
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, generateTestSpanSlice(), dest)
+	assert.Equal(t, &origs[0], &(*dest.orig)[0])
 }
 
 func TestSpanSlice_RemoveIf(t *testing.T) {
@@ -74,3 +74,10 @@ func (ms Status) CopyTo(dest Status) {
 	dest.SetCode(ms.Code())
 	dest.SetMessage(ms.Message())
 }
+
+// Clone returns a deep copy of the Status in a new instance.
+func (ms Status) Clone() Status {
+	dest := NewStatus()
+	ms.CopyTo(dest)
+	return dest
+}
@@ -38,6 +38,14 @@ func TestStatus_CopyTo(t *testing.T) {
 	assert.Panics(t, func() { ms.CopyTo(newStatus(&otlptrace.Status{}, &sharedState)) })
 }
 
+func TestStatus_Clone(t *testing.T) {
+	ms := generateTestStatus()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewStatus().CopyTo(ms)
+	assert.Equal(t, generateTestStatus(), clone)
+}
+
 func TestStatus_Code(t *testing.T) {
 	ms := NewStatus()
 	assert.Equal(t, StatusCode(0), ms.Code())
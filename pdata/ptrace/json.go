@@ -6,6 +6,7 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -15,6 +16,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
+var _ StreamMarshaler = (*JSONMarshaler)(nil)
+
 // JSONMarshaler marshals pdata.Traces to JSON bytes using the OTLP/JSON format.
 type JSONMarshaler struct{}
 
@@ -26,6 +29,12 @@ func (*JSONMarshaler) MarshalTraces(td Traces) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalTracesTo writes the OTLP/JSON encoding of the given pdata.Traces into w.
+func (*JSONMarshaler) MarshalTracesTo(w io.Writer, td Traces) error {
+	pb := internal.TracesToProto(internal.Traces(td))
+	return json.Marshal(w, &pb)
+}
+
 // JSONUnmarshaler unmarshals OTLP/JSON formatted-bytes to pdata.Traces.
 type JSONUnmarshaler struct{}
 
@@ -4,6 +4,7 @@
 package ptrace
 
 import (
+	"bytes"
 	"testing"
 
 	jsoniter "github.com/json-iterator/go"
@@ -104,6 +105,13 @@ func TestJSONMarshal(t *testing.T) {
 	assert.Equal(t, tracesJSON, string(jsonBuf))
 }
 
+func TestJSONMarshalTo(t *testing.T) {
+	encoder := &JSONMarshaler{}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, encoder.MarshalTracesTo(buf, tracesOTLP))
+	assert.Equal(t, tracesJSON, buf.String())
+}
+
 func TestJSONUnmarshalInvalid(t *testing.T) {
 	jsonStr := `{"extra":"", "resourceSpans": "extra"}`
 	decoder := &JSONUnmarshaler{}
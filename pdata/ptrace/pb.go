@@ -4,20 +4,44 @@
 package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 
 import (
+	"io"
+
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
+var _ StreamMarshaler = (*ProtoMarshaler)(nil)
 
 type ProtoMarshaler struct{}
 
+// MarshalTraces to the OTLP/protobuf format.
+// If the read-only td retains the encoding it was decoded from, that encoding is returned as is.
 func (e *ProtoMarshaler) MarshalTraces(td Traces) ([]byte, error) {
+	if raw := internal.TracesRawProto(internal.Traces(td)); raw != nil {
+		return raw, nil
+	}
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Marshal()
 }
 
+// MarshalTracesTo writes the OTLP/protobuf encoding of the given pdata.Traces into w. The ResourceSpans are encoded
+// and written one at a time, so that only the encoding of the largest one is held in memory.
+// If the read-only td retains the encoding it was decoded from, that encoding is written as is.
+func (e *ProtoMarshaler) MarshalTracesTo(w io.Writer, td Traces) error {
+	if raw := internal.TracesRawProto(internal.Traces(td)); raw != nil {
+		_, err := w.Write(raw)
+		return err
+	}
+	pb := internal.TracesToProto(internal.Traces(td))
+	return otlp.WriteProtoFieldOne(w, pb.ResourceSpans)
+}
+
 func (e *ProtoMarshaler) TracesSize(td Traces) int {
+	if raw := internal.TracesRawProto(internal.Traces(td)); raw != nil {
+		return len(raw)
+	}
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Size()
 }
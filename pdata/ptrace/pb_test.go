@@ -4,6 +4,9 @@
 package ptrace
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +35,52 @@ func TestProtoSizer(t *testing.T) {
 	assert.Equal(t, len(bytes), size)
 }
 
+func TestProtoMarshalTo(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("foo", "bar")
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("foo", strings.Repeat("x", 200))
+	td.ResourceSpans().AppendEmpty()
+
+	expected, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+
+	// The ResourceSpans are written one at a time.
+	w := &recordingWriter{}
+	require.NoError(t, marshaler.MarshalTracesTo(w, td))
+	assert.Equal(t, expected, w.Bytes())
+	assert.Len(t, w.writes, 3)
+	assert.Less(t, w.writes[1], len(expected))
+
+	w = &recordingWriter{}
+	require.NoError(t, marshaler.MarshalTracesTo(w, NewTraces()))
+	assert.Empty(t, w.Bytes())
+}
+
+func TestProtoMarshalToWriterError(t *testing.T) {
+	marshaler := &ProtoMarshaler{}
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	assert.Error(t, marshaler.MarshalTracesTo(errWriter{}, td))
+}
+
+// recordingWriter records the sizes of the writes.
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return w.Buffer.Write(p)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
 func TestProtoSizerEmptyTraces(t *testing.T) {
 	sizer := &ProtoMarshaler{}
 	assert.Equal(t, 0, sizer.TracesSize(NewTraces()))
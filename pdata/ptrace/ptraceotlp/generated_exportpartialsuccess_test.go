@@ -44,6 +44,14 @@ func TestExportPartialSuccess_CopyTo(t *testing.T) {
 	})
 }
 
+func TestExportPartialSuccess_Clone(t *testing.T) {
+	ms := generateTestExportPartialSuccess()
+	clone := ms.Clone()
+	assert.Equal(t, ms, clone)
+	NewExportPartialSuccess().CopyTo(ms)
+	assert.Equal(t, generateTestExportPartialSuccess(), clone)
+}
+
 func TestExportPartialSuccess_RejectedSpans(t *testing.T) {
 	ms := NewExportPartialSuccess()
 	assert.Equal(t, int64(0), ms.RejectedSpans())
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptraceotlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func doHTTPRequest(t *testing.T, srv GRPCServer, method, contentType, contentEncoding string, body []byte,
+	opts ...HTTPHandlerOption) *http.Response {
+	ts := httptest.NewServer(NewHTTPHandler(srv, opts...))
+	t.Cleanup(ts.Close)
+	req, err := http.NewRequest(method, ts.URL+"/v1/traces", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Encoding", contentEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, resp.Body.Close()) })
+	return resp
+}
+
+func TestHTTP(t *testing.T) {
+	protoReq, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+	resp := doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/x-protobuf", "", protoReq)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-protobuf", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	tr := NewExportResponse()
+	require.NoError(t, tr.UnmarshalProto(body))
+	assert.Equal(t, NewExportResponse(), tr)
+
+	jsonReq, err := generateTracesRequest().MarshalJSON()
+	require.NoError(t, err)
+	var gzipReq bytes.Buffer
+	gw := gzip.NewWriter(&gzipReq)
+	_, err = gw.Write(jsonReq)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	resp = doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/json; charset=utf-8", "gzip", gzipReq.Bytes())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	tr = NewExportResponse()
+	require.NoError(t, tr.UnmarshalJSON(body))
+	assert.Equal(t, NewExportResponse(), tr)
+}
+
+func TestHTTPError(t *testing.T) {
+	throttled, err := status.New(codes.ResourceExhausted, "throttled").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)})
+	require.NoError(t, err)
+	protoReq, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		err                error
+		body               []byte
+		expectedStatusCode int
+		expectedCode       codes.Code
+		expectedRetryAfter string
+	}{
+		{
+			name:               "unknown",
+			err:                errors.New("my error"),
+			body:               protoReq,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       codes.Unknown,
+		},
+		{
+			name:               "unavailable",
+			err:                status.Error(codes.Unavailable, "my error"),
+			body:               protoReq,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedCode:       codes.Unavailable,
+		},
+		{
+			name:               "throttled",
+			err:                throttled.Err(),
+			body:               protoReq,
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       codes.ResourceExhausted,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:               "invalid request",
+			body:               []byte("invalid"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doHTTPRequest(t, &fakeTracesServer{t: t, err: tt.err}, http.MethodPost, "application/x-protobuf", "", tt.body)
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.expectedRetryAfter, resp.Header.Get("Retry-After"))
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			st := &spb.Status{}
+			require.NoError(t, proto.Unmarshal(body, st))
+			assert.Equal(t, int32(tt.expectedCode), st.Code)
+		})
+	}
+
+	resp := doHTTPRequest(t, &fakeTracesServer{t: t, err: errors.New("my error")}, http.MethodPost, "application/json", "", []byte("{"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	st := &spb.Status{}
+	require.NoError(t, protojson.Unmarshal(body, st))
+	assert.Equal(t, int32(codes.InvalidArgument), st.Code)
+}
+
+func TestHTTPUnsupportedRequest(t *testing.T) {
+	resp := doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodGet, "application/x-protobuf", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp = doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "text/plain", "", nil)
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	resp = doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/x-protobuf", "br", nil)
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	resp = doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/x-protobuf", "gzip", []byte("invalid"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHTTPMaxRequestBodySize(t *testing.T) {
+	protoReq, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+	resp := doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/x-protobuf", "", protoReq,
+		WithMaxRequestBodySize(int64(len(protoReq))))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The limit applies to the decompressed body.
+	var gzipReq bytes.Buffer
+	gw := gzip.NewWriter(&gzipReq)
+	_, err = gw.Write(protoReq)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	resp = doHTTPRequest(t, &fakeTracesServer{t: t}, http.MethodPost, "application/x-protobuf", "gzip", gzipReq.Bytes(),
+		WithMaxRequestBodySize(int64(len(protoReq)-1)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
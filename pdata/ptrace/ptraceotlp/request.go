@@ -20,6 +20,7 @@ var jsonUnmarshaler = &ptrace.JSONUnmarshaler{}
 type ExportRequest struct {
 	orig  *otlpcollectortrace.ExportTraceServiceRequest
 	state *internal.State
+	raw   *[]byte
 }
 
 // NewExportRequest returns an empty ExportRequest.
@@ -28,6 +29,7 @@ func NewExportRequest() ExportRequest {
 	return ExportRequest{
 		orig:  &otlpcollectortrace.ExportTraceServiceRequest{},
 		state: &state,
+		raw:   new([]byte),
 	}
 }
 
@@ -38,23 +40,53 @@ func NewExportRequestFromTraces(td ptrace.Traces) ExportRequest {
 	return ExportRequest{
 		orig:  internal.GetOrigTraces(internal.Traces(td)),
 		state: internal.GetTracesState(internal.Traces(td)),
+		raw:   internal.GetTracesRaw(internal.Traces(td)),
 	}
 }
 
 // MarshalProto marshals ExportRequest into proto bytes.
 func (ms ExportRequest) MarshalProto() ([]byte, error) {
+	if raw := internal.TracesRawProto(internal.Traces(ms.Traces())); raw != nil {
+		return raw, nil
+	}
 	return ms.orig.Marshal()
 }
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
+	ms.resetRaw()
+	if err := ms.orig.Unmarshal(data); err != nil {
+		return err
+	}
+	otlp.MigrateTraces(ms.orig.ResourceSpans)
+	return nil
+}
+
+// UnmarshalProtoPassthrough unmarshalls ExportRequest from proto bytes and marks it as read-only.
+// The given bytes are retained, so that marshaling the request or its ptrace.Traces to OTLP/protobuf
+// returns them as is instead of encoding the data again. Because of that, data must not be modified
+// by the caller after this call.
+// Data that needs to be modified down the pipeline has to be copied, which also drops the retained bytes.
+func (ms ExportRequest) UnmarshalProtoPassthrough(data []byte) error {
+	ms.resetRaw()
 	if err := ms.orig.Unmarshal(data); err != nil {
 		return err
 	}
+	retainRaw := ms.raw != nil && !hasDeprecatedFields(ms.orig)
 	otlp.MigrateTraces(ms.orig.ResourceSpans)
+	ms.Traces().MarkReadOnly()
+	if retainRaw {
+		*ms.raw = data
+	}
 	return nil
 }
 
+func (ms ExportRequest) resetRaw() {
+	if ms.raw != nil {
+		*ms.raw = nil
+	}
+}
+
 // MarshalJSON marshals ExportRequest into JSON bytes.
 func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
@@ -66,6 +98,7 @@ func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls ExportRequest from JSON bytes.
 func (ms ExportRequest) UnmarshalJSON(data []byte) error {
+	ms.resetRaw()
 	td, err := jsonUnmarshaler.UnmarshalTraces(data)
 	if err != nil {
 		return err
@@ -75,5 +108,19 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Traces() ptrace.Traces {
-	return ptrace.Traces(internal.NewTraces(ms.orig, ms.state))
+	if ms.raw == nil || *ms.raw == nil {
+		return ptrace.Traces(internal.NewTraces(ms.orig, ms.state))
+	}
+	return ptrace.Traces(internal.NewTracesWithRaw(ms.orig, ms.state, ms.raw))
+}
+
+// hasDeprecatedFields returns true if the request uses deprecated fields that are migrated
+// after unmarshaling, in which case the original encoding does not match the data anymore.
+func hasDeprecatedFields(orig *otlpcollectortrace.ExportTraceServiceRequest) bool {
+	for _, r := range orig.ResourceSpans {
+		if len(r.DeprecatedScopeSpans) > 0 {
+			return true
+		}
+	}
+	return false
 }
@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 var _ json.Unmarshaler = ExportRequest{}
@@ -53,3 +56,47 @@ func TestRequestJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, strings.Join(strings.Fields(string(tracesRequestJSON)), ""), string(got))
 }
+
+func TestRequestProtoPassthrough(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test_span")
+	buf, err := NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	// Append an unknown field, which is dropped when the data is encoded again.
+	buf = append(buf, 0x98, 0x06, 0x01)
+
+	tr := NewExportRequest()
+	require.NoError(t, tr.UnmarshalProtoPassthrough(buf))
+	assert.True(t, tr.Traces().IsReadOnly())
+	assert.Equal(t, 1, tr.Traces().SpanCount())
+
+	got, err := tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+
+	got, err = (&ptrace.ProtoMarshaler{}).MarshalTraces(tr.Traces())
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	assert.Equal(t, len(buf), (&ptrace.ProtoMarshaler{}).TracesSize(tr.Traces()))
+
+	// A copy of the data does not retain the original encoding.
+	cp := ptrace.NewTraces()
+	tr.Traces().CopyTo(cp)
+	got, err = NewExportRequestFromTraces(cp).MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+
+	// Regular unmarshaling does not retain the original encoding.
+	tr = NewExportRequest()
+	require.NoError(t, tr.UnmarshalProto(buf))
+	got, err = tr.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf[:len(buf)-3], got)
+	assert.Equal(t, cp, tr.Traces())
+}
+
+func TestRequestProtoPassthroughError(t *testing.T) {
+	tr := NewExportRequest()
+	assert.Error(t, tr.UnmarshalProtoPassthrough([]byte("+$%")))
+	assert.False(t, tr.Traces().IsReadOnly())
+}
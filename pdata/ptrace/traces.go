@@ -6,6 +6,8 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
@@ -32,7 +34,7 @@ func NewTraces() Traces {
 
 // IsReadOnly returns true if this Traces instance is read-only.
 func (ms Traces) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Traces instance overriding the destination.
@@ -43,7 +45,7 @@ func (ms Traces) CopyTo(dest Traces) {
 // SpanCount calculates the total number of spans.
 func (ms Traces) SpanCount() int {
 	spanCount := 0
-	rss := ms.ResourceSpans()
+	rss := ms.readOnlyResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
 		ilss := rs.ScopeSpans()
@@ -63,3 +65,117 @@ func (ms Traces) ResourceSpans() ResourceSpansSlice {
 func (ms Traces) MarkReadOnly() {
 	internal.SetTracesState(internal.Traces(ms), internal.StateReadOnly)
 }
+
+// Clone returns a deep copy of the Traces in a new instance.
+func (ms Traces) Clone() Traces {
+	dest := NewTraces()
+	ms.CopyTo(dest)
+	return dest
+}
+
+// CloneResource returns a copy of the Traces in which only the resources are copied, the ScopeSpans are
+// shared with the Traces and copied on write, i.e. the ScopeSpans of a resource are copied when first
+// accessed in the returned Traces. This makes modifying the resources, e.g. adding a resource attribute
+// to read-only data, much cheaper than with Clone. Since the ScopeSpans are shared, the Traces is marked
+// as read-only.
+func (ms Traces) CloneResource() Traces {
+	dest := NewTraces()
+	state := dest.getState()
+	srcResourceSpans := ms.readOnlyResourceSpans()
+	destResourceSpans := dest.ResourceSpans()
+	destResourceSpans.EnsureCapacity(srcResourceSpans.Len())
+	for i := 0; i < srcResourceSpans.Len(); i++ {
+		srcR := srcResourceSpans.At(i)
+		destR := destResourceSpans.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		destR.orig.ScopeSpans = srcR.orig.ScopeSpans
+		shareScopeSpans(state, destR.orig)
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// CloneScope returns a copy of the Traces in which only the resources and the scopes are copied, the spans are
+// shared with the Traces and copied on write, i.e. the spans of a scope are copied when first accessed in the
+// returned Traces. This makes modifying the resources or the scopes much cheaper than with Clone.
+// Since the spans are shared, the Traces is marked as read-only.
+func (ms Traces) CloneScope() Traces {
+	dest := NewTraces()
+	state := dest.getState()
+	srcResourceSpans := ms.readOnlyResourceSpans()
+	destResourceSpans := dest.ResourceSpans()
+	destResourceSpans.EnsureCapacity(srcResourceSpans.Len())
+	for i := 0; i < srcResourceSpans.Len(); i++ {
+		srcR := srcResourceSpans.At(i)
+		destR := destResourceSpans.AppendEmpty()
+		srcR.Resource().CopyTo(destR.Resource())
+		destR.SetSchemaUrl(srcR.SchemaUrl())
+		srcScopeSpans := srcR.ScopeSpans()
+		destScopeSpans := destR.ScopeSpans()
+		destScopeSpans.EnsureCapacity(srcScopeSpans.Len())
+		for j := 0; j < srcScopeSpans.Len(); j++ {
+			srcS := srcScopeSpans.At(j)
+			destS := destScopeSpans.AppendEmpty()
+			srcS.Scope().CopyTo(destS.Scope())
+			destS.SetSchemaUrl(srcS.SchemaUrl())
+			destS.orig.Spans = srcS.orig.Spans
+			shareSpans(state, destS.orig)
+		}
+	}
+	ms.MarkReadOnly()
+	return dest
+}
+
+// InternAttributes replaces the attribute keys and string values of the resources, scopes, spans, span events
+// and span links with the strings interned by si, so that the data repeating them shares a single copy.
+func (ms Traces) InternAttributes(si *pcommon.StringInterner) {
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		rs.Resource().Attributes().Intern(si)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			ss.Scope().Attributes().Intern(si)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.Attributes().Intern(si)
+				for l := 0; l < span.Events().Len(); l++ {
+					span.Events().At(l).Attributes().Intern(si)
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					span.Links().At(l).Attributes().Intern(si)
+				}
+			}
+		}
+	}
+}
+
+// readOnlyResourceSpans returns the ResourceSpansSlice through a read-only state,
+// so that the subtrees shared with another instance are read without being copied.
+func (ms Traces) readOnlyResourceSpans() ResourceSpansSlice {
+	state := internal.StateReadOnly
+	return newResourceSpansSlice(&ms.getOrig().ResourceSpans, &state)
+}
+
+// shareScopeSpans registers the ScopeSpans of the ResourceSpans as shared, they are copied when first accessed.
+func shareScopeSpans(state *internal.State, orig *otlptrace.ResourceSpans) {
+	state.Share(&orig.ScopeSpans, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.ScopeSpans
+		orig.ScopeSpans = nil
+		newScopeSpansSlice(&shared, &sharedState).CopyTo(newScopeSpansSlice(&orig.ScopeSpans, state))
+	})
+}
+
+// shareSpans registers the spans of the ScopeSpans as shared, they are copied when first accessed.
+func shareSpans(state *internal.State, orig *otlptrace.ScopeSpans) {
+	state.Share(&orig.Spans, func() {
+		sharedState := internal.StateReadOnly
+		shared := orig.Spans
+		orig.Spans = nil
+		newSpanSlice(&shared, &sharedState).CopyTo(newSpanSlice(&orig.Spans, state))
+	})
+}
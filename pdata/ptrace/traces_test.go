@@ -4,8 +4,10 @@
 package ptrace
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -126,6 +128,97 @@ func TestReadOnlyTracesInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestTracesClone(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	clone := traces.Clone()
+	assert.Equal(t, traces, clone)
+	traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice(), clone.ResourceSpans())
+	assert.False(t, traces.IsReadOnly())
+}
+
+func TestTracesCloneResource(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	clone := traces.CloneResource()
+	assert.True(t, traces.IsReadOnly())
+	assert.False(t, clone.IsReadOnly())
+
+	// The scope spans are shared until accessed, counting the spans does not copy them.
+	assert.Equal(t, traces.SpanCount(), clone.SpanCount())
+	assert.Same(t, &traces.getOrig().ResourceSpans[0].ScopeSpans[0], &clone.getOrig().ResourceSpans[0].ScopeSpans[0])
+
+	clone.ResourceSpans().At(0).Resource().Attributes().PutStr("cloned", "true")
+	_, ok := traces.ResourceSpans().At(0).Resource().Attributes().Get("cloned")
+	assert.False(t, ok)
+	assert.Same(t, &traces.getOrig().ResourceSpans[0].ScopeSpans[0], &clone.getOrig().ResourceSpans[0].ScopeSpans[0])
+
+	span := clone.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.NotSame(t, &traces.getOrig().ResourceSpans[0].ScopeSpans[0], &clone.getOrig().ResourceSpans[0].ScopeSpans[0])
+	span.SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, traces.ResourceSpans().orig)
+	assert.Equal(t, "changed", clone.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestTracesCloneScope(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	clone := traces.CloneScope()
+	assert.True(t, traces.IsReadOnly())
+	assert.Equal(t, traces.getOrig(), clone.getOrig())
+
+	clone.ResourceSpans().At(0).ScopeSpans().At(0).Scope().SetName("changed")
+	assert.Same(t, &traces.getOrig().ResourceSpans[0].ScopeSpans[0].Spans[0], &clone.getOrig().ResourceSpans[0].ScopeSpans[0].Spans[0])
+	clone.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, traces.ResourceSpans().orig)
+}
+
+func TestTracesCloneMoveShared(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	clone := traces.CloneScope()
+
+	// The shared spans are copied before being moved out of the clone.
+	dest := NewTraces()
+	clone.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	assert.Equal(t, 0, clone.SpanCount())
+	dest.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, traces.ResourceSpans().orig)
+
+	clone = traces.CloneResource()
+	rs := NewResourceSpans()
+	clone.ResourceSpans().At(0).MoveTo(rs)
+	rs.ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, traces.ResourceSpans().orig)
+}
+
+func TestTracesInternAttributes(t *testing.T) {
+	traces := NewTraces()
+	for i := 0; i < 2; i++ {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(strings.Clone("service.name"), strings.Clone("svc"))
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.Events().AppendEmpty().Attributes().PutStr("event", strings.Clone("svc"))
+		span.Links().AppendEmpty().Attributes().PutStr("link", strings.Clone("svc"))
+	}
+	expected := traces.Clone()
+	si := pcommon.NewStringInterner(10)
+	traces.InternAttributes(si)
+	assert.Equal(t, expected, traces)
+
+	svc := unsafe.StringData(si.Intern(strings.Clone("svc")))
+	for _, rs := range traces.getOrig().ResourceSpans {
+		assert.Same(t, svc, unsafe.StringData(rs.Resource.Attributes[0].Value.GetStringValue()))
+		span := rs.ScopeSpans[0].Spans[0]
+		assert.Same(t, svc, unsafe.StringData(span.Events[0].Attributes[0].Value.GetStringValue()))
+		assert.Same(t, svc, unsafe.StringData(span.Links[0].Attributes[0].Value.GetStringValue()))
+	}
+
+	traces.MarkReadOnly()
+	assert.Panics(t, func() { traces.InternAttributes(si) })
+}
+
 func BenchmarkTracesUsage(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/clock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -39,6 +40,7 @@ var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
 type batchProcessor struct {
 	logger           *zap.Logger
+	clock            clock.Clock
 	timeout          time.Duration
 	sendBatchSize    int
 	sendBatchMaxSize int
@@ -83,7 +85,7 @@ type shard struct {
 	exportCtx context.Context
 
 	// timer informs the shard send a batch.
-	timer *time.Timer
+	timer clock.Timer
 
 	// newItem is used to receive data items from producers.
 	newItem chan any
@@ -110,7 +112,7 @@ var _ consumer.Metrics = (*batchProcessor)(nil)
 var _ consumer.Logs = (*batchProcessor)(nil)
 
 // newBatchProcessor returns a new batch processor component.
-func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func() batch) (*batchProcessor, error) {
+func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func() batch, clk clock.Clock) (*batchProcessor, error) {
 	// use lower-case, to be consistent with http/2 headers.
 	mks := make([]string, len(cfg.MetadataKeys))
 	for i, k := range cfg.MetadataKeys {
@@ -119,6 +121,7 @@ func newBatchProcessor(set processor.CreateSettings, cfg *Config, batchFunc func
 	sort.Strings(mks)
 	bp := &batchProcessor{
 		logger: set.Logger,
+		clock:  clk,
 
 		sendBatchSize:    int(cfg.SendBatchSize),
 		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
@@ -191,8 +194,8 @@ func (b *shard) startLoop() {
 	// timer, since <- from a nil channel is blocking.
 	var timerCh <-chan time.Time
 	if b.processor.timeout != 0 && b.processor.sendBatchSize != 0 {
-		b.timer = time.NewTimer(b.processor.timeout)
-		timerCh = b.timer.C
+		b.timer = b.processor.clock.NewTimer(b.processor.timeout)
+		timerCh = b.timer.Chan()
 	}
 	for {
 		select {
@@ -247,7 +250,7 @@ func (b *shard) hasTimer() bool {
 
 func (b *shard) stopTimer() {
 	if b.hasTimer() && !b.timer.Stop() {
-		<-b.timer.C
+		<-b.timer.Chan()
 	}
 }
 
@@ -358,28 +361,34 @@ func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(set processor.CreateSettings, next consumer.Traces, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchTraces(next) })
+	return newBatchProcessor(set, cfg, func() batch { return newBatchTraces(next, cfg.KeepTracesTogether) }, clock.System())
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(set processor.CreateSettings, next consumer.Metrics, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(next) })
+	return newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(next) }, clock.System())
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(set processor.CreateSettings, next consumer.Logs, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchLogs(next) })
+	return newBatchProcessor(set, cfg, func() batch { return newBatchLogs(next) }, clock.System())
 }
 
 type batchTraces struct {
-	nextConsumer consumer.Traces
-	traceData    ptrace.Traces
-	spanCount    int
-	sizer        ptrace.Sizer
-}
-
-func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
-	return &batchTraces{nextConsumer: nextConsumer, traceData: ptrace.NewTraces(), sizer: &ptrace.ProtoMarshaler{}}
+	nextConsumer       consumer.Traces
+	traceData          ptrace.Traces
+	spanCount          int
+	sizer              ptrace.Sizer
+	keepTracesTogether bool
+}
+
+func newBatchTraces(nextConsumer consumer.Traces, keepTracesTogether bool) *batchTraces {
+	return &batchTraces{
+		nextConsumer:       nextConsumer,
+		traceData:          ptrace.NewTraces(),
+		sizer:              &ptrace.ProtoMarshaler{},
+		keepTracesTogether: keepTracesTogether,
+	}
 }
 
 // add updates current batchTraces by adding new TraceData object
@@ -398,7 +407,12 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 	var req ptrace.Traces
 	var sent int
 	var bytes int
-	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
+	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize && bt.keepTracesTogether {
+		// The batch may be smaller than the maximum size to keep the traces together.
+		req = splitTracesByTraceID(sendBatchMaxSize, bt.traceData)
+		sent = req.SpanCount()
+		bt.spanCount -= sent
+	} else if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
 		req = splitTraces(sendBatchMaxSize, bt.traceData)
 		bt.spanCount -= sendBatchMaxSize
 		sent = sendBatchMaxSize
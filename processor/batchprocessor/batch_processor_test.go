@@ -15,11 +15,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/clock/clocktest"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -164,6 +166,50 @@ func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	assert.Equal(t, (requestCount*spansPerRequest)%int(cfg.SendBatchMaxSize), sink.AllTraces()[len(sink.AllTraces())-1].SpanCount())
 }
 
+func TestBatchProcessorSpansDeliveredKeepTracesTogether(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 128
+	cfg.SendBatchMaxSize = 130
+	cfg.KeepTracesTogether = true
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	spansPerRequest := 150
+	spansPerTrace := 10
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		td := testdata.GenerateTraces(spansPerRequest)
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for spanIndex := 0; spanIndex < spansPerRequest; spanIndex++ {
+			spans.At(spanIndex).SetTraceID([16]byte{byte(requestNum), byte(spanIndex / spansPerTrace)})
+		}
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+
+	require.Eventually(t, func() bool {
+		return sink.SpanCount() == requestCount*spansPerRequest
+	}, 10*time.Second, cfg.Timeout)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	batchByTrace := map[pcommon.TraceID]int{}
+	for i, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, td.SpanCount(), int(cfg.SendBatchMaxSize))
+		for j := 0; j < td.ResourceSpans().Len(); j++ {
+			spans := td.ResourceSpans().At(j).ScopeSpans().At(0).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				id := spans.At(k).TraceID()
+				if batch, ok := batchByTrace[id]; ok {
+					assert.Equal(t, batch, i, "the spans of the trace %v are in different batches", id)
+				}
+				batchByTrace[id] = i
+			}
+		}
+	}
+	assert.Len(t, batchByTrace, requestCount*spansPerRequest/spansPerTrace)
+}
+
 func TestBatchProcessorSentBySize(t *testing.T) {
 	telemetryTest(t, testBatchProcessorSentBySize)
 }
@@ -318,6 +364,38 @@ func TestBatchProcessorSentByTimeout(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorSentByTimeoutWithClock(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.Timeout = time.Hour
+
+	clk := clocktest.NewFakeClock(time.Now())
+	batcher, err := newBatchProcessor(processortest.NewNopCreateSettings(), cfg, func() batch { return newBatchTraces(sink, false) }, clk)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return clk.Waiters() == 1
+	}, time.Second, time.Millisecond)
+
+	for requestNum := 0; requestNum < 5; requestNum++ {
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	}
+
+	// The batch is not sent before the timeout elapsed.
+	clk.Advance(cfg.Timeout - time.Nanosecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Zero(t, sink.SpanCount())
+
+	assert.Eventually(t, func() bool {
+		clk.Advance(cfg.Timeout)
+		return sink.SpanCount() == 50
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 50, sink.SpanCount())
+}
+
 func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	cfg := Config{
 		Timeout:       3 * time.Second,
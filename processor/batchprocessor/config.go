@@ -10,8 +10,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighumanize"
 )
 
+// timeoutBounds are the bounds of the timeout after which the batches are sent.
+var timeoutBounds = confighumanize.DurationBounds{Min: time.Millisecond}
+
 // Config defines configuration for batch processor.
 type Config struct {
 	// Timeout sets the time after which a batch will be sent regardless of size.
@@ -28,6 +32,11 @@ type Config struct {
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
 
+	// KeepTracesTogether keeps the spans of the same trace in the same batch when splitting the
+	// batches larger than SendBatchMaxSize, unless the trace is larger than SendBatchMaxSize on
+	// its own. The batches may then be smaller than SendBatchMaxSize.
+	KeepTracesTogether bool `mapstructure:"keep_traces_together"`
+
 	// MetadataKeys is a list of client.Metadata keys that will be
 	// used to form distinct batchers.  If this setting is empty,
 	// a single batcher instance will be used.  When this setting
@@ -61,8 +70,5 @@ func (cfg *Config) Validate() error {
 		}
 		uniq[l] = true
 	}
-	if cfg.Timeout < 0 {
-		return errors.New("timeout must be greater or equal to 0")
-	}
-	return nil
+	return confighumanize.ValidateDuration("timeout", cfg.Timeout, timeoutBounds)
 }
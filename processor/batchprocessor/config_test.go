@@ -33,6 +33,7 @@ func TestUnmarshalConfig(t *testing.T) {
 		&Config{
 			SendBatchSize:            uint32(10000),
 			SendBatchMaxSize:         uint32(11000),
+			KeepTracesTogether:       true,
 			Timeout:                  time.Second * 10,
 			MetadataCardinalityLimit: 1000,
 		}, cfg)
@@ -70,6 +71,14 @@ func TestValidateConfig_InvalidTimeout(t *testing.T) {
 	assert.Error(t, cfg.Validate())
 }
 
+func TestValidateConfig_TimeoutWithoutUnit(t *testing.T) {
+	cm := confmap.NewFromStringMap(map[string]any{"timeout": 5})
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.EqualError(t, component.ValidateConfig(cfg),
+		`'timeout' must be at least 1ms, got 5ns: the durations without unit are in nanoseconds, use e.g. "5s"`)
+}
+
 func TestValidateConfig_ValidZero(t *testing.T) {
 	cfg := &Config{}
 	assert.NoError(t, cfg.Validate())
@@ -48,6 +48,9 @@ func newBatchProcessorTelemetry(set processor.CreateSettings, currentMetadataCar
 		level:         set.MetricsLevel,
 		detailed:      set.MetricsLevel == configtelemetry.LevelDetailed,
 	}
+	if set.PipelineID != (component.ID{}) {
+		bpt.processorAttr = append(bpt.processorAttr, attribute.String(obsmetrics.PipelineKey, set.PipelineID.String()))
+	}
 
 	if err := bpt.createOtelMetrics(set.TelemetrySettings, currentMetadataCardinality); err != nil {
 		return nil, err
@@ -102,7 +105,7 @@ func (bpt *batchProcessorTelemetry) createOtelMetrics(set component.TelemetrySet
 		metric.WithDescription("Number of distinct metadata value combinations being processed"),
 		metric.WithUnit("1"),
 		metric.WithInt64Callback(func(_ context.Context, obs metric.Int64Observer) error {
-			obs.Observe(int64(currentMetadataCardinality()))
+			obs.Observe(int64(currentMetadataCardinality()), metric.WithAttributes(bpt.processorAttr...))
 			return nil
 		}),
 	)
@@ -4,6 +4,7 @@
 package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
 
 import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -64,6 +65,85 @@ func splitTraces(size int, src ptrace.Traces) ptrace.Traces {
 	return dest
 }
 
+// splitTracesByTraceID removes spans from the input trace and returns a new trace of at most the
+// specified size, keeping the spans of the same trace together. The traces are taken in the order
+// of their first span, skipping the ones that do not fit in the remaining space. A trace is split
+// only if it is larger than the specified size on its own.
+func splitTracesByTraceID(size int, src ptrace.Traces) ptrace.Traces {
+	if src.SpanCount() <= size {
+		return src
+	}
+
+	var order []pcommon.TraceID
+	counts := map[pcommon.TraceID]int{}
+	rss := src.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				id := spans.At(k).TraceID()
+				if counts[id] == 0 {
+					order = append(order, id)
+				}
+				counts[id]++
+			}
+		}
+	}
+
+	// The number of spans to move by trace ID.
+	selected := map[pcommon.TraceID]int{}
+	remaining := size
+	for _, id := range order {
+		if counts[id] <= remaining {
+			selected[id] = counts[id]
+			remaining -= counts[id]
+			if remaining == 0 {
+				break
+			}
+		}
+	}
+	if remaining == size {
+		// All the traces are larger than the size, split the first one.
+		selected[order[0]] = size
+	}
+
+	dest := ptrace.NewTraces()
+	src.ResourceSpans().RemoveIf(func(srcRs ptrace.ResourceSpans) bool {
+		var destRs ptrace.ResourceSpans
+		hasDestRs := false
+		srcRs.ScopeSpans().RemoveIf(func(srcIls ptrace.ScopeSpans) bool {
+			var destIls ptrace.ScopeSpans
+			hasDestIls := false
+			srcIls.Spans().RemoveIf(func(srcSpan ptrace.Span) bool {
+				id := srcSpan.TraceID()
+				if selected[id] == 0 {
+					return false
+				}
+				selected[id]--
+				if !hasDestRs {
+					destRs = dest.ResourceSpans().AppendEmpty()
+					srcRs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(srcRs.SchemaUrl())
+					hasDestRs = true
+				}
+				if !hasDestIls {
+					destIls = destRs.ScopeSpans().AppendEmpty()
+					srcIls.Scope().CopyTo(destIls.Scope())
+					destIls.SetSchemaUrl(srcIls.SchemaUrl())
+					hasDestIls = true
+				}
+				srcSpan.MoveTo(destIls.Spans().AppendEmpty())
+				return true
+			})
+			return srcIls.Spans().Len() == 0
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+
+	return dest
+}
+
 // resourceSC calculates the total number of spans in the ptrace.ResourceSpans.
 func resourceSC(rs ptrace.ResourceSpans) (count int) {
 	for k := 0; k < rs.ScopeSpans().Len(); k++ {
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -145,3 +147,67 @@ func TestSplitTracesMultipleILS(t *testing.T) {
 	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-4", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(4).Name())
 }
+
+// generateTracesWithIDs generates a resource per element of the ids, with a span per trace ID
+// in the element.
+func generateTracesWithIDs(ids ...[]byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for i, rsIDs := range ids {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", int64(i))
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for j, id := range rsIDs {
+			span := spans.AppendEmpty()
+			span.SetTraceID(pcommon.TraceID{id})
+			span.SetName(getTestSpanName(i, j))
+		}
+	}
+	return td
+}
+
+func splitTraceIDs(td ptrace.Traces) [][]byte {
+	var ids [][]byte
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		var rsIDs []byte
+		spans := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			rsIDs = append(rsIDs, spans.At(j).TraceID()[0])
+		}
+		ids = append(ids, rsIDs)
+	}
+	return ids
+}
+
+func TestSplitTracesByTraceID_noop(t *testing.T) {
+	td := generateTracesWithIDs([]byte{1, 2}, []byte{1})
+	split := splitTracesByTraceID(3, td)
+	assert.Equal(t, td, split)
+}
+
+func TestSplitTracesByTraceID(t *testing.T) {
+	// Trace 1 is spread over both resources, trace 2 does not fit in the first batch.
+	td := generateTracesWithIDs([]byte{1, 2, 2, 2}, []byte{3, 1})
+
+	split := splitTracesByTraceID(4, td)
+	assert.Equal(t, [][]byte{{1}, {3, 1}}, splitTraceIDs(split))
+	assert.Equal(t, int64(0), split.ResourceSpans().At(0).Resource().Attributes().AsRaw()["resource"])
+	assert.Equal(t, int64(1), split.ResourceSpans().At(1).Resource().Attributes().AsRaw()["resource"])
+	assert.Equal(t, [][]byte{{2, 2, 2}}, splitTraceIDs(td))
+
+	split = splitTracesByTraceID(4, td)
+	assert.Equal(t, td, split)
+}
+
+func TestSplitTracesByTraceID_LargeTrace(t *testing.T) {
+	// Trace 1 is larger than the size on its own.
+	td := generateTracesWithIDs([]byte{1, 1, 1, 2, 2, 2})
+
+	split := splitTracesByTraceID(2, td)
+	assert.Equal(t, [][]byte{{1, 1}}, splitTraceIDs(split))
+	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	require.Equal(t, 4, td.SpanCount())
+
+	split = splitTracesByTraceID(2, td)
+	assert.Equal(t, [][]byte{{1}}, splitTraceIDs(split))
+	assert.Equal(t, [][]byte{{2, 2, 2}}, splitTraceIDs(td))
+}
@@ -105,6 +105,9 @@ func (f *factory) getMemoryLimiter(set processor.CreateSettings, cfg component.C
 		return memLimiter, nil
 	}
 
+	// The instance is shared by all the pipelines using the same config, its telemetry is not attributed
+	// to the pipeline it is created for.
+	set.PipelineID = component.ID{}
 	memLimiter, err := newMemoryLimiterProcessor(set, cfg.(*Config))
 	if err != nil {
 		return nil, err
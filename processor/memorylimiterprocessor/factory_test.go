@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -67,3 +70,28 @@ func TestCreateProcessor(t *testing.T) {
 	// calling it again should throw an error
 	assert.ErrorIs(t, lp.Shutdown(context.Background()), memorylimiter.ErrShutdownNotStarted)
 }
+
+func TestSharedProcessorWithoutPipeline(t *testing.T) {
+	id := component.MustNewID("memory_limiter")
+	tt, err := componenttest.SetupTelemetry(id)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MemoryLimitMiB = 5722
+	cfg.MemorySpikeLimitMiB = 1907
+	cfg.CheckInterval = 100 * time.Millisecond
+
+	set := processor.CreateSettings{ID: id, PipelineID: component.MustNewID("traces"), TelemetrySettings: tt.TelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+	tp, err := factory.CreateTracesProcessor(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	// The instance is shared by the pipelines, its metrics do not have a pipeline label.
+	require.NoError(t, tt.CheckProcessorTraces(1, 0, 0))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/droplog"
 	"go.opentelemetry.io/collector/internal/memorylimiter"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -15,9 +16,13 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
+// dropReason is the reason of the summaries of the data refused by the processor.
+const dropReason = "memory limit reached"
+
 type memoryLimiterProcessor struct {
 	memlimiter *memorylimiter.MemoryLimiter
 	obsrep     *processorhelper.ObsReport
+	dropLog    *droplog.Logger
 }
 
 // newMemoryLimiter returns a new memorylimiter processor.
@@ -37,6 +42,7 @@ func newMemoryLimiterProcessor(set processor.CreateSettings, cfg *Config) (*memo
 	p := &memoryLimiterProcessor{
 		memlimiter: ml,
 		obsrep:     obsrep,
+		dropLog:    droplog.New(set.Logger),
 	}
 
 	return p, nil
@@ -59,6 +65,7 @@ func (p *memoryLimiterProcessor) processTraces(ctx context.Context, td ptrace.Tr
 		// 	assumes that the pipeline is properly configured and a receiver is on the
 		// 	callstack and that the receiver will correctly retry the refused data again.
 		p.obsrep.TracesRefused(ctx, numSpans)
+		p.dropLog.Traces(dropReason, td)
 		return td, memorylimiter.ErrDataRefused
 	}
 
@@ -77,6 +84,7 @@ func (p *memoryLimiterProcessor) processMetrics(ctx context.Context, md pmetric.
 		// 	assumes that the pipeline is properly configured and a receiver is on the
 		// 	callstack.
 		p.obsrep.MetricsRefused(ctx, numDataPoints)
+		p.dropLog.Metrics(dropReason, md)
 		return md, memorylimiter.ErrDataRefused
 	}
 
@@ -95,6 +103,7 @@ func (p *memoryLimiterProcessor) processLogs(ctx context.Context, ld plog.Logs)
 		// 	assumes that the pipeline is properly configured and a receiver is on the
 		// 	callstack.
 		p.obsrep.LogsRefused(ctx, numRecords)
+		p.dropLog.Logs(dropReason, ld)
 		return ld, memorylimiter.ErrDataRefused
 	}
 
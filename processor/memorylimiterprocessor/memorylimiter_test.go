@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -486,3 +488,38 @@ func (be *ballastExtension) GetBallastSize() uint64 {
 func totalMemory() (uint64, error) {
 	return uint64(2048), nil
 }
+
+func TestTraceMemoryPressureLogsDroppedData(t *testing.T) {
+	memorylimiter.GetMemoryFn = totalMemory
+	memorylimiter.ReadMemStatsFn = func(ms *runtime.MemStats) {
+		ms.Alloc = 1800
+	}
+	t.Cleanup(func() {
+		memorylimiter.GetMemoryFn = iruntime.TotalMemory
+		memorylimiter.ReadMemStatsFn = runtime.ReadMemStats
+	})
+
+	core, observed := observer.New(zap.DebugLevel)
+	set := processortest.NewNopCreateSettings()
+	set.Logger = zap.New(core)
+	cfg := &Config{
+		CheckInterval:         time.Second,
+		MemoryLimitPercentage: 50,
+		MemorySpikePercentage: 1,
+	}
+	ml, err := newMemoryLimiterProcessor(set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ml.start(context.Background(), &host{}))
+	ml.memlimiter.CheckMemLimits()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	_, err = ml.processTraces(context.Background(), td)
+	assert.Equal(t, memorylimiter.ErrDataRefused, err)
+	require.NoError(t, ml.shutdown(context.Background()))
+
+	dropped := observed.FilterMessage("Dropped data.").All()
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "memory limit reached", dropped[0].ContextMap()["reason"])
+	assert.Equal(t, []any{"checkout"}, dropped[0].ContextMap()["service_names"])
+}
@@ -41,6 +41,10 @@ type CreateSettings struct {
 	// ID returns the ID of the component that will be created.
 	ID component.ID
 
+	// PipelineID is the ID of the pipeline the processor is created for.
+	// It is empty when the processor is not created by the service, e.g. in tests.
+	PipelineID component.ID
+
 	component.TelemetrySettings
 
 	// BuildInfo can be used by components for informational purposes
@@ -293,13 +297,16 @@ func (b *Builder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
 
-// logStabilityLevel logs the stability level of a component. The log level is set to info for
-// undefined, unmaintained, deprecated and development. The log level is set to debug
-// for alpha, beta and stable.
+// logStabilityLevel logs the stability level of a component. The log level is set to warn for
+// unmaintained, deprecated, development and alpha, and to info for undefined. The log level is set
+// to debug for beta and stable.
 func logStabilityLevel(logger *zap.Logger, sl component.StabilityLevel) {
-	if sl >= component.StabilityLevelAlpha {
+	switch {
+	case sl >= component.StabilityLevelBeta:
 		logger.Debug(sl.LogMessage())
-	} else {
+	case sl == component.StabilityLevelUndefined:
 		logger.Info(sl.LogMessage())
+	default:
+		logger.Warn(sl.LogMessage())
 	}
 }
@@ -39,7 +39,7 @@ func NewLogsProcessor(
 		return nil, errors.New("nil logsFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	eventOptions := spanAttributes(set)
 	bs := fromOptions(options)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		span := trace.SpanFromContext(ctx)
@@ -65,3 +65,9 @@ func NewLogsProcessor(
 		Logs:         logsConsumer,
 	}, nil
 }
+
+// Restart shuts down the processor and starts it again, see WithShutdown and WithStart. It is called by the service
+// when the panic isolation restarts the panicking components.
+func (lp *logProcessor) Restart(ctx context.Context, host component.Host) error {
+	return restart(ctx, host, lp.StartFunc, lp.ShutdownFunc)
+}
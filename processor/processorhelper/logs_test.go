@@ -44,6 +44,28 @@ func TestNewLogsProcessor_WithOptions(t *testing.T) {
 	assert.False(t, lp.Capabilities().MutatesData)
 }
 
+func TestNewLogsProcessor_Restart(t *testing.T) {
+	var calls []string
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil),
+		WithStart(func(context.Context, component.Host) error {
+			calls = append(calls, "start")
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			calls = append(calls, "shutdown")
+			return nil
+		}))
+	require.NoError(t, err)
+
+	restartable, ok := lp.(interface {
+		Restart(context.Context, component.Host) error
+	})
+	require.True(t, ok)
+	assert.NoError(t, restartable.Restart(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []string{"shutdown", "start"}, calls)
+	assert.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+}
+
 func TestNewLogsProcessor_NilRequiredFields(t *testing.T) {
 	_, err := NewLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), &testLogsCfg, consumertest.NewNop(), nil)
 	assert.Error(t, err)
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -39,11 +40,16 @@ func NewMetricsProcessor(
 		return nil, errors.New("nil metricsFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	eventOptions := spanAttributes(set)
 	bs := fromOptions(options)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		if bs.timestampValidator != nil {
+			if invalid := bs.timestampValidator.validateMetrics(md); invalid > 0 {
+				set.Logger.Debug("Invalid data point timestamps", zap.Int("data_points", invalid))
+			}
+		}
 		var err error
 		md, err = metricsFunc(ctx, md)
 		span.AddEvent("End processing.", eventOptions)
@@ -65,3 +71,9 @@ func NewMetricsProcessor(
 		Metrics:      metricsConsumer,
 	}, nil
 }
+
+// Restart shuts down the processor and starts it again, see WithShutdown and WithStart. It is called by the service
+// when the panic isolation restarts the panicking components.
+func (mp *metricsProcessor) Restart(ctx context.Context, host component.Host) error {
+	return restart(ctx, host, mp.StartFunc, mp.ShutdownFunc)
+}
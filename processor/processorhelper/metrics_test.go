@@ -44,6 +44,28 @@ func TestNewMetricsProcessor_WithOptions(t *testing.T) {
 	assert.False(t, mp.Capabilities().MutatesData)
 }
 
+func TestNewMetricsProcessor_Restart(t *testing.T) {
+	var calls []string
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), &testMetricsCfg, consumertest.NewNop(), newTestMProcessor(nil),
+		WithStart(func(context.Context, component.Host) error {
+			calls = append(calls, "start")
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			calls = append(calls, "shutdown")
+			return nil
+		}))
+	require.NoError(t, err)
+
+	restartable, ok := mp.(interface {
+		Restart(context.Context, component.Host) error
+	})
+	require.True(t, ok)
+	assert.NoError(t, restartable.Restart(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []string{"shutdown", "start"}, calls)
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+}
+
 func TestNewMetricsProcessor_NilRequiredFields(t *testing.T) {
 	_, err := NewMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), &testMetricsCfg, consumertest.NewNop(), nil)
 	assert.Error(t, err)
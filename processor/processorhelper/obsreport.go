@@ -68,11 +68,9 @@ func NewObsReport(cfg ObsReportSettings) (*ObsReport, error) {
 
 func newObsReport(cfg ObsReportSettings) (*ObsReport, error) {
 	report := &ObsReport{
-		level:  cfg.ProcessorCreateSettings.MetricsLevel,
-		logger: cfg.ProcessorCreateSettings.Logger,
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String()),
-		},
+		level:     cfg.ProcessorCreateSettings.MetricsLevel,
+		logger:    cfg.ProcessorCreateSettings.Logger,
+		otelAttrs: processorAttributes(cfg.ProcessorID, cfg.ProcessorCreateSettings.PipelineID),
 	}
 
 	if err := report.createOtelMetrics(cfg); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -56,6 +57,21 @@ func TestProcessorMetricsData(t *testing.T) {
 	})
 }
 
+func TestProcessorAttributes(t *testing.T) {
+	set := processor.CreateSettings{ID: processorID, TelemetrySettings: componenttest.NewNopTelemetrySettings()}
+	obsrep, err := newObsReport(ObsReportSettings{ProcessorID: processorID, ProcessorCreateSettings: set})
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("processor", "fakeProcessor")}, obsrep.otelAttrs)
+
+	set.PipelineID = component.MustNewIDWithName("traces", "backend")
+	obsrep, err = newObsReport(ObsReportSettings{ProcessorID: processorID, ProcessorCreateSettings: set})
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("processor", "fakeProcessor"),
+		attribute.String("pipeline", "traces/backend"),
+	}, obsrep.otelAttrs)
+}
+
 func TestBuildProcessorCustomMetricName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -4,6 +4,7 @@
 package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
 
 import (
+	"context"
 	"errors"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -12,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/processor"
 )
 
 // ErrSkipProcessingData is a sentinel value to indicate when traces or metrics should intentionally be dropped
@@ -49,7 +51,8 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
-	consumerOptions []consumer.Option
+	consumerOptions    []consumer.Option
+	timestampValidator *timestampValidator
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
@@ -66,6 +69,24 @@ func fromOptions(options []Option) *baseSettings {
 	return opts
 }
 
-func spanAttributes(id component.ID) trace.EventOption {
-	return trace.WithAttributes(attribute.String(obsmetrics.ProcessorKey, id.String()))
+// restart shuts down the processor and starts it again, see the Restart methods of the processors.
+func restart(ctx context.Context, host component.Host, start component.StartFunc, shutdown component.ShutdownFunc) error {
+	if err := shutdown.Shutdown(ctx); err != nil {
+		return err
+	}
+	return start.Start(ctx, host)
+}
+
+func spanAttributes(set processor.CreateSettings) trace.EventOption {
+	return trace.WithAttributes(processorAttributes(set.ID, set.PipelineID)...)
+}
+
+// processorAttributes returns the attributes identifying the processor, and its pipeline
+// when the processor is created by the service.
+func processorAttributes(id component.ID, pipelineID component.ID) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String(obsmetrics.ProcessorKey, id.String())}
+	if pipelineID != (component.ID{}) {
+		attrs = append(attrs, attribute.String(obsmetrics.PipelineKey, pipelineID.String()))
+	}
+	return attrs
 }
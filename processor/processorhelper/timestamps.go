@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// TimestampAction is what to do with the metric data points having invalid timestamps.
+type TimestampAction int
+
+const (
+	// TimestampActionFix sets the zero or future timestamps to the current time, and the start timestamps
+	// later than the timestamps to the timestamps.
+	TimestampActionFix TimestampAction = iota + 1
+	// TimestampActionDrop drops the data points with invalid timestamps.
+	TimestampActionDrop
+)
+
+// WithTimestampValidation validates the timestamps of the metric data points before processing them,
+// and fixes or drops the invalid ones depending on the action. The timestamps are invalid when they are zero
+// or later than the current time plus the maxFuture tolerance, and the start timestamps are invalid when
+// they are later than the timestamps.
+// This option only applies to the processors created with NewMetricsProcessor, and modifies the data,
+// so it must not be used along with WithCapabilities reporting that the processor does not mutate the data.
+func WithTimestampValidation(action TimestampAction, maxFuture time.Duration) Option {
+	return func(o *baseSettings) {
+		o.timestampValidator = &timestampValidator{action: action, maxFuture: maxFuture, now: time.Now}
+	}
+}
+
+type timestampValidator struct {
+	action    TimestampAction
+	maxFuture time.Duration
+	now       func() time.Time
+}
+
+type timestampedDataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// validateMetrics fixes or drops the data points with invalid timestamps, and returns their number.
+func (tv *timestampValidator) validateMetrics(md pmetric.Metrics) int {
+	now := tv.now()
+	invalid := 0
+	// check returns whether the data point must be removed.
+	check := func(dp timestampedDataPoint) bool {
+		validTimestamp := dp.Timestamp().Validate(now, tv.maxFuture) == nil
+		if validTimestamp && dp.StartTimestamp() <= dp.Timestamp() {
+			return false
+		}
+		invalid++
+		if tv.action == TimestampActionDrop {
+			return true
+		}
+		if !validTimestamp {
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+		}
+		dp.SetStartTimestamp(pcommon.AdjustStartTimestamp(dp.StartTimestamp(), dp.Timestamp()))
+		return false
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return check(dp) })
+				case pmetric.MetricTypeSum:
+					m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return check(dp) })
+				case pmetric.MetricTypeHistogram:
+					m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return check(dp) })
+				case pmetric.MetricTypeExponentialHistogram:
+					m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return check(dp) })
+				case pmetric.MetricTypeSummary:
+					m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return check(dp) })
+				}
+			}
+		}
+	}
+	return invalid
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+var testNow = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func ts(d time.Duration) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(testNow.Add(d))
+}
+
+// newTimestampsMetrics returns a metric of each type with a valid data point, and data points with
+// a zero timestamp, a future timestamp, and a start timestamp later than the timestamp.
+func newTimestampsMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	timestamps := [][2]pcommon.Timestamp{
+		{ts(-time.Hour), ts(-time.Minute)},
+		{0, 0},
+		{ts(-time.Hour), ts(time.Hour)},
+		{ts(-time.Minute), ts(-time.Hour)},
+	}
+	type dataPoint interface {
+		SetStartTimestamp(pcommon.Timestamp)
+		SetTimestamp(pcommon.Timestamp)
+	}
+	setTimestamps := func(appendEmpty func() dataPoint) {
+		for _, t := range timestamps {
+			dp := appendEmpty()
+			dp.SetStartTimestamp(t[0])
+			dp.SetTimestamp(t[1])
+		}
+	}
+	gauge := ms.AppendEmpty().SetEmptyGauge()
+	setTimestamps(func() dataPoint { return gauge.DataPoints().AppendEmpty() })
+	sum := ms.AppendEmpty().SetEmptySum()
+	setTimestamps(func() dataPoint { return sum.DataPoints().AppendEmpty() })
+	histogram := ms.AppendEmpty().SetEmptyHistogram()
+	setTimestamps(func() dataPoint { return histogram.DataPoints().AppendEmpty() })
+	expHistogram := ms.AppendEmpty().SetEmptyExponentialHistogram()
+	setTimestamps(func() dataPoint { return expHistogram.DataPoints().AppendEmpty() })
+	summary := ms.AppendEmpty().SetEmptySummary()
+	setTimestamps(func() dataPoint { return summary.DataPoints().AppendEmpty() })
+	return md
+}
+
+func TestTimestampValidatorFix(t *testing.T) {
+	tv := &timestampValidator{action: TimestampActionFix, maxFuture: time.Minute, now: func() time.Time { return testNow }}
+	md := newTimestampsMetrics()
+	assert.Equal(t, 15, tv.validateMetrics(md))
+	assert.Equal(t, 20, md.DataPointCount())
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	assert.Equal(t, ts(-time.Hour), dps.At(0).StartTimestamp())
+	assert.Equal(t, ts(-time.Minute), dps.At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(0), dps.At(1).StartTimestamp())
+	assert.Equal(t, ts(0), dps.At(1).Timestamp())
+	assert.Equal(t, ts(-time.Hour), dps.At(2).StartTimestamp())
+	assert.Equal(t, ts(0), dps.At(2).Timestamp())
+	assert.Equal(t, ts(-time.Hour), dps.At(3).StartTimestamp())
+	assert.Equal(t, ts(-time.Hour), dps.At(3).Timestamp())
+
+	// The fixed data points are valid.
+	assert.Equal(t, 0, tv.validateMetrics(md))
+}
+
+func TestTimestampValidatorDrop(t *testing.T) {
+	tv := &timestampValidator{action: TimestampActionDrop, maxFuture: time.Minute, now: func() time.Time { return testNow }}
+	md := newTimestampsMetrics()
+	assert.Equal(t, 15, tv.validateMetrics(md))
+	assert.Equal(t, 5, md.DataPointCount())
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(4).Summary().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, ts(-time.Minute), dps.At(0).Timestamp())
+}
+
+func TestNewMetricsProcessor_WithTimestampValidation(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), &testMetricsCfg, sink, newTestMProcessor(nil),
+		WithTimestampValidation(TimestampActionDrop, time.Minute))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	dps.AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	dps.AppendEmpty()
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.AllMetrics()[0].DataPointCount())
+}
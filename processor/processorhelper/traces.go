@@ -39,7 +39,7 @@ func NewTracesProcessor(
 		return nil, errors.New("nil tracesFunc")
 	}
 
-	eventOptions := spanAttributes(set.ID)
+	eventOptions := spanAttributes(set)
 	bs := fromOptions(options)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		span := trace.SpanFromContext(ctx)
@@ -66,3 +66,9 @@ func NewTracesProcessor(
 		Traces:       traceConsumer,
 	}, nil
 }
+
+// Restart shuts down the processor and starts it again, see WithShutdown and WithStart. It is called by the service
+// when the panic isolation restarts the panicking components.
+func (tp *tracesProcessor) Restart(ctx context.Context, host component.Host) error {
+	return restart(ctx, host, tp.StartFunc, tp.ShutdownFunc)
+}
@@ -44,6 +44,28 @@ func TestNewTracesProcessor_WithOptions(t *testing.T) {
 	assert.False(t, tp.Capabilities().MutatesData)
 }
 
+func TestNewTracesProcessor_Restart(t *testing.T) {
+	var calls []string
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(nil),
+		WithStart(func(context.Context, component.Host) error {
+			calls = append(calls, "start")
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			calls = append(calls, "shutdown")
+			return nil
+		}))
+	require.NoError(t, err)
+
+	restartable, ok := tp.(interface {
+		Restart(context.Context, component.Host) error
+	})
+	require.True(t, ok)
+	assert.NoError(t, restartable.Restart(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []string{"shutdown", "start"}, calls)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+}
+
 func TestNewTracesProcessor_NilRequiredFields(t *testing.T) {
 	_, err := NewTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), &testTracesCfg, consumertest.NewNop(), nil)
 	assert.Error(t, err)
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor // import "go.opentelemetry.io/collector/processor/quotaprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for the quota processor.
+type Config struct {
+	// MetadataKey is the name of the client metadata key holding the tenant.
+	// Receivers must be configured with `include_metadata: true` for the
+	// metadata to be available to the processor.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// AuthAttribute is the name of the authentication attribute holding the
+	// tenant, as set by the authenticator configured on the receiver.
+	AuthAttribute string `mapstructure:"auth_attribute"`
+
+	// Default is the quota applied to tenants without a specific quota.
+	Default Quota `mapstructure:"default"`
+
+	// Tenants contains the quotas for specific tenants, overriding Default.
+	Tenants map[string]Quota `mapstructure:"tenants"`
+
+	// MaxTenants is the maximum number of tenants tracked at the same time.
+	// The tenants are controlled by the clients, the least recently seen
+	// tenant is forgotten, and its quota reset, when the limit is reached.
+	MaxTenants int `mapstructure:"max_tenants"`
+}
+
+// Quota defines the rate limits applied to a single tenant.
+type Quota struct {
+	// ItemsPerSecond is the maximum number of spans, metric data points or
+	// log records per second. Zero means unlimited.
+	ItemsPerSecond float64 `mapstructure:"items_per_second"`
+
+	// BytesPerSecond is the maximum number of bytes per second, measured as
+	// the size of the OTLP protobuf encoding of the data. Zero means unlimited.
+	BytesPerSecond float64 `mapstructure:"bytes_per_second"`
+
+	// ItemsBurst is the maximum number of items that can be accepted at once
+	// after a period of inactivity. Zero means one second worth of items.
+	ItemsBurst float64 `mapstructure:"items_burst"`
+
+	// BytesBurst is the maximum number of bytes that can be accepted at once
+	// after a period of inactivity. Zero means one second worth of bytes.
+	BytesBurst float64 `mapstructure:"bytes_burst"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+var (
+	errNoTenantSource   = errors.New("one of metadata_key or auth_attribute must be specified")
+	errTooManySources   = errors.New("only one of metadata_key or auth_attribute can be specified")
+	errNegativeRateItem = errors.New("items_per_second must be greater than or equal to 0")
+	errNegativeRateByte = errors.New("bytes_per_second must be greater than or equal to 0")
+	errNegativeBurst    = errors.New("items_burst and bytes_burst must be greater than or equal to 0")
+	errNoMaxTenants     = errors.New("max_tenants must be greater than 0")
+)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MetadataKey == "" && cfg.AuthAttribute == "" {
+		return errNoTenantSource
+	}
+	if cfg.MetadataKey != "" && cfg.AuthAttribute != "" {
+		return errTooManySources
+	}
+	if cfg.MaxTenants <= 0 {
+		return errNoMaxTenants
+	}
+	if err := cfg.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	for tenant, q := range cfg.Tenants {
+		if err := q.validate(); err != nil {
+			return fmt.Errorf("tenant %q: %w", tenant, err)
+		}
+	}
+	return nil
+}
+
+func (q Quota) validate() error {
+	if q.ItemsPerSecond < 0 {
+		return errNegativeRateItem
+	}
+	if q.BytesPerSecond < 0 {
+		return errNegativeRateByte
+	}
+	if q.ItemsBurst < 0 || q.BytesBurst < 0 {
+		return errNegativeBurst
+	}
+	return nil
+}
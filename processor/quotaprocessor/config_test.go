@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			MetadataKey: "x-tenant",
+			Default:     Quota{ItemsPerSecond: 1000, BytesPerSecond: 1048576},
+			Tenants: map[string]Quota{
+				"acme":      {ItemsPerSecond: 50000, ItemsBurst: 200000},
+				"free-tier": {ItemsPerSecond: 100, BytesPerSecond: 65536},
+			},
+			MaxTenants: 500,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		expect string
+	}{
+		{
+			name:   "no tenant source",
+			cfg:    &Config{},
+			expect: errNoTenantSource.Error(),
+		},
+		{
+			name:   "both tenant sources",
+			cfg:    &Config{MetadataKey: "x-tenant", AuthAttribute: "tenant", MaxTenants: 1},
+			expect: errTooManySources.Error(),
+		},
+		{
+			name:   "no max tenants",
+			cfg:    &Config{MetadataKey: "x-tenant"},
+			expect: errNoMaxTenants.Error(),
+		},
+		{
+			name:   "negative default items",
+			cfg:    &Config{MetadataKey: "x-tenant", Default: Quota{ItemsPerSecond: -1}, MaxTenants: 1},
+			expect: "default: " + errNegativeRateItem.Error(),
+		},
+		{
+			name:   "negative tenant bytes",
+			cfg:    &Config{AuthAttribute: "tenant", Tenants: map[string]Quota{"acme": {BytesPerSecond: -1}}, MaxTenants: 1},
+			expect: `tenant "acme": ` + errNegativeRateByte.Error(),
+		},
+		{
+			name:   "negative burst",
+			cfg:    &Config{AuthAttribute: "tenant", Default: Quota{ItemsPerSecond: 10, ItemsBurst: -1}, MaxTenants: 1},
+			expect: "default: " + errNegativeBurst.Error(),
+		},
+		{
+			name: "valid",
+			cfg:  &Config{AuthAttribute: "tenant", Default: Quota{ItemsPerSecond: 10}, MaxTenants: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expect == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expect)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package quotaprocessor // import "go.opentelemetry.io/collector/processor/quotaprocessor"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/quotaprocessor/internal/metadata"
+)
+
+const defaultMaxTenants = 10000
+
+var processorCapabilities = consumer.Capabilities{MutatesData: false}
+
+type factory struct {
+	// quotaProcessors stores quotaProcessor instances with unique configs that multiple processors can reuse.
+	// This ensures a tenant consumes from the same quota regardless of the pipeline its data goes through.
+	quotaProcessors map[component.Config]*quotaProcessor
+	lock            sync.Mutex
+}
+
+// NewFactory returns a new factory for the Quota processor.
+func NewFactory() processor.Factory {
+	f := &factory{
+		quotaProcessors: map[component.Config]*quotaProcessor{},
+	}
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(f.createTracesProcessor, metadata.TracesStability),
+		processor.WithMetrics(f.createMetricsProcessor, metadata.MetricsStability),
+		processor.WithLogs(f.createLogsProcessor, metadata.LogsStability))
+}
+
+// createDefaultConfig creates the default configuration for processor. Notice
+// that the default configuration is expected to fail for this processor,
+// since the source of the tenant must be configured.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxTenants: defaultMaxTenants,
+	}
+}
+
+func (f *factory) createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	qp, err := f.getQuotaProcessor(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer,
+		qp.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func (f *factory) createMetricsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	qp, err := f.getQuotaProcessor(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer,
+		qp.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func (f *factory) createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	qp, err := f.getQuotaProcessor(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer,
+		qp.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+// getQuotaProcessor checks if we have a cached quotaProcessor with a specific config,
+// otherwise initialize and add one to the store.
+func (f *factory) getQuotaProcessor(set processor.CreateSettings, cfg component.Config) (*quotaProcessor, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if qp, ok := f.quotaProcessors[cfg]; ok {
+		return qp, nil
+	}
+
+	// The instance is shared by all the pipelines using the same config, its telemetry is not attributed
+	// to the pipeline it is created for.
+	set.PipelineID = component.ID{}
+	qp, err := newQuotaProcessor(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+
+	f.quotaProcessors[cfg] = qp
+	return qp, nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	require.NotNil(t, factory)
+
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetadataKey = "x-tenant"
+	cfg.Default.ItemsPerSecond = 1000
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+
+	assert.NoError(t, tp.Shutdown(context.Background()))
+	assert.NoError(t, mp.Shutdown(context.Background()))
+	assert.NoError(t, lp.Shutdown(context.Background()))
+}
+
+func TestSharedProcessorWithoutPipeline(t *testing.T) {
+	f := &factory{quotaProcessors: map[component.Config]*quotaProcessor{}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetadataKey = "x-tenant"
+
+	set := processortest.NewNopCreateSettings()
+	set.PipelineID = component.MustNewID("traces")
+	qp, err := f.getQuotaProcessor(set, cfg)
+	require.NoError(t, err)
+	// The instance is shared by the pipelines, its metrics do not have a pipeline label.
+	assert.Equal(t, []attribute.KeyValue{attribute.String("processor", set.ID.String())}, qp.telemetry.processorAttrs)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor // import "go.opentelemetry.io/collector/processor/quotaprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/quotaprocessor/internal/metadata"
+)
+
+const (
+	// tenantKey is the attribute used to report the tenant of the data.
+	tenantKey = "tenant"
+	// otherTenants is the value of the tenant attribute for the tenants
+	// without a specific quota.
+	otherTenants = "_other"
+)
+
+type quotaProcessorTelemetry struct {
+	processorAttrs []attribute.KeyValue
+
+	acceptedItems metric.Int64Counter
+	refusedItems  metric.Int64Counter
+	acceptedBytes metric.Int64Counter
+	refusedBytes  metric.Int64Counter
+}
+
+func newQuotaProcessorTelemetry(set processor.CreateSettings) (*quotaProcessorTelemetry, error) {
+	qpt := &quotaProcessorTelemetry{
+		processorAttrs: []attribute.KeyValue{attribute.String(obsmetrics.ProcessorKey, set.ID.String())},
+	}
+
+	var meter metric.Meter
+	// Quota metrics are emitted starting from Normal level only.
+	if set.MetricsLevel >= configtelemetry.LevelNormal {
+		meter = metadata.Meter(set.TelemetrySettings)
+	} else {
+		meter = noopmetric.Meter{}
+	}
+
+	var errors, err error
+	qpt.acceptedItems, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "accepted_items"),
+		metric.WithDescription("Number of items accepted within the tenant's quota"),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
+	qpt.refusedItems, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "refused_items"),
+		metric.WithDescription("Number of items refused because the tenant exceeded its quota"),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
+	qpt.acceptedBytes, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "accepted_bytes"),
+		metric.WithDescription("Number of bytes accepted within the tenant's quota"),
+		metric.WithUnit("By"),
+	)
+	errors = multierr.Append(errors, err)
+
+	qpt.refusedBytes, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "refused_bytes"),
+		metric.WithDescription("Number of bytes refused because the tenant exceeded its quota"),
+		metric.WithUnit("By"),
+	)
+	errors = multierr.Append(errors, err)
+
+	if errors != nil {
+		return nil, errors
+	}
+	return qpt, nil
+}
+
+func (qpt *quotaProcessorTelemetry) record(ctx context.Context, tenant string, accepted bool, items, bytes int64) {
+	processorAttrs := metric.WithAttributes(qpt.processorAttrs...)
+	tenantAttr := metric.WithAttributes(attribute.String(tenantKey, tenant))
+	if accepted {
+		qpt.acceptedItems.Add(ctx, items, processorAttrs, tenantAttr)
+		qpt.acceptedBytes.Add(ctx, bytes, processorAttrs, tenantAttr)
+		return
+	}
+	qpt.refusedItems.Add(ctx, items, processorAttrs, tenantAttr)
+	qpt.refusedBytes.Add(ctx, bytes, processorAttrs, tenantAttr)
+}
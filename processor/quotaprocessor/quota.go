@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor // import "go.opentelemetry.io/collector/processor/quotaprocessor"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type quotaProcessor struct {
+	cfg       *Config
+	obsrep    *processorhelper.ObsReport
+	telemetry *quotaProcessorTelemetry
+
+	tracesSizer  ptrace.Sizer
+	metricsSizer pmetric.Sizer
+	logsSizer    plog.Sizer
+
+	// now is used to get the current time, overridden in tests.
+	now func() time.Time
+
+	// limiters holds the limiters of the most recently seen tenants, the
+	// least recently used ones are evicted when MaxTenants is reached.
+	lock     sync.Mutex
+	limiters map[string]*list.Element
+	lru      *list.List
+}
+
+type tenantLimiter struct {
+	tenant  string
+	limiter *limiter
+}
+
+// newQuotaProcessor returns a new quota processor.
+func newQuotaProcessor(set processor.CreateSettings, cfg *Config) (*quotaProcessor, error) {
+	obsrep, err := processorhelper.NewObsReport(processorhelper.ObsReportSettings{
+		ProcessorID:             set.ID,
+		ProcessorCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	telemetry, err := newQuotaProcessorTelemetry(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quotaProcessor{
+		cfg:          cfg,
+		obsrep:       obsrep,
+		telemetry:    telemetry,
+		tracesSizer:  &ptrace.ProtoMarshaler{},
+		metricsSizer: &pmetric.ProtoMarshaler{},
+		logsSizer:    &plog.ProtoMarshaler{},
+		now:          time.Now,
+		limiters:     map[string]*list.Element{},
+		lru:          list.New(),
+	}, nil
+}
+
+func (qp *quotaProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	numSpans := td.SpanCount()
+	if err := qp.consume(ctx, numSpans, qp.tracesSizer.TracesSize(td)); err != nil {
+		qp.obsrep.TracesRefused(ctx, numSpans)
+		return td, err
+	}
+	qp.obsrep.TracesAccepted(ctx, numSpans)
+	return td, nil
+}
+
+func (qp *quotaProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	numDataPoints := md.DataPointCount()
+	if err := qp.consume(ctx, numDataPoints, qp.metricsSizer.MetricsSize(md)); err != nil {
+		qp.obsrep.MetricsRefused(ctx, numDataPoints)
+		return md, err
+	}
+	qp.obsrep.MetricsAccepted(ctx, numDataPoints)
+	return md, nil
+}
+
+func (qp *quotaProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	numRecords := ld.LogRecordCount()
+	if err := qp.consume(ctx, numRecords, qp.logsSizer.LogsSize(ld)); err != nil {
+		qp.obsrep.LogsRefused(ctx, numRecords)
+		return ld, err
+	}
+	qp.obsrep.LogsAccepted(ctx, numRecords)
+	return ld, nil
+}
+
+// consume takes the given number of items and bytes from the quota of the
+// tenant associated with the context, returning a ResourceExhausted error if
+// the tenant exceeded its quota. Receivers translate this error into a
+// throttling response (e.g. HTTP 429) so that clients retry later.
+func (qp *quotaProcessor) consume(ctx context.Context, items, bytes int) error {
+	tenant := qp.tenant(ctx)
+	if !qp.getLimiter(tenant).allow(qp.now(), float64(items), float64(bytes)) {
+		qp.telemetry.record(ctx, qp.tenantLabel(tenant), false, int64(items), int64(bytes))
+		return status.Errorf(codes.ResourceExhausted, "quota exceeded for tenant %q", tenant)
+	}
+	qp.telemetry.record(ctx, qp.tenantLabel(tenant), true, int64(items), int64(bytes))
+	return nil
+}
+
+// tenantLabel returns the value of the tenant attribute of the metrics. The
+// tenants are controlled by the clients, so only the configured tenants are
+// reported individually to bound the cardinality of the metrics.
+func (qp *quotaProcessor) tenantLabel(tenant string) string {
+	if _, ok := qp.cfg.Tenants[tenant]; ok {
+		return tenant
+	}
+	return otherTenants
+}
+
+// tenant returns the tenant associated with the context, or an empty string
+// if the tenant cannot be determined.
+func (qp *quotaProcessor) tenant(ctx context.Context) string {
+	info := client.FromContext(ctx)
+	if qp.cfg.MetadataKey != "" {
+		if vs := info.Metadata.Get(qp.cfg.MetadataKey); len(vs) > 0 {
+			return vs[0]
+		}
+		return ""
+	}
+	if info.Auth == nil {
+		return ""
+	}
+	if v, ok := info.Auth.GetAttribute(qp.cfg.AuthAttribute).(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (qp *quotaProcessor) getLimiter(tenant string) *limiter {
+	qp.lock.Lock()
+	defer qp.lock.Unlock()
+
+	if e, ok := qp.limiters[tenant]; ok {
+		qp.lru.MoveToFront(e)
+		return e.Value.(*tenantLimiter).limiter
+	}
+
+	if qp.lru.Len() >= qp.cfg.MaxTenants {
+		oldest := qp.lru.Back()
+		qp.lru.Remove(oldest)
+		delete(qp.limiters, oldest.Value.(*tenantLimiter).tenant)
+	}
+
+	q, ok := qp.cfg.Tenants[tenant]
+	if !ok {
+		q = qp.cfg.Default
+	}
+	l := newLimiter(q, qp.now())
+	qp.limiters[tenant] = qp.lru.PushFront(&tenantLimiter{tenant: tenant, limiter: l})
+	return l
+}
+
+// limiter enforces a Quota using one token bucket per limit. Each bucket
+// holds up to the burst of the limit, one second worth of tokens by default.
+type limiter struct {
+	lock  sync.Mutex
+	items *bucket
+	bytes *bucket
+}
+
+func newLimiter(q Quota, now time.Time) *limiter {
+	return &limiter{
+		items: newBucket(q.ItemsPerSecond, q.ItemsBurst, now),
+		bytes: newBucket(q.BytesPerSecond, q.BytesBurst, now),
+	}
+}
+
+// allow reports whether both the given number of items and bytes fit in the
+// quota, in which case they are taken from the buckets. Nothing is taken
+// otherwise, so that refused requests do not consume the quota.
+func (l *limiter) allow(now time.Time, items, bytes float64) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.items.refill(now)
+	l.bytes.refill(now)
+	if !l.items.has(items) || !l.bytes.has(bytes) {
+		return false
+	}
+	l.items.take(items)
+	l.bytes.take(bytes)
+	return true
+}
+
+// bucket is a token bucket, a nil bucket is unlimited.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate, burst float64, now time.Time) *bucket {
+	if rate == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = rate
+	}
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if b == nil {
+		return
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// has reports whether n tokens can be taken. A request larger than the burst
+// is allowed once the bucket is full, and the bucket then goes into debt until
+// it is refilled, so that such requests are delayed instead of always refused.
+func (b *bucket) has(n float64) bool {
+	return b == nil || b.tokens >= min(n, b.burst)
+}
+
+func (b *bucket) take(n float64) {
+	if b == nil {
+		return
+	}
+	b.tokens -= n
+}
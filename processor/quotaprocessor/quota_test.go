@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quotaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestQuotaProcessor(t *testing.T, cfg *Config) (*quotaProcessor, *fakeClock) {
+	if cfg.MaxTenants == 0 {
+		cfg.MaxTenants = defaultMaxTenants
+	}
+	qp, err := newQuotaProcessor(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	qp.now = clock.Now
+	return qp, clock
+}
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+	})
+}
+
+func assertResourceExhausted(t *testing.T, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+}
+
+func TestQuotaItemsPerTenant(t *testing.T) {
+	qp, clock := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{ItemsPerSecond: 10},
+		Tenants:     map[string]Quota{"acme": {ItemsPerSecond: 100}},
+	})
+
+	// The default quota applies to unknown tenants.
+	_, err := qp.processTraces(tenantContext("other"), testdata.GenerateTraces(8))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(8))
+	assertResourceExhausted(t, err)
+
+	// Other tenants are not affected by the exhausted quota.
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(80))
+	require.NoError(t, err)
+	_, err = qp.processLogs(tenantContext("acme"), testdata.GenerateLogs(20))
+	require.NoError(t, err)
+	_, err = qp.processMetrics(tenantContext("acme"), testdata.GenerateMetrics(1))
+	assertResourceExhausted(t, err)
+
+	// The refused request did not consume the quota, and the quota is refilled over time.
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(7))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+
+	// The quota never exceeds one second worth of items.
+	clock.now = clock.now.Add(time.Hour)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(10))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+}
+
+func TestQuotaLargerThanBurst(t *testing.T) {
+	qp, clock := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{ItemsPerSecond: 10},
+	})
+
+	// A request larger than the burst is accepted once the bucket is full.
+	_, err := qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(25))
+	require.NoError(t, err)
+
+	// The next requests are refused until the excess is paid back.
+	clock.now = clock.now.Add(time.Second)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+	clock.now = clock.now.Add(2 * time.Second)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(1))
+	require.NoError(t, err)
+}
+
+func TestQuotaBurst(t *testing.T) {
+	qp, clock := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{ItemsPerSecond: 10, ItemsBurst: 30},
+	})
+
+	_, err := qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(30))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+
+	// The bucket is refilled at the rate of the quota, up to the burst.
+	clock.now = clock.now.Add(time.Second)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(10))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+}
+
+func TestQuotaMaxTenants(t *testing.T) {
+	qp, _ := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{ItemsPerSecond: 10},
+		MaxTenants:  2,
+	})
+
+	_, err := qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(10))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(10))
+	require.NoError(t, err)
+	_, err = qp.processTraces(tenantContext("acme"), testdata.GenerateTraces(1))
+	assertResourceExhausted(t, err)
+	assert.Equal(t, 2, qp.lru.Len())
+
+	// The least recently seen tenant is evicted, and its quota reset.
+	_, err = qp.processTraces(tenantContext("third"), testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, qp.lru.Len())
+	assert.NotContains(t, qp.limiters, "other")
+	_, err = qp.processTraces(tenantContext("other"), testdata.GenerateTraces(10))
+	require.NoError(t, err)
+	assert.NotContains(t, qp.limiters, "acme")
+}
+
+func TestTenantLabel(t *testing.T) {
+	qp, _ := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Tenants:     map[string]Quota{"acme": {ItemsPerSecond: 10}},
+	})
+
+	assert.Equal(t, "acme", qp.tenantLabel("acme"))
+	assert.Equal(t, otherTenants, qp.tenantLabel("unknown"))
+	assert.Equal(t, otherTenants, qp.tenantLabel(""))
+}
+
+func TestQuotaBytes(t *testing.T) {
+	ld := testdata.GenerateLogs(5)
+	size := (&plog.ProtoMarshaler{}).LogsSize(ld)
+
+	qp, _ := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{BytesPerSecond: float64(size) * 1.5},
+	})
+
+	_, err := qp.processLogs(tenantContext("acme"), ld)
+	require.NoError(t, err)
+	_, err = qp.processLogs(tenantContext("acme"), ld)
+	assertResourceExhausted(t, err)
+}
+
+func TestQuotaUnlimited(t *testing.T) {
+	qp, _ := newTestQuotaProcessor(t, &Config{
+		MetadataKey: "x-tenant",
+		Tenants:     map[string]Quota{"acme": {ItemsPerSecond: 1}},
+	})
+
+	for i := 0; i < 10; i++ {
+		_, err := qp.processTraces(context.Background(), testdata.GenerateTraces(100))
+		require.NoError(t, err)
+	}
+}
+
+type authData struct {
+	attrs map[string]any
+}
+
+func (a *authData) GetAttribute(name string) any {
+	return a.attrs[name]
+}
+
+func (a *authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a.attrs))
+	for name := range a.attrs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestTenant(t *testing.T) {
+	metadataQP, _ := newTestQuotaProcessor(t, &Config{MetadataKey: "x-tenant"})
+	authQP, _ := newTestQuotaProcessor(t, &Config{AuthAttribute: "tenant"})
+
+	authCtx := client.NewContext(context.Background(), client.Info{
+		Auth: &authData{attrs: map[string]any{"tenant": "acme"}},
+	})
+	invalidAuthCtx := client.NewContext(context.Background(), client.Info{
+		Auth: &authData{attrs: map[string]any{"tenant": 42}},
+	})
+
+	assert.Equal(t, "acme", metadataQP.tenant(tenantContext("acme")))
+	assert.Equal(t, "", metadataQP.tenant(authCtx))
+	assert.Equal(t, "acme", authQP.tenant(authCtx))
+	assert.Equal(t, "", authQP.tenant(invalidAuthCtx))
+	assert.Equal(t, "", authQP.tenant(tenantContext("acme")))
+	assert.Equal(t, "", authQP.tenant(context.Background()))
+}
+
+func TestQuotaSharedAcrossPipelines(t *testing.T) {
+	factory := NewFactory()
+	cfg := &Config{
+		MetadataKey: "x-tenant",
+		Default:     Quota{ItemsPerSecond: 10},
+		MaxTenants:  defaultMaxTenants,
+	}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	lp, err := factory.CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(tenantContext("acme"), testdata.GenerateTraces(8)))
+	assertResourceExhausted(t, lp.ConsumeLogs(tenantContext("acme"), testdata.GenerateLogs(8)))
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitprocessor // import "go.opentelemetry.io/collector/processor/unitprocessor"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor/unitprocessor/ucum"
+)
+
+// Config defines configuration for the unit processor.
+type Config struct {
+	// TimeUnit is the unit the values of the metrics measuring a time are converted to, e.g. "s".
+	// The values are not converted if empty.
+	TimeUnit string `mapstructure:"time_unit"`
+
+	// InformationUnit is the unit the values of the metrics measuring an amount of information
+	// are converted to, e.g. "By". The values are not converted if empty.
+	InformationUnit string `mapstructure:"information_unit"`
+
+	// Timestamps configures the validation of the timestamps of the data points.
+	Timestamps TimestampsConfig `mapstructure:"timestamps"`
+}
+
+// Timestamp actions.
+const (
+	// TimestampActionFix sets the invalid timestamps to the current time, and the start timestamps
+	// later than the timestamps to the timestamps.
+	TimestampActionFix = "fix"
+	// TimestampActionDrop drops the data points with invalid timestamps.
+	TimestampActionDrop = "drop"
+)
+
+// TimestampsConfig configures the validation of the timestamps of the data points, which the backends
+// reject when they are zero or in the future, or when the start timestamps are later than the timestamps.
+type TimestampsConfig struct {
+	// Action is either "fix" or "drop". The timestamps are not validated if empty.
+	Action string `mapstructure:"action"`
+
+	// MaxFuture is how much later than the current time the timestamps may be, e.g. to tolerate
+	// the clock skew of the sources.
+	MaxFuture time.Duration `mapstructure:"max_future"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := validateUnit(cfg.TimeUnit, ucum.DimensionTime); err != nil {
+		return fmt.Errorf("time_unit: %w", err)
+	}
+	if err := validateUnit(cfg.InformationUnit, ucum.DimensionInformation); err != nil {
+		return fmt.Errorf("information_unit: %w", err)
+	}
+	switch cfg.Timestamps.Action {
+	case "", TimestampActionFix, TimestampActionDrop:
+	default:
+		return fmt.Errorf("timestamps: invalid action %q, must be %q or %q", cfg.Timestamps.Action, TimestampActionFix, TimestampActionDrop)
+	}
+	if cfg.Timestamps.MaxFuture < 0 {
+		return fmt.Errorf("timestamps: invalid max_future %v, must not be negative", cfg.Timestamps.MaxFuture)
+	}
+	return nil
+}
+
+func validateUnit(unit string, dim ucum.Dimension) error {
+	if unit == "" {
+		return nil
+	}
+	u, ok := ucum.Lookup(ucum.Normalize(unit))
+	if !ok {
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+	if u.Dimension != dim {
+		return fmt.Errorf("unit %q is not a unit of %s", unit, dim)
+	}
+	return nil
+}
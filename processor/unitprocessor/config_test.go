@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t, &Config{
+		TimeUnit:        "seconds",
+		InformationUnit: "By",
+		Timestamps:      TimestampsConfig{Action: TimestampActionFix, MaxFuture: 5 * time.Minute},
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		expect string
+	}{
+		{
+			name:   "unknown time unit",
+			cfg:    &Config{TimeUnit: "fortnight"},
+			expect: `time_unit: unknown unit "fortnight"`,
+		},
+		{
+			name:   "information unit as time unit",
+			cfg:    &Config{TimeUnit: "By"},
+			expect: `time_unit: unit "By" is not a unit of time`,
+		},
+		{
+			name:   "time unit as information unit",
+			cfg:    &Config{InformationUnit: "ms"},
+			expect: `information_unit: unit "ms" is not a unit of information`,
+		},
+		{
+			name:   "invalid timestamps action",
+			cfg:    &Config{Timestamps: TimestampsConfig{Action: "clamp"}},
+			expect: `timestamps: invalid action "clamp", must be "fix" or "drop"`,
+		},
+		{
+			name:   "negative max future",
+			cfg:    &Config{Timestamps: TimestampsConfig{Action: TimestampActionDrop, MaxFuture: -time.Second}},
+			expect: `timestamps: invalid max_future -1s, must not be negative`,
+		},
+		{
+			name: "valid",
+			cfg:  &Config{TimeUnit: "ms", InformationUnit: "MiB"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expect == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expect)
+		})
+	}
+}
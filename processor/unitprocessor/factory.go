@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package unitprocessor // import "go.opentelemetry.io/collector/processor/unitprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/unitprocessor/internal/metadata"
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Unit processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability))
+}
+
+// defaultMaxFuture is the default tolerance of the timestamps later than the current time.
+const defaultMaxFuture = time.Minute
+
+// createDefaultConfig creates the default configuration for processor, which only normalizes the units.
+func createDefaultConfig() component.Config {
+	return &Config{Timestamps: TimestampsConfig{MaxFuture: defaultMaxFuture}}
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	pCfg := cfg.(*Config)
+	up := newUnitProcessor(pCfg)
+	opts := []processorhelper.Option{processorhelper.WithCapabilities(processorCapabilities)}
+	switch pCfg.Timestamps.Action {
+	case TimestampActionFix:
+		opts = append(opts, processorhelper.WithTimestampValidation(processorhelper.TimestampActionFix, pCfg.Timestamps.MaxFuture))
+	case TimestampActionDrop:
+		opts = append(opts, processorhelper.WithTimestampValidation(processorhelper.TimestampActionDrop, pCfg.Timestamps.MaxFuture))
+	}
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer, up.processMetrics, opts...)
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ucum normalizes the metric units to the Unified Code for Units of Measure (UCUM),
+// see https://ucum.org/ucum, and converts the values between compatible units.
+// It supports the units of time and information, and the dimensionless units.
+package ucum // import "go.opentelemetry.io/collector/processor/unitprocessor/ucum"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is the physical quantity measured by a unit.
+type Dimension string
+
+const (
+	DimensionTime          Dimension = "time"
+	DimensionInformation   Dimension = "information"
+	DimensionDimensionless Dimension = "dimensionless"
+)
+
+// Unit is a UCUM unit.
+type Unit struct {
+	// Code is the case-sensitive UCUM code of the unit, e.g. "ms" or "MiBy".
+	Code string
+	// Dimension is the dimension of the unit. The dimension of a unit divided by another,
+	// e.g. "By/s", is the dimensions of both units separated by a slash, e.g. "information/time".
+	Dimension Dimension
+	// Factor is the value of the unit in the base unit of its dimension: seconds for the time,
+	// bytes for the information, and 1 for the dimensionless units.
+	Factor float64
+}
+
+var units = map[string]Unit{}
+
+// aliases maps the common non-UCUM spellings of the units to their UCUM code.
+var aliases = map[string]string{}
+
+func register(dim Dimension, code string, factor float64, names ...string) {
+	units[code] = Unit{Code: code, Dimension: dim, Factor: factor}
+	for _, name := range names {
+		aliases[name] = code
+	}
+}
+
+func init() {
+	register(DimensionTime, "ns", 1e-9, "nanosecond", "nanoseconds")
+	register(DimensionTime, "us", 1e-6, "µs", "microsecond", "microseconds")
+	register(DimensionTime, "ms", 1e-3, "millisecond", "milliseconds", "msec")
+	register(DimensionTime, "s", 1, "sec", "second", "seconds")
+	register(DimensionTime, "min", 60, "minute", "minutes")
+	register(DimensionTime, "h", 3600, "hour", "hours")
+	register(DimensionTime, "d", 86400, "day", "days")
+
+	register(DimensionInformation, "bit", 0.125, "bits")
+	register(DimensionInformation, "kbit", 1e3/8, "Kbit", "kbits")
+	register(DimensionInformation, "Mbit", 1e6/8, "Mbits")
+	register(DimensionInformation, "Gbit", 1e9/8, "Gbits")
+	register(DimensionInformation, "By", 1, "B", "byte", "bytes")
+	register(DimensionInformation, "kBy", 1e3, "kB", "KB", "kilobyte", "kilobytes")
+	register(DimensionInformation, "MBy", 1e6, "MB", "megabyte", "megabytes")
+	register(DimensionInformation, "GBy", 1e9, "GB", "gigabyte", "gigabytes")
+	register(DimensionInformation, "TBy", 1e12, "TB", "terabyte", "terabytes")
+	register(DimensionInformation, "KiBy", 1<<10, "KiB", "kibibyte", "kibibytes")
+	register(DimensionInformation, "MiBy", 1<<20, "MiB", "mebibyte", "mebibytes")
+	register(DimensionInformation, "GiBy", 1<<30, "GiB", "gibibyte", "gibibytes")
+	register(DimensionInformation, "TiBy", 1<<40, "TiB", "tebibyte", "tebibytes")
+
+	register(DimensionDimensionless, "1", 1, "ratio")
+	register(DimensionDimensionless, "%", 0.01, "percent")
+}
+
+// Normalize returns the UCUM code of the unit, e.g. "ms" for "milliseconds", or "MiBy/s" for "MiB/s".
+// The annotations, e.g. "{requests}", and the unknown units are returned as is.
+func Normalize(unit string) string {
+	unit = strings.TrimSpace(unit)
+	if num, den, ok := strings.Cut(unit, "/"); ok {
+		return normalize(num) + "/" + normalize(den)
+	}
+	return normalize(unit)
+}
+
+func normalize(unit string) string {
+	if _, ok := units[unit]; ok {
+		return unit
+	}
+	if code, ok := aliases[unit]; ok {
+		return code
+	}
+	if code, ok := aliases[strings.ToLower(unit)]; ok {
+		return code
+	}
+	return unit
+}
+
+// Lookup returns the unit of the UCUM code, which can be a unit divided by another, e.g. "By/s".
+// The code must be normalized, see Normalize.
+func Lookup(code string) (Unit, bool) {
+	num, den, ok := strings.Cut(code, "/")
+	if !ok {
+		u, ok := units[code]
+		return u, ok
+	}
+	numUnit, ok := units[num]
+	if !ok {
+		return Unit{}, false
+	}
+	denUnit, ok := units[den]
+	if !ok {
+		return Unit{}, false
+	}
+	return Unit{
+		Code:      code,
+		Dimension: numUnit.Dimension + "/" + denUnit.Dimension,
+		Factor:    numUnit.Factor / denUnit.Factor,
+	}, true
+}
+
+// ConversionFactor returns the factor to multiply the values by to convert them from a unit to another.
+// The units are normalized, and must have the same dimension.
+func ConversionFactor(from, to string) (float64, error) {
+	fromUnit, ok := Lookup(Normalize(from))
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	toUnit, ok := Lookup(Normalize(to))
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if fromUnit.Dimension != toUnit.Dimension {
+		return 0, fmt.Errorf("cannot convert %q of dimension %s to %q of dimension %s", from, fromUnit.Dimension, to, toUnit.Dimension)
+	}
+	return fromUnit.Factor / toUnit.Factor, nil
+}
+
+// Convert converts the value from a unit to another, see ConversionFactor.
+func Convert(value float64, from, to string) (float64, error) {
+	factor, err := ConversionFactor(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return value * factor, nil
+}
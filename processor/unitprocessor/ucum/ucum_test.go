@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ucum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"ms":           "ms",
+		"milliseconds": "ms",
+		"Seconds":      "s",
+		" sec ":        "s",
+		"µs":           "us",
+		"bytes":        "By",
+		"B":            "By",
+		"MiB":          "MiBy",
+		"KB":           "kBy",
+		"MB/s":         "MBy/s",
+		"bytes/second": "By/s",
+		"percent":      "%",
+		"1":            "1",
+		"{requests}":   "{requests}",
+		"furlongs":     "furlongs",
+		"":             "",
+	}
+	for unit, expected := range tests {
+		t.Run(unit, func(t *testing.T) {
+			assert.Equal(t, expected, Normalize(unit))
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	u, ok := Lookup("ms")
+	require.True(t, ok)
+	assert.Equal(t, Unit{Code: "ms", Dimension: DimensionTime, Factor: 1e-3}, u)
+
+	u, ok = Lookup("KiBy/min")
+	require.True(t, ok)
+	assert.Equal(t, Unit{Code: "KiBy/min", Dimension: DimensionInformation + "/" + DimensionTime, Factor: 1024.0 / 60}, u)
+
+	_, ok = Lookup("milliseconds")
+	assert.False(t, ok)
+	_, ok = Lookup("{requests}/s")
+	assert.False(t, ok)
+	_, ok = Lookup("By/{request}")
+	assert.False(t, ok)
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from     string
+		to       string
+		expected float64
+	}{
+		{value: 1500, from: "ms", to: "s", expected: 1.5},
+		{value: 2, from: "min", to: "seconds", expected: 120},
+		{value: 3, from: "MiB", to: "By", expected: 3 << 20},
+		{value: 8, from: "bit", to: "By", expected: 1},
+		{value: 1, from: "GBy", to: "MBy", expected: 1000},
+		{value: 50, from: "%", to: "1", expected: 0.5},
+		{value: 1, from: "MiBy/s", to: "KiBy/ms", expected: 1.024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			got, err := Convert(tt.value, tt.from, tt.to)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, got, 1e-9)
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	_, err := Convert(1, "furlongs", "s")
+	assert.EqualError(t, err, `unknown unit "furlongs"`)
+	_, err = Convert(1, "s", "{requests}")
+	assert.EqualError(t, err, `unknown unit "{requests}"`)
+	_, err = Convert(1, "ms", "By")
+	assert.EqualError(t, err, `cannot convert "ms" of dimension time to "By" of dimension information`)
+	_, err = Convert(1, "By/s", "By")
+	assert.EqualError(t, err, `cannot convert "By/s" of dimension information/time to "By" of dimension information`)
+}
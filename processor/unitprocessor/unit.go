@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitprocessor // import "go.opentelemetry.io/collector/processor/unitprocessor"
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/unitprocessor/ucum"
+)
+
+type unitProcessor struct {
+	// targets maps the dimensions to the unit the values are converted to.
+	targets map[ucum.Dimension]ucum.Unit
+}
+
+func newUnitProcessor(cfg *Config) *unitProcessor {
+	up := &unitProcessor{targets: map[ucum.Dimension]ucum.Unit{}}
+	for _, unit := range []string{cfg.TimeUnit, cfg.InformationUnit} {
+		if u, ok := ucum.Lookup(ucum.Normalize(unit)); ok {
+			up.targets[u.Dimension] = u
+		}
+	}
+	return up
+}
+
+func (up *unitProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				up.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+// processMetric normalizes the unit of the metric, and converts its values to the target unit of its dimension.
+// The rates, e.g. "MiBy/s", are converted by converting their numerator, e.g. to "By/s".
+func (up *unitProcessor) processMetric(m pmetric.Metric) {
+	unit := ucum.Normalize(m.Unit())
+	num, den, isRate := strings.Cut(unit, "/")
+	from, ok := ucum.Lookup(num)
+	if !ok {
+		m.SetUnit(unit)
+		return
+	}
+	to, ok := up.targets[from.Dimension]
+	// The exponential histograms cannot be scaled, their buckets are fixed.
+	if !ok || to.Code == from.Code || m.Type() == pmetric.MetricTypeExponentialHistogram {
+		m.SetUnit(unit)
+		return
+	}
+
+	factor := from.Factor / to.Factor
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		scaleNumberDataPoints(m.Gauge().DataPoints(), factor)
+	case pmetric.MetricTypeSum:
+		scaleNumberDataPoints(m.Sum().DataPoints(), factor)
+	case pmetric.MetricTypeHistogram:
+		scaleHistogramDataPoints(m.Histogram().DataPoints(), factor)
+	case pmetric.MetricTypeSummary:
+		scaleSummaryDataPoints(m.Summary().DataPoints(), factor)
+	}
+	if isRate {
+		m.SetUnit(to.Code + "/" + den)
+	} else {
+		m.SetUnit(to.Code)
+	}
+}
+
+// integralFactor returns the factor as an integer if it is one, allowing for floating point errors,
+// so that the integer values are kept integers when converted to a smaller unit.
+func integralFactor(factor float64) (int64, bool) {
+	rounded := math.Round(factor)
+	if rounded < 1 || rounded > math.MaxInt32 || math.Abs(factor-rounded) > 1e-9*rounded {
+		return 0, false
+	}
+	return int64(rounded), true
+}
+
+// scaleNumberDataPoints multiplies the values by the factor. The integer values are converted to
+// double values if the factor is not an integer.
+func scaleNumberDataPoints(dps pmetric.NumberDataPointSlice, factor float64) {
+	intFactor, isIntegral := integralFactor(factor)
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			if isIntegral {
+				dp.SetIntValue(dp.IntValue() * intFactor)
+			} else {
+				dp.SetDoubleValue(float64(dp.IntValue()) * factor)
+			}
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(dp.DoubleValue() * factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor, intFactor, isIntegral)
+	}
+}
+
+func scaleHistogramDataPoints(dps pmetric.HistogramDataPointSlice, factor float64) {
+	intFactor, isIntegral := integralFactor(factor)
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		bounds := dp.ExplicitBounds()
+		for j := 0; j < bounds.Len(); j++ {
+			bounds.SetAt(j, bounds.At(j)*factor)
+		}
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor, intFactor, isIntegral)
+	}
+}
+
+func scaleSummaryDataPoints(dps pmetric.SummaryDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetSum(dp.Sum() * factor)
+		qs := dp.QuantileValues()
+		for j := 0; j < qs.Len(); j++ {
+			qs.At(j).SetValue(qs.At(j).Value() * factor)
+		}
+	}
+}
+
+func scaleExemplars(exemplars pmetric.ExemplarSlice, factor float64, intFactor int64, isIntegral bool) {
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			if isIntegral {
+				e.SetIntValue(e.IntValue() * intFactor)
+			} else {
+				e.SetDoubleValue(float64(e.IntValue()) * factor)
+			}
+		case pmetric.ExemplarValueTypeDouble:
+			e.SetDoubleValue(e.DoubleValue() * factor)
+		}
+	}
+}
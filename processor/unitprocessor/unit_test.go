@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func newMetric(ms pmetric.MetricSlice, name, unit string) pmetric.Metric {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetUnit(unit)
+	return m
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := newMetric(ms, "latency", "milliseconds").SetEmptyGauge().DataPoints()
+	gauge.AppendEmpty().SetDoubleValue(1500)
+	intDp := gauge.AppendEmpty()
+	intDp.SetIntValue(250)
+	intDp.Exemplars().AppendEmpty().SetIntValue(100)
+
+	sum := newMetric(ms, "memory", "MiB").SetEmptySum().DataPoints()
+	sum.AppendEmpty().SetIntValue(3)
+
+	rate := newMetric(ms, "throughput", "KiB/s").SetEmptyGauge().DataPoints()
+	rate.AppendEmpty().SetDoubleValue(2)
+
+	hdp := newMetric(ms, "duration", "ms").SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.ExplicitBounds().FromRaw([]float64{100, 1000})
+	hdp.SetSum(2500)
+	hdp.SetMin(50)
+	hdp.SetMax(2000)
+
+	sdp := newMetric(ms, "size", "kB").SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetSum(10)
+	sdp.QuantileValues().AppendEmpty().SetValue(2)
+
+	expDp := newMetric(ms, "exp_duration", "milliseconds").SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	expDp.SetSum(1500)
+
+	newMetric(ms, "ratio", "percent").SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(50)
+	newMetric(ms, "requests", "{requests}").SetEmptySum().DataPoints().AppendEmpty().SetIntValue(10)
+
+	up := newUnitProcessor(&Config{TimeUnit: "s", InformationUnit: "By"})
+	_, err := up.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s", ms.At(0).Unit())
+	assert.InDelta(t, 1.5, gauge.At(0).DoubleValue(), 1e-9)
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, gauge.At(1).ValueType())
+	assert.InDelta(t, 0.25, gauge.At(1).DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.1, gauge.At(1).Exemplars().At(0).DoubleValue(), 1e-9)
+
+	// The integer values are kept integers when the factor is an integer.
+	assert.Equal(t, "By", ms.At(1).Unit())
+	assert.Equal(t, int64(3<<20), sum.At(0).IntValue())
+
+	assert.Equal(t, "By/s", ms.At(2).Unit())
+	assert.InDelta(t, 2048, rate.At(0).DoubleValue(), 1e-9)
+
+	assert.Equal(t, "s", ms.At(3).Unit())
+	assert.InDeltaSlice(t, []float64{0.1, 1}, hdp.ExplicitBounds().AsRaw(), 1e-9)
+	assert.InDelta(t, 2.5, hdp.Sum(), 1e-9)
+	assert.InDelta(t, 0.05, hdp.Min(), 1e-9)
+	assert.InDelta(t, 2, hdp.Max(), 1e-9)
+
+	assert.Equal(t, "By", ms.At(4).Unit())
+	assert.InDelta(t, 10000, sdp.Sum(), 1e-9)
+	assert.InDelta(t, 2000, sdp.QuantileValues().At(0).Value(), 1e-9)
+
+	// The exponential histograms are only normalized.
+	assert.Equal(t, "ms", ms.At(5).Unit())
+	assert.Equal(t, 1500.0, expDp.Sum())
+
+	assert.Equal(t, "%", ms.At(6).Unit())
+	assert.Equal(t, 50.0, ms.At(6).Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, "{requests}", ms.At(7).Unit())
+}
+
+func TestProcessMetricsNormalizeOnly(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	newMetric(ms, "latency", "milliseconds").SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1500)
+
+	up := newUnitProcessor(createDefaultConfig().(*Config))
+	_, err := up.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, "ms", ms.At(0).Unit())
+	assert.Equal(t, 1500.0, ms.At(0).Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestMetricsProcessor(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), &Config{TimeUnit: "ms"}, sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	newMetric(ms, "latency", "s").SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(2)
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "ms", m.Unit())
+	assert.Equal(t, int64(2000), m.Gauge().DataPoints().At(0).IntValue())
+	require.NoError(t, mp.Shutdown(context.Background()))
+}
+
+func TestMetricsProcessorTimestamps(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		action     string
+		wantPoints int
+	}{
+		{
+			action:     TimestampActionFix,
+			wantPoints: 2,
+		},
+		{
+			action:     TimestampActionDrop,
+			wantPoints: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			cfg := &Config{Timestamps: TimestampsConfig{Action: tt.action, MaxFuture: time.Hour}}
+			mp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+			md := pmetric.NewMetrics()
+			dps := newMetric(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics(), "requests", "1").SetEmptyGauge().DataPoints()
+			dps.AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(now))
+			// The zero timestamp is invalid.
+			dps.AppendEmpty()
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+			require.Len(t, sink.AllMetrics(), 1)
+			got := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+			require.Equal(t, tt.wantPoints, got.Len())
+			assert.Equal(t, pcommon.NewTimestampFromTime(now), got.At(0).Timestamp())
+			if tt.action == TimestampActionFix {
+				// The zero timestamp is set to the current time.
+				assert.NotZero(t, got.At(1).Timestamp())
+			}
+			require.NoError(t, mp.Shutdown(context.Background()))
+		})
+	}
+}
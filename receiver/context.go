@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiver // import "go.opentelemetry.io/collector/receiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type receiverKey struct{}
+
+// ContextWithReceiver returns a context carrying the ID of the receiver the data comes from.
+// It is set by the receiverhelper.ObsReport when the receive operations start, so that the
+// exporters can tell where the data they export comes from.
+func ContextWithReceiver(ctx context.Context, receiverID component.ID) context.Context {
+	return context.WithValue(ctx, receiverKey{}, receiverID)
+}
+
+// ReceiverFromContext returns the ID of the receiver the data comes from, see ContextWithReceiver.
+// It returns false if the context does not carry a receiver ID.
+func ReceiverFromContext(ctx context.Context) (component.ID, bool) {
+	receiverID, ok := ctx.Value(receiverKey{}).(component.ID)
+	return receiverID, ok
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestReceiverContext(t *testing.T) {
+	_, ok := ReceiverFromContext(context.Background())
+	assert.False(t, ok)
+
+	receiverID := component.MustNewIDWithName("otlp", "foo")
+	got, ok := ReceiverFromContext(ContextWithReceiver(context.Background(), receiverID))
+	assert.True(t, ok)
+	assert.Equal(t, receiverID, got)
+}
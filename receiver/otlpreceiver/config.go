@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/confmap"
 )
 
@@ -19,6 +21,7 @@ const (
 	// Protocol values.
 	protoGRPC = "protocols::grpc"
 	protoHTTP = "protocols::http"
+	protoWS   = "protocols::websocket"
 )
 
 type HTTPConfig struct {
@@ -32,18 +35,135 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// ProtoPassthrough retains the received OTLP/protobuf payloads, so that they can be forwarded
+	// as is by exporters sending OTLP/protobuf, instead of encoding the data again.
+	// The payloads are retained unless all the pipelines receiving the data mutate it, see the README.
+	ProtoPassthrough bool `mapstructure:"proto_passthrough,omitempty"`
+}
+
+// WebSocketConfig configures the reception of OTLP/protobuf over WebSocket connections, for the clients
+// which can only reach the receiver through proxies allowing WebSocket. Every binary message received on a
+// connection is an export request, answered in order by a binary message holding a google.rpc.Status.
+type WebSocketConfig struct {
+	*confighttp.ServerConfig `mapstructure:",squash"`
+
+	// The URL path to accept the traces connections on. If omitted "/v1/traces" will be used.
+	TracesURLPath string `mapstructure:"traces_url_path,omitempty"`
+
+	// The URL path to accept the metrics connections on. If omitted "/v1/metrics" will be used.
+	MetricsURLPath string `mapstructure:"metrics_url_path,omitempty"`
+
+	// The URL path to accept the logs connections on. If omitted "/v1/logs" will be used.
+	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// PingInterval is the interval of the pings sent to keep the connections alive (default: 30s).
+	PingInterval time.Duration `mapstructure:"ping_interval"`
+
+	// PongTimeout is the time to wait for the pong after a ping, the connection is closed once elapsed (default: 10s).
+	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+}
+
+// Validate checks the WebSocket configuration is valid.
+func (cfg *WebSocketConfig) Validate() error {
+	if cfg.PingInterval <= 0 {
+		return fmt.Errorf("invalid ping_interval %v, must be positive", cfg.PingInterval)
+	}
+	if cfg.PongTimeout <= 0 {
+		return fmt.Errorf("invalid pong_timeout %v, must be positive", cfg.PongTimeout)
+	}
+	return nil
 }
 
 // Protocols is the configuration for the supported protocols.
 type Protocols struct {
 	GRPC *configgrpc.ServerConfig `mapstructure:"grpc"`
 	HTTP *HTTPConfig              `mapstructure:"http"`
+	// WebSocket is disabled unless configured.
+	WebSocket *WebSocketConfig `mapstructure:"websocket"`
+}
+
+// Capture modes.
+const (
+	// CaptureModeRingBuffer keeps the last requests in memory, served by the zpages extension.
+	CaptureModeRingBuffer = "ring_buffer"
+	// CaptureModeFile appends the requests to a file, in JSON lines.
+	CaptureModeFile = "file"
+)
+
+// CaptureConfig configures the recording of the received requests and of the responses, e.g. to verify
+// what the clients send when diagnosing interoperability issues. The recording is disabled by default.
+type CaptureConfig struct {
+	// Mode is either "ring_buffer" or "file". The recording is disabled when empty.
+	Mode string `mapstructure:"mode"`
+
+	// Size is the number of requests kept in memory by the "ring_buffer" mode.
+	Size int `mapstructure:"size"`
+
+	// Path is the file the requests are appended to by the "file" mode.
+	Path string `mapstructure:"path"`
+
+	// MaxBodySize is the maximum size of the bodies recorded, e.g. "64KiB", the bodies are not truncated when 0.
+	MaxBodySize confighumanize.Size `mapstructure:"max_body_size"`
+
+	// IncludeHeaders are the names of the headers, or gRPC metadata keys, whose values are recorded.
+	// The values of the other headers are redacted, as well as the credentials, e.g. the Authorization header.
+	IncludeHeaders []string `mapstructure:"include_headers"`
+}
+
+// Validate checks the capture configuration is valid.
+func (cfg *CaptureConfig) Validate() error {
+	switch cfg.Mode {
+	case "":
+		return nil
+	case CaptureModeRingBuffer:
+		if cfg.Size <= 0 {
+			return fmt.Errorf("invalid capture size %d, must be positive", cfg.Size)
+		}
+	case CaptureModeFile:
+		if cfg.Path == "" {
+			return errors.New("capture path must be set for the file mode")
+		}
+	default:
+		return fmt.Errorf("invalid capture mode %q, must be %q or %q", cfg.Mode, CaptureModeRingBuffer, CaptureModeFile)
+	}
+	if cfg.MaxBodySize < 0 {
+		return fmt.Errorf("invalid capture max_body_size %d, must be positive", int64(cfg.MaxBodySize))
+	}
+	return nil
+}
+
+// ConnectionStatsConfig configures the statistics of the gRPC connections, served by the zpages extension,
+// e.g. to find the client flooding a gateway shared by many clients.
+type ConnectionStatsConfig struct {
+	// Enabled indicates whether to track the statistics of the connections.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// InternAttributesConfig configures the interning of the attribute keys and string values of the received
+// data, so that the data held downstream, e.g. in the sending queues of a gateway receiving the data of many
+// similar sources, references a single copy of the repeated strings, see pcommon.StringInterner.
+type InternAttributesConfig struct {
+	// Enabled indicates whether to intern the attributes.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Capacity is the maximum number of strings interned, the least recently used ones being evicted.
+	Capacity int `mapstructure:"capacity"`
 }
 
 // Config defines configuration for OTLP receiver.
 type Config struct {
-	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
+	// Protocols is the configuration for the supported protocols, currently gRPC, HTTP (Proto and JSON) and WebSocket.
 	Protocols `mapstructure:"protocols"`
+
+	// Capture configures the recording of the received requests for debugging purposes.
+	Capture CaptureConfig `mapstructure:"capture"`
+
+	// ConnectionStats configures the statistics of the gRPC connections.
+	ConnectionStats ConnectionStatsConfig `mapstructure:"connection_stats"`
+
+	// InternAttributes configures the interning of the attributes of the received data.
+	InternAttributes InternAttributesConfig `mapstructure:"intern_attributes"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -51,14 +171,25 @@ var _ confmap.Unmarshaler = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.GRPC == nil && cfg.HTTP == nil {
+	if cfg.GRPC == nil && cfg.HTTP == nil && cfg.WebSocket == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.ConnectionStats.Enabled && cfg.GRPC == nil {
+		return errors.New("connection_stats requires the grpc protocol")
+	}
+	if cfg.InternAttributes.Enabled && cfg.InternAttributes.Capacity <= 0 {
+		return fmt.Errorf("invalid intern_attributes capacity %d, must be positive", cfg.InternAttributes.Capacity)
+	}
 	return nil
 }
 
 // Unmarshal a confmap.Conf into the config struct.
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	// WebSocket is not part of the default protocols, its defaults only apply when it is configured.
+	if conf.IsSet(protoWS) && cfg.WebSocket == nil {
+		cfg.WebSocket = defaultWebSocketConfig()
+	}
+
 	// first load the config normally
 	err := conf.Unmarshal(cfg)
 	if err != nil {
@@ -85,6 +216,22 @@ func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 		}
 	}
 
+	if !conf.IsSet(protoWS) {
+		cfg.WebSocket = nil
+	} else {
+		var err error
+
+		if cfg.WebSocket.TracesURLPath, err = sanitizeURLPath(cfg.WebSocket.TracesURLPath); err != nil {
+			return err
+		}
+		if cfg.WebSocket.MetricsURLPath, err = sanitizeURLPath(cfg.WebSocket.MetricsURLPath); err != nil {
+			return err
+		}
+		if cfg.WebSocket.LogsURLPath, err = sanitizeURLPath(cfg.WebSocket.LogsURLPath); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
@@ -77,6 +78,72 @@ func TestUnmarshalConfigOnlyHTTPEmptyMap(t *testing.T) {
 	assert.Equal(t, defaultOnlyHTTP, cfg)
 }
 
+func TestUnmarshalConfigOnlyWebSocketNull(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "only_websocket_null.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+
+	defaultOnlyWebSocket := factory.CreateDefaultConfig().(*Config)
+	defaultOnlyWebSocket.GRPC = nil
+	defaultOnlyWebSocket.HTTP = nil
+	defaultOnlyWebSocket.WebSocket = defaultWebSocketConfig()
+	assert.Equal(t, defaultOnlyWebSocket, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestUnmarshalConfigWebSocket(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "websocket.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.GRPC = nil
+	expected.HTTP = nil
+	expected.WebSocket = defaultWebSocketConfig()
+	expected.WebSocket.TracesURLPath = "/traces"
+	expected.WebSocket.PingInterval = 15 * time.Second
+	assert.Equal(t, expected, cfg)
+}
+
+func TestWebSocketConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*WebSocketConfig)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*WebSocketConfig) {},
+		},
+		{
+			name:        "zero ping interval",
+			modify:      func(cfg *WebSocketConfig) { cfg.PingInterval = 0 },
+			expectedErr: "invalid ping_interval 0s, must be positive",
+		},
+		{
+			name:        "negative pong timeout",
+			modify:      func(cfg *WebSocketConfig) { cfg.PongTimeout = -time.Second },
+			expectedErr: "invalid pong_timeout -1s, must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultWebSocketConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestUnmarshalConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
@@ -97,7 +164,7 @@ func TestUnmarshalConfig(t *testing.T) {
 							KeyFile:  "test.key",
 						},
 					},
-					MaxRecvMsgSizeMiB:    32,
+					MaxRecvMsgSize:       32 * confighumanize.Mebibyte,
 					MaxConcurrentStreams: 16,
 					ReadBufferSize:       1024,
 					WriteBufferSize:      1024,
@@ -129,11 +196,25 @@ func TestUnmarshalConfig(t *testing.T) {
 							MaxAge:         7200,
 						},
 					},
-					TracesURLPath:  "/traces",
-					MetricsURLPath: "/v2/metrics",
-					LogsURLPath:    "/log/ingest",
+					TracesURLPath:    "/traces",
+					MetricsURLPath:   "/v2/metrics",
+					LogsURLPath:      "/log/ingest",
+					ProtoPassthrough: true,
 				},
 			},
+			Capture: CaptureConfig{
+				Mode:           CaptureModeRingBuffer,
+				Size:           50,
+				MaxBodySize:    1024,
+				IncludeHeaders: []string{"content-type", "x-scope-orgid"},
+			},
+			ConnectionStats: ConnectionStatsConfig{
+				Enabled: true,
+			},
+			InternAttributes: InternAttributesConfig{
+				Enabled:  true,
+				Capacity: 5000,
+			},
 		}, cfg)
 
 }
@@ -163,6 +244,13 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 					LogsURLPath:    defaultLogsURLPath,
 				},
 			},
+			Capture: CaptureConfig{
+				Size:        defaultCaptureSize,
+				MaxBodySize: defaultCaptureMaxBodySize,
+			},
+			InternAttributes: InternAttributesConfig{
+				Capacity: defaultInternAttributesCapacity,
+			},
 		}, cfg)
 }
 
@@ -191,6 +279,21 @@ func TestUnmarshalConfigEmptyProtocols(t *testing.T) {
 	assert.EqualError(t, component.ValidateConfig(cfg), "must specify at least one protocol when using the OTLP receiver")
 }
 
+func TestInternAttributesCapacity(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.InternAttributes = InternAttributesConfig{Enabled: true}
+	assert.EqualError(t, component.ValidateConfig(cfg), "invalid intern_attributes capacity 0, must be positive")
+}
+
+func TestConnectionStatsRequiresGRPC(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "only_http.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	cfg.ConnectionStats.Enabled = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "connection_stats requires the grpc protocol")
+}
+
 func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -221,6 +324,57 @@ func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	}
 }
 
+func TestCaptureConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         CaptureConfig
+		expectedErr string
+	}{
+		{
+			name: "disabled",
+			cfg:  CaptureConfig{},
+		},
+		{
+			name: "ring_buffer",
+			cfg:  CaptureConfig{Mode: CaptureModeRingBuffer, Size: 10},
+		},
+		{
+			name: "file",
+			cfg:  CaptureConfig{Mode: CaptureModeFile, Path: "requests.jsonl"},
+		},
+		{
+			name:        "invalid_mode",
+			cfg:         CaptureConfig{Mode: "stdout"},
+			expectedErr: `invalid capture mode "stdout", must be "ring_buffer" or "file"`,
+		},
+		{
+			name:        "invalid_size",
+			cfg:         CaptureConfig{Mode: CaptureModeRingBuffer},
+			expectedErr: "invalid capture size 0, must be positive",
+		},
+		{
+			name:        "missing_path",
+			cfg:         CaptureConfig{Mode: CaptureModeFile},
+			expectedErr: "capture path must be set for the file mode",
+		},
+		{
+			name:        "invalid_max_body_size",
+			cfg:         CaptureConfig{Mode: CaptureModeRingBuffer, Size: 10, MaxBodySize: -1},
+			expectedErr: "invalid capture max_body_size -1, must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestUnmarshalConfigEmpty(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
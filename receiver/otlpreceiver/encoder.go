@@ -22,6 +22,7 @@ const (
 
 var (
 	pbEncoder       = &protoEncoder{}
+	pbPassEncoder   = &protoEncoder{passthrough: true}
 	jsEncoder       = &jsonEncoder{}
 	jsonPbMarshaler = &jsonpb.Marshaler{}
 )
@@ -40,22 +41,34 @@ type encoder interface {
 	contentType() string
 }
 
-type protoEncoder struct{}
+type protoEncoder struct {
+	// passthrough retains the received payloads in the unmarshaled requests.
+	passthrough bool
+}
 
-func (protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+func (e protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
 	req := ptraceotlp.NewExportRequest()
+	if e.passthrough {
+		return req, req.UnmarshalProtoPassthrough(buf)
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
-func (protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+func (e protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
 	req := pmetricotlp.NewExportRequest()
+	if e.passthrough {
+		return req, req.UnmarshalProtoPassthrough(buf)
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
-func (protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+func (e protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
 	req := plogotlp.NewExportRequest()
+	if e.passthrough {
+		return req, req.UnmarshalProtoPassthrough(buf)
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
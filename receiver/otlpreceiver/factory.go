@@ -5,10 +5,12 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/localhostgate"
@@ -20,10 +22,19 @@ import (
 const (
 	grpcPort = 4317
 	httpPort = 4318
+	wsPort   = 4319
 
 	defaultTracesURLPath  = "/v1/traces"
 	defaultMetricsURLPath = "/v1/metrics"
 	defaultLogsURLPath    = "/v1/logs"
+
+	defaultPingInterval = 30 * time.Second
+	defaultPongTimeout  = 10 * time.Second
+
+	defaultCaptureSize        = 100
+	defaultCaptureMaxBodySize = 64 * confighumanize.Kibibyte
+
+	defaultInternAttributesCapacity = 10000
 )
 
 // NewFactory creates a new OTLP receiver factory.
@@ -58,6 +69,28 @@ func createDefaultConfig() component.Config {
 				LogsURLPath:    defaultLogsURLPath,
 			},
 		},
+		Capture: CaptureConfig{
+			Size:        defaultCaptureSize,
+			MaxBodySize: defaultCaptureMaxBodySize,
+		},
+		InternAttributes: InternAttributesConfig{
+			Capacity: defaultInternAttributesCapacity,
+		},
+	}
+}
+
+// defaultWebSocketConfig creates the default configuration of the WebSocket protocol,
+// which is only enabled when configured.
+func defaultWebSocketConfig() *WebSocketConfig {
+	return &WebSocketConfig{
+		ServerConfig: &confighttp.ServerConfig{
+			Endpoint: localhostgate.EndpointForPort(wsPort),
+		},
+		TracesURLPath:  defaultTracesURLPath,
+		MetricsURLPath: defaultMetricsURLPath,
+		LogsURLPath:    defaultLogsURLPath,
+		PingInterval:   defaultPingInterval,
+		PongTimeout:    defaultPongTimeout,
 	}
 }
 
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// internTraces returns a consumer.Traces interning the attributes of the received traces before passing
+// them to next. The received data is not shared yet, so that interning it does not race with the consumers.
+func internTraces(si *pcommon.StringInterner, next consumer.Traces) consumer.Traces {
+	tc, _ := consumer.NewTracesWithResponse(func(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+		td.InternAttributes(si)
+		return consumer.ConsumeTracesWithResponse(ctx, next, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tc
+}
+
+// internMetrics returns a consumer.Metrics interning the attributes of the received metrics before passing them to next.
+func internMetrics(si *pcommon.StringInterner, next consumer.Metrics) consumer.Metrics {
+	mc, _ := consumer.NewMetricsWithResponse(func(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+		md.InternAttributes(si)
+		return consumer.ConsumeMetricsWithResponse(ctx, next, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mc
+}
+
+// internLogs returns a consumer.Logs interning the attributes of the received logs before passing them to next.
+func internLogs(si *pcommon.StringInterner, next consumer.Logs) consumer.Logs {
+	lc, _ := consumer.NewLogsWithResponse(func(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+		ld.InternAttributes(si)
+		return consumer.ConsumeLogsWithResponse(ctx, next, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lc
+}
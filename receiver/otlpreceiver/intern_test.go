@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestInternAttributes(t *testing.T) {
+	si := pcommon.NewStringInterner(10)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	traces := new(consumertest.TracesSink)
+	require.NoError(t, internTraces(si, traces).ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 2, si.Len())
+	assert.Equal(t, []ptrace.Traces{td}, traces.AllTraces())
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "cart")
+	metrics := new(consumertest.MetricsSink)
+	require.NoError(t, internMetrics(si, metrics).ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 3, si.Len())
+	assert.Len(t, metrics.AllMetrics(), 1)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("k8s.namespace.name", "shop")
+	logs := new(consumertest.LogsSink)
+	require.NoError(t, internLogs(si, logs).ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 5, si.Len())
+	assert.Len(t, logs.AllLogs(), 1)
+}
+
+func TestInternAttributesResponse(t *testing.T) {
+	next, err := consumer.NewTracesWithResponse(func(context.Context, ptrace.Traces) (consumer.Response, error) {
+		return consumer.Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}, nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+
+	// The capabilities and the responses of the next consumer are kept.
+	tc := internTraces(pcommon.NewStringInterner(10), next)
+	assert.True(t, tc.Capabilities().MutatesData)
+	rsp, err := consumer.ConsumeTracesWithResponse(context.Background(), tc, ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}, rsp)
+}
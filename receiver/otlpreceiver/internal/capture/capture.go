@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capture records the requests received by the OTLP receiver, along with the responses,
+// e.g. to verify what the clients send when diagnosing interoperability issues.
+package capture // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/capture"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// redacted replaces the values of the headers that are not included.
+const redacted = "[REDACTED]"
+
+// credentialHeaders are redacted even if included.
+var credentialHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+}
+
+// Request is a recorded request and its response.
+type Request struct {
+	Time      time.Time `json:"time"`
+	Transport string    `json:"transport"`
+	// Path is the URL path of HTTP requests, or the full method of gRPC requests.
+	Path    string              `json:"path"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	// Body is the decompressed body of HTTP requests, or the protobuf encoding of gRPC requests.
+	Body          []byte `json:"body"`
+	BodyTruncated bool   `json:"body_truncated,omitempty"`
+	// Status is the HTTP status, or the gRPC status code, of the response.
+	Status                string `json:"status"`
+	ResponseBody          []byte `json:"response_body,omitempty"`
+	ResponseBodyTruncated bool   `json:"response_body_truncated,omitempty"`
+}
+
+// Recorder records the requests either in a ring buffer, or in a file.
+type Recorder struct {
+	maxBodySize int
+	// includeHeaders holds the lower case names of the headers recorded as is, the others are redacted.
+	includeHeaders map[string]bool
+
+	mu       sync.Mutex
+	requests []Request
+	next     int
+	full     bool
+	file     *os.File
+	enc      *json.Encoder
+}
+
+// NewRingBuffer returns a Recorder keeping the last size requests in memory.
+// The bodies are truncated to maxBodySize bytes, unless it is 0. The values of the headers
+// are redacted, except for the includeHeaders that do not carry credentials.
+func NewRingBuffer(size int, maxBodySize int, includeHeaders []string) *Recorder {
+	return &Recorder{
+		maxBodySize:    maxBodySize,
+		includeHeaders: headerSet(includeHeaders),
+		requests:       make([]Request, size),
+	}
+}
+
+// NewFile returns a Recorder appending the requests to the file at the given path, in JSON lines.
+// The bodies are truncated to maxBodySize bytes, unless it is 0. The values of the headers
+// are redacted, except for the includeHeaders that do not carry credentials.
+func NewFile(path string, maxBodySize int, includeHeaders []string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		maxBodySize:    maxBodySize,
+		includeHeaders: headerSet(includeHeaders),
+		file:           f,
+		enc:            json.NewEncoder(f),
+	}, nil
+}
+
+// Record records the request.
+func (r *Recorder) Record(req Request) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.enc != nil {
+		return r.enc.Encode(req)
+	}
+	r.requests[r.next] = req
+	r.next = (r.next + 1) % len(r.requests)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+// Requests returns the requests kept in the ring buffer, from the oldest to the newest.
+func (r *Recorder) Requests() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]Request{}, r.requests[:r.next]...)
+	}
+	return append(append([]Request{}, r.requests[r.next:]...), r.requests[:r.next]...)
+}
+
+// Close closes the file the requests are recorded in.
+func (r *Recorder) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// ServeHTTP serves the requests kept in the ring buffer as a JSON array.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(r.Requests())
+}
+
+// Handler returns an http.Handler recording the requests handled by next.
+func (r *Recorder) Handler(next http.Handler, onError func(error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec := Request{
+			Time:      time.Now(),
+			Transport: "http",
+			Path:      req.URL.Path,
+			Headers:   r.redact(req.Header),
+		}
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			// Let the handler report the error reading the rest of the body.
+			req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+			rec.Body, rec.BodyTruncated = r.truncate(body)
+		}
+
+		rw := &responseWriter{ResponseWriter: w, maxBodySize: r.maxBodySize, status: http.StatusOK}
+		next.ServeHTTP(rw, req)
+
+		rec.Status = strconv.Itoa(rw.status) + " " + http.StatusText(rw.status)
+		rec.ResponseBody, rec.ResponseBodyTruncated = rw.body.Bytes(), rw.truncated
+		if err := r.Record(rec); err != nil {
+			onError(err)
+		}
+	})
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor recording the requests.
+func (r *Recorder) UnaryServerInterceptor(onError func(error)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rec := Request{
+			Time:      time.Now(),
+			Transport: "grpc",
+			Path:      info.FullMethod,
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			rec.Headers = r.redact(md)
+		}
+		rec.Body, rec.BodyTruncated = r.truncate(marshal(req))
+
+		resp, err := handler(ctx, req)
+
+		rec.Status = status.Code(err).String()
+		if err == nil {
+			rec.ResponseBody, rec.ResponseBodyTruncated = r.truncate(marshal(resp))
+		}
+		if recErr := r.Record(rec); recErr != nil {
+			onError(recErr)
+		}
+		return resp, err
+	}
+}
+
+func (r *Recorder) truncate(body []byte) ([]byte, bool) {
+	if r.maxBodySize > 0 && len(body) > r.maxBodySize {
+		// Do not retain the whole body.
+		return bytes.Clone(body[:r.maxBodySize]), true
+	}
+	return body, false
+}
+
+// marshal returns the protobuf encoding of the OTLP messages.
+func marshal(msg any) []byte {
+	m, ok := msg.(interface{ Marshal() ([]byte, error) })
+	if !ok {
+		return nil
+	}
+	b, err := m.Marshal()
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func headerSet(headers []string) map[string]bool {
+	set := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		set[strings.ToLower(h)] = true
+	}
+	return set
+}
+
+// redact returns a copy of the headers, with the values of the headers that are not included redacted.
+func (r *Recorder) redact(headers map[string][]string) map[string][]string {
+	res := make(map[string][]string, len(headers))
+	for k, v := range headers {
+		name := strings.ToLower(k)
+		if !r.includeHeaders[name] || credentialHeaders[name] {
+			res[k] = []string{redacted}
+			continue
+		}
+		res[k] = append([]string{}, v...)
+	}
+	return res
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
+
+// responseWriter records the status and the body of the response.
+type responseWriter struct {
+	http.ResponseWriter
+	maxBodySize int
+	status      int
+	body        bytes.Buffer
+	truncated   bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	rec := b
+	if w.maxBodySize > 0 && w.body.Len()+len(rec) > w.maxBodySize {
+		rec = rec[:w.maxBodySize-w.body.Len()]
+		w.truncated = true
+	}
+	w.body.Write(rec)
+	return w.ResponseWriter.Write(b)
+}
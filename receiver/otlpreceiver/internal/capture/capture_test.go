@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRingBuffer(t *testing.T) {
+	r := NewRingBuffer(2, 0, nil)
+	assert.Empty(t, r.Requests())
+
+	require.NoError(t, r.Record(Request{Path: "/1"}))
+	assert.Equal(t, []Request{{Path: "/1"}}, r.Requests())
+
+	require.NoError(t, r.Record(Request{Path: "/2"}))
+	require.NoError(t, r.Record(Request{Path: "/3"}))
+	assert.Equal(t, []Request{{Path: "/2"}, {Path: "/3"}}, r.Requests())
+	require.NoError(t, r.Close())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	r, err := NewFile(path, 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, r.Record(Request{Path: "/1", Body: []byte("body")}))
+	require.NoError(t, r.Record(Request{Path: "/2"}))
+	require.NoError(t, r.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	dec := json.NewDecoder(bytes.NewReader(b))
+	var req Request
+	require.NoError(t, dec.Decode(&req))
+	assert.Equal(t, Request{Path: "/1", Body: []byte("body")}, req)
+	req = Request{}
+	require.NoError(t, dec.Decode(&req))
+	assert.Equal(t, Request{Path: "/2"}, req)
+	assert.ErrorIs(t, dec.Decode(&req), io.EOF)
+
+	_, err = NewFile(filepath.Join(t.TempDir(), "missing", "requests.jsonl"), 0, nil)
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRingBuffer(10, 4, []string{"content-type", "Authorization"})
+	var got []byte
+	h := r.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var err error
+		got, err = io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid"))
+	}), func(err error) { assert.NoError(t, err) })
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", bytes.NewReader([]byte("request body")))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", "secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	// The handler reads the whole body, and the client receives the whole response.
+	assert.Equal(t, []byte("request body"), got)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "invalid", rec.Body.String())
+
+	requests := r.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "http", requests[0].Transport)
+	assert.Equal(t, "/v1/traces", requests[0].Path)
+	assert.Equal(t, []string{redacted}, requests[0].Headers["Authorization"])
+	assert.Equal(t, []string{"application/json"}, requests[0].Headers["Content-Type"])
+	assert.Equal(t, []string{redacted}, requests[0].Headers["X-Api-Key"])
+	assert.Equal(t, []byte("requ"), requests[0].Body)
+	assert.True(t, requests[0].BodyTruncated)
+	assert.Equal(t, "400 Bad Request", requests[0].Status)
+	assert.Equal(t, []byte("inva"), requests[0].ResponseBody)
+	assert.True(t, requests[0].ResponseBodyTruncated)
+}
+
+func TestHandlerRecordError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	r, err := NewFile(path, 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	var recErr error
+	h := r.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), func(err error) { recErr = err })
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/traces", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Error(t, recErr)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	r := NewRingBuffer(10, 0, []string{"x-scope"})
+	interceptor := r.UnaryServerInterceptor(func(err error) { assert.NoError(t, err) })
+	info := &grpc.UnaryServerInfo{FullMethod: "/opentelemetry.proto.collector.trace.v1.TraceService/Export"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret", "x-scope", "tenant", "x-auth-token", "secret"))
+
+	_, err := interceptor(ctx, fakeMessage("request"), info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	requests := r.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "grpc", requests[0].Transport)
+	assert.Equal(t, info.FullMethod, requests[0].Path)
+	assert.Equal(t, []string{redacted}, requests[0].Headers["authorization"])
+	assert.Equal(t, []string{"tenant"}, requests[0].Headers["x-scope"])
+	assert.Equal(t, []string{redacted}, requests[0].Headers["x-auth-token"])
+	assert.Equal(t, []byte("request"), requests[0].Body)
+	assert.Equal(t, "Unavailable", requests[0].Status)
+	assert.Nil(t, requests[0].ResponseBody)
+
+	resp, err := interceptor(ctx, fakeMessage("request"), info, func(context.Context, any) (any, error) {
+		return fakeMessage("response"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fakeMessage("response"), resp)
+	requests = r.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "OK", requests[1].Status)
+	assert.Equal(t, []byte("response"), requests[1].ResponseBody)
+}
+
+// fakeMessage marshals like the gogo protobuf messages.
+type fakeMessage string
+
+func (m fakeMessage) Marshal() ([]byte, error) {
+	return []byte(m), nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := NewRingBuffer(10, 0, nil)
+	require.NoError(t, r.Record(Request{Path: "/v1/traces", Status: "200 OK"}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/capturez/otlp", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var requests []Request
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &requests))
+	assert.Equal(t, r.Requests(), requests)
+}
+
+func TestErrReader(t *testing.T) {
+	_, err := errReader{}.Read(nil)
+	assert.ErrorIs(t, err, io.EOF)
+	readErr := errors.New("read error")
+	_, err = errReader{readErr}.Read(nil)
+	assert.ErrorIs(t, err, readErr)
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package connstats tracks the statistics of the gRPC connections of the OTLP receiver, e.g. to find
+// the client flooding a gateway shared by many clients.
+package connstats // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/connstats"
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+
+	"go.opentelemetry.io/collector/clock"
+)
+
+// rateWindow is the window the rates are computed over.
+const rateWindow = 10 * time.Second
+
+// Connection is the statistics of a connection.
+type Connection struct {
+	RemoteAddress string `json:"remote_address"`
+	// UserAgent is the user agent sent by the client in the first stream of the connection.
+	UserAgent string `json:"user_agent,omitempty"`
+	// TLSSubject is the subject of the certificate of the client, for mutual TLS connections.
+	TLSSubject  string    `json:"tls_subject,omitempty"`
+	Established time.Time `json:"established"`
+	// ActiveStreams is the number of streams in progress, Streams the number of streams since the connection was established.
+	ActiveStreams int64 `json:"active_streams"`
+	Streams       int64 `json:"streams"`
+	// Messages and Bytes are the number of messages and of bytes, on the wire, received since the connection was established.
+	Messages int64 `json:"messages"`
+	Bytes    int64 `json:"bytes"`
+	// MessagesPerSecond and BytesPerSecond are the rates over the last 10 seconds.
+	MessagesPerSecond float64 `json:"messages_per_second"`
+	BytesPerSecond    float64 `json:"bytes_per_second"`
+}
+
+type connKey struct{}
+
+// conn holds the statistics of a connection and the counts of the current rate window.
+type conn struct {
+	mu    sync.Mutex
+	stats Connection
+
+	windowStart    time.Time
+	windowMessages int64
+	windowBytes    int64
+	// lastWindow is true once a window is complete, the rates being then those of the last window.
+	lastWindow bool
+}
+
+// roll starts a new window if the current one is complete.
+func (c *conn) roll(now time.Time) {
+	elapsed := now.Sub(c.windowStart)
+	if elapsed < rateWindow {
+		return
+	}
+	if elapsed < 2*rateWindow {
+		c.stats.MessagesPerSecond = float64(c.windowMessages) / elapsed.Seconds()
+		c.stats.BytesPerSecond = float64(c.windowBytes) / elapsed.Seconds()
+	} else {
+		// Nothing was received during the last window.
+		c.stats.MessagesPerSecond = 0
+		c.stats.BytesPerSecond = 0
+	}
+	c.windowStart = now
+	c.windowMessages = 0
+	c.windowBytes = 0
+	c.lastWindow = true
+}
+
+func (c *conn) snapshot(now time.Time) Connection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roll(now)
+	res := c.stats
+	if !c.lastWindow {
+		// Give the rates of the current window until one is complete.
+		if elapsed := now.Sub(c.windowStart).Seconds(); elapsed > 0 {
+			res.MessagesPerSecond = float64(c.windowMessages) / elapsed
+			res.BytesPerSecond = float64(c.windowBytes) / elapsed
+		}
+	}
+	return res
+}
+
+// Tracker is a stats.Handler tracking the statistics of the connections of a gRPC server.
+type Tracker struct {
+	clock clock.Clock
+
+	mu    sync.Mutex
+	conns map[*conn]struct{}
+}
+
+var _ stats.Handler = (*Tracker)(nil)
+
+// NewTracker returns a Tracker, to be given to the server with grpc.StatsHandler.
+func NewTracker() *Tracker {
+	return &Tracker{
+		clock: clock.System(),
+		conns: map[*conn]struct{}{},
+	}
+}
+
+// TagConn starts tracking the connection.
+func (t *Tracker) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	now := t.clock.Now()
+	c := &conn{
+		stats: Connection{
+			RemoteAddress: addrString(info.RemoteAddr),
+			Established:   now,
+		},
+		windowStart: now,
+	}
+	t.mu.Lock()
+	t.conns[c] = struct{}{}
+	t.mu.Unlock()
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// HandleConn stops tracking the connection once it ends.
+func (t *Tracker) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if c, ok := ctx.Value(connKey{}).(*conn); ok {
+		t.mu.Lock()
+		delete(t.conns, c)
+		t.mu.Unlock()
+	}
+}
+
+// TagRPC returns the context unchanged, the context of the connection being its parent.
+func (t *Tracker) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC counts the streams and the messages of the connection.
+func (t *Tracker) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	c, ok := ctx.Value(connKey{}).(*conn)
+	if !ok || s.IsClient() {
+		return
+	}
+	now := t.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roll(now)
+	switch st := s.(type) {
+	case *stats.Begin:
+		c.stats.ActiveStreams++
+		c.stats.Streams++
+		if c.stats.TLSSubject == "" {
+			c.stats.TLSSubject = tlsSubject(ctx)
+		}
+	case *stats.InHeader:
+		if ua := st.Header.Get("user-agent"); c.stats.UserAgent == "" && len(ua) > 0 {
+			c.stats.UserAgent = ua[0]
+		}
+	case *stats.InPayload:
+		c.stats.Messages++
+		c.stats.Bytes += int64(st.WireLength)
+		c.windowMessages++
+		c.windowBytes += int64(st.WireLength)
+	case *stats.End:
+		c.stats.ActiveStreams--
+	}
+}
+
+// Connections returns the statistics of the open connections, from the highest rate of bytes to the lowest.
+func (t *Tracker) Connections() []Connection {
+	t.mu.Lock()
+	conns := make([]*conn, 0, len(t.conns))
+	for c := range t.conns {
+		conns = append(conns, c)
+	}
+	t.mu.Unlock()
+
+	now := t.clock.Now()
+	res := make([]Connection, 0, len(conns))
+	for _, c := range conns {
+		res = append(res, c.snapshot(now))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].BytesPerSecond != res[j].BytesPerSecond {
+			return res[i].BytesPerSecond > res[j].BytesPerSecond
+		}
+		return res[i].RemoteAddress < res[j].RemoteAddress
+	})
+	return res
+}
+
+// ServeHTTP serves the statistics of the open connections as a JSON array.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(t.Connections())
+}
+
+// tlsSubject returns the subject of the certificate of the client, if any.
+func tlsSubject(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ""
+	}
+	return info.State.PeerCertificates[0].Subject.String()
+}
+
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
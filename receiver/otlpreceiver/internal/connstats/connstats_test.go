@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connstats
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+
+	"go.opentelemetry.io/collector/clock/clocktest"
+)
+
+func newTestTracker() (*Tracker, *clocktest.FakeClock) {
+	clk := clocktest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	t := NewTracker()
+	t.clock = clk
+	return t, clk
+}
+
+// stream simulates a stream of the connection, receiving the given number of messages of size bytes.
+func stream(ctx context.Context, tr *Tracker, messages int, size int) {
+	ctx = tr.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: "/opentelemetry.proto.collector.trace.v1.TraceService/Export"})
+	tr.HandleRPC(ctx, &stats.Begin{})
+	tr.HandleRPC(ctx, &stats.InHeader{Header: metadata.Pairs("user-agent", "OTel-Go-Exporter/1.25.0")})
+	for i := 0; i < messages; i++ {
+		tr.HandleRPC(ctx, &stats.InPayload{WireLength: size})
+	}
+	tr.HandleRPC(ctx, &stats.End{})
+}
+
+func TestTracker(t *testing.T) {
+	tr, clk := newTestTracker()
+	established := clk.Now()
+	ctx1 := tr.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000}})
+	ctx2 := tr.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 4000}})
+
+	stream(ctx1, tr, 2, 100)
+	stream(ctx2, tr, 10, 1000)
+	clk.Advance(5 * time.Second)
+
+	// The rates are those of the current window until one is complete.
+	assert.Equal(t, []Connection{
+		{
+			RemoteAddress:     "10.0.0.2:4000",
+			UserAgent:         "OTel-Go-Exporter/1.25.0",
+			Established:       established,
+			Streams:           1,
+			Messages:          10,
+			Bytes:             10000,
+			MessagesPerSecond: 2,
+			BytesPerSecond:    2000,
+		},
+		{
+			RemoteAddress:     "10.0.0.1:4000",
+			UserAgent:         "OTel-Go-Exporter/1.25.0",
+			Established:       established,
+			Streams:           1,
+			Messages:          2,
+			Bytes:             200,
+			MessagesPerSecond: 0.4,
+			BytesPerSecond:    40,
+		},
+	}, tr.Connections())
+
+	// The connection 1 floods the receiver in the next window.
+	clk.Advance(5 * time.Second)
+	stream(ctx1, tr, 100, 100)
+	clk.Advance(10 * time.Second)
+	conns := tr.Connections()
+	require.Len(t, conns, 2)
+	assert.Equal(t, "10.0.0.1:4000", conns[0].RemoteAddress)
+	assert.Equal(t, int64(2), conns[0].Streams)
+	assert.Equal(t, int64(102), conns[0].Messages)
+	assert.Equal(t, float64(10), conns[0].MessagesPerSecond)
+	assert.Equal(t, float64(1000), conns[0].BytesPerSecond)
+	assert.Equal(t, "10.0.0.2:4000", conns[1].RemoteAddress)
+	assert.Equal(t, float64(0), conns[1].MessagesPerSecond)
+
+	tr.HandleConn(ctx1, &stats.ConnEnd{})
+	conns = tr.Connections()
+	require.Len(t, conns, 1)
+	assert.Equal(t, "10.0.0.2:4000", conns[0].RemoteAddress)
+}
+
+func TestTrackerActiveStreams(t *testing.T) {
+	tr, _ := newTestTracker()
+	ctx := tr.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000}})
+	ctx = tr.TagRPC(ctx, &stats.RPCTagInfo{})
+	tr.HandleRPC(ctx, &stats.Begin{})
+	tr.HandleRPC(ctx, &stats.Begin{})
+	tr.HandleRPC(ctx, &stats.End{})
+	// The client side events and the events of untracked connections are ignored.
+	tr.HandleRPC(ctx, &stats.Begin{Client: true})
+	tr.HandleRPC(context.Background(), &stats.Begin{})
+
+	conns := tr.Connections()
+	require.Len(t, conns, 1)
+	assert.Equal(t, int64(1), conns[0].ActiveStreams)
+	assert.Equal(t, int64(2), conns[0].Streams)
+}
+
+func TestTrackerServeHTTP(t *testing.T) {
+	tr, _ := newTestTracker()
+	ctx := tr.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000}})
+	stream(ctx, tr, 1, 10)
+
+	rec := httptest.NewRecorder()
+	tr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/connectionz/otlp", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var conns []Connection
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &conns))
+	require.Len(t, conns, 1)
+	assert.Equal(t, "10.0.0.1:4000", conns[0].RemoteAddress)
+	assert.Equal(t, int64(10), conns[0].Bytes)
+}
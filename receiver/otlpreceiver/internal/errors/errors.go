@@ -5,28 +5,55 @@ package errors // import "go.opentelemetry.io/collector/receiver/otlpreceiver/in
 
 import (
 	"net/http"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
+// GetStatusFromError converts the error to a gRPC status according to its consumererror.Category,
+// unless it already has a status.
 func GetStatusFromError(err error) error {
 	s, ok := status.FromError(err)
 	if !ok {
 		// Default to a retryable error
 		// https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#failures
 		code := codes.Unavailable
-		if consumererror.IsPermanent(err) {
+		switch consumererror.Classify(err) {
+		case consumererror.CategoryPermanent:
 			// If an error is permanent but doesn't have an attached gRPC status, assume it is server-side.
 			code = codes.Internal
+		case consumererror.CategoryExpired:
+			code = codes.DeadlineExceeded
+		case consumererror.CategoryThrottled:
+			// ResourceExhausted is only retryable with the RetryInfo, i.e. when the delay is known.
+			if delay, ok := consumererror.ThrottleDelay(err); ok {
+				s = status.New(codes.ResourceExhausted, err.Error())
+				if ds, dErr := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); dErr == nil {
+					return ds.Err()
+				}
+				return s.Err()
+			}
 		}
 		s = status.New(code, err.Error())
 	}
 	return s.Err()
 }
 
+// GetRetryDelayFromStatus returns the delay of the RetryInfo of the status, if any.
+func GetRetryDelayFromStatus(s *status.Status) (time.Duration, bool) {
+	for _, detail := range s.Details() {
+		if ri, ok := detail.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
 func GetHTTPStatusCodeFromStatus(s *status.Status) int {
 	// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#failures
 	// to see if a code is retryable.
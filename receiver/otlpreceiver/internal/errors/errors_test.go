@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
 )
@@ -36,6 +40,31 @@ func Test_GetStatusFromError(t *testing.T) {
 			input:    fmt.Errorf("test"),
 			expected: status.New(codes.Unavailable, "test"),
 		},
+		{
+			name:     "Shutdown Error",
+			input:    consumererror.NewShutdown(fmt.Errorf("test")),
+			expected: status.New(codes.Unavailable, "interrupted due to shutdown: test"),
+		},
+		{
+			name:     "Expired Error",
+			input:    consumererror.NewExpired(fmt.Errorf("test")),
+			expected: status.New(codes.DeadlineExceeded, "Expired: test"),
+		},
+		{
+			name:     "Throttled Error",
+			input:    consumererror.NewThrottled(fmt.Errorf("test"), 3*time.Second),
+			expected: withRetryInfo(t, status.New(codes.ResourceExhausted, "Throttled (3s): test"), 3*time.Second),
+		},
+		{
+			name:     "Throttled Error With Delay Method",
+			input:    fmt.Errorf("export failed: %w", delayedError{}),
+			expected: withRetryInfo(t, status.New(codes.ResourceExhausted, "export failed: throttled"), 5*time.Second),
+		},
+		{
+			name:     "Throttled Error Without Delay",
+			input:    throttledError{},
+			expected: status.New(codes.Unavailable, "throttled"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -45,6 +74,40 @@ func Test_GetStatusFromError(t *testing.T) {
 	}
 }
 
+type throttledError struct{}
+
+func (throttledError) Error() string {
+	return "throttled"
+}
+
+func (throttledError) ErrorCategory() consumererror.Category {
+	return consumererror.CategoryThrottled
+}
+
+// delayedError is throttled with a delay like the throttle errors of the exporters.
+type delayedError struct {
+	throttledError
+}
+
+func (delayedError) ThrottleDelay() time.Duration {
+	return 5 * time.Second
+}
+
+func withRetryInfo(t *testing.T, s *status.Status, delay time.Duration) *status.Status {
+	s, err := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	require.NoError(t, err)
+	return s
+}
+
+func Test_GetRetryDelayFromStatus(t *testing.T) {
+	_, ok := GetRetryDelayFromStatus(status.New(codes.ResourceExhausted, "test"))
+	assert.False(t, ok)
+
+	delay, ok := GetRetryDelayFromStatus(withRetryInfo(t, status.New(codes.ResourceExhausted, "test"), 3*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+}
+
 func Test_GetHTTPStatusCodeFromStatus(t *testing.T) {
 	tests := []struct {
 		name     string
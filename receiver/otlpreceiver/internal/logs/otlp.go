@@ -38,7 +38,7 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	}
 
 	ctx = r.obsreport.StartLogsOp(ctx)
-	err := r.nextConsumer.ConsumeLogs(ctx, ld)
+	consumerRsp, err := consumer.ConsumeLogsWithResponse(ctx, r.nextConsumer, ld)
 	r.obsreport.EndLogsOp(ctx, dataFormatProtobuf, numSpans, err)
 
 	// Use appropriate status codes for permanent/non-permanent errors
@@ -51,5 +51,11 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 		return plogotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
-	return plogotlp.NewExportResponse(), nil
+	rsp := plogotlp.NewExportResponse()
+	// The items rejected by the consumers returning a response are reported as a partial success.
+	if consumerRsp.Rejected > 0 {
+		rsp.PartialSuccess().SetRejectedLogRecords(int64(consumerRsp.Rejected))
+		rsp.PartialSuccess().SetErrorMessage(consumerRsp.ErrorMessage)
+	}
+	return rsp, nil
 }
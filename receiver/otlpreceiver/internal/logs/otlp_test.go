@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -72,6 +73,20 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, plogotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialSuccess(t *testing.T) {
+	req := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2))
+	tc, err := consumer.NewLogsWithResponse(func(context.Context, plog.Logs) (consumer.Response, error) {
+		return consumer.Response{Accepted: 1, Rejected: 1, ErrorMessage: "one rejected"}, nil
+	})
+	require.NoError(t, err)
+
+	client := makeLogsServiceClient(t, tc)
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "one rejected", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeLogsServiceClient(t *testing.T, lc consumer.Logs) plogotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
@@ -38,7 +38,7 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 	}
 
 	ctx = r.obsreport.StartMetricsOp(ctx)
-	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	consumerRsp, err := consumer.ConsumeMetricsWithResponse(ctx, r.nextConsumer, md)
 	r.obsreport.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
 
 	// Use appropriate status codes for permanent/non-permanent errors
@@ -51,5 +51,11 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 		return pmetricotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
-	return pmetricotlp.NewExportResponse(), nil
+	rsp := pmetricotlp.NewExportResponse()
+	// The items rejected by the consumers returning a response are reported as a partial success.
+	if consumerRsp.Rejected > 0 {
+		rsp.PartialSuccess().SetRejectedDataPoints(int64(consumerRsp.Rejected))
+		rsp.PartialSuccess().SetErrorMessage(consumerRsp.ErrorMessage)
+	}
+	return rsp, nil
 }
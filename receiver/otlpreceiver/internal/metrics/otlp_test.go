@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -72,6 +73,20 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, pmetricotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialSuccess(t *testing.T) {
+	req := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(2))
+	tc, err := consumer.NewMetricsWithResponse(func(context.Context, pmetric.Metrics) (consumer.Response, error) {
+		return consumer.Response{Accepted: 1, Rejected: 1, ErrorMessage: "one rejected"}, nil
+	})
+	require.NoError(t, err)
+
+	client := makeMetricsServiceClient(t, tc)
+	resp, err := client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedDataPoints())
+	assert.Equal(t, "one rejected", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeMetricsServiceClient(t *testing.T, mc consumer.Metrics) pmetricotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, mc)
 
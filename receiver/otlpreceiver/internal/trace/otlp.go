@@ -39,7 +39,7 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	}
 
 	ctx = r.obsreport.StartTracesOp(ctx)
-	err := r.nextConsumer.ConsumeTraces(ctx, td)
+	consumerRsp, err := consumer.ConsumeTracesWithResponse(ctx, r.nextConsumer, td)
 	r.obsreport.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
 
 	// Use appropriate status codes for permanent/non-permanent errors
@@ -52,5 +52,11 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 		return ptraceotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
-	return ptraceotlp.NewExportResponse(), nil
+	rsp := ptraceotlp.NewExportResponse()
+	// The items rejected by the consumers returning a response are reported as a partial success.
+	if consumerRsp.Rejected > 0 {
+		rsp.PartialSuccess().SetRejectedSpans(int64(consumerRsp.Rejected))
+		rsp.PartialSuccess().SetErrorMessage(consumerRsp.ErrorMessage)
+	}
+	return rsp, nil
 }
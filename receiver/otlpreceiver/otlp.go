@@ -8,18 +8,24 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"path"
 	"sync"
 
+	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/zpagesregistry"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/capture"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/connstats"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
@@ -31,6 +37,7 @@ type otlpReceiver struct {
 	cfg        *Config
 	serverGRPC *grpc.Server
 	serverHTTP *http.Server
+	serverWS   *http.Server
 
 	nextTraces  consumer.Traces
 	nextMetrics consumer.Metrics
@@ -39,10 +46,61 @@ type otlpReceiver struct {
 
 	obsrepGRPC *receiverhelper.ObsReport
 	obsrepHTTP *receiverhelper.ObsReport
+	obsrepWS   *receiverhelper.ObsReport
+
+	// wsDone is closed on shutdown to close the WebSocket connections, tracked by wsConns.
+	wsDone  chan struct{}
+	wsConns sync.WaitGroup
+
+	// capture records the received requests, it is nil unless enabled.
+	capture *capture.Recorder
+	// connStats tracks the statistics of the gRPC connections, it is nil unless enabled.
+	connStats *connstats.Tracker
+	// interner interns the attributes of the received data, it is nil unless enabled.
+	interner *pcommon.StringInterner
 
 	settings *receiver.CreateSettings
 }
 
+func (r *otlpReceiver) startCapture(host component.Host) error {
+	switch r.cfg.Capture.Mode {
+	case CaptureModeRingBuffer:
+		r.capture = capture.NewRingBuffer(r.cfg.Capture.Size, int(r.cfg.Capture.MaxBodySize), r.cfg.Capture.IncludeHeaders)
+		name := path.Join("capturez", r.settings.ID.String())
+		if ok, err := zpagesregistry.Register(host, name, r.capture); ok {
+			r.settings.Logger.Info("Serving the captured requests", zap.String("zpage", name))
+			return err
+		}
+		r.settings.Logger.Warn("The captured requests are not served, the zpages extension is not enabled")
+	case CaptureModeFile:
+		var err error
+		if r.capture, err = capture.NewFile(r.cfg.Capture.Path, int(r.cfg.Capture.MaxBodySize),
+			r.cfg.Capture.IncludeHeaders); err != nil {
+			return err
+		}
+		r.settings.Logger.Info("Recording the received requests", zap.String("path", r.cfg.Capture.Path))
+	}
+	return nil
+}
+
+func (r *otlpReceiver) startConnectionStats(host component.Host) error {
+	if !r.cfg.ConnectionStats.Enabled || r.cfg.GRPC == nil {
+		return nil
+	}
+	r.connStats = connstats.NewTracker()
+	name := path.Join("connectionz", r.settings.ID.String())
+	if ok, err := zpagesregistry.Register(host, name, r.connStats); ok {
+		r.settings.Logger.Info("Serving the statistics of the gRPC connections", zap.String("zpage", name))
+		return err
+	}
+	r.settings.Logger.Warn("The statistics of the gRPC connections are not served, the zpages extension is not enabled")
+	return nil
+}
+
+func (r *otlpReceiver) captureError(err error) {
+	r.settings.Logger.Warn("Failed to record the received request", zap.Error(err))
+}
+
 // newOtlpReceiver just creates the OpenTelemetry receiver services. It is the caller's
 // responsibility to invoke the respective Start*Reception methods as well
 // as the various Stop*Reception methods to end it.
@@ -53,6 +111,10 @@ func newOtlpReceiver(cfg *Config, set *receiver.CreateSettings) (*otlpReceiver,
 		nextMetrics: nil,
 		nextLogs:    nil,
 		settings:    set,
+		wsDone:      make(chan struct{}),
+	}
+	if cfg.InternAttributes.Enabled {
+		r.interner = pcommon.NewStringInterner(cfg.InternAttributes.Capacity)
 	}
 
 	var err error
@@ -72,6 +134,14 @@ func newOtlpReceiver(cfg *Config, set *receiver.CreateSettings) (*otlpReceiver,
 	if err != nil {
 		return nil, err
 	}
+	r.obsrepWS, err = receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "websocket",
+		ReceiverCreateSettings: *set,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return r, nil
 }
@@ -82,8 +152,16 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 		return nil
 	}
 
+	var opts []grpc.ServerOption
+	if r.capture != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(r.capture.UnaryServerInterceptor(r.captureError)))
+	}
+	if r.connStats != nil {
+		opts = append(opts, grpc.StatsHandler(r.connStats))
+	}
+
 	var err error
-	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings); err != nil {
+	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings, opts...); err != nil {
 		return err
 	}
 
@@ -126,26 +204,31 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextTraces != nil {
 		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, httpTracesReceiver)
+			handleTraces(resp, req, httpTracesReceiver, r.protoPassthrough(r.nextTraces.Capabilities()))
 		})
 	}
 
 	if r.nextMetrics != nil {
 		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, httpMetricsReceiver)
+			handleMetrics(resp, req, httpMetricsReceiver, r.protoPassthrough(r.nextMetrics.Capabilities()))
 		})
 	}
 
 	if r.nextLogs != nil {
 		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleLogs(resp, req, httpLogsReceiver)
+			handleLogs(resp, req, httpLogsReceiver, r.protoPassthrough(r.nextLogs.Capabilities()))
 		})
 	}
 
+	var handler http.Handler = httpMux
+	if r.capture != nil {
+		handler = r.capture.Handler(httpMux, r.captureError)
+	}
+
 	var err error
-	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, handler, confighttp.WithErrorHandler(errorHandler)); err != nil {
 		return err
 	}
 
@@ -166,9 +249,77 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	return nil
 }
 
+func (r *otlpReceiver) startWebSocketServer(ctx context.Context, host component.Host) error {
+	// If WebSocket is not enabled, nothing to start.
+	if r.cfg.WebSocket == nil {
+		return nil
+	}
+
+	wsMux := http.NewServeMux()
+	newHandler := func(export wsExportFunc) *wsHandler {
+		return &wsHandler{
+			// The clients may compress the messages with the per-message deflate extension.
+			upgrader:     websocket.Upgrader{EnableCompression: true},
+			export:       export,
+			readLimit:    int64(r.cfg.WebSocket.MaxRequestBodySize),
+			pingInterval: r.cfg.WebSocket.PingInterval,
+			pongTimeout:  r.cfg.WebSocket.PongTimeout,
+			logger:       r.settings.Logger,
+			done:         r.wsDone,
+			conns:        &r.wsConns,
+		}
+	}
+	if r.nextTraces != nil {
+		wsMux.Handle(r.cfg.WebSocket.TracesURLPath, newHandler(wsExportTraces(trace.New(r.nextTraces, r.obsrepWS))))
+	}
+
+	if r.nextMetrics != nil {
+		wsMux.Handle(r.cfg.WebSocket.MetricsURLPath, newHandler(wsExportMetrics(metrics.New(r.nextMetrics, r.obsrepWS))))
+	}
+
+	if r.nextLogs != nil {
+		wsMux.Handle(r.cfg.WebSocket.LogsURLPath, newHandler(wsExportLogs(logs.New(r.nextLogs, r.obsrepWS))))
+	}
+
+	var err error
+	if r.serverWS, err = r.cfg.WebSocket.ToServer(ctx, host, r.settings.TelemetrySettings, wsMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+		return err
+	}
+
+	r.settings.Logger.Info("Starting WebSocket server", zap.String("endpoint", r.cfg.WebSocket.ServerConfig.Endpoint))
+	var wln net.Listener
+	if wln, err = r.cfg.WebSocket.ServerConfig.ToListener(ctx); err != nil {
+		return err
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errWS := r.serverWS.Serve(wln); errWS != nil && !errors.Is(errWS, http.ErrServerClosed) {
+			r.settings.ReportStatus(component.NewFatalErrorEvent(errWS))
+		}
+	}()
+	return nil
+}
+
+// protoPassthrough returns whether the received OTLP/protobuf payloads can be retained for a consumer with the given capabilities.
+// Mutating consumers need a copy of the data, so retaining the payloads would only add overhead. The consumer is the fanout to
+// the pipelines of the receiver, which only mutates the data when all the pipelines do: the payloads are retained as soon as
+// one of the pipelines does not mutate the data, the copies made for the other pipelines not retaining them.
+func (r *otlpReceiver) protoPassthrough(capabilities consumer.Capabilities) bool {
+	return r.cfg.HTTP.ProtoPassthrough && !capabilities.MutatesData
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.startCapture(host); err != nil {
+		return err
+	}
+	if err := r.startConnectionStats(host); err != nil {
+		return err
+	}
 	if err := r.startGRPCServer(host); err != nil {
 		return err
 	}
@@ -178,6 +329,9 @@ func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
 		// started GRPC server must be shutdown to ensure no goroutines are leaked.
 		return errors.Join(err, r.Shutdown(ctx))
 	}
+	if err := r.startWebSocketServer(ctx, host); err != nil {
+		return errors.Join(err, r.Shutdown(ctx))
+	}
 
 	return nil
 }
@@ -190,22 +344,55 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
 
+	if r.serverWS != nil {
+		err = errors.Join(err, r.serverWS.Shutdown(ctx))
+		select {
+		case <-r.wsDone:
+		default:
+			close(r.wsDone)
+		}
+		// Wait for the connections to be closed, they are not tracked by the HTTP server once upgraded.
+		served := make(chan struct{})
+		go func() {
+			r.wsConns.Wait()
+			close(served)
+		}()
+		select {
+		case <-served:
+		case <-ctx.Done():
+			err = errors.Join(err, ctx.Err())
+		}
+	}
+
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
 
 	r.shutdownWG.Wait()
+
+	if r.capture != nil {
+		err = errors.Join(err, r.capture.Close())
+	}
 	return err
 }
 
 func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) {
+	if r.interner != nil {
+		tc = internTraces(r.interner, tc)
+	}
 	r.nextTraces = tc
 }
 
 func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) {
+	if r.interner != nil {
+		mc = internMetrics(r.interner, mc)
+	}
 	r.nextMetrics = mc
 }
 
 func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) {
+	if r.interner != nil {
+		lc = internLogs(r.interner, lc)
+	}
 	r.nextLogs = lc
 }
@@ -13,6 +13,9 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -32,6 +35,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confighumanize"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
@@ -43,6 +47,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/capture"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/connstats"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
@@ -606,10 +612,11 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 		err                error
 		expectedCode       codes.Code
 		expectedStatusCode int
+		expectedRetryAfter string
 	}
 
 	expectedReceivedBatches := 2
-	expectedIngestionBlockedRPCs := 2
+	expectedIngestionBlockedRPCs := 3
 	ingestionStates := []ingestionStateTest{
 		{
 			okToIngest:   true,
@@ -627,6 +634,13 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 			expectedCode:       codes.Unavailable,
 			expectedStatusCode: http.StatusServiceUnavailable,
 		},
+		{
+			okToIngest:         false,
+			err:                consumererror.NewThrottled(errors.New("consumer error"), 3*time.Second),
+			expectedCode:       codes.ResourceExhausted,
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "3",
+		},
 		{
 			okToIngest:   true,
 			expectedCode: codes.OK,
@@ -674,6 +688,7 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 			assert.NoError(t, proto.Unmarshal(respBytes, errStatus))
 			assert.Equal(t, ingestionState.expectedStatusCode, resp.StatusCode)
 			assert.Equal(t, ingestionState.expectedCode, codes.Code(errStatus.Code))
+			assert.Equal(t, ingestionState.expectedRetryAfter, resp.Header.Get("Retry-After"))
 		}
 	}
 
@@ -730,7 +745,7 @@ func TestGRPCMaxRecvSize(t *testing.T) {
 	assert.NoError(t, cc.Close())
 	require.NoError(t, recv.Shutdown(context.Background()))
 
-	cfg.GRPC.MaxRecvMsgSizeMiB = 100
+	cfg.GRPC.MaxRecvMsgSize = 100 * confighumanize.Mebibyte
 	recv = newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
 	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
 	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
@@ -786,7 +801,7 @@ func testHTTPMaxRequestBodySize(t *testing.T, path string, contentType string, p
 			HTTP: &HTTPConfig{
 				ServerConfig: &confighttp.ServerConfig{
 					Endpoint:           addr,
-					MaxRequestBodySize: int64(size),
+					MaxRequestBodySize: confighumanize.Size(size),
 				},
 				TracesURLPath:  defaultTracesURLPath,
 				MetricsURLPath: defaultMetricsURLPath,
@@ -820,6 +835,136 @@ func TestHTTPMaxRequestBodySize(t *testing.T) {
 	}
 }
 
+type fakeZPagesExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	pages map[string]http.Handler
+}
+
+func (e *fakeZPagesExtension) RegisterZPage(name string, handler http.Handler) error {
+	e.pages[name] = handler
+	return nil
+}
+
+type zPagesHost struct {
+	component.Host
+	ext *fakeZPagesExtension
+}
+
+func (h *zPagesHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{component.MustNewID("zpages"): h.ext}
+}
+
+func TestCaptureRingBuffer(t *testing.T) {
+	addrGRPC := testutil.GetAvailableLocalAddress(t)
+	addrHTTP := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addrGRPC
+	cfg.HTTP.Endpoint = addrHTTP
+	cfg.Capture.Mode = CaptureModeRingBuffer
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, consumertest.NewNop())
+
+	host := &zPagesHost{Host: componenttest.NewNopHost(), ext: &fakeZPagesExtension{pages: map[string]http.Handler{}}}
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+	handler := host.ext.pages["capturez/otlp/receiver_test"]
+	require.NotNil(t, handler)
+
+	dr := generateTracesRequest(t)
+	req, err := http.NewRequest(http.MethodPost, "http://"+addrHTTP+dr.path, bytes.NewReader(dr.protoBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cc, err := grpc.NewClient(addrGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	require.NoError(t, exportTraces(cc, dr.data.(ptrace.Traces)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/capturez/otlp/receiver_test", nil))
+	var requests []capture.Request
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &requests))
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, "http", requests[0].Transport)
+	assert.Equal(t, "/v1/traces", requests[0].Path)
+	assert.Equal(t, []string{"[REDACTED]"}, requests[0].Headers["Authorization"])
+	assert.Equal(t, []string{"[REDACTED]"}, requests[0].Headers["Content-Type"])
+	assert.Equal(t, dr.protoBytes, requests[0].Body)
+	assert.Equal(t, "200 OK", requests[0].Status)
+
+	assert.Equal(t, "grpc", requests[1].Transport)
+	assert.Equal(t, "/opentelemetry.proto.collector.trace.v1.TraceService/Export", requests[1].Path)
+	assert.Equal(t, dr.protoBytes, requests[1].Body)
+	assert.Equal(t, "OK", requests[1].Status)
+}
+
+func TestCaptureFile(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	cfg.Capture.Mode = CaptureModeFile
+	cfg.Capture.Path = filepath.Join(t.TempDir(), "requests.jsonl")
+	cfg.Capture.IncludeHeaders = []string{"content-type"}
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, consumertest.NewNop())
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+
+	dr := generateTracesRequest(t)
+	doHTTPRequest(t, "http://"+addr+dr.path, "", "application/json", dr.jsonBytes, http.StatusOK)
+	require.NoError(t, recv.Shutdown(context.Background()))
+
+	f, err := os.Open(cfg.Capture.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	var recorded capture.Request
+	require.NoError(t, json.NewDecoder(f).Decode(&recorded))
+	assert.Equal(t, dr.jsonBytes, recorded.Body)
+	assert.Equal(t, []string{"application/json"}, recorded.Headers["Content-Type"])
+	assert.Equal(t, []string{"[REDACTED]"}, recorded.Headers["User-Agent"])
+}
+
+func TestConnectionStats(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP = nil
+	cfg.ConnectionStats.Enabled = true
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, consumertest.NewNop())
+
+	host := &zPagesHost{Host: componenttest.NewNopHost(), ext: &fakeZPagesExtension{pages: map[string]http.Handler{}}}
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+	handler := host.ext.pages["connectionz/otlp/receiver_test"]
+	require.NotNil(t, handler)
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUserAgent("test-client"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exportTraces(cc, td))
+	require.NoError(t, exportTraces(cc, td))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/connectionz/otlp/receiver_test", nil))
+	var conns []connstats.Connection
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &conns))
+	require.Len(t, conns, 1)
+	assert.Contains(t, conns[0].UserAgent, "test-client")
+	assert.Equal(t, int64(2), conns[0].Streams)
+	assert.Equal(t, int64(2), conns[0].Messages)
+	assert.Positive(t, conns[0].Bytes)
+}
+
 func newGRPCReceiver(t *testing.T, settings component.TelemetrySettings, endpoint string, c consumertest.Consumer) component.Component {
 	cfg := createDefaultConfig().(*Config)
 	cfg.GRPC.NetAddr.Endpoint = endpoint
@@ -6,8 +6,10 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 import (
 	"fmt"
 	"io"
+	"math"
 	"mime"
 	"net/http"
+	"strconv"
 
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/status"
@@ -23,8 +25,8 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, passthrough bool) {
+	enc, ok := readContentType(resp, req, passthrough)
 	if !ok {
 		return
 	}
@@ -54,8 +56,8 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, passthrough bool) {
+	enc, ok := readContentType(resp, req, passthrough)
 	if !ok {
 		return
 	}
@@ -85,8 +87,8 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, passthrough bool) {
+	enc, ok := readContentType(resp, req, passthrough)
 	if !ok {
 		return
 	}
@@ -116,7 +118,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool) {
+func readContentType(resp http.ResponseWriter, req *http.Request, passthrough bool) (encoder, bool) {
 	if req.Method != http.MethodPost {
 		handleUnmatchedMethod(resp)
 		return nil, false
@@ -124,6 +126,9 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 
 	switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
 	case pbContentType:
+		if passthrough {
+			return pbPassEncoder, true
+		}
 		return pbEncoder, true
 	case jsonContentType:
 		return jsEncoder, true
@@ -151,6 +156,9 @@ func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode in
 	s, ok := status.FromError(err)
 	if ok {
 		statusCode = errors.GetHTTPStatusCodeFromStatus(s)
+		if delay, ok := errors.GetRetryDelayFromStatus(s); ok && statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		}
 	} else {
 		s = errors.NewStatusFromMsgAndHTTPCode(err.Error(), statusCode)
 	}
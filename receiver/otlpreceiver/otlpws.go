@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+)
+
+// wsExportFunc exports the request received in a binary message, and returns the status to answer with.
+type wsExportFunc func(ctx context.Context, msg []byte) *status.Status
+
+// wsHandler accepts the WebSocket connections of a signal, and serves the export requests received on them.
+// The requests of a connection are exported one at a time, so that the responses are sent in order.
+type wsHandler struct {
+	upgrader     websocket.Upgrader
+	export       wsExportFunc
+	readLimit    int64
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	logger       *zap.Logger
+
+	// done is closed when the receiver shuts down, to close the connections.
+	done <-chan struct{}
+	// conns tracks the connections being served, which are not tracked by the HTTP server once upgraded.
+	conns *sync.WaitGroup
+}
+
+func (h *wsHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	conn, err := h.upgrader.Upgrade(resp, req, nil)
+	if err != nil {
+		// The upgrader has already replied with an HTTP error.
+		return
+	}
+	h.conns.Add(1)
+	defer h.conns.Done()
+	defer conn.Close()
+
+	if h.readLimit > 0 {
+		conn.SetReadLimit(h.readLimit)
+	}
+	_ = h.extendReadDeadline(conn)
+	conn.SetPongHandler(func(string) error { return h.extendReadDeadline(conn) })
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go h.keepAlive(conn, stop)
+
+	for {
+		msgType, msg, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.logger.Debug("WebSocket connection closed", zap.String("remote", req.RemoteAddr), zap.Error(err))
+			}
+			return
+		}
+		_ = h.extendReadDeadline(conn)
+
+		var st *status.Status
+		if msgType == websocket.BinaryMessage {
+			st = h.export(req.Context(), msg)
+		} else {
+			st = status.New(codes.InvalidArgument, "only binary messages holding OTLP/protobuf requests are supported")
+		}
+		rsp, err := pbEncoder.marshalStatus(st.Proto())
+		if err != nil {
+			h.logger.Warn("Failed to marshal the WebSocket response", zap.Error(err))
+			return
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(h.pongTimeout))
+		if err = conn.WriteMessage(websocket.BinaryMessage, rsp); err != nil {
+			return
+		}
+	}
+}
+
+// extendReadDeadline expects the next message or pong before the next ping is unanswered.
+func (h *wsHandler) extendReadDeadline(conn *websocket.Conn) error {
+	return conn.SetReadDeadline(time.Now().Add(h.pingInterval + h.pongTimeout))
+}
+
+// keepAlive pings the client until stop is closed, and closes the connection when the receiver shuts down.
+// The clients retry the requests in flight when the connection is closed.
+func (h *wsHandler) keepAlive(conn *websocket.Conn, stop <-chan struct{}) {
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.pongTimeout)); err != nil {
+				_ = conn.Close()
+				return
+			}
+		case <-h.done:
+			msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "receiver shutting down")
+			_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(h.pongTimeout))
+			_ = conn.Close()
+			return
+		case <-stop:
+			return
+		}
+	}
+}
+
+func wsExportTraces(tracesReceiver *trace.Receiver) wsExportFunc {
+	return func(ctx context.Context, msg []byte) *status.Status {
+		otlpReq, err := pbEncoder.unmarshalTracesRequest(msg)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error())
+		}
+		_, err = tracesReceiver.Export(ctx, otlpReq)
+		return wsStatusFromError(err)
+	}
+}
+
+func wsExportMetrics(metricsReceiver *metrics.Receiver) wsExportFunc {
+	return func(ctx context.Context, msg []byte) *status.Status {
+		otlpReq, err := pbEncoder.unmarshalMetricsRequest(msg)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error())
+		}
+		_, err = metricsReceiver.Export(ctx, otlpReq)
+		return wsStatusFromError(err)
+	}
+}
+
+func wsExportLogs(logsReceiver *logs.Receiver) wsExportFunc {
+	return func(ctx context.Context, msg []byte) *status.Status {
+		otlpReq, err := pbEncoder.unmarshalLogsRequest(msg)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error())
+		}
+		_, err = logsReceiver.Export(ctx, otlpReq)
+		return wsStatusFromError(err)
+	}
+}
+
+// wsStatusFromError returns the status of an export, the receivers already convert the errors to statuses.
+func wsStatusFromError(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+	return status.New(codes.Unknown, err.Error())
+}
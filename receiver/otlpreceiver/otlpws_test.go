@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newWebSocketReceiver(t *testing.T, endpoint string, c *errOrSinkConsumer) *otlpReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP = nil
+	cfg.WebSocket = defaultWebSocketConfig()
+	cfg.WebSocket.Endpoint = endpoint
+	return newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, c).(*otlpReceiver)
+}
+
+func wsRoundTrip(t *testing.T, conn *websocket.Conn, msgType int, msg []byte) *spb.Status {
+	require.NoError(t, conn.WriteMessage(msgType, msg))
+	rspType, rsp, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, rspType)
+	st := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(rsp, st))
+	return st
+}
+
+func TestWebSocket(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	r := newWebSocketReceiver(t, addr, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	for _, dr := range generateDataRequests(t) {
+		t.Run(dr.path, func(t *testing.T) {
+			sink.Reset()
+			// The messages are compressed when the client enables the per-message deflate extension.
+			dialer := websocket.Dialer{EnableCompression: true}
+			conn, resp, err := dialer.Dial("ws://"+addr+dr.path, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			assert.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+			assert.Equal(t, int32(codes.OK), wsRoundTrip(t, conn, websocket.BinaryMessage, dr.protoBytes).Code)
+			sink.checkData(t, dr.data, 1)
+
+			// The requests of a connection are answered in order, the connection is kept on errors.
+			sink.SetConsumeError(errors.New("temporary failure"))
+			assert.Equal(t, int32(codes.Unavailable), wsRoundTrip(t, conn, websocket.BinaryMessage, dr.protoBytes).Code)
+			sink.SetConsumeError(consumererror.NewPermanent(errors.New("permanent failure")))
+			assert.Equal(t, int32(codes.Internal), wsRoundTrip(t, conn, websocket.BinaryMessage, dr.protoBytes).Code)
+			assert.Equal(t, int32(codes.InvalidArgument), wsRoundTrip(t, conn, websocket.BinaryMessage, []byte("invalid")).Code)
+			assert.Equal(t, int32(codes.InvalidArgument), wsRoundTrip(t, conn, websocket.TextMessage, dr.jsonBytes).Code)
+			sink.checkData(t, dr.data, 1)
+		})
+	}
+}
+
+func TestWebSocketShutdown(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	r := newWebSocketReceiver(t, addr, newErrOrSinkConsumer())
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+addr+defaultTracesURLPath, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The connections are closed on shutdown, even if they are not tracked by the HTTP server.
+	require.NoError(t, r.Shutdown(context.Background()))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+}
+
+// blockingConsumer blocks the exports of the traces until release is closed.
+type blockingConsumer struct {
+	*errOrSinkConsumer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (bc *blockingConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	close(bc.started)
+	<-bc.release
+	return bc.errOrSinkConsumer.ConsumeTraces(ctx, td)
+}
+
+func TestWebSocketShutdownContext(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	bc := &blockingConsumer{errOrSinkConsumer: newErrOrSinkConsumer(), started: make(chan struct{}), release: make(chan struct{})}
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP = nil
+	cfg.WebSocket = defaultWebSocketConfig()
+	cfg.WebSocket.Endpoint = addr
+	r := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, bc).(*otlpReceiver)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+addr+defaultTracesURLPath, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The connection is served until the export of its request completes, Shutdown gives up when ctx is done.
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, generateTracesRequest(t).protoBytes))
+	<-bc.started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Shutdown(ctx), context.DeadlineExceeded)
+	close(bc.release)
+	assert.Eventually(t, func() bool { return len(bc.AllTraces()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestWebSocketUnknownPath(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	r := newWebSocketReceiver(t, addr, newErrOrSinkConsumer())
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws://"+addr+"/v1/unknown", nil)
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/receiver"
 )
@@ -25,6 +26,7 @@ const (
 
 // ObsReport is a helper to add observability to a receiver.
 type ObsReport struct {
+	receiverID     component.ID
 	level          configtelemetry.Level
 	spanNamePrefix string
 	transport      string
@@ -41,6 +43,7 @@ type ObsReport struct {
 	refusedMetricPointsCounter  metric.Int64Counter
 	acceptedLogRecordsCounter   metric.Int64Counter
 	refusedLogRecordsCounter    metric.Int64Counter
+	refusedRequestsCounter      metric.Int64Counter
 }
 
 // ObsReportSettings are settings for creating an ObsReport.
@@ -63,6 +66,7 @@ func NewObsReport(cfg ObsReportSettings) (*ObsReport, error) {
 
 func newReceiver(cfg ObsReportSettings) (*ObsReport, error) {
 	rec := &ObsReport{
+		receiverID:     cfg.ReceiverID,
 		level:          cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel,
 		spanNamePrefix: obsmetrics.ReceiverPrefix + cfg.ReceiverID.String(),
 		transport:      cfg.Transport,
@@ -129,6 +133,13 @@ func (rec *ObsReport) createOtelMetrics() error {
 	)
 	errors = multierr.Append(errors, err)
 
+	rec.refusedRequestsCounter, err = rec.meter.Int64Counter(
+		obsmetrics.ReceiverMetricPrefix+obsmetrics.RefusedRequestsKey,
+		metric.WithDescription("Number of requests that could not be pushed into the pipeline, by error category."),
+		metric.WithUnit("1"),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -187,7 +198,7 @@ func (rec *ObsReport) EndMetricsOp(
 }
 
 // startOp creates the span used to trace the operation. Returning
-// the updated context with the created span.
+// the updated context with the created span and the ID of the receiver.
 func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
 	var ctx context.Context
 	var span trace.Span
@@ -208,7 +219,7 @@ func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix strin
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(obsmetrics.TransportKey, rec.transport))
 	}
-	return ctx
+	return receiver.ContextWithReceiver(ctx, rec.receiverID)
 }
 
 // endOp records the observability signals at the end of an operation.
@@ -229,7 +240,7 @@ func (rec *ObsReport) endOp(
 	span := trace.SpanFromContext(receiverCtx)
 
 	if rec.level != configtelemetry.LevelNone {
-		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused, err)
 	}
 
 	// end span according to errors
@@ -259,7 +270,7 @@ func (rec *ObsReport) endOp(
 	span.End()
 }
 
-func (rec *ObsReport) recordMetrics(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
+func (rec *ObsReport) recordMetrics(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int, err error) {
 	var acceptedMeasure, refusedMeasure metric.Int64Counter
 	switch dataType {
 	case component.DataTypeTraces:
@@ -275,4 +286,10 @@ func (rec *ObsReport) recordMetrics(receiverCtx context.Context, dataType compon
 
 	acceptedMeasure.Add(receiverCtx, int64(numAccepted), metric.WithAttributes(rec.otelAttrs...))
 	refusedMeasure.Add(receiverCtx, int64(numRefused), metric.WithAttributes(rec.otelAttrs...))
+	if err != nil {
+		attrs := make([]attribute.KeyValue, 0, len(rec.otelAttrs)+1)
+		attrs = append(attrs, rec.otelAttrs...)
+		attrs = append(attrs, attribute.String(obsmetrics.ErrorCategoryKey, consumererror.Classify(err).String()))
+		rec.refusedRequestsCounter.Add(receiverCtx, 1, metric.WithAttributes(attrs...))
+	}
 }
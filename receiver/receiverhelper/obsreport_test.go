@@ -12,11 +12,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
 const (
@@ -53,6 +58,9 @@ func TestReceiveTraceDataOp(t *testing.T) {
 			require.NoError(t, err)
 			ctx := rec.StartTracesOp(parentCtx)
 			assert.NotNil(t, ctx)
+			gotID, ok := receiver.ReceiverFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, receiverID, gotID)
 			rec.EndTracesOp(ctx, format, params[i].items, param.err)
 		}
 
@@ -233,6 +241,38 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
+func TestReceiveRefusedRequestsByCategory(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := receivertest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelNormal
+
+	rec, err := NewObsReport(ObsReportSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: set})
+	require.NoError(t, err)
+	rec.EndTracesOp(rec.StartTracesOp(context.Background()), format, 7, nil)
+	rec.EndTracesOp(rec.StartTracesOp(context.Background()), format, 7, errFake)
+	rec.EndMetricsOp(rec.StartMetricsOp(context.Background()), format, 7, consumererror.NewPermanent(errFake))
+	rec.EndLogsOp(rec.StartLogsOp(context.Background()), format, 7, consumererror.NewShutdown(errFake))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	refused := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != obsmetrics.ReceiverMetricPrefix+obsmetrics.RefusedRequestsKey {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				transportAttr, _ := dp.Attributes.Value(obsmetrics.TransportKey)
+				assert.Equal(t, transport, transportAttr.AsString())
+				category, _ := dp.Attributes.Value(obsmetrics.ErrorCategoryKey)
+				refused[category.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"retryable": 1, "permanent": 1, "shutdown": 1}, refused)
+}
+
 func TestCheckReceiverTracesViews(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(receiverID)
 	require.NoError(t, err)
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit defines the configuration of the data flow audit log of the service.
+package audit // import "go.opentelemetry.io/collector/service/audit"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines the configuration of the data flow audit log, which periodically logs the number of
+// items received by each receiver and delivered by each exporter.
+type Config struct {
+	// Enabled enables the audit log.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the period of the audit log entries. If not set, defaults to 1 minute.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// TenantMetadataKey is the client metadata key, e.g. "x-tenant", whose value breaks down the counts
+	// by tenant. The receivers must include the metadata, see client.Info. Optional.
+	TenantMetadataKey string `mapstructure:"tenant_metadata_key"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Interval < 0 {
+		return errors.New("interval must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{Enabled: true}
+	assert.NoError(t, cfg.Validate())
+	cfg.Interval = time.Second
+	assert.NoError(t, cfg.Validate())
+	cfg.Interval = -time.Second
+	assert.EqualError(t, cfg.Validate(), "interval must be non-negative")
+}
@@ -6,8 +6,12 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"fmt"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/audit"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/panicisolation"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/resource"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -21,6 +25,27 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// MinStabilityLevel is the lowest stability level, e.g. "beta", allowed for the configured components.
+	// The service refuses to start if any extension or pipeline component has a lower stability level.
+	// If not set, components of any stability level are allowed.
+	MinStabilityLevel component.StabilityLevel `mapstructure:"min_stability_level"`
+
+	// Audit is the configuration of the data flow audit log, which periodically logs the number of items
+	// received by each receiver and delivered by each exporter.
+	Audit audit.Config `mapstructure:"audit"`
+
+	// DryRun replaces the exporters of the pipelines by sinks counting the items they receive, so that the
+	// data flow and the processors can be validated against real traffic without exporting any data.
+	DryRun bool `mapstructure:"dry_run"`
+
+	// Resource defines the resource attributes identifying the deployment of the collector, added to its
+	// own telemetry and optionally to the data entering the pipelines.
+	Resource resource.Config `mapstructure:"resource"`
+
+	// PanicIsolation recovers from the panics of the processors and the exporters consuming data, so that they
+	// fail the data instead of crashing the collector, and restarts the panicking components if configured.
+	PanicIsolation panicisolation.Config `mapstructure:"panic_isolation"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,6 +53,18 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.Audit.Validate(); err != nil {
+		return fmt.Errorf("service::audit config validation failed: %w", err)
+	}
+
+	if err := cfg.Resource.Validate(); err != nil {
+		return fmt.Errorf("service::resource config validation failed: %w", err)
+	}
+
+	if err := cfg.PanicIsolation.Validate(); err != nil {
+		return fmt.Errorf("service::panic_isolation config validation failed: %w", err)
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
@@ -61,6 +61,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`service::pipelines config validation failed: %w`, errors.New(`pipeline "wrongtype": unknown datatype "wrongtype"`)),
 		},
+		{
+			name: "invalid-service-resource",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Resource.Attributes = map[string]string{"": "value"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::resource config validation failed: %w`, errors.New(`attribute keys must not be empty`)),
+		},
+		{
+			name: "invalid-service-panic-isolation",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.PanicIsolation.MaxRestarts = -1
+				return cfg
+			},
+			expected: fmt.Errorf(`service::panic_isolation config validation failed: %w`, errors.New(`max_restarts must be non-negative`)),
+		},
 		{
 			name: "invalid-telemetry-metric-config",
 			cfgFn: func() *Config {
@@ -168,6 +168,9 @@ type Settings struct {
 
 	// Extensions builder for extensions.
 	Extensions *extension.Builder
+
+	// MinStabilityLevel is the lowest stability level allowed for the extensions.
+	MinStabilityLevel component.StabilityLevel
 }
 
 // New creates a new Extensions from Config.
@@ -190,6 +193,11 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 		}
 		extSet.TelemetrySettings.Logger = components.ExtensionLogger(set.Telemetry.Logger, extID)
 
+		if f, ok := set.Extensions.Factory(extID.Type()).(extension.Factory); ok && f.ExtensionStability() < set.MinStabilityLevel {
+			return nil, fmt.Errorf("extension %q has stability level %q, lower than the minimum allowed %q",
+				extID, f.ExtensionStability(), set.MinStabilityLevel)
+		}
+
 		ext, err := set.Extensions.Create(ctx, extSet)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create extension %q: %w", extID, err)
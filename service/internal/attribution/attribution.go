@@ -0,0 +1,389 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attribution attributes the CPU time and the allocations of the collector to the processors
+// and the exporters. The data consumed by the components is labeled with pprof labels, and CPU profiles
+// are periodically sampled to measure the CPU time spent with each label. The bytes allocated while the
+// components consume data are measured during the same sampling windows.
+//
+// Only the work done while consuming the data is attributed: the work done by the goroutines started by
+// the components, e.g. by the sending queue of the exporters or by the batch processor, is not.
+package attribution // import "go.opentelemetry.io/collector/service/internal/attribution"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime/metrics"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/service/attribution"
+
+	// The pprof labels set while a component consumes data, also visible in the profiles
+	// collected with the pprof extension.
+	labelKind     = "otel.component.kind"
+	labelName     = "otel.component.name"
+	labelPipeline = "otel.pipeline"
+
+	// heapAllocsMetric is the cumulative number of bytes allocated in the heap.
+	heapAllocsMetric = "/gc/heap/allocs:bytes"
+
+	defaultInterval = time.Minute
+	defaultDuration = 5 * time.Second
+)
+
+type componentKey struct {
+	kind     string
+	name     string
+	pipeline string
+}
+
+func (k componentKey) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("kind", k.kind), attribute.String("name", k.name)}
+	if k.pipeline != "" {
+		attrs = append(attrs, attribute.String("pipeline", k.pipeline))
+	}
+	return attrs
+}
+
+// Labels identify a component in the profiles and in the attribution metrics.
+type Labels struct {
+	key componentKey
+	set pprof.LabelSet
+}
+
+// ProcessorLabels returns the labels identifying the processor of the pipeline.
+func ProcessorLabels(id component.ID, pipelineID component.ID) Labels {
+	key := componentKey{kind: strings.ToLower(component.KindProcessor.String()), name: id.String(), pipeline: pipelineID.String()}
+	return Labels{key: key, set: pprof.Labels(labelKind, key.kind, labelName, key.name, labelPipeline, key.pipeline)}
+}
+
+// ExporterLabels returns the labels identifying the exporter. The pipeline label set by the
+// processor calling the exporter is cleared, as the exporters are shared by the pipelines.
+func ExporterLabels(id component.ID) Labels {
+	key := componentKey{kind: strings.ToLower(component.KindExporter.String()), name: id.String()}
+	return Labels{key: key, set: pprof.Labels(labelKind, key.kind, labelName, key.name, labelPipeline, "")}
+}
+
+// Profiler periodically samples CPU profiles and allocations, and reports the CPU time and the
+// allocated bytes of the components.
+type Profiler struct {
+	logger   *zap.Logger
+	interval time.Duration
+	duration time.Duration
+
+	// sampling is set during the sampling windows, while the allocations of the components are measured.
+	sampling atomic.Bool
+
+	mu sync.Mutex
+	// cpu holds the estimated CPU seconds spent by each component.
+	cpu map[componentKey]float64
+	// allocs holds the estimated bytes allocated by each component.
+	allocs map[componentKey]float64
+	// sampledAllocs holds the bytes allocated by each component during the current sampling window.
+	sampledAllocs map[componentKey]uint64
+	// profilerActive is set while the CPU profiles cannot be collected, as another CPU profile is being collected.
+	profilerActive bool
+
+	startCPUProfile func(io.Writer) error
+	stopCPUProfile  func()
+	heapAllocs      func() uint64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewProfiler returns a Profiler reporting the CPU time and the allocations of the components with the meter provider.
+func NewProfiler(cfg telemetry.AttributionConfig, mp otelmetric.MeterProvider, logger *zap.Logger) (*Profiler, error) {
+	p := &Profiler{
+		logger:          logger,
+		interval:        cfg.Interval,
+		duration:        cfg.Duration,
+		cpu:             map[componentKey]float64{},
+		allocs:          map[componentKey]float64{},
+		sampledAllocs:   map[componentKey]uint64{},
+		startCPUProfile: pprof.StartCPUProfile,
+		stopCPUProfile:  pprof.StopCPUProfile,
+		heapAllocs:      readHeapAllocs,
+		stopCh:          make(chan struct{}),
+	}
+	if p.interval == 0 {
+		p.interval = defaultInterval
+	}
+	if p.duration == 0 {
+		p.duration = defaultDuration
+	}
+	if p.duration >= p.interval {
+		return nil, errors.New("the attribution duration must be lower than the interval")
+	}
+
+	meter := mp.Meter(scopeName)
+	_, err := meter.Float64ObservableCounter(
+		"component_cpu_seconds",
+		otelmetric.WithDescription("Estimated CPU time spent by the component consuming data, sampled from CPU profiles"),
+		otelmetric.WithUnit("s"),
+		otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			for k, v := range p.cpu {
+				o.Observe(v, otelmetric.WithAttributes(k.attributes()...))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	_, err = meter.Float64ObservableCounter(
+		"component_allocated_bytes",
+		otelmetric.WithDescription("Estimated bytes allocated while the component consumes data, sampled periodically"),
+		otelmetric.WithUnit("By"),
+		otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			for k, v := range p.allocs {
+				o.Observe(v, otelmetric.WithAttributes(k.attributes()...))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// readHeapAllocs returns the cumulative number of bytes allocated in the heap by the process.
+func readHeapAllocs() uint64 {
+	sample := []metrics.Sample{{Name: heapAllocsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// Start starts sampling the CPU profiles and the allocations periodically.
+func (p *Profiler) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval - p.duration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.sample()
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops sampling the CPU profiles and the allocations.
+func (p *Profiler) Shutdown() {
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+// sample collects a CPU profile of p.duration and measures the allocations of the components during it, and
+// adds the CPU time and the allocations of the components, extrapolated to the whole interval, to their totals.
+// The CPU time is not sampled if another CPU profile is being collected, e.g. with the pprof extension.
+func (p *Profiler) sample() {
+	var buf bytes.Buffer
+	cpuErr := p.startCPUProfile(&buf)
+	p.reportCPUProfileError(cpuErr)
+	p.sampling.Store(true)
+
+	timer := time.NewTimer(p.duration)
+	stopped := false
+	select {
+	case <-timer.C:
+	case <-p.stopCh:
+		timer.Stop()
+		stopped = true
+	}
+	p.sampling.Store(false)
+	if cpuErr == nil {
+		p.stopCPUProfile()
+	}
+
+	p.mu.Lock()
+	sampledAllocs := p.sampledAllocs
+	p.sampledAllocs = map[componentKey]uint64{}
+	p.mu.Unlock()
+	if stopped {
+		return
+	}
+
+	var cpu map[componentKey]int64
+	if cpuErr == nil {
+		var err error
+		if cpu, err = cpuByComponent(buf.Bytes()); err != nil {
+			p.logger.Debug("Failed to parse the CPU profile attributing the CPU time to the components", zap.Error(err))
+		}
+	}
+	ratio := float64(p.interval) / float64(p.duration)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k, ns := range cpu {
+		p.cpu[k] += float64(ns) / 1e9 * ratio
+	}
+	for k, allocated := range sampledAllocs {
+		p.allocs[k] += float64(allocated) * ratio
+	}
+}
+
+// reportCPUProfileError reports the error starting the CPU profile, which fails while another CPU profile
+// is being collected. The error is logged once until a CPU profile is collected again.
+func (p *Profiler) reportCPUProfileError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		p.profilerActive = false
+		return
+	}
+	if p.profilerActive {
+		p.logger.Debug("Skipping the attribution of the CPU time to the components", zap.Error(err))
+		return
+	}
+	p.profilerActive = true
+	p.logger.Error("Skipping the attribution of the CPU time to the components, another CPU profile is being collected, "+
+		"e.g. with the pprof extension", zap.Error(err))
+}
+
+type allocFrameKey struct{}
+
+// allocFrame holds the bytes allocated by the labeled consumers called by the consumer being measured,
+// which are not attributed to it.
+type allocFrame struct {
+	nested atomic.Uint64
+}
+
+// consume calls consume with the pprof labels set, and measures the bytes allocated by the component
+// during the sampling windows. The measure is an estimate, the allocations of the goroutines running
+// concurrently being included.
+func (p *Profiler) consume(ctx context.Context, labels Labels, consume func(context.Context)) {
+	if !p.sampling.Load() {
+		pprof.Do(ctx, labels.set, consume)
+		return
+	}
+	parent, _ := ctx.Value(allocFrameKey{}).(*allocFrame)
+	frame := &allocFrame{}
+	ctx = context.WithValue(ctx, allocFrameKey{}, frame)
+	start := p.heapAllocs()
+	pprof.Do(ctx, labels.set, consume)
+	total := p.heapAllocs() - start
+	if parent != nil {
+		parent.nested.Add(total)
+	}
+	// The consumers called by this one are attributed their own allocations.
+	own := uint64(0)
+	if nested := frame.nested.Load(); nested < total {
+		own = total - nested
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sampledAllocs[labels.key] += own
+}
+
+// Traces returns a consumer.Traces consuming with next, with the pprof labels set.
+func (p *Profiler) Traces(labels Labels, next consumer.Traces) consumer.Traces {
+	return labeledTraces{Traces: next, labels: labels, profiler: p}
+}
+
+type labeledTraces struct {
+	consumer.Traces
+	labels   Labels
+	profiler *Profiler
+}
+
+func (lt labeledTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var err error
+	lt.profiler.consume(ctx, lt.labels, func(ctx context.Context) {
+		err = lt.Traces.ConsumeTraces(ctx, td)
+	})
+	return err
+}
+
+func (lt labeledTraces) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	var rsp consumer.Response
+	var err error
+	lt.profiler.consume(ctx, lt.labels, func(ctx context.Context) {
+		rsp, err = consumer.ConsumeTracesWithResponse(ctx, lt.Traces, td)
+	})
+	return rsp, err
+}
+
+// Metrics returns a consumer.Metrics consuming with next, with the pprof labels set.
+func (p *Profiler) Metrics(labels Labels, next consumer.Metrics) consumer.Metrics {
+	return labeledMetrics{Metrics: next, labels: labels, profiler: p}
+}
+
+type labeledMetrics struct {
+	consumer.Metrics
+	labels   Labels
+	profiler *Profiler
+}
+
+func (lm labeledMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var err error
+	lm.profiler.consume(ctx, lm.labels, func(ctx context.Context) {
+		err = lm.Metrics.ConsumeMetrics(ctx, md)
+	})
+	return err
+}
+
+func (lm labeledMetrics) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	var rsp consumer.Response
+	var err error
+	lm.profiler.consume(ctx, lm.labels, func(ctx context.Context) {
+		rsp, err = consumer.ConsumeMetricsWithResponse(ctx, lm.Metrics, md)
+	})
+	return rsp, err
+}
+
+// Logs returns a consumer.Logs consuming with next, with the pprof labels set.
+func (p *Profiler) Logs(labels Labels, next consumer.Logs) consumer.Logs {
+	return labeledLogs{Logs: next, labels: labels, profiler: p}
+}
+
+type labeledLogs struct {
+	consumer.Logs
+	labels   Labels
+	profiler *Profiler
+}
+
+func (ll labeledLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var err error
+	ll.profiler.consume(ctx, ll.labels, func(ctx context.Context) {
+		err = ll.Logs.ConsumeLogs(ctx, ld)
+	})
+	return err
+}
+
+func (ll labeledLogs) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	var rsp consumer.Response
+	var err error
+	ll.profiler.consume(ctx, ll.labels, func(ctx context.Context) {
+		rsp, err = consumer.ConsumeLogsWithResponse(ctx, ll.Logs, ld)
+	})
+	return rsp, err
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attribution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+var (
+	procID     = component.MustNewID("batch")
+	expID      = component.MustNewID("otlp")
+	pipelineID = component.MustNewID("traces")
+)
+
+// busy spins for the duration, to be sampled by the CPU profiles.
+func busy(d time.Duration) {
+	for start := time.Now(); time.Since(start) < d; {
+		for i := 0; i < 1000; i++ {
+			_ = i * i
+		}
+	}
+}
+
+// busyProfile returns a CPU profile of a processor and an exporter spinning.
+func busyProfile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, pprof.StartCPUProfile(&buf))
+	pprof.Do(context.Background(), ProcessorLabels(procID, pipelineID).set, func(ctx context.Context) {
+		busy(200 * time.Millisecond)
+		pprof.Do(ctx, ExporterLabels(expID).set, func(context.Context) {
+			busy(200 * time.Millisecond)
+		})
+	})
+	pprof.StopCPUProfile()
+	return buf.Bytes()
+}
+
+func TestCPUByComponent(t *testing.T) {
+	cpu, err := cpuByComponent(busyProfile(t))
+	require.NoError(t, err)
+	assert.Positive(t, cpu[componentKey{kind: "processor", name: "batch", pipeline: "traces"}])
+	// The pipeline label of the processor is cleared by the exporter.
+	assert.Positive(t, cpu[componentKey{kind: "exporter", name: "otlp"}])
+	assert.Zero(t, cpu[componentKey{kind: "exporter", name: "otlp", pipeline: "traces"}])
+}
+
+func TestCPUByComponentErrors(t *testing.T) {
+	_, err := cpuByComponent([]byte("not gzipped"))
+	assert.ErrorContains(t, err, "failed to decompress the profile")
+
+	profile := busyProfile(t)
+	_, err = cpuByComponent(profile[:len(profile)/2])
+	assert.Error(t, err)
+}
+
+func TestNewProfilerInvalidDuration(t *testing.T) {
+	_, err := NewProfiler(telemetry.AttributionConfig{Interval: time.Second, Duration: time.Second}, sdkmetric.NewMeterProvider(), zap.NewNop())
+	assert.EqualError(t, err, "the attribution duration must be lower than the interval")
+}
+
+func TestProfilerSample(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	p, err := NewProfiler(telemetry.AttributionConfig{Interval: time.Second, Duration: 500 * time.Millisecond},
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, p.interval)
+
+	profile := busyProfile(t)
+	p.startCPUProfile = func(w io.Writer) error {
+		_, err := w.Write(profile)
+		return err
+	}
+	p.stopCPUProfile = func() {}
+	p.duration = time.Millisecond
+	p.sample()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "component_cpu_seconds", m.Name)
+	sum, ok := m.Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 2)
+	for _, dp := range sum.DataPoints {
+		kind, _ := dp.Attributes.Value("kind")
+		_, hasPipeline := dp.Attributes.Value("pipeline")
+		assert.Equal(t, kind == attribute.StringValue("processor"), hasPipeline)
+		// The CPU time is extrapolated to the whole interval.
+		assert.Greater(t, dp.Value, 1.0)
+	}
+}
+
+func TestProfilerSampleAllocations(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	p, err := NewProfiler(telemetry.AttributionConfig{Interval: time.Second, Duration: 500 * time.Millisecond},
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), zap.NewNop())
+	require.NoError(t, err)
+	var allocated uint64
+	p.heapAllocs = func() uint64 { return allocated }
+	p.duration = 100 * time.Millisecond
+
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		allocated += 30
+		return nil
+	})
+	require.NoError(t, err)
+	exp := p.Traces(ExporterLabels(expID), next)
+	next, err = consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		allocated += 100
+		err := exp.ConsumeTraces(ctx, td)
+		allocated += 50
+		return err
+	})
+	require.NoError(t, err)
+	proc := p.Traces(ProcessorLabels(procID, pipelineID), next)
+
+	// The allocations are only measured during the sampling windows.
+	require.NoError(t, proc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.sample()
+	}()
+	require.Eventually(t, p.sampling.Load, time.Second, time.Millisecond)
+	require.NoError(t, proc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	<-done
+
+	// The allocations of the exporter are not attributed to the processor calling it, and are
+	// extrapolated to the whole interval.
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "component_allocated_bytes", m.Name)
+	sum, ok := m.Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	values := map[attribute.Set]float64{}
+	for _, dp := range sum.DataPoints {
+		values[dp.Attributes] = dp.Value
+	}
+	assert.Equal(t, map[attribute.Set]float64{
+		attribute.NewSet(attribute.String("kind", "processor"), attribute.String("name", "batch"), attribute.String("pipeline", "traces")): 1500,
+		attribute.NewSet(attribute.String("kind", "exporter"), attribute.String("name", "otlp")):                                           300,
+	}, values)
+}
+
+func TestProfilerSampleError(t *testing.T) {
+	p, err := NewProfiler(telemetry.AttributionConfig{}, sdkmetric.NewMeterProvider(), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, defaultInterval, p.interval)
+	assert.Equal(t, defaultDuration, p.duration)
+
+	p.duration = time.Millisecond
+	core, observed := observer.New(zap.DebugLevel)
+	p.logger = zap.New(core)
+
+	// The error is reported once while the CPU profiles cannot be collected.
+	p.startCPUProfile = func(io.Writer) error { return errors.New("cpu profiling already in use") }
+	p.sample()
+	p.sample()
+	assert.Empty(t, p.cpu)
+	assert.Equal(t, 1, observed.FilterLevelExact(zap.ErrorLevel).Len())
+	assert.Equal(t, 1, observed.FilterLevelExact(zap.DebugLevel).Len())
+
+	profile := busyProfile(t)
+	p.startCPUProfile = func(w io.Writer) error {
+		_, err := w.Write(profile)
+		return err
+	}
+	p.stopCPUProfile = func() {}
+	p.sample()
+	assert.NotEmpty(t, p.cpu)
+	p.startCPUProfile = func(io.Writer) error { return errors.New("cpu profiling already in use") }
+	p.sample()
+	assert.Equal(t, 2, observed.FilterLevelExact(zap.ErrorLevel).Len())
+}
+
+func TestProfilerStartShutdown(t *testing.T) {
+	p, err := NewProfiler(telemetry.AttributionConfig{Interval: 20 * time.Millisecond, Duration: 10 * time.Millisecond},
+		sdkmetric.NewMeterProvider(), zap.NewNop())
+	require.NoError(t, err)
+	p.Start()
+	time.Sleep(50 * time.Millisecond)
+	p.Shutdown()
+}
+
+func TestLabeledConsumers(t *testing.T) {
+	var labels []string
+	record := func(ctx context.Context) {
+		kind, _ := pprof.Label(ctx, labelKind)
+		name, _ := pprof.Label(ctx, labelName)
+		pipeline, _ := pprof.Label(ctx, labelPipeline)
+		labels = append(labels, kind+"/"+name+"/"+pipeline)
+	}
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		record(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	mc, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		record(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	want := errors.New("failed")
+	lc, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		record(ctx)
+		return want
+	})
+	require.NoError(t, err)
+
+	p, err := NewProfiler(telemetry.AttributionConfig{}, sdkmetric.NewMeterProvider(), zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Traces(ProcessorLabels(procID, pipelineID), tc).ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, p.Metrics(ExporterLabels(expID), mc).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Equal(t, want, p.Logs(ExporterLabels(expID), lc).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, []string{"processor/batch/traces", "exporter/otlp/", "exporter/otlp/"}, labels)
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attribution // import "go.opentelemetry.io/collector/service/internal/attribution"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The fields of the messages of the pprof profile format used to attribute the CPU time,
+// see https://github.com/google/pprof/blob/main/proto/profile.proto.
+const (
+	profileSampleType  = 1
+	profileSample      = 2
+	profileStringTable = 6
+
+	valueTypeType = 1
+
+	sampleValue = 2
+	sampleLabel = 3
+
+	labelKeyField = 1
+	labelStrField = 2
+)
+
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireLen    = 2
+	wireI32    = 5
+)
+
+var errTruncated = errors.New("truncated profile")
+
+// sampleLabels are the string labels of a sample, as indexes in the string table.
+type sampleLabels map[int64]int64
+
+type rawSample struct {
+	values []int64
+	labels sampleLabels
+}
+
+// cpuByComponent parses the gzipped CPU profile, and returns the CPU nanoseconds of the samples
+// grouped by the component identified by their labels. The samples without labels are ignored.
+func cpuByComponent(gzipped []byte) (map[componentKey]int64, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(gzipped))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the profile: %w", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the profile: %w", err)
+	}
+
+	var sampleTypes []int64
+	var samples []rawSample
+	var strs []string
+	err = forEachField(data, func(num int, wireType int, v uint64, b []byte) error {
+		switch {
+		case num == profileSampleType && wireType == wireLen:
+			var typ int64
+			err := forEachField(b, func(num int, wireType int, v uint64, _ []byte) error {
+				if num == valueTypeType && wireType == wireVarint {
+					typ = int64(v)
+				}
+				return nil
+			})
+			sampleTypes = append(sampleTypes, typ)
+			return err
+		case num == profileSample && wireType == wireLen:
+			s, err := parseSample(b)
+			samples = append(samples, s)
+			return err
+		case num == profileStringTable && wireType == wireLen:
+			strs = append(strs, string(b))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	str := func(i int64) string {
+		if i < 0 || i >= int64(len(strs)) {
+			return ""
+		}
+		return strs[i]
+	}
+	cpuIndex := -1
+	for i, typ := range sampleTypes {
+		if str(typ) == "cpu" {
+			cpuIndex = i
+		}
+	}
+	if cpuIndex < 0 {
+		return nil, errors.New("the profile has no cpu sample type")
+	}
+
+	res := map[componentKey]int64{}
+	for _, s := range samples {
+		if cpuIndex >= len(s.values) {
+			continue
+		}
+		var key componentKey
+		for k, v := range s.labels {
+			switch str(k) {
+			case labelKind:
+				key.kind = str(v)
+			case labelName:
+				key.name = str(v)
+			case labelPipeline:
+				key.pipeline = str(v)
+			}
+		}
+		if key.kind != "" && key.name != "" {
+			res[key] += s.values[cpuIndex]
+		}
+	}
+	return res, nil
+}
+
+func parseSample(b []byte) (rawSample, error) {
+	s := rawSample{labels: sampleLabels{}}
+	err := forEachField(b, func(num int, wireType int, v uint64, b []byte) error {
+		switch {
+		case num == sampleValue && wireType == wireVarint:
+			s.values = append(s.values, int64(v))
+		case num == sampleValue && wireType == wireLen:
+			// Packed repeated values.
+			for len(b) > 0 {
+				v, n := binary.Uvarint(b)
+				if n <= 0 {
+					return errTruncated
+				}
+				s.values = append(s.values, int64(v))
+				b = b[n:]
+			}
+		case num == sampleLabel && wireType == wireLen:
+			var key, str int64
+			err := forEachField(b, func(num int, wireType int, v uint64, _ []byte) error {
+				switch {
+				case num == labelKeyField && wireType == wireVarint:
+					key = int64(v)
+				case num == labelStrField && wireType == wireVarint:
+					str = int64(v)
+				}
+				return nil
+			})
+			if str != 0 {
+				s.labels[key] = str
+			}
+			return err
+		}
+		return nil
+	})
+	return s, err
+}
+
+// forEachField calls fn with each field of the protobuf message: v holds the value of the varint fields,
+// and b the bytes of the length delimited fields.
+func forEachField(data []byte, fn func(num int, wireType int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		num, wireType := int(tag>>3), int(tag&7)
+
+		var v uint64
+		var b []byte
+		switch wireType {
+		case wireVarint:
+			v, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireI64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			data = data[8:]
+		case wireI32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			data = data[4:]
+		case wireLen:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < l {
+				return errTruncated
+			}
+			b = data[n : n+int(l)]
+			data = data[n+int(l):]
+		default:
+			return fmt.Errorf("unsupported wire type %d in profile", wireType)
+		}
+		if err := fn(num, wireType, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
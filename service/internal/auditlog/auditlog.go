@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auditlog counts the items received by the receivers and delivered by the exporters,
+// and periodically logs the counts to prove how the data is routed.
+//
+// The exporters built with the exporterhelper report the items they delivered to the backends, once
+// the export succeeded or definitively failed after the retries, see exporter.DeliveryObserver.
+package auditlog // import "go.opentelemetry.io/collector/service/internal/auditlog"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/audit"
+)
+
+// Entry is the count of the items of a signal that went through a component, for a tenant.
+type Entry struct {
+	Component string `json:"component"`
+	Signal    string `json:"signal"`
+	Tenant    string `json:"tenant,omitempty"`
+	// Items is the number of items, i.e. spans, metric data points or log records, successfully consumed.
+	Items int64 `json:"items"`
+	// Failed is the number of items whose consumption failed.
+	Failed int64 `json:"failed,omitempty"`
+}
+
+type key struct {
+	kind     component.Kind
+	id       component.ID
+	dataType component.DataType
+	tenant   string
+}
+
+type counts struct {
+	items  int64
+	failed int64
+}
+
+// Tracker counts the items going through the receivers and the exporters.
+type Tracker struct {
+	logger    *zap.Logger
+	interval  time.Duration
+	tenantKey string
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[key]*counts
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+var _ exporter.DeliveryObserver = (*Tracker)(nil)
+
+const defaultInterval = time.Minute
+
+// NewTracker returns a Tracker logging the counts to the logger.
+func NewTracker(cfg audit.Config, logger *zap.Logger) *Tracker {
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	return &Tracker{
+		logger:    logger,
+		interval:  interval,
+		tenantKey: cfg.TenantMetadataKey,
+		start:     time.Now(),
+		counts:    make(map[key]*counts),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start starts logging the counts periodically.
+func (t *Tracker) Start() {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.Flush()
+			case <-t.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops logging the counts periodically, and logs the counts of the last interval.
+// It may be called several times, only the first call has an effect.
+func (t *Tracker) Shutdown() {
+	t.stopOnce.Do(func() {
+		close(t.stopCh)
+		t.wg.Wait()
+		t.Flush()
+	})
+}
+
+// Flush logs the counts since the previous flush and resets them.
+// Nothing is logged if no data went through the components.
+func (t *Tracker) Flush() {
+	t.mu.Lock()
+	start, end, current := t.start, time.Now(), t.counts
+	t.start, t.counts = end, make(map[key]*counts)
+	t.mu.Unlock()
+
+	if len(current) == 0 {
+		return
+	}
+	var received, delivered []Entry
+	for k, c := range current {
+		e := Entry{Component: k.id.String(), Signal: k.dataType.String(), Tenant: k.tenant, Items: c.items, Failed: c.failed}
+		if k.kind == component.KindReceiver {
+			received = append(received, e)
+		} else {
+			delivered = append(delivered, e)
+		}
+	}
+	sortEntries(received)
+	sortEntries(delivered)
+	t.logger.Info("Data flow audit",
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Any("received", received),
+		zap.Any("delivered", delivered))
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Component != entries[j].Component {
+			return entries[i].Component < entries[j].Component
+		}
+		if entries[i].Signal != entries[j].Signal {
+			return entries[i].Signal < entries[j].Signal
+		}
+		return entries[i].Tenant < entries[j].Tenant
+	})
+}
+
+func (t *Tracker) record(ctx context.Context, kind component.Kind, id component.ID, dataType component.DataType, items int, err error) {
+	k := key{kind: kind, id: id, dataType: dataType}
+	if t.tenantKey != "" {
+		metadata := client.FromContext(ctx).Metadata
+		// The batches of the exporters carry the client metadata of their first request.
+		if md, ok := exporterhelper.RequestMetadataFromContext(ctx); ok {
+			metadata = md.ClientMetadata
+		}
+		if values := metadata.Get(t.tenantKey); len(values) > 0 {
+			k.tenant = values[0]
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.counts[k]
+	if !ok {
+		c = &counts{}
+		t.counts[k] = c
+	}
+	if err != nil {
+		c.failed += int64(items)
+	} else {
+		c.items += int64(items)
+	}
+}
+
+// Delivered counts the items exported by an exporter, see exporter.DeliveryObserver.
+func (t *Tracker) Delivered(ctx context.Context, id component.ID, dataType component.DataType, items int, err error) {
+	t.record(ctx, component.KindExporter, id, dataType, items, err)
+}
+
+// Traces returns a consumer.Traces counting the spans consumed by next, on behalf of the component.
+func (t *Tracker) Traces(kind component.Kind, id component.ID, next consumer.Traces) consumer.Traces {
+	return auditTraces{Traces: next, tracker: t, kind: kind, id: id}
+}
+
+type auditTraces struct {
+	consumer.Traces
+	tracker *Tracker
+	kind    component.Kind
+	id      component.ID
+}
+
+func (at auditTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// Count before consuming, next may modify the data.
+	items := td.SpanCount()
+	err := at.Traces.ConsumeTraces(ctx, td)
+	at.tracker.record(ctx, at.kind, at.id, component.DataTypeTraces, items, err)
+	return err
+}
+
+func (at auditTraces) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	items := td.SpanCount()
+	rsp, err := consumer.ConsumeTracesWithResponse(ctx, at.Traces, td)
+	at.tracker.record(ctx, at.kind, at.id, component.DataTypeTraces, items, err)
+	return rsp, err
+}
+
+// Metrics returns a consumer.Metrics counting the data points consumed by next, on behalf of the component.
+func (t *Tracker) Metrics(kind component.Kind, id component.ID, next consumer.Metrics) consumer.Metrics {
+	return auditMetrics{Metrics: next, tracker: t, kind: kind, id: id}
+}
+
+type auditMetrics struct {
+	consumer.Metrics
+	tracker *Tracker
+	kind    component.Kind
+	id      component.ID
+}
+
+func (am auditMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := md.DataPointCount()
+	err := am.Metrics.ConsumeMetrics(ctx, md)
+	am.tracker.record(ctx, am.kind, am.id, component.DataTypeMetrics, items, err)
+	return err
+}
+
+func (am auditMetrics) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	items := md.DataPointCount()
+	rsp, err := consumer.ConsumeMetricsWithResponse(ctx, am.Metrics, md)
+	am.tracker.record(ctx, am.kind, am.id, component.DataTypeMetrics, items, err)
+	return rsp, err
+}
+
+// Logs returns a consumer.Logs counting the log records consumed by next, on behalf of the component.
+func (t *Tracker) Logs(kind component.Kind, id component.ID, next consumer.Logs) consumer.Logs {
+	return auditLogs{Logs: next, tracker: t, kind: kind, id: id}
+}
+
+type auditLogs struct {
+	consumer.Logs
+	tracker *Tracker
+	kind    component.Kind
+	id      component.ID
+}
+
+func (al auditLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	items := ld.LogRecordCount()
+	err := al.Logs.ConsumeLogs(ctx, ld)
+	al.tracker.record(ctx, al.kind, al.id, component.DataTypeLogs, items, err)
+	return err
+}
+
+func (al auditLogs) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	items := ld.LogRecordCount()
+	rsp, err := consumer.ConsumeLogsWithResponse(ctx, al.Logs, ld)
+	al.tracker.record(ctx, al.kind, al.id, component.DataTypeLogs, items, err)
+	return rsp, err
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/audit"
+)
+
+func generateTraces(spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < spans; i++ {
+		ss.Spans().AppendEmpty()
+	}
+	return td
+}
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+	})
+}
+
+func fields(entry observer.LoggedEntry) map[string]any {
+	res := map[string]any{}
+	for _, f := range entry.Context {
+		res[f.Key] = f.Interface
+	}
+	return res
+}
+
+func TestTracker(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	tracker := NewTracker(audit.Config{Enabled: true, TenantMetadataKey: "x-tenant"}, zap.New(core))
+	assert.Equal(t, time.Minute, tracker.interval)
+
+	rcvID := component.MustNewID("otlp")
+	expID := component.MustNewID("otlphttp")
+	failingID := component.MustNewIDWithName("otlphttp", "failing")
+
+	// The exporters report the items they delivered.
+	exporter, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		tracker.Delivered(ctx, expID, component.DataTypeTraces, td.SpanCount(), nil)
+		return nil
+	})
+	require.NoError(t, err)
+	receiver := tracker.Traces(component.KindReceiver, rcvID, exporter)
+
+	require.NoError(t, receiver.ConsumeTraces(tenantContext("a"), generateTraces(2)))
+	require.NoError(t, receiver.ConsumeTraces(tenantContext("a"), generateTraces(3)))
+	require.NoError(t, receiver.ConsumeTraces(tenantContext("b"), generateTraces(1)))
+	tracker.Delivered(context.Background(), failingID, component.DataTypeTraces, 4, errors.New("export failed"))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	require.NoError(t, tracker.Metrics(component.KindReceiver, rcvID, consumertest.NewNop()).ConsumeMetrics(context.Background(), md))
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, tracker.Logs(component.KindExporter, expID, consumertest.NewNop()).ConsumeLogs(context.Background(), ld))
+
+	tracker.Flush()
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Data flow audit", entry.Message)
+	f := fields(entry)
+	assert.Equal(t, []Entry{
+		{Component: "otlp", Signal: "metrics", Items: 1},
+		{Component: "otlp", Signal: "traces", Tenant: "a", Items: 5},
+		{Component: "otlp", Signal: "traces", Tenant: "b", Items: 1},
+	}, f["received"])
+	assert.Equal(t, []Entry{
+		{Component: "otlphttp", Signal: "logs", Items: 1},
+		{Component: "otlphttp", Signal: "traces", Tenant: "a", Items: 5},
+		{Component: "otlphttp", Signal: "traces", Tenant: "b", Items: 1},
+		{Component: "otlphttp/failing", Signal: "traces", Failed: 4},
+	}, f["delivered"])
+
+	// The counts are reset, and nothing is logged for the intervals without data.
+	tracker.Flush()
+	assert.Equal(t, 1, logs.Len())
+	require.NoError(t, receiver.ConsumeTraces(context.Background(), generateTraces(1)))
+	tracker.Flush()
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, []Entry{{Component: "otlp", Signal: "traces", Items: 1}}, fields(logs.All()[1])["received"])
+}
+
+func TestTrackerStartShutdown(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	tracker := NewTracker(audit.Config{Enabled: true, Interval: time.Millisecond}, zap.New(core))
+	receiver := tracker.Traces(component.KindReceiver, component.MustNewID("otlp"), consumertest.NewNop())
+
+	tracker.Start()
+	require.NoError(t, receiver.ConsumeTraces(context.Background(), generateTraces(1)))
+	assert.Eventually(t, func() bool { return logs.Len() == 1 }, time.Second, time.Millisecond)
+
+	// The counts of the last interval are logged on shutdown.
+	require.NoError(t, receiver.ConsumeTraces(context.Background(), generateTraces(2)))
+	tracker.Shutdown()
+	// Shutdown is idempotent.
+	tracker.Shutdown()
+	total := int64(0)
+	for _, entry := range logs.All() {
+		for _, e := range fields(entry)["received"].([]Entry) {
+			total += e.Items
+		}
+	}
+	assert.Equal(t, int64(3), total)
+}
@@ -4,10 +4,18 @@
 package capabilityconsumer // import "go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 
 import (
+	"context"
+
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
+// NewLogs returns a consumer.Logs reporting the capabilities cap. ReturnsResponse is reported as logs does,
+// the Response of logs being returned if any.
 func NewLogs(logs consumer.Logs, cap consumer.Capabilities) consumer.Logs {
+	cap.ReturnsResponse = logs.Capabilities().ReturnsResponse
 	if logs.Capabilities() == cap {
 		return logs
 	}
@@ -23,7 +31,14 @@ func (mts capLogs) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
 
+func (mts capLogs) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	return consumer.ConsumeLogsWithResponse(ctx, mts.Logs, ld)
+}
+
+// NewMetrics returns a consumer.Metrics reporting the capabilities cap. ReturnsResponse is reported as metrics does,
+// the Response of metrics being returned if any.
 func NewMetrics(metrics consumer.Metrics, cap consumer.Capabilities) consumer.Metrics {
+	cap.ReturnsResponse = metrics.Capabilities().ReturnsResponse
 	if metrics.Capabilities() == cap {
 		return metrics
 	}
@@ -39,7 +54,14 @@ func (mts capMetrics) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
 
+func (mts capMetrics) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	return consumer.ConsumeMetricsWithResponse(ctx, mts.Metrics, md)
+}
+
+// NewTraces returns a consumer.Traces reporting the capabilities cap. ReturnsResponse is reported as traces does,
+// the Response of traces being returned if any.
 func NewTraces(traces consumer.Traces, cap consumer.Capabilities) consumer.Traces {
+	cap.ReturnsResponse = traces.Capabilities().ReturnsResponse
 	if traces.Capabilities() == cap {
 		return traces
 	}
@@ -54,3 +76,7 @@ type capTraces struct {
 func (mts capTraces) Capabilities() consumer.Capabilities {
 	return mts.cap
 }
+
+func (mts capTraces) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	return consumer.ConsumeTracesWithResponse(ctx, mts.Traces, td)
+}
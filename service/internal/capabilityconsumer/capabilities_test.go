@@ -12,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -59,3 +60,20 @@ func TestTraces(t *testing.T) {
 	assert.Len(t, sink.AllTraces(), 1)
 	assert.Equal(t, testdata.GenerateTraces(1), sink.AllTraces()[0])
 }
+
+func TestMetricsWithResponse(t *testing.T) {
+	want := consumer.Response{Accepted: 1, Rejected: 1, ErrorMessage: "rejected"}
+	next, err := consumer.NewMetricsWithResponse(func(context.Context, pmetric.Metrics) (consumer.Response, error) {
+		return want, nil
+	})
+	require.NoError(t, err)
+
+	// The Response of the wrapped consumer is returned, whatever the given capabilities.
+	wrap := NewMetrics(next, consumer.Capabilities{MutatesData: true})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true, ReturnsResponse: true}, wrap.Capabilities())
+	rsp, err := consumer.ConsumeMetricsWithResponse(context.Background(), wrap, testdata.GenerateMetrics(1))
+	require.NoError(t, err)
+	assert.Equal(t, want, rsp)
+
+	assert.Same(t, next, NewMetrics(next, consumer.Capabilities{}))
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dryrun provides the sinks replacing the exporters in dry-run mode, which count the items
+// they receive instead of exporting them.
+package dryrun // import "go.opentelemetry.io/collector/service/internal/dryrun"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Sink counts the requests and the items, i.e. spans, metric data points or log records, it consumes,
+// and logs the counts and the throughput when shut down.
+type Sink struct {
+	logger *zap.Logger
+
+	started  time.Time
+	requests atomic.Int64
+	items    atomic.Int64
+}
+
+// NewSink returns a Sink logging to the logger of the exporter it replaces.
+func NewSink(logger *zap.Logger) *Sink {
+	return &Sink{logger: logger}
+}
+
+// Start implements component.Component.
+func (s *Sink) Start(context.Context, component.Host) error {
+	s.started = time.Now()
+	return nil
+}
+
+// Shutdown implements component.Component, and logs the counts.
+func (s *Sink) Shutdown(context.Context) error {
+	if s.started.IsZero() {
+		return nil
+	}
+	elapsed := time.Since(s.started)
+	items := s.items.Load()
+	s.logger.Info("Dry-run exporter summary",
+		zap.Int64("requests", s.requests.Load()),
+		zap.Int64("items", items),
+		zap.Duration("elapsed", elapsed),
+		zap.Float64("items_per_second", float64(items)/elapsed.Seconds()),
+	)
+	return nil
+}
+
+// Capabilities implements consumer.Traces, consumer.Metrics and consumer.Logs.
+func (s *Sink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces counts the spans.
+func (s *Sink) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	s.record(td.SpanCount())
+	return nil
+}
+
+// ConsumeMetrics counts the metric data points.
+func (s *Sink) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	s.record(md.DataPointCount())
+	return nil
+}
+
+// ConsumeLogs counts the log records.
+func (s *Sink) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	s.record(ld.LogRecordCount())
+	return nil
+}
+
+// Requests returns the number of requests consumed.
+func (s *Sink) Requests() int64 {
+	return s.requests.Load()
+}
+
+// Items returns the number of items consumed.
+func (s *Sink) Items() int64 {
+	return s.items.Load()
+}
+
+func (s *Sink) record(items int) {
+	s.requests.Add(1)
+	s.items.Add(int64(items))
+}
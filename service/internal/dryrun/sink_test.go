@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestSink(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	s := NewSink(zap.New(core))
+	assert.False(t, s.Capabilities().MutatesData)
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, s.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, s.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, s.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	assert.Equal(t, int64(3), s.Requests())
+	assert.Equal(t, int64(2+2+3), s.Items())
+
+	require.NoError(t, s.Shutdown(context.Background()))
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Dry-run exporter summary", entry.Message)
+	assert.Equal(t, int64(3), entry.ContextMap()["requests"])
+	assert.Equal(t, int64(7), entry.ContextMap()["items"])
+}
+
+func TestSinkShutdownNotStarted(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	require.NoError(t, NewSink(zap.New(core)).Shutdown(context.Background()))
+	assert.Equal(t, 0, logs.Len())
+}
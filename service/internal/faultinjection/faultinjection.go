@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faultinjection injects the faults of the extensions implementing extension.FaultInjector
+// before the data is consumed by the processors and the exporters.
+package faultinjection // import "go.opentelemetry.io/collector/service/internal/faultinjection"
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Injector calls the fault injectors of the extensions before the data is consumed by a component.
+type Injector struct {
+	injectors []extension.FaultInjector
+}
+
+// NewInjector returns the Injector of the given extensions, or nil if none of them is a fault injector.
+func NewInjector(exts map[component.ID]component.Component) *Injector {
+	ids := make([]component.ID, 0, len(exts))
+	for id, ext := range exts {
+		if _, ok := ext.(extension.FaultInjector); ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	// Call the injectors in a stable order.
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	inj := &Injector{injectors: make([]extension.FaultInjector, 0, len(ids))}
+	for _, id := range ids {
+		inj.injectors = append(inj.injectors, exts[id].(extension.FaultInjector))
+	}
+	return inj
+}
+
+// inject calls the fault injectors until one of them drops the data or returns an error.
+func (i *Injector) inject(ctx context.Context, kind component.Kind, id component.ID, dataType component.DataType) (bool, error) {
+	for _, fi := range i.injectors {
+		if drop, err := fi.InjectFault(ctx, kind, id, dataType); drop || err != nil {
+			return drop, err
+		}
+	}
+	return false, nil
+}
+
+// Traces returns a consumer.Traces consuming with next, after injecting the faults of the component.
+func (i *Injector) Traces(kind component.Kind, id component.ID, next consumer.Traces) consumer.Traces {
+	return faultyTraces{Traces: next, injector: i, kind: kind, id: id}
+}
+
+type faultyTraces struct {
+	consumer.Traces
+	injector *Injector
+	kind     component.Kind
+	id       component.ID
+}
+
+func (ft faultyTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if drop, err := ft.injector.inject(ctx, ft.kind, ft.id, component.DataTypeTraces); drop || err != nil {
+		return err
+	}
+	return ft.Traces.ConsumeTraces(ctx, td)
+}
+
+func (ft faultyTraces) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	if drop, err := ft.injector.inject(ctx, ft.kind, ft.id, component.DataTypeTraces); drop || err != nil {
+		return consumer.ResponseFromError(td.SpanCount(), err)
+	}
+	return consumer.ConsumeTracesWithResponse(ctx, ft.Traces, td)
+}
+
+// Metrics returns a consumer.Metrics consuming with next, after injecting the faults of the component.
+func (i *Injector) Metrics(kind component.Kind, id component.ID, next consumer.Metrics) consumer.Metrics {
+	return faultyMetrics{Metrics: next, injector: i, kind: kind, id: id}
+}
+
+type faultyMetrics struct {
+	consumer.Metrics
+	injector *Injector
+	kind     component.Kind
+	id       component.ID
+}
+
+func (fm faultyMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if drop, err := fm.injector.inject(ctx, fm.kind, fm.id, component.DataTypeMetrics); drop || err != nil {
+		return err
+	}
+	return fm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func (fm faultyMetrics) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	if drop, err := fm.injector.inject(ctx, fm.kind, fm.id, component.DataTypeMetrics); drop || err != nil {
+		return consumer.ResponseFromError(md.DataPointCount(), err)
+	}
+	return consumer.ConsumeMetricsWithResponse(ctx, fm.Metrics, md)
+}
+
+// Logs returns a consumer.Logs consuming with next, after injecting the faults of the component.
+func (i *Injector) Logs(kind component.Kind, id component.ID, next consumer.Logs) consumer.Logs {
+	return faultyLogs{Logs: next, injector: i, kind: kind, id: id}
+}
+
+type faultyLogs struct {
+	consumer.Logs
+	injector *Injector
+	kind     component.Kind
+	id       component.ID
+}
+
+func (fl faultyLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if drop, err := fl.injector.inject(ctx, fl.kind, fl.id, component.DataTypeLogs); drop || err != nil {
+		return err
+	}
+	return fl.Logs.ConsumeLogs(ctx, ld)
+}
+
+func (fl faultyLogs) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	if drop, err := fl.injector.inject(ctx, fl.kind, fl.id, component.DataTypeLogs); drop || err != nil {
+		return consumer.ResponseFromError(ld.LogRecordCount(), err)
+	}
+	return consumer.ConsumeLogsWithResponse(ctx, fl.Logs, ld)
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type fakeInjector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	drop  bool
+	err   error
+	calls []string
+}
+
+func (f *fakeInjector) InjectFault(_ context.Context, kind component.Kind, id component.ID, dataType component.DataType) (bool, error) {
+	f.calls = append(f.calls, kind.String()+"/"+id.String()+"/"+dataType.String())
+	return f.drop, f.err
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestNewInjector(t *testing.T) {
+	assert.Nil(t, NewInjector(map[component.ID]component.Component{
+		component.MustNewID("zpages"): &nopExtension{},
+	}))
+
+	first, second := &fakeInjector{}, &fakeInjector{drop: true}
+	inj := NewInjector(map[component.ID]component.Component{
+		component.MustNewID("zpages"):                      &nopExtension{},
+		component.MustNewIDWithName("faultinjection", "b"): second,
+		component.MustNewIDWithName("faultinjection", "a"): first,
+	})
+	require.NotNil(t, inj)
+
+	// The injectors are called in the order of their IDs, until one of them drops the data.
+	sink := &consumertest.TracesSink{}
+	require.NoError(t, inj.Traces(component.KindExporter, component.MustNewID("otlp"), sink).ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Empty(t, sink.AllTraces())
+	assert.Equal(t, []string{"Exporter/otlp/traces"}, first.calls)
+	assert.Equal(t, []string{"Exporter/otlp/traces"}, second.calls)
+}
+
+func TestInjector(t *testing.T) {
+	fi := &fakeInjector{}
+	inj := &Injector{injectors: []extension.FaultInjector{fi}}
+	procID := component.MustNewID("batch")
+
+	ts := &consumertest.TracesSink{}
+	tc := inj.Traces(component.KindProcessor, procID, ts)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, ts.AllTraces(), 1)
+
+	ms := &consumertest.MetricsSink{}
+	mc := inj.Metrics(component.KindProcessor, procID, ms)
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, ms.AllMetrics(), 1)
+
+	ls := &consumertest.LogsSink{}
+	lc := inj.Logs(component.KindProcessor, procID, ls)
+	require.NoError(t, lc.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, ls.AllLogs(), 1)
+	assert.Equal(t, []string{"Processor/batch/traces", "Processor/batch/metrics", "Processor/batch/logs"}, fi.calls)
+
+	// The data is not consumed when the injector returns an error.
+	fi.err = errors.New("injected failure")
+	assert.Equal(t, fi.err, tc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, fi.err, mc.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Equal(t, fi.err, lc.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, ts.AllTraces(), 1)
+	assert.Len(t, ms.AllMetrics(), 1)
+	assert.Len(t, ls.AllLogs(), 1)
+}
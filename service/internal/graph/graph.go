@@ -15,13 +15,18 @@ import (
 	"gonum.org/v1/gonum/graph/topo"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/attribution"
+	"go.opentelemetry.io/collector/service/internal/auditlog"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/isolation"
+	"go.opentelemetry.io/collector/service/internal/resource"
 	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
@@ -38,6 +43,28 @@ type Settings struct {
 
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
+
+	// MinStabilityLevel is the lowest stability level allowed for the components in the pipelines.
+	MinStabilityLevel component.StabilityLevel
+
+	// AuditTracker counts the items received by the receivers and delivered by the exporters, if not nil.
+	AuditTracker *auditlog.Tracker
+
+	// Profiler attributes the CPU time to the processors and the exporters, if not nil.
+	Profiler *attribution.Profiler
+
+	// FaultInjector injects the faults of the extensions before the data is consumed by the processors
+	// and the exporters, if not nil.
+	FaultInjector *faultinjection.Injector
+
+	// PanicIsolator recovers from the panics of the processors and the exporters consuming data, if not nil.
+	PanicIsolator *isolation.Isolator
+
+	// DryRun replaces the exporters by sinks counting the items they receive, instead of creating them.
+	DryRun bool
+
+	// ResourceInjector adds the resource attributes of the deployment to the data entering the pipelines, if not nil.
+	ResourceInjector *resource.Injector
 }
 
 type Graph struct {
@@ -69,6 +96,9 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 	if err := pipelines.createNodes(set); err != nil {
 		return nil, err
 	}
+	if err := pipelines.checkStability(set); err != nil {
+		return nil, err
+	}
 	pipelines.createEdges()
 	return pipelines, pipelines.buildComponents(ctx, set)
 }
@@ -112,6 +142,14 @@ func (g *Graph) createNodes(set Settings) error {
 			expNode := g.createExporter(pipelineID, exprID)
 			pipe.exporters[expNode.ID()] = expNode
 		}
+
+		if pipelineCfg.Mirror != nil {
+			if set.ConnectorBuilder.IsConfigured(pipelineCfg.Mirror.Exporter) {
+				return fmt.Errorf("connector %q used as mirror exporter in pipeline %q, only exporters are supported", pipelineCfg.Mirror.Exporter, pipelineID)
+			}
+			pipe.mirrorNode = newMirrorNode(pipelineID, *pipelineCfg.Mirror)
+			pipe.mirrorExporter = g.createExporter(pipelineID, pipelineCfg.Mirror.Exporter)
+		}
 	}
 
 	for connID := range connectors {
@@ -174,6 +212,55 @@ func (g *Graph) createNodes(set Settings) error {
 	return nil
 }
 
+// checkStability returns an error if any component in the pipelines has a stability level
+// lower than set.MinStabilityLevel for the data types it is used with.
+func (g *Graph) checkStability(set Settings) error {
+	if set.MinStabilityLevel == component.StabilityLevelUndefined {
+		return nil
+	}
+	for _, node := range graph.NodesOf(g.componentGraph.Nodes()) {
+		var (
+			id        component.ID
+			stability component.StabilityLevel
+			dataType  string
+		)
+		switch n := node.(type) {
+		case *receiverNode:
+			f, ok := set.ReceiverBuilder.Factory(n.componentID.Type()).(receiver.Factory)
+			if !ok {
+				continue
+			}
+			id, dataType, stability = n.componentID, n.pipelineType.String(), receiverStability(f, n.pipelineType)
+		case *processorNode:
+			f, ok := set.ProcessorBuilder.Factory(n.componentID.Type()).(processor.Factory)
+			if !ok {
+				continue
+			}
+			id, dataType, stability = n.componentID, n.pipelineID.Type().String(), processorStability(f, n.pipelineID.Type())
+		case *exporterNode:
+			f, ok := set.ExporterBuilder.Factory(n.componentID.Type()).(exporter.Factory)
+			if !ok {
+				continue
+			}
+			id, dataType, stability = n.componentID, n.pipelineType.String(), exporterStability(f, n.pipelineType)
+		case *connectorNode:
+			f, ok := set.ConnectorBuilder.Factory(n.componentID.Type()).(connector.Factory)
+			if !ok {
+				continue
+			}
+			id, dataType = n.componentID, n.exprPipelineType.String()+" to "+n.rcvrPipelineType.String()
+			stability = connectorStability(f, n.exprPipelineType, n.rcvrPipelineType)
+		default:
+			continue
+		}
+		if stability < set.MinStabilityLevel {
+			return fmt.Errorf("component %q has stability level %q for %s, lower than the minimum allowed %q",
+				id, stability, dataType, set.MinStabilityLevel)
+		}
+	}
+	return nil
+}
+
 func (g *Graph) createReceiver(pipelineID, recvID component.ID) *receiverNode {
 	rcvrNode := newReceiverNode(pipelineID.Type(), recvID)
 	if node := g.componentGraph.Node(rcvrNode.ID()); node != nil {
@@ -260,11 +347,16 @@ func (g *Graph) createEdges() {
 		for _, exporter := range pg.exporters {
 			g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.fanOutNode, exporter))
 		}
+
+		if pg.mirrorNode != nil {
+			g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.fanOutNode, pg.mirrorNode))
+			g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.mirrorNode, pg.mirrorExporter))
+		}
 	}
 }
 
 func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
-	nodes, err := topo.Sort(g.componentGraph)
+	nodes, err := g.sortComponents()
 	if err != nil {
 		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
 	}
@@ -272,7 +364,7 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
 
-		// skipped for capabilitiesNodes and fanoutNodes as they are not assigned componentIDs.
+		// skipped for capabilitiesNodes, fanoutNodes and mirrorNodes as they are not assigned componentIDs.
 		var telemetrySettings component.TelemetrySettings
 		if instanceID, ok := g.instanceIDs[node.ID()]; ok {
 			telemetrySettings = set.Telemetry.ToComponentTelemetrySettings(instanceID)
@@ -280,11 +372,11 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()), set.AuditTracker)
 		case *processorNode:
-			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0], set.Profiler, set.FaultInjector, set.PanicIsolator)
 		case *exporterNode:
-			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ExporterBuilder)
+			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ExporterBuilder, set.AuditTracker, set.Profiler, set.FaultInjector, set.PanicIsolator, set.DryRun)
 		case *connectorNode:
 			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
 		case *capabilitiesNode:
@@ -295,43 +387,36 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			for _, proc := range g.pipelines[n.pipelineID].processors {
 				capability.MutatesData = capability.MutatesData || proc.getConsumer().Capabilities().MutatesData
 			}
+			// Injecting the resource attributes modifies the data entering the pipeline.
+			capability.MutatesData = capability.MutatesData || set.ResourceInjector != nil
 			next := g.nextConsumers(n.ID())[0]
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
+				if set.ResourceInjector != nil {
+					next = set.ResourceInjector.Traces(next.(consumer.Traces))
+				}
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
 				n.baseConsumer = cc
 				n.ConsumeTracesFunc = cc.ConsumeTraces
 			case component.DataTypeMetrics:
+				if set.ResourceInjector != nil {
+					next = set.ResourceInjector.Metrics(next.(consumer.Metrics))
+				}
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
 				n.baseConsumer = cc
 				n.ConsumeMetricsFunc = cc.ConsumeMetrics
 			case component.DataTypeLogs:
+				if set.ResourceInjector != nil {
+					next = set.ResourceInjector.Logs(next.(consumer.Logs))
+				}
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
 				n.ConsumeLogsFunc = cc.ConsumeLogs
 			}
 		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
-			switch n.pipelineID.Type() {
-			case component.DataTypeTraces:
-				consumers := make([]consumer.Traces, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Traces))
-				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
-			case component.DataTypeMetrics:
-				consumers := make([]consumer.Metrics, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Metrics))
-				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
-			case component.DataTypeLogs:
-				consumers := make([]consumer.Logs, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Logs))
-				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
-			}
+			n.buildConsumer(g.nextConsumers(n.ID()))
+		case *mirrorNode:
+			n.buildConsumer(set.Telemetry.Logger, g.nextConsumers(n.ID())[0])
 		}
 		if err != nil {
 			return err
@@ -367,10 +452,20 @@ type pipelineNodes struct {
 
 	// Use map to assist with deduplication of connector instances.
 	exporters map[int64]graph.Node
+
+	// Emits a share of the data to the mirror exporter, if the pipeline has a mirror.
+	mirrorNode     *mirrorNode
+	mirrorExporter *exporterNode
+}
+
+// sortComponents returns the nodes in topological order. The nodes that do not depend on each other
+// are ordered by node ID, so the components are always built, started and shut down in the same order.
+func (g *Graph) sortComponents() ([]graph.Node, error) {
+	return topo.SortStabilized(g.componentGraph, nil)
 }
 
 func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
-	nodes, err := topo.Sort(g.componentGraph)
+	nodes, err := g.sortComponents()
 	if err != nil {
 		return err
 	}
@@ -380,6 +475,13 @@ func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
 	// component's consumer is ready to consume.
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
+		if mn, ok := node.(*mirrorNode); ok {
+			// The mirror nodes are not components, they are started without reporting their status.
+			if err = mn.mirror.Start(ctx, host); err != nil {
+				return err
+			}
+			continue
+		}
 		comp, ok := node.(component.Component)
 
 		if !ok {
@@ -394,6 +496,12 @@ func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
 		)
 
 		if compErr := comp.Start(ctx, host); compErr != nil {
+			var listenErr *confignet.ListenError
+			if errors.As(compErr, &listenErr) {
+				// Name the component, the same endpoint may be configured for several components.
+				compErr = fmt.Errorf("%s %q cannot listen on %s: %w",
+					strings.ToLower(instanceID.Kind.String()), instanceID.ID, listenErr.Address, compErr)
+			}
 			g.telemetry.Status.ReportStatus(
 				instanceID,
 				component.NewPermanentErrorEvent(compErr),
@@ -407,7 +515,7 @@ func (g *Graph) StartAll(ctx context.Context, host component.Host) error {
 }
 
 func (g *Graph) ShutdownAll(ctx context.Context) error {
-	nodes, err := topo.Sort(g.componentGraph)
+	nodes, err := g.sortComponents()
 	if err != nil {
 		return err
 	}
@@ -419,6 +527,11 @@ func (g *Graph) ShutdownAll(ctx context.Context) error {
 	var errs error
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
+		if mn, ok := node.(*mirrorNode); ok {
+			// The mirror nodes send the data buffered for the mirror exporter, which is shut down after them.
+			errs = multierr.Append(errs, mn.mirror.Shutdown(ctx))
+			continue
+		}
 		comp, ok := node.(component.Component)
 
 		if !ok {
@@ -468,6 +581,9 @@ func (g *Graph) GetExporters() map[component.DataType]map[component.ID]component
 				exportersMap[expNode.pipelineType][expNode.componentID] = expNode.Component
 			}
 		}
+		if pg.mirrorExporter != nil {
+			exportersMap[pg.mirrorExporter.pipelineType][pg.mirrorExporter.componentID] = pg.mirrorExporter.Component
+		}
 	}
 	return exportersMap
 }
@@ -506,7 +622,7 @@ func cycleErr(err error, cycles [][]graph.Node) error {
 		case *connectorNode:
 			componentDetails = append(componentDetails, fmt.Sprintf("connector %q (%s to %s)", n.componentID, n.exprPipelineType, n.rcvrPipelineType))
 		default:
-			continue // skip capabilities/fanout nodes
+			continue // skip capabilities/fanout/mirror nodes
 		}
 	}
 	return fmt.Errorf("cycle detected: %s", strings.Join(componentDetails, " -> "))
@@ -544,3 +660,39 @@ func connectorStability(f connector.Factory, expType, recType component.Type) co
 	}
 	return component.StabilityLevelUndefined
 }
+
+func receiverStability(f receiver.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesReceiverStability()
+	case component.DataTypeMetrics:
+		return f.MetricsReceiverStability()
+	case component.DataTypeLogs:
+		return f.LogsReceiverStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func processorStability(f processor.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesProcessorStability()
+	case component.DataTypeMetrics:
+		return f.MetricsProcessorStability()
+	case component.DataTypeLogs:
+		return f.LogsProcessorStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func exporterStability(f exporter.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesExporterStability()
+	case component.DataTypeMetrics:
+		return f.MetricsExporterStability()
+	case component.DataTypeLogs:
+		return f.LogsExporterStability()
+	}
+	return component.StabilityLevelUndefined
+}
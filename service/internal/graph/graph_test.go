@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,17 +18,24 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/internal/dryrun"
+	"go.opentelemetry.io/collector/service/internal/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/resource"
 	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/testcomponents"
@@ -168,6 +176,42 @@ func TestGraphStartStop(t *testing.T) {
 	}
 }
 
+func TestGraphStartStopDeterministicOrder(t *testing.T) {
+	edges := [][2]component.ID{
+		{component.MustNewIDWithName("r", "1"), component.MustNewIDWithName("p", "1")},
+		{component.MustNewIDWithName("r", "2"), component.MustNewIDWithName("p", "1")},
+		{component.MustNewIDWithName("r", "3"), component.MustNewIDWithName("p", "2")},
+		{component.MustNewIDWithName("p", "1"), component.MustNewIDWithName("e", "1")},
+		{component.MustNewIDWithName("p", "1"), component.MustNewIDWithName("e", "2")},
+		{component.MustNewIDWithName("p", "2"), component.MustNewIDWithName("e", "2")},
+		{component.MustNewIDWithName("p", "2"), component.MustNewIDWithName("e", "3")},
+	}
+
+	startAll := func() map[component.ID]int {
+		pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+		pg.telemetry = servicetelemetry.NewNopTelemetrySettings()
+		pg.instanceIDs = make(map[int64]*component.InstanceID)
+		for _, edge := range edges {
+			f, t := &testNode{id: edge[0]}, &testNode{id: edge[1]}
+			pg.instanceIDs[f.ID()] = &component.InstanceID{}
+			pg.instanceIDs[t.ID()] = &component.InstanceID{}
+			pg.componentGraph.SetEdge(simple.Edge{F: f, T: t})
+		}
+		ctx := &contextWithOrder{
+			Context: context.Background(),
+			order:   map[component.ID]int{},
+		}
+		require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+		return ctx.order
+	}
+
+	// The components that do not depend on each other are always started in the same order.
+	expected := startAll()
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, expected, startAll())
+	}
+}
+
 func TestGraphStartStopCycle(t *testing.T) {
 	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
 
@@ -220,6 +264,35 @@ func TestGraphStartStopComponentError(t *testing.T) {
 	assert.EqualError(t, pg.ShutdownAll(context.Background()), "bar")
 }
 
+func TestGraphStartListenError(t *testing.T) {
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.telemetry = servicetelemetry.NewNopTelemetrySettings()
+	var statuses []*component.StatusEvent
+	pg.telemetry.Status = status.NewReporter(func(_ *component.InstanceID, ev *component.StatusEvent) {
+		statuses = append(statuses, ev)
+	}, func(error) {})
+	pg.telemetry.Status.Ready()
+
+	listenErr := &confignet.ListenError{Transport: "tcp", Address: "localhost:4317", Err: syscall.EADDRINUSE}
+	r1 := &testNode{
+		id:       component.MustNewIDWithName("r", "1"),
+		startErr: fmt.Errorf("failed to start: %w", listenErr),
+	}
+	pg.instanceIDs = map[int64]*component.InstanceID{
+		r1.ID(): {ID: component.MustNewIDWithName("otlp", "in"), Kind: component.KindReceiver},
+	}
+	pg.componentGraph.AddNode(r1)
+
+	err := pg.StartAll(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `receiver "otlp/in" cannot listen on localhost:4317: failed to start: `+listenErr.Error())
+	assert.ErrorAs(t, err, &listenErr)
+
+	// The structured error is reported to the status watchers, e.g. the health check extensions.
+	require.Len(t, statuses, 2)
+	assert.Equal(t, component.StatusPermanentError, statuses[1].Status())
+	assert.Equal(t, err, statuses[1].Err())
+}
+
 func TestConnectorPipelinesGraph(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -1165,6 +1238,301 @@ func TestConnectorRouter(t *testing.T) {
 
 }
 
+func TestGraphMirror(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	mirrorID := component.MustNewIDWithName("exampleexporter", "mirror")
+	failingID := component.MustNewID("failing")
+
+	failingFactory := exporter.NewFactory(failingID.Type(),
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.CreateSettings, component.Config) (exporter.Traces, error) {
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+				consumer.Traces
+			}{Traces: consumertest.NewErr(errors.New("mirror failed"))}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+
+	ctx := context.Background()
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expID:     testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				mirrorID:  testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				failingID: failingFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+				failingFactory.Type():                        failingFactory,
+			},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewIDWithName("traces", "mirrored"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+				Mirror:    &pipelines.MirrorConfig{Exporter: mirrorID, Percent: 100},
+			},
+			component.MustNewIDWithName("traces", "failing"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+				Mirror:    &pipelines.MirrorConfig{Exporter: failingID, Percent: 100},
+			},
+		},
+	}
+
+	pg, err := Build(ctx, set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	allExporters := pg.GetExporters()
+	tracesExporter := allExporters[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+	tracesMirror := allExporters[component.DataTypeTraces][mirrorID].(*testcomponents.ExampleExporter)
+	assert.Contains(t, allExporters[component.DataTypeTraces], failingID)
+
+	// The failure of the mirror exporter does not affect the pipeline.
+	assert.NoError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(2)))
+	require.Len(t, tracesExporter.Traces, 2)
+	// The mirror exporter is called asynchronously, the shutdown waits until the mirrored data is sent.
+	require.NoError(t, pg.ShutdownAll(ctx))
+	require.Len(t, tracesMirror.Traces, 1)
+	assert.Equal(t, testdata.GenerateTraces(2), tracesMirror.Traces[0])
+}
+
+func TestGraphDryRun(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+
+	ctx := context.Background()
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		// The exporter factory is not used in dry-run mode.
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			component.MustNewID("logs"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		DryRun: true,
+	}
+
+	pg, err := Build(ctx, set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, pg.ShutdownAll(ctx)) }()
+
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	logsReceiver := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(2)))
+	assert.NoError(t, logsReceiver.ConsumeLogs(ctx, testdata.GenerateLogs(3)))
+
+	allExporters := pg.GetExporters()
+	tracesSink := allExporters[component.DataTypeTraces][expID].(*dryrun.Sink)
+	assert.Equal(t, int64(2), tracesSink.Items())
+	logsSink := allExporters[component.DataTypeLogs][expID].(*dryrun.Sink)
+	assert.Equal(t, int64(3), logsSink.Items())
+}
+
+func TestGraphResourceInjector(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+
+	ctx := context.Background()
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		ResourceInjector: resource.NewInjector(map[string]string{"deployment.environment": "production"}),
+	}
+
+	pg, err := Build(ctx, set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, pg.ShutdownAll(ctx)) }()
+
+	// The pipeline mutates the data, so that the data shared with other pipelines is copied first.
+	assert.True(t, pg.pipelines[component.MustNewID("traces")].capabilitiesNode.getConsumer().Capabilities().MutatesData)
+
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	tracesExporter := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+	require.Len(t, tracesExporter.Traces, 1)
+	val, ok := tracesExporter.Traces[0].ResourceSpans().At(0).Resource().Attributes().Get("deployment.environment")
+	require.True(t, ok)
+	assert.Equal(t, "production", val.Str())
+}
+
+// faultInjector fails the data consumed by the exporters, and drops the data consumed by the processors.
+type faultInjector struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (faultInjector) InjectFault(_ context.Context, kind component.Kind, _ component.ID, _ component.DataType) (bool, error) {
+	if kind == component.KindExporter {
+		return false, errors.New("injected failure")
+	}
+	return true, nil
+}
+
+func TestGraphFaultInjection(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("exampleprocessor")
+	expID := component.MustNewID("exampleexporter")
+
+	ctx := context.Background()
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				procID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			component.MustNewID("logs"): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+		FaultInjector: faultinjection.NewInjector(map[component.ID]component.Component{
+			component.MustNewID("faultinjection"): faultInjector{},
+		}),
+	}
+
+	pg, err := Build(ctx, set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(ctx, componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, pg.ShutdownAll(ctx)) }()
+
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	logsReceiver := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+	assert.EqualError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(1)), "injected failure")
+	assert.NoError(t, logsReceiver.ConsumeLogs(ctx, testdata.GenerateLogs(1)))
+
+	allExporters := pg.GetExporters()
+	assert.Empty(t, allExporters[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter).Traces)
+	assert.Empty(t, allExporters[component.DataTypeLogs][expID].(*testcomponents.ExampleExporter).Logs)
+}
+
+func TestFanOutNodePipelineContext(t *testing.T) {
+	var got []string
+	record := func(ctx context.Context) {
+		pipelineID, ok := exporter.PipelineFromContext(ctx)
+		assert.True(t, ok)
+		got = append(got, pipelineID.String())
+	}
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		record(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	mc, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		record(ctx)
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	lc, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		record(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+
+	tn := newFanOutNode(component.MustNewIDWithName("traces", "a"))
+	tn.buildConsumer([]baseConsumer{tc})
+	require.NoError(t, tn.getConsumer().(consumer.Traces).ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	mn := newFanOutNode(component.MustNewIDWithName("metrics", "b"))
+	mn.buildConsumer([]baseConsumer{mc})
+	// The capabilities of the exporters are kept.
+	assert.True(t, mn.getConsumer().Capabilities().MutatesData)
+	require.NoError(t, mn.getConsumer().(consumer.Metrics).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	ln := newFanOutNode(component.MustNewID("logs"))
+	ln.buildConsumer([]baseConsumer{lc})
+	require.NoError(t, ln.getConsumer().(consumer.Logs).ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	assert.Equal(t, []string{"traces/a", "metrics/b", "logs"}, got)
+}
+
 func TestGraphBuildErrors(t *testing.T) {
 	nopReceiverFactory := receivertest.NewNopFactory()
 	nopProcessorFactory := processortest.NewNopFactory()
@@ -1341,6 +1709,26 @@ func TestGraphBuildErrors(t *testing.T) {
 			},
 			expected: "failed to create \"bf\" receiver for data type \"traces\": telemetry type is not supported",
 		},
+		{
+			name: "connector_as_mirror",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+			},
+			connectorCfgs: map[component.ID]component.Config{
+				component.MustNewIDWithName("nop", "conn"): nopConnectorFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewID("traces"): {
+					Receivers: []component.ID{component.MustNewID("nop")},
+					Exporters: []component.ID{component.MustNewID("nop")},
+					Mirror:    &pipelines.MirrorConfig{Exporter: component.MustNewIDWithName("nop", "conn"), Percent: 10},
+				},
+			},
+			expected: "connector \"nop/conn\" used as mirror exporter in pipeline \"traces\", only exporters are supported",
+		},
 		{
 			name: "not_supported_connector_traces_traces.yaml",
 			receiverCfgs: map[component.ID]component.Config{
@@ -2083,6 +2471,68 @@ func TestGraphBuildErrors(t *testing.T) {
 	}
 }
 
+func TestGraphBuildMinStabilityLevel(t *testing.T) {
+	nopReceiverFactory := receivertest.NewNopFactory()
+	exampleExporterFactory := testcomponents.ExampleExporterFactory
+
+	tests := []struct {
+		name              string
+		minStabilityLevel component.StabilityLevel
+		expected          string
+	}{
+		{
+			name: "not_set",
+		},
+		{
+			name:              "development",
+			minStabilityLevel: component.StabilityLevelDevelopment,
+		},
+		{
+			name:              "alpha",
+			minStabilityLevel: component.StabilityLevelAlpha,
+			expected:          `component "exampleexporter" has stability level "Development" for traces, lower than the minimum allowed "Alpha"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := Settings{
+				BuildInfo: component.NewDefaultBuildInfo(),
+				Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+				ReceiverBuilder: receiver.NewBuilder(
+					map[component.ID]component.Config{
+						component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]receiver.Factory{
+						nopReceiverFactory.Type(): nopReceiverFactory,
+					}),
+				ProcessorBuilder: processor.NewBuilder(nil, nil),
+				ExporterBuilder: exporter.NewBuilder(
+					map[component.ID]component.Config{
+						component.NewID(exampleExporterFactory.Type()): exampleExporterFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]exporter.Factory{
+						exampleExporterFactory.Type(): exampleExporterFactory,
+					}),
+				ConnectorBuilder: connector.NewBuilder(nil, nil),
+				PipelineConfigs: pipelines.Config{
+					component.MustNewID("traces"): {
+						Receivers: []component.ID{component.NewID(nopReceiverFactory.Type())},
+						Exporters: []component.ID{component.NewID(exampleExporterFactory.Type())},
+					},
+				},
+				MinStabilityLevel: test.minStabilityLevel,
+			}
+			_, err := Build(context.Background(), set)
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
 // This includes all tests from the previous implmentation, plus a new one
 // relevant only to the new graph-based implementation.
 func TestGraphFailToStartAndShutdown(t *testing.T) {
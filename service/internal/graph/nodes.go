@@ -9,15 +9,27 @@ import (
 	"hash/fnv"
 	"strings"
 
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/attribution"
+	"go.opentelemetry.io/collector/service/internal/auditlog"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/dryrun"
+	"go.opentelemetry.io/collector/service/internal/faultinjection"
+	"go.opentelemetry.io/collector/service/internal/isolation"
+	"go.opentelemetry.io/collector/service/internal/mirrorconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 const (
@@ -27,6 +39,7 @@ const (
 	connectorSeed     = "connector"
 	capabilitiesSeed  = "capabilities"
 	fanOutToExporters = "fanout_to_exporters"
+	mirrorSeed        = "mirror"
 )
 
 // baseConsumer redeclared here since not public in consumer package. May consider to make that public.
@@ -72,6 +85,7 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 	info component.BuildInfo,
 	builder *receiver.Builder,
 	nexts []baseConsumer,
+	tracker *auditlog.Tracker,
 ) error {
 	set := receiver.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ReceiverLogger(tel.Logger, n.componentID, n.pipelineType)
@@ -82,19 +96,31 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		next := fanoutconsumer.NewTraces(consumers)
+		if tracker != nil {
+			next = tracker.Traces(component.KindReceiver, n.componentID, next)
+		}
+		n.Component, err = builder.CreateTraces(ctx, set, next)
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		next := fanoutconsumer.NewMetrics(consumers)
+		if tracker != nil {
+			next = tracker.Metrics(component.KindReceiver, n.componentID, next)
+		}
+		n.Component, err = builder.CreateMetrics(ctx, set, next)
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
+		next := fanoutconsumer.NewLogs(consumers)
+		if tracker != nil {
+			next = tracker.Logs(component.KindReceiver, n.componentID, next)
+		}
+		n.Component, err = builder.CreateLogs(ctx, set, next)
 	default:
 		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -113,6 +139,7 @@ type processorNode struct {
 	componentID component.ID
 	pipelineID  component.ID
 	component.Component
+	consumer baseConsumer
 }
 
 func newProcessorNode(pipelineID, procID component.ID) *processorNode {
@@ -124,7 +151,7 @@ func newProcessorNode(pipelineID, procID component.ID) *processorNode {
 }
 
 func (n *processorNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return n.consumer
 }
 
 func (n *processorNode) buildComponent(ctx context.Context,
@@ -132,17 +159,56 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	info component.BuildInfo,
 	builder *processor.Builder,
 	next baseConsumer,
+	profiler *attribution.Profiler,
+	injector *faultinjection.Injector,
+	isolator *isolation.Isolator,
 ) error {
-	set := processor.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
+	set := processor.CreateSettings{ID: n.componentID, PipelineID: n.pipelineID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ProcessorLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineID)
 	var err error
 	switch n.pipelineID.Type() {
 	case component.DataTypeTraces:
-		n.Component, err = builder.CreateTraces(ctx, set, next.(consumer.Traces))
+		var proc processor.Traces
+		if proc, err = builder.CreateTraces(ctx, set, next.(consumer.Traces)); err == nil {
+			n.Component, n.consumer = proc, proc
+			if isolator != nil {
+				n.consumer = isolator.Traces(component.KindProcessor, n.componentID, set.TelemetrySettings.Logger, proc, proc)
+			}
+			if profiler != nil {
+				n.consumer = profiler.Traces(attribution.ProcessorLabels(n.componentID, n.pipelineID), n.consumer.(consumer.Traces))
+			}
+			if injector != nil {
+				n.consumer = injector.Traces(component.KindProcessor, n.componentID, n.consumer.(consumer.Traces))
+			}
+		}
 	case component.DataTypeMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics))
+		var proc processor.Metrics
+		if proc, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics)); err == nil {
+			n.Component, n.consumer = proc, proc
+			if isolator != nil {
+				n.consumer = isolator.Metrics(component.KindProcessor, n.componentID, set.TelemetrySettings.Logger, proc, proc)
+			}
+			if profiler != nil {
+				n.consumer = profiler.Metrics(attribution.ProcessorLabels(n.componentID, n.pipelineID), n.consumer.(consumer.Metrics))
+			}
+			if injector != nil {
+				n.consumer = injector.Metrics(component.KindProcessor, n.componentID, n.consumer.(consumer.Metrics))
+			}
+		}
 	case component.DataTypeLogs:
-		n.Component, err = builder.CreateLogs(ctx, set, next.(consumer.Logs))
+		var proc processor.Logs
+		if proc, err = builder.CreateLogs(ctx, set, next.(consumer.Logs)); err == nil {
+			n.Component, n.consumer = proc, proc
+			if isolator != nil {
+				n.consumer = isolator.Logs(component.KindProcessor, n.componentID, set.TelemetrySettings.Logger, proc, proc)
+			}
+			if profiler != nil {
+				n.consumer = profiler.Logs(attribution.ProcessorLabels(n.componentID, n.pipelineID), n.consumer.(consumer.Logs))
+			}
+			if injector != nil {
+				n.consumer = injector.Logs(component.KindProcessor, n.componentID, n.consumer.(consumer.Logs))
+			}
+		}
 	default:
 		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID, n.pipelineID.Type())
 	}
@@ -161,6 +227,9 @@ type exporterNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+	// consumer is the exporter, or the exporter counting the delivered items if the audit log is enabled,
+	// wrapped by the fault injectors if any. It recovers from the panics of the exporter if enabled.
+	consumer baseConsumer
 }
 
 func newExporterNode(pipelineType component.DataType, exprID component.ID) *exporterNode {
@@ -172,7 +241,7 @@ func newExporterNode(pipelineType component.DataType, exprID component.ID) *expo
 }
 
 func (n *exporterNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return n.consumer
 }
 
 func (n *exporterNode) buildComponent(
@@ -180,23 +249,85 @@ func (n *exporterNode) buildComponent(
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *exporter.Builder,
+	tracker *auditlog.Tracker,
+	profiler *attribution.Profiler,
+	injector *faultinjection.Injector,
+	isolator *isolation.Isolator,
+	dryRun bool,
 ) error {
 	set := exporter.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ExporterLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineType)
-	var err error
+	if dryRun {
+		// The exporter is not created, the sink counts the items it would have exported.
+		sink := dryrun.NewSink(set.TelemetrySettings.Logger)
+		n.Component, n.consumer = sink, sink
+	}
 	switch n.pipelineType {
 	case component.DataTypeTraces:
-		n.Component, err = builder.CreateTraces(ctx, set)
+		if !dryRun {
+			exp, err := builder.CreateTraces(ctx, set)
+			if err != nil {
+				return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			}
+			n.Component, n.consumer = exp, exp
+		}
+		if isolator != nil {
+			n.consumer = isolator.Traces(component.KindExporter, n.componentID, set.TelemetrySettings.Logger, n.Component, n.consumer.(consumer.Traces))
+		}
+		if profiler != nil {
+			n.consumer = profiler.Traces(attribution.ExporterLabels(n.componentID), n.consumer.(consumer.Traces))
+		}
+		if tracker != nil && dryRun {
+			// The dry run sinks are counted when consuming the items, the exporters report the items
+			// they delivered themselves, see exporter.DeliveryObserver.
+			n.consumer = tracker.Traces(component.KindExporter, n.componentID, n.consumer.(consumer.Traces))
+		}
+		if injector != nil {
+			n.consumer = injector.Traces(component.KindExporter, n.componentID, n.consumer.(consumer.Traces))
+		}
 	case component.DataTypeMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set)
+		if !dryRun {
+			exp, err := builder.CreateMetrics(ctx, set)
+			if err != nil {
+				return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			}
+			n.Component, n.consumer = exp, exp
+		}
+		if isolator != nil {
+			n.consumer = isolator.Metrics(component.KindExporter, n.componentID, set.TelemetrySettings.Logger, n.Component, n.consumer.(consumer.Metrics))
+		}
+		if profiler != nil {
+			n.consumer = profiler.Metrics(attribution.ExporterLabels(n.componentID), n.consumer.(consumer.Metrics))
+		}
+		if tracker != nil && dryRun {
+			n.consumer = tracker.Metrics(component.KindExporter, n.componentID, n.consumer.(consumer.Metrics))
+		}
+		if injector != nil {
+			n.consumer = injector.Metrics(component.KindExporter, n.componentID, n.consumer.(consumer.Metrics))
+		}
 	case component.DataTypeLogs:
-		n.Component, err = builder.CreateLogs(ctx, set)
+		if !dryRun {
+			exp, err := builder.CreateLogs(ctx, set)
+			if err != nil {
+				return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			}
+			n.Component, n.consumer = exp, exp
+		}
+		if isolator != nil {
+			n.consumer = isolator.Logs(component.KindExporter, n.componentID, set.TelemetrySettings.Logger, n.Component, n.consumer.(consumer.Logs))
+		}
+		if profiler != nil {
+			n.consumer = profiler.Logs(attribution.ExporterLabels(n.componentID), n.consumer.(consumer.Logs))
+		}
+		if tracker != nil && dryRun {
+			n.consumer = tracker.Logs(component.KindExporter, n.componentID, n.consumer.(consumer.Logs))
+		}
+		if injector != nil {
+			n.consumer = injector.Logs(component.KindExporter, n.componentID, n.consumer.(consumer.Logs))
+		}
 	default:
 		return fmt.Errorf("error creating exporter %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
-	if err != nil {
-		return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
-	}
 	return nil
 }
 
@@ -394,3 +525,99 @@ func newFanOutNode(pipelineID component.ID) *fanOutNode {
 func (n *fanOutNode) getConsumer() baseConsumer {
 	return n.baseConsumer
 }
+
+// buildConsumer fans out the data to the next consumers, with the pipeline ID in the context so that
+// the exporters shared by several pipelines can tell them apart, see exporter.PipelineFromContext.
+func (n *fanOutNode) buildConsumer(nexts []baseConsumer) {
+	switch n.pipelineID.Type() {
+	case component.DataTypeTraces:
+		consumers := make([]consumer.Traces, 0, len(nexts))
+		for _, next := range nexts {
+			consumers = append(consumers, next.(consumer.Traces))
+		}
+		n.baseConsumer = pipelineTraces{Traces: fanoutconsumer.NewTraces(consumers), pipelineID: n.pipelineID}
+	case component.DataTypeMetrics:
+		consumers := make([]consumer.Metrics, 0, len(nexts))
+		for _, next := range nexts {
+			consumers = append(consumers, next.(consumer.Metrics))
+		}
+		n.baseConsumer = pipelineMetrics{Metrics: fanoutconsumer.NewMetrics(consumers), pipelineID: n.pipelineID}
+	case component.DataTypeLogs:
+		consumers := make([]consumer.Logs, 0, len(nexts))
+		for _, next := range nexts {
+			consumers = append(consumers, next.(consumer.Logs))
+		}
+		n.baseConsumer = pipelineLogs{Logs: fanoutconsumer.NewLogs(consumers), pipelineID: n.pipelineID}
+	}
+}
+
+type pipelineTraces struct {
+	consumer.Traces
+	pipelineID component.ID
+}
+
+func (pt pipelineTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return pt.Traces.ConsumeTraces(exporter.ContextWithPipeline(ctx, pt.pipelineID), td)
+}
+
+type pipelineMetrics struct {
+	consumer.Metrics
+	pipelineID component.ID
+}
+
+func (pm pipelineMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return pm.Metrics.ConsumeMetrics(exporter.ContextWithPipeline(ctx, pm.pipelineID), md)
+}
+
+type pipelineLogs struct {
+	consumer.Logs
+	pipelineID component.ID
+}
+
+func (pl pipelineLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return pl.Logs.ConsumeLogs(exporter.ContextWithPipeline(ctx, pl.pipelineID), ld)
+}
+
+var _ consumerNode = &mirrorNode{}
+
+// A pipeline with a mirror has one mirror node between the fan-out node and the mirror exporter.
+// Therefore, nodeID is derived from "pipeline ID".
+type mirrorNode struct {
+	nodeID
+	pipelineID component.ID
+	cfg        pipelines.MirrorConfig
+	baseConsumer
+	// mirror sends the data from a goroutine, it is started and shut down with the components of the graph.
+	mirror component.Component
+}
+
+func newMirrorNode(pipelineID component.ID, cfg pipelines.MirrorConfig) *mirrorNode {
+	return &mirrorNode{
+		nodeID:     newNodeID(mirrorSeed, pipelineID.String()),
+		pipelineID: pipelineID,
+		cfg:        cfg,
+	}
+}
+
+func (n *mirrorNode) getConsumer() baseConsumer {
+	return n.baseConsumer
+}
+
+func (n *mirrorNode) buildConsumer(logger *zap.Logger, next baseConsumer) {
+	logger = components.ExporterLogger(logger, n.cfg.Exporter, n.pipelineID.Type()).With(zap.String("mirror_in_pipeline", n.pipelineID.String()))
+	queueSize := n.cfg.QueueSize
+	if queueSize == 0 {
+		queueSize = pipelines.DefaultMirrorQueueSize
+	}
+	switch n.pipelineID.Type() {
+	case component.DataTypeTraces:
+		mirror := mirrorconsumer.NewTraces(next.(consumer.Traces), n.cfg.Percent, queueSize, logger)
+		n.baseConsumer, n.mirror = mirror, mirror
+	case component.DataTypeMetrics:
+		mirror := mirrorconsumer.NewMetrics(next.(consumer.Metrics), n.cfg.Percent, queueSize, logger)
+		n.baseConsumer, n.mirror = mirror, mirror
+	case component.DataTypeLogs:
+		mirror := mirrorconsumer.NewLogs(next.(consumer.Logs), n.cfg.Percent, queueSize, logger)
+		n.baseConsumer, n.mirror = mirror, mirror
+	}
+}
@@ -52,6 +52,9 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 				exprIDs = append(exprIDs, n.componentID.String()+" (connector)")
 			}
 		}
+		if p.mirrorExporter != nil {
+			exprIDs = append(exprIDs, p.mirrorExporter.componentID.String()+" (mirror)")
+		}
 
 		sumData.Rows = append(sumData.Rows, zpages.SummaryPipelinesTableRowData{
 			FullName:    c.String(),
@@ -0,0 +1,307 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package isolation recovers from the panics raised while the processors and the exporters consume data,
+// and restarts the panicking components according to the restart policy.
+package isolation // import "go.opentelemetry.io/collector/service/internal/isolation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/panicisolation"
+)
+
+// errRestarting refuses the data while the component is restarted. It is not permanent, so that the data is
+// retried once the component was restarted.
+var errRestarting = errors.New("the component is restarting after a panic")
+
+// Isolator recovers from the panics of the components consuming data, see panicisolation.Config.
+type Isolator struct {
+	cfg  panicisolation.Config
+	host component.Host
+
+	// ctx is canceled when the service shuts down, to cancel the restarts in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu protects stopped, so that no restart begins once the service is shutting down.
+	mu      sync.Mutex
+	stopped bool
+	// restarts waits for the restarts in progress.
+	restarts sync.WaitGroup
+}
+
+// NewIsolator returns an Isolator restarting the components with the given host.
+func NewIsolator(cfg panicisolation.Config, host component.Host) *Isolator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Isolator{cfg: cfg, host: host, ctx: ctx, cancel: cancel}
+}
+
+// Shutdown cancels the restarts of the components, and waits for the restarts in progress.
+// It must be called before the components are shut down.
+func (i *Isolator) Shutdown() {
+	i.mu.Lock()
+	i.stopped = true
+	i.mu.Unlock()
+	i.cancel()
+	i.restarts.Wait()
+}
+
+// beginRestart returns false if the service is shutting down, the restart must be ended with restarts.Done otherwise.
+func (i *Isolator) beginRestart() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.stopped {
+		return false
+	}
+	i.restarts.Add(1)
+	return true
+}
+
+// The states of a guard.
+const (
+	stateRunning int32 = iota
+	stateRestarting
+	// stateFailed refuses the data once the component panicked and cannot be restarted: the panic may have left
+	// the state of the component corrupted, e.g. its locks held, so it must not be called anymore.
+	stateFailed
+)
+
+// guard recovers from the panics of a component and restarts it.
+type guard struct {
+	isolator *Isolator
+	kind     component.Kind
+	id       component.ID
+	// logger identifies the component, see the loggers of the components package.
+	logger *zap.Logger
+	// restartable is nil if the component is not restarted after a panic.
+	restartable panicisolation.Restartable
+
+	// mu is held for reading by the consumptions, and for writing by the restarts to wait for the consumptions
+	// in progress before restarting the component. The restart itself runs without holding mu, the data is
+	// refused meanwhile, so that the restart does not block the data path.
+	mu    sync.RWMutex
+	state atomic.Int32
+	// restarts is only accessed by the goroutine which moved the state out of stateRunning.
+	restarts int
+	// failed is the error refusing the data in stateFailed.
+	failed atomic.Pointer[error]
+}
+
+func (i *Isolator) newGuard(kind component.Kind, id component.ID, logger *zap.Logger, comp component.Component) *guard {
+	g := &guard{isolator: i, kind: kind, id: id, logger: logger}
+	if i.cfg.RestartPolicy == panicisolation.RestartOnPanic {
+		g.restartable, _ = comp.(panicisolation.Restartable)
+	}
+	// The components consuming the data in their own goroutines recover from the panics raised there.
+	if ar, ok := comp.(panicisolation.AsyncRecoverer); ok {
+		ar.RecoverPanics(g.onAsyncPanic)
+	}
+	return g
+}
+
+// consume calls consume, and recovers from its panic. The data is then failed with a permanent error,
+// since consuming it again would most likely panic again, and the component is restarted if enabled.
+func (g *guard) consume(dataType component.DataType, summary func() []zap.Field, consume func() error) error {
+	g.mu.RLock()
+	switch g.state.Load() {
+	case stateRestarting:
+		g.mu.RUnlock()
+		return errRestarting
+	case stateFailed:
+		g.mu.RUnlock()
+		return *g.failed.Load()
+	}
+	recovered, err := g.call(dataType, summary, consume)
+	g.mu.RUnlock()
+	if recovered != nil {
+		g.panicked(recovered)
+	}
+	return err
+}
+
+// call calls consume, recovering from its panic.
+func (g *guard) call(dataType component.DataType, summary func() []zap.Field, consume func() error) (recovered any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			recovered = r
+			fields := append([]zap.Field{zap.Any("panic", r), zap.Stack("stack")}, summary()...)
+			g.logger.Error("Recovered from a panic while consuming data, the data is dropped", fields...)
+			err = consumererror.NewPermanent(fmt.Errorf("panic in %s %q while consuming %s: %v",
+				strings.ToLower(g.kind.String()), g.id, dataType, r))
+		}
+	}()
+	return nil, consume()
+}
+
+// onAsyncPanic is called by the component when it recovered from a panic in one of its goroutines.
+func (g *guard) onAsyncPanic(r any) {
+	g.logger.Error("Recovered from a panic of the component, the data is dropped", zap.Any("panic", r), zap.Stack("stack"))
+	g.panicked(r)
+}
+
+// panicked restarts the component in the background if enabled, or makes it refuse the data otherwise.
+func (g *guard) panicked(r any) {
+	// Only the first of the concurrent panics restarts the component.
+	if !g.state.CompareAndSwap(stateRunning, stateRestarting) {
+		return
+	}
+	switch {
+	case g.restartable == nil:
+		g.fail(fmt.Errorf("refuses the data after a panic: %v", r))
+	case g.restarts >= g.isolator.cfg.MaxRestarts:
+		g.fail(fmt.Errorf("refuses the data after a panic, it was restarted max_restarts times: %v", r))
+	case !g.isolator.beginRestart():
+		g.fail(fmt.Errorf("refuses the data after a panic while the service shuts down: %v", r))
+	default:
+		go g.restart()
+	}
+}
+
+// restart restarts the component once the consumptions in progress are done.
+func (g *guard) restart() {
+	defer g.isolator.restarts.Done()
+	// The consumptions started after the state was set to restarting refuse the data without calling the component.
+	g.mu.Lock()
+	g.restarts++
+	g.mu.Unlock()
+
+	if err := g.restartable.Restart(g.isolator.ctx, g.isolator.host); err != nil {
+		g.fail(fmt.Errorf("failed to restart after a panic: %w", err))
+		return
+	}
+	g.logger.Info("Restarted the component after a panic", zap.Int("restarts", g.restarts))
+	g.state.Store(stateRunning)
+}
+
+// fail makes the component refuse the data with a permanent error wrapping reason.
+func (g *guard) fail(reason error) {
+	err := consumererror.NewPermanent(fmt.Errorf("%s %q %w", strings.ToLower(g.kind.String()), g.id, reason))
+	g.failed.Store(&err)
+	g.state.Store(stateFailed)
+	g.logger.Error("The component refuses the data from now on, since the panic may have corrupted its state", zap.Error(reason))
+}
+
+// Traces returns a consumer.Traces consuming with next, recovering from the panics of the component comp.
+func (i *Isolator) Traces(kind component.Kind, id component.ID, logger *zap.Logger, comp component.Component, next consumer.Traces) consumer.Traces {
+	return isolatedTraces{Traces: next, guard: i.newGuard(kind, id, logger, comp)}
+}
+
+type isolatedTraces struct {
+	consumer.Traces
+	guard *guard
+}
+
+func (it isolatedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_spans", td.ResourceSpans().Len()), zap.Int("spans", td.SpanCount())}
+	}
+	return it.guard.consume(component.DataTypeTraces, summary, func() error {
+		return it.Traces.ConsumeTraces(ctx, td)
+	})
+}
+
+func (it isolatedTraces) ConsumeTracesWithResponse(ctx context.Context, td ptrace.Traces) (consumer.Response, error) {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_spans", td.ResourceSpans().Len()), zap.Int("spans", td.SpanCount())}
+	}
+	// Count before consuming, the data is refused as a whole if the component panics while modifying it.
+	items := td.SpanCount()
+	var rsp consumer.Response
+	err := it.guard.consume(component.DataTypeTraces, summary, func() error {
+		var err error
+		rsp, err = consumer.ConsumeTracesWithResponse(ctx, it.Traces, td)
+		return err
+	})
+	if err != nil && rsp == (consumer.Response{}) {
+		return consumer.ResponseFromError(items, err)
+	}
+	return rsp, err
+}
+
+// Metrics returns a consumer.Metrics consuming with next, recovering from the panics of the component comp.
+func (i *Isolator) Metrics(kind component.Kind, id component.ID, logger *zap.Logger, comp component.Component, next consumer.Metrics) consumer.Metrics {
+	return isolatedMetrics{Metrics: next, guard: i.newGuard(kind, id, logger, comp)}
+}
+
+type isolatedMetrics struct {
+	consumer.Metrics
+	guard *guard
+}
+
+func (im isolatedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_metrics", md.ResourceMetrics().Len()), zap.Int("data_points", md.DataPointCount())}
+	}
+	return im.guard.consume(component.DataTypeMetrics, summary, func() error {
+		return im.Metrics.ConsumeMetrics(ctx, md)
+	})
+}
+
+func (im isolatedMetrics) ConsumeMetricsWithResponse(ctx context.Context, md pmetric.Metrics) (consumer.Response, error) {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_metrics", md.ResourceMetrics().Len()), zap.Int("data_points", md.DataPointCount())}
+	}
+	// Count before consuming, the data is refused as a whole if the component panics while modifying it.
+	items := md.DataPointCount()
+	var rsp consumer.Response
+	err := im.guard.consume(component.DataTypeMetrics, summary, func() error {
+		var err error
+		rsp, err = consumer.ConsumeMetricsWithResponse(ctx, im.Metrics, md)
+		return err
+	})
+	if err != nil && rsp == (consumer.Response{}) {
+		return consumer.ResponseFromError(items, err)
+	}
+	return rsp, err
+}
+
+// Logs returns a consumer.Logs consuming with next, recovering from the panics of the component comp.
+func (i *Isolator) Logs(kind component.Kind, id component.ID, logger *zap.Logger, comp component.Component, next consumer.Logs) consumer.Logs {
+	return isolatedLogs{Logs: next, guard: i.newGuard(kind, id, logger, comp)}
+}
+
+type isolatedLogs struct {
+	consumer.Logs
+	guard *guard
+}
+
+func (il isolatedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_logs", ld.ResourceLogs().Len()), zap.Int("log_records", ld.LogRecordCount())}
+	}
+	return il.guard.consume(component.DataTypeLogs, summary, func() error {
+		return il.Logs.ConsumeLogs(ctx, ld)
+	})
+}
+
+func (il isolatedLogs) ConsumeLogsWithResponse(ctx context.Context, ld plog.Logs) (consumer.Response, error) {
+	summary := func() []zap.Field {
+		return []zap.Field{zap.Int("resource_logs", ld.ResourceLogs().Len()), zap.Int("log_records", ld.LogRecordCount())}
+	}
+	// Count before consuming, the data is refused as a whole if the component panics while modifying it.
+	items := ld.LogRecordCount()
+	var rsp consumer.Response
+	err := il.guard.consume(component.DataTypeLogs, summary, func() error {
+		var err error
+		rsp, err = consumer.ConsumeLogsWithResponse(ctx, il.Logs, ld)
+		return err
+	})
+	if err != nil && rsp == (consumer.Response{}) {
+		return consumer.ResponseFromError(items, err)
+	}
+	return rsp, err
+}
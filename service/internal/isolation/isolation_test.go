@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package isolation
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/panicisolation"
+)
+
+// asyncComponent recovers from the panics of its goroutines.
+type asyncComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+	onPanic func(any)
+}
+
+func (ac *asyncComponent) RecoverPanics(onPanic func(any)) {
+	ac.onPanic = onPanic
+}
+
+// restartableComponent counts its restarts.
+type restartableComponent struct {
+	asyncComponent
+	restarts   atomic.Int32
+	restartErr error
+}
+
+func (rc *restartableComponent) Restart(context.Context, component.Host) error {
+	rc.restarts.Add(1)
+	return rc.restartErr
+}
+
+// panicking panics while consuming the data while panics is true.
+type panicking struct {
+	panics bool
+}
+
+func (p *panicking) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (p *panicking) ConsumeTraces(context.Context, ptrace.Traces) error {
+	if p.panics {
+		panic("boom")
+	}
+	return nil
+}
+
+func (p *panicking) ConsumeMetrics(context.Context, pmetric.Metrics) error {
+	if p.panics {
+		panic("boom")
+	}
+	return nil
+}
+
+func (p *panicking) ConsumeLogs(context.Context, plog.Logs) error {
+	if p.panics {
+		panic("boom")
+	}
+	return nil
+}
+
+func generateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	return td
+}
+
+func newIsolator(policy panicisolation.RestartPolicy, maxRestarts int) *Isolator {
+	return NewIsolator(panicisolation.Config{Enabled: true, RestartPolicy: policy, MaxRestarts: maxRestarts}, componenttest.NewNopHost())
+}
+
+// waitRestarted waits for the restart of the component consuming with c.
+func waitRestarted(t *testing.T, c any) {
+	var g *guard
+	switch c := c.(type) {
+	case isolatedTraces:
+		g = c.guard
+	case isolatedMetrics:
+		g = c.guard
+	case isolatedLogs:
+		g = c.guard
+	}
+	require.Eventually(t, func() bool { return g.state.Load() != stateRestarting }, time.Second, time.Millisecond)
+}
+
+func TestIsolatorRefusesAfterPanic(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	isolator := newIsolator(panicisolation.RestartNever, panicisolation.DefaultMaxRestarts)
+	next := &panicking{panics: true}
+	tc := isolator.Traces(component.KindProcessor, component.MustNewID("batch"), zap.New(core), &asyncComponent{}, next)
+
+	err := tc.ConsumeTraces(context.Background(), generateTraces())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `panic in processor "batch" while consuming traces: boom`)
+
+	entries := logs.FilterMessage("Recovered from a panic while consuming data, the data is dropped").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(1), entries[0].ContextMap()["spans"])
+	assert.Equal(t, 1, logs.FilterMessage("The component refuses the data from now on, since the panic may have corrupted its state").Len())
+
+	// The state of the component may be corrupted, the data is refused without calling it.
+	next.panics = false
+	err = tc.ConsumeTraces(context.Background(), generateTraces())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `processor "batch" refuses the data after a panic: boom`)
+}
+
+func TestIsolatorResponseAfterPanic(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartNever, panicisolation.DefaultMaxRestarts)
+	tc := isolator.Traces(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), &asyncComponent{}, &panicking{panics: true})
+
+	// The data is rejected as a whole when the component panics.
+	rsp, err := consumer.ConsumeTracesWithResponse(context.Background(), tc, generateTraces())
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, rsp.Rejected)
+	assert.Zero(t, rsp.Accepted)
+}
+
+func TestIsolatorRefusesAfterPanicPerSignal(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartNever, panicisolation.DefaultMaxRestarts)
+	next := &panicking{panics: true}
+	mc := isolator.Metrics(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), &asyncComponent{}, next)
+	lc := isolator.Logs(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), &asyncComponent{}, next)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	err := mc.ConsumeMetrics(context.Background(), md)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `panic in exporter "otlp" while consuming metrics: boom`)
+	err = lc.ConsumeLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `panic in exporter "otlp" while consuming logs: boom`)
+
+	next.panics = false
+	assert.Error(t, mc.ConsumeMetrics(context.Background(), md))
+	assert.Error(t, lc.ConsumeLogs(context.Background(), ld))
+}
+
+func TestIsolatorAsyncPanic(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	isolator := newIsolator(panicisolation.RestartNever, panicisolation.DefaultMaxRestarts)
+	comp := &asyncComponent{}
+	sink := new(consumertest.TracesSink)
+	tc := isolator.Traces(component.KindExporter, component.MustNewID("otlp"), zap.New(core), comp, sink)
+	require.NotNil(t, comp.onPanic)
+
+	require.NoError(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	comp.onPanic("boom")
+	assert.Equal(t, 1, logs.FilterMessage("Recovered from a panic of the component, the data is dropped").Len())
+
+	err := tc.ConsumeTraces(context.Background(), generateTraces())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `exporter "otlp" refuses the data after a panic: boom`)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestIsolatorPassesThrough(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartNever, panicisolation.DefaultMaxRestarts)
+	sink := new(consumertest.TracesSink)
+	comp := struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}
+	tc := isolator.Traces(component.KindProcessor, component.MustNewID("batch"), zap.NewNop(), comp, sink)
+
+	require.NoError(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.Equal(t, sink.Capabilities(), tc.Capabilities())
+}
+
+func TestIsolatorRestartsOnPanic(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	isolator := newIsolator(panicisolation.RestartOnPanic, 2)
+	comp := &restartableComponent{}
+	next := &panicking{panics: true}
+	mc := isolator.Metrics(component.KindExporter, component.MustNewID("otlp"), zap.New(core), comp, next)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	for i := 0; i < 3; i++ {
+		err := mc.ConsumeMetrics(context.Background(), md)
+		require.Error(t, err)
+		assert.True(t, consumererror.IsPermanent(err))
+		waitRestarted(t, mc)
+	}
+	// The third panic exceeds max_restarts, the component refuses the data afterwards.
+	assert.Equal(t, int32(2), comp.restarts.Load())
+	assert.Equal(t, 2, logs.FilterMessage("Restarted the component after a panic").Len())
+
+	next.panics = false
+	err := mc.ConsumeMetrics(context.Background(), md)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `exporter "otlp" refuses the data after a panic, it was restarted max_restarts times: boom`)
+	isolator.Shutdown()
+}
+
+func TestIsolatorAcceptsAfterRestart(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 1)
+	comp := &restartableComponent{}
+	next := &panicking{panics: true}
+	tc := isolator.Traces(component.KindProcessor, component.MustNewID("batch"), zap.NewNop(), comp, next)
+
+	require.Error(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	waitRestarted(t, tc)
+	next.panics = false
+	require.NoError(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	assert.Equal(t, int32(1), comp.restarts.Load())
+	isolator.Shutdown()
+}
+
+func TestIsolatorRefusesWhileRestarting(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 1)
+	next := &panicking{panics: true}
+	tc := isolator.Traces(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), &restartableComponent{}, next)
+
+	// Holding the lock of the consumptions delays the restart.
+	g := tc.(isolatedTraces).guard
+	require.Error(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	g.mu.RLock()
+	require.Equal(t, stateRestarting, g.state.Load())
+	next.panics = false
+	err := tc.ConsumeTraces(context.Background(), generateTraces())
+	assert.ErrorIs(t, err, errRestarting)
+	assert.False(t, consumererror.IsPermanent(err))
+	g.mu.RUnlock()
+
+	waitRestarted(t, tc)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	isolator.Shutdown()
+}
+
+func TestIsolatorRestartsOnAsyncPanic(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 1)
+	comp := &restartableComponent{}
+	sink := new(consumertest.TracesSink)
+	tc := isolator.Traces(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), comp, sink)
+
+	comp.onPanic("boom")
+	waitRestarted(t, tc)
+	assert.Equal(t, int32(1), comp.restarts.Load())
+	require.NoError(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	assert.Equal(t, 1, sink.SpanCount())
+	isolator.Shutdown()
+}
+
+func TestIsolatorRestartFailure(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 3)
+	comp := &restartableComponent{restartErr: errors.New("cannot restart")}
+	next := &panicking{panics: true}
+	lc := isolator.Logs(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), comp, next)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.Error(t, lc.ConsumeLogs(context.Background(), ld))
+	waitRestarted(t, lc)
+	assert.Equal(t, int32(1), comp.restarts.Load())
+
+	// The component failed to restart, the data is refused.
+	next.panics = false
+	err := lc.ConsumeLogs(context.Background(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `exporter "otlp" failed to restart after a panic: cannot restart`)
+	isolator.Shutdown()
+}
+
+func TestIsolatorNotRestartable(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 3)
+	next := &panicking{panics: true}
+	tc := isolator.Traces(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), &asyncComponent{}, next)
+
+	require.Error(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	next.panics = false
+	err := tc.ConsumeTraces(context.Background(), generateTraces())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `exporter "otlp" refuses the data after a panic: boom`)
+	isolator.Shutdown()
+}
+
+func TestIsolatorShutdownStopsRestarts(t *testing.T) {
+	isolator := newIsolator(panicisolation.RestartOnPanic, 3)
+	comp := &restartableComponent{}
+	tc := isolator.Traces(component.KindProcessor, component.MustNewID("batch"), zap.NewNop(), comp, &panicking{panics: true})
+
+	isolator.Shutdown()
+	require.Error(t, tc.ConsumeTraces(context.Background(), generateTraces()))
+	err := tc.ConsumeTraces(context.Background(), generateTraces())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `processor "batch" refuses the data after a panic while the service shuts down: boom`)
+	assert.Equal(t, int32(0), comp.restarts.Load())
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mirrorconsumer // import "go.opentelemetry.io/collector/service/internal/mirrorconsumer"
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// dropReason is the reason logged with the summaries of the mirrored data dropped when the buffer is full.
+const dropReason = "mirror buffer is full"
+
+type bufferedData[T any] struct {
+	ctx  context.Context
+	data T
+}
+
+// buffer sends the mirrored data to the next consumer from a goroutine, so that a slow or blocked mirror
+// exporter does not slow the pipeline down. The data is dropped when the buffer is full.
+type buffer[T any] struct {
+	queue   chan bufferedData[T]
+	consume func(context.Context, T) error
+	drop    func(T)
+	logger  *zap.Logger
+	done    chan struct{}
+
+	mu      sync.RWMutex
+	started bool
+	stopped bool
+}
+
+func newBuffer[T any](size int, consume func(context.Context, T) error, drop func(T), logger *zap.Logger) *buffer[T] {
+	return &buffer[T]{
+		queue:   make(chan bufferedData[T], size),
+		consume: consume,
+		drop:    drop,
+		logger:  logger,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the goroutine sending the buffered data.
+func (b *buffer[T]) Start(context.Context, component.Host) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.started || b.stopped {
+		return nil
+	}
+	b.started = true
+	go func() {
+		defer close(b.done)
+		for bd := range b.queue {
+			if err := b.consume(bd.ctx, bd.data); err != nil {
+				b.logger.Debug("Failed to mirror data", zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops accepting data and waits until the buffered data is sent, or until ctx is done.
+func (b *buffer[T]) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return nil
+	}
+	b.stopped = true
+	close(b.queue)
+	started := b.started
+	b.mu.Unlock()
+
+	if !started {
+		for bd := range b.queue {
+			b.drop(bd.data)
+		}
+		return nil
+	}
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// send buffers the data, or drops it if the buffer is full or shut down. The data is sent with
+// the values of ctx, after the pipeline has returned.
+func (b *buffer[T]) send(ctx context.Context, data T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.stopped {
+		b.drop(data)
+		return
+	}
+	select {
+	case b.queue <- bufferedData[T]{ctx: context.WithoutCancel(ctx), data: data}:
+	default:
+		b.drop(data)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mirrorconsumer implements the consumers mirroring a share of the data of a pipeline to
+// a secondary exporter, without affecting the pipeline.
+package mirrorconsumer // import "go.opentelemetry.io/collector/service/internal/mirrorconsumer"
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/droplog"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Traces is a consumer.Traces mirroring the data, which must be started and shut down.
+type Traces interface {
+	component.Component
+	consumer.Traces
+}
+
+// Metrics is a consumer.Metrics mirroring the data, which must be started and shut down.
+type Metrics interface {
+	component.Component
+	consumer.Metrics
+}
+
+// Logs is a consumer.Logs mirroring the data, which must be started and shut down.
+type Logs interface {
+	component.Component
+	consumer.Logs
+}
+
+// sampler decides which data is mirrored.
+type sampler struct {
+	// threshold is the percentage of mirrored data, in hundredths of a percent.
+	threshold uint32
+}
+
+func newSampler(percent float64) sampler {
+	return sampler{threshold: uint32(percent * 100)}
+}
+
+// sampleTraceID returns whether the data of the trace is mirrored. The decision only
+// depends on the trace ID, so all the spans of a trace are mirrored or none are.
+func (s sampler) sampleTraceID(id pcommon.TraceID) bool {
+	h := fnv.New32a()
+	_, _ = h.Write(id[:])
+	return h.Sum32()%10000 < s.threshold
+}
+
+// sampleBatch returns whether the data without trace ID of a batch is mirrored.
+func (s sampler) sampleBatch() bool {
+	// #nosec G404 -- The sampling does not need a cryptographically secure random generator.
+	return uint32(rand.Intn(10000)) < s.threshold
+}
+
+// NewTraces returns a consumer.Traces sending the spans of percent of the traces to next.
+// The data is sent to next asynchronously through a buffer of queueSize batches, and dropped when
+// the buffer is full. The errors of next are logged and not returned.
+func NewTraces(next consumer.Traces, percent float64, queueSize int, logger *zap.Logger) Traces {
+	dropLog := droplog.New(logger)
+	return mirrorTraces{
+		sampler: newSampler(percent),
+		buffer: newBuffer(queueSize, next.ConsumeTraces, func(data ptrace.Traces) {
+			dropLog.Traces(dropReason, data)
+		}, logger),
+	}
+}
+
+type mirrorTraces struct {
+	sampler
+	*buffer[ptrace.Traces]
+}
+
+// Capabilities returns that the data is not mutated, next is given a copy of it.
+func (mt mirrorTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (mt mirrorTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	mirrored := ptrace.NewTraces()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		var destRS ptrace.ResourceSpans
+		hasDestRS := false
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			var destSS ptrace.ScopeSpans
+			hasDestSS := false
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				if !mt.sampleTraceID(span.TraceID()) {
+					continue
+				}
+				if !hasDestRS {
+					destRS, hasDestRS = mirrored.ResourceSpans().AppendEmpty(), true
+					rs.Resource().CopyTo(destRS.Resource())
+					destRS.SetSchemaUrl(rs.SchemaUrl())
+				}
+				if !hasDestSS {
+					destSS, hasDestSS = destRS.ScopeSpans().AppendEmpty(), true
+					ss.Scope().CopyTo(destSS.Scope())
+					destSS.SetSchemaUrl(ss.SchemaUrl())
+				}
+				span.CopyTo(destSS.Spans().AppendEmpty())
+			}
+		}
+	}
+	if mirrored.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	mt.send(ctx, mirrored)
+	return nil
+}
+
+// NewMetrics returns a consumer.Metrics sending percent of the batches to next.
+// The data is sent to next asynchronously through a buffer of queueSize batches, and dropped when
+// the buffer is full. The errors of next are logged and not returned.
+func NewMetrics(next consumer.Metrics, percent float64, queueSize int, logger *zap.Logger) Metrics {
+	dropLog := droplog.New(logger)
+	return mirrorMetrics{
+		sampler: newSampler(percent),
+		buffer: newBuffer(queueSize, next.ConsumeMetrics, func(data pmetric.Metrics) {
+			dropLog.Metrics(dropReason, data)
+		}, logger),
+	}
+}
+
+type mirrorMetrics struct {
+	sampler
+	*buffer[pmetric.Metrics]
+}
+
+// Capabilities returns that the data is not mutated, next is given a copy of it.
+func (mm mirrorMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (mm mirrorMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if !mm.sampleBatch() {
+		return nil
+	}
+	mirrored := pmetric.NewMetrics()
+	md.CopyTo(mirrored)
+	mm.send(ctx, mirrored)
+	return nil
+}
+
+// NewLogs returns a consumer.Logs sending the log records of percent of the traces to next.
+// The log records without trace ID are sent for percent of the batches.
+// The data is sent to next asynchronously through a buffer of queueSize batches, and dropped when
+// the buffer is full. The errors of next are logged and not returned.
+func NewLogs(next consumer.Logs, percent float64, queueSize int, logger *zap.Logger) Logs {
+	dropLog := droplog.New(logger)
+	return mirrorLogs{
+		sampler: newSampler(percent),
+		buffer: newBuffer(queueSize, next.ConsumeLogs, func(data plog.Logs) {
+			dropLog.Logs(dropReason, data)
+		}, logger),
+	}
+}
+
+type mirrorLogs struct {
+	sampler
+	*buffer[plog.Logs]
+}
+
+// Capabilities returns that the data is not mutated, next is given a copy of it.
+func (ml mirrorLogs) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (ml mirrorLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	batchSampled := ml.sampleBatch()
+	mirrored := plog.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var destRL plog.ResourceLogs
+		hasDestRL := false
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			var destSL plog.ScopeLogs
+			hasDestSL := false
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				if lr.TraceID().IsEmpty() {
+					if !batchSampled {
+						continue
+					}
+				} else if !ml.sampleTraceID(lr.TraceID()) {
+					continue
+				}
+				if !hasDestRL {
+					destRL, hasDestRL = mirrored.ResourceLogs().AppendEmpty(), true
+					rl.Resource().CopyTo(destRL.Resource())
+					destRL.SetSchemaUrl(rl.SchemaUrl())
+				}
+				if !hasDestSL {
+					destSL, hasDestSL = destRL.ScopeLogs().AppendEmpty(), true
+					sl.Scope().CopyTo(destSL.Scope())
+					destSL.SetSchemaUrl(sl.SchemaUrl())
+				}
+				lr.CopyTo(destSL.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	if mirrored.ResourceLogs().Len() == 0 {
+		return nil
+	}
+	ml.send(ctx, mirrored)
+	return nil
+}
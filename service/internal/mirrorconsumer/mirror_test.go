@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mirrorconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// start starts the mirror and shuts it down at the end of the test.
+func start(t *testing.T, mirror component.Component) {
+	require.NoError(t, mirror.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, mirror.Shutdown(context.Background()))
+	})
+}
+
+// generateTraces returns traces with two spans for each of the numTraces traces, spread over two scopes.
+func generateTraces(numTraces int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "test")
+	for s := 0; s < 2; s++ {
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for i := 0; i < numTraces; i++ {
+			span := ss.Spans().AppendEmpty()
+			span.SetTraceID(pcommon.TraceID{byte(i), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, byte(i)})
+			span.SetName("span")
+		}
+	}
+	return td
+}
+
+func TestTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	mirror := NewTraces(sink, 50, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, mirror.Capabilities())
+	require.NoError(t, mirror.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := generateTraces(100)
+	require.NoError(t, mirror.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, generateTraces(100), td)
+	// The shutdown waits until the buffered data is sent.
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	require.Len(t, sink.AllTraces(), 1)
+
+	s := newSampler(50)
+	expected := map[pcommon.TraceID]int{}
+	for i := 0; i < 100; i++ {
+		id := pcommon.TraceID{byte(i), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, byte(i)}
+		if s.sampleTraceID(id) {
+			expected[id] = 2
+		}
+	}
+	assert.Greater(t, len(expected), 20)
+	assert.Less(t, len(expected), 80)
+
+	// All the spans of the sampled traces are mirrored, keeping their resource and scope.
+	mirrored := sink.AllTraces()[0]
+	actual := map[pcommon.TraceID]int{}
+	require.Equal(t, 1, mirrored.ResourceSpans().Len())
+	rs := mirrored.ResourceSpans().At(0)
+	assert.Equal(t, td.ResourceSpans().At(0).Resource(), rs.Resource())
+	require.Equal(t, 2, rs.ScopeSpans().Len())
+	for i := 0; i < rs.ScopeSpans().Len(); i++ {
+		ss := rs.ScopeSpans().At(i)
+		assert.Equal(t, "scope", ss.Scope().Name())
+		for j := 0; j < ss.Spans().Len(); j++ {
+			actual[ss.Spans().At(j).TraceID()]++
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestTracesNoneSampled(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	mirror := NewTraces(sink, 0.01, 10, zap.NewNop())
+	start(t, mirror)
+	s := newSampler(0.01)
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	for i := 0; ; i++ {
+		id := pcommon.TraceID{byte(i), byte(i >> 8)}
+		if !s.sampleTraceID(id) {
+			span.SetTraceID(id)
+			break
+		}
+	}
+	require.NoError(t, mirror.ConsumeTraces(context.Background(), td))
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mirror := NewMetrics(sink, 100, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, mirror.Capabilities())
+	start(t, mirror)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, mirror.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, md, sink.AllMetrics()[0])
+
+	// The mirror is given a copy of the data.
+	sink.AllMetrics()[0].ResourceMetrics().AppendEmpty()
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
+
+func TestLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	mirror := NewLogs(sink, 100, 10, zap.NewNop())
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, mirror.Capabilities())
+	start(t, mirror)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("without trace")
+	lr := lrs.AppendEmpty()
+	lr.Body().SetStr("with trace")
+	lr.SetTraceID(pcommon.TraceID{1, 2, 3})
+	require.NoError(t, mirror.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, ld, sink.AllLogs()[0])
+}
+
+func TestErrorsIgnored(t *testing.T) {
+	err := errors.New("mirror failed")
+	tm := NewTraces(consumertest.NewErr(err), 100, 10, zap.NewNop())
+	start(t, tm)
+	assert.NoError(t, tm.ConsumeTraces(context.Background(), generateTraces(1)))
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	mm := NewMetrics(consumertest.NewErr(err), 100, 10, zap.NewNop())
+	start(t, mm)
+	assert.NoError(t, mm.ConsumeMetrics(context.Background(), md))
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	ml := NewLogs(consumertest.NewErr(err), 100, 10, zap.NewNop())
+	start(t, ml)
+	assert.NoError(t, ml.ConsumeLogs(context.Background(), ld))
+}
+
+// blockingMetrics blocks the consumption until unblocked.
+type blockingMetrics struct {
+	consumertest.MetricsSink
+	consuming chan struct{}
+	unblock   chan struct{}
+}
+
+func (bm *blockingMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	bm.consuming <- struct{}{}
+	<-bm.unblock
+	return bm.MetricsSink.ConsumeMetrics(ctx, md)
+}
+
+func TestBufferFull(t *testing.T) {
+	next := &blockingMetrics{consuming: make(chan struct{}, 1), unblock: make(chan struct{})}
+	core, observed := observer.New(zap.DebugLevel)
+	mirror := NewMetrics(next, 100, 1, zap.New(core))
+	start(t, mirror)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	// The first batch is being sent, the second one is buffered, and the third one is dropped without
+	// blocking the pipeline.
+	require.NoError(t, mirror.ConsumeMetrics(context.Background(), md))
+	<-next.consuming
+	require.NoError(t, mirror.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, mirror.ConsumeMetrics(context.Background(), md))
+	dropped := observed.FilterMessage("Dropped data.").All()
+	require.Len(t, dropped, 1)
+	assert.Equal(t, dropReason, dropped[0].ContextMap()["reason"])
+
+	close(next.unblock)
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	assert.Len(t, next.AllMetrics(), 2)
+
+	// The data consumed after the shutdown is dropped.
+	require.NoError(t, mirror.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 2)
+}
+
+func TestShutdownNotStarted(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	mirror := NewLogs(sink, 100, 10, zap.NewNop())
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, mirror.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, mirror.Shutdown(context.Background()))
+	assert.Empty(t, sink.AllLogs())
+}
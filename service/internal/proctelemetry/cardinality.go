@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// overflowSet is the attribute set of the data point aggregating the measurements over the cardinality limit,
+// as defined by the OpenTelemetry specification.
+var overflowSet = attribute.NewSet(attribute.Bool("otel.metric.overflow", true))
+
+// LimitCardinality returns a MeterProvider recording at most limit attribute sets per instrument of mp,
+// the last one being the overflow set aggregating the measurements of the attribute sets over the limit.
+// The attributes filtered out by the views of the high cardinality instrumentations do not count towards
+// the limit when disableHighCardinality is set. A limit lower than or equal to zero disables the limit.
+func LimitCardinality(mp metric.MeterProvider, limit int, disableHighCardinality bool) metric.MeterProvider {
+	if limit <= 0 {
+		return mp
+	}
+	return &limitedMeterProvider{MeterProvider: mp, limit: limit, disableHighCardinality: disableHighCardinality}
+}
+
+type limitedMeterProvider struct {
+	metric.MeterProvider
+	limit                  int
+	disableHighCardinality bool
+}
+
+func (mp *limitedMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	m := &limitedMeter{Meter: mp.MeterProvider.Meter(name, opts...), limit: mp.limit}
+	if mp.disableHighCardinality {
+		switch name {
+		case GRPCInstrumentation:
+			m.filter = cardinalityFilter(GRPCUnacceptableKeyValues...)
+		case HTTPInstrumentation:
+			m.filter = cardinalityFilter(HTTPUnacceptableKeyValues...)
+		}
+	}
+	return m
+}
+
+// attributeLimiter tracks the attribute sets recorded by an instrument.
+type attributeLimiter struct {
+	limit  int
+	filter attribute.Filter
+
+	mu   sync.Mutex
+	sets map[attribute.Distinct]struct{}
+}
+
+func newAttributeLimiter(limit int, filter attribute.Filter) *attributeLimiter {
+	return &attributeLimiter{limit: limit, filter: filter, sets: map[attribute.Distinct]struct{}{}}
+}
+
+// attributes returns the option recording the measurement with set, or with the overflow set if set is
+// over the limit.
+func (l *attributeLimiter) attributes(set attribute.Set) metric.MeasurementOption {
+	if l.filter != nil {
+		set, _ = set.Filter(l.filter)
+	}
+	key := set.Equivalent()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.sets[key]; !ok {
+		// One data point is kept for the overflow set.
+		if len(l.sets) >= l.limit-1 {
+			return metric.WithAttributeSet(overflowSet)
+		}
+		l.sets[key] = struct{}{}
+	}
+	return metric.WithAttributeSet(set)
+}
+
+func (l *attributeLimiter) int64Callback(cb metric.Int64Callback) metric.Int64Callback {
+	return func(ctx context.Context, o metric.Int64Observer) error {
+		return cb(ctx, &limitedInt64Observer{Int64Observer: o, limiter: l})
+	}
+}
+
+func (l *attributeLimiter) float64Callback(cb metric.Float64Callback) metric.Float64Callback {
+	return func(ctx context.Context, o metric.Float64Observer) error {
+		return cb(ctx, &limitedFloat64Observer{Float64Observer: o, limiter: l})
+	}
+}
+
+type limitedMeter struct {
+	metric.Meter
+	limit  int
+	filter attribute.Filter
+}
+
+func (m *limitedMeter) newLimiter() *attributeLimiter {
+	return newAttributeLimiter(m.limit, m.filter)
+}
+
+func (m *limitedMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	inst, err := m.Meter.Int64Counter(name, options...)
+	return &limitedInt64Counter{Int64Counter: inst, limiter: m.newLimiter()}, err
+}
+
+func (m *limitedMeter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	inst, err := m.Meter.Int64UpDownCounter(name, options...)
+	return &limitedInt64UpDownCounter{Int64UpDownCounter: inst, limiter: m.newLimiter()}, err
+}
+
+func (m *limitedMeter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	inst, err := m.Meter.Int64Histogram(name, options...)
+	return &limitedInt64Histogram{Int64Histogram: inst, limiter: m.newLimiter()}, err
+}
+
+func (m *limitedMeter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	inst, err := m.Meter.Float64Counter(name, options...)
+	return &limitedFloat64Counter{Float64Counter: inst, limiter: m.newLimiter()}, err
+}
+
+func (m *limitedMeter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	inst, err := m.Meter.Float64UpDownCounter(name, options...)
+	return &limitedFloat64UpDownCounter{Float64UpDownCounter: inst, limiter: m.newLimiter()}, err
+}
+
+func (m *limitedMeter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	inst, err := m.Meter.Float64Histogram(name, options...)
+	return &limitedFloat64Histogram{Float64Histogram: inst, limiter: m.newLimiter()}, err
+}
+
+// The observable instruments are created with the callbacks of their options wrapped, so that the
+// observations go through the limiter of the instrument.
+
+func (m *limitedMeter) Int64ObservableCounter(name string, options ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	l := m.newLimiter()
+	cfg := metric.NewInt64ObservableCounterConfig(options...)
+	opts := []metric.Int64ObservableCounterOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithInt64Callback(l.int64Callback(cb)))
+	}
+	inst, err := m.Meter.Int64ObservableCounter(name, opts...)
+	return &limitedInt64ObservableCounter{Int64ObservableCounter: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) Int64ObservableUpDownCounter(name string, options ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	l := m.newLimiter()
+	cfg := metric.NewInt64ObservableUpDownCounterConfig(options...)
+	opts := []metric.Int64ObservableUpDownCounterOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithInt64Callback(l.int64Callback(cb)))
+	}
+	inst, err := m.Meter.Int64ObservableUpDownCounter(name, opts...)
+	return &limitedInt64ObservableUpDownCounter{Int64ObservableUpDownCounter: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	l := m.newLimiter()
+	cfg := metric.NewInt64ObservableGaugeConfig(options...)
+	opts := []metric.Int64ObservableGaugeOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithInt64Callback(l.int64Callback(cb)))
+	}
+	inst, err := m.Meter.Int64ObservableGauge(name, opts...)
+	return &limitedInt64ObservableGauge{Int64ObservableGauge: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) Float64ObservableCounter(name string, options ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	l := m.newLimiter()
+	cfg := metric.NewFloat64ObservableCounterConfig(options...)
+	opts := []metric.Float64ObservableCounterOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithFloat64Callback(l.float64Callback(cb)))
+	}
+	inst, err := m.Meter.Float64ObservableCounter(name, opts...)
+	return &limitedFloat64ObservableCounter{Float64ObservableCounter: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) Float64ObservableUpDownCounter(name string, options ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	l := m.newLimiter()
+	cfg := metric.NewFloat64ObservableUpDownCounterConfig(options...)
+	opts := []metric.Float64ObservableUpDownCounterOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithFloat64Callback(l.float64Callback(cb)))
+	}
+	inst, err := m.Meter.Float64ObservableUpDownCounter(name, opts...)
+	return &limitedFloat64ObservableUpDownCounter{Float64ObservableUpDownCounter: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	l := m.newLimiter()
+	cfg := metric.NewFloat64ObservableGaugeConfig(options...)
+	opts := []metric.Float64ObservableGaugeOption{metric.WithDescription(cfg.Description()), metric.WithUnit(cfg.Unit())}
+	for _, cb := range cfg.Callbacks() {
+		opts = append(opts, metric.WithFloat64Callback(l.float64Callback(cb)))
+	}
+	inst, err := m.Meter.Float64ObservableGauge(name, opts...)
+	return &limitedFloat64ObservableGauge{Float64ObservableGauge: inst, limiter: l}, err
+}
+
+func (m *limitedMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	unwrapped := make([]metric.Observable, len(instruments))
+	for i, inst := range instruments {
+		unwrapped[i] = inst
+		if lo, ok := inst.(limitedObservable); ok {
+			unwrapped[i], _ = lo.unwrap()
+		}
+	}
+	return m.Meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return f(ctx, &limitedObserver{Observer: o})
+	}, unwrapped...)
+}
+
+type limitedInt64Counter struct {
+	metric.Int64Counter
+	limiter *attributeLimiter
+}
+
+func (c *limitedInt64Counter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.Int64Counter.Add(ctx, incr, c.limiter.attributes(metric.NewAddConfig(options).Attributes()))
+}
+
+type limitedInt64UpDownCounter struct {
+	metric.Int64UpDownCounter
+	limiter *attributeLimiter
+}
+
+func (c *limitedInt64UpDownCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.Int64UpDownCounter.Add(ctx, incr, c.limiter.attributes(metric.NewAddConfig(options).Attributes()))
+}
+
+type limitedInt64Histogram struct {
+	metric.Int64Histogram
+	limiter *attributeLimiter
+}
+
+func (h *limitedInt64Histogram) Record(ctx context.Context, incr int64, options ...metric.RecordOption) {
+	h.Int64Histogram.Record(ctx, incr, h.limiter.attributes(metric.NewRecordConfig(options).Attributes()))
+}
+
+type limitedFloat64Counter struct {
+	metric.Float64Counter
+	limiter *attributeLimiter
+}
+
+func (c *limitedFloat64Counter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	c.Float64Counter.Add(ctx, incr, c.limiter.attributes(metric.NewAddConfig(options).Attributes()))
+}
+
+type limitedFloat64UpDownCounter struct {
+	metric.Float64UpDownCounter
+	limiter *attributeLimiter
+}
+
+func (c *limitedFloat64UpDownCounter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	c.Float64UpDownCounter.Add(ctx, incr, c.limiter.attributes(metric.NewAddConfig(options).Attributes()))
+}
+
+type limitedFloat64Histogram struct {
+	metric.Float64Histogram
+	limiter *attributeLimiter
+}
+
+func (h *limitedFloat64Histogram) Record(ctx context.Context, incr float64, options ...metric.RecordOption) {
+	h.Float64Histogram.Record(ctx, incr, h.limiter.attributes(metric.NewRecordConfig(options).Attributes()))
+}
+
+// limitedObservable is implemented by the observable instruments created by a limitedMeter, it returns
+// the instrument created by the underlying meter and the limiter of the instrument.
+type limitedObservable interface {
+	unwrap() (metric.Observable, *attributeLimiter)
+}
+
+type limitedInt64ObservableCounter struct {
+	metric.Int64ObservableCounter
+	limiter *attributeLimiter
+}
+
+func (o *limitedInt64ObservableCounter) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Int64ObservableCounter, o.limiter
+}
+
+type limitedInt64ObservableUpDownCounter struct {
+	metric.Int64ObservableUpDownCounter
+	limiter *attributeLimiter
+}
+
+func (o *limitedInt64ObservableUpDownCounter) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Int64ObservableUpDownCounter, o.limiter
+}
+
+type limitedInt64ObservableGauge struct {
+	metric.Int64ObservableGauge
+	limiter *attributeLimiter
+}
+
+func (o *limitedInt64ObservableGauge) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Int64ObservableGauge, o.limiter
+}
+
+type limitedFloat64ObservableCounter struct {
+	metric.Float64ObservableCounter
+	limiter *attributeLimiter
+}
+
+func (o *limitedFloat64ObservableCounter) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Float64ObservableCounter, o.limiter
+}
+
+type limitedFloat64ObservableUpDownCounter struct {
+	metric.Float64ObservableUpDownCounter
+	limiter *attributeLimiter
+}
+
+func (o *limitedFloat64ObservableUpDownCounter) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Float64ObservableUpDownCounter, o.limiter
+}
+
+type limitedFloat64ObservableGauge struct {
+	metric.Float64ObservableGauge
+	limiter *attributeLimiter
+}
+
+func (o *limitedFloat64ObservableGauge) unwrap() (metric.Observable, *attributeLimiter) {
+	return o.Float64ObservableGauge, o.limiter
+}
+
+type limitedInt64Observer struct {
+	metric.Int64Observer
+	limiter *attributeLimiter
+}
+
+func (o *limitedInt64Observer) Observe(value int64, options ...metric.ObserveOption) {
+	o.Int64Observer.Observe(value, o.limiter.attributes(metric.NewObserveConfig(options).Attributes()))
+}
+
+type limitedFloat64Observer struct {
+	metric.Float64Observer
+	limiter *attributeLimiter
+}
+
+func (o *limitedFloat64Observer) Observe(value float64, options ...metric.ObserveOption) {
+	o.Float64Observer.Observe(value, o.limiter.attributes(metric.NewObserveConfig(options).Attributes()))
+}
+
+// limitedObserver records the observations of the instruments created by a limitedMeter with the
+// underlying instruments, through their limiter.
+type limitedObserver struct {
+	metric.Observer
+}
+
+func (o *limitedObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	lo, ok := obsrv.(limitedObservable)
+	if !ok {
+		o.Observer.ObserveInt64(obsrv, value, opts...)
+		return
+	}
+	inst, limiter := lo.unwrap()
+	o.Observer.ObserveInt64(inst.(metric.Int64Observable), value, limiter.attributes(metric.NewObserveConfig(opts).Attributes()))
+}
+
+func (o *limitedObserver) ObserveFloat64(obsrv metric.Float64Observable, value float64, opts ...metric.ObserveOption) {
+	lo, ok := obsrv.(limitedObservable)
+	if !ok {
+		o.Observer.ObserveFloat64(obsrv, value, opts...)
+		return
+	}
+	inst, limiter := lo.unwrap()
+	o.Observer.ObserveFloat64(inst.(metric.Float64Observable), value, limiter.attributes(metric.NewObserveConfig(opts).Attributes()))
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string][]attribute.Set {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	sets := map[string][]attribute.Set{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sets[m.Name] = append(sets[m.Name], dp.Attributes)
+				}
+			case metricdata.Gauge[float64]:
+				for _, dp := range data.DataPoints {
+					sets[m.Name] = append(sets[m.Name], dp.Attributes)
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					sets[m.Name] = append(sets[m.Name], dp.Attributes)
+				}
+			}
+		}
+	}
+	return sets
+}
+
+func TestLimitCardinality(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := LimitCardinality(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), 3, false)
+	meter := mp.Meter("test")
+
+	counter, err := meter.Int64Counter("counter")
+	require.NoError(t, err)
+	histogram, err := meter.Float64Histogram("histogram")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("exporter", strconv.Itoa(i))))
+		histogram.Record(context.Background(), 1, metric.WithAttributes(attribute.String("exporter", strconv.Itoa(i))))
+	}
+	// The attribute sets recorded before reaching the limit are still recorded.
+	counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("exporter", "0")))
+
+	_, err = meter.Float64ObservableGauge("gauge", metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+		for i := 0; i < 5; i++ {
+			o.Observe(1, metric.WithAttributes(attribute.String("exporter", strconv.Itoa(i))))
+		}
+		return nil
+	}))
+	require.NoError(t, err)
+	registered, err := meter.Float64ObservableGauge("registered")
+	require.NoError(t, err)
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for i := 0; i < 5; i++ {
+			o.ObserveFloat64(registered, 1, metric.WithAttributes(attribute.String("exporter", strconv.Itoa(i))))
+		}
+		return nil
+	}, registered)
+	require.NoError(t, err)
+
+	expected := []attribute.Set{
+		attribute.NewSet(attribute.String("exporter", "0")),
+		attribute.NewSet(attribute.String("exporter", "1")),
+		overflowSet,
+	}
+	sets := collect(t, reader)
+	for _, name := range []string{"counter", "histogram", "gauge", "registered"} {
+		assert.ElementsMatch(t, expected, sets[name], name)
+	}
+}
+
+func TestLimitCardinalityFiltersHighCardinality(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := LimitCardinality(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), 2, true)
+
+	// The peer addresses do not count towards the limit.
+	counter, err := mp.Meter(GRPCInstrumentation).Int64Counter("counter")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		counter.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String("rpc.method", "Export"),
+			GRPCUnacceptableKeyValues[0].Key.String(strconv.Itoa(i))))
+	}
+	assert.Equal(t, []attribute.Set{attribute.NewSet(attribute.String("rpc.method", "Export"))}, collect(t, reader)["counter"])
+}
+
+func TestLimitCardinalityDisabled(t *testing.T) {
+	mp := sdkmetric.NewMeterProvider()
+	assert.Same(t, mp, LimitCardinality(mp, 0, false))
+}
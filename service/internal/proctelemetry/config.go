@@ -18,7 +18,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/config"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/bridge/opencensus"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
@@ -66,9 +65,7 @@ func InitMetricReader(ctx context.Context, reader config.MetricReader, asyncErro
 		return initPullExporter(reader.Pull.Exporter, asyncErrorChannel)
 	}
 	if reader.Periodic != nil {
-		opts := []sdkmetric.PeriodicReaderOption{
-			sdkmetric.WithProducer(opencensus.NewMetricProducer()),
-		}
+		var opts []sdkmetric.PeriodicReaderOption
 		if reader.Periodic.Interval != nil {
 			opts = append(opts, sdkmetric.WithInterval(time.Duration(*reader.Periodic.Interval)*time.Millisecond))
 		}
@@ -95,7 +92,8 @@ func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disab
 
 func InitPrometheusServer(registry *prometheus.Registry, address string, asyncErrorChannel chan error) *http.Server {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	// OpenMetrics is required to expose the exemplars, it is only used when negotiated by the scraper.
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: exemplarsEnabled()}))
 	server := &http.Server{
 		Addr:    address,
 		Handler: mux,
@@ -108,6 +106,15 @@ func InitPrometheusServer(registry *prometheus.Registry, address string, asyncEr
 	return server
 }
 
+// exemplarsEnvKey is the environment variable enabling the experimental support for exemplars in the metrics SDK.
+// The SDK does not provide an option to enable them, the collector leaves it to the user.
+const exemplarsEnvKey = "OTEL_GO_X_EXEMPLAR"
+
+// exemplarsEnabled returns whether the experimental support for exemplars is enabled in the metrics SDK.
+func exemplarsEnabled() bool {
+	return strings.EqualFold(os.Getenv(exemplarsEnvKey), "true")
+}
+
 func batchViews(disableHighCardinality bool) []sdkmetric.View {
 	views := []sdkmetric.View{
 		sdkmetric.NewView(
@@ -165,7 +172,6 @@ func initPrometheusExporter(prometheusConfig *config.Prometheus, asyncErrorChann
 		otelprom.WithoutUnits(),
 		// Disabled for the moment until this becomes stable, and we are ready to break backwards compatibility.
 		otelprom.WithoutScopeInfo(),
-		otelprom.WithProducer(opencensus.NewMetricProducer()),
 		// This allows us to produce metrics that are backwards compatible w/ opencensus
 		otelprom.WithoutCounterSuffixes(),
 		otelprom.WithNamespace("otelcol"),
@@ -4,6 +4,11 @@
 package resource // import "go.opentelemetry.io/collector/service/internal/resource"
 
 import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -12,8 +17,47 @@ import (
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 )
 
-// New resource from telemetry configuration.
-func New(buildInfo component.BuildInfo, resourceCfg map[string]*string) *resource.Resource {
+// envResourceAttributes is the environment variable setting the resource attributes of the deployment,
+// see https://opentelemetry.io/docs/specs/otel/resource/sdk/#specifying-resource-information-via-an-environment-variable.
+const envResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+
+// DeploymentAttributes returns the resource attributes identifying the deployment of the collector: the attributes
+// set with the OTEL_RESOURCE_ATTRIBUTES environment variable, overridden by the configured attributes.
+func DeploymentAttributes(cfgAttrs map[string]string) (map[string]string, error) {
+	attrs, err := parseAttributes(os.Getenv(envResourceAttributes))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s environment variable: %w", envResourceAttributes, err)
+	}
+	for k, v := range cfgAttrs {
+		attrs[k] = v
+	}
+	return attrs, nil
+}
+
+// parseAttributes parses a comma-separated list of key=value pairs, whose values are percent-encoded.
+func parseAttributes(s string) (map[string]string, error) {
+	attrs := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("missing key or value in %q", pair)
+		}
+		v, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", k, err)
+		}
+		attrs[k] = v
+	}
+	return attrs, nil
+}
+
+// New resource from telemetry configuration. The attributes of the deployment, see DeploymentAttributes,
+// override the default attributes and are overridden by the configured ones.
+func New(buildInfo component.BuildInfo, deploymentAttrs map[string]string, resourceCfg map[string]*string) *resource.Resource {
 	var telAttrs []attribute.KeyValue
 
 	for k, v := range resourceCfg {
@@ -23,22 +67,42 @@ func New(buildInfo component.BuildInfo, resourceCfg map[string]*string) *resourc
 		}
 	}
 
-	if _, ok := resourceCfg[semconv.AttributeServiceName]; !ok {
+	for k, v := range deploymentAttrs {
+		if _, ok := resourceCfg[k]; !ok {
+			telAttrs = append(telAttrs, attribute.String(k, v))
+		}
+	}
+
+	// isSet returns true if the attribute is either configured or set by the deployment.
+	isSet := func(k string) bool {
+		_, inCfg := resourceCfg[k]
+		_, inDeployment := deploymentAttrs[k]
+		return inCfg || inDeployment
+	}
+
+	if !isSet(semconv.AttributeServiceName) {
 		// AttributeServiceName is not specified in the config. Use the default service name.
 		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceName, buildInfo.Command))
 	}
 
-	if _, ok := resourceCfg[semconv.AttributeServiceInstanceID]; !ok {
+	if !isSet(semconv.AttributeServiceInstanceID) {
 		// AttributeServiceInstanceID is not specified in the config. Auto-generate one.
 		instanceUUID, _ := uuid.NewRandom()
 		instanceID := instanceUUID.String()
 		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceInstanceID, instanceID))
 	}
 
-	if _, ok := resourceCfg[semconv.AttributeServiceVersion]; !ok {
+	if !isSet(semconv.AttributeServiceVersion) {
 		// AttributeServiceVersion is not specified in the config. Use the actual
 		// build version.
 		telAttrs = append(telAttrs, attribute.String(semconv.AttributeServiceVersion, buildInfo.Version))
 	}
+
+	for _, f := range buildInfo.CustomFields() {
+		// The custom fields of the distribution are overridden by the configured attributes.
+		if !isSet(f.Key) {
+			telAttrs = append(telAttrs, attribute.String(f.Key, f.Value))
+		}
+	}
 	return resource.NewWithAttributes(semconv.SchemaURL, telAttrs...)
 }
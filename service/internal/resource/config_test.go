@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
 
 	"go.opentelemetry.io/collector/component"
@@ -30,12 +31,15 @@ func ptr[T any](v T) *T {
 
 func TestNew(t *testing.T) {
 	tests := []struct {
-		name        string
-		resourceCfg map[string]*string
-		want        map[string]string
+		name            string
+		buildInfo       component.BuildInfo
+		deploymentAttrs map[string]string
+		resourceCfg     map[string]*string
+		want            map[string]string
 	}{
 		{
 			name:        "empty",
+			buildInfo:   buildInfo,
 			resourceCfg: map[string]*string{},
 			want: map[string]string{
 				"service.name":        "otelcol",
@@ -44,7 +48,8 @@ func TestNew(t *testing.T) {
 			},
 		},
 		{
-			name: "overwrite",
+			name:      "overwrite",
+			buildInfo: buildInfo,
 			resourceCfg: map[string]*string{
 				"service.name":        ptr("my-service"),
 				"service.version":     ptr("1.2.3"),
@@ -57,7 +62,8 @@ func TestNew(t *testing.T) {
 			},
 		},
 		{
-			name: "remove",
+			name:      "remove",
+			buildInfo: buildInfo,
 			resourceCfg: map[string]*string{
 				"service.name":        nil,
 				"service.version":     nil,
@@ -66,7 +72,8 @@ func TestNew(t *testing.T) {
 			want: map[string]string{},
 		},
 		{
-			name: "add",
+			name:      "add",
+			buildInfo: buildInfo,
 			resourceCfg: map[string]*string{
 				"host.name": ptr("my-host"),
 			},
@@ -77,11 +84,46 @@ func TestNew(t *testing.T) {
 				"host.name":           "my-host",
 			},
 		},
+		{
+			name: "custom fields",
+			buildInfo: component.NewBuildInfo("otelcol", "", component.WithBuildVersion("1.0.0"),
+				component.WithBuildCustomField("vendor", "acme"), component.WithBuildCustomField("channel", "stable")),
+			resourceCfg: map[string]*string{
+				"channel": ptr("beta"),
+			},
+			want: map[string]string{
+				"service.name":        "otelcol",
+				"service.version":     "1.0.0",
+				"service.instance.id": randomUUIDSpecialValue,
+				"vendor":              "acme",
+				"channel":             "beta",
+			},
+		},
+		{
+			name: "deployment attributes",
+			buildInfo: component.NewBuildInfo("otelcol", "", component.WithBuildVersion("1.0.0"),
+				component.WithBuildCustomField("vendor", "acme")),
+			deploymentAttrs: map[string]string{
+				"service.name":           "my-collector",
+				"vendor":                 "other",
+				"deployment.environment": "production",
+			},
+			resourceCfg: map[string]*string{
+				"deployment.environment": ptr("staging"),
+			},
+			want: map[string]string{
+				"service.name":           "my-collector",
+				"service.version":        "1.0.0",
+				"service.instance.id":    randomUUIDSpecialValue,
+				"vendor":                 "other",
+				"deployment.environment": "staging",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res := New(buildInfo, tt.resourceCfg)
+			res := New(tt.buildInfo, tt.deploymentAttrs, tt.resourceCfg)
 			got := make(map[string]string)
 			for _, attr := range res.Attributes() {
 				got[string(attr.Key)] = attr.Value.Emit()
@@ -105,6 +147,30 @@ func TestNew(t *testing.T) {
 
 }
 
+func TestDeploymentAttributes(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=production, k8s.cluster.name=my%20cluster,,region=eu")
+	attrs, err := DeploymentAttributes(map[string]string{"region": "us"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"deployment.environment": "production",
+		"k8s.cluster.name":       "my cluster",
+		"region":                 "us",
+	}, attrs)
+
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+	attrs, err = DeploymentAttributes(nil)
+	require.NoError(t, err)
+	assert.Empty(t, attrs)
+
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "region")
+	_, err = DeploymentAttributes(nil)
+	assert.EqualError(t, err, `invalid OTEL_RESOURCE_ATTRIBUTES environment variable: missing key or value in "region"`)
+
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "region=%zz")
+	_, err = DeploymentAttributes(nil)
+	assert.ErrorContains(t, err, `invalid value of "region"`)
+}
+
 func pdataFromSdk(res *sdkresource.Resource) pcommon.Resource {
 	// pcommon.NewResource is the best way to generate a new resource currently and is safe to use outside of tests.
 	// Because the resource is signal agnostic, and we need a net new resource, not an existing one, this is the only
@@ -121,7 +187,7 @@ func TestBuildResource(t *testing.T) {
 
 	// Check default config
 	var resMap map[string]*string
-	otelRes := New(buildInfo, resMap)
+	otelRes := New(buildInfo, nil, resMap)
 	res := pdataFromSdk(otelRes)
 
 	assert.Equal(t, res.Attributes().Len(), 3)
@@ -141,7 +207,7 @@ func TestBuildResource(t *testing.T) {
 		semconv.AttributeServiceVersion:    nil,
 		semconv.AttributeServiceInstanceID: nil,
 	}
-	otelRes = New(buildInfo, resMap)
+	otelRes = New(buildInfo, nil, resMap)
 	res = pdataFromSdk(otelRes)
 
 	// Attributes should not exist since we nil-ified all.
@@ -154,7 +220,7 @@ func TestBuildResource(t *testing.T) {
 		semconv.AttributeServiceVersion:    strPtr("b"),
 		semconv.AttributeServiceInstanceID: strPtr("c"),
 	}
-	otelRes = New(buildInfo, resMap)
+	otelRes = New(buildInfo, nil, resMap)
 	res = pdataFromSdk(otelRes)
 
 	assert.Equal(t, res.Attributes().Len(), 3)
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resource // import "go.opentelemetry.io/collector/service/internal/resource"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Injector adds the resource attributes of the deployment to the resources of the data,
+// without overriding the attributes already set.
+type Injector struct {
+	attrs map[string]string
+}
+
+// NewInjector returns an Injector adding the given attributes, or nil if there are none.
+func NewInjector(attrs map[string]string) *Injector {
+	if len(attrs) == 0 {
+		return nil
+	}
+	return &Injector{attrs: attrs}
+}
+
+func (inj *Injector) inject(res pcommon.Resource) {
+	resAttrs := res.Attributes()
+	for k, v := range inj.attrs {
+		if _, ok := resAttrs.Get(k); !ok {
+			resAttrs.PutStr(k, v)
+		}
+	}
+}
+
+// Traces returns a consumer.Traces injecting the attributes before consuming with next.
+// Since it modifies the data, the returned consumer mutates data.
+func (inj *Injector) Traces(next consumer.Traces) consumer.Traces {
+	return injectingTraces{Traces: next, inj: inj}
+}
+
+type injectingTraces struct {
+	consumer.Traces
+	inj *Injector
+}
+
+func (it injectingTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (it injectingTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		it.inj.inject(rss.At(i).Resource())
+	}
+	return it.Traces.ConsumeTraces(ctx, td)
+}
+
+// Metrics returns a consumer.Metrics injecting the attributes before consuming with next.
+// Since it modifies the data, the returned consumer mutates data.
+func (inj *Injector) Metrics(next consumer.Metrics) consumer.Metrics {
+	return injectingMetrics{Metrics: next, inj: inj}
+}
+
+type injectingMetrics struct {
+	consumer.Metrics
+	inj *Injector
+}
+
+func (im injectingMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (im injectingMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		im.inj.inject(rms.At(i).Resource())
+	}
+	return im.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// Logs returns a consumer.Logs injecting the attributes before consuming with next.
+// Since it modifies the data, the returned consumer mutates data.
+func (inj *Injector) Logs(next consumer.Logs) consumer.Logs {
+	return injectingLogs{Logs: next, inj: inj}
+}
+
+type injectingLogs struct {
+	consumer.Logs
+	inj *Injector
+}
+
+func (il injectingLogs) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (il injectingLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		il.inj.inject(rls.At(i).Resource())
+	}
+	return il.Logs.ConsumeLogs(ctx, ld)
+}
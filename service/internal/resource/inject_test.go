@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewInjector(t *testing.T) {
+	assert.Nil(t, NewInjector(nil))
+	assert.NotNil(t, NewInjector(map[string]string{"region": "eu"}))
+}
+
+func TestInjector(t *testing.T) {
+	inj := NewInjector(map[string]string{"region": "eu", "deployment.environment": "production"})
+	want := map[string]any{"region": "eu", "deployment.environment": "staging"}
+
+	tracesSink := new(consumertest.TracesSink)
+	tc := inj.Traces(tracesSink)
+	assert.True(t, tc.Capabilities().MutatesData)
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("deployment.environment", "staging")
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, want, tracesSink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().AsRaw())
+
+	metricsSink := new(consumertest.MetricsSink)
+	mc := inj.Metrics(metricsSink)
+	assert.True(t, mc.Capabilities().MutatesData)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("deployment.environment", "staging")
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, want, metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	logsSink := new(consumertest.LogsSink)
+	lc := inj.Logs(logsSink)
+	assert.True(t, lc.Capabilities().MutatesData)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("deployment.environment", "staging")
+	require.NoError(t, lc.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, want, logsSink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().AsRaw())
+}
@@ -5,7 +5,9 @@ package servicetelemetry // import "go.opentelemetry.io/collector/service/intern
 
 import (
 	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -41,13 +43,22 @@ type TelemetrySettings struct {
 }
 
 // ToComponentTelemetrySettings returns a TelemetrySettings for a specific component derived from
-// this service level Settings object.
+// this service level Settings object. The providers are never nil, so that all the components
+// can instrument themselves without checking them.
 func (s TelemetrySettings) ToComponentTelemetrySettings(id *component.InstanceID) component.TelemetrySettings {
 	statusFunc := status.NewReportStatusFunc(id, s.Status.ReportStatus)
+	tracerProvider := s.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = nooptrace.NewTracerProvider()
+	}
+	meterProvider := s.MeterProvider
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
 	return component.TelemetrySettings{
 		Logger:         s.Logger,
-		TracerProvider: s.TracerProvider,
-		MeterProvider:  s.MeterProvider,
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
 		MetricsLevel:   s.MetricsLevel,
 		Resource:       s.Resource,
 		ReportStatus:   statusFunc,
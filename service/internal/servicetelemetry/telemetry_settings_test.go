@@ -6,6 +6,7 @@ package servicetelemetry
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
 	nooptrace "go.opentelemetry.io/otel/trace/noop"
@@ -38,3 +39,13 @@ func TestSettings(t *testing.T) {
 	compSet := set.ToComponentTelemetrySettings(&component.InstanceID{})
 	compSet.ReportStatus(component.NewStatusEvent(component.StatusStarting))
 }
+
+func TestSettingsDefaultProviders(t *testing.T) {
+	set := NewNopTelemetrySettings()
+	set.TracerProvider = nil
+	set.MeterProvider = nil
+
+	compSet := set.ToComponentTelemetrySettings(&component.InstanceID{})
+	assert.Equal(t, nooptrace.NewTracerProvider(), compSet.TracerProvider)
+	assert.Equal(t, noopmetric.NewMeterProvider(), compSet.MeterProvider)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package panicisolation defines the configuration of the isolation of the panics of the components of the service.
+package panicisolation // import "go.opentelemetry.io/collector/service/panicisolation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// RestartPolicy defines whether the components are restarted after a panic.
+type RestartPolicy string
+
+const (
+	// RestartNever refuses the data once the component panicked.
+	RestartNever RestartPolicy = "never"
+	// RestartOnPanic restarts the components supporting it after a panic, see Restartable.
+	RestartOnPanic RestartPolicy = "on_panic"
+)
+
+// DefaultMaxRestarts is the default maximum number of restarts of each component.
+const DefaultMaxRestarts = 3
+
+// Config defines the isolation of the panics raised while the processors and the exporters consume data, so that
+// a panic in one component fails the data it consumes instead of crashing the collector.
+type Config struct {
+	// Enabled enables the isolation of the panics.
+	Enabled bool `mapstructure:"enabled"`
+
+	// RestartPolicy defines whether the components are restarted after a panic. If not set, defaults to "never".
+	RestartPolicy RestartPolicy `mapstructure:"restart_policy"`
+
+	// MaxRestarts is the maximum number of restarts of each component, after which the component refuses the data
+	// after its panics. Defaults to DefaultMaxRestarts.
+	MaxRestarts int `mapstructure:"max_restarts"`
+}
+
+func (cfg *Config) Validate() error {
+	switch cfg.RestartPolicy {
+	case "", RestartNever, RestartOnPanic:
+	default:
+		return fmt.Errorf("unknown restart_policy %q, must be %q or %q", cfg.RestartPolicy, RestartNever, RestartOnPanic)
+	}
+	if cfg.MaxRestarts < 0 {
+		return errors.New("max_restarts must be non-negative")
+	}
+	return nil
+}
+
+// Restartable is implemented by the components which can be restarted after a panic, e.g. the processors and the
+// exporters created with the processorhelper and the exporterhelper. The components not implementing it refuse the
+// data once they panicked. The components are matched by their method set, so that they do not need to depend on
+// this package.
+type Restartable interface {
+	// Restart resets the state of the component corrupted by a panic, so that it accepts the data again. It is
+	// called while the component does not consume data, and the context is canceled when the service shuts down.
+	Restart(ctx context.Context, host component.Host) error
+}
+
+// AsyncRecoverer is implemented by the components consuming the data in their own goroutines which can recover
+// from the panics raised there, e.g. the exporters created with the exporterhelper, whose sending queue exports
+// the data in its consumers. The components are matched by their method set, so that they do not need to depend
+// on this package.
+type AsyncRecoverer interface {
+	// RecoverPanics makes the component recover from the panics raised while it consumes data in its goroutines.
+	// onPanic is called with the recovered value from the goroutine which panicked, and the component refuses the
+	// data afterwards, until it is restarted, since its state may have been corrupted by the panic.
+	RecoverPanics(onPanic func(recovered any))
+}
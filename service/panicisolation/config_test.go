@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package panicisolation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{Enabled: true}
+	assert.NoError(t, cfg.Validate())
+	cfg.RestartPolicy = RestartOnPanic
+	cfg.MaxRestarts = 3
+	assert.NoError(t, cfg.Validate())
+	cfg.MaxRestarts = -1
+	assert.EqualError(t, cfg.Validate(), "max_restarts must be non-negative")
+	cfg.MaxRestarts = 0
+	cfg.RestartPolicy = "always"
+	assert.EqualError(t, cfg.Validate(), `unknown restart_policy "always", must be "never" or "on_panic"`)
+}
@@ -14,6 +14,7 @@ var (
 	errMissingServicePipelines         = errors.New("service must have at least one pipeline")
 	errMissingServicePipelineReceivers = errors.New("must have at least one receiver")
 	errMissingServicePipelineExporters = errors.New("must have at least one exporter")
+	errMissingMirrorExporter           = errors.New("mirror must have an exporter")
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -46,6 +47,40 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// Mirror configures the mirroring of a share of the data to a secondary exporter, e.g. to validate
+	// a migration to another backend. Optional.
+	Mirror *MirrorConfig `mapstructure:"mirror"`
+}
+
+// MirrorConfig defines the configuration of the mirroring of a pipeline.
+type MirrorConfig struct {
+	// Exporter is the exporter the data is mirrored to. Its failures are ignored by the pipeline.
+	Exporter component.ID `mapstructure:"exporter"`
+
+	// Percent is the percentage of the data mirrored, between 0 (excluded) and 100. The spans and log records
+	// are sampled consistently by trace ID, so the traces are mirrored entirely or not at all.
+	Percent float64 `mapstructure:"percent"`
+
+	// QueueSize is the maximum number of batches buffered for the mirror exporter, which is called asynchronously.
+	// The mirrored data is dropped when the buffer is full. Defaults to DefaultMirrorQueueSize.
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// DefaultMirrorQueueSize is the default number of batches buffered for the mirror exporter.
+const DefaultMirrorQueueSize = 100
+
+func (cfg *MirrorConfig) Validate() error {
+	if cfg.Exporter == (component.ID{}) {
+		return errMissingMirrorExporter
+	}
+	if cfg.Percent <= 0 || cfg.Percent > 100 {
+		return fmt.Errorf("mirror percent must be in (0, 100], got %v", cfg.Percent)
+	}
+	if cfg.QueueSize < 0 {
+		return fmt.Errorf("mirror queue size must not be negative, got %d", cfg.QueueSize)
+	}
+	return nil
 }
 
 func (cfg *PipelineConfig) Validate() error {
@@ -69,5 +104,16 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
+	if cfg.Mirror != nil {
+		if err := cfg.Mirror.Validate(); err != nil {
+			return err
+		}
+		for _, ref := range cfg.Exporters {
+			if ref == cfg.Mirror.Exporter {
+				return fmt.Errorf("references exporter %q both as exporter and mirror", ref)
+			}
+		}
+	}
+
 	return nil
 }
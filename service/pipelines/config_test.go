@@ -59,6 +59,51 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errMissingServicePipelines,
 		},
+		{
+			name: "valid-mirror",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Mirror = &MirrorConfig{Exporter: component.MustNewIDWithName("nop", "mirror"), Percent: 10}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "missing-mirror-exporter",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Mirror = &MirrorConfig{Percent: 10}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errMissingMirrorExporter),
+		},
+		{
+			name: "invalid-mirror-percent",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Mirror = &MirrorConfig{Exporter: component.MustNewIDWithName("nop", "mirror"), Percent: 150}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`mirror percent must be in (0, 100], got 150`)),
+		},
+		{
+			name: "invalid-mirror-queue-size",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Mirror = &MirrorConfig{Exporter: component.MustNewIDWithName("nop", "mirror"), Percent: 10, QueueSize: -1}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`mirror queue size must not be negative, got -1`)),
+		},
+		{
+			name: "mirror-exporter-reference",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Mirror = &MirrorConfig{Exporter: component.MustNewID("nop"), Percent: 10}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`references exporter "nop" both as exporter and mirror`)),
+		},
 		{
 			name: "invalid-service-pipeline-type",
 			cfgFn: func() Config {
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resource defines the configuration of the resource attributes identifying the deployment of the service.
+package resource // import "go.opentelemetry.io/collector/service/resource"
+
+import (
+	"errors"
+)
+
+// Config defines the resource attributes identifying the deployment of the collector, e.g. its environment
+// or its region. They are added to the attributes set with the OTEL_RESOURCE_ATTRIBUTES environment variable,
+// and override them.
+type Config struct {
+	// Attributes are the resource attributes of the deployment. They are included in the resource of the
+	// collector's own telemetry, unless overridden by service::telemetry::resource.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// InjectIntoData adds the resource attributes of the deployment to the resources of all the data entering
+	// the pipelines, without overriding the attributes already set. Disabled by default.
+	InjectIntoData bool `mapstructure:"inject_into_data"`
+}
+
+func (cfg *Config) Validate() error {
+	for k := range cfg.Attributes {
+		if k == "" {
+			return errors.New("attribute keys must not be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{}
+	assert.NoError(t, cfg.Validate())
+	cfg.Attributes = map[string]string{"deployment.environment": "production"}
+	assert.NoError(t, cfg.Validate())
+	cfg.Attributes[""] = "value"
+	assert.EqualError(t, cfg.Validate(), "attribute keys must not be empty")
+}
@@ -26,7 +26,11 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/attribution"
+	"go.opentelemetry.io/collector/service/internal/auditlog"
+	"go.opentelemetry.io/collector/service/internal/faultinjection"
 	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/isolation"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/internal/resource"
 	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
@@ -42,6 +46,10 @@ type Settings struct {
 	// CollectorConf contains the Collector's current configuration
 	CollectorConf *confmap.Conf
 
+	// ConfigOrigins are the origins of the keys of the Collector's current configuration,
+	// logged at debug level and served on the "configz" zPage.
+	ConfigOrigins []confmap.KeyOrigin
+
 	// Receivers builder for receivers.
 	Receivers *receiver.Builder
 
@@ -70,6 +78,10 @@ type Service struct {
 	telemetrySettings servicetelemetry.TelemetrySettings
 	host              *serviceHost
 	collectorConf     *confmap.Conf
+	auditTracker      *auditlog.Tracker
+	profiler          *attribution.Profiler
+	resourceInjector  *resource.Injector
+	panicIsolator     *isolation.Isolator
 }
 
 func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
@@ -85,6 +97,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			extensions:        set.Extensions,
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			configOrigins:     set.ConfigOrigins,
 		},
 		collectorConf: set.CollectorConf,
 	}
@@ -92,10 +105,19 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logger: %w", err)
 	}
-	res := resource.New(set.BuildInfo, cfg.Telemetry.Resource)
+	deploymentAttrs, err := resource.DeploymentAttributes(cfg.Resource.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	res := resource.New(set.BuildInfo, deploymentAttrs, cfg.Telemetry.Resource)
 	pcommonRes := pdataFromSdk(res)
 
+	if cfg.Resource.InjectIntoData {
+		srv.resourceInjector = resource.NewInjector(deploymentAttrs)
+	}
+
 	logger := tel.Logger()
+	logConfigOrigins(logger, set.ConfigOrigins)
 	logger.Info("Setting up own telemetry...")
 	mp, err := newMeterProvider(
 		meterProviderSettings{
@@ -135,6 +157,22 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	return srv, nil
 }
 
+func logConfigOrigins(logger *zap.Logger, origins []confmap.KeyOrigin) {
+	if !logger.Core().Enabled(zap.DebugLevel) {
+		return
+	}
+	for _, o := range origins {
+		logger.Debug("Configuration key resolved",
+			zap.String("key", o.Key),
+			zap.String("uri", o.URI),
+			zap.Strings("overridden", o.Overridden),
+			zap.Strings("expansions", o.Expansions),
+			zap.Strings("converters", o.Converters),
+			zap.Bool("default", o.Default),
+		)
+	}
+}
+
 func logsAboutMeterProvider(logger *zap.Logger, cfg telemetry.MetricsConfig, mp metric.MeterProvider, extendedConfig bool) {
 	if cfg.Level == configtelemetry.LevelNone || (cfg.Address == "" && len(cfg.Readers) == 0) {
 		logger.Info(
@@ -181,10 +219,22 @@ func (srv *Service) Start(ctx context.Context) error {
 		}
 	}
 
+	if srv.auditTracker != nil {
+		// The exporters report the items they delivered, once exported, to the tracker.
+		ctx = exporter.ContextWithDeliveryObserver(ctx, srv.auditTracker)
+	}
 	if err := srv.host.pipelines.StartAll(ctx, srv.host); err != nil {
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
+	if srv.auditTracker != nil {
+		srv.auditTracker.Start()
+	}
+
+	if srv.profiler != nil {
+		srv.profiler.Start()
+	}
+
 	if err := srv.host.serviceExtensions.NotifyPipelineReady(); err != nil {
 		return err
 	}
@@ -232,10 +282,24 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	// Stop restarting the panicking components before shutting them down.
+	if srv.panicIsolator != nil {
+		srv.panicIsolator.Shutdown()
+	}
+
 	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
+	// Log the data that went through the pipelines while they were shutting down.
+	if srv.auditTracker != nil {
+		srv.auditTracker.Shutdown()
+	}
+
+	if srv.profiler != nil {
+		srv.profiler.Shutdown()
+	}
+
 	if err := srv.host.serviceExtensions.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
@@ -253,11 +317,27 @@ func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings,
 		Telemetry:  srv.telemetrySettings,
 		BuildInfo:  srv.buildInfo,
 		Extensions: srv.host.extensions,
+
+		MinStabilityLevel: cfg.MinStabilityLevel,
 	}
 	if srv.host.serviceExtensions, err = extensions.New(ctx, extensionsSettings, cfg.Extensions); err != nil {
 		return fmt.Errorf("failed to build extensions: %w", err)
 	}
 
+	if cfg.Audit.Enabled {
+		srv.auditTracker = auditlog.NewTracker(cfg.Audit, srv.telemetrySettings.Logger)
+	}
+
+	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && cfg.Telemetry.Metrics.Attribution.Enabled {
+		if srv.profiler, err = attribution.NewProfiler(cfg.Telemetry.Metrics.Attribution, srv.telemetrySettings.MeterProvider, srv.telemetrySettings.Logger); err != nil {
+			return fmt.Errorf("failed to create the attribution profiler: %w", err)
+		}
+	}
+
+	if cfg.PanicIsolation.Enabled {
+		srv.panicIsolator = isolation.NewIsolator(cfg.PanicIsolation, srv.host)
+	}
+
 	pSet := graph.Settings{
 		Telemetry:        srv.telemetrySettings,
 		BuildInfo:        srv.buildInfo,
@@ -266,6 +346,18 @@ func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings,
 		ExporterBuilder:  set.Exporters,
 		ConnectorBuilder: set.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+
+		MinStabilityLevel: cfg.MinStabilityLevel,
+		AuditTracker:      srv.auditTracker,
+		Profiler:          srv.profiler,
+		FaultInjector:     faultinjection.NewInjector(srv.host.serviceExtensions.GetExtensions()),
+		PanicIsolator:     srv.panicIsolator,
+		DryRun:            cfg.DryRun,
+		ResourceInjector:  srv.resourceInjector,
+	}
+
+	if cfg.DryRun {
+		srv.telemetrySettings.Logger.Warn("Running in dry-run mode, the exporters are replaced by sinks counting the items and no data is exported")
 	}
 
 	if srv.host.pipelines, err = graph.Build(ctx, pSet); err != nil {
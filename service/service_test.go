@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -495,12 +496,35 @@ func assertMetrics(t *testing.T, metricsAddr string, expectedLabels map[string]l
 	}
 }
 
+func TestLogConfigOrigins(t *testing.T) {
+	origins := []confmap.KeyOrigin{
+		{Key: "exporters::otlp::endpoint", URI: "file:config.yaml", Overridden: []string{"file:base.yaml"}, Expansions: []string{"env:ENDPOINT"}},
+	}
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logConfigOrigins(zap.New(core), origins)
+	assert.Zero(t, logs.Len())
+
+	core, logs = observer.New(zapcore.DebugLevel)
+	logConfigOrigins(zap.New(core), origins)
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Configuration key resolved", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, "exporters::otlp::endpoint", fields["key"])
+	assert.Equal(t, "file:config.yaml", fields["uri"])
+	assert.Equal(t, []any{"file:base.yaml"}, fields["overridden"])
+	assert.Equal(t, []any{"env:ENDPOINT"}, fields["expansions"])
+	assert.Equal(t, false, fields["default"])
+}
+
 func assertZPages(t *testing.T, zpagesAddr string) {
 	paths := []string{
 		"/debug/tracez",
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/configz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
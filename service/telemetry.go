@@ -9,8 +9,6 @@ import (
 	"net/http"
 	"strconv"
 
-	ocmetric "go.opencensus.io/metric"
-	"go.opencensus.io/metric/metricproducer"
 	"go.opentelemetry.io/contrib/config"
 	"go.opentelemetry.io/otel/metric"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
@@ -30,9 +28,9 @@ const (
 )
 
 type meterProvider struct {
-	*sdkmetric.MeterProvider
-	ocRegistry *ocmetric.Registry
-	servers    []*http.Server
+	metric.MeterProvider
+	sdk     *sdkmetric.MeterProvider
+	servers []*http.Server
 }
 
 type meterProviderSettings struct {
@@ -70,11 +68,7 @@ func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (m
 		})
 	}
 
-	mp := &meterProvider{
-		// Initialize the ocRegistry, still used by the process metrics.
-		ocRegistry: ocmetric.NewRegistry(),
-	}
-	metricproducer.GlobalManager().AddProducer(mp.ocRegistry)
+	mp := &meterProvider{}
 	opts := []sdkmetric.Option{}
 	for _, reader := range set.cfg.Readers {
 		// https://github.com/open-telemetry/opentelemetry-collector/issues/8045
@@ -90,10 +84,11 @@ func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (m
 	}
 
 	var err error
-	mp.MeterProvider, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality)
+	mp.sdk, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality)
 	if err != nil {
 		return nil, err
 	}
+	mp.MeterProvider = proctelemetry.LimitCardinality(mp.sdk, set.cfg.CardinalityLimit, disableHighCardinality)
 	return mp, nil
 }
 
@@ -111,13 +106,11 @@ func (mp *meterProvider) LogAboutServers(logger *zap.Logger, cfg telemetry.Metri
 // Shutdown the meter provider and all the associated resources.
 // The type signature of this method matches that of the sdkmetric.MeterProvider.
 func (mp *meterProvider) Shutdown(ctx context.Context) error {
-	metricproducer.GlobalManager().DeleteProducer(mp.ocRegistry)
-
 	var errs error
 	for _, server := range mp.servers {
 		if server != nil {
 			errs = multierr.Append(errs, server.Close())
 		}
 	}
-	return multierr.Append(errs, mp.MeterProvider.Shutdown(ctx))
+	return multierr.Append(errs, mp.sdk.Shutdown(ctx))
 }
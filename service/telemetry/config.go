@@ -125,6 +125,30 @@ type MetricsConfig struct {
 	// Readers allow configuration of metric readers to emit metrics to
 	// any number of supported backends.
 	Readers []config.MetricReader `mapstructure:"readers"`
+
+	// Attribution configures the metrics attributing the CPU time of the collector to
+	// the processors and the exporters.
+	Attribution AttributionConfig `mapstructure:"attribution"`
+
+	// CardinalityLimit is the maximum number of attribute sets recorded per metric, e.g. the
+	// per-exporter attributes with the "detailed" level. The measurements of the attribute sets over
+	// the limit are aggregated in a single data point with the otel.metric.overflow attribute.
+	// Zero means no limit.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
+}
+
+// AttributionConfig defines the configurable settings for the attribution of the CPU time and the allocations to
+// the components. The CPU time is sampled by collecting a CPU profile of Duration every Interval, in which the
+// CPU time spent by the processors and the exporters is identified with pprof labels. The allocations are
+// measured during the same windows.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type AttributionConfig struct {
+	// Enabled enables the attribution metrics.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the interval between two samples, 1 minute if zero.
+	Interval time.Duration `mapstructure:"interval"`
+	// Duration is the duration of each sample, 5 seconds if zero.
+	Duration time.Duration `mapstructure:"duration"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -146,5 +170,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
 	}
 
+	if c.Metrics.Attribution.Interval < 0 || c.Metrics.Attribution.Duration < 0 {
+		return fmt.Errorf("collector telemetry attribution interval and duration must be non-negative")
+	}
+
+	if c.Metrics.CardinalityLimit < 0 {
+		return fmt.Errorf("collector telemetry metrics cardinality limit must be non-negative")
+	}
+
 	return nil
 }
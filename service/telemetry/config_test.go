@@ -5,6 +5,7 @@ package telemetry
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/contrib/config"
@@ -50,6 +51,39 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: true,
 		},
+		{
+			name: "valid attribution",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:       configtelemetry.LevelBasic,
+					Address:     "127.0.0.1:3333",
+					Attribution: AttributionConfig{Enabled: true, Interval: time.Minute, Duration: time.Second},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "negative attribution duration",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:       configtelemetry.LevelBasic,
+					Address:     "127.0.0.1:3333",
+					Attribution: AttributionConfig{Enabled: true, Duration: -time.Second},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "negative cardinality limit",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					CardinalityLimit: -1,
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
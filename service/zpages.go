@@ -20,6 +20,7 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zConfigPath    = "configz"
 )
 
 var (
@@ -42,6 +43,7 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.serviceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zConfigPath), host.handleConfigzRequest)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -64,6 +66,27 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zFeaturePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Configuration",
+		ComponentEndpoint: zConfigPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// handleConfigzRequest writes where the value of each key of the configuration comes from.
+// The values themselves are not written, as they may be sensitive.
+func (host *serviceHost) handleConfigzRequest(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Configuration Origins"})
+	props := make([][2]string, 0, len(host.configOrigins))
+	for _, o := range host.configOrigins {
+		props = append(props, [2]string{o.Key, o.String()})
+	}
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{
+		Name:       "Keys",
+		Properties: props,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
@@ -91,9 +114,13 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 }
 
 func getBuildInfoProperties(buildInfo component.BuildInfo) [][2]string {
-	return [][2]string{
+	properties := [][2]string{
 		{"Command", buildInfo.Command},
 		{"Description", buildInfo.Description},
 		{"Version", buildInfo.Version},
 	}
+	for _, f := range buildInfo.CustomFields() {
+		properties = append(properties, [2]string{f.Key, f.Value})
+	}
+	return properties
 }